@@ -23,6 +23,7 @@ import (
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/ray-project/kuberay/apiserver/pkg/auth"
 	"github.com/ray-project/kuberay/apiserver/pkg/interceptor"
 	"github.com/ray-project/kuberay/apiserver/pkg/manager"
 	"github.com/ray-project/kuberay/apiserver/pkg/server"
@@ -36,6 +37,13 @@ var (
 	collectMetricsFlag = flag.Bool("collectMetricsFlag", true, "Whether to collect Prometheus metrics in API server.")
 	logFile            = flag.String("logFilePath", "", "Synchronize logs to local file")
 	localSwaggerPath   = flag.String("localSwaggerPath", "", "Specify the root directory for `*.swagger.json` the swagger files.")
+	oidcIssuerURL      = flag.String("oidcIssuerURL", "", "OIDC issuer URL. If set, API calls must carry an ID token issued by this provider as bearer token.")
+	oidcClientID       = flag.String("oidcClientID", "", "OIDC client ID the ID tokens must be issued for.")
+	oidcUsernameClaim  = flag.String("oidcUsernameClaim", "sub", "OIDC claim used as the user name.")
+	oidcUsernamePrefix = flag.String("oidcUsernamePrefix", "", "Prefix prepended to user names, should match the Kubernetes API server configuration.")
+	oidcGroupsClaim    = flag.String("oidcGroupsClaim", "", "OIDC claim used as the user groups.")
+	oidcGroupsPrefix   = flag.String("oidcGroupsPrefix", "", "Prefix prepended to group names, should match the Kubernetes API server configuration.")
+	impersonateUsers   = flag.Bool("impersonateUsers", false, "Make Kubernetes requests on behalf of the authenticated user instead of the API server's service account. Requires OIDC.")
 	healthy            int32
 )
 
@@ -50,11 +58,29 @@ func main() {
 		_ = flagSet.Set("log_file", *logFile)
 	}
 
-	clientManager := manager.NewClientManager()
-	resourceManager := manager.NewResourceManager(&clientManager)
+	var authenticator auth.Authenticator
+	if *oidcIssuerURL != "" {
+		var err error
+		authenticator, err = auth.NewOIDCAuthenticator(context.Background(), auth.OIDCOptions{
+			IssuerURL:      *oidcIssuerURL,
+			ClientID:       *oidcClientID,
+			UsernameClaim:  *oidcUsernameClaim,
+			UsernamePrefix: *oidcUsernamePrefix,
+			GroupsClaim:    *oidcGroupsClaim,
+			GroupsPrefix:   *oidcGroupsPrefix,
+		})
+		if err != nil {
+			klog.Fatalf("Failed to create OIDC authenticator: %v", err)
+		}
+	} else if *impersonateUsers {
+		klog.Fatal("Impersonating users requires OIDC authentication, please set -oidcIssuerURL")
+	}
+
+	clientManager := manager.NewClientManager(*impersonateUsers)
+	resourceManager := manager.NewResourceManager(clientManager)
 
 	atomic.StoreInt32(&healthy, 1)
-	go startRpcServer(resourceManager)
+	go startRpcServer(resourceManager, authenticator)
 	startHttpProxy()
 	// See also https://gist.github.com/enricofoltran/10b4a980cd07cb02836f70a4ab3e72d7
 	quit := make(chan os.Signal, 1)
@@ -70,7 +96,7 @@ func main() {
 
 type RegisterHttpHandlerFromEndpoint func(ctx context.Context, mux *runtime.ServeMux, endpoint string, opts []grpc.DialOption) error
 
-func startRpcServer(resourceManager *manager.ResourceManager) {
+func startRpcServer(resourceManager *manager.ResourceManager, authenticator auth.Authenticator) {
 	klog.Info("Starting gRPC server")
 
 	listener, err := net.Listen("tcp", *rpcPortFlag)
@@ -83,16 +109,28 @@ func startRpcServer(resourceManager *manager.ResourceManager) {
 	jobServer := server.NewRayJobServer(resourceManager, &server.JobServerOptions{CollectMetrics: *collectMetricsFlag})
 	jobSubmissionServer := server.NewRayJobSubmissionServiceServer(clusterServer, &server.RayJobSubmissionServiceServerOptions{CollectMetrics: *collectMetricsFlag})
 	serveServer := server.NewRayServiceServer(resourceManager, &server.ServiceServerOptions{CollectMetrics: *collectMetricsFlag})
-
+	jobControlServer := server.NewRayJobControlServer(resourceManager, &server.RayJobControlServerOptions{})
+	serviceControlServer := server.NewRayServiceControlServer(resourceManager)
+	clusterTemplateServer := server.NewClusterTemplateServer(resourceManager)
+
+	unaryInterceptors := []grpc.UnaryServerInterceptor{grpc_prometheus.UnaryServerInterceptor, interceptor.ApiServerInterceptor}
+	streamInterceptors := []grpc.StreamServerInterceptor{grpc_prometheus.StreamServerInterceptor}
+	if authenticator != nil {
+		unaryInterceptors = append(unaryInterceptors, interceptor.NewAuthInterceptor(authenticator))
+		streamInterceptors = append(streamInterceptors, interceptor.NewAuthStreamInterceptor(authenticator))
+	}
 	s := grpc.NewServer(
-		grpc.StreamInterceptor(grpc_prometheus.StreamServerInterceptor),
-		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(grpc_prometheus.UnaryServerInterceptor, interceptor.ApiServerInterceptor)),
+		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(streamInterceptors...)),
+		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(unaryInterceptors...)),
 		grpc.MaxRecvMsgSize(math.MaxInt32))
 	api.RegisterClusterServiceServer(s, clusterServer)
 	api.RegisterComputeTemplateServiceServer(s, templateServer)
 	api.RegisterRayJobServiceServer(s, jobServer)
 	api.RegisterRayJobSubmissionServiceServer(s, jobSubmissionServer)
 	api.RegisterRayServeServiceServer(s, serveServer)
+	api.RegisterRayJobControlServiceServer(s, jobControlServer)
+	api.RegisterRayServiceControlServiceServer(s, serviceControlServer)
+	api.RegisterClusterTemplateServiceServer(s, clusterTemplateServer)
 
 	// Register reflection service on gRPC server.
 	reflection.Register(s)
@@ -134,10 +172,14 @@ func startHttpProxy() {
 	registerHttpHandlerFromEndpoint(api.RegisterRayJobServiceHandlerFromEndpoint, "JobService", ctx, runtimeMux)
 	registerHttpHandlerFromEndpoint(api.RegisterRayServeServiceHandlerFromEndpoint, "ServeService", ctx, runtimeMux)
 	registerHttpHandlerFromEndpoint(api.RegisterRayJobSubmissionServiceHandlerFromEndpoint, "RayJobSubmissionService", ctx, runtimeMux)
+	registerHttpHandlerFromEndpoint(api.RegisterRayJobControlServiceHandlerFromEndpoint, "JobControlService", ctx, runtimeMux)
+	registerHttpHandlerFromEndpoint(api.RegisterRayServiceControlServiceHandlerFromEndpoint, "ServiceControlService", ctx, runtimeMux)
+	registerHttpHandlerFromEndpoint(api.RegisterClusterTemplateServiceHandlerFromEndpoint, "ClusterTemplateService", ctx, runtimeMux)
 
 	// Create a top level mux to include both Http gRPC servers and other endpoints like metrics
 	topMux := http.NewServeMux()
 	// Seems /apis (matches /apis/v1alpha1/clusters) works fine
+	// Requests are authenticated by the gRPC server, the gateway forwards the Authorization header.
 	topMux.Handle("/", runtimeMux)
 	topMux.Handle("/metrics", promhttp.Handler())
 	topMux.HandleFunc("/swagger/", serveSwaggerFile)
@@ -0,0 +1,120 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+)
+
+// UserInfo identifies the caller of an API request.
+type UserInfo struct {
+	Username string
+	Groups   []string
+}
+
+// Authenticator validates a bearer token and returns the user it was issued to.
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (*UserInfo, error)
+}
+
+type userKey struct{}
+
+// WithUser returns a copy of ctx carrying the authenticated user.
+func WithUser(ctx context.Context, user *UserInfo) context.Context {
+	return context.WithValue(ctx, userKey{}, user)
+}
+
+// UserFromContext returns the authenticated user of the request, if any.
+func UserFromContext(ctx context.Context) (*UserInfo, bool) {
+	user, ok := ctx.Value(userKey{}).(*UserInfo)
+	return user, ok && user != nil
+}
+
+// BearerToken extracts the token from the value of an `Authorization: Bearer <token>` header.
+func BearerToken(authorization string) (string, bool) {
+	scheme, token, found := strings.Cut(strings.TrimSpace(authorization), " ")
+	if !found || !strings.EqualFold(scheme, "bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// OIDCOptions mirrors the OIDC flags of the Kubernetes API server, so the same users and groups are used for
+// impersonation as when the caller talks to Kubernetes directly.
+type OIDCOptions struct {
+	IssuerURL      string
+	ClientID       string
+	UsernameClaim  string
+	UsernamePrefix string
+	GroupsClaim    string
+	GroupsPrefix   string
+}
+
+type oidcAuthenticator struct {
+	verifier *oidc.IDTokenVerifier
+	options  OIDCOptions
+}
+
+// NewOIDCAuthenticator creates an Authenticator validating ID tokens issued by the OIDC provider. The provider
+// discovery document is fetched once, and signing keys are refreshed by the verifier as needed.
+func NewOIDCAuthenticator(ctx context.Context, options OIDCOptions) (Authenticator, error) {
+	if options.IssuerURL == "" || options.ClientID == "" {
+		return nil, errors.New("OIDC issuer URL and client ID are required")
+	}
+	if options.UsernameClaim == "" {
+		options.UsernameClaim = "sub"
+	}
+	provider, err := oidc.NewProvider(ctx, options.IssuerURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover OIDC provider %s: %w", options.IssuerURL, err)
+	}
+	return &oidcAuthenticator{
+		verifier: provider.Verifier(&oidc.Config{ClientID: options.ClientID}),
+		options:  options,
+	}, nil
+}
+
+func (a *oidcAuthenticator) Authenticate(ctx context.Context, token string) (*UserInfo, error) {
+	idToken, err := a.verifier.Verify(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	claims := map[string]interface{}{}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("failed to parse token claims: %w", err)
+	}
+	return userFromClaims(claims, a.options)
+}
+
+func userFromClaims(claims map[string]interface{}, options OIDCOptions) (*UserInfo, error) {
+	username, ok := claims[options.UsernameClaim].(string)
+	if !ok || username == "" {
+		return nil, fmt.Errorf("claim %q is missing from the token", options.UsernameClaim)
+	}
+	if options.UsernameClaim == "email" {
+		// Same as the Kubernetes API server, only accept verified emails as usernames.
+		if verified, ok := claims["email_verified"].(bool); ok && !verified {
+			return nil, errors.New("email of the token is not verified")
+		}
+	}
+	user := &UserInfo{Username: options.UsernamePrefix + username}
+
+	if options.GroupsClaim == "" {
+		return user, nil
+	}
+	switch groups := claims[options.GroupsClaim].(type) {
+	case string:
+		user.Groups = []string{options.GroupsPrefix + groups}
+	case []interface{}:
+		for _, group := range groups {
+			if g, ok := group.(string); ok {
+				user.Groups = append(user.Groups, options.GroupsPrefix+g)
+			}
+		}
+	}
+	return user, nil
+}
@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBearerToken(t *testing.T) {
+	token, ok := BearerToken("Bearer abc.def.ghi")
+	assert.True(t, ok)
+	assert.Equal(t, "abc.def.ghi", token)
+
+	token, ok = BearerToken("bearer  abc ")
+	assert.True(t, ok)
+	assert.Equal(t, "abc", token)
+
+	for _, header := range []string{"", "Bearer", "Bearer ", "Basic dXNlcjpwYXNz"} {
+		_, ok = BearerToken(header)
+		assert.False(t, ok, header)
+	}
+}
+
+func TestUserFromClaims(t *testing.T) {
+	options := OIDCOptions{UsernameClaim: "email", UsernamePrefix: "oidc:", GroupsClaim: "groups", GroupsPrefix: "oidc:"}
+
+	user, err := userFromClaims(map[string]interface{}{
+		"email":          "jane@example.com",
+		"email_verified": true,
+		"groups":         []interface{}{"ml-team", "admins"},
+	}, options)
+	require.NoError(t, err)
+	assert.Equal(t, "oidc:jane@example.com", user.Username)
+	assert.Equal(t, []string{"oidc:ml-team", "oidc:admins"}, user.Groups)
+
+	user, err = userFromClaims(map[string]interface{}{"email": "jane@example.com", "groups": "ml-team"}, options)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"oidc:ml-team"}, user.Groups)
+
+	_, err = userFromClaims(map[string]interface{}{"email": "jane@example.com", "email_verified": false}, options)
+	assert.Error(t, err)
+
+	_, err = userFromClaims(map[string]interface{}{"sub": "1234"}, options)
+	assert.Error(t, err)
+}
+
+func TestUserContext(t *testing.T) {
+	_, ok := UserFromContext(context.Background())
+	assert.False(t, ok)
+
+	user, ok := UserFromContext(WithUser(context.Background(), &UserInfo{Username: "jane"}))
+	assert.True(t, ok)
+	assert.Equal(t, "jane", user.Username)
+}
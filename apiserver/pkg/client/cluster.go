@@ -29,6 +29,7 @@ func NewRayClusterClientOrFatal(options util.ClientOptions) ClusterClientInterfa
 	}
 	cfg.QPS = options.QPS
 	cfg.Burst = options.Burst
+	cfg.Impersonate = options.Impersonate
 
 	rayClusterClient := rayclient.NewForConfigOrDie(cfg).RayV1()
 	return &RayClusterClient{client: rayClusterClient}
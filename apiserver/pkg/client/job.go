@@ -29,6 +29,7 @@ func NewRayJobClientOrFatal(options util.ClientOptions) JobClientInterface {
 	}
 	cfg.QPS = options.QPS
 	cfg.Burst = options.Burst
+	cfg.Impersonate = options.Impersonate
 
 	rayJobClient := rayclient.NewForConfigOrDie(cfg).RayV1()
 	return &RayJobClient{client: rayJobClient}
@@ -44,6 +44,7 @@ func CreateKubernetesCoreOrFatal(options util.ClientOptions) KubernetesClientInt
 	}
 	cfg.QPS = options.QPS
 	cfg.Burst = options.Burst
+	cfg.Impersonate = options.Impersonate
 
 	clientSet, err := kubernetes.NewForConfig(cfg)
 	if err != nil {
@@ -29,6 +29,7 @@ func NewRayServiceClientOrFatal(options util.ClientOptions) ServiceClientInterfa
 	}
 	cfg.QPS = options.QPS
 	cfg.Burst = options.Burst
+	cfg.Impersonate = options.Impersonate
 
 	rayServiceClient := rayclient.NewForConfigOrDie(cfg).RayV1()
 	return &RayServiceClient{client: rayServiceClient}
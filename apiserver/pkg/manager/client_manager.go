@@ -1,8 +1,14 @@
 package manager
 
 import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/auth"
 	"github.com/ray-project/kuberay/apiserver/pkg/client"
 	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	"k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
 )
 
@@ -12,6 +18,8 @@ type ClientManagerInterface interface {
 	ServiceClient() client.ServiceClientInterface
 	KubernetesClient() client.KubernetesClientInterface
 	Time() util.TimeInterface
+	// ForUser returns the clients to use for requests made by the given user.
+	ForUser(user *auth.UserInfo) ClientManagerInterface
 }
 
 // Container for all service clients
@@ -23,6 +31,12 @@ type ClientManager struct {
 	kubernetesClient client.KubernetesClientInterface
 	// auxiliary tools
 	time util.TimeInterface
+
+	// If set, Kubernetes requests are made on behalf of the authenticated caller, so that the caller's RBAC
+	// permissions apply instead of the ones of the API server's service account.
+	impersonateUsers bool
+	// Client managers of impersonated users, keyed by user name and groups.
+	userClientManagers sync.Map
 }
 
 func (c *ClientManager) ClusterClient() client.ClusterClientInterface {
@@ -45,14 +59,37 @@ func (c *ClientManager) Time() util.TimeInterface {
 	return c.time
 }
 
+func (c *ClientManager) ForUser(user *auth.UserInfo) ClientManagerInterface {
+	if !c.impersonateUsers || user == nil {
+		return c
+	}
+	groups := append([]string(nil), user.Groups...)
+	sort.Strings(groups)
+	key := user.Username + "\x00" + strings.Join(groups, "\x00")
+	if userClientManager, ok := c.userClientManagers.Load(key); ok {
+		return userClientManager.(*ClientManager)
+	}
+	userClientManager := &ClientManager{}
+	userClientManager.initClients(rest.ImpersonationConfig{UserName: user.Username, Groups: groups})
+	actual, _ := c.userClientManagers.LoadOrStore(key, userClientManager)
+	return actual.(*ClientManager)
+}
+
 func (c *ClientManager) init() {
 	// db, kubernetes initialization
 	klog.Info("Initializing client manager")
 
+	c.initClients(rest.ImpersonationConfig{})
+
+	klog.Infof("Client manager initialized successfully")
+}
+
+func (c *ClientManager) initClients(impersonate rest.ImpersonationConfig) {
 	// configure configs
 	defaultKubernetesClientConfig := util.ClientOptions{
-		QPS:   5,
-		Burst: 10,
+		QPS:         5,
+		Burst:       10,
+		Impersonate: impersonate,
 	}
 
 	// 1. utils initialization
@@ -64,12 +101,10 @@ func (c *ClientManager) init() {
 	c.jobClient = client.NewRayJobClientOrFatal(defaultKubernetesClientConfig)
 	c.serviceClient = client.NewRayServiceClientOrFatal(defaultKubernetesClientConfig)
 	c.kubernetesClient = client.CreateKubernetesCoreOrFatal(defaultKubernetesClientConfig)
-
-	klog.Infof("Client manager initialized successfully")
 }
 
-func NewClientManager() ClientManager {
-	clientManager := ClientManager{}
+func NewClientManager(impersonateUsers bool) *ClientManager {
+	clientManager := &ClientManager{impersonateUsers: impersonateUsers}
 	clientManager.init()
 
 	return clientManager
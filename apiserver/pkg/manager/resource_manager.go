@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/ray-project/kuberay/apiserver/pkg/auth"
 	"github.com/ray-project/kuberay/apiserver/pkg/model"
 	"github.com/ray-project/kuberay/apiserver/pkg/util"
 	api "github.com/ray-project/kuberay/proto/go_client"
@@ -57,29 +58,36 @@ func NewResourceManager(clientManager ClientManagerInterface) *ResourceManager {
 	}
 }
 
-// Clients
-func (r *ResourceManager) getRayClusterClient(namespace string) rayv1.RayClusterInterface {
-	return r.clientManager.ClusterClient().RayClusterClient(namespace)
+// Clients are the ones of the authenticated user of the request, if any.
+func (r *ResourceManager) clients(ctx context.Context) ClientManagerInterface {
+	if user, ok := auth.UserFromContext(ctx); ok {
+		return r.clientManager.ForUser(user)
+	}
+	return r.clientManager
+}
+
+func (r *ResourceManager) getRayClusterClient(ctx context.Context, namespace string) rayv1.RayClusterInterface {
+	return r.clients(ctx).ClusterClient().RayClusterClient(namespace)
 }
 
-func (r *ResourceManager) getRayJobClient(namespace string) rayv1.RayJobInterface {
-	return r.clientManager.JobClient().RayJobClient(namespace)
+func (r *ResourceManager) getRayJobClient(ctx context.Context, namespace string) rayv1.RayJobInterface {
+	return r.clients(ctx).JobClient().RayJobClient(namespace)
 }
 
-func (r *ResourceManager) getRayServiceClient(namespace string) rayv1.RayServiceInterface {
-	return r.clientManager.ServiceClient().RayServiceClient(namespace)
+func (r *ResourceManager) getRayServiceClient(ctx context.Context, namespace string) rayv1.RayServiceInterface {
+	return r.clients(ctx).ServiceClient().RayServiceClient(namespace)
 }
 
-func (r *ResourceManager) getKubernetesConfigMapClient(namespace string) clientv1.ConfigMapInterface {
-	return r.clientManager.KubernetesClient().ConfigMapClient(namespace)
+func (r *ResourceManager) getKubernetesConfigMapClient(ctx context.Context, namespace string) clientv1.ConfigMapInterface {
+	return r.clients(ctx).KubernetesClient().ConfigMapClient(namespace)
 }
 
-func (r *ResourceManager) getEventsClient(namespace string) clientv1.EventInterface {
-	return r.clientManager.KubernetesClient().EventsClient(namespace)
+func (r *ResourceManager) getEventsClient(ctx context.Context, namespace string) clientv1.EventInterface {
+	return r.clients(ctx).KubernetesClient().EventsClient(namespace)
 }
 
-func (r *ResourceManager) getKubernetesNamespaceClient() clientv1.NamespaceInterface {
-	return r.clientManager.KubernetesClient().NamespaceClient()
+func (r *ResourceManager) getKubernetesNamespaceClient(ctx context.Context) clientv1.NamespaceInterface {
+	return r.clients(ctx).KubernetesClient().NamespaceClient()
 }
 
 // clusters
@@ -100,7 +108,7 @@ func (r *ResourceManager) CreateCluster(ctx context.Context, apiCluster *api.Clu
 	clusterAt := r.clientManager.Time().Now().String()
 	rayCluster.Annotations["ray.io/creation-timestamp"] = clusterAt
 
-	newRayCluster, err := r.getRayClusterClient(apiCluster.Namespace).Create(ctx, rayCluster.Get(), metav1.CreateOptions{})
+	newRayCluster, err := r.getRayClusterClient(ctx, apiCluster.Namespace).Create(ctx, rayCluster.Get(), metav1.CreateOptions{})
 	if err != nil {
 		return nil, util.NewInternalServerError(err, "Failed to create a cluster for (%s/%s)", rayCluster.Namespace, rayCluster.Name)
 	}
@@ -137,7 +145,7 @@ func (r *ResourceManager) populateComputeTemplate(ctx context.Context, clusterSp
 }
 
 func (r *ResourceManager) GetCluster(ctx context.Context, clusterName string, namespace string) (*rayv1api.RayCluster, error) {
-	client := r.getRayClusterClient(namespace)
+	client := r.getRayClusterClient(ctx, namespace)
 	return getClusterByName(ctx, client, clusterName)
 }
 
@@ -147,7 +155,7 @@ func (r *ResourceManager) ListClusters(ctx context.Context, namespace string) ([
 			util.KubernetesManagedByLabelKey: util.ComponentName,
 		},
 	}
-	rayClusterList, err := r.getRayClusterClient(namespace).List(ctx, metav1.ListOptions{
+	rayClusterList, err := r.getRayClusterClient(ctx, namespace).List(ctx, metav1.ListOptions{
 		LabelSelector: labels.Set(labelSelector.MatchLabels).String(),
 	})
 	if err != nil {
@@ -164,7 +172,7 @@ func (r *ResourceManager) ListClusters(ctx context.Context, namespace string) ([
 }
 
 func (r *ResourceManager) ListAllClusters(ctx context.Context) ([]*rayv1api.RayCluster, error) {
-	namespaces, err := r.getKubernetesNamespaceClient().List(ctx, metav1.ListOptions{})
+	namespaces, err := r.getKubernetesNamespaceClient(ctx).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, util.Wrap(err, "Failed to fetch all Kubernetes namespaces")
 	}
@@ -176,7 +184,7 @@ func (r *ResourceManager) ListAllClusters(ctx context.Context) ([]*rayv1api.RayC
 		},
 	}
 	for _, namespace := range namespaces.Items {
-		rayClusterList, err := r.getRayClusterClient(namespace.Name).List(ctx, metav1.ListOptions{
+		rayClusterList, err := r.getRayClusterClient(ctx, namespace.Name).List(ctx, metav1.ListOptions{
 			LabelSelector: labels.Set(labelSelector.MatchLabels).String(),
 		})
 		if err != nil {
@@ -192,7 +200,7 @@ func (r *ResourceManager) ListAllClusters(ctx context.Context) ([]*rayv1api.RayC
 }
 
 func (r *ResourceManager) DeleteCluster(ctx context.Context, clusterName string, namespace string) error {
-	client := r.getRayClusterClient(namespace)
+	client := r.getRayClusterClient(ctx, namespace)
 	cluster, err := getClusterByName(ctx, client, clusterName)
 	if err != nil {
 		return util.Wrap(err, "Get cluster failure")
@@ -225,7 +233,7 @@ func (r *ResourceManager) CreateJob(ctx context.Context, apiJob *api.RayJob) (*r
 		return nil, util.NewInvalidInputErrorWithDetails(err, "Failed to create a Ray Job")
 	}
 
-	newRayJob, err := r.getRayJobClient(apiJob.Namespace).Create(ctx, rayJob.Get(), metav1.CreateOptions{})
+	newRayJob, err := r.getRayJobClient(ctx, apiJob.Namespace).Create(ctx, rayJob.Get(), metav1.CreateOptions{})
 	if err != nil {
 		return nil, util.NewInternalServerError(err, "Failed to create a job for (%s/%s)", apiJob.Namespace, apiJob.JobId)
 	}
@@ -234,7 +242,7 @@ func (r *ResourceManager) CreateJob(ctx context.Context, apiJob *api.RayJob) (*r
 }
 
 func (r *ResourceManager) GetJob(ctx context.Context, jobName string, namespace string) (*rayv1api.RayJob, error) {
-	client := r.getRayJobClient(namespace)
+	client := r.getRayJobClient(ctx, namespace)
 	return getJobByName(ctx, client, jobName)
 }
 
@@ -244,7 +252,7 @@ func (r *ResourceManager) ListJobs(ctx context.Context, namespace string) ([]*ra
 			util.KubernetesManagedByLabelKey: util.ComponentName,
 		},
 	}
-	rayJobList, err := r.getRayJobClient(namespace).List(ctx, metav1.ListOptions{
+	rayJobList, err := r.getRayJobClient(ctx, namespace).List(ctx, metav1.ListOptions{
 		LabelSelector: labels.Set(labelSelector.MatchLabels).String(),
 	})
 	if err != nil {
@@ -261,7 +269,7 @@ func (r *ResourceManager) ListJobs(ctx context.Context, namespace string) ([]*ra
 }
 
 func (r *ResourceManager) ListAllJobs(ctx context.Context) ([]*rayv1api.RayJob, error) {
-	namespaces, err := r.getKubernetesNamespaceClient().List(ctx, metav1.ListOptions{})
+	namespaces, err := r.getKubernetesNamespaceClient(ctx).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, util.Wrap(err, "Failed to fetch all Kubernetes namespaces")
 	}
@@ -273,7 +281,7 @@ func (r *ResourceManager) ListAllJobs(ctx context.Context) ([]*rayv1api.RayJob,
 		},
 	}
 	for _, namespace := range namespaces.Items {
-		rayJobList, err := r.getRayJobClient(namespace.Name).List(ctx, metav1.ListOptions{
+		rayJobList, err := r.getRayJobClient(ctx, namespace.Name).List(ctx, metav1.ListOptions{
 			LabelSelector: labels.Set(labelSelector.MatchLabels).String(),
 		})
 		if err != nil {
@@ -289,7 +297,7 @@ func (r *ResourceManager) ListAllJobs(ctx context.Context) ([]*rayv1api.RayJob,
 }
 
 func (r *ResourceManager) DeleteJob(ctx context.Context, jobName string, namespace string) error {
-	client := r.getRayJobClient(namespace)
+	client := r.getRayJobClient(ctx, namespace)
 	job, err := getJobByName(ctx, client, jobName)
 	if err != nil {
 		return util.Wrap(err, "Get job failure")
@@ -316,7 +324,7 @@ func (r *ResourceManager) CreateService(ctx context.Context, apiService *api.Ray
 	}
 	createdAt := r.clientManager.Time().Now().String()
 	rayService.Annotations["ray.io/creation-timestamp"] = createdAt
-	newRayService, err := r.getRayServiceClient(apiService.Namespace).Create(ctx, rayService.Get(), metav1.CreateOptions{})
+	newRayService, err := r.getRayServiceClient(ctx, apiService.Namespace).Create(ctx, rayService.Get(), metav1.CreateOptions{})
 	if err != nil {
 		return nil, util.NewInternalServerError(err, "Failed to create service for (%s/%s)", rayService.Namespace, rayService.Name)
 	}
@@ -327,7 +335,7 @@ func (r *ResourceManager) CreateService(ctx context.Context, apiService *api.Ray
 func (r *ResourceManager) UpdateRayService(ctx context.Context, apiService *api.RayService) (*rayv1api.RayService, error) {
 	name := apiService.Name
 	namespace := apiService.Namespace
-	client := r.getRayServiceClient(namespace)
+	client := r.getRayServiceClient(ctx, namespace)
 	oldService, err := getServiceByName(ctx, client, name)
 	if err != nil {
 		return nil, util.Wrap(err, fmt.Sprintf("Update service fail, no service named: %s ", name))
@@ -341,6 +349,9 @@ func (r *ResourceManager) UpdateRayService(ctx context.Context, apiService *api.
 	if err != nil {
 		return nil, err
 	}
+	if err := util.RecordPreviousRayClusterSpec(oldService, rayService.Get()); err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to record the previous cluster spec of service (%s/%s)", apiService.Namespace, apiService.Name)
+	}
 	rayService.Annotations["ray.io/update-timestamp"] = r.clientManager.Time().Now().String()
 	rayService.ResourceVersion = oldService.DeepCopy().ResourceVersion
 	newRayService, err := client.Update(ctx, rayService.Get(), metav1.UpdateOptions{})
@@ -351,17 +362,33 @@ func (r *ResourceManager) UpdateRayService(ctx context.Context, apiService *api.
 }
 
 func (r *ResourceManager) GetService(ctx context.Context, serviceName, namespace string) (*rayv1api.RayService, error) {
-	client := r.getRayServiceClient(namespace)
+	client := r.getRayServiceClient(ctx, namespace)
 	return getServiceByName(ctx, client, serviceName)
 }
 
+// UpdateServiceSpec writes back a RayService that was read with GetService and modified in place.
+func (r *ResourceManager) UpdateServiceSpec(ctx context.Context, rayService *rayv1api.RayService) (*rayv1api.RayService, error) {
+	if rayService.Annotations == nil {
+		rayService.Annotations = map[string]string{}
+	}
+	rayService.Annotations["ray.io/update-timestamp"] = r.clientManager.Time().Now().String()
+	newRayService, err := r.getRayServiceClient(ctx, rayService.Namespace).Update(ctx, rayService, metav1.UpdateOptions{})
+	if err != nil {
+		if errors.IsConflict(err) {
+			return nil, util.NewBadRequestError(err, "Service (%s/%s) was modified concurrently, please retry", rayService.Namespace, rayService.Name)
+		}
+		return nil, util.NewInternalServerError(err, "Failed to update service for (%s/%s)", rayService.Namespace, rayService.Name)
+	}
+	return newRayService, nil
+}
+
 func (r *ResourceManager) ListServices(ctx context.Context, namespace string) ([]*rayv1api.RayService, error) {
 	labelSelector := metav1.LabelSelector{
 		MatchLabels: map[string]string{
 			util.KubernetesManagedByLabelKey: util.ComponentName,
 		},
 	}
-	rayServiceList, err := r.getRayServiceClient(namespace).List(ctx, metav1.ListOptions{
+	rayServiceList, err := r.getRayServiceClient(ctx, namespace).List(ctx, metav1.ListOptions{
 		LabelSelector: labels.Set(labelSelector.MatchLabels).String(),
 	})
 	if err != nil {
@@ -378,7 +405,7 @@ func (r *ResourceManager) ListServices(ctx context.Context, namespace string) ([
 func (r *ResourceManager) ListAllServices(ctx context.Context) ([]*rayv1api.RayService, error) {
 	rayServices := make([]*rayv1api.RayService, 0)
 
-	namespaces, err := r.getKubernetesNamespaceClient().List(ctx, metav1.ListOptions{})
+	namespaces, err := r.getKubernetesNamespaceClient(ctx).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, util.Wrap(err, "Failed to fetch all Kubernetes namespaces")
 	}
@@ -394,7 +421,7 @@ func (r *ResourceManager) ListAllServices(ctx context.Context) ([]*rayv1api.RayS
 }
 
 func (r *ResourceManager) DeleteService(ctx context.Context, serviceName, namespace string) error {
-	client := r.getRayServiceClient(namespace)
+	client := r.getRayServiceClient(ctx, namespace)
 	service, err := getServiceByName(ctx, client, serviceName)
 	if err != nil {
 		return util.Wrap(err, "delete ray service failure")
@@ -418,7 +445,7 @@ func (r *ResourceManager) CreateComputeTemplate(ctx context.Context, runtime *ap
 		return nil, util.NewInternalServerError(err, "Failed to convert compute runtime (%s/%s)", runtime.Namespace, runtime.Name)
 	}
 
-	client := r.getKubernetesConfigMapClient(runtime.Namespace)
+	client := r.getKubernetesConfigMapClient(ctx, runtime.Namespace)
 	newRuntime, err := client.Create(ctx, computeTemplate, metav1.CreateOptions{})
 	if err != nil {
 		return nil, util.NewInternalServerError(err, "Failed to create a compute runtime for (%s/%s)", runtime.Namespace, runtime.Name)
@@ -428,12 +455,12 @@ func (r *ResourceManager) CreateComputeTemplate(ctx context.Context, runtime *ap
 }
 
 func (r *ResourceManager) GetComputeTemplate(ctx context.Context, name string, namespace string) (*corev1.ConfigMap, error) {
-	client := r.getKubernetesConfigMapClient(namespace)
+	client := r.getKubernetesConfigMapClient(ctx, namespace)
 	return getComputeTemplateByName(ctx, client, name)
 }
 
 func (r *ResourceManager) ListComputeTemplates(ctx context.Context, namespace string) ([]*corev1.ConfigMap, error) {
-	client := r.getKubernetesConfigMapClient(namespace)
+	client := r.getKubernetesConfigMapClient(ctx, namespace)
 	configMapList, err := client.List(ctx, metav1.ListOptions{LabelSelector: "ray.io/config-type=compute-template"})
 	if err != nil {
 		return nil, util.Wrap(err, "List compute templates failed")
@@ -449,14 +476,14 @@ func (r *ResourceManager) ListComputeTemplates(ctx context.Context, namespace st
 }
 
 func (r *ResourceManager) ListAllComputeTemplates(ctx context.Context) ([]*corev1.ConfigMap, error) {
-	namespaces, err := r.getKubernetesNamespaceClient().List(ctx, metav1.ListOptions{})
+	namespaces, err := r.getKubernetesNamespaceClient(ctx).List(ctx, metav1.ListOptions{})
 	if err != nil {
 		return nil, util.Wrap(err, "Failed to fetch all Kubernetes namespaces")
 	}
 
 	var result []*corev1.ConfigMap
 	for _, namespace := range namespaces.Items {
-		client := r.getKubernetesConfigMapClient(namespace.Name)
+		client := r.getKubernetesConfigMapClient(ctx, namespace.Name)
 		configMapList, err := client.List(ctx, metav1.ListOptions{LabelSelector: "ray.io/config-type=compute-template"})
 		if err != nil {
 			return nil, util.Wrap(err, fmt.Sprintf("List compute templates failed in %s", namespace.Name))
@@ -471,7 +498,7 @@ func (r *ResourceManager) ListAllComputeTemplates(ctx context.Context) ([]*corev
 }
 
 func (r *ResourceManager) DeleteComputeTemplate(ctx context.Context, name string, namespace string) error {
-	client := r.getKubernetesConfigMapClient(namespace)
+	client := r.getKubernetesConfigMapClient(ctx, namespace)
 
 	configMap, err := getComputeTemplateByName(ctx, client, name)
 	if err != nil {
@@ -485,6 +512,90 @@ func (r *ResourceManager) DeleteComputeTemplate(ctx context.Context, name string
 	return nil
 }
 
+// Cluster templates
+func (r *ResourceManager) CreateClusterTemplate(ctx context.Context, template *api.ClusterTemplate) (*corev1.ConfigMap, error) {
+	_, err := r.GetClusterTemplate(ctx, template.Name, template.Namespace)
+	if err == nil {
+		return nil, util.NewAlreadyExistError("Cluster template with name %s already exists in namespace %s", template.Name, template.Namespace)
+	}
+
+	configMap, err := util.NewClusterTemplate(template)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to convert cluster template (%s/%s)", template.Namespace, template.Name)
+	}
+
+	client := r.getKubernetesConfigMapClient(ctx, template.Namespace)
+	newConfigMap, err := client.Create(ctx, configMap, metav1.CreateOptions{})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to create a cluster template for (%s/%s)", template.Namespace, template.Name)
+	}
+
+	return newConfigMap, nil
+}
+
+func (r *ResourceManager) UpdateClusterTemplate(ctx context.Context, template *api.ClusterTemplate) (*corev1.ConfigMap, error) {
+	oldConfigMap, err := r.GetClusterTemplate(ctx, template.Name, template.Namespace)
+	if err != nil {
+		return nil, util.Wrap(err, "Get cluster template failed")
+	}
+
+	configMap, err := util.NewClusterTemplate(template)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to convert cluster template (%s/%s)", template.Namespace, template.Name)
+	}
+	configMap.ResourceVersion = oldConfigMap.ResourceVersion
+
+	client := r.getKubernetesConfigMapClient(ctx, template.Namespace)
+	newConfigMap, err := client.Update(ctx, configMap, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to update the cluster template (%s/%s)", template.Namespace, template.Name)
+	}
+
+	return newConfigMap, nil
+}
+
+func (r *ResourceManager) GetClusterTemplate(ctx context.Context, name string, namespace string) (*corev1.ConfigMap, error) {
+	client := r.getKubernetesConfigMapClient(ctx, namespace)
+	configMap, err := client.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, util.NewNotFoundError(err, "Cluster template %s not found", name)
+		}
+		return nil, util.Wrap(err, "Get cluster template failed")
+	}
+	if configMap.Labels["ray.io/config-type"] != util.ClusterTemplateConfigType {
+		return nil, util.NewNotFoundError(fmt.Errorf("config map %s is not a cluster template", name), "Cluster template %s not found", name)
+	}
+	return configMap, nil
+}
+
+func (r *ResourceManager) ListClusterTemplates(ctx context.Context, namespace string) ([]*corev1.ConfigMap, error) {
+	client := r.getKubernetesConfigMapClient(ctx, namespace)
+	configMapList, err := client.List(ctx, metav1.ListOptions{LabelSelector: "ray.io/config-type=" + util.ClusterTemplateConfigType})
+	if err != nil {
+		return nil, util.Wrap(err, "List cluster templates failed")
+	}
+
+	result := make([]*corev1.ConfigMap, 0, len(configMapList.Items))
+	for i := range configMapList.Items {
+		result = append(result, &configMapList.Items[i])
+	}
+	return result, nil
+}
+
+func (r *ResourceManager) DeleteClusterTemplate(ctx context.Context, name string, namespace string) error {
+	configMap, err := r.GetClusterTemplate(ctx, name, namespace)
+	if err != nil {
+		return util.Wrap(err, "Get cluster template failed")
+	}
+
+	client := r.getKubernetesConfigMapClient(ctx, namespace)
+	if err := client.Delete(ctx, configMap.Name, metav1.DeleteOptions{}); err != nil {
+		return util.NewInternalServerError(err, "failed to delete cluster template %v.", name)
+	}
+	return nil
+}
+
 // getClusterByName returns the Kubernetes RayCluster object by given name and client
 func getClusterByName(ctx context.Context, client rayv1.RayClusterInterface, name string) (*rayv1api.RayCluster, error) {
 	cluster, err := client.Get(ctx, name, metav1.GetOptions{})
@@ -548,8 +659,8 @@ func getComputeTemplateByName(ctx context.Context, client clientv1.ConfigMapInte
 }
 
 func (r *ResourceManager) GetClusterEvents(ctx context.Context, clusterName string, namespace string) ([]corev1.Event, error) {
-	client := r.getEventsClient(namespace)
-	clusterClient := r.getRayClusterClient(namespace)
+	client := r.getEventsClient(ctx, namespace)
+	clusterClient := r.getRayClusterClient(ctx, namespace)
 	return getRayClusterEventsByName(ctx, clusterName, client, clusterClient)
 }
 
@@ -574,7 +685,7 @@ func getRayClusterEventsByName(ctx context.Context, name string, client clientv1
 }
 
 func (r *ResourceManager) GetServiceEvents(ctx context.Context, service rayv1api.RayService) ([]corev1.Event, error) {
-	eventClient := r.getEventsClient(service.Namespace)
+	eventClient := r.getEventsClient(ctx, service.Namespace)
 	events, err := getRayServiceEventsByName(ctx, service.Name, eventClient)
 	if err != nil {
 		return nil, err
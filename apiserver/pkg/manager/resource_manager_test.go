@@ -0,0 +1,95 @@
+package manager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/auth"
+	"github.com/ray-project/kuberay/apiserver/pkg/client"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	clientv1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// fakeClientManager serves ConfigMaps from a fake clientset and hands out a separate client manager per user.
+type fakeClientManager struct {
+	kubernetesClient *fakeKubernetesClient
+	userManagers     map[string]*fakeClientManager
+}
+
+func (c *fakeClientManager) ClusterClient() client.ClusterClientInterface { return nil }
+
+func (c *fakeClientManager) JobClient() client.JobClientInterface { return nil }
+
+func (c *fakeClientManager) ServiceClient() client.ServiceClientInterface { return nil }
+
+func (c *fakeClientManager) KubernetesClient() client.KubernetesClientInterface {
+	return c.kubernetesClient
+}
+
+func (c *fakeClientManager) Time() util.TimeInterface { return util.NewRealTime() }
+
+func (c *fakeClientManager) ForUser(user *auth.UserInfo) ClientManagerInterface {
+	if userManager, ok := c.userManagers[user.Username]; ok {
+		return userManager
+	}
+	return c
+}
+
+type fakeKubernetesClient struct {
+	clientset *fake.Clientset
+}
+
+func (c *fakeKubernetesClient) PodClient(namespace string) clientv1.PodInterface {
+	return c.clientset.CoreV1().Pods(namespace)
+}
+
+func (c *fakeKubernetesClient) ConfigMapClient(namespace string) clientv1.ConfigMapInterface {
+	return c.clientset.CoreV1().ConfigMaps(namespace)
+}
+
+func (c *fakeKubernetesClient) NamespaceClient() clientv1.NamespaceInterface {
+	return c.clientset.CoreV1().Namespaces()
+}
+
+func (c *fakeKubernetesClient) EventsClient(namespace string) clientv1.EventInterface {
+	return c.clientset.CoreV1().Events(namespace)
+}
+
+func newFakeClientManager(objects ...corev1.ConfigMap) *fakeClientManager {
+	clientset := fake.NewSimpleClientset()
+	for i := range objects {
+		_, _ = clientset.CoreV1().ConfigMaps(objects[i].Namespace).Create(context.Background(), &objects[i], metav1.CreateOptions{})
+	}
+	return &fakeClientManager{kubernetesClient: &fakeKubernetesClient{clientset: clientset}}
+}
+
+func TestResourceManagerUsesClientsOfUser(t *testing.T) {
+	template := corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "default-template",
+			Namespace: "default",
+			Labels:    map[string]string{"ray.io/config-type": "compute-template"},
+		},
+	}
+	// Only the clients of alice can see the compute template.
+	clientManager := newFakeClientManager()
+	clientManager.userManagers = map[string]*fakeClientManager{"alice": newFakeClientManager(template)}
+	resourceManager := NewResourceManager(clientManager)
+
+	ctx := auth.WithUser(context.Background(), &auth.UserInfo{Username: "alice", Groups: []string{"system:authenticated"}})
+	configMap, err := resourceManager.GetComputeTemplate(ctx, "default-template", "default")
+	require.NoError(t, err)
+	assert.Equal(t, "default-template", configMap.Name)
+
+	ctx = auth.WithUser(context.Background(), &auth.UserInfo{Username: "bob"})
+	_, err = resourceManager.GetComputeTemplate(ctx, "default-template", "default")
+	assert.Error(t, err)
+
+	_, err = resourceManager.GetComputeTemplate(context.Background(), "default-template", "default")
+	assert.Error(t, err)
+}
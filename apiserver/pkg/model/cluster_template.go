@@ -0,0 +1,35 @@
+package model
+
+import (
+	"fmt"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"google.golang.org/protobuf/encoding/protojson"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func FromKubeToAPIClusterTemplate(configMap *corev1.ConfigMap) (*api.ClusterTemplate, error) {
+	clusterSpec := &api.ClusterSpec{}
+	if err := protojson.Unmarshal([]byte(configMap.Data["cluster_spec"]), clusterSpec); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal cluster spec of cluster template %s: %w", configMap.Name, err)
+	}
+	return &api.ClusterTemplate{
+		Name:        configMap.Name,
+		Namespace:   configMap.Namespace,
+		Description: configMap.Data["description"],
+		Version:     configMap.Data["version"],
+		ClusterSpec: clusterSpec,
+	}, nil
+}
+
+func FromKubeToAPIClusterTemplates(configMaps []*corev1.ConfigMap) ([]*api.ClusterTemplate, error) {
+	templates := make([]*api.ClusterTemplate, 0)
+	for _, configMap := range configMaps {
+		template, err := FromKubeToAPIClusterTemplate(configMap)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	return templates, nil
+}
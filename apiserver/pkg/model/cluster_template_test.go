@@ -0,0 +1,51 @@
+package model
+
+import (
+	"testing"
+
+	util "github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestClusterTemplateRoundTrip(t *testing.T) {
+	template := &api.ClusterTemplate{
+		Name:        "gpu-small",
+		Namespace:   "default",
+		Description: "one CPU head and a GPU worker group",
+		Version:     "2.9.0",
+		ClusterSpec: &api.ClusterSpec{
+			HeadGroupSpec: &api.HeadGroupSpec{
+				ComputeTemplate: "head-template",
+				Image:           "rayproject/ray:2.9.0",
+				RayStartParams:  map[string]string{"dashboard-host": "0.0.0.0"},
+			},
+			WorkerGroupSpec: []*api.WorkerGroupSpec{
+				{
+					GroupName:       "gpu",
+					ComputeTemplate: "gpu-template",
+					Image:           "rayproject/ray:2.9.0-gpu",
+					Replicas:        1,
+					MinReplicas:     0,
+					MaxReplicas:     4,
+				},
+			},
+		},
+	}
+
+	configMap, err := util.NewClusterTemplate(template)
+	assert.NoError(t, err)
+	assert.Equal(t, util.ClusterTemplateConfigType, configMap.Labels["ray.io/config-type"])
+
+	converted, err := FromKubeToAPIClusterTemplate(configMap)
+	assert.NoError(t, err)
+	assert.Equal(t, template.Name, converted.Name)
+	assert.Equal(t, template.Description, converted.Description)
+	assert.Equal(t, template.Version, converted.Version)
+	assert.True(t, proto.Equal(template.ClusterSpec, converted.ClusterSpec))
+
+	configMap.Data["cluster_spec"] = "not json"
+	_, err = FromKubeToAPIClusterTemplate(configMap)
+	assert.Error(t, err)
+}
@@ -590,6 +590,25 @@ func PoplulateRayServiceStatus(
 	return status
 }
 
+func FromCrdToApiServeStatus(service *rayv1api.RayService) *api.RayServeStatus {
+	return &api.RayServeStatus{
+		Name:                 service.Name,
+		Namespace:            service.Namespace,
+		ServiceStatus:        string(service.Status.ServiceStatus),
+		ActiveServiceStatus:  PopulateRayServeClusterStatus(service.Status.ActiveServiceStatus),
+		PendingServiceStatus: PopulateRayServeClusterStatus(service.Status.PendingServiceStatus),
+		NumServeEndpoints:    service.Status.NumServeEndpoints,
+	}
+}
+
+func PopulateRayServeClusterStatus(serviceStatus rayv1api.RayServiceStatus) *api.RayServeClusterStatus {
+	return &api.RayServeClusterStatus{
+		RayClusterName:         serviceStatus.RayClusterName,
+		RayClusterState:        string(serviceStatus.RayClusterStatus.State),
+		ServeApplicationStatus: PopulateServeApplicationStatus(serviceStatus.Applications),
+	}
+}
+
 func PopulateServeApplicationStatus(
 	serveApplicationStatuses map[string]rayv1api.AppStatus,
 ) []*api.ServeApplicationStatus {
@@ -0,0 +1,199 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/manager"
+	"github.com/ray-project/kuberay/apiserver/pkg/model"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// implements `type ClusterTemplateServiceServer interface` in cluster_template_grpc.pb.go
+// ClusterTemplateServer manages the cluster template catalog. Clients create RayClusters and RayJobs from a template
+// by only providing the fields specific to the cluster or job, and the cluster spec is expanded on the server side.
+type ClusterTemplateServer struct {
+	resourceManager *manager.ResourceManager
+	api.UnimplementedClusterTemplateServiceServer
+}
+
+func NewClusterTemplateServer(resourceManager *manager.ResourceManager) *ClusterTemplateServer {
+	return &ClusterTemplateServer{resourceManager: resourceManager}
+}
+
+func (s *ClusterTemplateServer) CreateClusterTemplate(ctx context.Context, request *api.CreateClusterTemplateRequest) (*api.ClusterTemplate, error) {
+	if err := ValidateClusterTemplate(request.Namespace, request.ClusterTemplate); err != nil {
+		return nil, util.Wrap(err, "Validate cluster template failed.")
+	}
+
+	configMap, err := s.resourceManager.CreateClusterTemplate(ctx, request.ClusterTemplate)
+	if err != nil {
+		return nil, util.Wrap(err, "Create cluster template failed.")
+	}
+
+	return toAPIClusterTemplate(configMap)
+}
+
+func (s *ClusterTemplateServer) GetClusterTemplate(ctx context.Context, request *api.GetClusterTemplateRequest) (*api.ClusterTemplate, error) {
+	return s.getClusterTemplate(ctx, request.Name, request.Namespace)
+}
+
+func (s *ClusterTemplateServer) ListClusterTemplates(ctx context.Context, request *api.ListClusterTemplatesRequest) (*api.ListClusterTemplatesResponse, error) {
+	if request.Namespace == "" {
+		return nil, util.NewInvalidInputError("Namespace is empty. Please specify a valid value.")
+	}
+
+	configMaps, err := s.resourceManager.ListClusterTemplates(ctx, request.Namespace)
+	if err != nil {
+		return nil, util.Wrap(err, fmt.Sprintf("List cluster templates in namespace %s failed.", request.Namespace))
+	}
+	templates, err := model.FromKubeToAPIClusterTemplates(configMaps)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to convert cluster templates")
+	}
+
+	return &api.ListClusterTemplatesResponse{
+		ClusterTemplates: templates,
+	}, nil
+}
+
+func (s *ClusterTemplateServer) UpdateClusterTemplate(ctx context.Context, request *api.UpdateClusterTemplateRequest) (*api.ClusterTemplate, error) {
+	if err := ValidateClusterTemplate(request.Namespace, request.ClusterTemplate); err != nil {
+		return nil, util.Wrap(err, "Validate cluster template failed.")
+	}
+	if request.Name != request.ClusterTemplate.Name {
+		return nil, util.NewInvalidInputError("The name in the request is different from the name in the cluster template definition.")
+	}
+
+	configMap, err := s.resourceManager.UpdateClusterTemplate(ctx, request.ClusterTemplate)
+	if err != nil {
+		return nil, util.Wrap(err, "Update cluster template failed.")
+	}
+
+	return toAPIClusterTemplate(configMap)
+}
+
+func (s *ClusterTemplateServer) DeleteClusterTemplate(ctx context.Context, request *api.DeleteClusterTemplateRequest) (*emptypb.Empty, error) {
+	if err := validateClusterTemplateKey(request.Name, request.Namespace); err != nil {
+		return nil, err
+	}
+
+	if err := s.resourceManager.DeleteClusterTemplate(ctx, request.Name, request.Namespace); err != nil {
+		return nil, util.Wrap(err, "Delete cluster template failed.")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// CreateClusterFromTemplate creates a RayCluster from a cluster definition without cluster spec. The cluster spec
+// comes from the template, and so does the version unless it is set in the request.
+func (s *ClusterTemplateServer) CreateClusterFromTemplate(ctx context.Context, request *api.CreateClusterFromTemplateRequest) (*api.Cluster, error) {
+	if request.Cluster == nil {
+		return nil, util.NewInvalidInputError("Cluster is empty. Please specify a valid value.")
+	}
+	if request.Cluster.ClusterSpec != nil {
+		return nil, util.NewInvalidInputError("Cluster spec must not be set when creating a cluster from a template.")
+	}
+	template, err := s.getClusterTemplate(ctx, request.Name, request.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster := request.Cluster
+	cluster.ClusterSpec = proto.Clone(template.ClusterSpec).(*api.ClusterSpec)
+	if cluster.Version == "" {
+		cluster.Version = template.Version
+	}
+	if err := ValidateCreateClusterRequest(&api.CreateClusterRequest{Cluster: cluster, Namespace: request.Namespace}); err != nil {
+		return nil, util.Wrap(err, "Validate create cluster request failed.")
+	}
+
+	created, err := s.resourceManager.CreateCluster(ctx, cluster)
+	if err != nil {
+		return nil, util.Wrap(err, "Create Cluster failed.")
+	}
+
+	return model.FromCrdToApiCluster(created, nil), nil
+}
+
+// CreateJobFromTemplate creates a RayJob from a job definition without cluster spec or cluster selector. The cluster
+// spec comes from the template, and so does the version unless it is set in the request.
+func (s *ClusterTemplateServer) CreateJobFromTemplate(ctx context.Context, request *api.CreateJobFromTemplateRequest) (*api.RayJob, error) {
+	if request.Job == nil {
+		return nil, util.NewInvalidInputError("Job is empty. Please specify a valid value.")
+	}
+	if request.Job.ClusterSpec != nil || len(request.Job.ClusterSelector) != 0 {
+		return nil, util.NewInvalidInputError("Cluster spec and cluster selector must not be set when creating a job from a template.")
+	}
+	template, err := s.getClusterTemplate(ctx, request.Name, request.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	job := request.Job
+	job.ClusterSpec = proto.Clone(template.ClusterSpec).(*api.ClusterSpec)
+	if job.Version == "" {
+		job.Version = template.Version
+	}
+	if err := ValidateCreateJobRequest(&api.CreateRayJobRequest{Job: job, Namespace: request.Namespace}); err != nil {
+		return nil, util.Wrap(err, "Validate job request failed.")
+	}
+
+	created, err := s.resourceManager.CreateJob(ctx, job)
+	if err != nil {
+		return nil, util.Wrap(err, "Create Job failed.")
+	}
+
+	return model.FromCrdToApiJob(created), nil
+}
+
+func (s *ClusterTemplateServer) getClusterTemplate(ctx context.Context, name string, namespace string) (*api.ClusterTemplate, error) {
+	if err := validateClusterTemplateKey(name, namespace); err != nil {
+		return nil, err
+	}
+
+	configMap, err := s.resourceManager.GetClusterTemplate(ctx, name, namespace)
+	if err != nil {
+		return nil, util.Wrap(err, "Get cluster template failed.")
+	}
+
+	return toAPIClusterTemplate(configMap)
+}
+
+func toAPIClusterTemplate(configMap *corev1.ConfigMap) (*api.ClusterTemplate, error) {
+	template, err := model.FromKubeToAPIClusterTemplate(configMap)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to convert cluster template")
+	}
+	return template, nil
+}
+
+func validateClusterTemplateKey(name string, namespace string) error {
+	if name == "" {
+		return util.NewInvalidInputError("Cluster template name is empty. Please specify a valid value.")
+	}
+	if namespace == "" {
+		return util.NewInvalidInputError("Namespace is empty. Please specify a valid value.")
+	}
+	return nil
+}
+
+func ValidateClusterTemplate(namespace string, template *api.ClusterTemplate) error {
+	if namespace == "" {
+		return util.NewInvalidInputError("Namespace is empty. Please specify a valid value.")
+	}
+	if template == nil {
+		return util.NewInvalidInputError("Cluster template is empty. Please specify a valid value.")
+	}
+	if namespace != template.Namespace {
+		return util.NewInvalidInputError("The namespace in the request is different from the namespace in the cluster template definition.")
+	}
+	if template.Name == "" {
+		return util.NewInvalidInputError("Cluster template name is empty. Please specify a valid value.")
+	}
+	return ValidateClusterSpec(template.ClusterSpec)
+}
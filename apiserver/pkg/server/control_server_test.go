@@ -0,0 +1,60 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestFilterJobAttempts(t *testing.T) {
+	rayJob := &rayv1api.RayJob{}
+	rayJob.Name = "rayjob-sample"
+	rayJob.Status.JobId = "rayjob-sample-xyz12"
+
+	jobs := []utils.RayJobInfo{
+		{SubmissionId: "rayjob-sample-xyz12", JobStatus: rayv1api.JobStatusRunning, StartTime: 20},
+		{SubmissionId: "other-job-abcde", JobStatus: rayv1api.JobStatusRunning, StartTime: 5},
+		{SubmissionId: "rayjob-sample-abcde", JobStatus: rayv1api.JobStatusFailed, StartTime: 10},
+	}
+	attempts := filterJobAttempts(rayJob, &jobs)
+	assert.Len(t, attempts, 2)
+	assert.Equal(t, "rayjob-sample-abcde", attempts[0].SubmissionId)
+	assert.False(t, attempts[0].Current)
+	assert.Equal(t, "rayjob-sample-xyz12", attempts[1].SubmissionId)
+	assert.True(t, attempts[1].Current)
+
+	assert.Empty(t, filterJobAttempts(rayJob, nil))
+}
+
+func TestNewLogOutput(t *testing.T) {
+	assert.Equal(t, "line 1\nline 2\n", newLogOutput("line 1\nline 2\n", 0))
+	assert.Equal(t, "line 2\n", newLogOutput("line 1\nline 2\n", 7))
+	assert.Equal(t, "", newLogOutput("line 1\n", 7))
+	// The log was truncated, e.g. because the job was resubmitted.
+	assert.Equal(t, "new\n", newLogOutput("new\n", 7))
+}
+
+func TestSetServeTargetCapacity(t *testing.T) {
+	serveConfig := "applications:\n- name: app1\n  import_path: fruit.deployment_graph\n"
+	capacity := int32(50)
+
+	updated, err := setServeTargetCapacity(serveConfig, &capacity)
+	assert.NoError(t, err)
+	assert.Contains(t, updated, "target_capacity: 50")
+	assert.Contains(t, updated, "import_path: fruit.deployment_graph")
+
+	updated, err = setServeTargetCapacity(updated, nil)
+	assert.NoError(t, err)
+	assert.NotContains(t, updated, "target_capacity")
+
+	_, err = setServeTargetCapacity("", &capacity)
+	assert.Error(t, err)
+
+	invalid := int32(101)
+	assert.Error(t, ValidateTargetCapacity(&invalid))
+	assert.NoError(t, ValidateTargetCapacity(&capacity))
+	assert.NoError(t, ValidateTargetCapacity(nil))
+}
@@ -0,0 +1,190 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/manager"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"google.golang.org/protobuf/types/known/emptypb"
+	klog "k8s.io/klog/v2"
+
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+const DefaultJobLogPollInterval = 2 * time.Second
+
+type RayJobControlServerOptions struct {
+	// LogPollInterval is how often the dashboard is polled for new driver output while following logs.
+	LogPollInterval time.Duration
+}
+
+// implements `type RayJobControlServiceServer interface` in job_grpc.pb.go
+// RayJobControlServer exposes RayJob operations that are not plain CRUD on the custom resource: streaming driver
+// logs, stopping the running Ray job and listing the job attempts. All of them are proxied through the Ray dashboard
+// of the RayCluster backing the RayJob, so API server clients don't need direct access to the Ray pods.
+type RayJobControlServer struct {
+	resourceManager     *manager.ResourceManager
+	options             *RayJobControlServerOptions
+	dashboardClientFunc func() utils.RayDashboardClientInterface
+	api.UnimplementedRayJobControlServiceServer
+}
+
+func NewRayJobControlServer(resourceManager *manager.ResourceManager, options *RayJobControlServerOptions) *RayJobControlServer {
+	if options.LogPollInterval <= 0 {
+		options.LogPollInterval = DefaultJobLogPollInterval
+	}
+	return &RayJobControlServer{resourceManager: resourceManager, options: options, dashboardClientFunc: utils.GetRayDashboardClientFunc(nil, false)}
+}
+
+// Streams the driver log of the RayJob. If follow is set, new output is sent in chunks until the Ray job reaches a
+// terminal state or the client disconnects.
+func (s *RayJobControlServer) GetRayJobLog(request *api.GetRayJobLogRequest, stream api.RayJobControlService_GetRayJobLogServer) error {
+	ctx := stream.Context()
+	rayJob, dashboardClient, err := s.getJobAndDashboardClient(ctx, request.Name, request.Namespace)
+	if err != nil {
+		return err
+	}
+
+	written := 0
+	for {
+		jobLog, err := dashboardClient.GetJobLog(ctx, rayJob.Status.JobId)
+		if err != nil {
+			if written == 0 {
+				return util.Wrap(err, "Get job log failed.")
+			}
+			klog.Warningf("failed to follow log of job %s/%s: %v", rayJob.Namespace, rayJob.Name, err)
+			return nil
+		}
+		if jobLog == nil {
+			if written == 0 {
+				return util.NewResourceNotFoundError("JobLog", rayJob.Status.JobId)
+			}
+			return nil
+		}
+		if chunk := newLogOutput(*jobLog, written); len(chunk) > 0 {
+			if err := stream.Send(&api.RayJobLogChunk{Log: chunk}); err != nil {
+				return err
+			}
+			written += len(chunk)
+		}
+		if !request.Follow {
+			return nil
+		}
+
+		jobInfo, err := dashboardClient.GetJobInfo(ctx, rayJob.Status.JobId)
+		if err == nil && rayv1api.IsJobTerminal(jobInfo.JobStatus) {
+			// Drain the output written between the last log read and the job termination.
+			if jobLog, err := dashboardClient.GetJobLog(ctx, rayJob.Status.JobId); err == nil && jobLog != nil {
+				if chunk := newLogOutput(*jobLog, written); len(chunk) > 0 {
+					return stream.Send(&api.RayJobLogChunk{Log: chunk})
+				}
+			}
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(s.options.LogPollInterval):
+		}
+	}
+}
+
+// Stops the Ray job that is currently running for the RayJob. The RayJob itself and its RayCluster are left untouched
+// so the outcome can still be inspected.
+func (s *RayJobControlServer) StopRayJob(ctx context.Context, request *api.StopRayJobRequest) (*emptypb.Empty, error) {
+	rayJob, dashboardClient, err := s.getJobAndDashboardClient(ctx, request.Name, request.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if rayv1api.IsJobTerminal(rayJob.Status.JobStatus) {
+		return nil, util.NewBadRequestError(errors.New("job is terminal"), "Job %s is already in terminal status %s", rayJob.Name, rayJob.Status.JobStatus)
+	}
+	if err := dashboardClient.StopJob(ctx, rayJob.Status.JobId); err != nil {
+		return nil, util.Wrap(err, "Stop job failed.")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// Lists the Ray job submissions the RayJob has made on its RayCluster. Attempts that ran on RayClusters which have
+// already been deleted by retries are only reflected in the succeeded/failed counters.
+func (s *RayJobControlServer) ListRayJobAttempts(ctx context.Context, request *api.ListRayJobAttemptsRequest) (*api.ListRayJobAttemptsResponse, error) {
+	rayJob, dashboardClient, err := s.getJobAndDashboardClient(ctx, request.Name, request.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	jobs, err := dashboardClient.ListJobs(ctx)
+	if err != nil {
+		return nil, util.Wrap(err, "List jobs failed.")
+	}
+	response := &api.ListRayJobAttemptsResponse{Attempts: filterJobAttempts(rayJob, jobs)}
+	if rayJob.Status.Succeeded != nil {
+		response.Succeeded = *rayJob.Status.Succeeded
+	}
+	if rayJob.Status.Failed != nil {
+		response.Failed = *rayJob.Status.Failed
+	}
+	return response, nil
+}
+
+func (s *RayJobControlServer) getJobAndDashboardClient(ctx context.Context, name string, namespace string) (*rayv1api.RayJob, utils.RayDashboardClientInterface, error) {
+	if name == "" {
+		return nil, nil, util.NewInvalidInputError("job name is empty. Please specify a valid value.")
+	}
+	if namespace == "" {
+		return nil, nil, util.NewInvalidInputError("job namespace is empty. Please specify a valid value.")
+	}
+	rayJob, err := s.resourceManager.GetJob(ctx, name, namespace)
+	if err != nil {
+		return nil, nil, util.Wrap(err, "Get job failed.")
+	}
+	if rayJob.Status.DashboardURL == "" || rayJob.Status.JobId == "" {
+		return nil, nil, util.NewBadRequestError(errors.New("job is not submitted"), "Job %s has not been submitted to a Ray cluster yet", name)
+	}
+	dashboardClient := s.dashboardClientFunc()
+	if err := dashboardClient.InitClient(ctx, rayJob.Status.DashboardURL, nil); err != nil {
+		return nil, nil, err
+	}
+	return rayJob, dashboardClient, nil
+}
+
+// newLogOutput returns the part of the log that hasn't been written yet. The dashboard always returns
+// the full log, so if it got shorter (e.g. the job was resubmitted) everything is written again.
+func newLogOutput(log string, written int) string {
+	if written > len(log) {
+		return log
+	}
+	return log[written:]
+}
+
+// filterJobAttempts returns the Ray jobs submitted for the RayJob, ordered by start time. Submission IDs
+// generated by KubeRay are prefixed with the RayJob name, and a user-provided ID is matched exactly.
+func filterJobAttempts(rayJob *rayv1api.RayJob, jobs *[]utils.RayJobInfo) []*api.RayJobAttempt {
+	attempts := make([]*api.RayJobAttempt, 0)
+	if jobs == nil {
+		return attempts
+	}
+	for _, job := range *jobs {
+		if job.SubmissionId != rayJob.Status.JobId && job.SubmissionId != rayJob.Spec.JobId && !strings.HasPrefix(job.SubmissionId, rayJob.Name+"-") {
+			continue
+		}
+		attempts = append(attempts, &api.RayJobAttempt{
+			SubmissionId: job.SubmissionId,
+			Status:       string(job.JobStatus),
+			Message:      job.Message,
+			StartTime:    job.StartTime,
+			EndTime:      job.EndTime,
+			Current:      job.SubmissionId == rayJob.Status.JobId,
+		})
+	}
+	sort.SliceStable(attempts, func(i, j int) bool {
+		return attempts[i].StartTime < attempts[j].StartTime
+	})
+	return attempts
+}
@@ -0,0 +1,134 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ray-project/kuberay/apiserver/pkg/manager"
+	"github.com/ray-project/kuberay/apiserver/pkg/model"
+	"github.com/ray-project/kuberay/apiserver/pkg/util"
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"sigs.k8s.io/yaml"
+
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+// serveTargetCapacityKey is the top level key of the Ray Serve config that scales all applications proportionally.
+const serveTargetCapacityKey = "target_capacity"
+
+// implements `type RayServiceControlServiceServer interface` in serve_grpc.pb.go
+// RayServiceControlServer exposes the serve status maintained by the RayService controller and the operations used to
+// steer traffic of a running RayService: rolling back a pending upgrade and changing the Serve target capacity.
+// Creating and updating RayServices is handled by RayServiceServer.
+type RayServiceControlServer struct {
+	resourceManager *manager.ResourceManager
+	api.UnimplementedRayServiceControlServiceServer
+}
+
+func NewRayServiceControlServer(resourceManager *manager.ResourceManager) *RayServiceControlServer {
+	return &RayServiceControlServer{resourceManager: resourceManager}
+}
+
+// Gets the application and deployment statuses of the active and pending RayClusters.
+func (s *RayServiceControlServer) GetRayServeStatus(ctx context.Context, request *api.GetRayServeStatusRequest) (*api.RayServeStatus, error) {
+	rayService, err := s.getService(ctx, request.Name, request.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	return model.FromCrdToApiServeStatus(rayService), nil
+}
+
+// Cancels an in-progress zero downtime upgrade by restoring the RayCluster spec the RayService had before its last
+// update through the API server. The controller then deletes the pending RayCluster and keeps serving traffic from the
+// active one. The Serve config is not changed.
+func (s *RayServiceControlServer) RollbackRayService(ctx context.Context, request *api.RollbackRayServiceRequest) (*api.RayServeStatus, error) {
+	rayService, err := s.getService(ctx, request.Name, request.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if rayService.Status.PendingServiceStatus.RayClusterName == "" {
+		return nil, util.NewBadRequestError(errors.New("no pending cluster"), "Service %s has no upgrade in progress", rayService.Name)
+	}
+	previousSpec, err := util.GetPreviousRayClusterSpec(rayService)
+	if err != nil {
+		return nil, util.NewInternalServerError(err, "Failed to decode the previous cluster spec of service %s", rayService.Name)
+	}
+	if previousSpec == nil {
+		return nil, util.NewBadRequestError(errors.New("no previous cluster spec"), "Service %s has no previous cluster spec recorded by the API server to roll back to", rayService.Name)
+	}
+	rayService.Spec.RayClusterSpec = *previousSpec
+	delete(rayService.Annotations, util.RayServicePreviousClusterSpecAnnotationKey)
+	updated, err := s.resourceManager.UpdateServiceSpec(ctx, rayService)
+	if err != nil {
+		return nil, err
+	}
+	return model.FromCrdToApiServeStatus(updated), nil
+}
+
+// Sets `target_capacity` in the Serve config of the RayService. Ray Serve applies it in place, so no new RayCluster is
+// created.
+func (s *RayServiceControlServer) UpdateRayServiceTargetCapacity(ctx context.Context, request *api.UpdateRayServiceTargetCapacityRequest) (*api.RayServeStatus, error) {
+	var targetCapacity *int32
+	if request.TargetCapacity != nil {
+		targetCapacity = &request.TargetCapacity.Value
+	}
+	if err := ValidateTargetCapacity(targetCapacity); err != nil {
+		return nil, err
+	}
+	rayService, err := s.getService(ctx, request.Name, request.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	serveConfig, err := setServeTargetCapacity(rayService.Spec.ServeConfigV2, targetCapacity)
+	if err != nil {
+		return nil, util.NewInvalidInputErrorWithDetails(err, "Failed to parse serve config of the service")
+	}
+	rayService.Spec.ServeConfigV2 = serveConfig
+	updated, err := s.resourceManager.UpdateServiceSpec(ctx, rayService)
+	if err != nil {
+		return nil, err
+	}
+	return model.FromCrdToApiServeStatus(updated), nil
+}
+
+func (s *RayServiceControlServer) getService(ctx context.Context, name string, namespace string) (*rayv1api.RayService, error) {
+	if name == "" {
+		return nil, util.NewInvalidInputError("ray service name is empty. Please specify a valid value.")
+	}
+	if namespace == "" {
+		return nil, util.NewInvalidInputError("ray service namespace is empty. Please specify a valid value.")
+	}
+	rayService, err := s.resourceManager.GetService(ctx, name, namespace)
+	if err != nil {
+		return nil, util.Wrap(err, "get ray service failed")
+	}
+	return rayService, nil
+}
+
+func ValidateTargetCapacity(targetCapacity *int32) error {
+	if targetCapacity != nil && (*targetCapacity < 0 || *targetCapacity > 100) {
+		return util.NewInvalidInputError("Target capacity must be between 0 and 100, got %d.", *targetCapacity)
+	}
+	return nil
+}
+
+// setServeTargetCapacity returns the Serve config with `target_capacity` set, or removed if targetCapacity is nil.
+func setServeTargetCapacity(serveConfigV2 string, targetCapacity *int32) (string, error) {
+	if serveConfigV2 == "" {
+		return "", errors.New("serve config is empty")
+	}
+	serveConfig := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(serveConfigV2), &serveConfig); err != nil {
+		return "", err
+	}
+	if targetCapacity == nil {
+		delete(serveConfig, serveTargetCapacityKey)
+	} else {
+		serveConfig[serveTargetCapacityKey] = *targetCapacity
+	}
+	updated, err := yaml.Marshal(serveConfig)
+	if err != nil {
+		return "", err
+	}
+	return string(updated), nil
+}
@@ -0,0 +1,45 @@
+package util
+
+import (
+	"fmt"
+
+	api "github.com/ray-project/kuberay/proto/go_client"
+	"google.golang.org/protobuf/encoding/protojson"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ClusterTemplateConfigType = "cluster-template"
+	ClusterTemplateLabelKey   = "ray.io/cluster-template"
+)
+
+// Build cluster template. A cluster template is a named cluster layout defined by platform admins, stored as a
+// ConfigMap. Clients create RayClusters and RayJobs from it by name, and the API server expands it into the
+// cluster spec of the request.
+func NewClusterTemplate(template *api.ClusterTemplate) (*corev1.ConfigMap, error) {
+	clusterSpecJSON, err := protojson.Marshal(template.ClusterSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal cluster spec for cluster template %s: %w", template.Name, err)
+	}
+
+	config := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      template.Name,
+			Namespace: template.Namespace,
+			Labels: map[string]string{
+				"ray.io/config-type":    ClusterTemplateConfigType,
+				ClusterTemplateLabelKey: template.Name,
+			},
+		},
+		Data: map[string]string{
+			"name":         template.Name,
+			"namespace":    template.Namespace,
+			"description":  template.Description,
+			"version":      template.Version,
+			"cluster_spec": string(clusterSpecJSON),
+		},
+	}
+
+	return config, nil
+}
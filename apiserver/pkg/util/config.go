@@ -1,9 +1,13 @@
 package util
 
+import "k8s.io/client-go/rest"
+
 // ClientOptions contains configuration needed to create a Kubernetes client
 type ClientOptions struct {
 	QPS   float32
 	Burst int
+	// Impersonate makes the client send requests on behalf of the given user.
+	Impersonate rest.ImpersonationConfig
 }
 
 // TODO: this needs to be revised.
@@ -20,6 +24,8 @@ const (
 	// Role level
 	RayClusterComputeTemplateAnnotationKey = "ray.io/compute-template"
 	RayClusterImageAnnotationKey           = "ray.io/compute-image"
+	// Service level
+	RayServicePreviousClusterSpecAnnotationKey = "ray.io/previous-ray-cluster-spec"
 
 	RayClusterDefaultImageRepository = "rayproject/ray"
 )
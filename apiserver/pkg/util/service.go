@@ -1,7 +1,9 @@
 package util
 
 import (
+	"encoding/json"
 	"errors"
+	"reflect"
 
 	api "github.com/ray-project/kuberay/proto/go_client"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -36,6 +38,40 @@ func NewRayService(apiService *api.RayService, computeTemplateMap map[string]*ap
 	return &RayService{rayService}, nil
 }
 
+// RecordPreviousRayClusterSpec remembers the RayCluster spec of the old RayService in the annotations of the updated
+// one if the update changes it, so that the zero downtime upgrade it triggers can be rolled back. If the spec doesn't
+// change, the previously recorded spec is kept.
+func RecordPreviousRayClusterSpec(oldService *rayv1api.RayService, newService *rayv1api.RayService) error {
+	if newService.Annotations == nil {
+		newService.Annotations = map[string]string{}
+	}
+	if reflect.DeepEqual(oldService.Spec.RayClusterSpec, newService.Spec.RayClusterSpec) {
+		if previous, ok := oldService.Annotations[RayServicePreviousClusterSpecAnnotationKey]; ok {
+			newService.Annotations[RayServicePreviousClusterSpecAnnotationKey] = previous
+		}
+		return nil
+	}
+	previous, err := json.Marshal(oldService.Spec.RayClusterSpec)
+	if err != nil {
+		return err
+	}
+	newService.Annotations[RayServicePreviousClusterSpecAnnotationKey] = string(previous)
+	return nil
+}
+
+// GetPreviousRayClusterSpec returns the RayCluster spec recorded by RecordPreviousRayClusterSpec, or nil if there is none.
+func GetPreviousRayClusterSpec(rayService *rayv1api.RayService) (*rayv1api.RayClusterSpec, error) {
+	previous, ok := rayService.Annotations[RayServicePreviousClusterSpecAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	spec := &rayv1api.RayClusterSpec{}
+	if err := json.Unmarshal([]byte(previous), spec); err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
 func buildRayServiceLabels(apiService *api.RayService) map[string]string {
 	labels := map[string]string{}
 	labels[RayClusterUserLabelKey] = apiService.User
@@ -6,6 +6,9 @@ import (
 	api "github.com/ray-project/kuberay/proto/go_client"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	rayv1api "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 )
 
 var apiServiceNoServe = &api.RayService{
@@ -38,3 +41,30 @@ func TestBuildService(t *testing.T) {
 	assert.NotNil(t, got.Spec.ServiceUnhealthySecondThreshold)
 	assert.Nil(t, got.Spec.DeploymentUnhealthySecondThreshold)
 }
+
+func TestRecordPreviousRayClusterSpec(t *testing.T) {
+	oldService, err := NewRayService(apiServiceV2, map[string]*api.ComputeTemplate{"foo": &template})
+	require.NoError(t, err)
+	previous, err := GetPreviousRayClusterSpec(oldService.Get())
+	require.NoError(t, err)
+	assert.Nil(t, previous)
+
+	// The cluster spec changes, so the old one is recorded.
+	newService := oldService.DeepCopy()
+	newService.Spec.RayClusterSpec.RayVersion = "2.9.0"
+	require.NoError(t, RecordPreviousRayClusterSpec(oldService.Get(), newService))
+	previous, err = GetPreviousRayClusterSpec(newService)
+	require.NoError(t, err)
+	assert.Empty(t, previous.RayVersion)
+	assert.Equal(t, oldService.Spec.RayClusterSpec.WorkerGroupSpecs[0].GroupName, previous.WorkerGroupSpecs[0].GroupName)
+
+	// Only the serve config changes, so the recorded spec is kept.
+	serveUpdate := newService.DeepCopy()
+	serveUpdate.Annotations = nil
+	serveUpdate.Spec.ServeConfigV2 = "Another fake Yaml file"
+	require.NoError(t, RecordPreviousRayClusterSpec(newService, serveUpdate))
+	assert.Equal(t, newService.Annotations[RayServicePreviousClusterSpecAnnotationKey], serveUpdate.Annotations[RayServicePreviousClusterSpecAnnotationKey])
+
+	_, err = GetPreviousRayClusterSpec(&rayv1api.RayService{})
+	assert.NoError(t, err)
+}
@@ -3,10 +3,13 @@ import time
 import requests
 from python_apiserver_client.params import (
     Cluster,
+    ClusterTemplate,
     RayJobInfo,
     RayJobRequest,
     Template,
     cluster_decoder,
+    cluster_template_decoder,
+    cluster_templates_decoder,
     clusters_decoder,
     template_decoder,
     templates_decoder,
@@ -126,6 +129,103 @@ class KubeRayAPIs:
             return response.status_code, response.json()["message"]
         return response.status_code, None
 
+    def list_cluster_templates(self, ns: str) -> tuple[int, str, list[ClusterTemplate]]:
+        """
+        List cluster templates in a given namespace of the k8 cluster
+        :param ns: namespace to query
+        :return: tuple containing
+            http return code
+            message - only returned if http return code is not equal to 200
+            list of cluster templates
+        """
+        # Execute HTTP request
+        url = self.base + self.api_base + f"namespaces/{ns}/cluster_templates"
+        response = requests.get(url, headers=_headers, timeout=TIMEOUT)
+        # Check execution status
+        if response.status_code // 100 != 2:
+            return response.status_code, response.json()["message"], None
+        return response.status_code, None, cluster_templates_decoder(response.json())
+
+    def get_cluster_template(self, ns: str, name: str) -> tuple[int, str, ClusterTemplate]:
+        """
+        get a cluster template
+        :param ns: namespace
+        :param name: cluster template name
+        :return: tuple containing
+            http return code
+            message - only returned if http return code is not equal to 200
+            cluster template
+        """
+        # Execute HTTP request
+        url = self.base + self.api_base + f"namespaces/{ns}/cluster_templates/{name}"
+        response = requests.get(url, headers=_headers, timeout=TIMEOUT)
+        # Check execution status
+        if response.status_code // 100 != 2:
+            return response.status_code, response.json()["message"], None
+        return response.status_code, None, cluster_template_decoder(response.json())
+
+    def create_cluster_template(self, template: ClusterTemplate) -> tuple[int, str]:
+        """
+        Create a cluster template
+        :param template - definition of a cluster template
+        :return: a tuple containing
+            http return code
+            message - only returned if http return code is not equal to 200
+        """
+        # Execute HTTP request
+        url = self.base + self.api_base + f"namespaces/{template.namespace}/cluster_templates"
+        response = requests.post(url, json=template.to_dict(), headers=_headers, timeout=TIMEOUT)
+        if response.status_code // 100 != 2:
+            return response.status_code, response.json()["message"]
+        return response.status_code, None
+
+    def update_cluster_template(self, template: ClusterTemplate) -> tuple[int, str]:
+        """
+        Replace an existing cluster template. Clusters already created from the template are not changed
+        :param template - new definition of the cluster template
+        :return: a tuple containing
+            http return code
+            message - only returned if http return code is not equal to 200
+        """
+        # Execute HTTP request
+        url = self.base + self.api_base + f"namespaces/{template.namespace}/cluster_templates/{template.name}"
+        response = requests.put(url, json=template.to_dict(), headers=_headers, timeout=TIMEOUT)
+        if response.status_code // 100 != 2:
+            return response.status_code, response.json()["message"]
+        return response.status_code, None
+
+    def delete_cluster_template(self, ns: str, name: str) -> tuple[int, str]:
+        """
+        delete a cluster template
+        :param ns: namespace
+        :param name: cluster template name
+        :returns: a tuple containing
+            http return code
+            message - only returned if http return code is not equal to 200
+        """
+        # Execute HTTP request
+        url = self.base + self.api_base + f"namespaces/{ns}/cluster_templates/{name}"
+        response = requests.delete(url, headers=_headers, timeout=TIMEOUT)
+        if response.status_code // 100 != 2:
+            return response.status_code, response.json()["message"]
+        return response.status_code, None
+
+    def create_cluster_from_template(self, name: str, cluster: Cluster) -> tuple[int, str]:
+        """
+        Create cluster from a cluster template in the namespace of the cluster
+        :param name: cluster template name
+        :param cluster: cluster definition without cluster spec. The version of the template is used if not set
+        :return: a tuple containing
+            http return code
+            message - only returned if http return code is not equal to 200
+        """
+        # Execute HTTP request
+        url = self.base + self.api_base + f"namespaces/{cluster.namespace}/cluster_templates/{name}/clusters"
+        response = requests.post(url, json=cluster.to_dict(), headers=_headers, timeout=TIMEOUT)
+        if response.status_code // 100 != 2:
+            return response.status_code, response.json()["message"]
+        return response.status_code, None
+
     def list_clusters(self) -> tuple[int, str, list[Cluster]]:
         """
         List clusters across all namespaces of the k8 cluster
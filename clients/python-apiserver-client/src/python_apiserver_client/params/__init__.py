@@ -50,4 +50,9 @@ from python_apiserver_client.params.cluster import (
     cluster_decoder,
     clusters_decoder,
 )
+from python_apiserver_client.params.clustertemplate import (
+    ClusterTemplate,
+    cluster_template_decoder,
+    cluster_templates_decoder,
+)
 from python_apiserver_client.params.jobsubmission import RayJobRequest, RayJobInfo
@@ -254,7 +254,7 @@ class Cluster:
         user - required, user who owns the cluster
         version - required, Ray cluster version - typically Ray version
         deployment_environment - optional (see Environment)
-        cluster_spec - required, ray cluster configuration
+        cluster_spec - required, ray cluster configuration. Must be None when the cluster is created from a cluster template
         annotations - optional, annotations, for example, "kubernetes.io/ingress.class" to define Ingress class
         cluster_environment - optional, cluster environment variables
         created_at - output, cluster creation ts
@@ -359,8 +359,9 @@ class Cluster:
             "namespace": self.namespace,
             "user": self.user,
             "version": self.version,
-            "clusterSpec": self.cluster_spec.to_dict(),
         }
+        if self.cluster_spec is not None:
+            dst["clusterSpec"] = self.cluster_spec.to_dict()
         if self.environment is not None:
             dst["environment"] = self.environment.value
         if self.annotations is not None:
@@ -0,0 +1,96 @@
+from typing import Any
+
+from python_apiserver_client.params import ClusterSpec, cluster_spec_decoder
+
+
+class ClusterTemplate:
+    """
+    ClusterTemplate is used to define a named cluster spec that clusters can be created from.
+    It provides APIs to create, stringify and convert to dict.
+
+    Methods:
+    - Create cluster template: gets the following parameters:
+        name - required, unique (per namespace) cluster template name
+        namespace - required, cluster template's namespace (should exist)
+        cluster_spec - required, ray cluster configuration of the clusters created from the template
+        description - optional, human-readable description of the template
+        version - optional, default Ray version of the clusters created from the template
+    - to_string() -> str: convert cluster template to string for printing
+    - to_dict() -> dict[str, Any] convert to dict
+    """
+
+    def __init__(
+            self,
+            name: str,
+            namespace: str,
+            cluster_spec: ClusterSpec,
+            description: str = None,
+            version: str = None,
+    ):
+        """
+        Initialization
+        :param name: cluster template name
+        :param namespace: cluster template namespace
+        :param cluster_spec: cluster spec
+        :param description: description
+        :param version: default Ray version
+        """
+        self.name = name
+        self.namespace = namespace
+        self.cluster_spec = cluster_spec
+        self.description = description
+        self.version = version
+
+    def to_string(self) -> str:
+        """
+        convert to string representation
+        :return: string representation of cluster template
+        """
+        val = f"name: {self.name}, namespace = {self.namespace}, cluster_spec = {self.cluster_spec.to_string()}"
+        if self.description is not None:
+            val += f", description = {self.description}"
+        if self.version is not None:
+            val += f", version = {self.version}"
+        return val
+
+    def to_dict(self) -> dict[str, Any]:
+        """
+        convert to dictionary
+        :return: dictionary representation of cluster template
+        """
+        dst = {"name": self.name, "namespace": self.namespace, "clusterSpec": self.cluster_spec.to_dict()}
+        if self.description is not None:
+            dst["description"] = self.description
+        if self.version is not None:
+            dst["version"] = self.version
+        return dst
+
+
+"""
+    Creates new cluster template from dictionary, used for unmarshalling json. Python does not
+    support multiple constructors, so do it this way
+"""
+
+
+def cluster_template_decoder(dct: dict[str, Any]) -> ClusterTemplate:
+    """
+    Create cluster template from its dictionary representation
+    :param dct: dictionary representation of cluster template
+    :return: cluster template
+    """
+    return ClusterTemplate(
+        name=dct.get("name", ""),
+        namespace=dct.get("namespace", ""),
+        cluster_spec=cluster_spec_decoder(dct.get("clusterSpec")),
+        description=dct.get("description"),
+        version=dct.get("version"),
+    )
+
+
+def cluster_templates_decoder(dct: dict[str, Any]) -> list[ClusterTemplate]:
+    """
+    Create list of cluster templates from its dictionary representation
+    :param dct: dictionary representation of a list of cluster templates
+    :return: list of cluster templates
+    """
+    return [cluster_template_decoder(template) for template in dct.get("clusterTemplates", [])]
@@ -8,6 +8,7 @@ from python_apiserver_client.params import (
     Cluster,
     ClusterEvent,
     ClusterSpec,
+    ClusterTemplate,
     ConfigMapVolume,
     EmptyDirVolume,
     Environment,
@@ -32,6 +33,7 @@ from python_apiserver_client.params import (
     autoscaling_decoder,
     cluster_decoder,
     cluster_spec_decoder,
+    cluster_template_decoder,
     env_var_from_decoder,
     environment_variables_decoder,
     head_node_spec_decoder,
@@ -395,6 +397,41 @@ def test_cluster():
     print(f"cluster with output: {cluster_decoder(cluster_dict).to_string()}")
 
 
+def test_cluster_template():
+    spec = ClusterSpec(
+        head_node=HeadNodeSpec(
+            compute_template="template",
+            image="rayproject/ray:2.9.0-py310",
+            ray_start_params=DEFAULT_HEAD_START_PARAMS,
+        ),
+        worker_groups=[
+            WorkerNodeSpec(
+                group_name="group",
+                compute_template="template",
+                replicas=1,
+                min_replicas=0,
+                max_replicas=4,
+                image="rayproject/ray:2.9.0-py310",
+                ray_start_params=DEFAULT_WORKER_START_PARAMS,
+            ),
+        ],
+    )
+    template = ClusterTemplate(
+        name="small-cluster",
+        namespace="default",
+        cluster_spec=spec,
+        description="head node and a small worker group",
+        version="2.9.0",
+    )
+    print(f"\ncluster template: {template.to_string()}")
+    template_json = json.dumps(template.to_dict())
+    print(f"cluster template JSON: {template_json}")
+    assert cluster_template_decoder(json.loads(template_json)).to_string() == template.to_string()
+
+    cluster = Cluster(name="test", namespace="default", user="boris", version="2.9.0", cluster_spec=None)
+    assert "clusterSpec" not in cluster.to_dict()
+
+
 def test_submission():
     yaml = """
     pip:
@@ -0,0 +1,333 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package go_client
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ClusterTemplateServiceClient is the client API for ClusterTemplateService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ClusterTemplateServiceClient interface {
+	// Creates a new cluster template.
+	CreateClusterTemplate(ctx context.Context, in *CreateClusterTemplateRequest, opts ...grpc.CallOption) (*ClusterTemplate, error)
+	// Finds a specific cluster template by its name and namespace.
+	GetClusterTemplate(ctx context.Context, in *GetClusterTemplateRequest, opts ...grpc.CallOption) (*ClusterTemplate, error)
+	// Finds all cluster templates in a given namespace.
+	ListClusterTemplates(ctx context.Context, in *ListClusterTemplatesRequest, opts ...grpc.CallOption) (*ListClusterTemplatesResponse, error)
+	// Replaces an existing cluster template. Clusters and jobs already created from the template are not changed.
+	UpdateClusterTemplate(ctx context.Context, in *UpdateClusterTemplateRequest, opts ...grpc.CallOption) (*ClusterTemplate, error)
+	// Deletes a cluster template by its name and namespace.
+	DeleteClusterTemplate(ctx context.Context, in *DeleteClusterTemplateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Creates a new cluster from a cluster template. The cluster must not have a cluster spec.
+	CreateClusterFromTemplate(ctx context.Context, in *CreateClusterFromTemplateRequest, opts ...grpc.CallOption) (*Cluster, error)
+	// Creates a new job from a cluster template. The job must not have a cluster spec or a cluster selector.
+	CreateJobFromTemplate(ctx context.Context, in *CreateJobFromTemplateRequest, opts ...grpc.CallOption) (*RayJob, error)
+}
+
+type clusterTemplateServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewClusterTemplateServiceClient(cc grpc.ClientConnInterface) ClusterTemplateServiceClient {
+	return &clusterTemplateServiceClient{cc}
+}
+
+func (c *clusterTemplateServiceClient) CreateClusterTemplate(ctx context.Context, in *CreateClusterTemplateRequest, opts ...grpc.CallOption) (*ClusterTemplate, error) {
+	out := new(ClusterTemplate)
+	err := c.cc.Invoke(ctx, "/proto.ClusterTemplateService/CreateClusterTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterTemplateServiceClient) GetClusterTemplate(ctx context.Context, in *GetClusterTemplateRequest, opts ...grpc.CallOption) (*ClusterTemplate, error) {
+	out := new(ClusterTemplate)
+	err := c.cc.Invoke(ctx, "/proto.ClusterTemplateService/GetClusterTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterTemplateServiceClient) ListClusterTemplates(ctx context.Context, in *ListClusterTemplatesRequest, opts ...grpc.CallOption) (*ListClusterTemplatesResponse, error) {
+	out := new(ListClusterTemplatesResponse)
+	err := c.cc.Invoke(ctx, "/proto.ClusterTemplateService/ListClusterTemplates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterTemplateServiceClient) UpdateClusterTemplate(ctx context.Context, in *UpdateClusterTemplateRequest, opts ...grpc.CallOption) (*ClusterTemplate, error) {
+	out := new(ClusterTemplate)
+	err := c.cc.Invoke(ctx, "/proto.ClusterTemplateService/UpdateClusterTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterTemplateServiceClient) DeleteClusterTemplate(ctx context.Context, in *DeleteClusterTemplateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/proto.ClusterTemplateService/DeleteClusterTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterTemplateServiceClient) CreateClusterFromTemplate(ctx context.Context, in *CreateClusterFromTemplateRequest, opts ...grpc.CallOption) (*Cluster, error) {
+	out := new(Cluster)
+	err := c.cc.Invoke(ctx, "/proto.ClusterTemplateService/CreateClusterFromTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *clusterTemplateServiceClient) CreateJobFromTemplate(ctx context.Context, in *CreateJobFromTemplateRequest, opts ...grpc.CallOption) (*RayJob, error) {
+	out := new(RayJob)
+	err := c.cc.Invoke(ctx, "/proto.ClusterTemplateService/CreateJobFromTemplate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ClusterTemplateServiceServer is the server API for ClusterTemplateService service.
+// All implementations must embed UnimplementedClusterTemplateServiceServer
+// for forward compatibility
+type ClusterTemplateServiceServer interface {
+	// Creates a new cluster template.
+	CreateClusterTemplate(context.Context, *CreateClusterTemplateRequest) (*ClusterTemplate, error)
+	// Finds a specific cluster template by its name and namespace.
+	GetClusterTemplate(context.Context, *GetClusterTemplateRequest) (*ClusterTemplate, error)
+	// Finds all cluster templates in a given namespace.
+	ListClusterTemplates(context.Context, *ListClusterTemplatesRequest) (*ListClusterTemplatesResponse, error)
+	// Replaces an existing cluster template. Clusters and jobs already created from the template are not changed.
+	UpdateClusterTemplate(context.Context, *UpdateClusterTemplateRequest) (*ClusterTemplate, error)
+	// Deletes a cluster template by its name and namespace.
+	DeleteClusterTemplate(context.Context, *DeleteClusterTemplateRequest) (*emptypb.Empty, error)
+	// Creates a new cluster from a cluster template. The cluster must not have a cluster spec.
+	CreateClusterFromTemplate(context.Context, *CreateClusterFromTemplateRequest) (*Cluster, error)
+	// Creates a new job from a cluster template. The job must not have a cluster spec or a cluster selector.
+	CreateJobFromTemplate(context.Context, *CreateJobFromTemplateRequest) (*RayJob, error)
+	mustEmbedUnimplementedClusterTemplateServiceServer()
+}
+
+// UnimplementedClusterTemplateServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedClusterTemplateServiceServer struct {
+}
+
+func (UnimplementedClusterTemplateServiceServer) CreateClusterTemplate(context.Context, *CreateClusterTemplateRequest) (*ClusterTemplate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateClusterTemplate not implemented")
+}
+func (UnimplementedClusterTemplateServiceServer) GetClusterTemplate(context.Context, *GetClusterTemplateRequest) (*ClusterTemplate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClusterTemplate not implemented")
+}
+func (UnimplementedClusterTemplateServiceServer) ListClusterTemplates(context.Context, *ListClusterTemplatesRequest) (*ListClusterTemplatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListClusterTemplates not implemented")
+}
+func (UnimplementedClusterTemplateServiceServer) UpdateClusterTemplate(context.Context, *UpdateClusterTemplateRequest) (*ClusterTemplate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateClusterTemplate not implemented")
+}
+func (UnimplementedClusterTemplateServiceServer) DeleteClusterTemplate(context.Context, *DeleteClusterTemplateRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteClusterTemplate not implemented")
+}
+func (UnimplementedClusterTemplateServiceServer) CreateClusterFromTemplate(context.Context, *CreateClusterFromTemplateRequest) (*Cluster, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateClusterFromTemplate not implemented")
+}
+func (UnimplementedClusterTemplateServiceServer) CreateJobFromTemplate(context.Context, *CreateJobFromTemplateRequest) (*RayJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateJobFromTemplate not implemented")
+}
+func (UnimplementedClusterTemplateServiceServer) mustEmbedUnimplementedClusterTemplateServiceServer() {
+}
+
+// UnsafeClusterTemplateServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ClusterTemplateServiceServer will
+// result in compilation errors.
+type UnsafeClusterTemplateServiceServer interface {
+	mustEmbedUnimplementedClusterTemplateServiceServer()
+}
+
+func RegisterClusterTemplateServiceServer(s grpc.ServiceRegistrar, srv ClusterTemplateServiceServer) {
+	s.RegisterService(&ClusterTemplateService_ServiceDesc, srv)
+}
+
+func _ClusterTemplateService_CreateClusterTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateClusterTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterTemplateServiceServer).CreateClusterTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ClusterTemplateService/CreateClusterTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterTemplateServiceServer).CreateClusterTemplate(ctx, req.(*CreateClusterTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClusterTemplateService_GetClusterTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetClusterTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterTemplateServiceServer).GetClusterTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ClusterTemplateService/GetClusterTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterTemplateServiceServer).GetClusterTemplate(ctx, req.(*GetClusterTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClusterTemplateService_ListClusterTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListClusterTemplatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterTemplateServiceServer).ListClusterTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ClusterTemplateService/ListClusterTemplates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterTemplateServiceServer).ListClusterTemplates(ctx, req.(*ListClusterTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClusterTemplateService_UpdateClusterTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateClusterTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterTemplateServiceServer).UpdateClusterTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ClusterTemplateService/UpdateClusterTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterTemplateServiceServer).UpdateClusterTemplate(ctx, req.(*UpdateClusterTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClusterTemplateService_DeleteClusterTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteClusterTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterTemplateServiceServer).DeleteClusterTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ClusterTemplateService/DeleteClusterTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterTemplateServiceServer).DeleteClusterTemplate(ctx, req.(*DeleteClusterTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClusterTemplateService_CreateClusterFromTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateClusterFromTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterTemplateServiceServer).CreateClusterFromTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ClusterTemplateService/CreateClusterFromTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterTemplateServiceServer).CreateClusterFromTemplate(ctx, req.(*CreateClusterFromTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ClusterTemplateService_CreateJobFromTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateJobFromTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ClusterTemplateServiceServer).CreateJobFromTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.ClusterTemplateService/CreateJobFromTemplate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ClusterTemplateServiceServer).CreateJobFromTemplate(ctx, req.(*CreateJobFromTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ClusterTemplateService_ServiceDesc is the grpc.ServiceDesc for ClusterTemplateService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ClusterTemplateService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.ClusterTemplateService",
+	HandlerType: (*ClusterTemplateServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateClusterTemplate",
+			Handler:    _ClusterTemplateService_CreateClusterTemplate_Handler,
+		},
+		{
+			MethodName: "GetClusterTemplate",
+			Handler:    _ClusterTemplateService_GetClusterTemplate_Handler,
+		},
+		{
+			MethodName: "ListClusterTemplates",
+			Handler:    _ClusterTemplateService_ListClusterTemplates_Handler,
+		},
+		{
+			MethodName: "UpdateClusterTemplate",
+			Handler:    _ClusterTemplateService_UpdateClusterTemplate_Handler,
+		},
+		{
+			MethodName: "DeleteClusterTemplate",
+			Handler:    _ClusterTemplateService_DeleteClusterTemplate_Handler,
+		},
+		{
+			MethodName: "CreateClusterFromTemplate",
+			Handler:    _ClusterTemplateService_CreateClusterFromTemplate_Handler,
+		},
+		{
+			MethodName: "CreateJobFromTemplate",
+			Handler:    _ClusterTemplateService_CreateJobFromTemplate_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "cluster_template.proto",
+}
@@ -254,3 +254,195 @@ var RayJobService_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "job.proto",
 }
+
+// RayJobControlServiceClient is the client API for RayJobControlService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RayJobControlServiceClient interface {
+	// Streams the driver log of a job. If follow is set, new output is streamed until the job reaches a terminal state.
+	GetRayJobLog(ctx context.Context, in *GetRayJobLogRequest, opts ...grpc.CallOption) (RayJobControlService_GetRayJobLogClient, error)
+	// Stops the Ray job that is currently running for a job. The RayJob and its RayCluster are left untouched.
+	StopRayJob(ctx context.Context, in *StopRayJobRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Lists the Ray job submissions a job has made on its RayCluster.
+	ListRayJobAttempts(ctx context.Context, in *ListRayJobAttemptsRequest, opts ...grpc.CallOption) (*ListRayJobAttemptsResponse, error)
+}
+
+type rayJobControlServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRayJobControlServiceClient(cc grpc.ClientConnInterface) RayJobControlServiceClient {
+	return &rayJobControlServiceClient{cc}
+}
+
+func (c *rayJobControlServiceClient) GetRayJobLog(ctx context.Context, in *GetRayJobLogRequest, opts ...grpc.CallOption) (RayJobControlService_GetRayJobLogClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RayJobControlService_ServiceDesc.Streams[0], "/proto.RayJobControlService/GetRayJobLog", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &rayJobControlServiceGetRayJobLogClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RayJobControlService_GetRayJobLogClient interface {
+	Recv() (*RayJobLogChunk, error)
+	grpc.ClientStream
+}
+
+type rayJobControlServiceGetRayJobLogClient struct {
+	grpc.ClientStream
+}
+
+func (x *rayJobControlServiceGetRayJobLogClient) Recv() (*RayJobLogChunk, error) {
+	m := new(RayJobLogChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *rayJobControlServiceClient) StopRayJob(ctx context.Context, in *StopRayJobRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/proto.RayJobControlService/StopRayJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rayJobControlServiceClient) ListRayJobAttempts(ctx context.Context, in *ListRayJobAttemptsRequest, opts ...grpc.CallOption) (*ListRayJobAttemptsResponse, error) {
+	out := new(ListRayJobAttemptsResponse)
+	err := c.cc.Invoke(ctx, "/proto.RayJobControlService/ListRayJobAttempts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RayJobControlServiceServer is the server API for RayJobControlService service.
+// All implementations must embed UnimplementedRayJobControlServiceServer
+// for forward compatibility
+type RayJobControlServiceServer interface {
+	// Streams the driver log of a job. If follow is set, new output is streamed until the job reaches a terminal state.
+	GetRayJobLog(*GetRayJobLogRequest, RayJobControlService_GetRayJobLogServer) error
+	// Stops the Ray job that is currently running for a job. The RayJob and its RayCluster are left untouched.
+	StopRayJob(context.Context, *StopRayJobRequest) (*emptypb.Empty, error)
+	// Lists the Ray job submissions a job has made on its RayCluster.
+	ListRayJobAttempts(context.Context, *ListRayJobAttemptsRequest) (*ListRayJobAttemptsResponse, error)
+	mustEmbedUnimplementedRayJobControlServiceServer()
+}
+
+// UnimplementedRayJobControlServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedRayJobControlServiceServer struct {
+}
+
+func (UnimplementedRayJobControlServiceServer) GetRayJobLog(*GetRayJobLogRequest, RayJobControlService_GetRayJobLogServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetRayJobLog not implemented")
+}
+func (UnimplementedRayJobControlServiceServer) StopRayJob(context.Context, *StopRayJobRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopRayJob not implemented")
+}
+func (UnimplementedRayJobControlServiceServer) ListRayJobAttempts(context.Context, *ListRayJobAttemptsRequest) (*ListRayJobAttemptsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRayJobAttempts not implemented")
+}
+func (UnimplementedRayJobControlServiceServer) mustEmbedUnimplementedRayJobControlServiceServer() {}
+
+// UnsafeRayJobControlServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RayJobControlServiceServer will
+// result in compilation errors.
+type UnsafeRayJobControlServiceServer interface {
+	mustEmbedUnimplementedRayJobControlServiceServer()
+}
+
+func RegisterRayJobControlServiceServer(s grpc.ServiceRegistrar, srv RayJobControlServiceServer) {
+	s.RegisterService(&RayJobControlService_ServiceDesc, srv)
+}
+
+func _RayJobControlService_GetRayJobLog_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetRayJobLogRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RayJobControlServiceServer).GetRayJobLog(m, &rayJobControlServiceGetRayJobLogServer{stream})
+}
+
+type RayJobControlService_GetRayJobLogServer interface {
+	Send(*RayJobLogChunk) error
+	grpc.ServerStream
+}
+
+type rayJobControlServiceGetRayJobLogServer struct {
+	grpc.ServerStream
+}
+
+func (x *rayJobControlServiceGetRayJobLogServer) Send(m *RayJobLogChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RayJobControlService_StopRayJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopRayJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RayJobControlServiceServer).StopRayJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.RayJobControlService/StopRayJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RayJobControlServiceServer).StopRayJob(ctx, req.(*StopRayJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RayJobControlService_ListRayJobAttempts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRayJobAttemptsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RayJobControlServiceServer).ListRayJobAttempts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.RayJobControlService/ListRayJobAttempts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RayJobControlServiceServer).ListRayJobAttempts(ctx, req.(*ListRayJobAttemptsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RayJobControlService_ServiceDesc is the grpc.ServiceDesc for RayJobControlService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RayJobControlService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.RayJobControlService",
+	HandlerType: (*RayJobControlServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "StopRayJob",
+			Handler:    _RayJobControlService_StopRayJob_Handler,
+		},
+		{
+			MethodName: "ListRayJobAttempts",
+			Handler:    _RayJobControlService_ListRayJobAttempts_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetRayJobLog",
+			Handler:       _RayJobControlService_GetRayJobLog_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "job.proto",
+}
@@ -294,3 +294,170 @@ var RayServeService_ServiceDesc = grpc.ServiceDesc{
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "serve.proto",
 }
+
+// RayServiceControlServiceClient is the client API for RayServiceControlService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type RayServiceControlServiceClient interface {
+	// Gets the application and deployment statuses of the active and pending RayClusters of a service.
+	GetRayServeStatus(ctx context.Context, in *GetRayServeStatusRequest, opts ...grpc.CallOption) (*RayServeStatus, error)
+	// Cancels an in-progress zero downtime upgrade by restoring the RayCluster spec the service had before its last
+	// update through the API server. The Serve config is not changed.
+	RollbackRayService(ctx context.Context, in *RollbackRayServiceRequest, opts ...grpc.CallOption) (*RayServeStatus, error)
+	// Sets the Serve target capacity of a service. Ray Serve applies it in place, so no new RayCluster is created.
+	UpdateRayServiceTargetCapacity(ctx context.Context, in *UpdateRayServiceTargetCapacityRequest, opts ...grpc.CallOption) (*RayServeStatus, error)
+}
+
+type rayServiceControlServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRayServiceControlServiceClient(cc grpc.ClientConnInterface) RayServiceControlServiceClient {
+	return &rayServiceControlServiceClient{cc}
+}
+
+func (c *rayServiceControlServiceClient) GetRayServeStatus(ctx context.Context, in *GetRayServeStatusRequest, opts ...grpc.CallOption) (*RayServeStatus, error) {
+	out := new(RayServeStatus)
+	err := c.cc.Invoke(ctx, "/proto.RayServiceControlService/GetRayServeStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rayServiceControlServiceClient) RollbackRayService(ctx context.Context, in *RollbackRayServiceRequest, opts ...grpc.CallOption) (*RayServeStatus, error) {
+	out := new(RayServeStatus)
+	err := c.cc.Invoke(ctx, "/proto.RayServiceControlService/RollbackRayService", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rayServiceControlServiceClient) UpdateRayServiceTargetCapacity(ctx context.Context, in *UpdateRayServiceTargetCapacityRequest, opts ...grpc.CallOption) (*RayServeStatus, error) {
+	out := new(RayServeStatus)
+	err := c.cc.Invoke(ctx, "/proto.RayServiceControlService/UpdateRayServiceTargetCapacity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RayServiceControlServiceServer is the server API for RayServiceControlService service.
+// All implementations must embed UnimplementedRayServiceControlServiceServer
+// for forward compatibility
+type RayServiceControlServiceServer interface {
+	// Gets the application and deployment statuses of the active and pending RayClusters of a service.
+	GetRayServeStatus(context.Context, *GetRayServeStatusRequest) (*RayServeStatus, error)
+	// Cancels an in-progress zero downtime upgrade by restoring the RayCluster spec the service had before its last
+	// update through the API server. The Serve config is not changed.
+	RollbackRayService(context.Context, *RollbackRayServiceRequest) (*RayServeStatus, error)
+	// Sets the Serve target capacity of a service. Ray Serve applies it in place, so no new RayCluster is created.
+	UpdateRayServiceTargetCapacity(context.Context, *UpdateRayServiceTargetCapacityRequest) (*RayServeStatus, error)
+	mustEmbedUnimplementedRayServiceControlServiceServer()
+}
+
+// UnimplementedRayServiceControlServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedRayServiceControlServiceServer struct {
+}
+
+func (UnimplementedRayServiceControlServiceServer) GetRayServeStatus(context.Context, *GetRayServeStatusRequest) (*RayServeStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRayServeStatus not implemented")
+}
+func (UnimplementedRayServiceControlServiceServer) RollbackRayService(context.Context, *RollbackRayServiceRequest) (*RayServeStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollbackRayService not implemented")
+}
+func (UnimplementedRayServiceControlServiceServer) UpdateRayServiceTargetCapacity(context.Context, *UpdateRayServiceTargetCapacityRequest) (*RayServeStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRayServiceTargetCapacity not implemented")
+}
+func (UnimplementedRayServiceControlServiceServer) mustEmbedUnimplementedRayServiceControlServiceServer() {
+}
+
+// UnsafeRayServiceControlServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RayServiceControlServiceServer will
+// result in compilation errors.
+type UnsafeRayServiceControlServiceServer interface {
+	mustEmbedUnimplementedRayServiceControlServiceServer()
+}
+
+func RegisterRayServiceControlServiceServer(s grpc.ServiceRegistrar, srv RayServiceControlServiceServer) {
+	s.RegisterService(&RayServiceControlService_ServiceDesc, srv)
+}
+
+func _RayServiceControlService_GetRayServeStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRayServeStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RayServiceControlServiceServer).GetRayServeStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.RayServiceControlService/GetRayServeStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RayServiceControlServiceServer).GetRayServeStatus(ctx, req.(*GetRayServeStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RayServiceControlService_RollbackRayService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackRayServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RayServiceControlServiceServer).RollbackRayService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.RayServiceControlService/RollbackRayService",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RayServiceControlServiceServer).RollbackRayService(ctx, req.(*RollbackRayServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RayServiceControlService_UpdateRayServiceTargetCapacity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRayServiceTargetCapacityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RayServiceControlServiceServer).UpdateRayServiceTargetCapacity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.RayServiceControlService/UpdateRayServiceTargetCapacity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RayServiceControlServiceServer).UpdateRayServiceTargetCapacity(ctx, req.(*UpdateRayServiceTargetCapacityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RayServiceControlService_ServiceDesc is the grpc.ServiceDesc for RayServiceControlService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RayServiceControlService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.RayServiceControlService",
+	HandlerType: (*RayServiceControlServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetRayServeStatus",
+			Handler:    _RayServiceControlService_GetRayServeStatus_Handler,
+		},
+		{
+			MethodName: "RollbackRayService",
+			Handler:    _RayServiceControlService_RollbackRayService_Handler,
+		},
+		{
+			MethodName: "UpdateRayServiceTargetCapacity",
+			Handler:    _RayServiceControlService_UpdateRayServiceTargetCapacity_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "serve.proto",
+}
@@ -25,6 +25,7 @@ cp ${TMP_OUTPUT}/github.com/ray-project/kuberay/proto/go_client/* ./go_client
 # Note: swagger files are generate to source folder directly. No files in ${TMP_OUTPUT}
 jq -s 'reduce .[] as $item ({}; . * $item) | .info.title = "KubeRay API" | .info.description = "This file contains REST API specification for KubeRay. The file is autogenerated from the swagger definition." | .info.version = "'0.6.0'" | .info.license = { "name": "Apache 2.0", "url": "https://raw.githubusercontent.com/ray-project/kuberay/master/LICENSE" }' \
   /go/src/github.com/ray-project/kuberay/proto/swagger/cluster.swagger.json \
+  /go/src/github.com/ray-project/kuberay/proto/swagger/cluster_template.swagger.json \
   /go/src/github.com/ray-project/kuberay/proto/swagger/config.swagger.json \
   /go/src/github.com/ray-project/kuberay/proto/swagger/error.swagger.json \
   /go/src/github.com/ray-project/kuberay/proto/swagger/job.swagger.json \
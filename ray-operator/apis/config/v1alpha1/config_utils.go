@@ -2,11 +2,14 @@ package v1alpha1
 
 import (
 	"fmt"
+	"text/template"
 
 	"github.com/go-logr/logr"
 
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler/volcano"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/batchscheduler/yunikorn"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 )
 
 func ValidateBatchSchedulerConfig(logger logr.Logger, config Configuration) error {
@@ -32,3 +35,78 @@ func ValidateBatchSchedulerConfig(logger logr.Logger, config Configuration) erro
 
 	return nil
 }
+
+// ValidateInjectedEnvPolicy checks that the environment variable names of the policy are not empty and that
+// the value templates can be parsed.
+func ValidateInjectedEnvPolicy(policy *InjectedEnvPolicy) error {
+	if policy == nil {
+		return nil
+	}
+	if err := validateInjectedEnvRules(policy.InjectedEnvRules); err != nil {
+		return err
+	}
+	for namespace, rules := range policy.NamespaceOverrides {
+		if err := validateInjectedEnvRules(rules); err != nil {
+			return fmt.Errorf("namespace %s: %w", namespace, err)
+		}
+	}
+	return nil
+}
+
+func validateInjectedEnvRules(rules InjectedEnvRules) error {
+	for _, name := range rules.Exclude {
+		if name == "" {
+			return fmt.Errorf("excluded environment variable name must not be empty")
+		}
+	}
+	for name, newName := range rules.Rename {
+		if name == "" || newName == "" {
+			return fmt.Errorf("cannot rename environment variable %q to %q", name, newName)
+		}
+	}
+	for name, value := range rules.Values {
+		if _, err := template.New(name).Option("missingkey=error").Parse(value); err != nil {
+			return fmt.Errorf("invalid value template for environment variable %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// ValidateImageArchitecturePolicy checks that every rule of the policy has an image prefix and architectures.
+func ValidateImageArchitecturePolicy(policy *ImageArchitecturePolicy) error {
+	if policy == nil {
+		return nil
+	}
+	for i, rule := range policy.Rules {
+		if rule.ImagePrefix == "" {
+			return fmt.Errorf("image architecture rule %d: imagePrefix must not be empty", i)
+		}
+		if len(rule.Architectures) == 0 {
+			return fmt.Errorf("image architecture rule for %s: architectures must not be empty", rule.ImagePrefix)
+		}
+		for _, architecture := range rule.Architectures {
+			if architecture == "" {
+				return fmt.Errorf("image architecture rule for %s: architecture must not be empty", rule.ImagePrefix)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateServeProxyHealthCheck checks the default health check of the Ray Serve proxies.
+func ValidateServeProxyHealthCheck(healthCheck *rayv1.ServeProxyHealthCheck) error {
+	if err := utils.ValidateServeProxyHealthCheck(healthCheck); err != nil {
+		return fmt.Errorf("serveProxyHealthCheck is invalid: %w", err)
+	}
+	return nil
+}
+
+// ValidateWatchNamespaceSelector checks that the watch namespace selector isn't combined with a watchNamespace
+// restricting the watched namespaces. The selector requires listing and watching the Namespaces of the cluster, which
+// only a ClusterRole allows, while a watchNamespace usually comes with the namespaced RBAC of the watched namespaces.
+func ValidateWatchNamespaceSelector(config Configuration) error {
+	if config.WatchNamespaceSelector != "" && config.WatchNamespace != "" {
+		return fmt.Errorf("watchNamespaceSelector can't be combined with watchNamespace %q, since it requires a ClusterRole to watch the Namespaces", config.WatchNamespace)
+	}
+	return nil
+}
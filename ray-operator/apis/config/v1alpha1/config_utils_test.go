@@ -1,6 +1,7 @@
 package v1alpha1
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/go-logr/logr"
@@ -103,3 +104,210 @@ func TestValidateBatchSchedulerConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateInjectedEnvPolicy(t *testing.T) {
+	tests := []struct {
+		policy  *InjectedEnvPolicy
+		name    string
+		wantErr bool
+	}{
+		{
+			name:    "nil policy",
+			policy:  nil,
+			wantErr: false,
+		},
+		{
+			name: "valid policy",
+			policy: &InjectedEnvPolicy{
+				InjectedEnvRules: InjectedEnvRules{
+					Exclude: []string{"RAY_USAGE_STATS_KUBERAY_IN_USE"},
+					Rename:  map[string]string{"RAY_ADDRESS": "KUBERAY_RAY_ADDRESS"},
+					Values:  map[string]string{"FQ_RAY_IP": "{{ .ClusterName }}-head-svc.{{ .Namespace }}"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "empty excluded name",
+			policy: &InjectedEnvPolicy{
+				InjectedEnvRules: InjectedEnvRules{Exclude: []string{""}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "rename to empty name",
+			policy: &InjectedEnvPolicy{
+				InjectedEnvRules: InjectedEnvRules{Rename: map[string]string{"RAY_ADDRESS": ""}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid template in namespace override",
+			policy: &InjectedEnvPolicy{
+				NamespaceOverrides: map[string]InjectedEnvRules{
+					"team-a": {Values: map[string]string{"RAY_ADDRESS": "{{ .ClusterName "}},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateInjectedEnvPolicy(tt.policy); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateInjectedEnvPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestInjectedEnvPolicyRulesForNamespace(t *testing.T) {
+	policy := &InjectedEnvPolicy{
+		InjectedEnvRules: InjectedEnvRules{
+			Exclude: []string{"RAY_USAGE_STATS_KUBERAY_IN_USE"},
+			Rename:  map[string]string{"RAY_ADDRESS": "KUBERAY_RAY_ADDRESS", "RAY_PORT": "KUBERAY_RAY_PORT"},
+		},
+		NamespaceOverrides: map[string]InjectedEnvRules{
+			"team-a": {
+				Exclude: []string{"RAY_IP"},
+				Rename:  map[string]string{"RAY_ADDRESS": "TEAM_A_RAY_ADDRESS"},
+			},
+		},
+	}
+
+	rules := policy.RulesForNamespace("default")
+	if !reflect.DeepEqual(rules, policy.InjectedEnvRules) {
+		t.Errorf("RulesForNamespace(default) = %v, want %v", rules, policy.InjectedEnvRules)
+	}
+
+	rules = policy.RulesForNamespace("team-a")
+	want := InjectedEnvRules{
+		Exclude: []string{"RAY_USAGE_STATS_KUBERAY_IN_USE", "RAY_IP"},
+		Rename:  map[string]string{"RAY_ADDRESS": "TEAM_A_RAY_ADDRESS", "RAY_PORT": "KUBERAY_RAY_PORT"},
+		Values:  map[string]string{},
+	}
+	if !reflect.DeepEqual(rules, want) {
+		t.Errorf("RulesForNamespace(team-a) = %v, want %v", rules, want)
+	}
+
+	var nilPolicy *InjectedEnvPolicy
+	if rules := nilPolicy.RulesForNamespace("default"); !reflect.DeepEqual(rules, InjectedEnvRules{}) {
+		t.Errorf("RulesForNamespace() of nil policy = %v, want empty rules", rules)
+	}
+}
+
+func TestDashboardMetricsPolicySettingsForNamespace(t *testing.T) {
+	policy := &DashboardMetricsPolicy{
+		DashboardMetricsSettings: DashboardMetricsSettings{
+			GrafanaHost:    "http://grafana.monitoring:3000",
+			PrometheusHost: "http://prometheus.monitoring:9090",
+		},
+		NamespaceOverrides: map[string]DashboardMetricsSettings{
+			"team-a": {
+				GrafanaHost:    "http://grafana.team-a:3000",
+				PrometheusName: "team-a-prometheus",
+			},
+		},
+	}
+
+	if settings := policy.SettingsForNamespace("default"); settings != policy.DashboardMetricsSettings {
+		t.Errorf("SettingsForNamespace(default) = %v, want %v", settings, policy.DashboardMetricsSettings)
+	}
+
+	want := DashboardMetricsSettings{
+		GrafanaHost:    "http://grafana.team-a:3000",
+		PrometheusHost: "http://prometheus.monitoring:9090",
+		PrometheusName: "team-a-prometheus",
+	}
+	if settings := policy.SettingsForNamespace("team-a"); settings != want {
+		t.Errorf("SettingsForNamespace(team-a) = %v, want %v", settings, want)
+	}
+
+	var nilPolicy *DashboardMetricsPolicy
+	if settings := nilPolicy.SettingsForNamespace("default"); settings != (DashboardMetricsSettings{}) {
+		t.Errorf("SettingsForNamespace() of nil policy = %v, want empty settings", settings)
+	}
+}
+
+func TestValidateImageArchitecturePolicy(t *testing.T) {
+	tests := []struct {
+		policy  *ImageArchitecturePolicy
+		name    string
+		wantErr bool
+	}{
+		{
+			name:    "nil policy",
+			policy:  nil,
+			wantErr: false,
+		},
+		{
+			name: "valid policy",
+			policy: &ImageArchitecturePolicy{
+				Rules:           []ImageArchitectureRule{{ImagePrefix: "rayproject/ray:2.9.0", Architectures: []string{"amd64", "arm64"}}},
+				DetectRayImages: true,
+			},
+			wantErr: false,
+		},
+		{
+			name: "empty image prefix",
+			policy: &ImageArchitecturePolicy{
+				Rules: []ImageArchitectureRule{{Architectures: []string{"amd64"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "no architectures",
+			policy: &ImageArchitecturePolicy{
+				Rules: []ImageArchitectureRule{{ImagePrefix: "rayproject/ray"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty architecture",
+			policy: &ImageArchitecturePolicy{
+				Rules: []ImageArchitectureRule{{ImagePrefix: "rayproject/ray", Architectures: []string{""}}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateImageArchitecturePolicy(tt.policy); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateImageArchitecturePolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateWatchNamespaceSelector(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Configuration
+		wantErr bool
+	}{
+		{
+			name:    "no selector",
+			config:  Configuration{WatchNamespace: "ns1,ns2"},
+			wantErr: false,
+		},
+		{
+			name:    "selector watching all namespaces",
+			config:  Configuration{WatchNamespaceSelector: "kuberay.io/enabled=true"},
+			wantErr: false,
+		},
+		{
+			name:    "selector with a restricting watch namespace",
+			config:  Configuration{WatchNamespace: "ns1", WatchNamespaceSelector: "kuberay.io/enabled=true"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateWatchNamespaceSelector(tt.config); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateWatchNamespaceSelector() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -3,8 +3,10 @@ package v1alpha1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 )
 
@@ -32,6 +34,13 @@ type Configuration struct {
 	// If empty, all namespaces will be watched.
 	WatchNamespace string `json:"watchNamespace,omitempty"`
 
+	// WatchNamespaceSelector is a label selector of the namespaces whose custom resources are reconciled, e.g.
+	// `kuberay.io/enabled=true`, or `kuberay.io/disabled!=true` to deny namespaces. The labels of the namespaces are
+	// matched at runtime, so that namespaces can be onboarded to KubeRay gradually by labeling them, without restarting
+	// the operator. The operator lists and watches the Namespaces, which requires a ClusterRole, so it can't be combined
+	// with WatchNamespace. If empty, the custom resources in all the watched namespaces are reconciled.
+	WatchNamespaceSelector string `json:"watchNamespaceSelector,omitempty"`
+
 	// LogFile is a path to a local file for synchronizing logs.
 	LogFile string `json:"logFile,omitempty"`
 
@@ -43,6 +52,10 @@ type Configuration struct {
 	// Defaults to `json` if empty.
 	LogStdoutEncoder string `json:"logStdoutEncoder,omitempty"`
 
+	// LogVerbosity is the highest verbosity of the logs, from 0 to 2. The RayClusters, RayJobs, and RayServices
+	// annotated with `ray.io/log-verbosity` use the verbosity of the annotation instead.
+	LogVerbosity int `json:"logVerbosity,omitempty"`
+
 	// BatchScheduler enables the batch scheduler integration with a specific scheduler
 	// based on the given name, currently, supported values are volcano and yunikorn.
 	BatchScheduler string `json:"batchScheduler,omitempty"`
@@ -70,6 +83,273 @@ type Configuration struct {
 
 	// DeleteRayJobAfterJobFinishes deletes the RayJob CR itself if shutdownAfterJobFinishes is set to true.
 	DeleteRayJobAfterJobFinishes bool `json:"deleteRayJobAfterJobFinishes,omitempty"`
+
+	// ManageCRDs makes the operator apply the CRDs bundled with it at startup, and wait for them to be established.
+	// This is intended for installations without Helm. The operator refuses to start if applying the CRDs would
+	// remove a version that objects are still stored in.
+	ManageCRDs bool `json:"manageCRDs,omitempty"`
+
+	// InjectedEnvPolicy controls the environment variables KubeRay injects into the Ray container of
+	// head and worker Pods, e.g. RAY_ADDRESS, FQ_RAY_IP or RAY_USAGE_STATS_KUBERAY_IN_USE. Environment
+	// variables set in the Pod template are never modified.
+	InjectedEnvPolicy *InjectedEnvPolicy `json:"injectedEnvPolicy,omitempty"`
+
+	// GeneratedPodSecurity sets the security settings of the Pods and containers that KubeRay generates, which
+	// can't be set in the custom resources. This is needed to run Ray in namespaces enforcing restricted security
+	// policies or with sandboxed runtimes such as gVisor or Kata Containers.
+	GeneratedPodSecurity *GeneratedPodSecurity `json:"generatedPodSecurity,omitempty"`
+
+	// ClusterDomain is the DNS domain of the Kubernetes cluster used in the FQDNs of the head services, for clusters
+	// that don't use cluster.local. It takes precedence over the CLUSTER_DOMAIN environment variable, and can be
+	// overridden by the ClusterDomain of a RayCluster.
+	ClusterDomain string `json:"clusterDomain,omitempty"`
+
+	// PodSpecDefaults are the defaults of Pod spec fields of the head, worker and submitter Pods. A field set in the
+	// Pod template of a custom resource overrides the default.
+	PodSpecDefaults *PodSpecDefaults `json:"podSpecDefaults,omitempty"`
+
+	// ClusterStateProviderAddr is the address the cluster state provider binds to. The cluster state provider serves
+	// the normalized state of the RayClusters, e.g. for external autoscalers, to the users allowed to get them. It is
+	// disabled if empty.
+	ClusterStateProviderAddr string `json:"clusterStateProviderAddr,omitempty"`
+
+	// ServeMetricsAdapterAddr is the address the Ray Serve custom metrics adapter binds to. The adapter serves the load
+	// of the Serve replicas through the custom metrics API, so that HPAs can scale on it. It is disabled if empty.
+	ServeMetricsAdapterAddr string `json:"serveMetricsAdapterAddr,omitempty"`
+
+	// DashboardProxyAddr is the address the Ray dashboard proxy binds to. The proxy serves the dashboards of the
+	// RayClusters to the users allowed to get their `rayclusters/proxy` subresource. It is disabled if empty.
+	DashboardProxyAddr string `json:"dashboardProxyAddr,omitempty"`
+
+	// RayServiceHealthAddr is the address the RayService health endpoint binds to. It serves the health of the
+	// RayServices, so that external load balancers can health-check them. It is disabled if empty.
+	RayServiceHealthAddr string `json:"rayServiceHealthAddr,omitempty"`
+
+	// FastDeletion deletes the custom resources in all the namespaces without the graceful paths: the Redis cleanup of
+	// GCS fault tolerance, the drain of the Ray Pods, the stop of the Ray jobs, and the wait for the objects created
+	// for RayJobs and RayServices to be deleted. This speeds up tearing down many custom resources in CI and
+	// development clusters. It can be enabled per namespace with the `ray.io/fast-deletion: "true"` annotation.
+	FastDeletion bool `json:"fastDeletion,omitempty"`
+
+	// RayServiceRequeueInterval is the interval between the reconciliations of the RayServices that are created,
+	// upgraded or restarted. Defaults to 2s. The intervals are jittered so that the RayServices don't poll their Ray
+	// dashboards at the same time, e.g. after a restart of the operator.
+	RayServiceRequeueInterval metav1.Duration `json:"rayServiceRequeueInterval,omitempty"`
+
+	// RayServiceStableRequeueInterval is the interval between the reconciliations of the RayServices whose Serve
+	// applications are running and that aren't upgraded. A longer interval reduces the requests to the Ray dashboards
+	// of large fleets of RayServices. Defaults to the RayServiceRequeueInterval.
+	RayServiceStableRequeueInterval metav1.Duration `json:"rayServiceStableRequeueInterval,omitempty"`
+
+	// GracefulShutdownTimeout is how long the operator waits, when it's terminated, for the in-flight reconciliations
+	// to finish, e.g. to submit the Serve configs and update the statuses, before exiting. No reconciliation is
+	// started once the operator is terminated. Defaults to 30s.
+	GracefulShutdownTimeout metav1.Duration `json:"gracefulShutdownTimeout,omitempty"`
+
+	// DashboardMetrics sets the environment variables of the head Pods that the Ray dashboard uses to query
+	// Prometheus and to embed the Grafana panels, so that the metrics views of the dashboard work without
+	// configuring every RayCluster.
+	DashboardMetrics *DashboardMetricsPolicy `json:"dashboardMetrics,omitempty"`
+
+	// ImageArchitectures describes the CPU architectures that the container images support, so that KubeRay can
+	// require nodes of a matching architecture for the head, worker and submitter Pods. This prevents, for example,
+	// x86-only GPU images from being scheduled on arm64 nodes.
+	ImageArchitectures *ImageArchitecturePolicy `json:"imageArchitectures,omitempty"`
+
+	// ServeProxyHealthCheck customizes the health check of the Ray Serve proxies, which sets the `ray.io/serve` label
+	// of the Pods of RayServices, for example to tolerate slow proxies on loaded head Pods with a longer timeout and
+	// retries. The health check of a RayService overrides the fields it sets.
+	ServeProxyHealthCheck *rayv1.ServeProxyHealthCheck `json:"serveProxyHealthCheck,omitempty"`
+}
+
+// ImageArchitecturePolicy maps container images to the CPU architectures they support. The images aren't inspected
+// in their registries, so an image that matches no rule doesn't constrain the architecture of its Pod.
+type ImageArchitecturePolicy struct {
+	// Rules map image prefixes, such as `rayproject/ray:2.9.0-gpu` or `my-registry.example.com/ray`, to the
+	// architectures of the images. The rule with the longest matching prefix applies. The `docker.io/` prefix of the
+	// images and of the rules is ignored.
+	Rules []ImageArchitectureRule `json:"rules,omitempty"`
+
+	// DetectRayImages infers the architecture of the images of the rayproject/ray and rayproject/ray-ml repositories
+	// that match no rule from their tags: the tags with the `-aarch64` suffix are arm64, the others are amd64.
+	DetectRayImages bool `json:"detectRayImages,omitempty"`
+}
+
+// ImageArchitectureRule describes the architectures of the images with a prefix.
+type ImageArchitectureRule struct {
+	// ImagePrefix is the prefix of the image references the rule applies to.
+	ImagePrefix string `json:"imagePrefix"`
+
+	// Architectures are the values of the kubernetes.io/arch node label that the images support, e.g. amd64 or arm64.
+	Architectures []string `json:"architectures"`
+}
+
+// DashboardMetricsSettings describes the addresses of Prometheus and Grafana for the Ray dashboard. Empty fields
+// aren't injected.
+type DashboardMetricsSettings struct {
+	// GrafanaHost is injected as RAY_GRAFANA_HOST. It's the address the head Pod uses to reach Grafana, e.g.
+	// http://prometheus-grafana.prometheus-system.svc:80.
+	GrafanaHost string `json:"grafanaHost,omitempty"`
+
+	// GrafanaIframeHost is injected as RAY_GRAFANA_IFRAME_HOST. It's the address the browser loads the embedded
+	// Grafana panels from, if it differs from GrafanaHost, e.g. http://127.0.0.1:3000 with port forwarding.
+	GrafanaIframeHost string `json:"grafanaIframeHost,omitempty"`
+
+	// PrometheusHost is injected as RAY_PROMETHEUS_HOST. It's the address the head Pod uses to reach Prometheus,
+	// e.g. http://prometheus-kube-prometheus-prometheus.prometheus-system.svc:9090.
+	PrometheusHost string `json:"prometheusHost,omitempty"`
+
+	// PrometheusName is injected as RAY_PROMETHEUS_NAME. It's the name of the Prometheus data source of Grafana.
+	PrometheusName string `json:"prometheusName,omitempty"`
+}
+
+// DashboardMetricsPolicy is the operator-wide DashboardMetricsSettings, with optional per-namespace overrides.
+type DashboardMetricsPolicy struct {
+	DashboardMetricsSettings `json:",inline"`
+
+	// NamespaceOverrides are merged on top of the operator-wide settings for RayClusters in the given namespace.
+	// Non-empty fields replace the operator-wide ones.
+	NamespaceOverrides map[string]DashboardMetricsSettings `json:"namespaceOverrides,omitempty"`
+}
+
+// SettingsForNamespace returns the settings that apply to RayClusters in the namespace.
+func (policy *DashboardMetricsPolicy) SettingsForNamespace(namespace string) DashboardMetricsSettings {
+	if policy == nil {
+		return DashboardMetricsSettings{}
+	}
+	settings := policy.DashboardMetricsSettings
+	override := policy.NamespaceOverrides[namespace]
+	if override.GrafanaHost != "" {
+		settings.GrafanaHost = override.GrafanaHost
+	}
+	if override.GrafanaIframeHost != "" {
+		settings.GrafanaIframeHost = override.GrafanaIframeHost
+	}
+	if override.PrometheusHost != "" {
+		settings.PrometheusHost = override.PrometheusHost
+	}
+	if override.PrometheusName != "" {
+		settings.PrometheusName = override.PrometheusName
+	}
+	return settings
+}
+
+// PodSpecDefaults describes the defaults of Pod spec fields of the Pods created by KubeRay.
+type PodSpecDefaults struct {
+	// EnableServiceLinks is the default of enableServiceLinks. Setting it to false avoids injecting environment
+	// variables for every Service in the namespace, which can make the environment of the containers too large.
+	EnableServiceLinks *bool `json:"enableServiceLinks,omitempty"`
+
+	// AutomountServiceAccountToken is the default of automountServiceAccountToken. It isn't applied to head Pods
+	// running the Ray autoscaler, because the autoscaler needs the token to scale the RayCluster.
+	AutomountServiceAccountToken *bool `json:"automountServiceAccountToken,omitempty"`
+}
+
+// GeneratedPodSecurity describes the security settings of the Pods and containers generated by KubeRay.
+// Settings of the Pod templates of the custom resources always take precedence.
+type GeneratedPodSecurity struct {
+	// RuntimeClassName is set on the default submitter Pods of RayJobs.
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+
+	// PodSecurityContext is set on the default submitter Pods of RayJobs.
+	PodSecurityContext *corev1.PodSecurityContext `json:"podSecurityContext,omitempty"`
+
+	// ContainerSecurityContext is set on the containers injected by KubeRay without a security context: the
+	// autoscaler and oauth2-proxy containers, the wait-gcs-ready init container, and the container of the default
+	// submitter Pods of RayJobs.
+	ContainerSecurityContext *corev1.SecurityContext `json:"containerSecurityContext,omitempty"`
+
+	// Restricted makes KubeRay use security contexts compliant with the restricted Pod Security Standard
+	// when podSecurityContext or containerSecurityContext isn't set.
+	Restricted bool `json:"restricted,omitempty"`
+}
+
+// GetPodSecurityContext returns the security context of the Pods generated by KubeRay, or nil if it isn't set.
+func (security *GeneratedPodSecurity) GetPodSecurityContext() *corev1.PodSecurityContext {
+	if security == nil {
+		return nil
+	}
+	if security.PodSecurityContext != nil {
+		return security.PodSecurityContext.DeepCopy()
+	}
+	if security.Restricted {
+		return &corev1.PodSecurityContext{
+			RunAsNonRoot:   ptr.To(true),
+			SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+		}
+	}
+	return nil
+}
+
+// GetContainerSecurityContext returns the security context of the containers injected by KubeRay, or nil if it
+// isn't set.
+func (security *GeneratedPodSecurity) GetContainerSecurityContext() *corev1.SecurityContext {
+	if security == nil {
+		return nil
+	}
+	if security.ContainerSecurityContext != nil {
+		return security.ContainerSecurityContext.DeepCopy()
+	}
+	if security.Restricted {
+		return &corev1.SecurityContext{
+			AllowPrivilegeEscalation: ptr.To(false),
+			Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+			RunAsNonRoot:             ptr.To(true),
+			SeccompProfile:           &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+		}
+	}
+	return nil
+}
+
+// InjectedEnvRules describes how the environment variables injected by KubeRay are changed.
+type InjectedEnvRules struct {
+	// Exclude lists the names of injected environment variables that are not injected.
+	Exclude []string `json:"exclude,omitempty"`
+
+	// Rename maps the name of an injected environment variable to the name it is injected as.
+	Rename map[string]string `json:"rename,omitempty"`
+
+	// Values maps the name of an injected environment variable to a Go template that replaces its value.
+	// The template is rendered with .Value (the value KubeRay would inject), .ClusterName, .Namespace,
+	// .NodeType and .GroupName, e.g. `{{ .ClusterName }}-head-svc.{{ .Namespace }}:6379`.
+	Values map[string]string `json:"values,omitempty"`
+}
+
+// InjectedEnvPolicy is the operator-wide InjectedEnvRules, with optional per-namespace overrides.
+type InjectedEnvPolicy struct {
+	InjectedEnvRules `json:",inline"`
+
+	// NamespaceOverrides are merged on top of the operator-wide rules for RayClusters in the given namespace.
+	// Excluded variables are added to the operator-wide list, and renames and values replace the operator-wide
+	// ones for the same variable.
+	NamespaceOverrides map[string]InjectedEnvRules `json:"namespaceOverrides,omitempty"`
+}
+
+// RulesForNamespace returns the rules that apply to RayClusters in the namespace.
+func (policy *InjectedEnvPolicy) RulesForNamespace(namespace string) InjectedEnvRules {
+	if policy == nil {
+		return InjectedEnvRules{}
+	}
+	override, ok := policy.NamespaceOverrides[namespace]
+	if !ok {
+		return policy.InjectedEnvRules
+	}
+	rules := InjectedEnvRules{
+		Exclude: append(append([]string{}, policy.Exclude...), override.Exclude...),
+		Rename:  map[string]string{},
+		Values:  map[string]string{},
+	}
+	for _, m := range []map[string]string{policy.Rename, override.Rename} {
+		for k, v := range m {
+			rules.Rename[k] = v
+		}
+	}
+	for _, m := range []map[string]string{policy.Values, override.Values} {
+		for k, v := range m {
+			rules.Values[k] = v
+		}
+	}
+	return rules
 }
 
 func (config Configuration) GetDashboardClient(mgr manager.Manager) func() utils.RayDashboardClientInterface {
@@ -77,5 +357,5 @@ func (config Configuration) GetDashboardClient(mgr manager.Manager) func() utils
 }
 
 func (config Configuration) GetHttpProxyClient(mgr manager.Manager) func() utils.RayHttpProxyClientInterface {
-	return utils.GetRayHttpProxyClientFunc(mgr, config.UseKubernetesProxy)
+	return utils.GetRayHttpProxyClientFunc(mgr, config.UseKubernetesProxy, config.ServeProxyHealthCheck)
 }
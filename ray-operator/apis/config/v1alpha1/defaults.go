@@ -1,6 +1,8 @@
 package v1alpha1
 
 import (
+	"time"
+
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
 )
@@ -10,6 +12,8 @@ const (
 	DefaultProbeAddr            = ":8082"
 	DefaultEnableLeaderElection = true
 	DefaultReconcileConcurrency = 1
+
+	DefaultGracefulShutdownTimeout = 30 * time.Second
 )
 
 func addDefaultingFuncs(scheme *runtime.Scheme) error {
@@ -32,6 +32,31 @@ func (in *Configuration) DeepCopyInto(out *Configuration) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.InjectedEnvPolicy != nil {
+		in, out := &in.InjectedEnvPolicy, &out.InjectedEnvPolicy
+		*out = new(InjectedEnvPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GeneratedPodSecurity != nil {
+		in, out := &in.GeneratedPodSecurity, &out.GeneratedPodSecurity
+		*out = new(GeneratedPodSecurity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodSpecDefaults != nil {
+		in, out := &in.PodSpecDefaults, &out.PodSpecDefaults
+		*out = new(PodSpecDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DashboardMetrics != nil {
+		in, out := &in.DashboardMetrics, &out.DashboardMetrics
+		*out = new(DashboardMetricsPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImageArchitectures != nil {
+		in, out := &in.ImageArchitectures, &out.ImageArchitectures
+		*out = new(ImageArchitecturePolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Configuration.
@@ -51,3 +76,195 @@ func (in *Configuration) DeepCopyObject() runtime.Object {
 	}
 	return nil
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardMetricsPolicy) DeepCopyInto(out *DashboardMetricsPolicy) {
+	*out = *in
+	out.DashboardMetricsSettings = in.DashboardMetricsSettings
+	if in.NamespaceOverrides != nil {
+		in, out := &in.NamespaceOverrides, &out.NamespaceOverrides
+		*out = make(map[string]DashboardMetricsSettings, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardMetricsPolicy.
+func (in *DashboardMetricsPolicy) DeepCopy() *DashboardMetricsPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardMetricsPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DashboardMetricsSettings) DeepCopyInto(out *DashboardMetricsSettings) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardMetricsSettings.
+func (in *DashboardMetricsSettings) DeepCopy() *DashboardMetricsSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(DashboardMetricsSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GeneratedPodSecurity) DeepCopyInto(out *GeneratedPodSecurity) {
+	*out = *in
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.PodSecurityContext != nil {
+		in, out := &in.PodSecurityContext, &out.PodSecurityContext
+		*out = new(v1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContainerSecurityContext != nil {
+		in, out := &in.ContainerSecurityContext, &out.ContainerSecurityContext
+		*out = new(v1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GeneratedPodSecurity.
+func (in *GeneratedPodSecurity) DeepCopy() *GeneratedPodSecurity {
+	if in == nil {
+		return nil
+	}
+	out := new(GeneratedPodSecurity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageArchitecturePolicy) DeepCopyInto(out *ImageArchitecturePolicy) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]ImageArchitectureRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageArchitecturePolicy.
+func (in *ImageArchitecturePolicy) DeepCopy() *ImageArchitecturePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageArchitecturePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageArchitectureRule) DeepCopyInto(out *ImageArchitectureRule) {
+	*out = *in
+	if in.Architectures != nil {
+		in, out := &in.Architectures, &out.Architectures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageArchitectureRule.
+func (in *ImageArchitectureRule) DeepCopy() *ImageArchitectureRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageArchitectureRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InjectedEnvPolicy) DeepCopyInto(out *InjectedEnvPolicy) {
+	*out = *in
+	in.InjectedEnvRules.DeepCopyInto(&out.InjectedEnvRules)
+	if in.NamespaceOverrides != nil {
+		in, out := &in.NamespaceOverrides, &out.NamespaceOverrides
+		*out = make(map[string]InjectedEnvRules, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InjectedEnvPolicy.
+func (in *InjectedEnvPolicy) DeepCopy() *InjectedEnvPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(InjectedEnvPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InjectedEnvRules) DeepCopyInto(out *InjectedEnvRules) {
+	*out = *in
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rename != nil {
+		in, out := &in.Rename, &out.Rename
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InjectedEnvRules.
+func (in *InjectedEnvRules) DeepCopy() *InjectedEnvRules {
+	if in == nil {
+		return nil
+	}
+	out := new(InjectedEnvRules)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSpecDefaults) DeepCopyInto(out *PodSpecDefaults) {
+	*out = *in
+	if in.EnableServiceLinks != nil {
+		in, out := &in.EnableServiceLinks, &out.EnableServiceLinks
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AutomountServiceAccountToken != nil {
+		in, out := &in.AutomountServiceAccountToken, &out.AutomountServiceAccountToken
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSpecDefaults.
+func (in *PodSpecDefaults) DeepCopy() *PodSpecDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSpecDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
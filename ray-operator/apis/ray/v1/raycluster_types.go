@@ -1,9 +1,11 @@
 package v1
 
 import (
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -30,16 +32,78 @@ type RayClusterSpec struct {
 	EnableInTreeAutoscaling *bool `json:"enableInTreeAutoscaling,omitempty"`
 	// GcsFaultToleranceOptions for enabling GCS FT
 	GcsFaultToleranceOptions *GcsFaultToleranceOptions `json:"gcsFaultToleranceOptions,omitempty"`
+	// ServiceMeshOptions configures the Ray Pods and the RayJob submitter to work with a service mesh sidecar.
+	ServiceMeshOptions *ServiceMeshOptions `json:"serviceMeshOptions,omitempty"`
+	// IdleTimeoutSeconds is how long the RayCluster can have no running Ray jobs and no alive actors before the
+	// IdleAction is taken. The activity is polled from the Ray dashboard, and the RayCluster is never considered idle
+	// while the dashboard is unreachable. It is ignored for RayClusters created by RayJob and RayService.
+	// +kubebuilder:validation:Minimum=60
+	IdleTimeoutSeconds *int32 `json:"idleTimeoutSeconds,omitempty"`
+	// IdleAction is the action taken when the RayCluster has been idle for IdleTimeoutSeconds. Defaults to Suspend.
+	// +kubebuilder:validation:Enum=Suspend;Delete
+	IdleAction *IdleAction `json:"idleAction,omitempty"`
+	// ImagePrePull pre-pulls the images of the Ray Pods on the nodes that can run them before the Pods of the
+	// RayCluster are first created, so that the startup isn't dominated by pulling large images.
+	ImagePrePull *ImagePrePullOptions `json:"imagePrePull,omitempty"`
+	// DashboardIngress exposes the Ray dashboard through an Ingress managed by KubeRay. For a RayService, the Ingress
+	// is managed by the RayService and routes to the head service of the RayService, so it follows the cluster switchover.
+	DashboardIngress *DashboardIngressOptions `json:"dashboardIngress,omitempty"`
+	// Profiling enables profiling the Ray Pods on demand. A Ray Pod is profiled by annotating it with `ray.io/profile`,
+	// and KubeRay then attaches an ephemeral container that runs the profiler to the Ray container of the Pod.
+	Profiling *ProfilingOptions `json:"profiling,omitempty"`
+	// RayStartHooks are scripts that the Ray containers of all Pods run around the `ray start` command generated by
+	// KubeRay, for node-local setup that shouldn't replace the generated command. They aren't run if the command of
+	// the Ray container is overwritten.
+	RayStartHooks *RayStartHooks `json:"rayStartHooks,omitempty"`
+	// IPFamilyPolicy is set on the Services KubeRay generates for the RayCluster, including the head, headless worker
+	// and serve Services, unless it's set in their templates. Use RequireDualStack or PreferDualStack on dual-stack
+	// Kubernetes clusters.
+	IPFamilyPolicy *corev1.IPFamilyPolicy `json:"ipFamilyPolicy,omitempty"`
+	// IPFamilies is set on the Services KubeRay generates for the RayCluster unless it's set in their templates. The
+	// first family is the primary family of the Services.
+	// +kubebuilder:validation:MaxItems=2
+	IPFamilies []corev1.IPFamily `json:"ipFamilies,omitempty"`
+	// ClusterDomain is the DNS domain of the Kubernetes cluster used in the FQDN of the head service, e.g. for the
+	// address the workers connect to. It overrides the cluster domain of the operator, which defaults to cluster.local.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	ClusterDomain *string `json:"clusterDomain,omitempty"`
+	// MetricsOptions injects a sidecar into the Ray Pods that scrapes their Ray metrics and sends them to a Prometheus
+	// remote-write endpoint, for environments without the ServiceMonitor support of the Prometheus Operator.
+	MetricsOptions *MetricsOptions `json:"metricsOptions,omitempty"`
+	// PrometheusMonitoring creates PodMonitors of the Prometheus Operator that scrape the metrics of the head and
+	// worker Pods. It's ignored if the Prometheus Operator CRDs aren't installed.
+	PrometheusMonitoring *PrometheusMonitoringOptions `json:"prometheusMonitoring,omitempty"`
+	// TmpDirPolicy stores the Ray temporary directory /tmp/ray of the head and worker Pods, which contains the spilled
+	// objects and the logs, on a dedicated volume, so that object spilling doesn't fill the root disk of the nodes.
+	TmpDirPolicy *TmpDirPolicy `json:"tmpDirPolicy,omitempty"`
+	// MetadataSync keeps some labels and annotations of the RayCluster in sync on its Pods and Services, including the
+	// existing ones, for example for chargeback or service mesh policies.
+	MetadataSync *MetadataSync `json:"metadataSync,omitempty"`
+	// DedicatedServiceAccount makes KubeRay create a ServiceAccount, a Role and a RoleBinding dedicated to the
+	// RayCluster and named after it, instead of sharing the default ServiceAccount of the namespace. The Ray Pods whose
+	// templates don't set a serviceAccountName, including the Redis cleanup Job of GCS fault tolerance, run with the
+	// ServiceAccount. The Role only grants the permissions the autoscaler needs if it's enabled, so that the RayClusters
+	// of different tenants sharing a namespace can't act on each other. The objects are deleted with the RayCluster.
+	DedicatedServiceAccount *bool `json:"dedicatedServiceAccount,omitempty"`
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
 	// HeadGroupSpecs are the spec for the head pod
 	HeadGroupSpec HeadGroupSpec `json:"headGroupSpec"`
 	// RayVersion is used to determine the command for the Kubernetes Job managed by RayJob
+	// and to check that the Ray version supports the KubeRay features in use.
 	RayVersion string `json:"rayVersion,omitempty"`
 	// WorkerGroupSpecs are the specs for the worker pods
 	WorkerGroupSpecs []WorkerGroupSpec `json:"workerGroupSpecs,omitempty"`
 }
 
+// RayStartHooks are scripts read from ConfigMaps in the namespace of the RayCluster and run by the Ray containers.
+type RayStartHooks struct {
+	// PreRayStart is a script run before `ray start`. The Ray container exits if the script fails.
+	PreRayStart *corev1.ConfigMapKeySelector `json:"preRayStart,omitempty"`
+	// PostRayStart is a script run in the background once the Raylet of the Pod is healthy. Its failures are ignored.
+	PostRayStart *corev1.ConfigMapKeySelector `json:"postRayStart,omitempty"`
+}
+
 // GcsFaultToleranceOptions contains configs for GCS FT
 type GcsFaultToleranceOptions struct {
 	RedisUsername            *RedisCredential `json:"redisUsername,omitempty"`
@@ -48,6 +112,173 @@ type GcsFaultToleranceOptions struct {
 	RedisAddress             string           `json:"redisAddress"`
 }
 
+// IdleAction is the action taken when a RayCluster has been idle for IdleTimeoutSeconds.
+type IdleAction string
+
+const (
+	// SuspendIdleAction sets `spec.suspend` to true, which deletes the Pods of the RayCluster.
+	SuspendIdleAction IdleAction = "Suspend"
+	// DeleteIdleAction deletes the RayCluster.
+	DeleteIdleAction IdleAction = "Delete"
+)
+
+// ImagePrePullOptions configures the pre-pulling of the images of the Ray Pods. KubeRay creates a short-lived DaemonSet
+// for each group with the same scheduling constraints as the Pods of the group, and doesn't create the Pods until the
+// images are pulled on every node that can run the group or the timeout expires.
+type ImagePrePullOptions struct {
+	// TimeoutSeconds is how long KubeRay waits for the images to be pulled before creating the Pods anyway.
+	// Defaults to 600.
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+	// Command is run by the init containers that pull the images, and must exit successfully in every image of the
+	// group. Defaults to `/bin/sh -c "exit 0"`, which fails on images without a shell.
+	Command []string `json:"command,omitempty"`
+}
+
+// DashboardIngressOptions configures the Ingress of the Ray dashboard.
+type DashboardIngressOptions struct {
+	// IngressClassName is the name of the IngressClass of the Ingress.
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+	// Host is the host name of the Ingress rule. If it's empty, the rule applies to all inbound HTTP traffic.
+	Host string `json:"host,omitempty"`
+	// TLSSecretName is the name of the Secret that contains the TLS certificate of the host. TLS is disabled if it's empty.
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+	// Annotations are added to the Ingress, for example the authentication annotations of the ingress controller.
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// OAuth2Proxy injects an oauth2-proxy sidecar into the head Pod, and the Ingress routes to the sidecar instead of
+	// the dashboard, so that only authenticated users can access the dashboard. The head service then exposes the
+	// sidecar instead of the dashboard, and KubeRay accesses the dashboard through the head Pod.
+	OAuth2Proxy *OAuth2ProxyOptions `json:"oauth2Proxy,omitempty"`
+}
+
+// OAuth2ProxyOptions configures the oauth2-proxy sidecar of the head Pod. KubeRay sets the HTTP address and the
+// upstream of the proxy, and the provider is configured through Args and Env.
+type OAuth2ProxyOptions struct {
+	// Image is the image of the oauth2-proxy sidecar, for example quay.io/oauth2-proxy/oauth2-proxy:v7.6.0.
+	Image string `json:"image"`
+	// Args are additional arguments of oauth2-proxy, for example the provider and the allowed email domains.
+	Args []string `json:"args,omitempty"`
+	// Env are the environment variables of the sidecar, for example the client secret and the cookie secret.
+	Env []corev1.EnvVar `json:"env,omitempty"`
+	// Resources are the compute resources of the sidecar.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// ProfilingOptions configures the ephemeral containers that profile the Ray Pods. The profiler is chosen by the value
+// of the `ray.io/profile` annotation of the Pod: `py-spy-dump`, `py-spy-record` or `memray`. The process to profile
+// and the duration can be set with the `ray.io/profile-pid` and `ray.io/profile-duration-seconds` annotations, and
+// default to 1 and 30. KubeRay records the location of the output in an event of the RayCluster.
+type ProfilingOptions struct {
+	// Image is the image of the profiling container. It should contain py-spy and memray.
+	Image string `json:"image"`
+	// OutputDir is the directory where the outputs are written. Mount a volume of the Pod, for example a PVC, at the
+	// directory with VolumeMounts to keep the outputs after the Pod is deleted. Defaults to /tmp/ray/profiles.
+	OutputDir *string `json:"outputDir,omitempty"`
+	// VolumeMounts are the volumes of the Pod mounted into the profiling container.
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+	// UploadCommand is run after the profiler with the path of the output in the PROFILE_OUTPUT environment variable,
+	// for example to upload the output to S3.
+	UploadCommand []string `json:"uploadCommand,omitempty"`
+	// Env are the environment variables of the profiling container, for example the credentials of the upload.
+	Env []corev1.EnvVar `json:"env,omitempty"`
+}
+
+// TmpDirType is the kind of volume that the Ray temporary directory is stored on.
+type TmpDirType string
+
+const (
+	// TmpDirTypeEmptyDir stores the Ray temporary directory on an emptyDir volume on the disk of the node.
+	TmpDirTypeEmptyDir TmpDirType = "EmptyDir"
+	// TmpDirTypeMemory stores the Ray temporary directory on a memory-backed emptyDir volume. The files written to it
+	// count against the memory limit of the Ray container.
+	TmpDirTypeMemory TmpDirType = "Memory"
+	// TmpDirTypeEphemeralVolume stores the Ray temporary directory on a generic ephemeral volume, i.e. a PVC created
+	// with the Pod and deleted with it, for example on a local SSD storage class.
+	TmpDirTypeEphemeralVolume TmpDirType = "EphemeralVolume"
+)
+
+// TmpDirPolicy describes the volume that the Ray temporary directory is stored on. The volume is mounted at /tmp/ray
+// in the Ray container, and in the autoscaler container that shares the logs of the head Pod, and RAY_TMPDIR is set
+// to /tmp. It isn't applied to the Pods whose Ray container already mounts a volume at /tmp/ray.
+// +kubebuilder:validation:XValidation:rule="self.type != 'EphemeralVolume' || has(self.size)",message="size is required for the EphemeralVolume type"
+type TmpDirPolicy struct {
+	// Size is the size limit of the emptyDir volume, or the requested size of the ephemeral volume. The size limit
+	// of a memory-backed volume defaults to the memory limit of the Ray container.
+	Size *resource.Quantity `json:"size,omitempty"`
+	// StorageClassName is the storage class of the ephemeral volume. Defaults to the default storage class.
+	StorageClassName *string `json:"storageClassName,omitempty"`
+	// Type is the kind of volume.
+	// +kubebuilder:validation:Enum=EmptyDir;Memory;EphemeralVolume
+	Type TmpDirType `json:"type"`
+}
+
+// MetadataSync lists the keys of the labels and the annotations of the RayCluster that KubeRay copies to the head and
+// worker Pods and to the Services of the RayCluster. When the value of one of them changes on the RayCluster, the
+// existing Pods and Services are patched rather than recreated. The keys that the RayCluster doesn't have are left
+// unchanged on the Pods and Services.
+type MetadataSync struct {
+	// Labels are the keys of the labels to sync, for example `team` or `cost-center`. The labels of KubeRay, with the
+	// `ray.io/` prefix, can't be synced.
+	Labels []string `json:"labels,omitempty"`
+	// Annotations are the keys of the annotations to sync.
+	Annotations []string `json:"annotations,omitempty"`
+}
+
+// MetricsOptions configures the metrics exporter sidecar of the Ray Pods. The sidecar runs Prometheus in agent mode,
+// which scrapes the metrics port of the Ray container and remote-writes the metrics. The metrics are labeled with the
+// namespace and the name of the RayCluster, the node type, the group and the name of the Pod, and with the CR that
+// created the RayCluster, if any.
+type MetricsOptions struct {
+	// RemoteWriteURL is the URL of the Prometheus remote-write endpoint, for example
+	// http://prometheus.monitoring:9090/api/v1/write.
+	RemoteWriteURL string `json:"remoteWriteURL"`
+	// Image is the image of the sidecar. It should contain Prometheus v2.32 or later. Defaults to prom/prometheus:v2.53.0.
+	Image *string `json:"image,omitempty"`
+	// ScrapeIntervalSeconds is how often the Ray metrics are scraped. Defaults to 15.
+	// +kubebuilder:validation:Minimum=1
+	ScrapeIntervalSeconds *int32 `json:"scrapeIntervalSeconds,omitempty"`
+	// BearerTokenSecret is the key of a Secret in the namespace of the Ray Pods that holds the bearer token sent to the
+	// remote-write endpoint.
+	BearerTokenSecret *corev1.SecretKeySelector `json:"bearerTokenSecret,omitempty"`
+	// ExternalLabels are added to all the metrics, for example the name of the Kubernetes cluster.
+	ExternalLabels map[string]string `json:"externalLabels,omitempty"`
+	// Resources are the compute resources of the sidecar.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// PrometheusMonitoringOptions configures the PodMonitors of the RayCluster. KubeRay creates a PodMonitor for the head
+// Pod and one for the worker Pods, which label the metrics with the names of the RayCluster and the group. PodMonitors
+// are used instead of ServiceMonitors because the worker Pods aren't selected by any Service. The PodMonitors are
+// owned by the RayCluster, so they are deleted with it, but they aren't deleted when PrometheusMonitoring is unset.
+type PrometheusMonitoringOptions struct {
+	// Labels are added to the PodMonitors, for example `release: prometheus` for the Prometheus to select them.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Interval is how often the metrics are scraped, for example 30s. Defaults to the scrape interval of the Prometheus.
+	// +kubebuilder:validation:Pattern=`^(0|(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?)$`
+	Interval *string `json:"interval,omitempty"`
+}
+
+// ServiceMeshType is the service mesh whose sidecar is injected into the Ray Pods.
+type ServiceMeshType string
+
+const (
+	IstioServiceMesh   ServiceMeshType = "Istio"
+	LinkerdServiceMesh ServiceMeshType = "Linkerd"
+)
+
+// ServiceMeshOptions contains configs for running Ray in a service mesh. KubeRay generates the mesh annotations
+// that make the Ray container wait for the sidecar to be ready and that exclude the Ray internal ports from the
+// traffic redirection of the sidecar. The default RayJob submitter also stops the sidecar when it completes,
+// so the submitter Kubernetes Job can finish.
+type ServiceMeshOptions struct {
+	// Type is the service mesh whose sidecar is injected into the Ray Pods. Supported values are Istio and Linkerd.
+	// +kubebuilder:validation:Enum=Istio;Linkerd
+	Type ServiceMeshType `json:"type"`
+	// ExcludedPorts are additional ports excluded from the traffic redirection of the sidecar. The GCS port and the
+	// Ray internal ports set in rayStartParams are always excluded.
+	ExcludedPorts []int32 `json:"excludedPorts,omitempty"`
+}
+
 // RedisCredential is the redis username/password or a reference to the source containing the username/password
 type RedisCredential struct {
 	ValueFrom *corev1.EnvVarSource `json:"valueFrom,omitempty"`
@@ -60,22 +291,111 @@ type HeadGroupSpec struct {
 	ServiceType corev1.ServiceType `json:"serviceType,omitempty"`
 	// HeadService is the Kubernetes service of the head pod.
 	HeadService *corev1.Service `json:"headService,omitempty"`
+	// ExistingHeadServiceName is the name of a pre-created Service in the namespace of the RayCluster, e.g. one managed
+	// by a service mesh operator, that is used as the head service instead of the one generated by KubeRay. KubeRay only
+	// validates that the Service selects the head Pod and exposes the GCS server port, and never updates or deletes it.
+	// It can't be used with HeadService or ServicePorts.
+	ExistingHeadServiceName string `json:"existingHeadServiceName,omitempty"`
 	// EnableIngress indicates whether operator should create ingress object for head service or not.
 	EnableIngress *bool `json:"enableIngress,omitempty"`
+	// ObjectStoreMemoryPercent is the percentage of the memory limit of the Ray container that is reserved for the
+	// object store. If set, `object-store-memory` is set to this percentage of the memory limit and `memory` to the
+	// rest of the limit, unless they are set in rayStartParams.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=99
+	ObjectStoreMemoryPercent *int32 `json:"objectStoreMemoryPercent,omitempty"`
 	// RayStartParams are the params of the start command: node-manager-port, object-store-memory, ...
 	RayStartParams map[string]string `json:"rayStartParams"`
 	// Template is the exact pod template used in K8s depoyments, statefulsets, etc.
 	Template corev1.PodTemplateSpec `json:"template"`
+	// EnableColdStandby pre-provisions a standby head Pod that waits without running Ray. When the head Pod fails,
+	// KubeRay promotes the standby Pod to be the new head instead of creating a new head Pod, which saves the time
+	// to schedule the Pod and pull the image. The GCS state is lost on failover unless GCS fault tolerance is enabled.
+	// It requires the RayHeadColdStandby feature gate, and every container of the head Pod must set its command.
+	EnableColdStandby *bool `json:"enableColdStandby,omitempty"`
+	// ServicePorts customizes the ports of the head service generated by KubeRay.
+	ServicePorts *HeadServicePorts `json:"servicePorts,omitempty"`
+	// StatefulSet makes KubeRay manage the head Pod with a StatefulSet of one replica instead of a bare Pod. The head
+	// Pod then keeps its name and hostname when it's replaced, and can retain its PersistentVolumeClaims. It requires
+	// the RayHeadStatefulSet feature gate, and can't be used with EnableColdStandby.
+	StatefulSet *HeadStatefulSetOptions `json:"statefulSet,omitempty"`
+	// HealthProbes customizes the timing of the probes that KubeRay generates for the Ray container of the head Pod.
+	HealthProbes *HeadHealthProbes `json:"healthProbes,omitempty"`
+}
+
+// HeadHealthProbes customizes the probes that KubeRay generates for the Ray container of the head Pod. They don't
+// apply to the probes set in the template, nor when the probe injection is disabled.
+type HeadHealthProbes struct {
+	// StartupProbe customizes the startup probe, which checks that the GCS server accepts connections with
+	// `ray health-check` and that the dashboard reports it healthy. The liveness and readiness probes only run once it
+	// succeeds, so the head Pod isn't ready before the worker Pods can join the RayCluster.
+	StartupProbe *HealthProbeThresholds `json:"startupProbe,omitempty"`
+	// ReadinessProbe customizes the readiness probe, which checks the Raylet and the GCS server through the dashboard.
+	ReadinessProbe *HealthProbeThresholds `json:"readinessProbe,omitempty"`
+}
+
+// HealthProbeThresholds customizes the timing of a probe generated by KubeRay. The unset fields keep the defaults.
+type HealthProbeThresholds struct {
+	// +kubebuilder:validation:Minimum=0
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+	// +kubebuilder:validation:Minimum=1
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+}
+
+// HeadStatefulSetOptions configures the StatefulSet of the head Pod. The restart policy of the head Pod is always
+// Always, because StatefulSets don't support other restart policies.
+type HeadStatefulSetOptions struct {
+	// VolumeClaimTemplates are the PersistentVolumeClaims created for the head Pod. The claims are mounted by
+	// adding a volume mount with the name of the template to the containers of the head Pod.
+	VolumeClaimTemplates []corev1.PersistentVolumeClaimTemplate `json:"volumeClaimTemplates,omitempty"`
+	// PersistentVolumeClaimRetentionPolicy decides whether the PersistentVolumeClaims of the head Pod are deleted
+	// when the RayCluster is deleted. Defaults to retaining them.
+	PersistentVolumeClaimRetentionPolicy *appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy `json:"persistentVolumeClaimRetentionPolicy,omitempty"`
+}
+
+// HeadServicePorts customizes the ports of the head service. By default, the head service exposes the ports of the
+// Ray container of the head Pod, or KubeRay's default ports if the container has no ports, and the metrics port.
+type HeadServicePorts struct {
+	// Exclude are the names of the ports that are removed from the head service, for example ["metrics"].
+	// The gcs-server port can't be removed because the worker Pods connect to the head Pod through it. Note that
+	// RayJob, RayService and the idle timeout of RayCluster need the dashboard port.
+	Exclude []string `json:"exclude,omitempty"`
+	// Additional are the ports added to the head service, for example custom application ports.
+	Additional []corev1.ServicePort `json:"additional,omitempty"`
+	// ContainerPorts are the named ports of the other containers of the head Pod, e.g. sidecars, that are added to
+	// the head service. The ports keep the name, number and protocol of the container ports.
+	ContainerPorts []HeadContainerServicePort `json:"containerPorts,omitempty"`
+}
+
+// HeadContainerServicePort refers to a named port of a container of the head Pod other than the Ray container.
+type HeadContainerServicePort struct {
+	// ContainerName is the name of the container of the head Pod that declares the port.
+	ContainerName string `json:"containerName"`
+	// PortName is the name of the port in the ports of the container.
+	PortName string `json:"portName"`
+	// ExposeOnServeService adds the port to the serve service too. The serve service of a RayService also selects
+	// the worker Pods that run a Serve proxy, so the port should only be served by the head Pod if that's intended.
+	ExposeOnServeService *bool `json:"exposeOnServeService,omitempty"`
 }
 
 // WorkerGroupSpec are the specs for the worker pods
 type WorkerGroupSpec struct {
 	// Suspend indicates whether a worker group should be suspended.
-	// A suspended worker group will have all pods deleted.
-	// This is not a user-facing API and is only used by RayJob DeletionPolicy.
+	// A suspended worker group will have all pods deleted, and the pods are recreated when the group is resumed.
+	// The other worker groups aren't affected. It requires the RayWorkerGroupSuspend feature gate, and is also
+	// used by RayJob DeletionPolicy. Suspending worker groups isn't supported with the autoscaler enabled.
 	Suspend *bool `json:"suspend,omitempty"`
 	// we can have multiple worker groups, we distinguish them by name
 	GroupName string `json:"groupName"`
+	// Namespace is the namespace that the Pods of the worker group are created in, e.g. a tenant namespace with its own
+	// GPU node pool. Defaults to the namespace of the RayCluster. Pods in another namespace can't be owned by the
+	// RayCluster, so they are labeled with its namespace and deleted by KubeRay before the RayCluster is deleted. It
+	// requires the RayMultiNamespaceWorkerGroups feature gate.
+	Namespace string `json:"namespace,omitempty"`
 	// Replicas is the number of desired Pods for this worker group. See https://github.com/ray-project/kuberay/pull/1443 for more details about the reason for making this field optional.
 	// +kubebuilder:default:=0
 	Replicas *int32 `json:"replicas,omitempty"`
@@ -88,6 +408,12 @@ type WorkerGroupSpec struct {
 	// IdleTimeoutSeconds denotes the number of seconds to wait before the v2 autoscaler terminates an idle worker pod of this type.
 	// This value is only used with the Ray Autoscaler enabled and defaults to the value set by the AutoscalingConfig if not specified for this worker group.
 	IdleTimeoutSeconds *int32 `json:"idleTimeoutSeconds,omitempty"`
+	// ObjectStoreMemoryPercent is the percentage of the memory limit of the Ray container that is reserved for the
+	// object store. If set, `object-store-memory` is set to this percentage of the memory limit and `memory` to the
+	// rest of the limit, unless they are set in rayStartParams.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=99
+	ObjectStoreMemoryPercent *int32 `json:"objectStoreMemoryPercent,omitempty"`
 	// RayStartParams are the params of the start command: address, object-store-memory, ...
 	RayStartParams map[string]string `json:"rayStartParams"`
 	// Template is a pod template for the worker
@@ -97,12 +423,98 @@ type WorkerGroupSpec struct {
 	// NumOfHosts denotes the number of hosts to create per replica. The default value is 1.
 	// +kubebuilder:default:=1
 	NumOfHosts int32 `json:"numOfHosts,omitempty"`
+	// EnableRankEnv assigns each worker Pod of the group a stable rank, and injects it into the Ray container as the
+	// RANK environment variable and the number of worker Pods of the group as WORLD_SIZE. The rank of a deleted Pod
+	// is reused by the Pod that replaces it. WORLD_SIZE is the number of Pods when the Pod is created.
+	EnableRankEnv *bool `json:"enableRankEnv,omitempty"`
+	// GracefulDrainSeconds is the maximum number of seconds to wait for a worker Pod of the group to be drained by Ray
+	// before the Pod is deleted to scale down the group or to suspend it. KubeRay asks Ray to drain the node of the Pod,
+	// so that no new tasks or actors are scheduled on it, and deletes the Pod once the node is drained or the timeout
+	// expires. The Pods are deleted without draining if not set or set to 0.
+	// +kubebuilder:validation:Minimum=0
+	GracefulDrainSeconds *int32 `json:"gracefulDrainSeconds,omitempty"`
+	// UpdateStrategy decides how the existing worker Pods of the group are replaced when the template or the
+	// rayStartParams of the group change. Defaults to OnDelete, which only applies the changes to new Pods.
+	UpdateStrategy *WorkerGroupUpdateStrategy `json:"updateStrategy,omitempty"`
+	// Burstable lets the Pods of the group burst onto the virtual nodes of virtual-kubelet providers, such as Azure
+	// Container Instances, when no physical node of the Kubernetes cluster fits them. The Pods are labeled with
+	// `ray.io/burstable`, and the Pods on virtual nodes are deleted first when KubeRay scales the group down.
+	Burstable *BurstableOptions `json:"burstable,omitempty"`
+	// OOMPolicy increases the memory of the new Pods of the group after the Ray containers of the group are
+	// repeatedly killed for running out of memory.
+	OOMPolicy *OOMPolicy `json:"oomPolicy,omitempty"`
+}
+
+// OOMPolicy describes how the memory of the Pods of a worker group is increased after OOM kills.
+type OOMPolicy struct {
+	// OOMKillsPerIncrease is the number of OOM kills of the Ray containers of the group after which the memory is
+	// increased. Defaults to 3.
+	// +kubebuilder:validation:Minimum=1
+	OOMKillsPerIncrease *int32 `json:"oomKillsPerIncrease,omitempty"`
+	// MaxMemory caps the increased memory request and limit of the Ray container.
+	MaxMemory *resource.Quantity `json:"maxMemory,omitempty"`
+	// MemoryIncreasePercent is the percentage by which the memory request and limit of the Ray container of the new
+	// Pods are increased, compounded for each increase.
+	// +kubebuilder:validation:Minimum=1
+	MemoryIncreasePercent int32 `json:"memoryIncreasePercent"`
+}
+
+// BurstableOptions describe the virtual nodes that the Pods of a burstable worker group can be scheduled on.
+type BurstableOptions struct {
+	// VirtualNodeSelector are the labels of the virtual nodes. The Pods prefer the nodes without these labels.
+	// Defaults to `type: virtual-kubelet`.
+	VirtualNodeSelector map[string]string `json:"virtualNodeSelector,omitempty"`
+	// Tolerations tolerate the taints of the virtual nodes, and are added to the tolerations of the Pods. Defaults to
+	// tolerating the `virtual-kubelet.io/provider` taint.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
+// WorkerGroupUpdateStrategyType is the way the outdated worker Pods of a worker group are replaced.
+type WorkerGroupUpdateStrategyType string
+
+const (
+	// RecreateWorkerGroupUpdateStrategyType deletes all the outdated worker Pods before creating new ones.
+	RecreateWorkerGroupUpdateStrategyType WorkerGroupUpdateStrategyType = "Recreate"
+	// RollingUpdateWorkerGroupUpdateStrategyType replaces the outdated worker Pods gradually, see RollingUpdateWorkerGroup.
+	RollingUpdateWorkerGroupUpdateStrategyType WorkerGroupUpdateStrategyType = "RollingUpdate"
+	// OnDeleteWorkerGroupUpdateStrategyType keeps the outdated worker Pods until they are deleted by other means.
+	OnDeleteWorkerGroupUpdateStrategyType WorkerGroupUpdateStrategyType = "OnDelete"
+)
+
+// WorkerGroupUpdateStrategy decides how the outdated worker Pods of a worker group are replaced. A worker Pod is
+// outdated if it was created from a different template or rayStartParams than the current ones of the group. Pods
+// created before KubeRay started tracking the template of the Pods are never considered outdated.
+type WorkerGroupUpdateStrategy struct {
+	// Type is the update strategy. Defaults to OnDelete.
+	// +kubebuilder:validation:Enum=Recreate;RollingUpdate;OnDelete
+	// +kubebuilder:default:=OnDelete
+	Type WorkerGroupUpdateStrategyType `json:"type,omitempty"`
+	// RollingUpdate configures the RollingUpdate strategy.
+	RollingUpdate *RollingUpdateWorkerGroup `json:"rollingUpdate,omitempty"`
+}
+
+// RollingUpdateWorkerGroup configures the rolling update of the worker Pods of a worker group.
+type RollingUpdateWorkerGroup struct {
+	// MaxUnavailable is the maximum number of worker Pods, or the percentage of the desired worker Pods, that can be
+	// unavailable during the update. A percentage is rounded down. Defaults to 25%.
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+	// MaxSurge is the maximum number of worker Pods, or the percentage of the desired worker Pods, that can be created
+	// above the desired number of worker Pods during the update. A percentage is rounded up. Defaults to 25%.
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
 }
 
 // ScaleStrategy to remove workers
 type ScaleStrategy struct {
 	// WorkersToDelete workers to be deleted
 	WorkersToDelete []string `json:"workersToDelete,omitempty"`
+	// MaxParallelDeletions is the maximum number of Pods of WorkersToDelete that are deleted at the same time. The
+	// other Pods are deleted once the deleted Pods are gone, so that the workers are removed in batches. Pods being
+	// drained count as being deleted. By default, all the Pods are deleted at once.
+	// +kubebuilder:validation:Minimum=1
+	MaxParallelDeletions *int32 `json:"maxParallelDeletions,omitempty"`
+	// RespectPodDisruptionBudgets deletes the Pods of WorkersToDelete with the Eviction API, so that the
+	// PodDisruptionBudgets selecting them are respected. A Pod whose eviction is refused is retried later.
+	RespectPodDisruptionBudgets *bool `json:"respectPodDisruptionBudgets,omitempty"`
 }
 
 // AutoscalerOptions specifies optional configuration for the Ray autoscaler.
@@ -133,6 +545,24 @@ type AutoscalerOptions struct {
 	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
 	// Optional list of volumeMounts.  This is needed for enabling TLS for the autoscaler container.
 	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+	// BalloonPods optionally creates placeholder Pods for the pending resource demands of the Ray autoscaler,
+	// so that the Kubernetes cluster autoscaler can provision nodes before the Ray worker Pods are created.
+	// It is only used when the RayClusterPendingResourceDemands feature gate is enabled.
+	BalloonPods *BalloonPodsOptions `json:"balloonPods,omitempty"`
+}
+
+// BalloonPodsOptions configures the placeholder Pods created for the pending resource demands of the Ray autoscaler.
+// A balloon Pod requests the same resources and has the same scheduling constraints as a Pod of the worker group
+// that can satisfy the demand.
+type BalloonPodsOptions struct {
+	// PriorityClassName is the priority class of the balloon Pods. It should have a lower priority than the Ray Pods,
+	// typically a negative one, so that the balloon Pods are preempted when the Ray worker Pods are scheduled.
+	PriorityClassName string `json:"priorityClassName"`
+	// Image is the container image of the balloon Pods. Defaults to registry.k8s.io/pause:3.9.
+	Image *string `json:"image,omitempty"`
+	// MaxPods is the maximum number of balloon Pods of the RayCluster. Defaults to 10.
+	// +kubebuilder:validation:Minimum=0
+	MaxPods *int32 `json:"maxPods,omitempty"`
 }
 
 // +kubebuilder:validation:Enum=Default;Aggressive;Conservative
@@ -196,6 +626,79 @@ type RayClusterStatus struct {
 	// observedGeneration is the most recent generation observed for this RayCluster. It corresponds to the
 	// RayCluster's generation, which is updated on mutation by the API Server.
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// PendingResourceDemands are the resource requests that the Ray autoscaler can't satisfy with the current nodes.
+	// It is only populated when autoscaling and the RayClusterPendingResourceDemands feature gate are enabled.
+	PendingResourceDemands []ResourceDemand `json:"pendingResourceDemands,omitempty"`
+	// LastActivityTime is the last time the RayCluster had running Ray jobs or alive actors.
+	// It is only populated when IdleTimeoutSeconds is set.
+	LastActivityTime *metav1.Time `json:"lastActivityTime,omitempty"`
+	// LastReconcileError is the error returned by the last reconciliation of the RayCluster. It is cleared once a
+	// reconciliation succeeds.
+	LastReconcileError *ReconcileError `json:"lastReconcileError,omitempty"`
+	// MemoryFailures count the Ray containers killed for running out of memory and the Ray Pods evicted, per group.
+	MemoryFailures []GroupMemoryFailures `json:"memoryFailures,omitempty"`
+	// Journal is the list of the last decisions of KubeRay about the RayCluster, from the oldest to the newest.
+	Journal []JournalEntry `json:"journal,omitempty"`
+}
+
+// GroupMemoryFailures are the OOM kills and the evictions of the Pods of a group of the RayCluster.
+type GroupMemoryFailures struct {
+	// LastOOMKilledTime is the last time a Ray container of the group was OOMKilled.
+	LastOOMKilledTime *metav1.Time `json:"lastOOMKilledTime,omitempty"`
+	// LastEvictedTime is the last time a Pod of the group was evicted.
+	LastEvictedTime *metav1.Time `json:"lastEvictedTime,omitempty"`
+	// GroupName is the name of the worker group, or `headgroup` for the head Pod.
+	GroupName string `json:"groupName"`
+	// OOMKilled is the number of times a Ray container of the group was OOMKilled.
+	OOMKilled int32 `json:"oomKilled,omitempty"`
+	// Evicted is the number of Pods of the group that were evicted.
+	Evicted int32 `json:"evicted,omitempty"`
+	// MemoryIncreases is the number of times the memory of the new Pods of the group was increased by the OOMPolicy
+	// of the worker group.
+	MemoryIncreases int32 `json:"memoryIncreases,omitempty"`
+}
+
+// ReconcileError is an error returned by the reconciliation of a custom resource.
+type ReconcileError struct {
+	// Timestamp is the last time the error was returned.
+	Timestamp metav1.Time `json:"timestamp"`
+	// Message is the error message.
+	Message string `json:"message"`
+	// Count is the number of consecutive reconciliations that returned an error with the same message. A repeated
+	// error is recorded at most once per minute, so the reconciliations in between aren't counted.
+	Count int32 `json:"count"`
+}
+
+// JournalEntry is a decision of KubeRay about a custom resource, recorded from the event that KubeRay emitted for it.
+type JournalEntry struct {
+	// Time is when the decision was made.
+	Time metav1.Time `json:"time"`
+	// Type is the type of the event, Normal or Warning.
+	Type string `json:"type"`
+	// Action is the reason of the event, for example DeletedPod.
+	Action string `json:"action"`
+	// Message explains the decision.
+	Message string `json:"message,omitempty"`
+}
+
+// ResourceUsage is the cumulative usage of the resources requested by the ready Ray Pods of a RayJob or a RayService,
+// so that chargeback systems can read it from the custom resource.
+type ResourceUsage struct {
+	// CPUHours is the number of CPU-hours requested by the ready Ray Pods, for example `12.5`.
+	CPUHours string `json:"cpuHours,omitempty"`
+	// GPUHours is the number of GPU-hours requested by the ready Ray Pods.
+	GPUHours string `json:"gpuHours,omitempty"`
+	// LastSampleTime is when the Ray Pods were last sampled. The usage between two samples is the resources requested
+	// by the ready Ray Pods at the later sample multiplied by the time between the samples.
+	LastSampleTime *metav1.Time `json:"lastSampleTime,omitempty"`
+}
+
+// ResourceDemand is a resource shape requested from the Ray cluster by tasks, actors or placement groups.
+type ResourceDemand struct {
+	// Resources are the Ray resources of the request, for example {"CPU": "1", "GPU": "1"}. Memory is in bytes.
+	Resources map[string]resource.Quantity `json:"resources,omitempty"`
+	// Count is the number of pending requests with this shape.
+	Count int32 `json:"count,omitempty"`
 }
 
 type RayClusterConditionType string
@@ -208,6 +711,17 @@ const (
 	HeadPodRunningAndReady         = "HeadPodRunningAndReady"
 	// UnknownReason says that the reason for the condition is unknown.
 	UnknownReason = "Unknown"
+	// Reasons of the RayClusterQuotaExceeded condition.
+	RayQuotaAdmitted = "RayQuotaAdmitted"
+	RayQuotaQueued   = "RayQuotaQueued"
+	RayQuotaRejected = "RayQuotaRejected"
+	// Reasons of the RayClusterImagesPrePulled condition.
+	ImagePrePullCompleted = "ImagePrePullCompleted"
+	ImagePrePullTimedOut  = "ImagePrePullTimedOut"
+	ImagePrePullSkipped   = "ImagePrePullSkipped"
+	// Reasons of the AdmissionDenied condition of RayClusters, RayJobs and RayServices.
+	AdmissionRejected     = "AdmissionRejected"
+	ResourceQuotaExceeded = "ResourceQuotaExceeded"
 )
 
 const (
@@ -222,6 +736,16 @@ const (
 	RayClusterSuspending RayClusterConditionType = "RayClusterSuspending"
 	// RayClusterSuspended is set to true when all Pods belonging to a suspending RayCluster are deleted. Note that RayClusterSuspending and RayClusterSuspended cannot both be true at the same time.
 	RayClusterSuspended RayClusterConditionType = "RayClusterSuspended"
+	// RayClusterQuotaExceeded is set to true when the RayCluster would exceed a RayQuota in its namespace. KubeRay doesn't
+	// create the Pods of the RayCluster while the condition is true. It is set to false once the RayCluster is admitted.
+	RayClusterQuotaExceeded RayClusterConditionType = "QuotaExceeded"
+	// RayClusterImagesPrePulled is set to true once the images of a RayCluster with ImagePrePull are pre-pulled, or the
+	// pre-pulling times out. KubeRay doesn't create the Pods of the RayCluster until the condition is set.
+	RayClusterImagesPrePulled RayClusterConditionType = "ImagesPrePulled"
+	// RayClusterAdmissionDenied is set to true when the server-side dry-run of a Pod or Service of the RayCluster is
+	// denied by an admission webhook, an admission policy, or a ResourceQuota. It is removed once a reconciliation
+	// succeeds.
+	RayClusterAdmissionDenied RayClusterConditionType = "AdmissionDenied"
 )
 
 // HeadInfo gives info about head
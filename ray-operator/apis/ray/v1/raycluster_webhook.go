@@ -1,8 +1,11 @@
 package v1
 
 import (
+	"fmt"
 	"regexp"
+	"strconv"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -19,6 +22,9 @@ var (
 	nameRegex, _  = regexp.Compile("^[a-z]([-a-z0-9]*[a-z0-9])?$")
 )
 
+// maxNameLength is the maximum length of a RayCluster name. The name is used as the value of the ray.io/cluster label.
+const maxNameLength = 63
+
 func (r *RayCluster) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
@@ -33,13 +39,13 @@ var _ webhook.Validator = &RayCluster{}
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *RayCluster) ValidateCreate() (admission.Warnings, error) {
 	rayclusterlog.Info("validate create", "name", r.Name)
-	return nil, r.validateRayCluster()
+	return r.memoryWarnings(), r.validateRayCluster()
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (r *RayCluster) ValidateUpdate(_ runtime.Object) (admission.Warnings, error) {
 	rayclusterlog.Info("validate update", "name", r.Name)
-	return nil, r.validateRayCluster()
+	return r.memoryWarnings(), r.validateRayCluster()
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -59,6 +65,10 @@ func (r *RayCluster) validateRayCluster() error {
 		allErrs = append(allErrs, err)
 	}
 
+	if err := CheckRayVersionCompatibility(&r.Spec); err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("rayVersion"), r.Spec.RayVersion, err.Error()))
+	}
+
 	if len(allErrs) == 0 {
 		return nil
 	}
@@ -69,6 +79,9 @@ func (r *RayCluster) validateRayCluster() error {
 }
 
 func (r *RayCluster) validateName() *field.Error {
+	if len(r.Name) > maxNameLength {
+		return field.TooLong(field.NewPath("metadata").Child("name"), r.Name, maxNameLength)
+	}
 	if !nameRegex.MatchString(r.Name) {
 		return field.Invalid(field.NewPath("metadata").Child("name"), r.Name, "name must consist of lower case alphanumeric characters or '-', start with an alphabetic character, and end with an alphanumeric character (e.g. 'my-name',  or 'abc-123', regex used for validation is '[a-z]([-a-z0-9]*[a-z0-9])?')")
 	}
@@ -87,3 +100,34 @@ func (r *RayCluster) validateWorkerGroups() *field.Error {
 
 	return nil
 }
+
+// memoryWarnings warns about the Ray Pods whose object store memory set in rayStartParams doesn't fit in the memory
+// limit of the Ray container, which is a frequent cause of OOM kills.
+func (r *RayCluster) memoryWarnings() admission.Warnings {
+	var warnings admission.Warnings
+	check := func(path *field.Path, rayStartParams map[string]string, template corev1.PodTemplateSpec) {
+		if len(template.Spec.Containers) == 0 {
+			return
+		}
+		memoryLimit := template.Spec.Containers[0].Resources.Limits[corev1.ResourceMemory]
+		objectStoreMemory, err := strconv.ParseInt(rayStartParams["object-store-memory"], 10, 64)
+		if memoryLimit.IsZero() || err != nil {
+			return
+		}
+		if objectStoreMemory >= memoryLimit.Value() {
+			warnings = append(warnings, fmt.Sprintf("%s: object-store-memory %d exceeds the memory limit %s of the Ray container, "+
+				"the Pod may be OOM killed; consider objectStoreMemoryPercent instead", path.Child("rayStartParams"), objectStoreMemory, memoryLimit.String()))
+			return
+		}
+		if memory, err := strconv.ParseInt(rayStartParams["memory"], 10, 64); err == nil && objectStoreMemory+memory > memoryLimit.Value() {
+			warnings = append(warnings, fmt.Sprintf("%s: the sum of object-store-memory %d and memory %d exceeds the memory limit %s of the Ray container, "+
+				"the Pod may be OOM killed", path.Child("rayStartParams"), objectStoreMemory, memory, memoryLimit.String()))
+		}
+	}
+
+	check(field.NewPath("spec").Child("headGroupSpec"), r.Spec.HeadGroupSpec.RayStartParams, r.Spec.HeadGroupSpec.Template)
+	for i, workerGroup := range r.Spec.WorkerGroupSpecs {
+		check(field.NewPath("spec").Child("workerGroupSpecs").Index(i), workerGroup.RayStartParams, workerGroup.Template)
+	}
+	return warnings
+}
@@ -0,0 +1,53 @@
+package v1
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateName(t *testing.T) {
+	for name, valid := range map[string]bool{
+		"raycluster-sample":     true,
+		strings.Repeat("a", 63): true,
+		strings.Repeat("a", 64): false,
+		"1-raycluster":          false,
+		"RayCluster":            false,
+	} {
+		cluster := &RayCluster{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		assert.Equal(t, valid, cluster.validateName() == nil, name)
+	}
+}
+
+func TestMemoryWarnings(t *testing.T) {
+	template := corev1.PodTemplateSpec{
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{
+			Name: "ray-worker",
+			Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+			},
+		}}},
+	}
+	cluster := &RayCluster{
+		Spec: RayClusterSpec{
+			HeadGroupSpec: HeadGroupSpec{
+				RayStartParams: map[string]string{"object-store-memory": "100000000"},
+				Template:       template,
+			},
+			WorkerGroupSpecs: []WorkerGroupSpec{
+				{RayStartParams: map[string]string{"object-store-memory": "2000000000"}, Template: template},
+				{RayStartParams: map[string]string{"object-store-memory": "600000000", "memory": "600000000"}, Template: template},
+				{RayStartParams: map[string]string{}, Template: template},
+			},
+		},
+	}
+
+	warnings := cluster.memoryWarnings()
+	assert.Len(t, warnings, 2)
+	assert.Contains(t, warnings[0], "spec.workerGroupSpecs[0].rayStartParams: object-store-memory 2000000000 exceeds the memory limit 1Gi")
+	assert.Contains(t, warnings[1], "spec.workerGroupSpecs[1].rayStartParams: the sum of object-store-memory 600000000 and memory 600000000 exceeds the memory limit 1Gi")
+}
@@ -60,6 +60,9 @@ const (
 	K8sJobMode      JobSubmissionMode = "K8sJobMode"      // Submit job via Kubernetes Job
 	HTTPMode        JobSubmissionMode = "HTTPMode"        // Submit job via HTTP request
 	InteractiveMode JobSubmissionMode = "InteractiveMode" // Don't submit job in KubeRay. Instead, wait for user to submit job and provide the job submission ID.
+	// Don't submit any job in KubeRay. The RayCluster is kept for the user to connect to until the RayJob is deleted,
+	// suspended, passes the activeDeadlineSeconds, or is idle for longer than the idle timeout.
+	InteractiveSessionMode JobSubmissionMode = "InteractiveSession"
 )
 
 type DeletionPolicy string
@@ -74,6 +77,43 @@ const (
 type SubmitterConfig struct {
 	// BackoffLimit of the submitter k8s job.
 	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+	// ReuseSubmitterPod makes KubeRay submit the Ray job through the Ray dashboard HTTP API, after checking that
+	// the job isn't submitted yet, instead of creating a submitter Kubernetes Job for each RayJob. This avoids the
+	// startup of a submitter Pod for high-frequency short jobs. The submitter Pod template isn't used and the logs
+	// of the Ray job aren't streamed by a submitter. It is only supported in K8sJobMode.
+	ReuseSubmitterPod *bool `json:"reuseSubmitterPod,omitempty"`
+}
+
+// InteractiveSessionOptions configures the RayJobs in the InteractiveSession mode.
+type InteractiveSessionOptions struct {
+	// IdleTimeoutSeconds is the duration in seconds the session may have no running Ray job before the RayJob
+	// is completed. The session is idle from the time it starts, or the time its last Ray job ends.
+	// If unset, the session is never completed for being idle.
+	IdleTimeoutSeconds *int32 `json:"idleTimeoutSeconds,omitempty"`
+}
+
+// CheckpointResumePolicy decides whether the retries of a RayJob resume from the checkpoints of its Ray job.
+type CheckpointResumePolicy string
+
+const (
+	// ResumeFromLatestCheckpoint resumes the retries of the RayJob from the latest checkpoint under the URI.
+	ResumeFromLatestCheckpoint CheckpointResumePolicy = "Latest"
+	// NeverResumeFromCheckpoint starts the retries of the RayJob over, even if there are checkpoints under the URI.
+	NeverResumeFromCheckpoint CheckpointResumePolicy = "Never"
+)
+
+// CheckpointingOptions configures the checkpoints of the Ray job of a RayJob.
+type CheckpointingOptions struct {
+	// URI is the storage location the Ray job writes its checkpoints to, e.g. `s3://bucket/checkpoints/my-job`.
+	// It's passed to the entrypoint in the RAY_JOB_CHECKPOINT_URI environment variable.
+	// +kubebuilder:validation:MinLength=1
+	URI string `json:"uri"`
+	// ResumePolicy decides whether the retries of the RayJob resume from the checkpoints under the URI. The
+	// entrypoint of a retry that resumes gets the location of the checkpoints in the
+	// RAY_JOB_RESUME_FROM_CHECKPOINT_URI environment variable. Defaults to Latest.
+	// +kubebuilder:validation:Enum=Latest;Never
+	// +optional
+	ResumePolicy CheckpointResumePolicy `json:"resumePolicy,omitempty"`
 }
 
 // RayJobSpec defines the desired state of RayJob
@@ -88,7 +128,12 @@ type RayJobSpec struct {
 	// RayClusterSpec is the cluster template to run the job
 	RayClusterSpec *RayClusterSpec `json:"rayClusterSpec,omitempty"`
 	// SubmitterPodTemplate is the template for the pod that will run `ray job submit`.
+	// The container named `ray-job-submitter` runs the submission, or the first container if there is none, and
+	// the other containers are sidecars. The image of the submitter container defaults to the image of the Ray head.
 	SubmitterPodTemplate *corev1.PodTemplateSpec `json:"submitterPodTemplate,omitempty"`
+	// ImagePullSecrets are added to the head and worker Pods of the RayCluster created for the RayJob, and to the
+	// submitter Pods, so that they don't need to be set in every Pod template.
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
 	// Metadata is data to store along with this job.
 	Metadata map[string]string `json:"metadata,omitempty"`
 	// clusterSelector is used to select running rayclusters by labels
@@ -122,8 +167,16 @@ type RayJobSpec struct {
 	// In "K8sJobMode", the KubeRay operator creates a submitter Kubernetes Job to submit the Ray job.
 	// In "HTTPMode", the KubeRay operator sends a request to the RayCluster to create a Ray job.
 	// In "InteractiveMode", the KubeRay operator waits for a user to submit a job to the Ray cluster.
+	// In "InteractiveSession", the KubeRay operator never submits a job. It provisions the RayCluster, exposes
+	// how to connect to it in the status, and completes the RayJob once the session is idle, or fails it once it
+	// passes the activeDeadlineSeconds, so that the RayCluster is cleaned up like the one of a finished job.
 	// +kubebuilder:default:=K8sJobMode
 	SubmissionMode JobSubmissionMode `json:"submissionMode,omitempty"`
+	// InteractiveSession configures the session of a RayJob in the InteractiveSession mode.
+	InteractiveSession *InteractiveSessionOptions `json:"interactiveSession,omitempty"`
+	// Checkpointing configures the checkpoints of the Ray job, so that its retries can resume from them.
+	// A RayJob with checkpointing enabled also doesn't resubmit a Ray job that has already succeeded.
+	Checkpointing *CheckpointingOptions `json:"checkpointing,omitempty"`
 	// EntrypointResources specifies the custom resources and quantities to reserve for the
 	// entrypoint command.
 	EntrypointResources string `json:"entrypointResources,omitempty"`
@@ -145,6 +198,14 @@ type RayJobSpec struct {
 	Suspend bool `json:"suspend,omitempty"`
 }
 
+// InteractiveSessionStatus is the status of the session of a RayJob in the InteractiveSession mode.
+type InteractiveSessionStatus struct {
+	// ClientURL is the Ray Client address to connect to the RayCluster, e.g. `ray://<head service>:10001`.
+	ClientURL string `json:"clientURL,omitempty"`
+	// LastActivityTime is the time the session started or its last Ray job ended, whichever is later.
+	LastActivityTime *metav1.Time `json:"lastActivityTime,omitempty"`
+}
+
 // RayJobStatus defines the observed state of RayJob
 type RayJobStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
@@ -170,12 +231,41 @@ type RayJobStatus struct {
 	Failed *int32 `json:"failed,omitempty"`
 	// RayClusterStatus is the status of the RayCluster running the job.
 	RayClusterStatus RayClusterStatus `json:"rayClusterStatus,omitempty"`
+	// ResumedFromCheckpointURI is the location of the checkpoints the current attempt of the Ray job resumes from.
+	ResumedFromCheckpointURI string `json:"resumedFromCheckpointURI,omitempty"`
+	// Session is the status of the session of a RayJob in the InteractiveSession mode.
+	Session *InteractiveSessionStatus `json:"session,omitempty"`
+	// ResourceUsage is the usage of the resources of the RayCluster of the RayJob. It's only set with the
+	// RayResourceUsageAccounting feature gate, and not for the RayJobs with a clusterSelector.
+	ResourceUsage *ResourceUsage `json:"resourceUsage,omitempty"`
 
 	// observedGeneration is the most recent generation observed for this RayJob. It corresponds to the
 	// RayJob's generation, which is updated on mutation by the API Server.
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// LastReconcileError is the error returned by the last reconciliation of the RayJob. It is cleared once a
+	// reconciliation succeeds.
+	LastReconcileError *ReconcileError `json:"lastReconcileError,omitempty"`
+	// Journal is the list of the last decisions of KubeRay about the RayJob, from the oldest to the newest.
+	Journal []JournalEntry `json:"journal,omitempty"`
+	// Represents the latest available observations of a RayJob's current state.
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
+type RayJobConditionType string
+
+const (
+	// RayJobCleanupSucceeded is set while the RayJob is being deleted. It is false while the objects created for the
+	// RayJob are cleaned up, and true once they are gone.
+	RayJobCleanupSucceeded RayJobConditionType = "CleanupSucceeded"
+	// RayJobAdmissionDenied is set to true when the server-side dry-run of the RayCluster of the RayJob is denied. It is
+	// removed once a reconciliation succeeds.
+	RayJobAdmissionDenied RayJobConditionType = "AdmissionDenied"
+)
+
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:categories=all
 // +kubebuilder:subresource:status
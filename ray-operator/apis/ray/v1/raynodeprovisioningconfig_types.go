@@ -0,0 +1,54 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RayNodeProvisioningConfigSpec defines the desired state of RayNodeProvisioningConfig
+type RayNodeProvisioningConfigSpec struct {
+	// Accelerators map the accelerator resources requested by Ray Pods to the scheduling settings that the Pods
+	// need to run on the nodes providing the accelerators.
+	Accelerators []AcceleratorProvisioningRule `json:"accelerators"`
+}
+
+// AcceleratorProvisioningRule defines the scheduling settings of the Ray Pods requesting an accelerator resource.
+type AcceleratorProvisioningRule struct {
+	// ResourceName is the name of the accelerator resource, for example nvidia.com/gpu or google.com/tpu. The rule
+	// applies to the Ray Pods with a container requesting or limiting a non-zero quantity of the resource.
+	ResourceName corev1.ResourceName `json:"resourceName"`
+	// Tolerations are added to the Pod unless the Pod already has the same tolerations.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// NodeSelector is merged into the node selector of the Pod. The keys set by the Pod take precedence.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// RuntimeClassName is set on the Pod if the Pod doesn't set a runtime class.
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+}
+
+// RayNodeProvisioningConfig defines the tolerations, node selector and runtime class that the KubeRay operator
+// adds to every Ray Pod requesting an accelerator resource, so that users don't need to set them in each RayCluster.
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories=all,scope=Cluster
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="age",type="date",JSONPath=".metadata.creationTimestamp",priority=0
+// +genclient
+// +genclient:nonNamespaced
+type RayNodeProvisioningConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec RayNodeProvisioningConfigSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RayNodeProvisioningConfigList contains a list of RayNodeProvisioningConfig
+type RayNodeProvisioningConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RayNodeProvisioningConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RayNodeProvisioningConfig{}, &RayNodeProvisioningConfigList{})
+}
@@ -0,0 +1,54 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RayPlacementPolicySpec defines the desired state of RayPlacementPolicy
+type RayPlacementPolicySpec struct {
+	// NamespaceSelector selects the namespaces of the Ray Pods that the policy applies to, by the labels of the
+	// namespaces. The policy applies to the Ray Pods of all the namespaces if it's not set.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// Selector selects the Ray Pods that the policy applies to, by the labels of the Pods and of their RayClusters.
+	// The labels of the Pods take precedence. For example, `ray.io/originated-from-crd: RayService` selects the Pods
+	// of the RayServices. The policy applies to all the Ray Pods of the selected namespaces if it's not set.
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// NodeSelector is merged into the node selector of the Pod. The keys set by the policy take precedence, so that
+	// the users can't move their Pods out of the node pools of the policy.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Tolerations are added to the Pod unless the Pod already has the same tolerations.
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// Affinity is appended to the affinity of the Pod. The required node selector terms of the policy are combined
+	// with the ones of the Pod, so that the Pod is only scheduled on the nodes matching both. The other terms are
+	// appended to the ones of the Pod.
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+}
+
+// RayPlacementPolicy defines the node selector, tolerations and affinity that the KubeRay operator adds to the Ray
+// Pods matching its selectors, so that platform admins control which node pools the Ray workloads can use.
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories=all,scope=Cluster
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="age",type="date",JSONPath=".metadata.creationTimestamp",priority=0
+// +genclient
+// +genclient:nonNamespaced
+type RayPlacementPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec RayPlacementPolicySpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RayPlacementPolicyList contains a list of RayPlacementPolicy
+type RayPlacementPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RayPlacementPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RayPlacementPolicy{}, &RayPlacementPolicyList{})
+}
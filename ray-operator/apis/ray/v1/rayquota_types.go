@@ -0,0 +1,68 @@
+package v1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RayQuotaPolicy decides what happens to a RayCluster that would exceed a RayQuota.
+type RayQuotaPolicy string
+
+const (
+	// QueueRayQuotaPolicy holds the RayCluster without Pods until the quota has enough capacity for it.
+	QueueRayQuotaPolicy RayQuotaPolicy = "Queue"
+	// RejectRayQuotaPolicy never creates the Pods of the RayCluster unless its spec is updated to fit the quota.
+	RejectRayQuotaPolicy RayQuotaPolicy = "Reject"
+)
+
+// RayQuotaSpec defines the desired state of RayQuota
+type RayQuotaSpec struct {
+	// Hard is the maximum total resources of the RayClusters in the namespace, for example
+	// {"cpu": "100", "nvidia.com/gpu": "8"}. Unlike ResourceQuota, the resources of a RayCluster are counted with
+	// the maxReplicas of each worker group, so that an admitted RayCluster can always scale up. Worker groups without
+	// maxReplicas are counted with minReplicas. RayClusters created by RayJob and RayService are counted as well.
+	Hard corev1.ResourceList `json:"hard"`
+	// Policy decides what happens to a RayCluster that would exceed the quota. Defaults to Queue.
+	// +kubebuilder:validation:Enum=Queue;Reject
+	Policy *RayQuotaPolicy `json:"policy,omitempty"`
+}
+
+// RayQuotaStatus defines the observed state of RayQuota
+type RayQuotaStatus struct {
+	// Used is the total resources of the RayClusters admitted by the quota.
+	Used corev1.ResourceList `json:"used,omitempty"`
+	// AdmittedRayClusters is the number of RayClusters admitted by the quota.
+	AdmittedRayClusters int32 `json:"admittedRayClusters,omitempty"`
+	// QueuedRayClusters is the number of RayClusters waiting for the quota to have enough capacity.
+	QueuedRayClusters int32 `json:"queuedRayClusters,omitempty"`
+}
+
+// RayQuota limits the total resources of the RayClusters in a namespace.
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:categories=all
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="admitted",type=integer,JSONPath=".status.admittedRayClusters",priority=0
+// +kubebuilder:printcolumn:name="queued",type=integer,JSONPath=".status.queuedRayClusters",priority=0
+// +kubebuilder:printcolumn:name="age",type="date",JSONPath=".metadata.creationTimestamp",priority=0
+// +genclient
+type RayQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RayQuotaSpec   `json:"spec,omitempty"`
+	Status RayQuotaStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// RayQuotaList contains a list of RayQuota
+type RayQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RayQuota `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&RayQuota{}, &RayQuotaList{})
+}
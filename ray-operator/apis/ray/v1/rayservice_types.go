@@ -25,6 +25,102 @@ const (
 	None RayServiceUpgradeType = "None"
 )
 
+// RayServicePromotionType decides when a ready pending RayCluster is promoted to the active RayCluster.
+type RayServicePromotionType string
+
+const (
+	// During upgrade, the pending RayCluster is promoted as soon as it is ready.
+	AutomaticPromotion RayServicePromotionType = "Automatic"
+	// During upgrade, the ready pending RayCluster is only promoted after the RayService is annotated with
+	// `ray.io/promote: "true"`.
+	ManualPromotion RayServicePromotionType = "Manual"
+)
+
+// KubeRayVersionPolicy decides how the active RayCluster of a RayService is updated after an upgrade of KubeRay.
+type KubeRayVersionPolicy string
+
+const (
+	// AutoKubeRayVersionPolicy updates the active RayCluster as soon as the version of KubeRay changes.
+	AutoKubeRayVersionPolicy KubeRayVersionPolicy = "Auto"
+	// ManualKubeRayVersionPolicy defers the update of the active RayCluster until the RayService is annotated with
+	// `ray.io/update-kuberay-version: "true"`, for example in a maintenance window.
+	ManualKubeRayVersionPolicy KubeRayVersionPolicy = "Manual"
+)
+
+// ServiceReconcileMode decides how KubeRay handles modifications of the Services it generates for a RayService
+// that were made outside of KubeRay.
+type ServiceReconcileMode string
+
+const (
+	// EnforceServiceReconcileMode reverts the modifications of the selector and ports of the head and serve Services.
+	EnforceServiceReconcileMode ServiceReconcileMode = "Enforce"
+	// ObserveServiceReconcileMode keeps the modifications and reports them with the ServiceDriftDetected condition.
+	ObserveServiceReconcileMode ServiceReconcileMode = "Observe"
+)
+
+type RayServiceConditionType string
+
+const (
+	// ServiceDriftDetected is set to true when the selector or ports of the head or serve Service of the RayService
+	// were modified outside of KubeRay and the modifications are kept because the ServiceReconcileMode is Observe.
+	ServiceDriftDetected RayServiceConditionType = "ServiceDriftDetected"
+	// CleanupSucceeded is set while the RayService is being deleted. It is false while the objects created for the
+	// RayService are cleaned up, and true once they are gone.
+	CleanupSucceeded RayServiceConditionType = "CleanupSucceeded"
+	// RayServiceReady is true when the Serve applications of the active RayCluster are running and meet the SLOs of
+	// serveAlerting.
+	RayServiceReady RayServiceConditionType = "Ready"
+	// RayServiceAdmissionDenied is set to true when the server-side dry-run of a RayCluster or Service of the RayService
+	// is denied. It is removed once a reconciliation succeeds.
+	RayServiceAdmissionDenied RayServiceConditionType = "AdmissionDenied"
+	// KubeRayVersionUpdateDeferred is set to true while the update of the active RayCluster to the version of KubeRay
+	// waits for an approval because the KubeRayVersionPolicy is Manual. It is removed once the versions match.
+	KubeRayVersionUpdateDeferred RayServiceConditionType = "KubeRayVersionUpdateDeferred"
+	// RayServiceFailedOver is set when the failoverPolicy is set. It is true once the Ready condition stayed false for
+	// longer than the unhealthyThresholdSeconds of the policy, and false again once the RayService is ready.
+	RayServiceFailedOver RayServiceConditionType = "FailedOver"
+	// RayServiceMaintenanceActive is set to true while maintenanceMode is set and the serve service points at the
+	// maintenance responder. It is removed once maintenanceMode is unset.
+	RayServiceMaintenanceActive RayServiceConditionType = "MaintenanceActive"
+)
+
+// Custom Reason for RayServiceCondition
+const (
+	ServicesModified        = "ServicesModified"
+	ServicesMatchRayService = "ServicesMatchRayService"
+)
+
+// Reasons of the Ready condition of RayServices
+const (
+	ServeApplicationsRunning    = "ServeApplicationsRunning"
+	ServeApplicationsNotReady   = "ServeApplicationsNotReady"
+	ServeApplicationSLOViolated = "ServeApplicationSLOViolated"
+	InMaintenance               = "InMaintenance"
+)
+
+// Reasons of the MaintenanceActive condition of RayServices
+const (
+	MaintenanceModeEnabled = "MaintenanceModeEnabled"
+)
+
+// Reasons of the FailedOver condition of RayServices
+const (
+	UnhealthyThresholdExceeded    = "UnhealthyThresholdExceeded"
+	UnhealthyThresholdNotExceeded = "UnhealthyThresholdNotExceeded"
+)
+
+// Reasons of the KubeRayVersionUpdateDeferred condition of RayServices
+const (
+	KubeRayVersionMismatch = "KubeRayVersionMismatch"
+)
+
+// Reasons of the CleanupSucceeded condition of RayServices and RayJobs
+const (
+	CleanupInProgress = "CleanupInProgress"
+	CleanupCompleted  = "CleanupCompleted"
+	CleanupTimedOut   = "CleanupTimedOut"
+)
+
 // These statuses should match Ray Serve's application statuses
 // See `enum ApplicationStatus` in https://sourcegraph.com/github.com/ray-project/ray/-/blob/src/ray/protobuf/serve.proto for more details.
 var ApplicationStatusEnum = struct {
@@ -57,6 +153,249 @@ var DeploymentStatusEnum = struct {
 type RayServiceUpgradeStrategy struct {
 	// Type represents the strategy used when upgrading the RayService. Currently supports `NewCluster` and `None`.
 	Type *RayServiceUpgradeType `json:"type,omitempty"`
+	// EnablePreviewService creates the `<name>-preview-serve-svc` Service that points at the pending RayCluster
+	// during an upgrade, so that the new version can be smoke-tested with real requests before the traffic switches
+	// over. The Service is deleted once the pending RayCluster is promoted or the upgrade is rolled back.
+	EnablePreviewService *bool `json:"enablePreviewService,omitempty"`
+	// Promotion decides when the pending RayCluster is promoted once it is ready. With `Manual`, the traffic only switches
+	// over after the RayService is annotated with `ray.io/promote: "true"`, and the upgrade can be aborted with
+	// `ray.io/abort: "true"`, which deletes the pending RayCluster. Defaults to `Automatic`.
+	// +kubebuilder:validation:Enum=Automatic;Manual
+	Promotion *RayServicePromotionType `json:"promotion,omitempty"`
+	// IgnoredPaths are JSON pointers to fields of rayClusterConfig whose changes don't trigger an upgrade, for example
+	// the fields that admission webhooks add to the RayCluster. A `*` segment matches any element of a list or any key
+	// of a map, for example `/workerGroupSpecs/*/template/metadata/annotations/sidecar.istio.io~1status`. Changing
+	// the list triggers an upgrade.
+	IgnoredPaths []string `json:"ignoredPaths,omitempty"`
+	// ApplicationScoped upgrades the Serve applications of serveConfigV2 independently: KubeRay tracks the changes of
+	// each entry of `applications`, reports the upgrade of each application in its `upgradeStatus`, and keeps the
+	// RayService ready while only the applications being upgraded aren't running, so that the other applications keep
+	// serving. Ray Serve only redeploys the applications whose entry changed.
+	ApplicationScoped *bool `json:"applicationScoped,omitempty"`
+	// KubeRayVersionPolicy decides whether the active RayCluster is updated as soon as the version of KubeRay changes
+	// (`Auto`), or only after the RayService is annotated with `ray.io/update-kuberay-version: "true"` (`Manual`), so
+	// that the updates caused by upgrades of KubeRay can be deferred to a maintenance window. The changes of the spec of
+	// the RayService are deferred as well while the update waits. Defaults to `Auto`.
+	// +kubebuilder:validation:Enum=Auto;Manual
+	KubeRayVersionPolicy *KubeRayVersionPolicy `json:"kubeRayVersionPolicy,omitempty"`
+	// RequestShadowing mirrors a percentage of the live requests to the pending RayCluster once it is ready, and only
+	// promotes it after its error rate stayed close to the one of the active RayCluster.
+	RequestShadowing *RequestShadowing `json:"requestShadowing,omitempty"`
+}
+
+// RequestShadowing mirrors the requests sent to the active RayCluster to the pending RayCluster during an upgrade with a
+// Gateway API HTTPRoute. The HTTPRoute routes the traffic of the Gateway to the serve service, and KubeRay adds a
+// RequestMirror filter to the preview serve service while the pending RayCluster is shadowed. The responses of the
+// pending RayCluster are discarded. Shadowing is skipped if the Gateway API CRDs aren't installed.
+type RequestShadowing struct {
+	// GatewayName is the name of the Gateway in the namespace of the RayService that the HTTPRoute is attached to. It
+	// must match the gatewayName of the Cookie session affinity if both are set.
+	GatewayName string `json:"gatewayName"`
+	// Percent is the percentage of the requests mirrored to the pending RayCluster. Defaults to 10.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	Percent *int32 `json:"percent,omitempty"`
+	// DurationSeconds is how long the pending RayCluster is shadowed before it can be promoted. Defaults to 300.
+	// +kubebuilder:validation:Minimum=0
+	DurationSeconds *int32 `json:"durationSeconds,omitempty"`
+	// MaxErrorRateIncreasePercent is by how many percentage points the error rate of the pending RayCluster may exceed
+	// the one of the active RayCluster for the pending RayCluster to be promoted. Defaults to 1.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	MaxErrorRateIncreasePercent *int32 `json:"maxErrorRateIncreasePercent,omitempty"`
+}
+
+// ServeSessionAffinityType is the kind of session affinity of the serve service.
+type ServeSessionAffinityType string
+
+const (
+	// ClientIPServeSessionAffinity sends the requests of a client IP to the same Ray Serve proxy.
+	ClientIPServeSessionAffinity ServeSessionAffinityType = "ClientIP"
+	// CookieServeSessionAffinity sends the requests with the same session cookie to the same Ray Serve proxy.
+	CookieServeSessionAffinity ServeSessionAffinityType = "Cookie"
+)
+
+// ServeSessionAffinity makes the requests of a session stick to the same Ray Serve proxy, which is needed by stateful
+// Serve deployments such as the ones keeping a per-session KV cache.
+type ServeSessionAffinity struct {
+	// Type is the kind of session affinity. ClientIP sets the session affinity of the serve service. Cookie creates a
+	// Gateway API HTTPRoute with cookie based session persistence in front of the serve service.
+	// +kubebuilder:validation:Enum=ClientIP;Cookie
+	Type ServeSessionAffinityType `json:"type"`
+	// TimeoutSeconds is the maximum duration of a session. Defaults to 10800 (3 hours).
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+	// CookieName is the name of the session cookie. It is only used by the Cookie type. Defaults to "ray-serve-session".
+	CookieName *string `json:"cookieName,omitempty"`
+	// GatewayName is the name of the Gateway in the namespace of the RayService that the HTTPRoute is attached to.
+	// It is required by the Cookie type, and the Gateway implementation must support session persistence.
+	GatewayName string `json:"gatewayName,omitempty"`
+}
+
+// ServeTopologyRoutingMode is how the serve service prefers the serve Pods in the zone of the clients.
+type ServeTopologyRoutingMode string
+
+const (
+	// AutoServeTopologyRouting sets the `service.kubernetes.io/topology-mode: Auto` annotation of the serve service, so
+	// that Kubernetes adds the zone hints to its EndpointSlices once the serve Pods are spread enough across the zones.
+	AutoServeTopologyRouting ServeTopologyRoutingMode = "Auto"
+	// ManagedServeTopologyRouting removes the selector of the serve service, and KubeRay manages its EndpointSlice, in
+	// which every serve Pod is hinted to its own zone. The requests stay in their zone as long as it has a ready serve
+	// Pod.
+	ManagedServeTopologyRouting ServeTopologyRoutingMode = "Managed"
+)
+
+// ServeTopologyRouting makes the serve service prefer the serve Pods in the zone of the clients, to cut the cross-zone
+// data transfer of the high-throughput inference.
+type ServeTopologyRouting struct {
+	// Mode is Auto or Managed. Defaults to Auto.
+	// +kubebuilder:validation:Enum=Auto;Managed
+	Mode *ServeTopologyRoutingMode `json:"mode,omitempty"`
+}
+
+// ServeProbe is a synthetic request sent to the Ray Serve proxy of the pending RayCluster. The RUNNING status of the
+// Serve applications doesn't guarantee that requests succeed end to end, so the pending RayCluster is only considered
+// ready once the probe succeeds as well.
+type ServeProbe struct {
+	// Path is the HTTP path of the request, for example `/my-app/healthz`.
+	// +kubebuilder:validation:Pattern=`^/`
+	Path string `json:"path"`
+	// Method is the HTTP method of the request. Defaults to GET, or POST if Body is set.
+	// +kubebuilder:validation:Enum=GET;POST;PUT
+	Method *string `json:"method,omitempty"`
+	// Body is the body of the request.
+	Body *string `json:"body,omitempty"`
+	// Headers are the HTTP headers of the request, for example `Content-Type`.
+	Headers map[string]string `json:"headers,omitempty"`
+	// ExpectedStatusCode is the status code of a successful response. Defaults to 200.
+	// +kubebuilder:validation:Minimum=100
+	// +kubebuilder:validation:Maximum=599
+	ExpectedStatusCode *int32 `json:"expectedStatusCode,omitempty"`
+	// LatencyThresholdMilliseconds is the maximum latency of a successful response. Defaults to 1000.
+	// +kubebuilder:validation:Minimum=1
+	LatencyThresholdMilliseconds *int32 `json:"latencyThresholdMilliseconds,omitempty"`
+}
+
+// ServeReadinessCheckType is the check that decides whether the Serve applications of a RayCluster are ready to serve
+// the traffic.
+type ServeReadinessCheckType string
+
+const (
+	// DashboardServeReadinessCheck considers the Serve applications ready when they're all RUNNING in the Ray dashboard.
+	DashboardServeReadinessCheck ServeReadinessCheckType = "Dashboard"
+	// ProbeServeReadinessCheck additionally requires the serveProbe to succeed on the pending RayCluster.
+	ProbeServeReadinessCheck ServeReadinessCheckType = "Probe"
+	// MetricsServeReadinessCheck additionally requires a metric exported by the Ray Pods of the pending RayCluster to
+	// reach a minimum.
+	MetricsServeReadinessCheck ServeReadinessCheckType = "Metrics"
+)
+
+// ServeReadiness selects the check that decides whether the Serve applications of a RayCluster are ready. The Serve
+// applications must be RUNNING in the Ray dashboard whatever the check, so the other checks only add conditions, for
+// example that the models were warmed up or loaded into the GPUs.
+type ServeReadiness struct {
+	// Type is Dashboard, Probe, Metrics, or the name of a checker registered in the operator. Defaults to Probe if
+	// serveProbe is set, and to Dashboard otherwise.
+	Type ServeReadinessCheckType `json:"type,omitempty"`
+	// Metric is the metric checked by the Metrics type.
+	Metric *ServeReadinessMetric `json:"metric,omitempty"`
+}
+
+// ServeReadinessMetric is a Prometheus metric exported on the metrics port of the Ray containers, for example a gauge
+// set by the Serve replicas once their model is loaded.
+type ServeReadinessMetric struct {
+	// Name is the name of the metric, for example `ray_model_loaded`.
+	Name string `json:"name"`
+	// MinValue is the minimum of the sum of the samples of the metric over the running and ready Ray Pods of the
+	// RayCluster. Defaults to 1.
+	// +kubebuilder:validation:Minimum=0
+	MinValue *int32 `json:"minValue,omitempty"`
+}
+
+// ServeProxyHealthCheck customizes the health check of the Ray Serve proxies, which sets the `ray.io/serve` label of
+// the Pods. The fields that aren't set default to the ones of the operator configuration.
+type ServeProxyHealthCheck struct {
+	// Path is the HTTP path of the health check. Defaults to `/-/healthz`.
+	// +kubebuilder:validation:Pattern=`^/`
+	Path *string `json:"path,omitempty"`
+	// TimeoutSeconds is the timeout of each attempt. Defaults to 2.
+	// +kubebuilder:validation:Minimum=1
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+	// Retries is the number of attempts after a failed one before the proxy is considered unhealthy. Defaults to 0.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	Retries *int32 `json:"retries,omitempty"`
+	// ExpectedStatusCodes are the status codes of a healthy response. Defaults to 200.
+	ExpectedStatusCodes []int32 `json:"expectedStatusCodes,omitempty"`
+}
+
+// ServeApplicationSLO declares the thresholds that a Serve application is expected to meet.
+type ServeApplicationSLO struct {
+	// Name is the name of the Serve application in serveConfigV2.
+	Name string `json:"name"`
+	// MaxUnhealthySeconds is how long the application may stay UNHEALTHY or DEPLOY_FAILED before the SLO is violated.
+	// +kubebuilder:validation:Minimum=0
+	MaxUnhealthySeconds *int32 `json:"maxUnhealthySeconds,omitempty"`
+	// MinReplicas is the minimum number of RUNNING replicas of the application, summed over its deployments.
+	// +kubebuilder:validation:Minimum=0
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+}
+
+// ServeAlertingOptions configures the alerting of the Serve applications of a RayService. A violated SLO sets the
+// Ready condition of the RayService to false, and KubeRay creates a PrometheusRule with the matching alerts if the
+// Prometheus Operator CRDs are installed.
+type ServeAlertingOptions struct {
+	// Applications are the SLOs of the Serve applications.
+	// +listType=map
+	// +listMapKey=name
+	Applications []ServeApplicationSLO `json:"applications"`
+	// Labels are added to the PrometheusRule, so that it's selected by the ruleSelector of the Prometheus.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// FailoverPolicy notifies the systems orchestrating an active/passive deployment over several regions when the
+// RayService stays unhealthy, so that they can fail the traffic over to another region, and when it recovers. KubeRay
+// emits an event, calls the webhook and updates the ExternalDNS annotation of the serve service on both transitions.
+type FailoverPolicy struct {
+	// UnhealthyThresholdSeconds is how long the Ready condition must stay false before the RayService fails over.
+	// Defaults to 60.
+	// +kubebuilder:validation:Minimum=0
+	UnhealthyThresholdSeconds *int32 `json:"unhealthyThresholdSeconds,omitempty"`
+	// WebhookURL receives a POST request with a JSON body describing the transition when the RayService fails over and
+	// when it recovers. A failed request is retried on the next reconciliation.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	WebhookURL *string `json:"webhookURL,omitempty"`
+	// ExternalDNSHostname is set as the `external-dns.alpha.kubernetes.io/hostname` annotation of the serve service
+	// while the RayService hasn't failed over, and removed once it fails over, so that ExternalDNS withdraws the DNS
+	// record of the region and the record of the passive region takes over.
+	ExternalDNSHostname *string `json:"externalDNSHostname,omitempty"`
+}
+
+// ServeConfigSource is an HTTP endpoint serving the Serve config of a RayService, for example the endpoint of a model
+// registry. KubeRay polls the endpoint and deploys the Serve config whenever its content changes, so that registering a
+// new model version updates the Serve applications without editing the RayService.
+type ServeConfigSource struct {
+	// URL is the HTTP or HTTPS URL of the Serve config. The response body is a Serve config in the format of
+	// serveConfigV2.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+	// PollIntervalSeconds is how often the URL is polled. Defaults to 300.
+	// +kubebuilder:validation:Minimum=10
+	PollIntervalSeconds *int32 `json:"pollIntervalSeconds,omitempty"`
+	// AuthSecretRef is a key of a Secret in the namespace of the RayService whose value is sent as the Authorization
+	// header of the requests, for example `Bearer <token>`.
+	AuthSecretRef *corev1.SecretKeySelector `json:"authSecretRef,omitempty"`
+}
+
+// ServeConfigSourceStatus is the Serve config last fetched from the serveConfigSource of a RayService.
+type ServeConfigSourceStatus struct {
+	// Revision is the ETag of the response the Serve config was fetched from, or its content hash if the source doesn't
+	// return an ETag.
+	Revision string `json:"revision,omitempty"`
+	// ContentHash is the SHA-256 hash of the Serve config.
+	ContentHash string `json:"contentHash,omitempty"`
+	// LastFetchTime is when the source was last polled successfully.
+	LastFetchTime *metav1.Time `json:"lastFetchTime,omitempty"`
 }
 
 // RayServiceSpec defines the desired state of RayService
@@ -67,15 +406,80 @@ type RayServiceSpec struct {
 	DeploymentUnhealthySecondThreshold *int32 `json:"deploymentUnhealthySecondThreshold,omitempty"`
 	// ServeService is the Kubernetes service for head node and worker nodes who have healthy http proxy to serve traffics.
 	ServeService *corev1.Service `json:"serveService,omitempty"`
+	// ServeSessionAffinity makes the requests of a session stick to the same Ray Serve proxy.
+	ServeSessionAffinity *ServeSessionAffinity `json:"serveSessionAffinity,omitempty"`
+	// ServeTopologyRouting makes the serve service prefer the serve Pods in the zone of the clients. KubeRay manages the
+	// `service.kubernetes.io/topology-mode` annotation of the serve service, unless it is set in serveService.
+	ServeTopologyRouting *ServeTopologyRouting `json:"serveTopologyRouting,omitempty"`
+	// ServeProbe is sent to the Ray Serve proxy of the pending RayCluster before it is promoted to serve the traffic.
+	ServeProbe *ServeProbe `json:"serveProbe,omitempty"`
+	// ServeReadiness selects the check that decides whether the Serve applications of a RayCluster are ready, for
+	// example to wait for the models to be loaded before the pending RayCluster is promoted.
+	ServeReadiness *ServeReadiness `json:"serveReadiness,omitempty"`
+	// ServeProxyHealthCheck customizes the health check of the Ray Serve proxies, for example to tolerate slow proxies
+	// on loaded head Pods.
+	ServeProxyHealthCheck *ServeProxyHealthCheck `json:"serveProxyHealthCheck,omitempty"`
+	// ServeAlerting declares per-application SLOs that are reported by the Ready condition and by Prometheus alerts.
+	ServeAlerting *ServeAlertingOptions `json:"serveAlerting,omitempty"`
+	// FailoverPolicy notifies external systems when the RayService stays unhealthy, to fail the traffic over to another
+	// region.
+	FailoverPolicy *FailoverPolicy `json:"failoverPolicy,omitempty"`
 	// UpgradeStrategy defines the scaling policy used when upgrading the RayService.
 	UpgradeStrategy *RayServiceUpgradeStrategy `json:"upgradeStrategy,omitempty"`
 	// Important: Run "make" to regenerate code after modifying this file
 	// Defines the applications and deployments to deploy, should be a YAML multi-line scalar string.
 	ServeConfigV2  string         `json:"serveConfigV2,omitempty"`
 	RayClusterSpec RayClusterSpec `json:"rayClusterConfig,omitempty"`
+	// ServeConfigSource fetches the Serve config from an HTTP endpoint, for example a model registry, instead of
+	// serveConfigV2. The last fetched Serve config is stored in the `<name>-serve-config` ConfigMap, and its revision in
+	// the status. It can't be set with serveConfigV2. It requires the RayServiceServeConfigSource feature gate.
+	ServeConfigSource *ServeConfigSource `json:"serveConfigSource,omitempty"`
+	// ServeConfigV2Variables are the ConfigMaps and Secrets whose keys are substituted for the `${KEY}` variables
+	// of serveConfigV2 when the Serve applications are deployed, so that the same config can be reused across
+	// environments. The prefix of a source is prepended to its keys, and a key defined in several sources takes
+	// the value of the last one. `$${` is replaced by a literal `${`.
+	ServeConfigV2Variables []corev1.EnvFromSource `json:"serveConfigV2Variables,omitempty"`
+	// ServeApplicationWorkerGroups maps the names of Serve applications to the worker groups that their replicas are
+	// placed on, for example to place the LLM applications on the GPU worker groups. The worker groups advertise a
+	// `worker-group-<group name>` custom Ray resource, and the deployments of the applications listed in serveConfigV2
+	// request a fraction of it. The deployments that aren't listed in serveConfigV2 aren't constrained.
+	ServeApplicationWorkerGroups map[string]string `json:"serveApplicationWorkerGroups,omitempty"`
 	// If the field is set to true, the value of the label `ray.io/serve` on the head Pod should always be false.
 	// Therefore, the head Pod's endpoint will not be added to the Kubernetes Serve service.
 	ExcludeHeadPodFromServeSvc bool `json:"excludeHeadPodFromServeSvc,omitempty"`
+	// If the field is set to true, the Ray Serve proxies of the worker Pods are health-checked like the one of the head
+	// Pod, and the label `ray.io/serve` of a worker Pod is set to false while its proxy is unhealthy. Therefore, a
+	// worker Pod with a wedged proxy is removed from the Kubernetes Serve service.
+	WorkerServeProxyHealthCheck bool `json:"workerServeProxyHealthCheck,omitempty"`
+	// ImagePullSecrets are added to the head and worker Pods of the RayClusters created for the RayService, so that
+	// they don't need to be set in every Pod template.
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+	// PriorityClassName is set as the priorityClassName of the head and worker Pods of the RayClusters created for the
+	// RayService, and as the `ray.io/priority-class-name` label of the RayClusters read by the batch schedulers, so
+	// that the pending RayCluster of an upgrade is scheduled with the same priority as the active one. Changing it
+	// triggers an upgrade.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// SchedulerName is set as the schedulerName of the head and worker Pods of the RayClusters created for the
+	// RayService. Changing it triggers an upgrade.
+	SchedulerName string `json:"schedulerName,omitempty"`
+	// QueueLabels are added to the labels of the RayClusters created for the RayService, for example
+	// `kueue.x-k8s.io/queue-name` or `volcano.sh/queue-name`, so that the pending RayCluster of an upgrade is queued
+	// like the active one. Changing them only affects the RayClusters created afterwards.
+	QueueLabels map[string]string `json:"queueLabels,omitempty"`
+	// ServiceReconcileMode decides whether KubeRay reverts the modifications of the selector and ports of the head
+	// and serve Services made outside of KubeRay (Enforce), or only reports them with the ServiceDriftDetected
+	// condition (Observe). Defaults to Observe.
+	// +kubebuilder:validation:Enum=Enforce;Observe
+	ServiceReconcileMode *ServiceReconcileMode `json:"serviceReconcileMode,omitempty"`
+	// DisableDashboard is for the locked-down environments where KubeRay must not use the HTTP API of the Ray
+	// dashboard. The Serve config is deployed with `serve deploy` in a Job in the namespace of the RayCluster, and the
+	// Serve applications are considered running once the Job succeeded, the GCS server of the head Pod accepts
+	// connections and the Serve proxy of the head Pod is healthy. The statuses of the Serve deployments aren't reported.
+	DisableDashboard *bool `json:"disableDashboard,omitempty"`
+	// MaintenanceMode repoints the serve service to a static responder managed by KubeRay, which answers every request
+	// with 503 Service Unavailable, for a planned downtime. The RayClusters are still reconciled, and the Ready condition
+	// is false without failing the RayService over.
+	MaintenanceMode bool `json:"maintenanceMode,omitempty"`
 }
 
 // RayServiceStatuses defines the observed state of RayService
@@ -90,9 +494,59 @@ type RayServiceStatuses struct {
 	// NumServeEndpoints indicates the number of Ray Pods that are actively serving or have been selected by the serve service.
 	// Ray Pods without a proxy actor or those that are unhealthy will not be counted.
 	NumServeEndpoints int32 `json:"numServeEndpoints,omitempty"`
+	// HeadServiceName is the name of the head Service of the RayService.
+	HeadServiceName string `json:"headServiceName,omitempty"`
+	// ServeServiceName is the name of the serve Service of the RayService.
+	ServeServiceName string `json:"serveServiceName,omitempty"`
 	// observedGeneration is the most recent generation observed for this RayService. It corresponds to the
 	// RayService's generation, which is updated on mutation by the API Server.
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// LastReconcileError is the error returned by the last reconciliation of the RayService. It is cleared once a
+	// reconciliation succeeds.
+	LastReconcileError *ReconcileError `json:"lastReconcileError,omitempty"`
+	// Journal is the list of the last decisions of KubeRay about the RayService, from the oldest to the newest.
+	Journal []JournalEntry `json:"journal,omitempty"`
+	// RequestShadowing is the result of the shadowing of the last pending RayCluster.
+	RequestShadowing *RequestShadowingStatus `json:"requestShadowing,omitempty"`
+	// ServeConfigSource is the Serve config last fetched from the serveConfigSource.
+	ServeConfigSource *ServeConfigSourceStatus `json:"serveConfigSource,omitempty"`
+	// ResourceUsage is the usage of the resources of the active and pending RayClusters of the RayService. It's only
+	// set with the RayResourceUsageAccounting feature gate.
+	ResourceUsage *ResourceUsage `json:"resourceUsage,omitempty"`
+	// Represents the latest available observations of a RayService's current state.
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+}
+
+// ServeRequestCounts are the numbers of HTTP requests handled by the Ray Serve proxies of a RayCluster.
+type ServeRequestCounts struct {
+	// Requests is the number of requests.
+	Requests int64 `json:"requests,omitempty"`
+	// Errors is the number of requests that failed.
+	Errors int64 `json:"errors,omitempty"`
+}
+
+// RequestShadowingStatus reports the error rates of the active and pending RayClusters while the pending RayCluster is
+// shadowed.
+type RequestShadowingStatus struct {
+	// RayClusterName is the name of the shadowed pending RayCluster.
+	RayClusterName string `json:"rayClusterName,omitempty"`
+	// StartTime is when the requests started to be mirrored to the pending RayCluster.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// ActiveBaseline and PendingBaseline are the counters of the Serve proxies of the RayClusters at StartTime.
+	ActiveBaseline  ServeRequestCounts `json:"activeBaseline,omitempty"`
+	PendingBaseline ServeRequestCounts `json:"pendingBaseline,omitempty"`
+	// Active and Pending are the requests handled by the RayClusters since StartTime.
+	Active  ServeRequestCounts `json:"active,omitempty"`
+	Pending ServeRequestCounts `json:"pending,omitempty"`
+	// ErrorRateDeltaPercent is the error rate of the pending RayCluster minus the one of the active RayCluster, in
+	// percentage points, for example `0.25`.
+	ErrorRateDeltaPercent string `json:"errorRateDeltaPercent,omitempty"`
+	// Passed is true once the pending RayCluster was shadowed for durationSeconds within the error rate threshold.
+	Passed bool `json:"passed,omitempty"`
 }
 
 type RayServiceStatus struct {
@@ -102,6 +556,20 @@ type RayServiceStatus struct {
 	RayClusterStatus RayClusterStatus     `json:"rayClusterStatus,omitempty"`
 }
 
+// ServeApplicationUpgradeStatus is the state of the upgrade of a Serve application with the ApplicationScoped upgrade
+// strategy.
+type ServeApplicationUpgradeStatus string
+
+const (
+	// ServeApplicationUpgrading means that the entry of the application in serveConfigV2 changed and the application
+	// isn't running the new config yet.
+	ServeApplicationUpgrading ServeApplicationUpgradeStatus = "Upgrading"
+	// ServeApplicationUpgraded means that the application runs the latest config.
+	ServeApplicationUpgraded ServeApplicationUpgradeStatus = "Upgraded"
+	// ServeApplicationUpgradeFailed means that the deployment of the latest config of the application failed.
+	ServeApplicationUpgradeFailed ServeApplicationUpgradeStatus = "UpgradeFailed"
+)
+
 type AppStatus struct {
 	// Keep track of how long the service is healthy.
 	// Update when Serve deployment is healthy or first time convert to unhealthy from healthy.
@@ -109,6 +577,12 @@ type AppStatus struct {
 	Deployments          map[string]ServeDeploymentStatus `json:"serveDeploymentStatuses,omitempty"`
 	Status               string                           `json:"status,omitempty"`
 	Message              string                           `json:"message,omitempty"`
+	// UpgradeStatus is the state of the upgrade of the application. It is only set with the ApplicationScoped upgrade
+	// strategy.
+	UpgradeStatus ServeApplicationUpgradeStatus `json:"upgradeStatus,omitempty"`
+	// ServeConfigHash is the hash of the entry of the application in the submitted serveConfigV2. It is only set with
+	// the ApplicationScoped upgrade strategy.
+	ServeConfigHash string `json:"serveConfigHash,omitempty"`
 }
 
 // ServeDeploymentStatus defines the current state of a Serve deployment
@@ -120,6 +594,18 @@ type ServeDeploymentStatus struct {
 	// TODO: change status type to enum
 	Status  string `json:"status,omitempty"`
 	Message string `json:"message,omitempty"`
+	// Replicas summarizes the states and the placement of the replicas of the Serve deployment.
+	Replicas *ServeReplicasSummary `json:"replicas,omitempty"`
+}
+
+// ServeReplicasSummary summarizes the replicas of a Serve deployment reported by the Ray dashboard.
+type ServeReplicasSummary struct {
+	// States is the number of replicas in each state, e.g. RUNNING or STARTING.
+	States map[string]int32 `json:"states,omitempty"`
+	// Nodes is the number of replicas placed on each Ray node, keyed by the IP of the node.
+	Nodes map[string]int32 `json:"nodes,omitempty"`
+	// StuckStarting is the number of replicas that have been in the STARTING state for too long.
+	StuckStarting int32 `json:"stuckStarting,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -0,0 +1,84 @@
+package v1
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	semver "github.com/Masterminds/semver/v3"
+)
+
+// RayVersionFeature is a KubeRay feature that requires a minimum Ray version.
+type RayVersionFeature string
+
+const (
+	AutoscalerV2RayVersionFeature  RayVersionFeature = "autoscaler v2"
+	ServeConfigV2RayVersionFeature RayVersionFeature = "serveConfigV2"
+	JobMetadataRayVersionFeature   RayVersionFeature = "RayJob metadata"
+)
+
+// minRayVersions is the compatibility matrix of the KubeRay features and the minimum Ray versions supporting them.
+var minRayVersions = map[RayVersionFeature]*semver.Version{
+	AutoscalerV2RayVersionFeature:  semver.MustParse("2.10.0"),
+	ServeConfigV2RayVersionFeature: semver.MustParse("2.0.0"),
+	JobMetadataRayVersionFeature:   semver.MustParse("2.6.0"),
+}
+
+var imageTagRayVersionRegex = regexp.MustCompile(`^(\d+\.\d+\.\d+)`)
+
+// GetRayVersion returns the Ray version of the RayCluster spec. It is read from rayVersion, or from the tag of the
+// image of the Ray container of the head Pod (e.g. rayproject/ray:2.41.0-py310) if rayVersion isn't set. The second
+// return value is false if the version is unknown, e.g. for nightly images.
+func GetRayVersion(spec *RayClusterSpec) (*semver.Version, bool) {
+	if spec.RayVersion != "" {
+		version, err := semver.NewVersion(spec.RayVersion)
+		return version, err == nil
+	}
+	if len(spec.HeadGroupSpec.Template.Spec.Containers) == 0 {
+		return nil, false
+	}
+	image := spec.HeadGroupSpec.Template.Spec.Containers[0].Image
+	image, _, _ = strings.Cut(image, "@")
+	tagIndex := strings.LastIndex(image, ":")
+	if tagIndex < 0 || strings.Contains(image[tagIndex:], "/") {
+		return nil, false
+	}
+	match := imageTagRayVersionRegex.FindString(image[tagIndex+1:])
+	if match == "" {
+		return nil, false
+	}
+	version, err := semver.NewVersion(match)
+	return version, err == nil
+}
+
+// CheckRayVersionCompatibility returns an error if the Ray version of the RayCluster spec doesn't support a KubeRay
+// feature used by the spec or one of the given features used by the custom resource owning the spec. The check is
+// skipped if the Ray version is unknown.
+func CheckRayVersionCompatibility(spec *RayClusterSpec, features ...RayVersionFeature) error {
+	version, ok := GetRayVersion(spec)
+	if !ok {
+		return nil
+	}
+	if isAutoscalerV2Enabled(spec) {
+		features = append(features, AutoscalerV2RayVersionFeature)
+	}
+	for _, feature := range features {
+		if minVersion := minRayVersions[feature]; version.LessThan(minVersion) {
+			return fmt.Errorf("%s requires Ray %s or later, but the Ray version of the RayCluster is %s. "+
+				"Please upgrade the Ray image and rayVersion, or stop using %s", feature, minVersion, version, feature)
+		}
+	}
+	return nil
+}
+
+func isAutoscalerV2Enabled(spec *RayClusterSpec) bool {
+	if spec.EnableInTreeAutoscaling == nil || !*spec.EnableInTreeAutoscaling || len(spec.HeadGroupSpec.Template.Spec.Containers) == 0 {
+		return false
+	}
+	for _, env := range spec.HeadGroupSpec.Template.Spec.Containers[0].Env {
+		if env.Name == "RAY_enable_autoscaler_v2" {
+			return env.Value == "1" || strings.EqualFold(env.Value, "true")
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestGetRayVersion(t *testing.T) {
+	tests := map[string]struct {
+		rayVersion string
+		image      string
+		expected   string
+	}{
+		"rayVersion is set": {
+			rayVersion: "2.41.0",
+			image:      "rayproject/ray:2.9.0",
+			expected:   "2.41.0",
+		},
+		"invalid rayVersion": {
+			rayVersion: "nightly",
+			image:      "rayproject/ray:2.9.0",
+		},
+		"version from the image tag": {
+			image:    "rayproject/ray:2.41.0-py310-gpu",
+			expected: "2.41.0",
+		},
+		"version from the image tag of a registry with a port": {
+			image:    "localhost:5000/rayproject/ray:2.9.0@sha256:abcdef",
+			expected: "2.9.0",
+		},
+		"image without a tag": {
+			image: "localhost:5000/rayproject/ray",
+		},
+		"nightly image": {
+			image: "rayproject/ray:nightly-py310",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			spec := &RayClusterSpec{
+				RayVersion: tc.rayVersion,
+				HeadGroupSpec: HeadGroupSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{Containers: []corev1.Container{{Image: tc.image}}},
+					},
+				},
+			}
+			version, ok := GetRayVersion(spec)
+			assert.Equal(t, tc.expected != "", ok)
+			if ok {
+				assert.Equal(t, tc.expected, version.String())
+			}
+		})
+	}
+}
+
+func TestCheckRayVersionCompatibility(t *testing.T) {
+	newSpec := func(image string, autoscalerV2 bool) *RayClusterSpec {
+		container := corev1.Container{Image: image}
+		if autoscalerV2 {
+			container.Env = []corev1.EnvVar{{Name: "RAY_enable_autoscaler_v2", Value: "1"}}
+		}
+		return &RayClusterSpec{
+			EnableInTreeAutoscaling: ptr.To(true),
+			HeadGroupSpec: HeadGroupSpec{
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{container}}},
+			},
+		}
+	}
+
+	assert.NoError(t, CheckRayVersionCompatibility(newSpec("rayproject/ray:2.9.0", false)))
+	assert.NoError(t, CheckRayVersionCompatibility(newSpec("rayproject/ray:2.10.0", true)))
+	assert.NoError(t, CheckRayVersionCompatibility(newSpec("rayproject/ray:nightly", true)))
+	assert.ErrorContains(t, CheckRayVersionCompatibility(newSpec("rayproject/ray:2.9.0", true)),
+		"autoscaler v2 requires Ray 2.10.0 or later, but the Ray version of the RayCluster is 2.9.0")
+	assert.ErrorContains(t, CheckRayVersionCompatibility(newSpec("rayproject/ray:2.5.1", false), JobMetadataRayVersionFeature),
+		"RayJob metadata requires Ray 2.6.0 or later")
+}
@@ -5,11 +5,48 @@
 package v1
 
 import (
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	resource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AcceleratorProvisioningRule) DeepCopyInto(out *AcceleratorProvisioningRule) {
+	*out = *in
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AcceleratorProvisioningRule.
+func (in *AcceleratorProvisioningRule) DeepCopy() *AcceleratorProvisioningRule {
+	if in == nil {
+		return nil
+	}
+	out := new(AcceleratorProvisioningRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AppStatus) DeepCopyInto(out *AppStatus) {
 	*out = *in
@@ -90,6 +127,11 @@ func (in *AutoscalerOptions) DeepCopyInto(out *AutoscalerOptions) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.BalloonPods != nil {
+		in, out := &in.BalloonPods, &out.BalloonPods
+		*out = new(BalloonPodsOptions)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalerOptions.
@@ -103,608 +145,2276 @@ func (in *AutoscalerOptions) DeepCopy() *AutoscalerOptions {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GcsFaultToleranceOptions) DeepCopyInto(out *GcsFaultToleranceOptions) {
+func (in *BalloonPodsOptions) DeepCopyInto(out *BalloonPodsOptions) {
 	*out = *in
-	if in.RedisUsername != nil {
-		in, out := &in.RedisUsername, &out.RedisUsername
-		*out = new(RedisCredential)
-		(*in).DeepCopyInto(*out)
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(string)
+		**out = **in
 	}
-	if in.RedisPassword != nil {
-		in, out := &in.RedisPassword, &out.RedisPassword
-		*out = new(RedisCredential)
-		(*in).DeepCopyInto(*out)
+	if in.MaxPods != nil {
+		in, out := &in.MaxPods, &out.MaxPods
+		*out = new(int32)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GcsFaultToleranceOptions.
-func (in *GcsFaultToleranceOptions) DeepCopy() *GcsFaultToleranceOptions {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BalloonPodsOptions.
+func (in *BalloonPodsOptions) DeepCopy() *BalloonPodsOptions {
 	if in == nil {
 		return nil
 	}
-	out := new(GcsFaultToleranceOptions)
+	out := new(BalloonPodsOptions)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HeadGroupSpec) DeepCopyInto(out *HeadGroupSpec) {
+func (in *BurstableOptions) DeepCopyInto(out *BurstableOptions) {
 	*out = *in
-	if in.HeadService != nil {
-		in, out := &in.HeadService, &out.HeadService
-		*out = new(corev1.Service)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.EnableIngress != nil {
-		in, out := &in.EnableIngress, &out.EnableIngress
-		*out = new(bool)
-		**out = **in
-	}
-	if in.RayStartParams != nil {
-		in, out := &in.RayStartParams, &out.RayStartParams
+	if in.VirtualNodeSelector != nil {
+		in, out := &in.VirtualNodeSelector, &out.VirtualNodeSelector
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
-	in.Template.DeepCopyInto(&out.Template)
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeadGroupSpec.
-func (in *HeadGroupSpec) DeepCopy() *HeadGroupSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BurstableOptions.
+func (in *BurstableOptions) DeepCopy() *BurstableOptions {
 	if in == nil {
 		return nil
 	}
-	out := new(HeadGroupSpec)
+	out := new(BurstableOptions)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HeadInfo) DeepCopyInto(out *HeadInfo) {
+func (in *CheckpointingOptions) DeepCopyInto(out *CheckpointingOptions) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeadInfo.
-func (in *HeadInfo) DeepCopy() *HeadInfo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckpointingOptions.
+func (in *CheckpointingOptions) DeepCopy() *CheckpointingOptions {
 	if in == nil {
 		return nil
 	}
-	out := new(HeadInfo)
+	out := new(CheckpointingOptions)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RayCluster) DeepCopyInto(out *RayCluster) {
+func (in *DashboardIngressOptions) DeepCopyInto(out *DashboardIngressOptions) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.IngressClassName != nil {
+		in, out := &in.IngressClassName, &out.IngressClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.OAuth2Proxy != nil {
+		in, out := &in.OAuth2Proxy, &out.OAuth2Proxy
+		*out = new(OAuth2ProxyOptions)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayCluster.
-func (in *RayCluster) DeepCopy() *RayCluster {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DashboardIngressOptions.
+func (in *DashboardIngressOptions) DeepCopy() *DashboardIngressOptions {
 	if in == nil {
 		return nil
 	}
-	out := new(RayCluster)
+	out := new(DashboardIngressOptions)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RayCluster) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailoverPolicy) DeepCopyInto(out *FailoverPolicy) {
+	*out = *in
+	if in.UnhealthyThresholdSeconds != nil {
+		in, out := &in.UnhealthyThresholdSeconds, &out.UnhealthyThresholdSeconds
+		*out = new(int32)
+		**out = **in
 	}
-	return nil
+	if in.WebhookURL != nil {
+		in, out := &in.WebhookURL, &out.WebhookURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.ExternalDNSHostname != nil {
+		in, out := &in.ExternalDNSHostname, &out.ExternalDNSHostname
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailoverPolicy.
+func (in *FailoverPolicy) DeepCopy() *FailoverPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(FailoverPolicy)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RayClusterList) DeepCopyInto(out *RayClusterList) {
+func (in *GcsFaultToleranceOptions) DeepCopyInto(out *GcsFaultToleranceOptions) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]RayCluster, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.RedisUsername != nil {
+		in, out := &in.RedisUsername, &out.RedisUsername
+		*out = new(RedisCredential)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RedisPassword != nil {
+		in, out := &in.RedisPassword, &out.RedisPassword
+		*out = new(RedisCredential)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayClusterList.
-func (in *RayClusterList) DeepCopy() *RayClusterList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GcsFaultToleranceOptions.
+func (in *GcsFaultToleranceOptions) DeepCopy() *GcsFaultToleranceOptions {
 	if in == nil {
 		return nil
 	}
-	out := new(RayClusterList)
+	out := new(GcsFaultToleranceOptions)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RayClusterList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GroupMemoryFailures) DeepCopyInto(out *GroupMemoryFailures) {
+	*out = *in
+	if in.LastOOMKilledTime != nil {
+		in, out := &in.LastOOMKilledTime, &out.LastOOMKilledTime
+		*out = (*in).DeepCopy()
 	}
-	return nil
+	if in.LastEvictedTime != nil {
+		in, out := &in.LastEvictedTime, &out.LastEvictedTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupMemoryFailures.
+func (in *GroupMemoryFailures) DeepCopy() *GroupMemoryFailures {
+	if in == nil {
+		return nil
+	}
+	out := new(GroupMemoryFailures)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RayClusterSpec) DeepCopyInto(out *RayClusterSpec) {
+func (in *HeadContainerServicePort) DeepCopyInto(out *HeadContainerServicePort) {
 	*out = *in
-	if in.Suspend != nil {
-		in, out := &in.Suspend, &out.Suspend
+	if in.ExposeOnServeService != nil {
+		in, out := &in.ExposeOnServeService, &out.ExposeOnServeService
 		*out = new(bool)
 		**out = **in
 	}
-	if in.ManagedBy != nil {
-		in, out := &in.ManagedBy, &out.ManagedBy
-		*out = new(string)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeadContainerServicePort.
+func (in *HeadContainerServicePort) DeepCopy() *HeadContainerServicePort {
+	if in == nil {
+		return nil
 	}
-	if in.AutoscalerOptions != nil {
-		in, out := &in.AutoscalerOptions, &out.AutoscalerOptions
-		*out = new(AutoscalerOptions)
+	out := new(HeadContainerServicePort)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeadGroupSpec) DeepCopyInto(out *HeadGroupSpec) {
+	*out = *in
+	if in.HeadService != nil {
+		in, out := &in.HeadService, &out.HeadService
+		*out = new(corev1.Service)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.HeadServiceAnnotations != nil {
-		in, out := &in.HeadServiceAnnotations, &out.HeadServiceAnnotations
+	if in.EnableIngress != nil {
+		in, out := &in.EnableIngress, &out.EnableIngress
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ObjectStoreMemoryPercent != nil {
+		in, out := &in.ObjectStoreMemoryPercent, &out.ObjectStoreMemoryPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RayStartParams != nil {
+		in, out := &in.RayStartParams, &out.RayStartParams
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
-	if in.EnableInTreeAutoscaling != nil {
-		in, out := &in.EnableInTreeAutoscaling, &out.EnableInTreeAutoscaling
+	in.Template.DeepCopyInto(&out.Template)
+	if in.EnableColdStandby != nil {
+		in, out := &in.EnableColdStandby, &out.EnableColdStandby
 		*out = new(bool)
 		**out = **in
 	}
-	if in.GcsFaultToleranceOptions != nil {
-		in, out := &in.GcsFaultToleranceOptions, &out.GcsFaultToleranceOptions
-		*out = new(GcsFaultToleranceOptions)
+	if in.ServicePorts != nil {
+		in, out := &in.ServicePorts, &out.ServicePorts
+		*out = new(HeadServicePorts)
 		(*in).DeepCopyInto(*out)
 	}
-	in.HeadGroupSpec.DeepCopyInto(&out.HeadGroupSpec)
-	if in.WorkerGroupSpecs != nil {
-		in, out := &in.WorkerGroupSpecs, &out.WorkerGroupSpecs
-		*out = make([]WorkerGroupSpec, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.StatefulSet != nil {
+		in, out := &in.StatefulSet, &out.StatefulSet
+		*out = new(HeadStatefulSetOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HealthProbes != nil {
+		in, out := &in.HealthProbes, &out.HealthProbes
+		*out = new(HeadHealthProbes)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayClusterSpec.
-func (in *RayClusterSpec) DeepCopy() *RayClusterSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeadGroupSpec.
+func (in *HeadGroupSpec) DeepCopy() *HeadGroupSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RayClusterSpec)
+	out := new(HeadGroupSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RayClusterStatus) DeepCopyInto(out *RayClusterStatus) {
+func (in *HeadHealthProbes) DeepCopyInto(out *HeadHealthProbes) {
 	*out = *in
-	out.DesiredCPU = in.DesiredCPU.DeepCopy()
-	out.DesiredMemory = in.DesiredMemory.DeepCopy()
-	out.DesiredGPU = in.DesiredGPU.DeepCopy()
-	out.DesiredTPU = in.DesiredTPU.DeepCopy()
-	if in.LastUpdateTime != nil {
-		in, out := &in.LastUpdateTime, &out.LastUpdateTime
-		*out = (*in).DeepCopy()
-	}
-	if in.StateTransitionTimes != nil {
-		in, out := &in.StateTransitionTimes, &out.StateTransitionTimes
-		*out = make(map[ClusterState]*metav1.Time, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val.DeepCopy()
-		}
-	}
-	if in.Endpoints != nil {
-		in, out := &in.Endpoints, &out.Endpoints
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(HealthProbeThresholds)
+		(*in).DeepCopyInto(*out)
 	}
-	out.Head = in.Head
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(HealthProbeThresholds)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayClusterStatus.
-func (in *RayClusterStatus) DeepCopy() *RayClusterStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeadHealthProbes.
+func (in *HeadHealthProbes) DeepCopy() *HeadHealthProbes {
 	if in == nil {
 		return nil
 	}
-	out := new(RayClusterStatus)
+	out := new(HeadHealthProbes)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RayJob) DeepCopyInto(out *RayJob) {
+func (in *HeadInfo) DeepCopyInto(out *HeadInfo) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayJob.
-func (in *RayJob) DeepCopy() *RayJob {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeadInfo.
+func (in *HeadInfo) DeepCopy() *HeadInfo {
 	if in == nil {
 		return nil
 	}
-	out := new(RayJob)
+	out := new(HeadInfo)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RayJob) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeadServicePorts) DeepCopyInto(out *HeadServicePorts) {
+	*out = *in
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	return nil
+	if in.Additional != nil {
+		in, out := &in.Additional, &out.Additional
+		*out = make([]corev1.ServicePort, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ContainerPorts != nil {
+		in, out := &in.ContainerPorts, &out.ContainerPorts
+		*out = make([]HeadContainerServicePort, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeadServicePorts.
+func (in *HeadServicePorts) DeepCopy() *HeadServicePorts {
+	if in == nil {
+		return nil
+	}
+	out := new(HeadServicePorts)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RayJobList) DeepCopyInto(out *RayJobList) {
+func (in *HeadStatefulSetOptions) DeepCopyInto(out *HeadStatefulSetOptions) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]RayJob, len(*in))
+	if in.VolumeClaimTemplates != nil {
+		in, out := &in.VolumeClaimTemplates, &out.VolumeClaimTemplates
+		*out = make([]corev1.PersistentVolumeClaimTemplate, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PersistentVolumeClaimRetentionPolicy != nil {
+		in, out := &in.PersistentVolumeClaimRetentionPolicy, &out.PersistentVolumeClaimRetentionPolicy
+		*out = new(appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayJobList.
-func (in *RayJobList) DeepCopy() *RayJobList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeadStatefulSetOptions.
+func (in *HeadStatefulSetOptions) DeepCopy() *HeadStatefulSetOptions {
 	if in == nil {
 		return nil
 	}
-	out := new(RayJobList)
+	out := new(HeadStatefulSetOptions)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RayJobList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthProbeThresholds) DeepCopyInto(out *HealthProbeThresholds) {
+	*out = *in
+	if in.InitialDelaySeconds != nil {
+		in, out := &in.InitialDelaySeconds, &out.InitialDelaySeconds
+		*out = new(int32)
+		**out = **in
 	}
-	return nil
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PeriodSeconds != nil {
+		in, out := &in.PeriodSeconds, &out.PeriodSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthProbeThresholds.
+func (in *HealthProbeThresholds) DeepCopy() *HealthProbeThresholds {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthProbeThresholds)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RayJobSpec) DeepCopyInto(out *RayJobSpec) {
+func (in *ImagePrePullOptions) DeepCopyInto(out *ImagePrePullOptions) {
 	*out = *in
-	if in.ActiveDeadlineSeconds != nil {
-		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
 		*out = new(int32)
 		**out = **in
 	}
-	if in.BackoffLimit != nil {
-		in, out := &in.BackoffLimit, &out.BackoffLimit
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePrePullOptions.
+func (in *ImagePrePullOptions) DeepCopy() *ImagePrePullOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePrePullOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InteractiveSessionOptions) DeepCopyInto(out *InteractiveSessionOptions) {
+	*out = *in
+	if in.IdleTimeoutSeconds != nil {
+		in, out := &in.IdleTimeoutSeconds, &out.IdleTimeoutSeconds
 		*out = new(int32)
 		**out = **in
 	}
-	if in.RayClusterSpec != nil {
-		in, out := &in.RayClusterSpec, &out.RayClusterSpec
-		*out = new(RayClusterSpec)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InteractiveSessionOptions.
+func (in *InteractiveSessionOptions) DeepCopy() *InteractiveSessionOptions {
+	if in == nil {
+		return nil
 	}
-	if in.SubmitterPodTemplate != nil {
-		in, out := &in.SubmitterPodTemplate, &out.SubmitterPodTemplate
-		*out = new(corev1.PodTemplateSpec)
+	out := new(InteractiveSessionOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InteractiveSessionStatus) DeepCopyInto(out *InteractiveSessionStatus) {
+	*out = *in
+	if in.LastActivityTime != nil {
+		in, out := &in.LastActivityTime, &out.LastActivityTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InteractiveSessionStatus.
+func (in *InteractiveSessionStatus) DeepCopy() *InteractiveSessionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InteractiveSessionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JournalEntry) DeepCopyInto(out *JournalEntry) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JournalEntry.
+func (in *JournalEntry) DeepCopy() *JournalEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(JournalEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetadataSync) DeepCopyInto(out *MetadataSync) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetadataSync.
+func (in *MetadataSync) DeepCopy() *MetadataSync {
+	if in == nil {
+		return nil
+	}
+	out := new(MetadataSync)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricsOptions) DeepCopyInto(out *MetricsOptions) {
+	*out = *in
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(string)
+		**out = **in
+	}
+	if in.ScrapeIntervalSeconds != nil {
+		in, out := &in.ScrapeIntervalSeconds, &out.ScrapeIntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BearerTokenSecret != nil {
+		in, out := &in.BearerTokenSecret, &out.BearerTokenSecret
+		*out = new(corev1.SecretKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Metadata != nil {
-		in, out := &in.Metadata, &out.Metadata
+	if in.ExternalLabels != nil {
+		in, out := &in.ExternalLabels, &out.ExternalLabels
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
-	if in.ClusterSelector != nil {
-		in, out := &in.ClusterSelector, &out.ClusterSelector
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricsOptions.
+func (in *MetricsOptions) DeepCopy() *MetricsOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricsOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OAuth2ProxyOptions) DeepCopyInto(out *OAuth2ProxyOptions) {
+	*out = *in
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAuth2ProxyOptions.
+func (in *OAuth2ProxyOptions) DeepCopy() *OAuth2ProxyOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(OAuth2ProxyOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OOMPolicy) DeepCopyInto(out *OOMPolicy) {
+	*out = *in
+	if in.OOMKillsPerIncrease != nil {
+		in, out := &in.OOMKillsPerIncrease, &out.OOMKillsPerIncrease
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxMemory != nil {
+		in, out := &in.MaxMemory, &out.MaxMemory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OOMPolicy.
+func (in *OOMPolicy) DeepCopy() *OOMPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(OOMPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProfilingOptions) DeepCopyInto(out *ProfilingOptions) {
+	*out = *in
+	if in.OutputDir != nil {
+		in, out := &in.OutputDir, &out.OutputDir
+		*out = new(string)
+		**out = **in
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]corev1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UploadCommand != nil {
+		in, out := &in.UploadCommand, &out.UploadCommand
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProfilingOptions.
+func (in *ProfilingOptions) DeepCopy() *ProfilingOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ProfilingOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusMonitoringOptions) DeepCopyInto(out *PrometheusMonitoringOptions) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
-	if in.SubmitterConfig != nil {
-		in, out := &in.SubmitterConfig, &out.SubmitterConfig
-		*out = new(SubmitterConfig)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ManagedBy != nil {
-		in, out := &in.ManagedBy, &out.ManagedBy
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
 		*out = new(string)
 		**out = **in
 	}
-	if in.DeletionPolicy != nil {
-		in, out := &in.DeletionPolicy, &out.DeletionPolicy
-		*out = new(DeletionPolicy)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusMonitoringOptions.
+func (in *PrometheusMonitoringOptions) DeepCopy() *PrometheusMonitoringOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusMonitoringOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayCluster) DeepCopyInto(out *RayCluster) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayCluster.
+func (in *RayCluster) DeepCopy() *RayCluster {
+	if in == nil {
+		return nil
+	}
+	out := new(RayCluster)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RayCluster) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayClusterList) DeepCopyInto(out *RayClusterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RayCluster, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayClusterList.
+func (in *RayClusterList) DeepCopy() *RayClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(RayClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RayClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayClusterSpec) DeepCopyInto(out *RayClusterSpec) {
+	*out = *in
+	if in.Suspend != nil {
+		in, out := &in.Suspend, &out.Suspend
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ManagedBy != nil {
+		in, out := &in.ManagedBy, &out.ManagedBy
+		*out = new(string)
+		**out = **in
+	}
+	if in.AutoscalerOptions != nil {
+		in, out := &in.AutoscalerOptions, &out.AutoscalerOptions
+		*out = new(AutoscalerOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HeadServiceAnnotations != nil {
+		in, out := &in.HeadServiceAnnotations, &out.HeadServiceAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EnableInTreeAutoscaling != nil {
+		in, out := &in.EnableInTreeAutoscaling, &out.EnableInTreeAutoscaling
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GcsFaultToleranceOptions != nil {
+		in, out := &in.GcsFaultToleranceOptions, &out.GcsFaultToleranceOptions
+		*out = new(GcsFaultToleranceOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceMeshOptions != nil {
+		in, out := &in.ServiceMeshOptions, &out.ServiceMeshOptions
+		*out = new(ServiceMeshOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IdleTimeoutSeconds != nil {
+		in, out := &in.IdleTimeoutSeconds, &out.IdleTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IdleAction != nil {
+		in, out := &in.IdleAction, &out.IdleAction
+		*out = new(IdleAction)
+		**out = **in
+	}
+	if in.ImagePrePull != nil {
+		in, out := &in.ImagePrePull, &out.ImagePrePull
+		*out = new(ImagePrePullOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DashboardIngress != nil {
+		in, out := &in.DashboardIngress, &out.DashboardIngress
+		*out = new(DashboardIngressOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Profiling != nil {
+		in, out := &in.Profiling, &out.Profiling
+		*out = new(ProfilingOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RayStartHooks != nil {
+		in, out := &in.RayStartHooks, &out.RayStartHooks
+		*out = new(RayStartHooks)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IPFamilyPolicy != nil {
+		in, out := &in.IPFamilyPolicy, &out.IPFamilyPolicy
+		*out = new(corev1.IPFamilyPolicy)
+		**out = **in
+	}
+	if in.IPFamilies != nil {
+		in, out := &in.IPFamilies, &out.IPFamilies
+		*out = make([]corev1.IPFamily, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterDomain != nil {
+		in, out := &in.ClusterDomain, &out.ClusterDomain
+		*out = new(string)
+		**out = **in
+	}
+	if in.MetricsOptions != nil {
+		in, out := &in.MetricsOptions, &out.MetricsOptions
+		*out = new(MetricsOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrometheusMonitoring != nil {
+		in, out := &in.PrometheusMonitoring, &out.PrometheusMonitoring
+		*out = new(PrometheusMonitoringOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TmpDirPolicy != nil {
+		in, out := &in.TmpDirPolicy, &out.TmpDirPolicy
+		*out = new(TmpDirPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MetadataSync != nil {
+		in, out := &in.MetadataSync, &out.MetadataSync
+		*out = new(MetadataSync)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DedicatedServiceAccount != nil {
+		in, out := &in.DedicatedServiceAccount, &out.DedicatedServiceAccount
+		*out = new(bool)
+		**out = **in
+	}
+	in.HeadGroupSpec.DeepCopyInto(&out.HeadGroupSpec)
+	if in.WorkerGroupSpecs != nil {
+		in, out := &in.WorkerGroupSpecs, &out.WorkerGroupSpecs
+		*out = make([]WorkerGroupSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayClusterSpec.
+func (in *RayClusterSpec) DeepCopy() *RayClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RayClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayClusterStatus) DeepCopyInto(out *RayClusterStatus) {
+	*out = *in
+	out.DesiredCPU = in.DesiredCPU.DeepCopy()
+	out.DesiredMemory = in.DesiredMemory.DeepCopy()
+	out.DesiredGPU = in.DesiredGPU.DeepCopy()
+	out.DesiredTPU = in.DesiredTPU.DeepCopy()
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.StateTransitionTimes != nil {
+		in, out := &in.StateTransitionTimes, &out.StateTransitionTimes
+		*out = make(map[ClusterState]*metav1.Time, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	out.Head = in.Head
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PendingResourceDemands != nil {
+		in, out := &in.PendingResourceDemands, &out.PendingResourceDemands
+		*out = make([]ResourceDemand, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastActivityTime != nil {
+		in, out := &in.LastActivityTime, &out.LastActivityTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastReconcileError != nil {
+		in, out := &in.LastReconcileError, &out.LastReconcileError
+		*out = new(ReconcileError)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MemoryFailures != nil {
+		in, out := &in.MemoryFailures, &out.MemoryFailures
+		*out = make([]GroupMemoryFailures, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Journal != nil {
+		in, out := &in.Journal, &out.Journal
+		*out = make([]JournalEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayClusterStatus.
+func (in *RayClusterStatus) DeepCopy() *RayClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RayClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayJob) DeepCopyInto(out *RayJob) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayJob.
+func (in *RayJob) DeepCopy() *RayJob {
+	if in == nil {
+		return nil
+	}
+	out := new(RayJob)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RayJob) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayJobList) DeepCopyInto(out *RayJobList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RayJob, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayJobList.
+func (in *RayJobList) DeepCopy() *RayJobList {
+	if in == nil {
+		return nil
+	}
+	out := new(RayJobList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RayJobList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayJobSpec) DeepCopyInto(out *RayJobSpec) {
+	*out = *in
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BackoffLimit != nil {
+		in, out := &in.BackoffLimit, &out.BackoffLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RayClusterSpec != nil {
+		in, out := &in.RayClusterSpec, &out.RayClusterSpec
+		*out = new(RayClusterSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SubmitterPodTemplate != nil {
+		in, out := &in.SubmitterPodTemplate, &out.SubmitterPodTemplate
+		*out = new(corev1.PodTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ClusterSelector != nil {
+		in, out := &in.ClusterSelector, &out.ClusterSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SubmitterConfig != nil {
+		in, out := &in.SubmitterConfig, &out.SubmitterConfig
+		*out = new(SubmitterConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InteractiveSession != nil {
+		in, out := &in.InteractiveSession, &out.InteractiveSession
+		*out = new(InteractiveSessionOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Checkpointing != nil {
+		in, out := &in.Checkpointing, &out.Checkpointing
+		*out = new(CheckpointingOptions)
+		**out = **in
+	}
+	if in.ManagedBy != nil {
+		in, out := &in.ManagedBy, &out.ManagedBy
+		*out = new(string)
+		**out = **in
+	}
+	if in.DeletionPolicy != nil {
+		in, out := &in.DeletionPolicy, &out.DeletionPolicy
+		*out = new(DeletionPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayJobSpec.
+func (in *RayJobSpec) DeepCopy() *RayJobSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RayJobSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayJobStatus) DeepCopyInto(out *RayJobStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.EndTime != nil {
+		in, out := &in.EndTime, &out.EndTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Succeeded != nil {
+		in, out := &in.Succeeded, &out.Succeeded
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Failed != nil {
+		in, out := &in.Failed, &out.Failed
+		*out = new(int32)
+		**out = **in
+	}
+	in.RayClusterStatus.DeepCopyInto(&out.RayClusterStatus)
+	if in.Session != nil {
+		in, out := &in.Session, &out.Session
+		*out = new(InteractiveSessionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceUsage != nil {
+		in, out := &in.ResourceUsage, &out.ResourceUsage
+		*out = new(ResourceUsage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastReconcileError != nil {
+		in, out := &in.LastReconcileError, &out.LastReconcileError
+		*out = new(ReconcileError)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Journal != nil {
+		in, out := &in.Journal, &out.Journal
+		*out = make([]JournalEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayJobStatus.
+func (in *RayJobStatus) DeepCopy() *RayJobStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RayJobStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayNodeProvisioningConfig) DeepCopyInto(out *RayNodeProvisioningConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayNodeProvisioningConfig.
+func (in *RayNodeProvisioningConfig) DeepCopy() *RayNodeProvisioningConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RayNodeProvisioningConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RayNodeProvisioningConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayNodeProvisioningConfigList) DeepCopyInto(out *RayNodeProvisioningConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RayNodeProvisioningConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayNodeProvisioningConfigList.
+func (in *RayNodeProvisioningConfigList) DeepCopy() *RayNodeProvisioningConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(RayNodeProvisioningConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RayNodeProvisioningConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayNodeProvisioningConfigSpec) DeepCopyInto(out *RayNodeProvisioningConfigSpec) {
+	*out = *in
+	if in.Accelerators != nil {
+		in, out := &in.Accelerators, &out.Accelerators
+		*out = make([]AcceleratorProvisioningRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayNodeProvisioningConfigSpec.
+func (in *RayNodeProvisioningConfigSpec) DeepCopy() *RayNodeProvisioningConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RayNodeProvisioningConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayPlacementPolicy) DeepCopyInto(out *RayPlacementPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayPlacementPolicy.
+func (in *RayPlacementPolicy) DeepCopy() *RayPlacementPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RayPlacementPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RayPlacementPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayPlacementPolicyList) DeepCopyInto(out *RayPlacementPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RayPlacementPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayPlacementPolicyList.
+func (in *RayPlacementPolicyList) DeepCopy() *RayPlacementPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(RayPlacementPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RayPlacementPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayPlacementPolicySpec) DeepCopyInto(out *RayPlacementPolicySpec) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayPlacementPolicySpec.
+func (in *RayPlacementPolicySpec) DeepCopy() *RayPlacementPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RayPlacementPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayQuota) DeepCopyInto(out *RayQuota) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayQuota.
+func (in *RayQuota) DeepCopy() *RayQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(RayQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RayQuota) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayQuotaList) DeepCopyInto(out *RayQuotaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RayQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayQuotaList.
+func (in *RayQuotaList) DeepCopy() *RayQuotaList {
+	if in == nil {
+		return nil
+	}
+	out := new(RayQuotaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RayQuotaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayQuotaSpec) DeepCopyInto(out *RayQuotaSpec) {
+	*out = *in
+	if in.Hard != nil {
+		in, out := &in.Hard, &out.Hard
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(RayQuotaPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayQuotaSpec.
+func (in *RayQuotaSpec) DeepCopy() *RayQuotaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RayQuotaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayQuotaStatus) DeepCopyInto(out *RayQuotaStatus) {
+	*out = *in
+	if in.Used != nil {
+		in, out := &in.Used, &out.Used
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayQuotaStatus.
+func (in *RayQuotaStatus) DeepCopy() *RayQuotaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RayQuotaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayService) DeepCopyInto(out *RayService) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayService.
+func (in *RayService) DeepCopy() *RayService {
+	if in == nil {
+		return nil
+	}
+	out := new(RayService)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RayService) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayServiceList) DeepCopyInto(out *RayServiceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RayService, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayServiceList.
+func (in *RayServiceList) DeepCopy() *RayServiceList {
+	if in == nil {
+		return nil
+	}
+	out := new(RayServiceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RayServiceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayServiceSpec) DeepCopyInto(out *RayServiceSpec) {
+	*out = *in
+	if in.ServiceUnhealthySecondThreshold != nil {
+		in, out := &in.ServiceUnhealthySecondThreshold, &out.ServiceUnhealthySecondThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DeploymentUnhealthySecondThreshold != nil {
+		in, out := &in.DeploymentUnhealthySecondThreshold, &out.DeploymentUnhealthySecondThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ServeService != nil {
+		in, out := &in.ServeService, &out.ServeService
+		*out = new(corev1.Service)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServeSessionAffinity != nil {
+		in, out := &in.ServeSessionAffinity, &out.ServeSessionAffinity
+		*out = new(ServeSessionAffinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServeTopologyRouting != nil {
+		in, out := &in.ServeTopologyRouting, &out.ServeTopologyRouting
+		*out = new(ServeTopologyRouting)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServeProbe != nil {
+		in, out := &in.ServeProbe, &out.ServeProbe
+		*out = new(ServeProbe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServeReadiness != nil {
+		in, out := &in.ServeReadiness, &out.ServeReadiness
+		*out = new(ServeReadiness)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServeProxyHealthCheck != nil {
+		in, out := &in.ServeProxyHealthCheck, &out.ServeProxyHealthCheck
+		*out = new(ServeProxyHealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServeAlerting != nil {
+		in, out := &in.ServeAlerting, &out.ServeAlerting
+		*out = new(ServeAlertingOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FailoverPolicy != nil {
+		in, out := &in.FailoverPolicy, &out.FailoverPolicy
+		*out = new(FailoverPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpgradeStrategy != nil {
+		in, out := &in.UpgradeStrategy, &out.UpgradeStrategy
+		*out = new(RayServiceUpgradeStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	in.RayClusterSpec.DeepCopyInto(&out.RayClusterSpec)
+	if in.ServeConfigSource != nil {
+		in, out := &in.ServeConfigSource, &out.ServeConfigSource
+		*out = new(ServeConfigSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServeConfigV2Variables != nil {
+		in, out := &in.ServeConfigV2Variables, &out.ServeConfigV2Variables
+		*out = make([]corev1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ServeApplicationWorkerGroups != nil {
+		in, out := &in.ServeApplicationWorkerGroups, &out.ServeApplicationWorkerGroups
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.QueueLabels != nil {
+		in, out := &in.QueueLabels, &out.QueueLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ServiceReconcileMode != nil {
+		in, out := &in.ServiceReconcileMode, &out.ServiceReconcileMode
+		*out = new(ServiceReconcileMode)
+		**out = **in
+	}
+	if in.DisableDashboard != nil {
+		in, out := &in.DisableDashboard, &out.DisableDashboard
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayServiceSpec.
+func (in *RayServiceSpec) DeepCopy() *RayServiceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RayServiceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayServiceStatus) DeepCopyInto(out *RayServiceStatus) {
+	*out = *in
+	if in.Applications != nil {
+		in, out := &in.Applications, &out.Applications
+		*out = make(map[string]AppStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	in.RayClusterStatus.DeepCopyInto(&out.RayClusterStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayServiceStatus.
+func (in *RayServiceStatus) DeepCopy() *RayServiceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RayServiceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayServiceStatuses) DeepCopyInto(out *RayServiceStatuses) {
+	*out = *in
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+	in.ActiveServiceStatus.DeepCopyInto(&out.ActiveServiceStatus)
+	in.PendingServiceStatus.DeepCopyInto(&out.PendingServiceStatus)
+	if in.LastReconcileError != nil {
+		in, out := &in.LastReconcileError, &out.LastReconcileError
+		*out = new(ReconcileError)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Journal != nil {
+		in, out := &in.Journal, &out.Journal
+		*out = make([]JournalEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RequestShadowing != nil {
+		in, out := &in.RequestShadowing, &out.RequestShadowing
+		*out = new(RequestShadowingStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServeConfigSource != nil {
+		in, out := &in.ServeConfigSource, &out.ServeConfigSource
+		*out = new(ServeConfigSourceStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceUsage != nil {
+		in, out := &in.ResourceUsage, &out.ResourceUsage
+		*out = new(ResourceUsage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayServiceStatuses.
+func (in *RayServiceStatuses) DeepCopy() *RayServiceStatuses {
+	if in == nil {
+		return nil
+	}
+	out := new(RayServiceStatuses)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayServiceUpgradeStrategy) DeepCopyInto(out *RayServiceUpgradeStrategy) {
+	*out = *in
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(RayServiceUpgradeType)
+		**out = **in
+	}
+	if in.EnablePreviewService != nil {
+		in, out := &in.EnablePreviewService, &out.EnablePreviewService
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Promotion != nil {
+		in, out := &in.Promotion, &out.Promotion
+		*out = new(RayServicePromotionType)
+		**out = **in
+	}
+	if in.IgnoredPaths != nil {
+		in, out := &in.IgnoredPaths, &out.IgnoredPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ApplicationScoped != nil {
+		in, out := &in.ApplicationScoped, &out.ApplicationScoped
+		*out = new(bool)
+		**out = **in
+	}
+	if in.KubeRayVersionPolicy != nil {
+		in, out := &in.KubeRayVersionPolicy, &out.KubeRayVersionPolicy
+		*out = new(KubeRayVersionPolicy)
+		**out = **in
+	}
+	if in.RequestShadowing != nil {
+		in, out := &in.RequestShadowing, &out.RequestShadowing
+		*out = new(RequestShadowing)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayServiceUpgradeStrategy.
+func (in *RayServiceUpgradeStrategy) DeepCopy() *RayServiceUpgradeStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(RayServiceUpgradeStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RayStartHooks) DeepCopyInto(out *RayStartHooks) {
+	*out = *in
+	if in.PreRayStart != nil {
+		in, out := &in.PreRayStart, &out.PreRayStart
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PostRayStart != nil {
+		in, out := &in.PostRayStart, &out.PostRayStart
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayStartHooks.
+func (in *RayStartHooks) DeepCopy() *RayStartHooks {
+	if in == nil {
+		return nil
+	}
+	out := new(RayStartHooks)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReconcileError) DeepCopyInto(out *ReconcileError) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReconcileError.
+func (in *ReconcileError) DeepCopy() *ReconcileError {
+	if in == nil {
+		return nil
+	}
+	out := new(ReconcileError)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisCredential) DeepCopyInto(out *RedisCredential) {
+	*out = *in
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(corev1.EnvVarSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisCredential.
+func (in *RedisCredential) DeepCopy() *RedisCredential {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisCredential)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequestShadowing) DeepCopyInto(out *RequestShadowing) {
+	*out = *in
+	if in.Percent != nil {
+		in, out := &in.Percent, &out.Percent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DurationSeconds != nil {
+		in, out := &in.DurationSeconds, &out.DurationSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxErrorRateIncreasePercent != nil {
+		in, out := &in.MaxErrorRateIncreasePercent, &out.MaxErrorRateIncreasePercent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestShadowing.
+func (in *RequestShadowing) DeepCopy() *RequestShadowing {
+	if in == nil {
+		return nil
+	}
+	out := new(RequestShadowing)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequestShadowingStatus) DeepCopyInto(out *RequestShadowingStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	out.ActiveBaseline = in.ActiveBaseline
+	out.PendingBaseline = in.PendingBaseline
+	out.Active = in.Active
+	out.Pending = in.Pending
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestShadowingStatus.
+func (in *RequestShadowingStatus) DeepCopy() *RequestShadowingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RequestShadowingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceDemand) DeepCopyInto(out *ResourceDemand) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(map[string]resource.Quantity, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceDemand.
+func (in *ResourceDemand) DeepCopy() *ResourceDemand {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceDemand)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceUsage) DeepCopyInto(out *ResourceUsage) {
+	*out = *in
+	if in.LastSampleTime != nil {
+		in, out := &in.LastSampleTime, &out.LastSampleTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceUsage.
+func (in *ResourceUsage) DeepCopy() *ResourceUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollingUpdateWorkerGroup) DeepCopyInto(out *RollingUpdateWorkerGroup) {
+	*out = *in
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdateWorkerGroup.
+func (in *RollingUpdateWorkerGroup) DeepCopy() *RollingUpdateWorkerGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(RollingUpdateWorkerGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleStrategy) DeepCopyInto(out *ScaleStrategy) {
+	*out = *in
+	if in.WorkersToDelete != nil {
+		in, out := &in.WorkersToDelete, &out.WorkersToDelete
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxParallelDeletions != nil {
+		in, out := &in.MaxParallelDeletions, &out.MaxParallelDeletions
+		*out = new(int32)
+		**out = **in
+	}
+	if in.RespectPodDisruptionBudgets != nil {
+		in, out := &in.RespectPodDisruptionBudgets, &out.RespectPodDisruptionBudgets
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleStrategy.
+func (in *ScaleStrategy) DeepCopy() *ScaleStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServeAlertingOptions) DeepCopyInto(out *ServeAlertingOptions) {
+	*out = *in
+	if in.Applications != nil {
+		in, out := &in.Applications, &out.Applications
+		*out = make([]ServeApplicationSLO, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayJobSpec.
-func (in *RayJobSpec) DeepCopy() *RayJobSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServeAlertingOptions.
+func (in *ServeAlertingOptions) DeepCopy() *ServeAlertingOptions {
 	if in == nil {
 		return nil
 	}
-	out := new(RayJobSpec)
+	out := new(ServeAlertingOptions)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RayJobStatus) DeepCopyInto(out *RayJobStatus) {
+func (in *ServeApplicationSLO) DeepCopyInto(out *ServeApplicationSLO) {
 	*out = *in
-	if in.StartTime != nil {
-		in, out := &in.StartTime, &out.StartTime
-		*out = (*in).DeepCopy()
-	}
-	if in.EndTime != nil {
-		in, out := &in.EndTime, &out.EndTime
-		*out = (*in).DeepCopy()
-	}
-	if in.Succeeded != nil {
-		in, out := &in.Succeeded, &out.Succeeded
+	if in.MaxUnhealthySeconds != nil {
+		in, out := &in.MaxUnhealthySeconds, &out.MaxUnhealthySeconds
 		*out = new(int32)
 		**out = **in
 	}
-	if in.Failed != nil {
-		in, out := &in.Failed, &out.Failed
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
 		*out = new(int32)
 		**out = **in
 	}
-	in.RayClusterStatus.DeepCopyInto(&out.RayClusterStatus)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayJobStatus.
-func (in *RayJobStatus) DeepCopy() *RayJobStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServeApplicationSLO.
+func (in *ServeApplicationSLO) DeepCopy() *ServeApplicationSLO {
 	if in == nil {
 		return nil
 	}
-	out := new(RayJobStatus)
+	out := new(ServeApplicationSLO)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RayService) DeepCopyInto(out *RayService) {
+func (in *ServeConfigSource) DeepCopyInto(out *ServeConfigSource) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.PollIntervalSeconds != nil {
+		in, out := &in.PollIntervalSeconds, &out.PollIntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.AuthSecretRef != nil {
+		in, out := &in.AuthSecretRef, &out.AuthSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayService.
-func (in *RayService) DeepCopy() *RayService {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServeConfigSource.
+func (in *ServeConfigSource) DeepCopy() *ServeConfigSource {
 	if in == nil {
 		return nil
 	}
-	out := new(RayService)
+	out := new(ServeConfigSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RayService) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServeConfigSourceStatus) DeepCopyInto(out *ServeConfigSourceStatus) {
+	*out = *in
+	if in.LastFetchTime != nil {
+		in, out := &in.LastFetchTime, &out.LastFetchTime
+		*out = (*in).DeepCopy()
 	}
-	return nil
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServeConfigSourceStatus.
+func (in *ServeConfigSourceStatus) DeepCopy() *ServeConfigSourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ServeConfigSourceStatus)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RayServiceList) DeepCopyInto(out *RayServiceList) {
+func (in *ServeDeploymentStatus) DeepCopyInto(out *ServeDeploymentStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]RayService, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.HealthLastUpdateTime != nil {
+		in, out := &in.HealthLastUpdateTime, &out.HealthLastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(ServeReplicasSummary)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayServiceList.
-func (in *RayServiceList) DeepCopy() *RayServiceList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServeDeploymentStatus.
+func (in *ServeDeploymentStatus) DeepCopy() *ServeDeploymentStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(RayServiceList)
+	out := new(ServeDeploymentStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RayServiceList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServeProbe) DeepCopyInto(out *ServeProbe) {
+	*out = *in
+	if in.Method != nil {
+		in, out := &in.Method, &out.Method
+		*out = new(string)
+		**out = **in
+	}
+	if in.Body != nil {
+		in, out := &in.Body, &out.Body
+		*out = new(string)
+		**out = **in
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ExpectedStatusCode != nil {
+		in, out := &in.ExpectedStatusCode, &out.ExpectedStatusCode
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LatencyThresholdMilliseconds != nil {
+		in, out := &in.LatencyThresholdMilliseconds, &out.LatencyThresholdMilliseconds
+		*out = new(int32)
+		**out = **in
 	}
-	return nil
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServeProbe.
+func (in *ServeProbe) DeepCopy() *ServeProbe {
+	if in == nil {
+		return nil
+	}
+	out := new(ServeProbe)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RayServiceSpec) DeepCopyInto(out *RayServiceSpec) {
+func (in *ServeProxyHealthCheck) DeepCopyInto(out *ServeProxyHealthCheck) {
 	*out = *in
-	if in.ServiceUnhealthySecondThreshold != nil {
-		in, out := &in.ServiceUnhealthySecondThreshold, &out.ServiceUnhealthySecondThreshold
+	if in.Path != nil {
+		in, out := &in.Path, &out.Path
+		*out = new(string)
+		**out = **in
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
 		*out = new(int32)
 		**out = **in
 	}
-	if in.DeploymentUnhealthySecondThreshold != nil {
-		in, out := &in.DeploymentUnhealthySecondThreshold, &out.DeploymentUnhealthySecondThreshold
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
 		*out = new(int32)
 		**out = **in
 	}
-	if in.ServeService != nil {
-		in, out := &in.ServeService, &out.ServeService
-		*out = new(corev1.Service)
-		(*in).DeepCopyInto(*out)
+	if in.ExpectedStatusCodes != nil {
+		in, out := &in.ExpectedStatusCodes, &out.ExpectedStatusCodes
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
 	}
-	if in.UpgradeStrategy != nil {
-		in, out := &in.UpgradeStrategy, &out.UpgradeStrategy
-		*out = new(RayServiceUpgradeStrategy)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServeProxyHealthCheck.
+func (in *ServeProxyHealthCheck) DeepCopy() *ServeProxyHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(ServeProxyHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServeReadiness) DeepCopyInto(out *ServeReadiness) {
+	*out = *in
+	if in.Metric != nil {
+		in, out := &in.Metric, &out.Metric
+		*out = new(ServeReadinessMetric)
 		(*in).DeepCopyInto(*out)
 	}
-	in.RayClusterSpec.DeepCopyInto(&out.RayClusterSpec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayServiceSpec.
-func (in *RayServiceSpec) DeepCopy() *RayServiceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServeReadiness.
+func (in *ServeReadiness) DeepCopy() *ServeReadiness {
 	if in == nil {
 		return nil
 	}
-	out := new(RayServiceSpec)
+	out := new(ServeReadiness)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RayServiceStatus) DeepCopyInto(out *RayServiceStatus) {
+func (in *ServeReadinessMetric) DeepCopyInto(out *ServeReadinessMetric) {
 	*out = *in
-	if in.Applications != nil {
-		in, out := &in.Applications, &out.Applications
-		*out = make(map[string]AppStatus, len(*in))
-		for key, val := range *in {
-			(*out)[key] = *val.DeepCopy()
-		}
+	if in.MinValue != nil {
+		in, out := &in.MinValue, &out.MinValue
+		*out = new(int32)
+		**out = **in
 	}
-	in.RayClusterStatus.DeepCopyInto(&out.RayClusterStatus)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayServiceStatus.
-func (in *RayServiceStatus) DeepCopy() *RayServiceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServeReadinessMetric.
+func (in *ServeReadinessMetric) DeepCopy() *ServeReadinessMetric {
 	if in == nil {
 		return nil
 	}
-	out := new(RayServiceStatus)
+	out := new(ServeReadinessMetric)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RayServiceStatuses) DeepCopyInto(out *RayServiceStatuses) {
+func (in *ServeReplicasSummary) DeepCopyInto(out *ServeReplicasSummary) {
 	*out = *in
-	if in.LastUpdateTime != nil {
-		in, out := &in.LastUpdateTime, &out.LastUpdateTime
-		*out = (*in).DeepCopy()
+	if in.States != nil {
+		in, out := &in.States, &out.States
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	in.ActiveServiceStatus.DeepCopyInto(&out.ActiveServiceStatus)
-	in.PendingServiceStatus.DeepCopyInto(&out.PendingServiceStatus)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayServiceStatuses.
-func (in *RayServiceStatuses) DeepCopy() *RayServiceStatuses {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServeReplicasSummary.
+func (in *ServeReplicasSummary) DeepCopy() *ServeReplicasSummary {
 	if in == nil {
 		return nil
 	}
-	out := new(RayServiceStatuses)
+	out := new(ServeReplicasSummary)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RayServiceUpgradeStrategy) DeepCopyInto(out *RayServiceUpgradeStrategy) {
+func (in *ServeRequestCounts) DeepCopyInto(out *ServeRequestCounts) {
 	*out = *in
-	if in.Type != nil {
-		in, out := &in.Type, &out.Type
-		*out = new(RayServiceUpgradeType)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RayServiceUpgradeStrategy.
-func (in *RayServiceUpgradeStrategy) DeepCopy() *RayServiceUpgradeStrategy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServeRequestCounts.
+func (in *ServeRequestCounts) DeepCopy() *ServeRequestCounts {
 	if in == nil {
 		return nil
 	}
-	out := new(RayServiceUpgradeStrategy)
+	out := new(ServeRequestCounts)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RedisCredential) DeepCopyInto(out *RedisCredential) {
+func (in *ServeSessionAffinity) DeepCopyInto(out *ServeSessionAffinity) {
 	*out = *in
-	if in.ValueFrom != nil {
-		in, out := &in.ValueFrom, &out.ValueFrom
-		*out = new(corev1.EnvVarSource)
-		(*in).DeepCopyInto(*out)
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CookieName != nil {
+		in, out := &in.CookieName, &out.CookieName
+		*out = new(string)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisCredential.
-func (in *RedisCredential) DeepCopy() *RedisCredential {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServeSessionAffinity.
+func (in *ServeSessionAffinity) DeepCopy() *ServeSessionAffinity {
 	if in == nil {
 		return nil
 	}
-	out := new(RedisCredential)
+	out := new(ServeSessionAffinity)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ScaleStrategy) DeepCopyInto(out *ScaleStrategy) {
+func (in *ServeTopologyRouting) DeepCopyInto(out *ServeTopologyRouting) {
 	*out = *in
-	if in.WorkersToDelete != nil {
-		in, out := &in.WorkersToDelete, &out.WorkersToDelete
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.Mode != nil {
+		in, out := &in.Mode, &out.Mode
+		*out = new(ServeTopologyRoutingMode)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleStrategy.
-func (in *ScaleStrategy) DeepCopy() *ScaleStrategy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServeTopologyRouting.
+func (in *ServeTopologyRouting) DeepCopy() *ServeTopologyRouting {
 	if in == nil {
 		return nil
 	}
-	out := new(ScaleStrategy)
+	out := new(ServeTopologyRouting)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServeDeploymentStatus) DeepCopyInto(out *ServeDeploymentStatus) {
+func (in *ServiceMeshOptions) DeepCopyInto(out *ServiceMeshOptions) {
 	*out = *in
-	if in.HealthLastUpdateTime != nil {
-		in, out := &in.HealthLastUpdateTime, &out.HealthLastUpdateTime
-		*out = (*in).DeepCopy()
+	if in.ExcludedPorts != nil {
+		in, out := &in.ExcludedPorts, &out.ExcludedPorts
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServeDeploymentStatus.
-func (in *ServeDeploymentStatus) DeepCopy() *ServeDeploymentStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceMeshOptions.
+func (in *ServiceMeshOptions) DeepCopy() *ServiceMeshOptions {
 	if in == nil {
 		return nil
 	}
-	out := new(ServeDeploymentStatus)
+	out := new(ServiceMeshOptions)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -717,6 +2427,11 @@ func (in *SubmitterConfig) DeepCopyInto(out *SubmitterConfig) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ReuseSubmitterPod != nil {
+		in, out := &in.ReuseSubmitterPod, &out.ReuseSubmitterPod
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubmitterConfig.
@@ -729,6 +2444,31 @@ func (in *SubmitterConfig) DeepCopy() *SubmitterConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TmpDirPolicy) DeepCopyInto(out *TmpDirPolicy) {
+	*out = *in
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TmpDirPolicy.
+func (in *TmpDirPolicy) DeepCopy() *TmpDirPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(TmpDirPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkerGroupSpec) DeepCopyInto(out *WorkerGroupSpec) {
 	*out = *in
@@ -757,6 +2497,11 @@ func (in *WorkerGroupSpec) DeepCopyInto(out *WorkerGroupSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ObjectStoreMemoryPercent != nil {
+		in, out := &in.ObjectStoreMemoryPercent, &out.ObjectStoreMemoryPercent
+		*out = new(int32)
+		**out = **in
+	}
 	if in.RayStartParams != nil {
 		in, out := &in.RayStartParams, &out.RayStartParams
 		*out = make(map[string]string, len(*in))
@@ -766,6 +2511,31 @@ func (in *WorkerGroupSpec) DeepCopyInto(out *WorkerGroupSpec) {
 	}
 	in.Template.DeepCopyInto(&out.Template)
 	in.ScaleStrategy.DeepCopyInto(&out.ScaleStrategy)
+	if in.EnableRankEnv != nil {
+		in, out := &in.EnableRankEnv, &out.EnableRankEnv
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GracefulDrainSeconds != nil {
+		in, out := &in.GracefulDrainSeconds, &out.GracefulDrainSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(WorkerGroupUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Burstable != nil {
+		in, out := &in.Burstable, &out.Burstable
+		*out = new(BurstableOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OOMPolicy != nil {
+		in, out := &in.OOMPolicy, &out.OOMPolicy
+		*out = new(OOMPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerGroupSpec.
@@ -777,3 +2547,23 @@ func (in *WorkerGroupSpec) DeepCopy() *WorkerGroupSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkerGroupUpdateStrategy) DeepCopyInto(out *WorkerGroupUpdateStrategy) {
+	*out = *in
+	if in.RollingUpdate != nil {
+		in, out := &in.RollingUpdate, &out.RollingUpdate
+		*out = new(RollingUpdateWorkerGroup)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkerGroupUpdateStrategy.
+func (in *WorkerGroupUpdateStrategy) DeepCopy() *WorkerGroupUpdateStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkerGroupUpdateStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
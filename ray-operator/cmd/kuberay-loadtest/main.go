@@ -0,0 +1,110 @@
+// The kuberay-loadtest binary measures the KubeRay controllers at scale. It runs the controllers in the same process
+// against a Kubernetes cluster, or against an envtest control plane with `--envtest`, creates many RayClusters and
+// RayServices with fake Ray dashboard backends, and prints a JSON report of the reconcile latencies, the API server
+// requests, and the peak memory. It exits with an error if the report exceeds any of the thresholds.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/ray-project/kuberay/ray-operator/test/loadtest"
+)
+
+func main() {
+	os.Exit(run())
+}
+
+// run returns the exit code, so that the deferred calls, such as stopping envtest, run before the binary exits.
+func run() int {
+	var options loadtest.Options
+	var thresholds loadtest.Thresholds
+	var useEnvtest bool
+	var crdDirectory string
+	var maxPeakHeapMB uint64
+
+	flag.StringVar(&options.Namespace, "namespace", "kuberay-loadtest", "The namespace to create the custom resources in.")
+	flag.IntVar(&options.RayClusters, "rayclusters", 100, "The number of RayClusters to create.")
+	flag.IntVar(&options.RayServices, "rayservices", 20, "The number of RayServices to create.")
+	var workers int
+	flag.IntVar(&workers, "workers-per-cluster", 1, "The number of worker Pods of each RayCluster.")
+	flag.DurationVar(&options.Timeout, "timeout", 10*time.Minute, "How long to wait for the custom resources to become ready.")
+	flag.DurationVar(&options.SteadyStateDuration, "steady-state-duration", time.Minute, "How long to measure the controllers after the custom resources are ready.")
+	flag.IntVar(&options.ReconcileConcurrency, "reconcile-concurrency", 1, "The number of concurrent reconciliations of each controller.")
+	flag.BoolVar(&options.FakeKubelet, "fake-kubelet", false, "Mark the Ray Pods as running and ready. Implied by --envtest.")
+	flag.BoolVar(&options.Cleanup, "cleanup", true, "Delete the custom resources at the end of the run.")
+	flag.BoolVar(&useEnvtest, "envtest", false, "Run against an envtest control plane instead of the cluster of the kubeconfig.")
+	flag.StringVar(&crdDirectory, "crd-dir", "config/crd/bases", "The directory of the KubeRay CRDs to install in the envtest control plane.")
+	flag.DurationVar(&thresholds.MaxTimeToReadyP99, "max-time-to-ready-p99", 0, "Fail if the p99 time to ready of a kind exceeds this duration. 0 disables the check.")
+	flag.Float64Var(&thresholds.MaxSteadyStateAPIQPS, "max-steady-state-qps", 0, "Fail if the steady state API QPS exceeds this value. 0 disables the check.")
+	flag.Uint64Var(&maxPeakHeapMB, "max-peak-heap-mb", 0, "Fail if the peak heap exceeds this many MiB. 0 disables the check.")
+	flag.BoolVar(&thresholds.AllowNotReadyResource, "allow-not-ready", false, "Don't fail if some custom resources don't become ready before the timeout.")
+	opts := zap.Options{Development: true, TimeEncoder: zapcore.ISO8601TimeEncoder}
+	opts.BindFlags(flag.CommandLine)
+	flag.Parse()
+
+	options.WorkersPerCluster = int32(workers) //nolint:gosec // the flag is small
+	thresholds.MaxPeakHeapBytes = maxPeakHeapMB * 1024 * 1024
+	logger := zap.New(zap.UseFlagOptions(&opts))
+	ctrl.SetLogger(logger)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	ctx = ctrl.LoggerInto(ctx, logger)
+
+	var config *rest.Config
+	if useEnvtest {
+		testEnv := &envtest.Environment{
+			CRDDirectoryPaths:     []string{crdDirectory},
+			ErrorIfCRDPathMissing: true,
+		}
+		var err error
+		if config, err = testEnv.Start(); err != nil {
+			logger.Error(err, "Failed to start the envtest control plane")
+			return 1
+		}
+		defer func() {
+			if err := testEnv.Stop(); err != nil {
+				logger.Error(err, "Failed to stop the envtest control plane")
+			}
+		}()
+		// There are no kubelets in envtest.
+		options.FakeKubelet = true
+		// The API server of envtest doesn't garbage collect, so there is nothing to gain from the cleanup.
+		options.Cleanup = false
+	} else {
+		config = ctrl.GetConfigOrDie()
+	}
+
+	report, err := loadtest.Run(ctx, config, options)
+	if err != nil {
+		logger.Error(err, "The load test failed")
+		return 1
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		logger.Error(err, "Failed to print the report")
+		return 1
+	}
+
+	if violations := report.Violations(thresholds); len(violations) > 0 {
+		for _, violation := range violations {
+			fmt.Fprintln(os.Stderr, violation)
+		}
+		return 1
+	}
+	return 0
+}
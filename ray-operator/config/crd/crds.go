@@ -0,0 +1,9 @@
+// Package crd bundles the KubeRay CRDs with the operator binary.
+package crd
+
+import "embed"
+
+// Bases contains the generated CRD manifests.
+//
+//go:embed bases/*.yaml
+var Bases embed.FS
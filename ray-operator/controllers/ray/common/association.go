@@ -90,6 +90,25 @@ func RayClusterHeadPodsAssociationOptions(instance *rayv1.RayCluster) Associatio
 	}
 }
 
+func RayClusterHeadStandbyPodsAssociationOptions(instance *rayv1.RayCluster) AssociationOptions {
+	return AssociationOptions{
+		client.InNamespace(instance.Namespace),
+		client.MatchingLabels{
+			utils.RayClusterLabelKey:  instance.Name,
+			utils.RayNodeTypeLabelKey: utils.RayNodeHeadStandbyLabelValue,
+		},
+	}
+}
+
+func RayClusterBalloonPodsAssociationOptions(instance *rayv1.RayCluster) AssociationOptions {
+	return AssociationOptions{
+		client.InNamespace(instance.Namespace),
+		client.MatchingLabels{
+			utils.RayBalloonPodClusterLabelKey: instance.Name,
+		},
+	}
+}
+
 func RayClusterWorkerPodsAssociationOptions(instance *rayv1.RayCluster) AssociationOptions {
 	return AssociationOptions{
 		client.InNamespace(instance.Namespace),
@@ -110,12 +129,33 @@ func RayClusterRedisCleanupJobAssociationOptions(instance *rayv1.RayCluster) Ass
 	}
 }
 
+// RayClusterGroupPodsAssociationOptions selects the Pods of the group in the namespace of the group, which differs from
+// the namespace of the RayCluster for worker groups with `namespace`.
 func RayClusterGroupPodsAssociationOptions(instance *rayv1.RayCluster, group string) AssociationOptions {
+	namespace := instance.Namespace
+	labels := client.MatchingLabels{
+		utils.RayClusterLabelKey:   instance.Name,
+		utils.RayNodeGroupLabelKey: group,
+	}
+	for _, worker := range instance.Spec.WorkerGroupSpecs {
+		if worker.GroupName == group && IsRemoteWorkerGroup(instance, worker) {
+			namespace = worker.Namespace
+			labels[utils.RayClusterNamespaceLabelKey] = instance.Namespace
+		}
+	}
+	return AssociationOptions{
+		client.InNamespace(namespace),
+		labels,
+	}
+}
+
+// RayClusterRemoteWorkerPodsAssociationOptions selects the worker Pods of the RayCluster in all the namespaces other
+// than the namespace of the RayCluster.
+func RayClusterRemoteWorkerPodsAssociationOptions(instance *rayv1.RayCluster) AssociationOptions {
 	return AssociationOptions{
-		client.InNamespace(instance.Namespace),
 		client.MatchingLabels{
-			utils.RayClusterLabelKey:   instance.Name,
-			utils.RayNodeGroupLabelKey: group,
+			utils.RayClusterLabelKey:          instance.Name,
+			utils.RayClusterNamespaceLabelKey: instance.Namespace,
 		},
 	}
 }
@@ -364,3 +364,45 @@ func TestRayClusterNetworkResourcesOptions(t *testing.T) {
 
 	assert.Equal(t, expected, result)
 }
+
+func TestRayClusterGroupPodsAssociationOptions(t *testing.T) {
+	instance := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "raycluster-example",
+			Namespace: "default",
+		},
+		Spec: rayv1.RayClusterSpec{
+			WorkerGroupSpecs: []rayv1.WorkerGroupSpec{
+				{GroupName: "local"},
+				{GroupName: "remote", Namespace: "tenant"},
+			},
+		},
+	}
+
+	expected := []client.ListOption{
+		client.InNamespace(instance.Namespace),
+		client.MatchingLabels(map[string]string{
+			utils.RayClusterLabelKey:   instance.Name,
+			utils.RayNodeGroupLabelKey: "local",
+		}),
+	}
+	assert.Equal(t, expected, RayClusterGroupPodsAssociationOptions(instance, "local").ToListOptions())
+
+	expected = []client.ListOption{
+		client.InNamespace("tenant"),
+		client.MatchingLabels(map[string]string{
+			utils.RayClusterLabelKey:          instance.Name,
+			utils.RayNodeGroupLabelKey:        "remote",
+			utils.RayClusterNamespaceLabelKey: instance.Namespace,
+		}),
+	}
+	assert.Equal(t, expected, RayClusterGroupPodsAssociationOptions(instance, "remote").ToListOptions())
+
+	expected = []client.ListOption{
+		client.MatchingLabels(map[string]string{
+			utils.RayClusterLabelKey:          instance.Name,
+			utils.RayClusterNamespaceLabelKey: instance.Namespace,
+		}),
+	}
+	assert.Equal(t, expected, RayClusterRemoteWorkerPodsAssociationOptions(instance).ToListOptions())
+}
@@ -0,0 +1,168 @@
+package common
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+const BalloonContainerName = "balloon"
+
+// CalculateBalloonPods returns the number of balloon Pods needed by each worker group to satisfy the pending
+// resource demands. Each demand is assigned to the first worker group whose Ray node provides every resource of the
+// demand, and a worker group never gets more balloon Pods than it can scale up by. The total is capped by MaxPods.
+func CalculateBalloonPods(cluster *rayv1.RayCluster, demands []rayv1.ResourceDemand) map[string]int32 {
+	maxPods := int32(utils.DefaultBalloonPodMaxPods)
+	if options := cluster.Spec.AutoscalerOptions; options != nil && options.BalloonPods != nil && options.BalloonPods.MaxPods != nil {
+		maxPods = *options.BalloonPods.MaxPods
+	}
+
+	type groupCapacity struct {
+		resources map[string]float64
+		headroom  int32
+	}
+	groups := make([]groupCapacity, len(cluster.Spec.WorkerGroupSpecs))
+	for i, worker := range cluster.Spec.WorkerGroupSpecs {
+		if worker.Suspend != nil && *worker.Suspend {
+			continue
+		}
+		headroom := int32(0)
+		if worker.MaxReplicas != nil && worker.Replicas != nil {
+			headroom = *worker.MaxReplicas - *worker.Replicas
+		}
+		groups[i] = groupCapacity{
+			resources: getRayNodeResources(worker.RayStartParams, worker.Template.Spec.Containers[utils.RayContainerIndex].Resources),
+			headroom:  headroom,
+		}
+	}
+
+	counts := map[string]int32{}
+	total := int32(0)
+	for _, demand := range demands {
+		if total >= maxPods {
+			break
+		}
+		for i := range groups {
+			bundlesPerPod := bundlesPerRayNode(groups[i].resources, demand.Resources)
+			if bundlesPerPod == 0 || groups[i].headroom <= 0 {
+				continue
+			}
+			pods := int32(math.Ceil(float64(demand.Count) / float64(bundlesPerPod)))
+			pods = min(pods, groups[i].headroom, maxPods-total)
+			groupName := cluster.Spec.WorkerGroupSpecs[i].GroupName
+			counts[groupName] += pods
+			groups[i].headroom -= pods
+			total += pods
+			break
+		}
+	}
+	return counts
+}
+
+// BuildBalloonPod returns a placeholder Pod that requests the same resources and has the same scheduling constraints
+// as a Pod of the worker group. The Pod is labeled with RayBalloonPodClusterLabelKey instead of RayClusterLabelKey so
+// that KubeRay never treats it as a Ray Pod.
+func BuildBalloonPod(cluster *rayv1.RayCluster, worker rayv1.WorkerGroupSpec) corev1.Pod {
+	options := cluster.Spec.AutoscalerOptions.BalloonPods
+	image := utils.DefaultBalloonPodImage
+	if options.Image != nil {
+		image = *options.Image
+	}
+	templateSpec := worker.Template.Spec.DeepCopy()
+	podResource := utils.CalculatePodResource(*templateSpec)
+
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: utils.CheckName(fmt.Sprintf("%s-%s-balloon", cluster.Name, worker.GroupName)) + utils.DashSymbol,
+			Namespace:    cluster.Namespace,
+			Labels: map[string]string{
+				utils.RayBalloonPodClusterLabelKey: cluster.Name,
+				utils.RayNodeGroupLabelKey:         worker.GroupName,
+				utils.KubernetesCreatedByLabelKey:  utils.ComponentName,
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  BalloonContainerName,
+					Image: image,
+					Resources: corev1.ResourceRequirements{
+						Requests: podResource,
+						Limits:   podResource,
+					},
+				},
+			},
+			PriorityClassName:             options.PriorityClassName,
+			NodeSelector:                  templateSpec.NodeSelector,
+			Affinity:                      templateSpec.Affinity,
+			Tolerations:                   templateSpec.Tolerations,
+			TopologySpreadConstraints:     templateSpec.TopologySpreadConstraints,
+			RuntimeClassName:              templateSpec.RuntimeClassName,
+			TerminationGracePeriodSeconds: ptr.To[int64](0),
+		},
+	}
+}
+
+// getRayNodeResources returns the Ray resources of a node, following the same rules as generateRayStartCommand.
+func getRayNodeResources(rayStartParams map[string]string, containerResource corev1.ResourceRequirements) map[string]float64 {
+	params := make(map[string]string, len(rayStartParams))
+	for k, v := range rayStartParams {
+		params[k] = v
+	}
+	if err := addWellKnownAcceleratorResources(params, containerResource.Limits); err != nil {
+		return nil
+	}
+	resources, err := getResourcesMap(params)
+	if err != nil {
+		return nil
+	}
+
+	if value, ok := params["num-cpus"]; ok {
+		resources["CPU"] = parseRayStartFloat(value)
+	} else if cpu := containerResource.Limits[corev1.ResourceCPU]; !cpu.IsZero() {
+		resources["CPU"] = float64(cpu.Value())
+	} else if cpu := containerResource.Requests[corev1.ResourceCPU]; !cpu.IsZero() {
+		resources["CPU"] = float64(cpu.Value())
+	}
+	if value, ok := params["memory"]; ok {
+		resources["memory"] = parseRayStartFloat(value)
+	} else if memory := containerResource.Limits[corev1.ResourceMemory]; !memory.IsZero() {
+		resources["memory"] = float64(memory.Value())
+	}
+	if value, ok := params["num-gpus"]; ok {
+		resources["GPU"] = parseRayStartFloat(value)
+	}
+	return resources
+}
+
+func parseRayStartFloat(value string) float64 {
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0
+	}
+	return f
+}
+
+// bundlesPerRayNode returns how many requests of the demand shape fit in a Ray node.
+func bundlesPerRayNode(nodeResources map[string]float64, shape map[string]resource.Quantity) int32 {
+	bundles := int32(math.MaxInt32)
+	for name, quantity := range shape {
+		request := quantity.AsApproximateFloat64()
+		if request <= 0 {
+			continue
+		}
+		bundles = min(bundles, int32(nodeResources[name]/request))
+	}
+	if bundles == math.MaxInt32 {
+		return 0
+	}
+	return bundles
+}
@@ -0,0 +1,72 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestCalculateBalloonPods(t *testing.T) {
+	cluster := instance.DeepCopy()
+	cpuWorker := cluster.Spec.WorkerGroupSpecs[0].DeepCopy()
+	cpuWorker.GroupName = "cpu"
+	cpuWorker.RayStartParams = map[string]string{}
+	cpuWorker.Replicas = ptr.To[int32](0)
+	cpuWorker.MaxReplicas = ptr.To[int32](2)
+	cpuWorker.Template.Spec.Containers[utils.RayContainerIndex].Resources = corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("4"),
+			corev1.ResourceMemory: resource.MustParse("8Gi"),
+		},
+	}
+	gpuWorker := cpuWorker.DeepCopy()
+	gpuWorker.GroupName = "gpu"
+	gpuWorker.MaxReplicas = ptr.To[int32](10)
+	gpuWorker.Template.Spec.Containers[utils.RayContainerIndex].Resources.Limits["nvidia.com/gpu"] = resource.MustParse("1")
+	cluster.Spec.WorkerGroupSpecs = []rayv1.WorkerGroupSpec{*cpuWorker, *gpuWorker}
+	cluster.Spec.AutoscalerOptions = &rayv1.AutoscalerOptions{
+		BalloonPods: &rayv1.BalloonPodsOptions{PriorityClassName: "balloon", MaxPods: ptr.To[int32](5)},
+	}
+
+	demand := func(count int32, resources map[string]string) rayv1.ResourceDemand {
+		shape := map[string]resource.Quantity{}
+		for name, value := range resources {
+			shape[name] = resource.MustParse(value)
+		}
+		return rayv1.ResourceDemand{Resources: shape, Count: count}
+	}
+
+	// 9 tasks with 1 CPU need 3 CPU workers, but the CPU worker group can only scale up by 2.
+	assert.Equal(t, map[string]int32{"cpu": 2}, CalculateBalloonPods(cluster, []rayv1.ResourceDemand{demand(9, map[string]string{"CPU": "1"})}))
+	// GPU demands only fit the GPU worker group, and the total is capped by MaxPods.
+	assert.Equal(t, map[string]int32{"cpu": 1, "gpu": 4}, CalculateBalloonPods(cluster, []rayv1.ResourceDemand{
+		demand(2, map[string]string{"CPU": "2", "memory": "1Gi"}),
+		demand(6, map[string]string{"GPU": "1"}),
+	}))
+	// Demands of resources that no worker group provides are ignored.
+	assert.Empty(t, CalculateBalloonPods(cluster, []rayv1.ResourceDemand{demand(1, map[string]string{"custom": "1"})}))
+	assert.Empty(t, CalculateBalloonPods(cluster, []rayv1.ResourceDemand{demand(1, map[string]string{"CPU": "8"})}))
+}
+
+func TestBuildBalloonPod(t *testing.T) {
+	cluster := instance.DeepCopy()
+	cluster.Spec.AutoscalerOptions = &rayv1.AutoscalerOptions{
+		BalloonPods: &rayv1.BalloonPodsOptions{PriorityClassName: "balloon"},
+	}
+	worker := cluster.Spec.WorkerGroupSpecs[0]
+	worker.Template.Spec.Tolerations = []corev1.Toleration{{Key: "ray", Operator: corev1.TolerationOpExists}}
+
+	pod := BuildBalloonPod(cluster, worker)
+	assert.Equal(t, cluster.Name, pod.Labels[utils.RayBalloonPodClusterLabelKey])
+	assert.NotContains(t, pod.Labels, utils.RayClusterLabelKey)
+	assert.Equal(t, "balloon", pod.Spec.PriorityClassName)
+	assert.Equal(t, worker.Template.Spec.Tolerations, pod.Spec.Tolerations)
+	assert.Equal(t, utils.DefaultBalloonPodImage, pod.Spec.Containers[0].Image)
+	assert.Equal(t, utils.CalculatePodResource(worker.Template.Spec), pod.Spec.Containers[0].Resources.Requests)
+}
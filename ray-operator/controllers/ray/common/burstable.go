@@ -0,0 +1,86 @@
+package common
+
+import (
+	"slices"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// burstableNodeAffinityWeight is the weight of the preference of burstable worker Pods for physical nodes. It's the
+// maximum weight so that the Pods only land on virtual nodes when no physical node fits them.
+const burstableNodeAffinityWeight = 100
+
+// GetVirtualNodeSelector returns the labels of the virtual nodes of a burstable worker group.
+func GetVirtualNodeSelector(options *rayv1.BurstableOptions) map[string]string {
+	if options == nil || len(options.VirtualNodeSelector) == 0 {
+		return map[string]string{utils.DefaultVirtualNodeLabelKey: utils.DefaultVirtualNodeLabelValue}
+	}
+	return options.VirtualNodeSelector
+}
+
+// getVirtualNodeTolerations returns the tolerations of the taints of the virtual nodes of a burstable worker group.
+func getVirtualNodeTolerations(options *rayv1.BurstableOptions) []corev1.Toleration {
+	if len(options.Tolerations) == 0 {
+		return []corev1.Toleration{{Key: utils.VirtualKubeletTaintKey, Operator: corev1.TolerationOpExists}}
+	}
+	return options.Tolerations
+}
+
+// ApplyBurstableOptions lets the worker Pod be scheduled on the virtual nodes of a burstable worker group: it labels
+// the Pod, adds the tolerations of the virtual nodes that the Pod doesn't tolerate yet, and makes the Pod prefer the
+// physical nodes.
+func ApplyBurstableOptions(pod *corev1.Pod, options *rayv1.BurstableOptions) {
+	if options == nil {
+		return
+	}
+	if pod.Labels == nil {
+		pod.Labels = map[string]string{}
+	}
+	pod.Labels[utils.RayBurstableLabelKey] = "true"
+
+	// The tolerations and the affinity may be shared with the Pod template of the RayCluster, so they're copied
+	// before being modified.
+	pod.Spec.Tolerations = slices.Clone(pod.Spec.Tolerations)
+	for _, toleration := range getVirtualNodeTolerations(options) {
+		if !slices.ContainsFunc(pod.Spec.Tolerations, func(t corev1.Toleration) bool { return t.MatchToleration(&toleration) }) {
+			pod.Spec.Tolerations = append(pod.Spec.Tolerations, toleration)
+		}
+	}
+
+	virtualNodeSelector := GetVirtualNodeSelector(options)
+	keys := make([]string, 0, len(virtualNodeSelector))
+	for key := range virtualNodeSelector {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	// NotIn also matches the nodes without the label, i.e. the physical nodes.
+	term := corev1.NodeSelectorTerm{}
+	for _, key := range keys {
+		term.MatchExpressions = append(term.MatchExpressions, corev1.NodeSelectorRequirement{
+			Key:      key,
+			Operator: corev1.NodeSelectorOpNotIn,
+			Values:   []string{virtualNodeSelector[key]},
+		})
+	}
+	pod.Spec.Affinity = pod.Spec.Affinity.DeepCopy()
+	if pod.Spec.Affinity == nil {
+		pod.Spec.Affinity = &corev1.Affinity{}
+	}
+	if pod.Spec.Affinity.NodeAffinity == nil {
+		pod.Spec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	pod.Spec.Affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(
+		pod.Spec.Affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+		corev1.PreferredSchedulingTerm{Weight: burstableNodeAffinityWeight, Preference: term},
+	)
+}
+
+// IsVirtualNode returns whether the node is a virtual node of a burstable worker group.
+func IsVirtualNode(node *corev1.Node, options *rayv1.BurstableOptions) bool {
+	return labels.SelectorFromSet(GetVirtualNodeSelector(options)).Matches(labels.Set(node.Labels))
+}
@@ -0,0 +1,66 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestApplyBurstableOptions(t *testing.T) {
+	pod := &corev1.Pod{}
+	ApplyBurstableOptions(pod, nil)
+	assert.Equal(t, &corev1.Pod{}, pod)
+
+	// The defaults tolerate and avoid the virtual-kubelet nodes.
+	ApplyBurstableOptions(pod, &rayv1.BurstableOptions{})
+	assert.Equal(t, "true", pod.Labels[utils.RayBurstableLabelKey])
+	assert.Equal(t, []corev1.Toleration{{Key: utils.VirtualKubeletTaintKey, Operator: corev1.TolerationOpExists}}, pod.Spec.Tolerations)
+	assert.Equal(t, []corev1.PreferredSchedulingTerm{{
+		Weight: 100,
+		Preference: corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{
+			{Key: utils.DefaultVirtualNodeLabelKey, Operator: corev1.NodeSelectorOpNotIn, Values: []string{utils.DefaultVirtualNodeLabelValue}},
+		}},
+	}}, pod.Spec.Affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution)
+
+	// The tolerations of the template are kept, and the template isn't modified.
+	fargateToleration := corev1.Toleration{Key: "eks.amazonaws.com/compute-type", Operator: corev1.TolerationOpEqual, Value: "fargate", Effect: corev1.TaintEffectNoSchedule}
+	templateTolerations := []corev1.Toleration{fargateToleration}
+	templateAffinity := &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+		PreferredDuringSchedulingIgnoredDuringExecution: []corev1.PreferredSchedulingTerm{{Weight: 1}},
+	}}
+	pod = &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{utils.RayNodeGroupLabelKey: "burst"}},
+		Spec:       corev1.PodSpec{Tolerations: templateTolerations, Affinity: templateAffinity},
+	}
+	ApplyBurstableOptions(pod, &rayv1.BurstableOptions{
+		VirtualNodeSelector: map[string]string{"eks.amazonaws.com/compute-type": "fargate", "b": "c"},
+		Tolerations:         []corev1.Toleration{fargateToleration},
+	})
+	assert.Equal(t, map[string]string{utils.RayNodeGroupLabelKey: "burst", utils.RayBurstableLabelKey: "true"}, pod.Labels)
+	assert.Equal(t, []corev1.Toleration{fargateToleration}, pod.Spec.Tolerations)
+	assert.Equal(t, []corev1.PreferredSchedulingTerm{
+		{Weight: 1},
+		{Weight: 100, Preference: corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{
+			{Key: "b", Operator: corev1.NodeSelectorOpNotIn, Values: []string{"c"}},
+			{Key: "eks.amazonaws.com/compute-type", Operator: corev1.NodeSelectorOpNotIn, Values: []string{"fargate"}},
+		}}},
+	}, pod.Spec.Affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution)
+	assert.Len(t, templateAffinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution, 1)
+}
+
+func TestIsVirtualNode(t *testing.T) {
+	virtualKubeletNode := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"type": "virtual-kubelet"}}}
+	fargateNode := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"eks.amazonaws.com/compute-type": "fargate"}}}
+	physicalNode := &corev1.Node{}
+
+	assert.True(t, IsVirtualNode(virtualKubeletNode, &rayv1.BurstableOptions{}))
+	assert.False(t, IsVirtualNode(physicalNode, &rayv1.BurstableOptions{}))
+	fargate := &rayv1.BurstableOptions{VirtualNodeSelector: map[string]string{"eks.amazonaws.com/compute-type": "fargate"}}
+	assert.True(t, IsVirtualNode(fargateNode, fargate))
+	assert.False(t, IsVirtualNode(virtualKubeletNode, fargate))
+}
@@ -0,0 +1,29 @@
+package common
+
+import (
+	"strconv"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// GetCleanupTimeout returns how long the objects created for the custom resource are waited for to be deleted before
+// the cleanup finalizer is removed anyway. It's set with the RayCleanupTimeoutAnnotationKey annotation, and invalid
+// values fall back to the default.
+func GetCleanupTimeout(obj metav1.Object) time.Duration {
+	if value, ok := obj.GetAnnotations()[utils.RayCleanupTimeoutAnnotationKey]; ok {
+		if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return utils.DefaultCleanupTimeoutSeconds * time.Second
+}
+
+// IsCleanupTimedOut returns whether the cleanup of the objects created for the custom resource, which is being
+// deleted, has taken longer than its cleanup timeout.
+func IsCleanupTimedOut(obj metav1.Object, now time.Time) bool {
+	deletionTimestamp := obj.GetDeletionTimestamp()
+	return deletionTimestamp != nil && now.Sub(deletionTimestamp.Time) > GetCleanupTimeout(obj)
+}
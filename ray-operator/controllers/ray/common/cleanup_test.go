@@ -0,0 +1,39 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestGetCleanupTimeout(t *testing.T) {
+	rayService := &rayv1.RayService{}
+	assert.Equal(t, 10*time.Minute, GetCleanupTimeout(rayService))
+
+	rayService.Annotations = map[string]string{utils.RayCleanupTimeoutAnnotationKey: "30"}
+	assert.Equal(t, 30*time.Second, GetCleanupTimeout(rayService))
+
+	rayService.Annotations[utils.RayCleanupTimeoutAnnotationKey] = "invalid"
+	assert.Equal(t, 10*time.Minute, GetCleanupTimeout(rayService))
+}
+
+func TestIsCleanupTimedOut(t *testing.T) {
+	now := time.Now()
+	rayJob := &rayv1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{utils.RayCleanupTimeoutAnnotationKey: "60"},
+		},
+	}
+	assert.False(t, IsCleanupTimedOut(rayJob, now))
+
+	rayJob.DeletionTimestamp = &metav1.Time{Time: now.Add(-30 * time.Second)}
+	assert.False(t, IsCleanupTimedOut(rayJob, now))
+
+	rayJob.DeletionTimestamp = &metav1.Time{Time: now.Add(-2 * time.Minute)}
+	assert.True(t, IsCleanupTimedOut(rayJob, now))
+}
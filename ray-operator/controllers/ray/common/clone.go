@@ -0,0 +1,76 @@
+package common
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// kubeRayLabelPrefix is the prefix of the labels that KubeRay sets on the RayClusters it creates, e.g. to associate
+// them with a RayJob or a RayService. They aren't copied to the clones.
+const kubeRayLabelPrefix = "ray.io/"
+
+// ParseCloneSource returns the RayCluster referenced by the value of the RayCloneFromAnnotationKey annotation of a
+// RayCluster in the given namespace. The value is `<name>` or `<namespace>/<name>`.
+func ParseCloneSource(value string, namespace string) (types.NamespacedName, error) {
+	source := types.NamespacedName{Namespace: namespace, Name: value}
+	if sourceNamespace, name, found := strings.Cut(value, "/"); found {
+		source = types.NamespacedName{Namespace: sourceNamespace, Name: name}
+		if errs := validation.IsDNS1123Label(source.Namespace); len(errs) > 0 {
+			return source, fmt.Errorf("invalid namespace %q: %s", source.Namespace, strings.Join(errs, ", "))
+		}
+	}
+	if errs := validation.IsDNS1123Subdomain(source.Name); len(errs) > 0 {
+		return source, fmt.Errorf("invalid name %q: %s", source.Name, strings.Join(errs, ", "))
+	}
+	return source, nil
+}
+
+// IsCloneAllowed returns whether the source RayCluster can be cloned into the namespace. A RayCluster can always be
+// cloned into its own namespace, and into the namespaces listed in its RayCloneToNamespacesAnnotationKey annotation,
+// so that the users of a namespace can't read the spec of the RayClusters of the other namespaces.
+func IsCloneAllowed(source *rayv1.RayCluster, namespace string) bool {
+	if source.Namespace == namespace {
+		return true
+	}
+	allowed := strings.Split(source.Annotations[utils.RayCloneToNamespacesAnnotationKey], ",")
+	for i := range allowed {
+		allowed[i] = strings.TrimSpace(allowed[i])
+	}
+	return slices.Contains(allowed, "*") || slices.Contains(allowed, namespace)
+}
+
+// CloneRayCluster replaces the spec of the clone with a copy of the spec of the source, and adds the labels of the
+// source that the clone doesn't set, except the labels of KubeRay. The source isn't managed by the clone's external
+// controller, if any, so `managedBy` isn't copied. The RayCloneFromAnnotationKey annotation of the clone is replaced
+// with RayClonedFromAnnotationKey.
+func CloneRayCluster(clone *rayv1.RayCluster, source *rayv1.RayCluster) {
+	spec := source.Spec.DeepCopy()
+	spec.ManagedBy = clone.Spec.ManagedBy
+	clone.Spec = *spec
+
+	for key, value := range source.Labels {
+		if strings.HasPrefix(key, kubeRayLabelPrefix) {
+			continue
+		}
+		if _, ok := clone.Labels[key]; ok {
+			continue
+		}
+		if clone.Labels == nil {
+			clone.Labels = map[string]string{}
+		}
+		clone.Labels[key] = value
+	}
+
+	delete(clone.Annotations, utils.RayCloneFromAnnotationKey)
+	if clone.Annotations == nil {
+		clone.Annotations = map[string]string{}
+	}
+	clone.Annotations[utils.RayClonedFromAnnotationKey] = source.Namespace + "/" + source.Name
+}
@@ -0,0 +1,79 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestParseCloneSource(t *testing.T) {
+	source, err := ParseCloneSource("my-cluster", "debug")
+	require.NoError(t, err)
+	assert.Equal(t, types.NamespacedName{Namespace: "debug", Name: "my-cluster"}, source)
+
+	source, err = ParseCloneSource("production/my-cluster", "debug")
+	require.NoError(t, err)
+	assert.Equal(t, types.NamespacedName{Namespace: "production", Name: "my-cluster"}, source)
+
+	_, err = ParseCloneSource("production/", "debug")
+	require.Error(t, err)
+	_, err = ParseCloneSource("Production/my-cluster", "debug")
+	require.Error(t, err)
+}
+
+func TestIsCloneAllowed(t *testing.T) {
+	source := &rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Namespace: "production"}}
+	assert.True(t, IsCloneAllowed(source, "production"))
+	assert.False(t, IsCloneAllowed(source, "debug"))
+
+	source.Annotations = map[string]string{utils.RayCloneToNamespacesAnnotationKey: "staging, debug"}
+	assert.True(t, IsCloneAllowed(source, "debug"))
+	assert.False(t, IsCloneAllowed(source, "dev"))
+
+	source.Annotations[utils.RayCloneToNamespacesAnnotationKey] = "*"
+	assert.True(t, IsCloneAllowed(source, "dev"))
+}
+
+func TestCloneRayCluster(t *testing.T) {
+	source := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-cluster",
+			Namespace: "production",
+			Labels: map[string]string{
+				"team":                                "ml",
+				utils.RayOriginatedFromCRNameLabelKey: "my-service",
+			},
+		},
+		Spec: rayv1.RayClusterSpec{
+			RayVersion: "2.9.0",
+			ManagedBy:  ptr.To("kueue.x-k8s.io/multikueue"),
+			HeadGroupSpec: rayv1.HeadGroupSpec{
+				RayStartParams: map[string]string{"num-cpus": "0"},
+			},
+		},
+	}
+	clone := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "debug-cluster",
+			Namespace:   "debug",
+			Annotations: map[string]string{utils.RayCloneFromAnnotationKey: "production/my-cluster"},
+		},
+	}
+
+	CloneRayCluster(clone, source)
+	assert.Equal(t, "2.9.0", clone.Spec.RayVersion)
+	assert.Nil(t, clone.Spec.ManagedBy)
+	assert.Equal(t, map[string]string{"team": "ml"}, clone.Labels)
+	assert.Equal(t, map[string]string{utils.RayClonedFromAnnotationKey: "production/my-cluster"}, clone.Annotations)
+
+	// The spec of the source isn't shared with the clone.
+	clone.Spec.HeadGroupSpec.RayStartParams["num-cpus"] = "1"
+	assert.Equal(t, "0", source.Spec.HeadGroupSpec.RayStartParams["num-cpus"])
+}
@@ -0,0 +1,51 @@
+package common
+
+import (
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// BuildDebugRayClusterForRayService builds the debug RayCluster of the RayService from its active RayCluster. It has
+// the spec of the active RayCluster without autoscaling and with a single worker, in the first worker group. It
+// doesn't have the labels of KubeRay, so that it isn't one of the RayClusters that serve the traffic of the RayService.
+func BuildDebugRayClusterForRayService(rayService *rayv1.RayService, activeRayCluster *rayv1.RayCluster) *rayv1.RayCluster {
+	spec := activeRayCluster.Spec.DeepCopy()
+	spec.EnableInTreeAutoscaling = nil
+	spec.AutoscalerOptions = nil
+	for i := range spec.WorkerGroupSpecs {
+		worker := &spec.WorkerGroupSpecs[i]
+		replicas := int32(0)
+		if i == 0 {
+			replicas = 1
+		}
+		worker.Replicas = ptr.To(replicas)
+		worker.MinReplicas = ptr.To(replicas)
+		worker.MaxReplicas = ptr.To(replicas)
+		worker.ScaleStrategy = rayv1.ScaleStrategy{}
+	}
+
+	labels := map[string]string{}
+	for key, value := range activeRayCluster.Labels {
+		if !strings.HasPrefix(key, kubeRayLabelPrefix) {
+			labels[key] = value
+		}
+	}
+
+	return &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utils.GenerateDebugRayClusterName(rayService.Name),
+			Namespace: rayService.Namespace,
+			Labels:    labels,
+			Annotations: map[string]string{
+				utils.RayServiceDebugClusterOfAnnotationKey: rayService.Name,
+				utils.KubeRayVersion:                        utils.KUBERAY_VERSION,
+			},
+		},
+		Spec: *spec,
+	}
+}
@@ -0,0 +1,52 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestBuildDebugRayClusterForRayService(t *testing.T) {
+	rayService := &rayv1.RayService{ObjectMeta: metav1.ObjectMeta{Name: "serve", Namespace: "ray"}}
+	activeRayCluster := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "serve-raycluster-abcde",
+			Namespace: "ray",
+			Labels: map[string]string{
+				"team":                                "ml",
+				utils.RayOriginatedFromCRNameLabelKey: "serve",
+			},
+		},
+		Spec: rayv1.RayClusterSpec{
+			EnableInTreeAutoscaling: ptr.To(true),
+			WorkerGroupSpecs: []rayv1.WorkerGroupSpec{
+				{GroupName: "cpu", Replicas: ptr.To[int32](3), MinReplicas: ptr.To[int32](2), MaxReplicas: ptr.To[int32](10),
+					ScaleStrategy: rayv1.ScaleStrategy{WorkersToDelete: []string{"pod1"}}},
+				{GroupName: "gpu", Replicas: ptr.To[int32](2), MinReplicas: ptr.To[int32](1), MaxReplicas: ptr.To[int32](4)},
+			},
+		},
+	}
+
+	debugRayCluster := BuildDebugRayClusterForRayService(rayService, activeRayCluster)
+	assert.Equal(t, "serve-debug", debugRayCluster.Name)
+	assert.Equal(t, "ray", debugRayCluster.Namespace)
+	assert.Equal(t, map[string]string{"team": "ml"}, debugRayCluster.Labels)
+	assert.Equal(t, "serve", debugRayCluster.Annotations[utils.RayServiceDebugClusterOfAnnotationKey])
+	assert.Nil(t, debugRayCluster.Spec.EnableInTreeAutoscaling)
+
+	workers := debugRayCluster.Spec.WorkerGroupSpecs
+	assert.Equal(t, int32(1), *workers[0].Replicas)
+	assert.Equal(t, int32(1), *workers[0].MinReplicas)
+	assert.Equal(t, int32(1), *workers[0].MaxReplicas)
+	assert.Empty(t, workers[0].ScaleStrategy.WorkersToDelete)
+	assert.Equal(t, int32(0), *workers[1].Replicas)
+	assert.Equal(t, int32(0), *workers[1].MaxReplicas)
+
+	// The active RayCluster isn't modified.
+	assert.Equal(t, int32(3), *activeRayCluster.Spec.WorkerGroupSpecs[0].Replicas)
+}
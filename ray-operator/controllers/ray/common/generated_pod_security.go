@@ -0,0 +1,70 @@
+package common
+
+import (
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	configapi "github.com/ray-project/kuberay/ray-operator/apis/config/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// waitGCSReadyInitContainerName is the name of the init container KubeRay injects into worker Pods.
+const waitGCSReadyInitContainerName = "wait-gcs-ready"
+
+// injectedContainerNames are the names of the containers KubeRay injects into head and worker Pods.
+var injectedContainerNames = []string{AutoscalerContainerName, utils.OAuth2ProxyContainerName, utils.MetricsExporterContainerName}
+
+// ApplyGeneratedPodSecurity sets the container security context of the GeneratedPodSecurity on the containers
+// KubeRay injected into the head or worker Pod, unless they already have a security context.
+func ApplyGeneratedPodSecurity(pod *corev1.Pod, security *configapi.GeneratedPodSecurity) {
+	securityContext := security.GetContainerSecurityContext()
+	if securityContext == nil {
+		return
+	}
+	for i := range pod.Spec.Containers {
+		if container := &pod.Spec.Containers[i]; slices.Contains(injectedContainerNames, container.Name) && container.SecurityContext == nil {
+			container.SecurityContext = securityContext.DeepCopy()
+		}
+	}
+	for i := range pod.Spec.InitContainers {
+		if container := &pod.Spec.InitContainers[i]; container.Name == waitGCSReadyInitContainerName && container.SecurityContext == nil {
+			container.SecurityContext = securityContext.DeepCopy()
+		}
+	}
+}
+
+// ApplyGeneratedPodSecurityToSubmitter sets the runtime class and the security contexts of the GeneratedPodSecurity
+// on the default submitter Pod template of a RayJob.
+func ApplyGeneratedPodSecurityToSubmitter(template *corev1.PodTemplateSpec, security *configapi.GeneratedPodSecurity) {
+	if security == nil {
+		return
+	}
+	if template.Spec.RuntimeClassName == nil && security.RuntimeClassName != nil {
+		template.Spec.RuntimeClassName = ptr.To(*security.RuntimeClassName)
+	}
+	if template.Spec.SecurityContext == nil {
+		template.Spec.SecurityContext = security.GetPodSecurityContext()
+	}
+	for i := range template.Spec.Containers {
+		if template.Spec.Containers[i].SecurityContext == nil {
+			template.Spec.Containers[i].SecurityContext = security.GetContainerSecurityContext()
+		}
+	}
+}
+
+// ApplyPodSpecDefaults sets the PodSpecDefaults on the fields of the Pod spec that aren't set in the Pod template.
+// The default of automountServiceAccountToken isn't applied if the Pod needs the service account token, e.g. because
+// it runs the Ray autoscaler.
+func ApplyPodSpecDefaults(podSpec *corev1.PodSpec, defaults *configapi.PodSpecDefaults, needsServiceAccountToken bool) {
+	if defaults == nil {
+		return
+	}
+	if podSpec.EnableServiceLinks == nil && defaults.EnableServiceLinks != nil {
+		podSpec.EnableServiceLinks = ptr.To(*defaults.EnableServiceLinks)
+	}
+	if podSpec.AutomountServiceAccountToken == nil && defaults.AutomountServiceAccountToken != nil && !needsServiceAccountToken {
+		podSpec.AutomountServiceAccountToken = ptr.To(*defaults.AutomountServiceAccountToken)
+	}
+}
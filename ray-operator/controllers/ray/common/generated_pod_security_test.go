@@ -0,0 +1,95 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	configapi "github.com/ray-project/kuberay/ray-operator/apis/config/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestApplyGeneratedPodSecurity(t *testing.T) {
+	userSecurityContext := &corev1.SecurityContext{RunAsUser: ptr.To[int64](1000)}
+	newPod := func() *corev1.Pod {
+		return &corev1.Pod{Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{Name: waitGCSReadyInitContainerName}, {Name: "user-init"}},
+			Containers: []corev1.Container{
+				{Name: "ray-head"},
+				{Name: AutoscalerContainerName},
+				{Name: utils.OAuth2ProxyContainerName, SecurityContext: userSecurityContext},
+			},
+		}}
+	}
+
+	pod := newPod()
+	ApplyGeneratedPodSecurity(pod, nil)
+	assert.Equal(t, newPod(), pod)
+
+	pod = newPod()
+	ApplyGeneratedPodSecurity(pod, &configapi.GeneratedPodSecurity{Restricted: true})
+	restricted := (&configapi.GeneratedPodSecurity{Restricted: true}).GetContainerSecurityContext()
+	assert.Nil(t, pod.Spec.Containers[0].SecurityContext)
+	assert.Equal(t, restricted, pod.Spec.Containers[1].SecurityContext)
+	assert.Equal(t, userSecurityContext, pod.Spec.Containers[2].SecurityContext)
+	assert.Equal(t, restricted, pod.Spec.InitContainers[0].SecurityContext)
+	assert.Nil(t, pod.Spec.InitContainers[1].SecurityContext)
+	assert.Equal(t, ptr.To(false), restricted.AllowPrivilegeEscalation)
+	assert.Equal(t, corev1.SeccompProfileTypeRuntimeDefault, restricted.SeccompProfile.Type)
+
+	// An explicit container security context takes precedence over the restricted one.
+	pod = newPod()
+	ApplyGeneratedPodSecurity(pod, &configapi.GeneratedPodSecurity{
+		Restricted:               true,
+		ContainerSecurityContext: &corev1.SecurityContext{ReadOnlyRootFilesystem: ptr.To(true)},
+	})
+	assert.Equal(t, &corev1.SecurityContext{ReadOnlyRootFilesystem: ptr.To(true)}, pod.Spec.Containers[1].SecurityContext)
+}
+
+func TestApplyGeneratedPodSecurityToSubmitter(t *testing.T) {
+	template := corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "ray-job-submitter"}}}}
+	ApplyGeneratedPodSecurityToSubmitter(&template, &configapi.GeneratedPodSecurity{
+		RuntimeClassName: ptr.To("gvisor"),
+		Restricted:       true,
+	})
+	assert.Equal(t, ptr.To("gvisor"), template.Spec.RuntimeClassName)
+	assert.Equal(t, &corev1.PodSecurityContext{
+		RunAsNonRoot:   ptr.To(true),
+		SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+	}, template.Spec.SecurityContext)
+	assert.Equal(t, []corev1.Capability{"ALL"}, template.Spec.Containers[0].SecurityContext.Capabilities.Drop)
+
+	template = corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "ray-job-submitter"}}}}
+	ApplyGeneratedPodSecurityToSubmitter(&template, nil)
+	assert.Nil(t, template.Spec.RuntimeClassName)
+	assert.Nil(t, template.Spec.SecurityContext)
+	assert.Nil(t, template.Spec.Containers[0].SecurityContext)
+}
+
+func TestApplyPodSpecDefaults(t *testing.T) {
+	defaults := &configapi.PodSpecDefaults{
+		EnableServiceLinks:           ptr.To(false),
+		AutomountServiceAccountToken: ptr.To(false),
+	}
+
+	podSpec := corev1.PodSpec{}
+	ApplyPodSpecDefaults(&podSpec, defaults, false)
+	assert.Equal(t, ptr.To(false), podSpec.EnableServiceLinks)
+	assert.Equal(t, ptr.To(false), podSpec.AutomountServiceAccountToken)
+
+	// The fields set in the Pod template override the defaults.
+	podSpec = corev1.PodSpec{EnableServiceLinks: ptr.To(true)}
+	ApplyPodSpecDefaults(&podSpec, defaults, false)
+	assert.Equal(t, ptr.To(true), podSpec.EnableServiceLinks)
+
+	// The service account token is mounted into Pods that need it.
+	podSpec = corev1.PodSpec{}
+	ApplyPodSpecDefaults(&podSpec, defaults, true)
+	assert.Nil(t, podSpec.AutomountServiceAccountToken)
+
+	podSpec = corev1.PodSpec{}
+	ApplyPodSpecDefaults(&podSpec, nil, false)
+	assert.Equal(t, corev1.PodSpec{}, podSpec)
+}
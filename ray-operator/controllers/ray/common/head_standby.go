@@ -0,0 +1,101 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	"github.com/ray-project/kuberay/ray-operator/pkg/features"
+)
+
+const (
+	HeadStandbyVolumeName      = "ray-head-standby"
+	HeadStandbyVolumeMountPath = "/etc/ray/head-standby"
+	headStandbyPromotedFile    = "promoted"
+)
+
+// IsColdStandbyEnabled returns whether a standby head Pod should be pre-provisioned for the RayCluster.
+func IsColdStandbyEnabled(instance *rayv1.RayCluster) bool {
+	return features.Enabled(features.RayHeadColdStandby) &&
+		instance.Spec.HeadGroupSpec.EnableColdStandby != nil && *instance.Spec.HeadGroupSpec.EnableColdStandby
+}
+
+// ConfigureHeadStandbyPod turns a head Pod into a standby head Pod. The containers of the standby Pod wait until the
+// RayHeadStandbyPromotedAnnotationKey annotation is set to "true" before running their command. The annotation is
+// projected into the containers with a downward API volume, so promoting the Pod doesn't require restarting it.
+// Exec probes pass while waiting for startup and liveness, and fail for readiness. It returns an error if a container
+// doesn't set its command, since the entrypoint of the image can't be delayed.
+func ConfigureHeadStandbyPod(pod *corev1.Pod) error {
+	for _, container := range pod.Spec.Containers {
+		if len(container.Command) == 0 && len(container.Args) == 0 {
+			return fmt.Errorf("container %s of the head Pod doesn't set command or args, which the standby head Pod requires", container.Name)
+		}
+	}
+
+	pod.Labels[utils.RayNodeTypeLabelKey] = utils.RayNodeHeadStandbyLabelValue
+	pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+		Name: HeadStandbyVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			DownwardAPI: &corev1.DownwardAPIVolumeSource{
+				Items: []corev1.DownwardAPIVolumeFile{
+					{
+						Path: headStandbyPromotedFile,
+						FieldRef: &corev1.ObjectFieldSelector{
+							FieldPath: fmt.Sprintf("metadata.annotations['%s']", utils.RayHeadStandbyPromotedAnnotationKey),
+						},
+					},
+				},
+			},
+		},
+	})
+
+	isPromoted := fmt.Sprintf(`[ "$(cat %s/%s 2>/dev/null)" = "true" ]`, HeadStandbyVolumeMountPath, headStandbyPromotedFile)
+	for index := range pod.Spec.Containers {
+		container := &pod.Spec.Containers[index]
+		command := append(append([]string{}, container.Command...), container.Args...)
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      HeadStandbyVolumeName,
+			MountPath: HeadStandbyVolumeMountPath,
+			ReadOnly:  true,
+		})
+		container.Command = []string{"/bin/bash", "-lc", "--"}
+		container.Args = []string{fmt.Sprintf("until %s; do sleep 1; done; exec %s", isPromoted, shellQuoteCommand(command))}
+
+		if probe := container.LivenessProbe; probe != nil && probe.Exec != nil {
+			probe.Exec.Command = []string{"bash", "-c", fmt.Sprintf("%s || exit 0; exec %s", isPromoted, shellQuoteCommand(probe.Exec.Command))}
+		}
+		if probe := container.StartupProbe; probe != nil && probe.Exec != nil {
+			probe.Exec.Command = []string{"bash", "-c", fmt.Sprintf("%s || exit 0; exec %s", isPromoted, shellQuoteCommand(probe.Exec.Command))}
+		}
+		if probe := container.ReadinessProbe; probe != nil && probe.Exec != nil {
+			probe.Exec.Command = []string{"bash", "-c", fmt.Sprintf("%s && exec %s", isPromoted, shellQuoteCommand(probe.Exec.Command))}
+		}
+	}
+	return nil
+}
+
+// IsHeadStandbyPodPromotable returns whether the standby head Pod is running and can take over as the head.
+func IsHeadStandbyPodPromotable(pod corev1.Pod) bool {
+	return pod.DeletionTimestamp == nil && pod.Status.Phase == corev1.PodRunning
+}
+
+// PromoteHeadStandbyPod makes the standby head Pod the head Pod. The head service selects it once the labels are
+// updated, and its containers start once the kubelet refreshes the downward API volume.
+func PromoteHeadStandbyPod(pod *corev1.Pod) {
+	pod.Labels[utils.RayNodeTypeLabelKey] = string(rayv1.HeadNode)
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[utils.RayHeadStandbyPromotedAnnotationKey] = "true"
+}
+
+func shellQuoteCommand(command []string) string {
+	quoted := make([]string, len(command))
+	for i, arg := range command {
+		quoted[i] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+	}
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,56 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestConfigureHeadStandbyPod(t *testing.T) {
+	cluster := instance.DeepCopy()
+	ctx := context.Background()
+	podTemplateSpec := DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, "head", "6379")
+	pod := BuildPod(ctx, podTemplateSpec, rayv1.HeadNode, cluster.Spec.HeadGroupSpec.RayStartParams, "6379", false, utils.GetCRDType(""), "")
+	ApplyHeadHealthProbes(&pod, cluster.Spec.HeadGroupSpec, cluster.Spec.HeadGroupSpec.RayStartParams)
+	rayContainer := pod.Spec.Containers[utils.RayContainerIndex]
+	originalArgs := rayContainer.Args[0]
+
+	require.NoError(t, ConfigureHeadStandbyPod(&pod))
+	rayContainer = pod.Spec.Containers[utils.RayContainerIndex]
+
+	assert.Equal(t, utils.RayNodeHeadStandbyLabelValue, pod.Labels[utils.RayNodeTypeLabelKey])
+	assert.True(t, checkIfVolumeExists(&pod, HeadStandbyVolumeName))
+	assert.True(t, checkIfVolumeMounted(&rayContainer, HeadStandbyVolumeMountPath))
+	assert.Equal(t, []string{"/bin/bash", "-lc", "--"}, rayContainer.Command)
+	assert.Equal(t,
+		`until [ "$(cat /etc/ray/head-standby/promoted 2>/dev/null)" = "true" ]; do sleep 1; done; exec '/bin/bash' '-lc' '--' '`+originalArgs+`'`,
+		rayContainer.Args[0])
+	if rayContainer.LivenessProbe != nil && rayContainer.LivenessProbe.Exec != nil {
+		assert.Contains(t, rayContainer.LivenessProbe.Exec.Command[2], "|| exit 0; exec ")
+	}
+	if rayContainer.StartupProbe != nil && rayContainer.StartupProbe.Exec != nil {
+		assert.Contains(t, rayContainer.StartupProbe.Exec.Command[2], "|| exit 0; exec ")
+	}
+	if rayContainer.ReadinessProbe != nil && rayContainer.ReadinessProbe.Exec != nil {
+		assert.Contains(t, rayContainer.ReadinessProbe.Exec.Command[2], `= "true" ] && exec `)
+	}
+
+	PromoteHeadStandbyPod(&pod)
+	assert.Equal(t, string(rayv1.HeadNode), pod.Labels[utils.RayNodeTypeLabelKey])
+	assert.Equal(t, "true", pod.Annotations[utils.RayHeadStandbyPromotedAnnotationKey])
+
+	// The entrypoint of the image can't be delayed.
+	pod = BuildPod(ctx, podTemplateSpec, rayv1.HeadNode, cluster.Spec.HeadGroupSpec.RayStartParams, "6379", false, utils.GetCRDType(""), "")
+	pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: "sidecar", Image: "fluent/fluent-bit"})
+	require.ErrorContains(t, ConfigureHeadStandbyPod(&pod), "container sidecar")
+}
+
+func TestShellQuoteCommand(t *testing.T) {
+	assert.Equal(t, `'echo' 'it'\''s' ''`, shellQuoteCommand([]string{"echo", "it's", ""}))
+}
@@ -0,0 +1,89 @@
+package common
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	"github.com/ray-project/kuberay/ray-operator/pkg/features"
+)
+
+// IsHeadStatefulSetEnabled returns whether the head Pod of the RayCluster is managed by a StatefulSet.
+func IsHeadStatefulSetEnabled(instance *rayv1.RayCluster) bool {
+	return features.Enabled(features.RayHeadStatefulSet) && instance.Spec.HeadGroupSpec.StatefulSet != nil
+}
+
+// HeadStatefulSetSelector returns the labels that select the head Pod of the RayCluster.
+func HeadStatefulSetSelector(instance rayv1.RayCluster) map[string]string {
+	return map[string]string{
+		utils.RayClusterLabelKey:  instance.Name,
+		utils.RayNodeTypeLabelKey: string(rayv1.HeadNode),
+	}
+}
+
+// BuildHeadStatefulSet builds the StatefulSet of one replica whose Pod template is the head Pod built by KubeRay. The
+// StatefulSet is governed by the headless Service built by BuildHeadStatefulSetService, so the head Pod gets a stable
+// DNS name even if the head service of the RayCluster isn't headless.
+func BuildHeadStatefulSet(instance rayv1.RayCluster, headPod corev1.Pod) *appsv1.StatefulSet {
+	options := instance.Spec.HeadGroupSpec.StatefulSet
+
+	template := corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      headPod.Labels,
+			Annotations: headPod.Annotations,
+		},
+		Spec: *headPod.Spec.DeepCopy(),
+	}
+	// StatefulSets only support the Always restart policy.
+	template.Spec.RestartPolicy = corev1.RestartPolicyAlways
+
+	claims := make([]corev1.PersistentVolumeClaim, 0, len(options.VolumeClaimTemplates))
+	for _, claimTemplate := range options.VolumeClaimTemplates {
+		claims = append(claims, corev1.PersistentVolumeClaim{
+			ObjectMeta: *claimTemplate.ObjectMeta.DeepCopy(),
+			Spec:       *claimTemplate.Spec.DeepCopy(),
+		})
+	}
+
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utils.GenerateHeadStatefulSetName(instance.Name),
+			Namespace: instance.Namespace,
+			Labels:    HeadStatefulSetSelector(instance),
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:                             ptr.To[int32](1),
+			ServiceName:                          utils.GenerateHeadStatefulSetName(instance.Name),
+			Selector:                             &metav1.LabelSelector{MatchLabels: HeadStatefulSetSelector(instance)},
+			Template:                             template,
+			VolumeClaimTemplates:                 claims,
+			PersistentVolumeClaimRetentionPolicy: options.PersistentVolumeClaimRetentionPolicy.DeepCopy(),
+		},
+	}
+}
+
+// BuildHeadStatefulSetService builds the headless Service that governs the StatefulSet of the head Pod. It has the
+// same name as the StatefulSet.
+func BuildHeadStatefulSetService(instance rayv1.RayCluster) *corev1.Service {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utils.GenerateHeadStatefulSetName(instance.Name),
+			Namespace: instance.Namespace,
+			Labels: map[string]string{
+				utils.RayClusterLabelKey:          instance.Name,
+				utils.KubernetesCreatedByLabelKey: utils.ComponentName,
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP:                corev1.ClusterIPNone,
+			Selector:                 HeadStatefulSetSelector(instance),
+			Type:                     corev1.ServiceTypeClusterIP,
+			PublishNotReadyAddresses: true,
+		},
+	}
+	setIPFamilies(service, instance.Spec)
+	return service
+}
@@ -0,0 +1,174 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+var (
+	// hostNetworkHeadPortOffsets are the offsets in the port block of a RayCluster of the ports of the head processes.
+	hostNetworkHeadPortOffsets = map[string]int{
+		"port":                   0,
+		"dashboard-port":         1,
+		"ray-client-server-port": 2,
+	}
+	// hostNetworkNodePortOffsets are the offsets in the port block of a RayCluster of the ports of the processes of
+	// all the Ray nodes. The ports of the block after hostNetworkMinWorkerPortOffset are used by the Ray workers.
+	hostNetworkNodePortOffsets = map[string]int{
+		"metrics-export-port":         3,
+		"node-manager-port":           4,
+		"object-manager-port":         5,
+		"dashboard-agent-listen-port": 6,
+		"dashboard-agent-grpc-port":   7,
+		"runtime-env-agent-port":      8,
+	}
+	// hostNetworkHeadContainerPorts are the named container ports of the head, which are exposed by the head service,
+	// and their rayStartParams.
+	hostNetworkHeadContainerPorts = []struct{ name, param string }{
+		{utils.GcsServerPortName, "port"},
+		{utils.DashboardPortName, "dashboard-port"},
+		{utils.ClientPortName, "ray-client-server-port"},
+		{utils.MetricsPortName, "metrics-export-port"},
+	}
+)
+
+const hostNetworkMinWorkerPortOffset = 10
+
+// IsHostNetworkEnabled returns whether the head or a worker group of the RayCluster uses the network of the nodes.
+func IsHostNetworkEnabled(cluster *rayv1.RayCluster) bool {
+	if cluster.Spec.HeadGroupSpec.Template.Spec.HostNetwork {
+		return true
+	}
+	for _, worker := range cluster.Spec.WorkerGroupSpecs {
+		if worker.Template.Spec.HostNetwork {
+			return true
+		}
+	}
+	return false
+}
+
+// HostNetworkPortsConfigMapKey returns the key of the RayCluster in the data of the HostNetworkPortsConfigMapName
+// ConfigMap. Namespaces can't contain dots, so the keys of the RayClusters are unique.
+func HostNetworkPortsConfigMapKey(cluster *rayv1.RayCluster) string {
+	return cluster.Namespace + "." + cluster.Name
+}
+
+// AllocateHostNetworkPortBlock returns the port block of the key in the data of the HostNetworkPortsConfigMapName
+// ConfigMap. If the key has no block, the blocks of the keys for which isStale returns true are freed, and the lowest
+// free block is allocated to the key. It returns whether the data was changed.
+func AllocateHostNetworkPortBlock(data map[string]string, key string, isStale func(key string) bool) (int, bool, error) {
+	if value, ok := data[key]; ok {
+		block, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, false, fmt.Errorf("invalid host network port block %q of %s: %w", value, key, err)
+		}
+		return block, false, nil
+	}
+
+	used := map[int]bool{}
+	for otherKey, value := range data {
+		if isStale(otherKey) {
+			delete(data, otherKey)
+			continue
+		}
+		if block, err := strconv.Atoi(value); err == nil {
+			used[block] = true
+		}
+	}
+	for block := 0; block < utils.HostNetworkPortBlocks; block++ {
+		if !used[block] {
+			data[key] = strconv.Itoa(block)
+			return block, true, nil
+		}
+	}
+	return 0, true, fmt.Errorf("all the %d host network port blocks are allocated", utils.HostNetworkPortBlocks)
+}
+
+// ApplyHostNetworkPorts sets the ports of the Ray processes that aren't set in the rayStartParams of the RayCluster
+// to the ports of its block, and updates the named container ports of the head accordingly, so that the head service
+// exposes them. The Ray Pods of the RayCluster are also spread over the nodes, since they use the same ports.
+func ApplyHostNetworkPorts(cluster *rayv1.RayCluster, block int) {
+	start := utils.HostNetworkPortRangeStart + block*utils.HostNetworkPortBlockSize
+	headSpec := &cluster.Spec.HeadGroupSpec
+	headSpec.RayStartParams = setMissingHostNetworkPorts(headSpec.RayStartParams, start, rayv1.HeadNode)
+	setHostNetworkHeadContainerPorts(&headSpec.Template.Spec.Containers[utils.RayContainerIndex], headSpec.RayStartParams)
+	addHostNetworkAntiAffinity(&headSpec.Template.Spec, cluster.Name)
+	for i := range cluster.Spec.WorkerGroupSpecs {
+		workerSpec := &cluster.Spec.WorkerGroupSpecs[i]
+		workerSpec.RayStartParams = setMissingHostNetworkPorts(workerSpec.RayStartParams, start, rayv1.WorkerNode)
+		if port, err := strconv.ParseInt(workerSpec.RayStartParams["metrics-export-port"], 10, 32); err == nil {
+			setContainerPort(&workerSpec.Template.Spec.Containers[utils.RayContainerIndex], utils.MetricsPortName, int32(port))
+		}
+		addHostNetworkAntiAffinity(&workerSpec.Template.Spec, cluster.Name)
+	}
+}
+
+func setMissingHostNetworkPorts(rayStartParams map[string]string, start int, nodeType rayv1.RayNodeType) map[string]string {
+	if rayStartParams == nil {
+		rayStartParams = map[string]string{}
+	}
+	setMissing := func(offsets map[string]int) {
+		for param, offset := range offsets {
+			if _, ok := rayStartParams[param]; !ok {
+				rayStartParams[param] = strconv.Itoa(start + offset)
+			}
+		}
+	}
+	if nodeType == rayv1.HeadNode {
+		setMissing(hostNetworkHeadPortOffsets)
+	}
+	setMissing(hostNetworkNodePortOffsets)
+	_, hasMinWorkerPort := rayStartParams["min-worker-port"]
+	_, hasMaxWorkerPort := rayStartParams["max-worker-port"]
+	if !hasMinWorkerPort && !hasMaxWorkerPort {
+		rayStartParams["min-worker-port"] = strconv.Itoa(start + hostNetworkMinWorkerPortOffset)
+		rayStartParams["max-worker-port"] = strconv.Itoa(start + utils.HostNetworkPortBlockSize - 1)
+	}
+	return rayStartParams
+}
+
+// setHostNetworkHeadContainerPorts sets the named container ports of the head to the ports of the rayStartParams. If
+// the head has no container ports, the default ones are added first, since the head service only exposes the default
+// ports of a head without container ports.
+func setHostNetworkHeadContainerPorts(container *corev1.Container, rayStartParams map[string]string) {
+	if len(container.Ports) == 0 {
+		container.Ports = append(container.Ports, corev1.ContainerPort{Name: utils.ServingPortName, ContainerPort: utils.DefaultServingPort})
+	}
+	for _, p := range hostNetworkHeadContainerPorts {
+		if port, err := strconv.ParseInt(rayStartParams[p.param], 10, 32); err == nil {
+			setContainerPort(container, p.name, int32(port))
+		}
+	}
+}
+
+func setContainerPort(container *corev1.Container, name string, port int32) {
+	for i := range container.Ports {
+		if container.Ports[i].Name == name {
+			container.Ports[i].ContainerPort = port
+			return
+		}
+	}
+	container.Ports = append(container.Ports, corev1.ContainerPort{Name: name, ContainerPort: port})
+}
+
+// addHostNetworkAntiAffinity requires the Pod not to be scheduled on the nodes running another Pod of the RayCluster.
+func addHostNetworkAntiAffinity(podSpec *corev1.PodSpec, clusterName string) {
+	term := corev1.PodAffinityTerm{
+		LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{utils.RayClusterLabelKey: clusterName}},
+		TopologyKey:   corev1.LabelHostname,
+	}
+	if podSpec.Affinity == nil {
+		podSpec.Affinity = &corev1.Affinity{}
+	}
+	if podSpec.Affinity.PodAntiAffinity == nil {
+		podSpec.Affinity.PodAntiAffinity = &corev1.PodAntiAffinity{}
+	}
+	antiAffinity := podSpec.Affinity.PodAntiAffinity
+	antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution, term)
+}
@@ -0,0 +1,109 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestAllocateHostNetworkPortBlock(t *testing.T) {
+	notStale := func(string) bool { return false }
+	data := map[string]string{"ns.a": "0", "ns.b": "2"}
+
+	// The lowest free block is allocated.
+	block, changed, err := AllocateHostNetworkPortBlock(data, "ns.c", notStale)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, 1, block)
+	assert.Equal(t, "1", data["ns.c"])
+
+	// The block of a key is kept.
+	block, changed, err = AllocateHostNetworkPortBlock(data, "ns.b", notStale)
+	require.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, 2, block)
+
+	// The blocks of the stale keys are freed.
+	block, _, err = AllocateHostNetworkPortBlock(data, "ns.d", func(key string) bool { return key == "ns.a" })
+	require.NoError(t, err)
+	assert.Equal(t, 0, block)
+	assert.Equal(t, map[string]string{"ns.b": "2", "ns.c": "1", "ns.d": "0"}, data)
+
+	// No block is allocated once all of them are used.
+	data = map[string]string{}
+	for i := 0; i < utils.HostNetworkPortBlocks; i++ {
+		_, _, err = AllocateHostNetworkPortBlock(data, string(rune('a'+i)), notStale)
+		require.NoError(t, err)
+	}
+	_, _, err = AllocateHostNetworkPortBlock(data, "ns.full", notStale)
+	assert.EqualError(t, err, "all the 60 host network port blocks are allocated")
+}
+
+func TestApplyHostNetworkPorts(t *testing.T) {
+	cluster := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "raycluster", Namespace: "default"},
+		Spec: rayv1.RayClusterSpec{
+			HeadGroupSpec: rayv1.HeadGroupSpec{
+				RayStartParams: map[string]string{"dashboard-port": "8265"},
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					HostNetwork: true,
+					Containers:  []corev1.Container{{Name: "ray-head"}},
+				}},
+			},
+			WorkerGroupSpecs: []rayv1.WorkerGroupSpec{{
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					HostNetwork: true,
+					Containers: []corev1.Container{{
+						Name:  "ray-worker",
+						Ports: []corev1.ContainerPort{{Name: utils.MetricsPortName, ContainerPort: utils.DefaultMetricsPort}},
+					}},
+				}},
+			}},
+		},
+	}
+	assert.True(t, IsHostNetworkEnabled(cluster))
+
+	ApplyHostNetworkPorts(cluster, 2)
+
+	// The ports set by the users are kept.
+	assert.Equal(t, map[string]string{
+		"port":                        "20400",
+		"dashboard-port":              "8265",
+		"ray-client-server-port":      "20402",
+		"metrics-export-port":         "20403",
+		"node-manager-port":           "20404",
+		"object-manager-port":         "20405",
+		"dashboard-agent-listen-port": "20406",
+		"dashboard-agent-grpc-port":   "20407",
+		"runtime-env-agent-port":      "20408",
+		"min-worker-port":             "20410",
+		"max-worker-port":             "20599",
+	}, cluster.Spec.HeadGroupSpec.RayStartParams)
+	assert.Equal(t, []corev1.ContainerPort{
+		{Name: utils.ServingPortName, ContainerPort: utils.DefaultServingPort},
+		{Name: utils.GcsServerPortName, ContainerPort: 20400},
+		{Name: utils.DashboardPortName, ContainerPort: 8265},
+		{Name: utils.ClientPortName, ContainerPort: 20402},
+		{Name: utils.MetricsPortName, ContainerPort: 20403},
+	}, cluster.Spec.HeadGroupSpec.Template.Spec.Containers[utils.RayContainerIndex].Ports)
+
+	workerSpec := cluster.Spec.WorkerGroupSpecs[0]
+	assert.NotContains(t, workerSpec.RayStartParams, "port")
+	assert.Equal(t, "20406", workerSpec.RayStartParams["dashboard-agent-listen-port"])
+	assert.Equal(t, []corev1.ContainerPort{{Name: utils.MetricsPortName, ContainerPort: 20403}},
+		workerSpec.Template.Spec.Containers[utils.RayContainerIndex].Ports)
+
+	// The Pods of the RayCluster are spread over the nodes.
+	for _, podSpec := range []corev1.PodSpec{cluster.Spec.HeadGroupSpec.Template.Spec, workerSpec.Template.Spec} {
+		assert.Equal(t, []corev1.PodAffinityTerm{{
+			LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{utils.RayClusterLabelKey: "raycluster"}},
+			TopologyKey:   corev1.LabelHostname,
+		}}, podSpec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution)
+	}
+}
@@ -0,0 +1,122 @@
+package common
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// HTTPRouteGroupVersionKind is the GroupVersionKind of the Gateway API HTTPRoute. KubeRay uses unstructured
+// HTTPRoutes so that the Gateway API CRDs are only needed when the Cookie session affinity or the request shadowing is
+// used.
+var HTTPRouteGroupVersionKind = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"}
+
+// IsServeHTTPRouteNeeded returns whether the RayService routes the traffic of a Gateway to the serve service with an
+// HTTPRoute, which is the case with the Cookie session affinity and with the request shadowing.
+func IsServeHTTPRouteNeeded(rayService rayv1.RayService) bool {
+	affinity := rayService.Spec.ServeSessionAffinity
+	return (affinity != nil && affinity.Type == rayv1.CookieServeSessionAffinity) || utils.GetRequestShadowing(&rayService) != nil
+}
+
+// BuildServeHTTPRouteForRayService builds the HTTPRoute that routes the traffic of the Gateway to the serve service,
+// with cookie based session persistence if the Cookie session affinity is used. If mirrorService isn't nil, the
+// percentage of the requests of the request shadowing is mirrored to it.
+func BuildServeHTTPRouteForRayService(rayService rayv1.RayService, serveService *corev1.Service, mirrorService *corev1.Service) (*unstructured.Unstructured, error) {
+	if !IsServeHTTPRouteNeeded(rayService) {
+		return nil, fmt.Errorf("the HTTPRoute is only used by the %s session affinity and the request shadowing", rayv1.CookieServeSessionAffinity)
+	}
+	if len(serveService.Spec.Ports) == 0 {
+		return nil, fmt.Errorf("the serve service %s/%s does not have any ports", serveService.Namespace, serveService.Name)
+	}
+
+	rule := map[string]interface{}{
+		"backendRefs": []interface{}{
+			map[string]interface{}{
+				"name": serveService.Name,
+				"port": int64(serveService.Spec.Ports[0].Port),
+			},
+		},
+	}
+	var gatewayName string
+	if shadowing := utils.GetRequestShadowing(&rayService); shadowing != nil {
+		gatewayName = shadowing.GatewayName
+		if mirrorService != nil {
+			if len(mirrorService.Spec.Ports) == 0 {
+				return nil, fmt.Errorf("the service %s/%s does not have any ports", mirrorService.Namespace, mirrorService.Name)
+			}
+			rule["filters"] = []interface{}{
+				map[string]interface{}{
+					"type": "RequestMirror",
+					"requestMirror": map[string]interface{}{
+						"backendRef": map[string]interface{}{
+							"name": mirrorService.Name,
+							"port": int64(mirrorService.Spec.Ports[0].Port),
+						},
+						"percent": int64(GetRequestShadowingPercent(shadowing)),
+					},
+				},
+			}
+		}
+	}
+	if affinity := rayService.Spec.ServeSessionAffinity; affinity != nil && affinity.Type == rayv1.CookieServeSessionAffinity {
+		gatewayName = affinity.GatewayName
+		cookieName := utils.DefaultServeSessionCookieName
+		if affinity.CookieName != nil {
+			cookieName = *affinity.CookieName
+		}
+		rule["sessionPersistence"] = map[string]interface{}{
+			"type":            "Cookie",
+			"sessionName":     cookieName,
+			"absoluteTimeout": fmt.Sprintf("%ds", GetServeSessionAffinityTimeoutSeconds(affinity)),
+		}
+	}
+
+	route := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"parentRefs": []interface{}{
+					map[string]interface{}{"name": gatewayName},
+				},
+				"rules": []interface{}{rule},
+			},
+		},
+	}
+	route.SetGroupVersionKind(HTTPRouteGroupVersionKind)
+	route.SetName(utils.GenerateServeHTTPRouteName(rayService.Name))
+	route.SetNamespace(rayService.Namespace)
+	route.SetLabels(map[string]string{
+		utils.RayOriginatedFromCRNameLabelKey: rayService.Name,
+		utils.RayOriginatedFromCRDLabelKey:    utils.RayOriginatedFromCRDLabelValue(utils.RayServiceCRD),
+	})
+	return route, nil
+}
+
+// GetRequestShadowingPercent returns the percentage of the requests mirrored to the pending RayCluster.
+func GetRequestShadowingPercent(shadowing *rayv1.RequestShadowing) int32 {
+	if shadowing.Percent != nil {
+		return *shadowing.Percent
+	}
+	return utils.DefaultRequestShadowingPercent
+}
+
+// GetRequestShadowingDurationSeconds returns how long the pending RayCluster is shadowed before it can be promoted.
+func GetRequestShadowingDurationSeconds(shadowing *rayv1.RequestShadowing) int32 {
+	if shadowing.DurationSeconds != nil {
+		return *shadowing.DurationSeconds
+	}
+	return utils.DefaultRequestShadowingDurationSeconds
+}
+
+// GetRequestShadowingMaxErrorRateIncreasePercent returns by how many percentage points the error rate of the pending
+// RayCluster may exceed the one of the active RayCluster.
+func GetRequestShadowingMaxErrorRateIncreasePercent(shadowing *rayv1.RequestShadowing) int32 {
+	if shadowing.MaxErrorRateIncreasePercent != nil {
+		return *shadowing.MaxErrorRateIncreasePercent
+	}
+	return utils.DefaultRequestShadowingMaxErrorRateIncreasePercent
+}
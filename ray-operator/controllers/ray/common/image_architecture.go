@@ -0,0 +1,134 @@
+package common
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	configapi "github.com/ray-project/kuberay/ray-operator/apis/config/v1alpha1"
+)
+
+// rayImageRepositories are the repositories whose architecture can be inferred from the tag with DetectRayImages.
+var rayImageRepositories = []string{"rayproject/ray", "rayproject/ray-ml"}
+
+// ApplyImageArchitectureAffinity requires nodes whose kubernetes.io/arch label is one of the architectures supported
+// by all the images of the Pod, according to the policy. The Pod spec is left unchanged if it already constrains the
+// architecture, or if none of its images matches the policy. It returns an error if the images have no architecture
+// in common, because such a Pod can't run on any node.
+func ApplyImageArchitectureAffinity(podSpec *corev1.PodSpec, policy *configapi.ImageArchitecturePolicy) error {
+	if policy == nil || constrainsArchitecture(podSpec) {
+		return nil
+	}
+	var architectures []string
+	constrained := false
+	for _, container := range slices.Concat(podSpec.InitContainers, podSpec.Containers) {
+		imageArchitectures := GetImageArchitectures(container.Image, policy)
+		if imageArchitectures == nil {
+			continue
+		}
+		if !constrained {
+			architectures, constrained = slices.Clone(imageArchitectures), true
+			continue
+		}
+		architectures = slices.DeleteFunc(architectures, func(architecture string) bool {
+			return !slices.Contains(imageArchitectures, architecture)
+		})
+	}
+	if !constrained {
+		return nil
+	}
+	if len(architectures) == 0 {
+		return fmt.Errorf("the images of the Pod have no CPU architecture in common")
+	}
+	slices.Sort(architectures)
+
+	requirement := corev1.NodeSelectorRequirement{
+		Key:      corev1.LabelArchStable,
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   architectures,
+	}
+	// The affinity may be shared with the Pod template of the custom resource, so it's copied before being modified.
+	podSpec.Affinity = podSpec.Affinity.DeepCopy()
+	if podSpec.Affinity == nil {
+		podSpec.Affinity = &corev1.Affinity{}
+	}
+	if podSpec.Affinity.NodeAffinity == nil {
+		podSpec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	nodeAffinity := podSpec.Affinity.NodeAffinity
+	if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+	}
+	required := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(required.NodeSelectorTerms) == 0 {
+		required.NodeSelectorTerms = []corev1.NodeSelectorTerm{{}}
+	}
+	// The terms are ORed, so the requirement is added to each of them.
+	for i := range required.NodeSelectorTerms {
+		term := &required.NodeSelectorTerms[i]
+		term.MatchExpressions = append(term.MatchExpressions, requirement)
+	}
+	return nil
+}
+
+// GetImageArchitectures returns the architectures of the image according to the policy, or nil if they are unknown.
+func GetImageArchitectures(image string, policy *configapi.ImageArchitecturePolicy) []string {
+	if policy == nil || image == "" {
+		return nil
+	}
+	image = normalizeImageReference(image)
+	var architectures []string
+	longestPrefix := -1
+	for _, rule := range policy.Rules {
+		prefix := normalizeImageReference(rule.ImagePrefix)
+		if strings.HasPrefix(image, prefix) && len(prefix) > longestPrefix {
+			architectures, longestPrefix = rule.Architectures, len(prefix)
+		}
+	}
+	if architectures != nil || !policy.DetectRayImages {
+		return architectures
+	}
+
+	repository, tag, found := strings.Cut(image, ":")
+	if !slices.Contains(rayImageRepositories, repository) || !found || strings.Contains(tag, "@") {
+		return nil
+	}
+	// The rayproject images are built for x86_64, and the ones for arm64 are published with the -aarch64 suffix.
+	if strings.HasSuffix(tag, "-aarch64") {
+		return []string{"arm64"}
+	}
+	return []string{"amd64"}
+}
+
+// normalizeImageReference removes the registry of Docker Hub from the image reference, so that `rayproject/ray` and
+// `docker.io/rayproject/ray` are considered the same.
+func normalizeImageReference(image string) string {
+	for _, registry := range []string{"docker.io/", "index.docker.io/"} {
+		if strings.HasPrefix(image, registry) {
+			image = strings.TrimPrefix(image, registry)
+			return strings.TrimPrefix(image, "library/")
+		}
+	}
+	return image
+}
+
+// constrainsArchitecture returns whether the node selector or the required node affinity of the Pod spec already
+// select nodes by their architecture.
+func constrainsArchitecture(podSpec *corev1.PodSpec) bool {
+	if _, ok := podSpec.NodeSelector[corev1.LabelArchStable]; ok {
+		return true
+	}
+	if podSpec.Affinity == nil || podSpec.Affinity.NodeAffinity == nil || podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return false
+	}
+	for _, term := range podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		for _, expression := range term.MatchExpressions {
+			if expression.Key == corev1.LabelArchStable {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	configapi "github.com/ray-project/kuberay/ray-operator/apis/config/v1alpha1"
+)
+
+func TestGetImageArchitectures(t *testing.T) {
+	policy := &configapi.ImageArchitecturePolicy{
+		Rules: []configapi.ImageArchitectureRule{
+			{ImagePrefix: "rayproject/ray:2.9.0", Architectures: []string{"amd64", "arm64"}},
+			{ImagePrefix: "docker.io/rayproject/ray:2.9.0-gpu", Architectures: []string{"amd64"}},
+			{ImagePrefix: "registry.example.com/ray", Architectures: []string{"arm64"}},
+		},
+		DetectRayImages: true,
+	}
+	tests := map[string][]string{
+		// The longest matching prefix applies, regardless of the docker.io registry.
+		"rayproject/ray:2.9.0":                         {"amd64", "arm64"},
+		"rayproject/ray:2.9.0-gpu":                     {"amd64"},
+		"docker.io/rayproject/ray:2.9.0-py310":         {"amd64", "arm64"},
+		"registry.example.com/ray/ray:nightly":         {"arm64"},
+		"rayproject/ray:2.10.0-aarch64":                {"arm64"},
+		"rayproject/ray-ml:2.10.0-py310-gpu":           {"amd64"},
+		"index.docker.io/rayproject/ray:2.10.0":        {"amd64"},
+		"rayproject/ray@sha256:0123456789abcdef":       nil,
+		"my-registry.example.com/rayproject/ray:2.9.0": nil,
+		"busybox:1.28":                                 nil,
+		"":                                             nil,
+	}
+	for image, expected := range tests {
+		assert.Equal(t, expected, GetImageArchitectures(image, policy), image)
+	}
+
+	policy.DetectRayImages = false
+	assert.Nil(t, GetImageArchitectures("rayproject/ray:2.10.0-aarch64", policy))
+	assert.Nil(t, GetImageArchitectures("rayproject/ray:2.10.0", nil))
+}
+
+func TestApplyImageArchitectureAffinity(t *testing.T) {
+	policy := &configapi.ImageArchitecturePolicy{
+		Rules: []configapi.ImageArchitectureRule{
+			{ImagePrefix: "multiarch/ray", Architectures: []string{"arm64", "amd64"}},
+			{ImagePrefix: "arm/sidecar", Architectures: []string{"arm64"}},
+		},
+		DetectRayImages: true,
+	}
+	archRequirement := func(values ...string) corev1.NodeSelectorRequirement {
+		return corev1.NodeSelectorRequirement{Key: corev1.LabelArchStable, Operator: corev1.NodeSelectorOpIn, Values: values}
+	}
+
+	t.Run("unknown images are not constrained", func(t *testing.T) {
+		podSpec := corev1.PodSpec{Containers: []corev1.Container{{Image: "busybox"}}}
+		require.NoError(t, ApplyImageArchitectureAffinity(&podSpec, policy))
+		assert.Nil(t, podSpec.Affinity)
+		require.NoError(t, ApplyImageArchitectureAffinity(&podSpec, nil))
+		assert.Nil(t, podSpec.Affinity)
+	})
+
+	t.Run("the architectures of all the images are intersected", func(t *testing.T) {
+		podSpec := corev1.PodSpec{
+			InitContainers: []corev1.Container{{Image: "busybox"}},
+			Containers:     []corev1.Container{{Image: "multiarch/ray:2.9.0"}, {Image: "arm/sidecar:1.0"}},
+		}
+		require.NoError(t, ApplyImageArchitectureAffinity(&podSpec, policy))
+		assert.Equal(t, []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{archRequirement("arm64")}}},
+			podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms)
+	})
+
+	t.Run("the requirement is added to each existing term without modifying the template", func(t *testing.T) {
+		zoneA := corev1.NodeSelectorRequirement{Key: corev1.LabelTopologyZone, Operator: corev1.NodeSelectorOpIn, Values: []string{"a"}}
+		zoneB := corev1.NodeSelectorRequirement{Key: corev1.LabelTopologyZone, Operator: corev1.NodeSelectorOpIn, Values: []string{"b"}}
+		templateAffinity := &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{
+				{MatchExpressions: []corev1.NodeSelectorRequirement{zoneA}},
+				{MatchExpressions: []corev1.NodeSelectorRequirement{zoneB}},
+			}},
+		}}
+		podSpec := corev1.PodSpec{Affinity: templateAffinity, Containers: []corev1.Container{{Image: "rayproject/ray:2.9.0-gpu"}}}
+		require.NoError(t, ApplyImageArchitectureAffinity(&podSpec, policy))
+		assert.Equal(t, []corev1.NodeSelectorTerm{
+			{MatchExpressions: []corev1.NodeSelectorRequirement{zoneA, archRequirement("amd64")}},
+			{MatchExpressions: []corev1.NodeSelectorRequirement{zoneB, archRequirement("amd64")}},
+		}, podSpec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms)
+		assert.Len(t, templateAffinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions, 1)
+	})
+
+	t.Run("an architecture set in the Pod template takes precedence", func(t *testing.T) {
+		podSpec := corev1.PodSpec{
+			NodeSelector: map[string]string{corev1.LabelArchStable: "arm64"},
+			Containers:   []corev1.Container{{Image: "rayproject/ray:2.9.0"}},
+		}
+		require.NoError(t, ApplyImageArchitectureAffinity(&podSpec, policy))
+		assert.Nil(t, podSpec.Affinity)
+
+		affinity := &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{
+				{MatchExpressions: []corev1.NodeSelectorRequirement{archRequirement("arm64")}},
+			}},
+		}}
+		podSpec = corev1.PodSpec{Affinity: affinity, Containers: []corev1.Container{{Image: "rayproject/ray:2.9.0"}}}
+		require.NoError(t, ApplyImageArchitectureAffinity(&podSpec, policy))
+		assert.Equal(t, affinity, podSpec.Affinity)
+	})
+
+	t.Run("images without a common architecture", func(t *testing.T) {
+		podSpec := corev1.PodSpec{Containers: []corev1.Container{{Image: "rayproject/ray:2.9.0"}, {Image: "arm/sidecar:1.0"}}}
+		require.Error(t, ApplyImageArchitectureAffinity(&podSpec, policy))
+		assert.Nil(t, podSpec.Affinity)
+	})
+}
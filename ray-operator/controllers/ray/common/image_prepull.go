@@ -0,0 +1,98 @@
+package common
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+const ImagePrePullPauseContainerName = "pause"
+
+// ImagePrePullGroups returns the Pod templates of the head group and the worker groups that aren't suspended, keyed
+// by group name.
+func ImagePrePullGroups(cluster *rayv1.RayCluster) map[string]corev1.PodTemplateSpec {
+	groups := map[string]corev1.PodTemplateSpec{
+		utils.RayNodeHeadGroupLabelValue: cluster.Spec.HeadGroupSpec.Template,
+	}
+	for _, worker := range cluster.Spec.WorkerGroupSpecs {
+		if worker.Suspend != nil && *worker.Suspend {
+			continue
+		}
+		groups[worker.GroupName] = worker.Template
+	}
+	return groups
+}
+
+// BuildImagePrePullDaemonSet returns a DaemonSet that pulls the images of the Pod template on every node that can run
+// it. Each image is pulled by an init container that runs the command of the ImagePrePullOptions and exits, and the
+// Pod then idles in a pause container until KubeRay deletes the DaemonSet.
+func BuildImagePrePullDaemonSet(cluster *rayv1.RayCluster, groupName string, template corev1.PodTemplateSpec) *appsv1.DaemonSet {
+	labels := map[string]string{
+		utils.RayImagePrePullClusterLabelKey: cluster.Name,
+		utils.RayNodeGroupLabelKey:           groupName,
+	}
+	podLabels := map[string]string{utils.KubernetesCreatedByLabelKey: utils.ComponentName}
+	for k, v := range labels {
+		podLabels[k] = v
+	}
+
+	command := []string{"/bin/sh", "-c", "exit 0"}
+	if cluster.Spec.ImagePrePull != nil && len(cluster.Spec.ImagePrePull.Command) > 0 {
+		command = cluster.Spec.ImagePrePull.Command
+	}
+	var initContainers []corev1.Container
+	seen := map[string]bool{}
+	for _, container := range append(append([]corev1.Container{}, template.Spec.InitContainers...), template.Spec.Containers...) {
+		if container.Image == "" || seen[container.Image] {
+			continue
+		}
+		seen[container.Image] = true
+		initContainers = append(initContainers, corev1.Container{
+			Name:            fmt.Sprintf("pull-%d", len(initContainers)),
+			Image:           container.Image,
+			ImagePullPolicy: container.ImagePullPolicy,
+			Command:         command,
+		})
+	}
+
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utils.CheckName(fmt.Sprintf("%s-%s-prepull", cluster.Name, groupName)),
+			Namespace: cluster.Namespace,
+			Labels:    podLabels,
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: podLabels},
+				Spec: corev1.PodSpec{
+					InitContainers: initContainers,
+					Containers: []corev1.Container{
+						{
+							Name:  ImagePrePullPauseContainerName,
+							Image: utils.DefaultImagePrePullPauseImage,
+						},
+					},
+					ServiceAccountName:            template.Spec.ServiceAccountName,
+					ImagePullSecrets:              template.Spec.ImagePullSecrets,
+					NodeSelector:                  template.Spec.NodeSelector,
+					Affinity:                      template.Spec.Affinity,
+					Tolerations:                   template.Spec.Tolerations,
+					TerminationGracePeriodSeconds: ptr.To[int64](0),
+				},
+			},
+		},
+	}
+}
+
+// IsImagePrePullDaemonSetCompleted returns whether the images are pulled on every node that the DaemonSet is scheduled to.
+func IsImagePrePullDaemonSetCompleted(daemonSet *appsv1.DaemonSet) bool {
+	return daemonSet.Generation > 0 && daemonSet.Status.ObservedGeneration >= daemonSet.Generation &&
+		daemonSet.Status.NumberReady >= daemonSet.Status.DesiredNumberScheduled
+}
@@ -0,0 +1,57 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestBuildImagePrePullDaemonSet(t *testing.T) {
+	cluster := instance.DeepCopy()
+	worker := cluster.Spec.WorkerGroupSpecs[0]
+	worker.Template.Spec.NodeSelector = map[string]string{"pool": "gpu"}
+	worker.Template.Spec.Tolerations = []corev1.Toleration{{Key: "gpu", Operator: corev1.TolerationOpExists}}
+	worker.Template.Spec.InitContainers = []corev1.Container{{Name: "init", Image: worker.Template.Spec.Containers[0].Image}}
+	worker.Template.Spec.Containers = append(worker.Template.Spec.Containers, corev1.Container{Name: "sidecar", Image: "fluent-bit"})
+
+	daemonSet := BuildImagePrePullDaemonSet(cluster, worker.GroupName, worker.Template)
+	assert.Equal(t, cluster.Name+"-"+worker.GroupName+"-prepull", daemonSet.Name)
+	assert.Equal(t, cluster.Name, daemonSet.Spec.Template.Labels[utils.RayImagePrePullClusterLabelKey])
+	assert.NotContains(t, daemonSet.Spec.Template.Labels, utils.RayClusterLabelKey)
+	assert.Equal(t, worker.Template.Spec.NodeSelector, daemonSet.Spec.Template.Spec.NodeSelector)
+	assert.Equal(t, worker.Template.Spec.Tolerations, daemonSet.Spec.Template.Spec.Tolerations)
+	// Each image is pulled once.
+	images := []string{}
+	for _, container := range daemonSet.Spec.Template.Spec.InitContainers {
+		images = append(images, container.Image)
+	}
+	assert.Equal(t, []string{worker.Template.Spec.Containers[0].Image, "fluent-bit"}, images)
+	assert.Equal(t, utils.DefaultImagePrePullPauseImage, daemonSet.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, []string{"/bin/sh", "-c", "exit 0"}, daemonSet.Spec.Template.Spec.InitContainers[0].Command)
+
+	// Images without a shell need another command.
+	cluster.Spec.ImagePrePull = &rayv1.ImagePrePullOptions{Command: []string{"/usr/bin/true"}}
+	daemonSet = BuildImagePrePullDaemonSet(cluster, worker.GroupName, worker.Template)
+	for _, container := range daemonSet.Spec.Template.Spec.InitContainers {
+		assert.Equal(t, []string{"/usr/bin/true"}, container.Command)
+	}
+
+	// The status of a DaemonSet isn't meaningful before the DaemonSet controller observes it.
+	assert.False(t, IsImagePrePullDaemonSetCompleted(&appsv1.DaemonSet{}))
+	daemonSet.Generation = 1
+	daemonSet.Status = appsv1.DaemonSetStatus{ObservedGeneration: 1, DesiredNumberScheduled: 2, NumberReady: 1}
+	assert.False(t, IsImagePrePullDaemonSetCompleted(daemonSet))
+	daemonSet.Status.NumberReady = 2
+	assert.True(t, IsImagePrePullDaemonSetCompleted(daemonSet))
+
+	cluster.Spec.WorkerGroupSpecs[0].Suspend = ptr.To(true)
+	groups := ImagePrePullGroups(cluster)
+	assert.Len(t, groups, 1)
+	assert.Contains(t, groups, utils.RayNodeHeadGroupLabelValue)
+}
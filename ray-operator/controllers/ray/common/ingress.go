@@ -42,11 +42,7 @@ func BuildIngressForHeadService(ctx context.Context, cluster rayv1.RayCluster) (
 
 	var paths []networkingv1.HTTPIngressPath
 	pathType := networkingv1.PathTypeExact
-	servicePorts := getServicePorts(cluster)
-	dashboardPort := int32(utils.DefaultDashboardPort)
-	if port, ok := servicePorts["dashboard"]; ok {
-		dashboardPort = port
-	}
+	dashboardPort := getDashboardPort(cluster)
 
 	headSvcName, err := utils.GenerateHeadServiceName(utils.RayClusterCRD, cluster.Spec, cluster.Name)
 	if err != nil {
@@ -98,3 +94,55 @@ func BuildIngressForHeadService(ctx context.Context, cluster rayv1.RayCluster) (
 
 	return ingress, nil
 }
+
+// BuildDashboardIngress builds the Ingress of the Ray dashboard configured by `spec.dashboardIngress` of the RayCluster.
+// The Ingress routes to the given service, which is the head service of the RayCluster or the RayService.
+func BuildDashboardIngress(cluster rayv1.RayCluster, name string, namespace string, labels map[string]string, serviceName string) *networkingv1.Ingress {
+	options := cluster.Spec.DashboardIngress
+
+	port := getDashboardPort(cluster)
+	if options.OAuth2Proxy != nil {
+		port = utils.DefaultOAuth2ProxyPort
+	}
+	pathType := networkingv1.PathTypePrefix
+	rule := networkingv1.IngressRule{
+		Host: options.Host,
+		IngressRuleValue: networkingv1.IngressRuleValue{
+			HTTP: &networkingv1.HTTPIngressRuleValue{
+				Paths: []networkingv1.HTTPIngressPath{
+					{
+						Path:     "/",
+						PathType: &pathType,
+						Backend: networkingv1.IngressBackend{
+							Service: &networkingv1.IngressServiceBackend{
+								Name: serviceName,
+								Port: networkingv1.ServiceBackendPort{Number: port},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        utils.GenerateDashboardIngressName(name),
+			Namespace:   namespace,
+			Labels:      labels,
+			Annotations: options.Annotations,
+		},
+		Spec: networkingv1.IngressSpec{
+			IngressClassName: options.IngressClassName,
+			Rules:            []networkingv1.IngressRule{rule},
+		},
+	}
+	if options.TLSSecretName != "" {
+		tls := networkingv1.IngressTLS{SecretName: options.TLSSecretName}
+		if options.Host != "" {
+			tls.Hosts = []string{options.Host}
+		}
+		ingress.Spec.TLS = []networkingv1.IngressTLS{tls}
+	}
+	return ingress
+}
@@ -12,7 +12,9 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 var instanceWithIngressEnabled = &rayv1.RayCluster{
@@ -131,3 +133,30 @@ func TestBuildIngressForHeadService(t *testing.T) {
 		}
 	}
 }
+
+func TestBuildDashboardIngress(t *testing.T) {
+	cluster := instanceWithIngressEnabledWithoutIngressClass.DeepCopy()
+	cluster.Spec.DashboardIngress = &rayv1.DashboardIngressOptions{
+		IngressClassName: ptr.To("nginx"),
+		Host:             "ray.example.com",
+		TLSSecretName:    "ray-tls",
+		Annotations:      map[string]string{"nginx.ingress.kubernetes.io/auth-url": "https://auth.example.com"},
+	}
+
+	labels := map[string]string{utils.KubernetesCreatedByLabelKey: utils.ComponentName}
+	ingress := BuildDashboardIngress(*cluster, cluster.Name, cluster.Namespace, labels, "head-svc")
+	assert.Equal(t, utils.GenerateDashboardIngressName(cluster.Name), ingress.Name)
+	assert.Equal(t, labels, ingress.Labels)
+	assert.Equal(t, cluster.Spec.DashboardIngress.Annotations, ingress.Annotations)
+	assert.Equal(t, "nginx", *ingress.Spec.IngressClassName)
+	assert.Equal(t, []networkingv1.IngressTLS{{Hosts: []string{"ray.example.com"}, SecretName: "ray-tls"}}, ingress.Spec.TLS)
+	assert.Equal(t, "ray.example.com", ingress.Spec.Rules[0].Host)
+	backend := ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service
+	assert.Equal(t, "head-svc", backend.Name)
+	assert.Equal(t, int32(utils.DefaultDashboardPort), backend.Port.Number)
+
+	// The Ingress routes to the oauth2-proxy sidecar if it's enabled.
+	cluster.Spec.DashboardIngress.OAuth2Proxy = &rayv1.OAuth2ProxyOptions{Image: "quay.io/oauth2-proxy/oauth2-proxy"}
+	ingress = BuildDashboardIngress(*cluster, cluster.Name, cluster.Namespace, labels, "head-svc")
+	assert.Equal(t, int32(utils.DefaultOAuth2ProxyPort), ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Port.Number)
+}
@@ -0,0 +1,96 @@
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+
+	configapi "github.com/ray-project/kuberay/ray-operator/apis/config/v1alpha1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// InjectedEnvTemplateData is the data the value templates of an InjectedEnvPolicy are rendered with.
+type InjectedEnvTemplateData struct {
+	// Value is the value KubeRay would inject. It is empty for variables set from a field reference.
+	Value       string
+	ClusterName string
+	Namespace   string
+	NodeType    string
+	GroupName   string
+}
+
+// ApplyInjectedEnvPolicy applies the rules to the environment variables KubeRay injected into the Ray container
+// of the Pod. The first numUserEnv environment variables of the container come from the Pod template and are
+// never changed. If a renamed variable collides with a variable from the Pod template, the one from the Pod
+// template is kept.
+func ApplyInjectedEnvPolicy(pod *corev1.Pod, numUserEnv int, rules configapi.InjectedEnvRules) error {
+	if len(rules.Exclude) == 0 && len(rules.Rename) == 0 && len(rules.Values) == 0 {
+		return nil
+	}
+	container := &pod.Spec.Containers[utils.RayContainerIndex]
+	if numUserEnv > len(container.Env) {
+		numUserEnv = len(container.Env)
+	}
+	userEnv := container.Env[:numUserEnv]
+	env := append([]corev1.EnvVar{}, userEnv...)
+	data := InjectedEnvTemplateData{
+		ClusterName: pod.Labels[utils.RayClusterLabelKey],
+		Namespace:   pod.Namespace,
+		NodeType:    pod.Labels[utils.RayNodeTypeLabelKey],
+		GroupName:   pod.Labels[utils.RayNodeGroupLabelKey],
+	}
+	for _, envVar := range container.Env[numUserEnv:] {
+		if slices.Contains(rules.Exclude, envVar.Name) {
+			continue
+		}
+		if valueTemplate, ok := rules.Values[envVar.Name]; ok {
+			data.Value = envVar.Value
+			value, err := renderInjectedEnvValue(envVar.Name, valueTemplate, data)
+			if err != nil {
+				return err
+			}
+			envVar.Value = value
+			envVar.ValueFrom = nil
+		}
+		if newName, ok := rules.Rename[envVar.Name]; ok {
+			envVar.Name = newName
+		}
+		if utils.EnvVarExists(envVar.Name, userEnv) {
+			continue
+		}
+		env = append(env, envVar)
+	}
+	container.Env = env
+	return nil
+}
+
+// ApplyDashboardMetricsEnv injects the environment variables of the settings into the Ray container of the head Pod,
+// unless they are set in the Pod template.
+func ApplyDashboardMetricsEnv(pod *corev1.Pod, settings configapi.DashboardMetricsSettings) {
+	container := &pod.Spec.Containers[utils.RayContainerIndex]
+	for _, envVar := range []corev1.EnvVar{
+		{Name: utils.RAY_GRAFANA_HOST, Value: settings.GrafanaHost},
+		{Name: utils.RAY_GRAFANA_IFRAME_HOST, Value: settings.GrafanaIframeHost},
+		{Name: utils.RAY_PROMETHEUS_HOST, Value: settings.PrometheusHost},
+		{Name: utils.RAY_PROMETHEUS_NAME, Value: settings.PrometheusName},
+	} {
+		if envVar.Value != "" && !utils.EnvVarExists(envVar.Name, container.Env) {
+			container.Env = append(container.Env, envVar)
+		}
+	}
+}
+
+func renderInjectedEnvValue(name string, valueTemplate string, data InjectedEnvTemplateData) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(valueTemplate)
+	if err != nil {
+		return "", fmt.Errorf("invalid value template for environment variable %s: %w", name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render value of environment variable %s: %w", name, err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,92 @@
+package common
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	configapi "github.com/ray-project/kuberay/ray-operator/apis/config/v1alpha1"
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestApplyInjectedEnvPolicy(t *testing.T) {
+	cluster := instance.DeepCopy()
+	ctx := context.Background()
+	numUserEnv := len(cluster.Spec.WorkerGroupSpecs[0].Template.Spec.Containers[utils.RayContainerIndex].Env)
+	userEnvName := cluster.Spec.WorkerGroupSpecs[0].Template.Spec.Containers[utils.RayContainerIndex].Env[0].Name
+
+	fqdnRayIP := utils.GenerateFQDNServiceName(ctx, *cluster, cluster.Namespace)
+	podName := cluster.Name + utils.DashSymbol + string(rayv1.WorkerNode) + utils.DashSymbol + utils.FormatInt32(0)
+	podTemplateSpec := DefaultWorkerPodTemplate(ctx, *cluster, *cluster.Spec.WorkerGroupSpecs[0].DeepCopy(), podName, fqdnRayIP, "6379")
+	pod := BuildPod(ctx, podTemplateSpec, rayv1.WorkerNode, cluster.Spec.WorkerGroupSpecs[0].RayStartParams, "6379", false, utils.GetCRDType(""), fqdnRayIP)
+
+	rules := configapi.InjectedEnvRules{
+		Exclude: []string{utils.RAY_USAGE_STATS_KUBERAY_IN_USE, userEnvName},
+		Rename:  map[string]string{utils.RAY_ADDRESS: "KUBERAY_RAY_ADDRESS", utils.RAY_PORT: userEnvName},
+		Values: map[string]string{
+			utils.RAY_ADDRESS:      "{{ .ClusterName }}-head-svc.{{ .Namespace }}:10001",
+			utils.FQ_RAY_IP:        "{{ .Value }}:6379",
+			utils.RAY_CLUSTER_NAME: "{{ .NodeType }}/{{ .GroupName }}",
+		},
+	}
+	require.NoError(t, ApplyInjectedEnvPolicy(&pod, numUserEnv, rules))
+
+	rayContainer := pod.Spec.Containers[utils.RayContainerIndex]
+	// Environment variables from the Pod template are never changed.
+	assert.Equal(t, cluster.Spec.WorkerGroupSpecs[0].Template.Spec.Containers[utils.RayContainerIndex].Env, rayContainer.Env[:numUserEnv])
+	assert.Nil(t, getEnvVar(rayContainer, utils.RAY_USAGE_STATS_KUBERAY_IN_USE))
+	assert.Nil(t, getEnvVar(rayContainer, utils.RAY_ADDRESS))
+	assert.Nil(t, getEnvVar(rayContainer, utils.RAY_PORT))
+	checkContainerEnv(t, rayContainer, "KUBERAY_RAY_ADDRESS", "raycluster-sample-head-svc.default:10001")
+	checkContainerEnv(t, rayContainer, utils.RAY_CLUSTER_NAME, "worker/"+cluster.Spec.WorkerGroupSpecs[0].GroupName)
+	checkContainerEnv(t, rayContainer, utils.FQ_RAY_IP, fqdnRayIP+":6379")
+
+	count := 0
+	for _, env := range rayContainer.Env {
+		if env.Name == userEnvName {
+			count++
+		}
+	}
+	assert.Equal(t, 1, count, "the renamed variable must not override the one from the Pod template")
+}
+
+func TestApplyInjectedEnvPolicyInvalidTemplate(t *testing.T) {
+	cluster := instance.DeepCopy()
+	ctx := context.Background()
+	podTemplateSpec := DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, "head", "6379")
+	pod := BuildPod(ctx, podTemplateSpec, rayv1.HeadNode, cluster.Spec.HeadGroupSpec.RayStartParams, "6379", false, utils.GetCRDType(""), "")
+
+	err := ApplyInjectedEnvPolicy(&pod, 0, configapi.InjectedEnvRules{
+		Values: map[string]string{utils.RAY_ADDRESS: "{{ .Unknown }}"},
+	})
+	require.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), utils.RAY_ADDRESS))
+}
+
+func TestApplyDashboardMetricsEnv(t *testing.T) {
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "ray-head",
+					Env:  []corev1.EnvVar{{Name: utils.RAY_GRAFANA_HOST, Value: "http://user-grafana:3000"}},
+				},
+			},
+		},
+	}
+	ApplyDashboardMetricsEnv(&pod, configapi.DashboardMetricsSettings{
+		GrafanaHost:    "http://grafana.monitoring:3000",
+		PrometheusHost: "http://prometheus.monitoring:9090",
+	})
+
+	// The value set by the user is kept and empty settings are not injected.
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: utils.RAY_GRAFANA_HOST, Value: "http://user-grafana:3000"},
+		{Name: utils.RAY_PROMETHEUS_HOST, Value: "http://prometheus.monitoring:9090"},
+	}, pod.Spec.Containers[utils.RayContainerIndex].Env)
+}
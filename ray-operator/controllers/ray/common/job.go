@@ -21,6 +21,19 @@ import (
 func getRuntimeEnvJson(rayJobInstance *rayv1.RayJob) (string, error) {
 	runtimeEnvYAML := rayJobInstance.Spec.RuntimeEnvYAML
 
+	if rayJobInstance.Spec.Checkpointing != nil {
+		// The runtime environment is amended with the location of the checkpoints.
+		runtimeEnv, err := utils.GetRayJobRuntimeEnv(rayJobInstance)
+		if err != nil {
+			return "", err
+		}
+		jsonData, err := json.Marshal(runtimeEnv)
+		if err != nil {
+			return "", err
+		}
+		return pkgutils.ConvertByteSliceToString(jsonData), nil
+	}
+
 	if len(runtimeEnvYAML) > 0 {
 		// Convert YAML to JSON
 		jsonData, err := yaml.YAMLToJSON(pkgutils.ConvertStringToByteSlice(runtimeEnvYAML))
@@ -55,6 +68,11 @@ func GetMetadataJson(metadata map[string]string, rayVersion string) (string, err
 	return pkgutils.ConvertByteSliceToString(metadataBytes), nil
 }
 
+// SubmitterContainerName is the name of the container running the submission in the default submitter template.
+// In a user-provided template, the container with this name runs the submission, or the first container if there
+// is none.
+const SubmitterContainerName = "ray-job-submitter"
+
 // GetK8sJobCommand builds the K8s job command for the Ray job.
 func GetK8sJobCommand(rayJobInstance *rayv1.RayJob) ([]string, error) {
 	address := rayJobInstance.Status.DashboardURL
@@ -139,7 +157,7 @@ func GetDefaultSubmitterTemplate(rayClusterInstance *rayv1.RayCluster) corev1.Po
 		Spec: corev1.PodSpec{
 			Containers: []corev1.Container{
 				{
-					Name: "ray-job-submitter",
+					Name: SubmitterContainerName,
 					// Use the image of the Ray head to be defensive against version mismatch issues
 					Image: rayClusterInstance.Spec.HeadGroupSpec.Template.Spec.Containers[utils.RayContainerIndex].Image,
 					Resources: corev1.ResourceRequirements{
@@ -158,3 +176,20 @@ func GetDefaultSubmitterTemplate(rayClusterInstance *rayv1.RayCluster) corev1.Po
 		},
 	}
 }
+
+// GetSubmitterContainerIndex returns the index of the container running the submission in the submitter Pod spec.
+func GetSubmitterContainerIndex(podSpec corev1.PodSpec) int {
+	for i, container := range podSpec.Containers {
+		if container.Name == SubmitterContainerName {
+			return i
+		}
+	}
+	return utils.RayContainerIndex
+}
+
+// IsSubmitterPodReused returns whether the Ray job is submitted by KubeRay through the Ray dashboard HTTP API,
+// instead of a submitter Kubernetes Job running a submitter Pod per RayJob.
+func IsSubmitterPodReused(rayJobInstance *rayv1.RayJob) bool {
+	return rayJobInstance.Spec.SubmissionMode == rayv1.K8sJobMode && rayJobInstance.Spec.SubmitterConfig != nil &&
+		rayJobInstance.Spec.SubmitterConfig.ReuseSubmitterPod != nil && *rayJobInstance.Spec.SubmitterConfig.ReuseSubmitterPod
+}
@@ -63,6 +63,42 @@ pip: ["python-multipart==0.0.6"]
 	assert.Equal(t, expectedMap, actualMap)
 }
 
+func TestGetRuntimeEnvJsonWithCheckpointing(t *testing.T) {
+	rayJob := &rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			RuntimeEnvYAML: `
+pip: ["torch"]
+env_vars:
+  FOO: bar
+`,
+			Checkpointing: &rayv1.CheckpointingOptions{URI: "s3://bucket/checkpoints"},
+		},
+		Status: rayv1.RayJobStatus{
+			ResumedFromCheckpointURI: "s3://bucket/checkpoints",
+		},
+	}
+	jsonOutput, err := getRuntimeEnvJson(rayJob)
+	assert.NoError(t, err)
+
+	var actualMap map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(jsonOutput), &actualMap))
+	assert.Equal(t, map[string]interface{}{
+		"pip": []interface{}{"torch"},
+		"env_vars": map[string]interface{}{
+			"FOO":                                    "bar",
+			utils.RAY_JOB_CHECKPOINT_URI:             "s3://bucket/checkpoints",
+			utils.RAY_JOB_RESUME_FROM_CHECKPOINT_URI: "s3://bucket/checkpoints",
+		},
+	}, actualMap)
+
+	// The first attempt doesn't resume from a checkpoint.
+	rayJob.Spec.RuntimeEnvYAML = ""
+	rayJob.Status.ResumedFromCheckpointURI = ""
+	jsonOutput, err = getRuntimeEnvJson(rayJob)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"env_vars":{"RAY_JOB_CHECKPOINT_URI":"s3://bucket/checkpoints"}}`, jsonOutput)
+}
+
 func TestGetMetadataJson(t *testing.T) {
 	expected := `{"testKey":"testValue"}`
 	metadataJson, err := GetMetadataJson(testRayJob.Spec.Metadata, testRayJob.Spec.RayClusterSpec.RayVersion)
@@ -0,0 +1,103 @@
+package common
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+const (
+	MaintenanceResponderContainerName = "maintenance-responder"
+	maintenanceResponderConfigKey     = "default.conf"
+)
+
+// MaintenanceResponderName returns the name of the Deployment and the ConfigMap of the maintenance responder of the
+// RayService.
+func MaintenanceResponderName(rayService *rayv1.RayService) string {
+	return utils.CheckName(fmt.Sprintf("%s-maintenance", rayService.Name))
+}
+
+// MaintenanceResponderSelector returns the selector of the serve service of the RayService while it's in maintenance.
+func MaintenanceResponderSelector(rayService *rayv1.RayService) map[string]string {
+	return map[string]string{utils.RayServiceMaintenanceLabelKey: rayService.Name}
+}
+
+// BuildMaintenanceResponder returns the ConfigMap and the Deployment of the maintenance responder of the RayService.
+// The nginx server listens on the serve port of the head container, so that the serve service can select it without
+// changing its ports, and answers every request with a 503.
+func BuildMaintenanceResponder(rayService *rayv1.RayService) (*corev1.ConfigMap, *appsv1.Deployment) {
+	name := MaintenanceResponderName(rayService)
+	labels := MaintenanceResponderSelector(rayService)
+	labels[utils.KubernetesCreatedByLabelKey] = utils.ComponentName
+
+	port := utils.DefaultServingPort
+	headContainers := rayService.Spec.RayClusterSpec.HeadGroupSpec.Template.Spec.Containers
+	if len(headContainers) > utils.RayContainerIndex {
+		port = utils.FindContainerPort(&headContainers[utils.RayContainerIndex], utils.ServingPortName, utils.DefaultServingPort)
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: rayService.Namespace,
+			Labels:    labels,
+		},
+		Data: map[string]string{
+			maintenanceResponderConfigKey: fmt.Sprintf(`server {
+    listen %d;
+    listen [::]:%d;
+    location / {
+        default_type text/plain;
+        return 503 "The service is under maintenance.\n";
+    }
+}
+`, port, port),
+		},
+	}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: rayService.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](1),
+			Selector: &metav1.LabelSelector{MatchLabels: MaintenanceResponderSelector(rayService)},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  MaintenanceResponderContainerName,
+							Image: utils.DefaultMaintenanceResponderImage,
+							Ports: []corev1.ContainerPort{{Name: utils.ServingPortName, ContainerPort: int32(port)}},
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(port)},
+								},
+							},
+							VolumeMounts: []corev1.VolumeMount{{Name: "config", MountPath: "/etc/nginx/conf.d", ReadOnly: true}},
+						},
+					},
+					ImagePullSecrets: rayService.Spec.ImagePullSecrets,
+					Volumes: []corev1.Volume{
+						{
+							Name: "config",
+							VolumeSource: corev1.VolumeSource{
+								ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: name}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	return configMap, deployment
+}
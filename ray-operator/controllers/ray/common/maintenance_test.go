@@ -0,0 +1,41 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestBuildMaintenanceResponder(t *testing.T) {
+	rayService := &rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{Name: "rayservice", Namespace: "default"},
+		Spec: rayv1.RayServiceSpec{
+			RayClusterSpec:   *instance.Spec.DeepCopy(),
+			ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry"}},
+		},
+	}
+	rayService.Spec.RayClusterSpec.HeadGroupSpec.Template.Spec.Containers[utils.RayContainerIndex].Ports = []corev1.ContainerPort{
+		{Name: utils.ServingPortName, ContainerPort: 9000},
+	}
+
+	configMap, deployment := BuildMaintenanceResponder(rayService)
+	assert.Equal(t, "rayservice-maintenance", configMap.Name)
+	assert.Equal(t, "rayservice-maintenance", deployment.Name)
+	assert.Contains(t, configMap.Data[maintenanceResponderConfigKey], "listen 9000;")
+	assert.Contains(t, configMap.Data[maintenanceResponderConfigKey], "return 503")
+	// The serve service selects the Pods of the responder, and the operator caches the Deployment.
+	for k, v := range MaintenanceResponderSelector(rayService) {
+		assert.Equal(t, v, deployment.Spec.Template.Labels[k])
+	}
+	assert.Equal(t, utils.ComponentName, deployment.Labels[utils.KubernetesCreatedByLabelKey])
+	assert.NotContains(t, deployment.Spec.Template.Labels, utils.RayClusterLabelKey)
+	container := deployment.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, utils.DefaultMaintenanceResponderImage, container.Image)
+	assert.Equal(t, int32(9000), container.Ports[0].ContainerPort)
+	assert.Equal(t, rayService.Spec.ImagePullSecrets, deployment.Spec.Template.Spec.ImagePullSecrets)
+}
@@ -0,0 +1,172 @@
+package common
+
+import (
+	"math"
+	"slices"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+const (
+	// oomKilledReason is the reason of the terminated state of a container killed for running out of memory.
+	oomKilledReason = "OOMKilled"
+	// evictedReason is the reason of a Pod evicted by the kubelet or by the eviction API.
+	evictedReason = "Evicted"
+	// defaultOOMKillsPerIncrease is the default number of OOM kills after which the OOMPolicy increases the memory.
+	defaultOOMKillsPerIncrease = 3
+)
+
+// MemoryFailure is an OOM kill of the Ray container of a Pod, or an eviction of a Pod.
+type MemoryFailure struct {
+	Time      metav1.Time
+	PodName   string
+	GroupName string
+	Evicted   bool
+}
+
+// findGroupMemoryFailures returns the status of the group, or nil if the group has no memory failures.
+func findGroupMemoryFailures(statuses []rayv1.GroupMemoryFailures, groupName string) *rayv1.GroupMemoryFailures {
+	for i := range statuses {
+		if statuses[i].GroupName == groupName {
+			return &statuses[i]
+		}
+	}
+	return nil
+}
+
+// isAfter returns whether t is after the last recorded time, if any.
+func isAfter(t metav1.Time, last *metav1.Time) bool {
+	return last == nil || t.After(last.Time)
+}
+
+// getEvictionTime returns the last transition time of the conditions of an evicted Pod, which doesn't change once
+// the Pod is evicted, so that the eviction is only counted once.
+func getEvictionTime(pod *corev1.Pod) metav1.Time {
+	var evictionTime metav1.Time
+	for _, condition := range pod.Status.Conditions {
+		if condition.LastTransitionTime.After(evictionTime.Time) {
+			evictionTime = condition.LastTransitionTime
+		}
+	}
+	if evictionTime.IsZero() && pod.Status.StartTime != nil {
+		evictionTime = *pod.Status.StartTime
+	}
+	return evictionTime
+}
+
+// FindMemoryFailures returns the OOM kills of the Ray containers and the evictions of the Pods that happened after
+// the last ones recorded in the statuses, sorted by time. Only the last two terminations of a container are visible
+// in its status, so the OOM kills of a container that restarts faster than the RayCluster is reconciled may be
+// undercounted.
+func FindMemoryFailures(pods []corev1.Pod, statuses []rayv1.GroupMemoryFailures) []MemoryFailure {
+	var failures []MemoryFailure
+	for i := range pods {
+		pod := &pods[i]
+		groupName := pod.Labels[utils.RayNodeGroupLabelKey]
+		if groupName == "" || len(pod.Spec.Containers) == 0 {
+			continue
+		}
+		var lastOOMKilledTime, lastEvictedTime *metav1.Time
+		if status := findGroupMemoryFailures(statuses, groupName); status != nil {
+			lastOOMKilledTime, lastEvictedTime = status.LastOOMKilledTime, status.LastEvictedTime
+		}
+
+		if pod.Status.Reason == evictedReason {
+			if evictionTime := getEvictionTime(pod); !evictionTime.IsZero() && isAfter(evictionTime, lastEvictedTime) {
+				failures = append(failures, MemoryFailure{Time: evictionTime, PodName: pod.Name, GroupName: groupName, Evicted: true})
+			}
+			continue
+		}
+
+		rayContainerName := pod.Spec.Containers[utils.RayContainerIndex].Name
+		for _, containerStatus := range pod.Status.ContainerStatuses {
+			if containerStatus.Name != rayContainerName {
+				continue
+			}
+			for _, terminated := range []*corev1.ContainerStateTerminated{containerStatus.State.Terminated, containerStatus.LastTerminationState.Terminated} {
+				if terminated != nil && terminated.Reason == oomKilledReason && isAfter(terminated.FinishedAt, lastOOMKilledTime) {
+					failures = append(failures, MemoryFailure{Time: terminated.FinishedAt, PodName: pod.Name, GroupName: groupName})
+				}
+			}
+		}
+	}
+	sort.SliceStable(failures, func(i, j int) bool {
+		return failures[i].Time.Before(&failures[j].Time)
+	})
+	return failures
+}
+
+// RecordMemoryFailures adds the failures to the statuses of their groups, and returns the updated statuses.
+func RecordMemoryFailures(statuses []rayv1.GroupMemoryFailures, failures []MemoryFailure) []rayv1.GroupMemoryFailures {
+	for _, failure := range failures {
+		status := findGroupMemoryFailures(statuses, failure.GroupName)
+		if status == nil {
+			statuses = append(statuses, rayv1.GroupMemoryFailures{GroupName: failure.GroupName})
+			status = &statuses[len(statuses)-1]
+		}
+		failureTime := failure.Time.DeepCopy()
+		if failure.Evicted {
+			status.Evicted++
+			if isAfter(*failureTime, status.LastEvictedTime) {
+				status.LastEvictedTime = failureTime
+			}
+		} else {
+			status.OOMKilled++
+			if isAfter(*failureTime, status.LastOOMKilledTime) {
+				status.LastOOMKilledTime = failureTime
+			}
+		}
+	}
+	return statuses
+}
+
+// GetMemoryIncreases returns the number of times the OOMPolicy increases the memory of the Pods of a group that had
+// the given number of OOM kills.
+func GetMemoryIncreases(policy *rayv1.OOMPolicy, oomKilled int32) int32 {
+	if policy == nil || policy.MemoryIncreasePercent <= 0 {
+		return 0
+	}
+	oomKillsPerIncrease := int32(defaultOOMKillsPerIncrease)
+	if policy.OOMKillsPerIncrease != nil && *policy.OOMKillsPerIncrease > 0 {
+		oomKillsPerIncrease = *policy.OOMKillsPerIncrease
+	}
+	return oomKilled / oomKillsPerIncrease
+}
+
+// increaseMemory returns the quantity increased by the factor, capped at the maximum memory. A quantity that already
+// exceeds the maximum is kept.
+func increaseMemory(quantity resource.Quantity, factor float64, maxMemory *resource.Quantity) resource.Quantity {
+	increased := resource.NewQuantity(int64(math.Ceil(float64(quantity.Value())*factor)), resource.BinarySI)
+	if maxMemory != nil && increased.Cmp(*maxMemory) > 0 {
+		if quantity.Cmp(*maxMemory) > 0 {
+			return quantity
+		}
+		return maxMemory.DeepCopy()
+	}
+	return *increased
+}
+
+// ApplyOOMPolicy increases the memory request and limit of the Ray container by the memory increase percentage of the
+// OOMPolicy, compounded for each increase.
+func ApplyOOMPolicy(podSpec *corev1.PodSpec, policy *rayv1.OOMPolicy, increases int32) {
+	if policy == nil || increases <= 0 || len(podSpec.Containers) == 0 {
+		return
+	}
+	factor := math.Pow(1+float64(policy.MemoryIncreasePercent)/100, float64(increases))
+	// The containers may be shared with the template of the RayCluster.
+	podSpec.Containers = slices.Clone(podSpec.Containers)
+	container := &podSpec.Containers[utils.RayContainerIndex]
+	container.Resources = *container.Resources.DeepCopy()
+	if memory, ok := container.Resources.Requests[corev1.ResourceMemory]; ok {
+		container.Resources.Requests[corev1.ResourceMemory] = increaseMemory(memory, factor, policy.MaxMemory)
+	}
+	if memory, ok := container.Resources.Limits[corev1.ResourceMemory]; ok {
+		container.Resources.Limits[corev1.ResourceMemory] = increaseMemory(memory, factor, policy.MaxMemory)
+	}
+}
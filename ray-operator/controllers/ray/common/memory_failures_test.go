@@ -0,0 +1,88 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func newMemoryFailureTestPod(name string, groupName string) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{utils.RayNodeGroupLabelKey: groupName}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "ray-worker"}, {Name: "sidecar"}}},
+	}
+}
+
+func TestFindMemoryFailures(t *testing.T) {
+	t1 := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	t2 := metav1.NewTime(t1.Add(time.Minute))
+	t3 := metav1.NewTime(t1.Add(2 * time.Minute))
+
+	restarted := newMemoryFailureTestPod("restarted", "small-group")
+	restarted.Status.ContainerStatuses = []corev1.ContainerStatus{
+		{Name: "ray-worker", LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", FinishedAt: t1}}},
+		// The OOM kills of the other containers aren't counted.
+		{Name: "sidecar", LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", FinishedAt: t3}}},
+	}
+	failed := newMemoryFailureTestPod("failed", "small-group")
+	failed.Status.ContainerStatuses = []corev1.ContainerStatus{
+		{Name: "ray-worker", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Reason: "OOMKilled", FinishedAt: t3}}},
+	}
+	evicted := newMemoryFailureTestPod("evicted", "headgroup")
+	evicted.Status.Phase = corev1.PodFailed
+	evicted.Status.Reason = "Evicted"
+	evicted.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, LastTransitionTime: t2}}
+	pods := []corev1.Pod{failed, restarted, evicted}
+
+	failures := FindMemoryFailures(pods, nil)
+	assert.Equal(t, []MemoryFailure{
+		{Time: t1, PodName: "restarted", GroupName: "small-group"},
+		{Time: t2, PodName: "evicted", GroupName: "headgroup", Evicted: true},
+		{Time: t3, PodName: "failed", GroupName: "small-group"},
+	}, failures)
+
+	statuses := RecordMemoryFailures(nil, failures)
+	assert.Equal(t, []rayv1.GroupMemoryFailures{
+		{GroupName: "small-group", OOMKilled: 2, LastOOMKilledTime: &t3},
+		{GroupName: "headgroup", Evicted: 1, LastEvictedTime: &t2},
+	}, statuses)
+
+	// The failures that were already recorded aren't counted again.
+	assert.Empty(t, FindMemoryFailures(pods, statuses))
+}
+
+func TestGetMemoryIncreases(t *testing.T) {
+	assert.Equal(t, int32(0), GetMemoryIncreases(nil, 10))
+	assert.Equal(t, int32(3), GetMemoryIncreases(&rayv1.OOMPolicy{MemoryIncreasePercent: 20}, 10))
+	assert.Equal(t, int32(5), GetMemoryIncreases(&rayv1.OOMPolicy{MemoryIncreasePercent: 20, OOMKillsPerIncrease: ptr.To[int32](2)}, 10))
+}
+
+func TestApplyOOMPolicy(t *testing.T) {
+	templateResources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi"), corev1.ResourceCPU: resource.MustParse("1")},
+		Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+	}
+	podSpec := corev1.PodSpec{Containers: []corev1.Container{{Resources: templateResources}}}
+
+	ApplyOOMPolicy(&podSpec, &rayv1.OOMPolicy{MemoryIncreasePercent: 50}, 0)
+	assert.Equal(t, templateResources, podSpec.Containers[0].Resources)
+
+	// The increases are compounded, and the maximum memory caps the limit.
+	maxMemory := resource.MustParse("3Gi")
+	ApplyOOMPolicy(&podSpec, &rayv1.OOMPolicy{MemoryIncreasePercent: 50, MaxMemory: &maxMemory}, 2)
+	resources := podSpec.Containers[0].Resources
+	assert.Equal(t, int64(2304*1024*1024), resources.Requests.Memory().Value())
+	assert.Equal(t, maxMemory.Value(), resources.Limits.Memory().Value())
+	assert.Equal(t, "1", resources.Requests.Cpu().String())
+	// The resources of the template aren't modified.
+	assert.Equal(t, "1Gi", templateResources.Requests.Memory().String())
+	assert.Equal(t, "2Gi", templateResources.Limits.Memory().String())
+}
@@ -0,0 +1,75 @@
+package common
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+// ValidateMetadataSync checks that the keys of the MetadataSync are valid label and annotation keys, and that no
+// label of KubeRay is synced.
+func ValidateMetadataSync(metadataSync *rayv1.MetadataSync) error {
+	if metadataSync == nil {
+		return nil
+	}
+	for _, key := range metadataSync.Labels {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("invalid label key %q: %s", key, strings.Join(errs, ", "))
+		}
+		if strings.HasPrefix(key, kubeRayLabelPrefix) {
+			return fmt.Errorf("the label %s of KubeRay can't be synced", key)
+		}
+	}
+	for _, key := range metadataSync.Annotations {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("invalid annotation key %q: %s", key, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
+// syncKeys returns the target with the values of the keys of the source, and whether it changed. The keys missing
+// from the source are left unchanged. The target is copied before it's changed, since it may be shared with the
+// template of the RayCluster.
+func syncKeys(target map[string]string, source map[string]string, keys []string) (map[string]string, bool) {
+	var synced map[string]string
+	for _, key := range keys {
+		value, ok := source[key]
+		if !ok {
+			continue
+		}
+		if current, ok := target[key]; ok && current == value {
+			continue
+		}
+		if synced == nil {
+			synced = maps.Clone(target)
+			if synced == nil {
+				synced = map[string]string{}
+			}
+		}
+		synced[key] = value
+	}
+	if synced == nil {
+		return target, false
+	}
+	return synced, true
+}
+
+// SyncMetadata copies the labels and the annotations of the RayCluster listed in its MetadataSync to the object, a Pod
+// or a Service of the RayCluster, and returns whether the object changed.
+func SyncMetadata(object metav1.Object, instance *rayv1.RayCluster) bool {
+	metadataSync := instance.Spec.MetadataSync
+	if metadataSync == nil {
+		return false
+	}
+	labels, labelsChanged := syncKeys(object.GetLabels(), instance.Labels, metadataSync.Labels)
+	object.SetLabels(labels)
+	annotations, annotationsChanged := syncKeys(object.GetAnnotations(), instance.Annotations, metadataSync.Annotations)
+	object.SetAnnotations(annotations)
+	return labelsChanged || annotationsChanged
+}
@@ -0,0 +1,48 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestValidateMetadataSync(t *testing.T) {
+	require.NoError(t, ValidateMetadataSync(nil))
+	require.NoError(t, ValidateMetadataSync(&rayv1.MetadataSync{Labels: []string{"team", "example.com/cost-center"}, Annotations: []string{"ray.io/revision"}}))
+	require.ErrorContains(t, ValidateMetadataSync(&rayv1.MetadataSync{Labels: []string{"ray.io/cluster"}}), "can't be synced")
+	require.ErrorContains(t, ValidateMetadataSync(&rayv1.MetadataSync{Labels: []string{"cost center"}}), "invalid label key")
+	require.ErrorContains(t, ValidateMetadataSync(&rayv1.MetadataSync{Annotations: []string{"/revision"}}), "invalid annotation key")
+}
+
+func TestSyncMetadata(t *testing.T) {
+	instance := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      map[string]string{"team": "ml", "env": "production"},
+			Annotations: map[string]string{"revision": "2"},
+		},
+		Spec: rayv1.RayClusterSpec{
+			MetadataSync: &rayv1.MetadataSync{Labels: []string{"team", "cost-center"}, Annotations: []string{"revision"}},
+		},
+	}
+	templateLabels := map[string]string{"team": "research", "app": "ray"}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: templateLabels}}
+
+	assert.True(t, SyncMetadata(pod, instance))
+	// Only the listed keys are synced, and the keys that the RayCluster doesn't have are left unchanged.
+	assert.Equal(t, map[string]string{"team": "ml", "app": "ray"}, pod.Labels)
+	assert.Equal(t, map[string]string{"revision": "2"}, pod.Annotations)
+	// The labels of the template aren't modified.
+	assert.Equal(t, "research", templateLabels["team"])
+
+	assert.False(t, SyncMetadata(pod, instance))
+
+	instance.Spec.MetadataSync = nil
+	instance.Labels["team"] = "infra"
+	assert.False(t, SyncMetadata(pod, instance))
+	assert.Equal(t, "ml", pod.Labels["team"])
+}
@@ -36,6 +36,19 @@ var (
 		},
 		[]string{"namespace"},
 	)
+	workersToDeleteIgnoredCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ray_operator_workers_to_delete_ignored_total",
+			Help: "Counts number of entries of WorkersToDelete ignored because their Pods don't exist or belong to another worker group",
+		},
+		[]string{"namespace", "reason"},
+	)
+)
+
+// Reasons of the entries of WorkersToDelete that are ignored.
+const (
+	WorkerToDeleteNotFound     = "NotFound"
+	WorkerToDeleteInOtherGroup = "OtherGroup"
 )
 
 func init() {
@@ -43,7 +56,8 @@ func init() {
 	metrics.Registry.MustRegister(clustersCreatedCount,
 		clustersDeletedCount,
 		clustersSuccessfulCount,
-		clustersFailedCount)
+		clustersFailedCount,
+		workersToDeleteIgnoredCount)
 }
 
 func CreatedClustersCounterInc(namespace string) {
@@ -62,3 +76,7 @@ func SuccessfulClustersCounterInc(namespace string) {
 func FailedClustersCounterInc(namespace string) {
 	clustersFailedCount.WithLabelValues(namespace).Inc()
 }
+
+func WorkersToDeleteIgnoredCounterInc(namespace string, reason string) {
+	workersToDeleteIgnoredCount.WithLabelValues(namespace, reason).Inc()
+}
@@ -0,0 +1,132 @@
+package common
+
+import (
+	"fmt"
+	"path"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+const (
+	// metricsExporterConfigEnvVar is the environment variable of the metrics exporter sidecar that holds the
+	// Prometheus configuration, which the sidecar writes to a file before starting Prometheus.
+	metricsExporterConfigEnvVar = "PROMETHEUS_CONFIG"
+	// metricsExporterPodNameEnvVar is expanded in the external labels of the Prometheus configuration.
+	metricsExporterPodNameEnvVar = "POD_NAME"
+	metricsExporterTokenVolume   = "ray-metrics-exporter-token"
+	metricsExporterTokenDir      = "/etc/ray-metrics-exporter"
+	metricsExporterTokenFile     = "token"
+)
+
+// configureMetricsExporter injects the metrics exporter sidecar into the head or worker Pod template if the
+// MetricsOptions of the RayCluster are set. It must be called after the metrics port of the Ray container is set.
+func configureMetricsExporter(podTemplate *corev1.PodTemplateSpec, instance rayv1.RayCluster, rayNodeType rayv1.RayNodeType, groupName string) {
+	options := instance.Spec.MetricsOptions
+	if options == nil {
+		return
+	}
+	container := BuildMetricsExporterContainer(instance, podTemplate, rayNodeType, groupName)
+	podTemplate.Spec.Containers = append(podTemplate.Spec.Containers, container)
+	if options.BearerTokenSecret != nil {
+		podTemplate.Spec.Volumes = append(podTemplate.Spec.Volumes, corev1.Volume{
+			Name: metricsExporterTokenVolume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: options.BearerTokenSecret.Name,
+					Items:      []corev1.KeyToPath{{Key: options.BearerTokenSecret.Key, Path: metricsExporterTokenFile}},
+					Optional:   options.BearerTokenSecret.Optional,
+				},
+			},
+		})
+	}
+}
+
+// BuildMetricsExporterContainer builds the sidecar that runs Prometheus in agent mode to scrape the metrics port of the
+// Ray container and remote-write the metrics.
+func BuildMetricsExporterContainer(instance rayv1.RayCluster, podTemplate *corev1.PodTemplateSpec, rayNodeType rayv1.RayNodeType, groupName string) corev1.Container {
+	options := instance.Spec.MetricsOptions
+	image := utils.DefaultMetricsExporterImage
+	if options.Image != nil {
+		image = *options.Image
+	}
+	metricsPort := utils.FindContainerPort(&podTemplate.Spec.Containers[utils.RayContainerIndex], utils.MetricsPortName, utils.DefaultMetricsPort)
+
+	script := fmt.Sprintf("printf '%%s' \"$%s\" > /tmp/prometheus.yml && exec /bin/prometheus --config.file=/tmp/prometheus.yml "+
+		"--enable-feature=agent,expand-external-labels --storage.agent.path=/tmp/prometheus-agent", metricsExporterConfigEnvVar)
+	container := corev1.Container{
+		Name:    utils.MetricsExporterContainerName,
+		Image:   image,
+		Command: []string{"/bin/sh", "-c", script},
+		Env: []corev1.EnvVar{
+			{
+				Name: metricsExporterPodNameEnvVar,
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"},
+				},
+			},
+			{
+				Name:  metricsExporterConfigEnvVar,
+				Value: buildMetricsExporterConfig(instance, rayNodeType, groupName, metricsPort),
+			},
+		},
+	}
+	if options.BearerTokenSecret != nil {
+		container.VolumeMounts = []corev1.VolumeMount{{Name: metricsExporterTokenVolume, MountPath: metricsExporterTokenDir, ReadOnly: true}}
+	}
+	if options.Resources != nil {
+		container.Resources = *options.Resources
+	}
+	return container
+}
+
+// buildMetricsExporterConfig generates the Prometheus configuration of the metrics exporter sidecar.
+func buildMetricsExporterConfig(instance rayv1.RayCluster, rayNodeType rayv1.RayNodeType, groupName string, metricsPort int) string {
+	options := instance.Spec.MetricsOptions
+	scrapeInterval := int32(utils.DefaultMetricsScrapeIntervalSeconds)
+	if options.ScrapeIntervalSeconds != nil {
+		scrapeInterval = *options.ScrapeIntervalSeconds
+	}
+
+	externalLabels := map[string]string{}
+	for k, v := range options.ExternalLabels {
+		externalLabels[k] = v
+	}
+	externalLabels["namespace"] = instance.Namespace
+	externalLabels["ray_cluster"] = instance.Name
+	externalLabels["ray_node_type"] = string(rayNodeType)
+	externalLabels["ray_group"] = groupName
+	externalLabels["pod"] = fmt.Sprintf("${%s}", metricsExporterPodNameEnvVar)
+	if name, ok := instance.Labels[utils.RayOriginatedFromCRNameLabelKey]; ok {
+		externalLabels["ray_originated_from_cr_name"] = name
+		externalLabels["ray_originated_from_crd"] = instance.Labels[utils.RayOriginatedFromCRDLabelKey]
+	}
+
+	remoteWrite := map[string]interface{}{"url": options.RemoteWriteURL}
+	if options.BearerTokenSecret != nil {
+		remoteWrite["authorization"] = map[string]interface{}{
+			"credentials_file": path.Join(metricsExporterTokenDir, metricsExporterTokenFile),
+		}
+	}
+	config := map[string]interface{}{
+		"global": map[string]interface{}{
+			"scrape_interval": fmt.Sprintf("%ds", scrapeInterval),
+			"external_labels": externalLabels,
+		},
+		"scrape_configs": []interface{}{
+			map[string]interface{}{
+				"job_name": "ray",
+				"static_configs": []interface{}{
+					map[string]interface{}{"targets": []string{fmt.Sprintf("localhost:%d", metricsPort)}},
+				},
+			},
+		},
+		"remote_write": []interface{}{remoteWrite},
+	}
+	// The configuration only consists of strings, maps and slices, so marshaling it can't fail.
+	data, _ := yaml.Marshal(config)
+	return string(data)
+}
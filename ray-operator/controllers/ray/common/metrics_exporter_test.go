@@ -0,0 +1,104 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestConfigureMetricsExporter(t *testing.T) {
+	ctx := context.Background()
+	cluster := instance.DeepCopy()
+	cluster.Labels = map[string]string{
+		utils.RayOriginatedFromCRNameLabelKey: "rayjob-sample",
+		utils.RayOriginatedFromCRDLabelKey:    utils.RayOriginatedFromCRDLabelValue(utils.RayJobCRD),
+	}
+
+	// No sidecar is injected without MetricsOptions.
+	podTemplate := DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, "head", "6379")
+	assert.Equal(t, -1, findContainer(podTemplate.Spec.Containers, utils.MetricsExporterContainerName))
+
+	cluster.Spec.MetricsOptions = &rayv1.MetricsOptions{
+		RemoteWriteURL:        "http://prometheus.monitoring:9090/api/v1/write",
+		ScrapeIntervalSeconds: ptr.To[int32](30),
+		BearerTokenSecret: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "remote-write"},
+			Key:                  "token",
+		},
+		ExternalLabels: map[string]string{"k8s_cluster": "prod"},
+	}
+	worker := cluster.Spec.WorkerGroupSpecs[0]
+	podTemplate = DefaultWorkerPodTemplate(ctx, *cluster, *worker.DeepCopy(), "worker", "fqdn", "6379")
+	i := findContainer(podTemplate.Spec.Containers, utils.MetricsExporterContainerName)
+	require.NotEqual(t, -1, i)
+	container := podTemplate.Spec.Containers[i]
+	assert.Equal(t, utils.DefaultMetricsExporterImage, container.Image)
+	assert.Equal(t, []corev1.VolumeMount{{Name: metricsExporterTokenVolume, MountPath: metricsExporterTokenDir, ReadOnly: true}}, container.VolumeMounts)
+	assert.Contains(t, podTemplate.Spec.Volumes, corev1.Volume{
+		Name: metricsExporterTokenVolume,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: "remote-write",
+				Items:      []corev1.KeyToPath{{Key: "token", Path: metricsExporterTokenFile}},
+			},
+		},
+	})
+
+	var configValue string
+	for _, env := range container.Env {
+		if env.Name == metricsExporterConfigEnvVar {
+			configValue = env.Value
+		}
+	}
+	config := struct {
+		Global struct {
+			ScrapeInterval string            `json:"scrape_interval"`
+			ExternalLabels map[string]string `json:"external_labels"`
+		} `json:"global"`
+		ScrapeConfigs []struct {
+			StaticConfigs []struct {
+				Targets []string `json:"targets"`
+			} `json:"static_configs"`
+		} `json:"scrape_configs"`
+		RemoteWrite []struct {
+			URL           string `json:"url"`
+			Authorization struct {
+				CredentialsFile string `json:"credentials_file"`
+			} `json:"authorization"`
+		} `json:"remote_write"`
+	}{}
+	require.NoError(t, yaml.Unmarshal([]byte(configValue), &config))
+	assert.Equal(t, "30s", config.Global.ScrapeInterval)
+	assert.Equal(t, map[string]string{
+		"k8s_cluster":                 "prod",
+		"namespace":                   cluster.Namespace,
+		"ray_cluster":                 cluster.Name,
+		"ray_node_type":               string(rayv1.WorkerNode),
+		"ray_group":                   worker.GroupName,
+		"pod":                         "${POD_NAME}",
+		"ray_originated_from_cr_name": "rayjob-sample",
+		"ray_originated_from_crd":     utils.RayOriginatedFromCRDLabelValue(utils.RayJobCRD),
+	}, config.Global.ExternalLabels)
+	require.Len(t, config.ScrapeConfigs, 1)
+	assert.Equal(t, []string{"localhost:8080"}, config.ScrapeConfigs[0].StaticConfigs[0].Targets)
+	require.Len(t, config.RemoteWrite, 1)
+	assert.Equal(t, "http://prometheus.monitoring:9090/api/v1/write", config.RemoteWrite[0].URL)
+	assert.Equal(t, "/etc/ray-metrics-exporter/token", config.RemoteWrite[0].Authorization.CredentialsFile)
+}
+
+func findContainer(containers []corev1.Container, name string) int {
+	for i, container := range containers {
+		if container.Name == name {
+			return i
+		}
+	}
+	return -1
+}
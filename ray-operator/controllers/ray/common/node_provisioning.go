@@ -0,0 +1,55 @@
+package common
+
+import (
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+// ApplyNodeProvisioningConfigs adds the tolerations, node selector and runtime class of the accelerator rules
+// matching the resources requested by the containers of the Pod. The settings of the Pod take precedence over the
+// rules, and the configs are applied in the order of their names so that conflicting rules are resolved consistently.
+func ApplyNodeProvisioningConfigs(pod *corev1.Pod, configs []rayv1.RayNodeProvisioningConfig) {
+	configs = slices.Clone(configs)
+	slices.SortFunc(configs, func(a, b rayv1.RayNodeProvisioningConfig) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	for _, config := range configs {
+		for _, rule := range config.Spec.Accelerators {
+			if !isResourceRequestedByPod(pod, rule.ResourceName) {
+				continue
+			}
+			for _, toleration := range rule.Tolerations {
+				if !slices.ContainsFunc(pod.Spec.Tolerations, func(t corev1.Toleration) bool { return t.MatchToleration(&toleration) }) {
+					pod.Spec.Tolerations = append(pod.Spec.Tolerations, toleration)
+				}
+			}
+			for key, value := range rule.NodeSelector {
+				if pod.Spec.NodeSelector == nil {
+					pod.Spec.NodeSelector = map[string]string{}
+				}
+				if _, ok := pod.Spec.NodeSelector[key]; !ok {
+					pod.Spec.NodeSelector[key] = value
+				}
+			}
+			if rule.RuntimeClassName != nil && pod.Spec.RuntimeClassName == nil {
+				pod.Spec.RuntimeClassName = rule.RuntimeClassName
+			}
+		}
+	}
+}
+
+// isResourceRequestedByPod returns whether a container of the Pod requests or limits a non-zero quantity of the resource.
+func isResourceRequestedByPod(pod *corev1.Pod, resourceName corev1.ResourceName) bool {
+	for _, container := range pod.Spec.Containers {
+		for _, resources := range []corev1.ResourceList{container.Resources.Requests, container.Resources.Limits} {
+			if quantity, ok := resources[resourceName]; ok && !quantity.IsZero() {
+				return true
+			}
+		}
+	}
+	return false
+}
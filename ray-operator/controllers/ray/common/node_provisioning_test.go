@@ -0,0 +1,78 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestApplyNodeProvisioningConfigs(t *testing.T) {
+	gpuToleration := corev1.Toleration{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule}
+	configs := []rayv1.RayNodeProvisioningConfig{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "b"},
+			Spec: rayv1.RayNodeProvisioningConfigSpec{Accelerators: []rayv1.AcceleratorProvisioningRule{{
+				ResourceName: "nvidia.com/gpu",
+				NodeSelector: map[string]string{"accelerator": "h100"},
+			}}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "a"},
+			Spec: rayv1.RayNodeProvisioningConfigSpec{Accelerators: []rayv1.AcceleratorProvisioningRule{
+				{
+					ResourceName:     "nvidia.com/gpu",
+					Tolerations:      []corev1.Toleration{gpuToleration},
+					NodeSelector:     map[string]string{"accelerator": "a100", "pool": "gpu"},
+					RuntimeClassName: ptr.To("nvidia"),
+				},
+				{
+					ResourceName: "google.com/tpu",
+					NodeSelector: map[string]string{"pool": "tpu"},
+				},
+			}},
+		},
+	}
+	newPod := func(resources corev1.ResourceList) *corev1.Pod {
+		return &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+			Name:      "ray-head",
+			Resources: corev1.ResourceRequirements{Limits: resources},
+		}}}}
+	}
+
+	// The rules of the accelerators requested by the Pod are applied in the order of the names of the configs.
+	pod := newPod(corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")})
+	ApplyNodeProvisioningConfigs(pod, configs)
+	assert.Equal(t, []corev1.Toleration{gpuToleration}, pod.Spec.Tolerations)
+	assert.Equal(t, map[string]string{"accelerator": "a100", "pool": "gpu"}, pod.Spec.NodeSelector)
+	assert.Equal(t, ptr.To("nvidia"), pod.Spec.RuntimeClassName)
+
+	// Applying the configs again doesn't duplicate the tolerations.
+	ApplyNodeProvisioningConfigs(pod, configs)
+	assert.Len(t, pod.Spec.Tolerations, 1)
+
+	// The settings of the Pod take precedence over the rules.
+	pod = newPod(corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("1")})
+	pod.Spec.NodeSelector = map[string]string{"accelerator": "l4"}
+	pod.Spec.RuntimeClassName = ptr.To("custom")
+	ApplyNodeProvisioningConfigs(pod, configs)
+	assert.Equal(t, map[string]string{"accelerator": "l4", "pool": "gpu"}, pod.Spec.NodeSelector)
+	assert.Equal(t, ptr.To("custom"), pod.Spec.RuntimeClassName)
+
+	// Pods without accelerators, or with a zero quantity, are unchanged.
+	for _, resources := range []corev1.ResourceList{
+		{corev1.ResourceCPU: resource.MustParse("1")},
+		{"nvidia.com/gpu": resource.MustParse("0")},
+	} {
+		pod = newPod(resources)
+		ApplyNodeProvisioningConfigs(pod, configs)
+		assert.Empty(t, pod.Spec.Tolerations)
+		assert.Nil(t, pod.Spec.NodeSelector)
+		assert.Nil(t, pod.Spec.RuntimeClassName)
+	}
+}
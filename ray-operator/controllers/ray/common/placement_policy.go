@@ -0,0 +1,123 @@
+package common
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+// ApplyPlacementPolicies adds the node selector, tolerations and affinity of the RayPlacementPolicies selecting the
+// Pod of the RayCluster and its namespace. The policies are applied in the order of their names, so a node selector
+// key set by several policies gets the value of the last one.
+func ApplyPlacementPolicies(pod *corev1.Pod, cluster *rayv1.RayCluster, namespace *corev1.Namespace, policies []rayv1.RayPlacementPolicy) error {
+	podLabels := labels.Merge(cluster.Labels, pod.Labels)
+	policies = slices.Clone(policies)
+	slices.SortFunc(policies, func(a, b rayv1.RayPlacementPolicy) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	for _, policy := range policies {
+		matches, err := placementPolicyMatches(policy, podLabels, namespace)
+		if err != nil {
+			return fmt.Errorf("invalid RayPlacementPolicy %s: %w", policy.Name, err)
+		}
+		if !matches {
+			continue
+		}
+		for key, value := range policy.Spec.NodeSelector {
+			if pod.Spec.NodeSelector == nil {
+				pod.Spec.NodeSelector = map[string]string{}
+			}
+			pod.Spec.NodeSelector[key] = value
+		}
+		for _, toleration := range policy.Spec.Tolerations {
+			if !slices.ContainsFunc(pod.Spec.Tolerations, func(t corev1.Toleration) bool { return t.MatchToleration(&toleration) }) {
+				pod.Spec.Tolerations = append(pod.Spec.Tolerations, toleration)
+			}
+		}
+		if policy.Spec.Affinity != nil {
+			pod.Spec.Affinity = mergeAffinity(pod.Spec.Affinity, policy.Spec.Affinity.DeepCopy())
+		}
+	}
+	return nil
+}
+
+// placementPolicyMatches returns whether the selectors of the policy select the labels of the Pod and its namespace.
+// A selector that isn't set selects everything.
+func placementPolicyMatches(policy rayv1.RayPlacementPolicy, podLabels map[string]string, namespace *corev1.Namespace) (bool, error) {
+	for _, s := range []struct {
+		selector *metav1.LabelSelector
+		labels   map[string]string
+	}{
+		{policy.Spec.NamespaceSelector, namespace.Labels},
+		{policy.Spec.Selector, podLabels},
+	} {
+		if s.selector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(s.selector)
+		if err != nil {
+			return false, err
+		}
+		if !selector.Matches(labels.Set(s.labels)) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// mergeAffinity appends the terms of the policy to the affinity of the Pod. The required node selector terms are
+// ORed, so each term of the Pod is combined with each term of the policy to only select the nodes matching both.
+func mergeAffinity(affinity *corev1.Affinity, policy *corev1.Affinity) *corev1.Affinity {
+	if affinity == nil {
+		return policy
+	}
+	if policyNodeAffinity := policy.NodeAffinity; policyNodeAffinity != nil {
+		if affinity.NodeAffinity == nil {
+			affinity.NodeAffinity = &corev1.NodeAffinity{}
+		}
+		nodeAffinity := affinity.NodeAffinity
+		if required := policyNodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution; required != nil {
+			if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil || len(nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms) == 0 {
+				nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = required
+			} else {
+				var terms []corev1.NodeSelectorTerm
+				for _, podTerm := range nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+					for _, policyTerm := range required.NodeSelectorTerms {
+						terms = append(terms, corev1.NodeSelectorTerm{
+							MatchExpressions: append(slices.Clone(podTerm.MatchExpressions), policyTerm.MatchExpressions...),
+							MatchFields:      append(slices.Clone(podTerm.MatchFields), policyTerm.MatchFields...),
+						})
+					}
+				}
+				nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms = terms
+			}
+		}
+		nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+			policyNodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution...)
+	}
+	if policyPodAffinity := policy.PodAffinity; policyPodAffinity != nil {
+		if affinity.PodAffinity == nil {
+			affinity.PodAffinity = &corev1.PodAffinity{}
+		}
+		affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution,
+			policyPodAffinity.RequiredDuringSchedulingIgnoredDuringExecution...)
+		affinity.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(affinity.PodAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+			policyPodAffinity.PreferredDuringSchedulingIgnoredDuringExecution...)
+	}
+	if policyPodAntiAffinity := policy.PodAntiAffinity; policyPodAntiAffinity != nil {
+		if affinity.PodAntiAffinity == nil {
+			affinity.PodAntiAffinity = &corev1.PodAntiAffinity{}
+		}
+		affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution,
+			policyPodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution...)
+		affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(affinity.PodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+			policyPodAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution...)
+	}
+	return affinity
+}
@@ -0,0 +1,83 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestApplyPlacementPolicies(t *testing.T) {
+	prodToleration := corev1.Toleration{Key: "pool", Operator: corev1.TolerationOpEqual, Value: "prod", Effect: corev1.TaintEffectNoSchedule}
+	zoneTerm := corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{
+		{Key: "topology.kubernetes.io/zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"us-east1-b"}},
+	}}
+	policies := []rayv1.RayPlacementPolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "b-prod-services"},
+			Spec: rayv1.RayPlacementPolicySpec{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+				Selector:          &metav1.LabelSelector{MatchLabels: map[string]string{utils.RayOriginatedFromCRDLabelKey: "RayService"}},
+				NodeSelector:      map[string]string{"pool": "prod-serving"},
+				Tolerations:       []corev1.Toleration{prodToleration},
+				Affinity: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{zoneTerm}},
+				}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "a-prod"},
+			Spec: rayv1.RayPlacementPolicySpec{
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+				NodeSelector:      map[string]string{"pool": "prod", "arch": "amd64"},
+			},
+		},
+	}
+	prod := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod", Labels: map[string]string{"env": "prod"}}}
+	dev := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev", Labels: map[string]string{"env": "dev"}}}
+	service := &rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{utils.RayOriginatedFromCRDLabelKey: "RayService"}}}
+	gpuTerm := corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{
+		{Key: "gpu", Operator: corev1.NodeSelectorOpExists},
+	}}
+	newPod := func() *corev1.Pod {
+		return &corev1.Pod{Spec: corev1.PodSpec{
+			NodeSelector: map[string]string{"pool": "custom", "disk": "ssd"},
+			Affinity: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{gpuTerm}},
+			}},
+		}}
+	}
+
+	// The policies selecting the RayCluster are applied in the order of their names, and override the Pod.
+	pod := newPod()
+	require.NoError(t, ApplyPlacementPolicies(pod, service, prod, policies))
+	assert.Equal(t, map[string]string{"pool": "prod-serving", "arch": "amd64", "disk": "ssd"}, pod.Spec.NodeSelector)
+	assert.Equal(t, []corev1.Toleration{prodToleration}, pod.Spec.Tolerations)
+	assert.Equal(t, []corev1.NodeSelectorTerm{{MatchExpressions: append(gpuTerm.MatchExpressions, zoneTerm.MatchExpressions...)}},
+		pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms)
+
+	// The policies whose selector doesn't match the labels of the Pod or of its RayCluster are skipped.
+	pod = newPod()
+	require.NoError(t, ApplyPlacementPolicies(pod, &rayv1.RayCluster{}, prod, policies))
+	assert.Equal(t, map[string]string{"pool": "prod", "arch": "amd64", "disk": "ssd"}, pod.Spec.NodeSelector)
+	assert.Empty(t, pod.Spec.Tolerations)
+
+	// The Pods of the other namespaces are unchanged.
+	pod = newPod()
+	require.NoError(t, ApplyPlacementPolicies(pod, service, dev, policies))
+	assert.Equal(t, newPod(), pod)
+
+	// An invalid selector is reported.
+	invalid := rayv1.RayPlacementPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "invalid"},
+		Spec: rayv1.RayPlacementPolicySpec{Selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "env", Operator: "Unknown"},
+		}}},
+	}
+	assert.ErrorContains(t, ApplyPlacementPolicies(newPod(), service, prod, []rayv1.RayPlacementPolicy{invalid}), "invalid RayPlacementPolicy invalid")
+}
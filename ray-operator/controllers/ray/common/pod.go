@@ -5,7 +5,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"net"
 	"os"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -18,6 +21,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
@@ -38,6 +42,8 @@ const (
 	NeuronCoreRayResourceName          = "neuron_cores"
 	TPUContainerResourceName           = "google.com/tpu"
 	TPURayResourceName                 = "TPU"
+	// Increase the open file descriptor limit of the `ray start` process and its child processes to 65536.
+	ulimitCmd = "ulimit -n 65536"
 )
 
 var customAcceleratorToRayResourceMap = map[string]string{
@@ -171,6 +177,7 @@ func DefaultHeadPodTemplate(ctx context.Context, instance rayv1.RayCluster, head
 	headSpec.RayStartParams = setMissingRayStartParams(ctx, headSpec.RayStartParams, rayv1.HeadNode, headPort, "")
 
 	initTemplateAnnotations(instance, &podTemplate)
+	configureServiceMesh(&podTemplate, instance.Spec.ServiceMeshOptions, headSpec.RayStartParams, headPort)
 
 	// if in-tree autoscaling is enabled, then autoscaler container should be injected into head pod.
 	if utils.IsAutoscalingEnabled(&instance) {
@@ -189,6 +196,12 @@ func DefaultHeadPodTemplate(ctx context.Context, instance rayv1.RayCluster, head
 		podTemplate.Spec.Containers = append(podTemplate.Spec.Containers, autoscalerContainer)
 	}
 
+	setDedicatedServiceAccount(&podTemplate, &instance)
+
+	if IsOAuth2ProxyEnabled(instance) {
+		podTemplate.Spec.Containers = append(podTemplate.Spec.Containers, BuildOAuth2ProxyContainer(instance))
+	}
+
 	configureGCSFaultTolerance(&podTemplate, instance, rayv1.HeadNode)
 
 	// If the metrics port does not exist in the Ray container, add a default one for Prometheus.
@@ -201,6 +214,8 @@ func DefaultHeadPodTemplate(ctx context.Context, instance rayv1.RayCluster, head
 		podTemplate.Spec.Containers[utils.RayContainerIndex].Ports = append(podTemplate.Spec.Containers[utils.RayContainerIndex].Ports, metricsPort)
 	}
 
+	configureMetricsExporter(&podTemplate, instance, rayv1.HeadNode, utils.RayNodeHeadGroupLabelValue)
+
 	return podTemplate
 }
 
@@ -224,7 +239,12 @@ func DefaultWorkerPodTemplate(ctx context.Context, instance rayv1.RayCluster, wo
 	podTemplate.GenerateName = podName
 	// Pods created by RayCluster should be restricted to the namespace of the RayCluster.
 	// This ensures privilege of KubeRay users are contained within the namespace of the RayCluster.
-	podTemplate.ObjectMeta.Namespace = instance.Namespace
+	// Worker groups with `namespace` are the exception, which requires the RayMultiNamespaceWorkerGroups feature gate.
+	podTemplate.ObjectMeta.Namespace = GetWorkerGroupNamespace(&instance, workerSpec)
+	// The dedicated ServiceAccount only exists in the namespace of the RayCluster.
+	if podTemplate.ObjectMeta.Namespace == instance.Namespace {
+		setDedicatedServiceAccount(&podTemplate, &instance)
+	}
 
 	// The Ray worker should only start once the GCS server is ready.
 	// only inject init container only when ENABLE_INIT_CONTAINER_INJECTION is true
@@ -234,7 +254,7 @@ func DefaultWorkerPodTemplate(ctx context.Context, instance rayv1.RayCluster, wo
 		// Do not modify `deepCopyRayContainer` anywhere.
 		deepCopyRayContainer := podTemplate.Spec.Containers[utils.RayContainerIndex].DeepCopy()
 		initContainer := corev1.Container{
-			Name:            "wait-gcs-ready",
+			Name:            waitGCSReadyInitContainerName,
 			Image:           podTemplate.Spec.Containers[utils.RayContainerIndex].Image,
 			ImagePullPolicy: podTemplate.Spec.Containers[utils.RayContainerIndex].ImagePullPolicy,
 			Command:         []string{"/bin/bash", "-lc", "--"},
@@ -290,9 +310,13 @@ func DefaultWorkerPodTemplate(ctx context.Context, instance rayv1.RayCluster, wo
 		podTemplate.Labels = make(map[string]string)
 	}
 	podTemplate.Labels = labelPod(rayv1.WorkerNode, instance.Name, workerSpec.GroupName, workerSpec.Template.ObjectMeta.Labels)
+	if IsRemoteWorkerGroup(&instance, workerSpec) {
+		podTemplate.Labels[utils.RayClusterNamespaceLabelKey] = instance.Namespace
+	}
 	workerSpec.RayStartParams = setMissingRayStartParams(ctx, workerSpec.RayStartParams, rayv1.WorkerNode, headPort, fqdnRayIP)
 
 	initTemplateAnnotations(instance, &podTemplate)
+	configureServiceMesh(&podTemplate, instance.Spec.ServiceMeshOptions, workerSpec.RayStartParams, headPort)
 	configureGCSFaultTolerance(&podTemplate, instance, rayv1.WorkerNode)
 
 	// If the metrics port does not exist in the Ray container, add a default one for Prometheus.
@@ -305,20 +329,24 @@ func DefaultWorkerPodTemplate(ctx context.Context, instance rayv1.RayCluster, wo
 		podTemplate.Spec.Containers[utils.RayContainerIndex].Ports = append(podTemplate.Spec.Containers[utils.RayContainerIndex].Ports, metricsPort)
 	}
 
+	configureMetricsExporter(&podTemplate, instance, rayv1.WorkerNode, workerSpec.GroupName)
+
 	return podTemplate
 }
 
-func initLivenessAndReadinessProbe(rayContainer *corev1.Container, rayNodeType rayv1.RayNodeType, creatorCRDType utils.CRDType) {
+// initLivenessAndReadinessProbe injects the probes of the Ray container. They check the ports of the dashboard agent
+// and the dashboard in the rayStartParams, or the default ones.
+func initLivenessAndReadinessProbe(rayContainer *corev1.Container, rayNodeType rayv1.RayNodeType, creatorCRDType utils.CRDType, rayStartParams map[string]string) {
 	rayAgentRayletHealthCommand := fmt.Sprintf(
 		utils.BaseWgetHealthCommand,
 		utils.DefaultReadinessProbeTimeoutSeconds,
-		utils.DefaultDashboardAgentListenPort,
+		getRayStartParamPort(rayStartParams, "dashboard-agent-listen-port", utils.DefaultDashboardAgentListenPort),
 		utils.RayAgentRayletHealthPath,
 	)
 	rayDashboardGCSHealthCommand := fmt.Sprintf(
 		utils.BaseWgetHealthCommand,
 		utils.DefaultReadinessProbeFailureThreshold,
-		utils.DefaultDashboardPort,
+		getRayStartParamPort(rayStartParams, "dashboard-port", utils.DefaultDashboardPort),
 		utils.RayDashboardGCSHealthPath,
 	)
 
@@ -379,6 +407,58 @@ func initLivenessAndReadinessProbe(rayContainer *corev1.Container, rayNodeType r
 	}
 }
 
+// ApplyHeadHealthProbes injects the startup probe of the Ray container of the head Pod built by BuildPod, and applies
+// the thresholds of the HealthProbes of the head group to the startup and readiness probes generated by KubeRay. The
+// startup probe checks that the GCS server accepts connections and that the dashboard reports it healthy, so the head
+// Pod isn't ready before the worker Pods can join the RayCluster. The probes set in the template are kept as they are.
+func ApplyHeadHealthProbes(pod *corev1.Pod, headSpec rayv1.HeadGroupSpec, rayStartParams map[string]string) {
+	if !getEnableProbesInjection() {
+		return
+	}
+	rayContainer := &pod.Spec.Containers[utils.RayContainerIndex]
+	templateContainer := headSpec.Template.Spec.Containers[utils.RayContainerIndex]
+	healthProbes := rayv1.HeadHealthProbes{}
+	if headSpec.HealthProbes != nil {
+		healthProbes = *headSpec.HealthProbes
+	}
+
+	if templateContainer.StartupProbe == nil {
+		startupProbe := &corev1.Probe{
+			InitialDelaySeconds: utils.DefaultHeadStartupProbeInitialDelaySeconds,
+			TimeoutSeconds:      utils.DefaultHeadStartupProbeTimeoutSeconds,
+			PeriodSeconds:       utils.DefaultHeadStartupProbePeriodSeconds,
+			SuccessThreshold:    utils.DefaultHeadStartupProbeSuccessThreshold,
+			FailureThreshold:    utils.DefaultHeadStartupProbeFailureThreshold,
+		}
+		applyHealthProbeThresholds(startupProbe, healthProbes.StartupProbe)
+		commands := []string{
+			fmt.Sprintf(utils.BaseRayHealthCheckCommand, getRayStartParamPort(rayStartParams, "port", utils.DefaultGcsServerPort)),
+			fmt.Sprintf(
+				utils.BaseWgetHealthCommand,
+				startupProbe.TimeoutSeconds,
+				getRayStartParamPort(rayStartParams, "dashboard-port", utils.DefaultDashboardPort),
+				utils.RayDashboardGCSHealthPath,
+			),
+		}
+		startupProbe.Exec = &corev1.ExecAction{Command: []string{"bash", "-c", strings.Join(commands, " && ")}}
+		rayContainer.StartupProbe = startupProbe
+	}
+
+	if templateContainer.ReadinessProbe == nil && rayContainer.ReadinessProbe != nil {
+		applyHealthProbeThresholds(rayContainer.ReadinessProbe, healthProbes.ReadinessProbe)
+	}
+}
+
+func applyHealthProbeThresholds(probe *corev1.Probe, thresholds *rayv1.HealthProbeThresholds) {
+	if thresholds == nil {
+		return
+	}
+	probe.InitialDelaySeconds = ptr.Deref(thresholds.InitialDelaySeconds, probe.InitialDelaySeconds)
+	probe.TimeoutSeconds = ptr.Deref(thresholds.TimeoutSeconds, probe.TimeoutSeconds)
+	probe.PeriodSeconds = ptr.Deref(thresholds.PeriodSeconds, probe.PeriodSeconds)
+	probe.FailureThreshold = ptr.Deref(thresholds.FailureThreshold, probe.FailureThreshold)
+}
+
 // BuildPod a pod config
 func BuildPod(ctx context.Context, podTemplateSpec corev1.PodTemplateSpec, rayNodeType rayv1.RayNodeType, rayStartParams map[string]string, headPort string, enableRayAutoscaler bool, creatorCRDType utils.CRDType, fqdnRayIP string) (aPod corev1.Pod) {
 	log := ctrl.LoggerFrom(ctx)
@@ -423,8 +503,6 @@ func BuildPod(ctx context.Context, podTemplateSpec corev1.PodTemplateSpec, rayNo
 		cmd += convertCmdToString(pod.Spec.Containers[utils.RayContainerIndex].Args)
 	}
 
-	// Increase the open file descriptor limit of the `ray start` process and its child processes to 65536.
-	ulimitCmd := "ulimit -n 65536"
 	// Generate the `ray start` command.
 	rayStartCmd := generateRayStartCommand(ctx, rayNodeType, rayStartParams, pod.Spec.Containers[utils.RayContainerIndex].Resources)
 
@@ -464,12 +542,20 @@ func BuildPod(ctx context.Context, podTemplateSpec corev1.PodTemplateSpec, rayNo
 		// Configure the readiness and liveness probes for the Ray container. These probes
 		// play a crucial role in KubeRay health checks. Without them, certain failures,
 		// such as the Raylet process crashing, may go undetected.
-		initLivenessAndReadinessProbe(&pod.Spec.Containers[utils.RayContainerIndex], rayNodeType, creatorCRDType)
+		initLivenessAndReadinessProbe(&pod.Spec.Containers[utils.RayContainerIndex], rayNodeType, creatorCRDType, rayStartParams)
 	}
 
 	return pod
 }
 
+// getRayStartParamPort returns the port of the rayStartParam, or the default port if it isn't set or isn't a number.
+func getRayStartParamPort(rayStartParams map[string]string, param string, defaultPort int) int {
+	if port, err := strconv.Atoi(rayStartParams[param]); err == nil {
+		return port
+	}
+	return defaultPort
+}
+
 // BuildAutoscalerContainer builds a Ray autoscaler container which can be appended to the head pod.
 func BuildAutoscalerContainer(autoscalerImage string) corev1.Container {
 	container := corev1.Container{
@@ -528,6 +614,115 @@ func BuildAutoscalerContainer(autoscalerImage string) corev1.Container {
 	return container
 }
 
+// AddImagePullSecrets adds the image pull secrets the Pod spec doesn't have yet.
+func AddImagePullSecrets(podSpec *corev1.PodSpec, secrets []corev1.LocalObjectReference) {
+	for _, secret := range secrets {
+		if !slices.Contains(podSpec.ImagePullSecrets, secret) {
+			podSpec.ImagePullSecrets = append(podSpec.ImagePullSecrets, secret)
+		}
+	}
+}
+
+// AddImagePullSecretsToRayClusterSpec adds the image pull secrets to the head and worker Pod templates of the spec.
+func AddImagePullSecretsToRayClusterSpec(spec *rayv1.RayClusterSpec, secrets []corev1.LocalObjectReference) {
+	AddImagePullSecrets(&spec.HeadGroupSpec.Template.Spec, secrets)
+	for i := range spec.WorkerGroupSpecs {
+		AddImagePullSecrets(&spec.WorkerGroupSpecs[i].Template.Spec, secrets)
+	}
+}
+
+// SetSchedulingToRayClusterSpec sets the priority class and the scheduler that aren't empty to the head and worker Pod
+// templates of the spec, overriding the ones of the templates.
+func SetSchedulingToRayClusterSpec(spec *rayv1.RayClusterSpec, priorityClassName, schedulerName string) {
+	podSpecs := []*corev1.PodSpec{&spec.HeadGroupSpec.Template.Spec}
+	for i := range spec.WorkerGroupSpecs {
+		podSpecs = append(podSpecs, &spec.WorkerGroupSpecs[i].Template.Spec)
+	}
+	for _, podSpec := range podSpecs {
+		if priorityClassName != "" {
+			podSpec.PriorityClassName = priorityClassName
+		}
+		if schedulerName != "" {
+			podSpec.SchedulerName = schedulerName
+		}
+	}
+}
+
+// IsGracefulDrainEnabled returns whether the worker Pods of the group are drained by Ray before they are deleted.
+func IsGracefulDrainEnabled(worker rayv1.WorkerGroupSpec) bool {
+	return worker.GracefulDrainSeconds != nil && *worker.GracefulDrainSeconds > 0
+}
+
+// GetWorkerGroupNamespace returns the namespace of the Pods of the worker group, which defaults to the namespace of the
+// RayCluster.
+func GetWorkerGroupNamespace(instance *rayv1.RayCluster, worker rayv1.WorkerGroupSpec) string {
+	if worker.Namespace != "" {
+		return worker.Namespace
+	}
+	return instance.Namespace
+}
+
+// IsRemoteWorkerGroup returns whether the Pods of the worker group are created in another namespace than the RayCluster.
+func IsRemoteWorkerGroup(instance *rayv1.RayCluster, worker rayv1.WorkerGroupSpec) bool {
+	return GetWorkerGroupNamespace(instance, worker) != instance.Namespace
+}
+
+// HasRemoteWorkerGroups returns whether any worker group of the RayCluster creates its Pods in another namespace.
+func HasRemoteWorkerGroups(instance *rayv1.RayCluster) bool {
+	for _, worker := range instance.Spec.WorkerGroupSpecs {
+		if IsRemoteWorkerGroup(instance, worker) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetMaxParallelDeletions returns the maximum number of Pods of WorkersToDelete of the group that are deleted at the
+// same time, or 0 if there is no limit.
+func GetMaxParallelDeletions(worker rayv1.WorkerGroupSpec) int {
+	if worker.ScaleStrategy.MaxParallelDeletions == nil {
+		return 0
+	}
+	return int(*worker.ScaleStrategy.MaxParallelDeletions)
+}
+
+// IsPodDisruptionBudgetRespected returns whether the Pods of WorkersToDelete of the group are evicted rather than
+// deleted, so that PodDisruptionBudgets are respected.
+func IsPodDisruptionBudgetRespected(worker rayv1.WorkerGroupSpec) bool {
+	return worker.ScaleStrategy.RespectPodDisruptionBudgets != nil && *worker.ScaleStrategy.RespectPodDisruptionBudgets
+}
+
+// IsOAuth2ProxyEnabled returns whether an oauth2-proxy sidecar is injected into the head Pod to authenticate the
+// access to the dashboard.
+func IsOAuth2ProxyEnabled(cluster rayv1.RayCluster) bool {
+	return utils.IsDashboardBehindOAuth2Proxy(&cluster)
+}
+
+// BuildOAuth2ProxyContainer builds the oauth2-proxy sidecar that proxies the authenticated requests to the dashboard.
+func BuildOAuth2ProxyContainer(cluster rayv1.RayCluster) corev1.Container {
+	options := cluster.Spec.DashboardIngress.OAuth2Proxy
+	args := []string{
+		fmt.Sprintf("--http-address=0.0.0.0:%d", utils.DefaultOAuth2ProxyPort),
+		fmt.Sprintf("--upstream=http://127.0.0.1:%d", getDashboardPort(cluster)),
+	}
+	container := corev1.Container{
+		Name:  utils.OAuth2ProxyContainerName,
+		Image: options.Image,
+		Args:  append(args, options.Args...),
+		Env:   options.Env,
+		Ports: []corev1.ContainerPort{
+			{
+				Name:          utils.OAuth2ProxyPortName,
+				ContainerPort: utils.DefaultOAuth2ProxyPort,
+			},
+		},
+	}
+	if options.Resources != nil {
+		container.Resources = *options.Resources
+	}
+	return container
+}
+
 // Merge the user overrides from autoscalerOptions into the autoscaler container config.
 func mergeAutoscalerOverrides(autoscalerContainer *corev1.Container, autoscalerOptions *rayv1.AutoscalerOptions) {
 	if autoscalerOptions != nil {
@@ -697,7 +892,7 @@ func setContainerEnvVars(pod *corev1.Pod, rayNodeType rayv1.RayNodeType, fqdnRay
 	// Setting the RAY_ADDRESS env allows connecting to Ray using ray.init() when connecting
 	// from within the cluster.
 	if !utils.EnvVarExists(utils.RAY_ADDRESS, container.Env) {
-		rayAddress := fmt.Sprintf("%s:%s", ip, headPort)
+		rayAddress := net.JoinHostPort(ip, headPort)
 		addressEnv := corev1.EnvVar{Name: utils.RAY_ADDRESS, Value: rayAddress}
 		container.Env = append(container.Env, addressEnv)
 	}
@@ -724,7 +919,7 @@ func setMissingRayStartParams(ctx context.Context, rayStartParams map[string]str
 	// Note: The argument headPort is unused for nodeType == rayv1.HeadNode.
 	if nodeType == rayv1.WorkerNode {
 		if _, ok := rayStartParams["address"]; !ok {
-			address := fmt.Sprintf("%s:%s", fqdnRayIP, headPort)
+			address := net.JoinHostPort(fqdnRayIP, headPort)
 			rayStartParams["address"] = address
 		}
 	}
@@ -806,6 +1001,33 @@ func generateRayStartCommand(ctx context.Context, nodeType rayv1.RayNodeType, ra
 	return rayStartCmd
 }
 
+// SizeObjectStoreMemory returns the rayStartParams with `object-store-memory` set to objectStoreMemoryPercent of the
+// memory limit of the Ray container and `memory` set to the rest of the limit, unless they are already set. The
+// rayStartParams are returned unchanged if objectStoreMemoryPercent is nil or the container has no memory limit.
+func SizeObjectStoreMemory(rayStartParams map[string]string, objectStoreMemoryPercent *int32, resource corev1.ResourceRequirements) map[string]string {
+	memoryLimit := resource.Limits[corev1.ResourceMemory]
+	if objectStoreMemoryPercent == nil || memoryLimit.IsZero() {
+		return rayStartParams
+	}
+	rayStartParams = maps.Clone(rayStartParams)
+	if rayStartParams == nil {
+		rayStartParams = map[string]string{}
+	}
+	objectStoreMemory := memoryLimit.Value() * int64(*objectStoreMemoryPercent) / 100
+	if value, ok := rayStartParams[ObjectStoreMemoryKey]; ok {
+		// The object store memory set by the user is subtracted from the heap memory if it can be parsed.
+		if parsed, err := strconv.ParseInt(value, 10, 64); err == nil {
+			objectStoreMemory = parsed
+		}
+	} else {
+		rayStartParams[ObjectStoreMemoryKey] = strconv.FormatInt(objectStoreMemory, 10)
+	}
+	if _, ok := rayStartParams["memory"]; !ok && objectStoreMemory < memoryLimit.Value() {
+		rayStartParams["memory"] = strconv.FormatInt(memoryLimit.Value()-objectStoreMemory, 10)
+	}
+	return rayStartParams
+}
+
 func addWellKnownAcceleratorResources(rayStartParams map[string]string, resourceLimits corev1.ResourceList) error {
 	if len(resourceLimits) == 0 {
 		return nil
@@ -1001,3 +1223,11 @@ func findMemoryReqOrLimit(container corev1.Container) (res *resource.Quantity) {
 	}
 	return nil
 }
+
+// setDedicatedServiceAccount runs the Pod with the ServiceAccount dedicated to the RayCluster if it's enabled and the
+// template doesn't set a serviceAccountName.
+func setDedicatedServiceAccount(podTemplate *corev1.PodTemplateSpec, cluster *rayv1.RayCluster) {
+	if utils.IsDedicatedServiceAccountEnabled(cluster) && podTemplate.Spec.ServiceAccountName == "" {
+		podTemplate.Spec.ServiceAccountName = utils.GetDedicatedServiceAccountName(cluster)
+	}
+}
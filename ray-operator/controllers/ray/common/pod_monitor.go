@@ -0,0 +1,76 @@
+package common
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// PodMonitorGroupVersionKind is the GroupVersionKind of the PodMonitor of the Prometheus Operator. KubeRay uses
+// unstructured PodMonitors so that the Prometheus Operator CRDs are only needed when `prometheusMonitoring` is set.
+var PodMonitorGroupVersionKind = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "PodMonitor"}
+
+// BuildPodMonitor builds the PodMonitor that scrapes the metrics port of the head or worker Pods of the RayCluster,
+// including the worker Pods in other namespaces.
+func BuildPodMonitor(cluster rayv1.RayCluster, nodeType rayv1.RayNodeType) *unstructured.Unstructured {
+	options := cluster.Spec.PrometheusMonitoring
+	namespaces := []interface{}{cluster.Namespace}
+	if nodeType == rayv1.WorkerNode {
+		seen := map[string]bool{cluster.Namespace: true}
+		for _, worker := range cluster.Spec.WorkerGroupSpecs {
+			if namespace := GetWorkerGroupNamespace(&cluster, worker); !seen[namespace] {
+				seen[namespace] = true
+				namespaces = append(namespaces, namespace)
+			}
+		}
+	}
+
+	endpoint := map[string]interface{}{
+		"port": utils.MetricsPortName,
+		"relabelings": []interface{}{
+			map[string]interface{}{
+				"action":       "replace",
+				"sourceLabels": []interface{}{"__meta_kubernetes_pod_label_ray_io_cluster"},
+				"targetLabel":  "ray_io_cluster",
+			},
+			map[string]interface{}{
+				"action":       "replace",
+				"sourceLabels": []interface{}{"__meta_kubernetes_pod_label_ray_io_group"},
+				"targetLabel":  "ray_io_group",
+			},
+		},
+	}
+	if options.Interval != nil {
+		endpoint["interval"] = *options.Interval
+	}
+
+	monitor := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"namespaceSelector": map[string]interface{}{
+					"matchNames": namespaces,
+				},
+				"selector": map[string]interface{}{
+					"matchLabels": map[string]interface{}{
+						utils.RayClusterLabelKey:  cluster.Name,
+						utils.RayNodeTypeLabelKey: string(nodeType),
+					},
+				},
+				"podMetricsEndpoints": []interface{}{endpoint},
+			},
+		},
+	}
+	monitor.SetGroupVersionKind(PodMonitorGroupVersionKind)
+	monitor.SetName(utils.GeneratePodMonitorName(cluster.Name, nodeType))
+	monitor.SetNamespace(cluster.Namespace)
+	labels := map[string]string{}
+	for k, v := range options.Labels {
+		labels[k] = v
+	}
+	labels[utils.RayClusterLabelKey] = cluster.Name
+	labels[utils.RayNodeTypeLabelKey] = string(nodeType)
+	monitor.SetLabels(labels)
+	return monitor
+}
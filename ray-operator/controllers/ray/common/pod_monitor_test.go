@@ -0,0 +1,39 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestBuildPodMonitor(t *testing.T) {
+	cluster := instance.DeepCopy()
+	cluster.Spec.PrometheusMonitoring = &rayv1.PrometheusMonitoringOptions{Labels: map[string]string{"release": "prometheus"}}
+	remoteGroup := cluster.Spec.WorkerGroupSpecs[0].DeepCopy()
+	remoteGroup.GroupName = "remote-group"
+	remoteGroup.Namespace = "tenant"
+	cluster.Spec.WorkerGroupSpecs = append(cluster.Spec.WorkerGroupSpecs, *remoteGroup)
+
+	monitor := BuildPodMonitor(*cluster, rayv1.HeadNode)
+	assert.Equal(t, PodMonitorGroupVersionKind, monitor.GroupVersionKind())
+	assert.Equal(t, utils.GeneratePodMonitorName(cluster.Name, rayv1.HeadNode), monitor.GetName())
+	assert.Equal(t, cluster.Namespace, monitor.GetNamespace())
+	assert.Equal(t, "prometheus", monitor.GetLabels()["release"])
+	matchLabels, _, _ := unstructured.NestedStringMap(monitor.Object, "spec", "selector", "matchLabels")
+	assert.Equal(t, map[string]string{utils.RayClusterLabelKey: cluster.Name, utils.RayNodeTypeLabelKey: string(rayv1.HeadNode)}, matchLabels)
+	namespaces, _, _ := unstructured.NestedStringSlice(monitor.Object, "spec", "namespaceSelector", "matchNames")
+	assert.Equal(t, []string{cluster.Namespace}, namespaces)
+	endpoints, _, _ := unstructured.NestedSlice(monitor.Object, "spec", "podMetricsEndpoints")
+	assert.Len(t, endpoints, 1)
+	assert.Equal(t, utils.MetricsPortName, endpoints[0].(map[string]interface{})["port"])
+	assert.NotContains(t, endpoints[0], "interval")
+
+	// The PodMonitor of the worker Pods also selects the namespaces of the worker groups.
+	monitor = BuildPodMonitor(*cluster, rayv1.WorkerNode)
+	namespaces, _, _ = unstructured.NestedStringSlice(monitor.Object, "spec", "namespaceSelector", "matchNames")
+	assert.Equal(t, []string{cluster.Namespace, "tenant"}, namespaces)
+}
@@ -3,6 +3,7 @@ package common
 import (
 	"context"
 	"fmt"
+	"maps"
 	"os"
 	"reflect"
 	"sort"
@@ -969,6 +970,33 @@ func TestHeadPodTemplate_AutoscalerImage(t *testing.T) {
 	assert.Equal(t, customAutoscalerImage, podTemplateSpec.Spec.Containers[autoscalerContainerIndex].Image)
 }
 
+func TestHeadPodTemplate_WithOAuth2Proxy(t *testing.T) {
+	cluster := instance.DeepCopy()
+	cluster.Spec.DashboardIngress = &rayv1.DashboardIngressOptions{
+		OAuth2Proxy: &rayv1.OAuth2ProxyOptions{
+			Image: "quay.io/oauth2-proxy/oauth2-proxy",
+			Args:  []string{"--provider=github"},
+		},
+	}
+	podName := strings.ToLower(cluster.Name + utils.DashSymbol + string(rayv1.HeadNode) + utils.DashSymbol + utils.FormatInt32(0))
+	podTemplateSpec := DefaultHeadPodTemplate(context.Background(), *cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+
+	container := podTemplateSpec.Spec.Containers[len(podTemplateSpec.Spec.Containers)-1]
+	assert.Equal(t, utils.OAuth2ProxyContainerName, container.Name)
+	assert.Equal(t, "quay.io/oauth2-proxy/oauth2-proxy", container.Image)
+	assert.Equal(t, []string{
+		fmt.Sprintf("--http-address=0.0.0.0:%d", utils.DefaultOAuth2ProxyPort),
+		fmt.Sprintf("--upstream=http://127.0.0.1:%d", utils.DefaultDashboardPort),
+		"--provider=github",
+	}, container.Args)
+	assert.Equal(t, int32(utils.DefaultOAuth2ProxyPort), container.Ports[0].ContainerPort)
+
+	// The head service exposes the port of the sidecar instead of the dashboard port.
+	ports := getServicePorts(*cluster)
+	assert.Equal(t, int32(utils.DefaultOAuth2ProxyPort), ports[utils.OAuth2ProxyPortName])
+	assert.NotContains(t, ports, utils.DashboardPortName)
+}
+
 // If no service account is specified in the RayCluster,
 // the head pod's service account should be an empty string.
 func TestHeadPodTemplate_WithNoServiceAccount(t *testing.T) {
@@ -1016,6 +1044,30 @@ func TestHeadPodTemplate_WithServiceAccount(t *testing.T) {
 	}
 }
 
+// If the RayCluster has a dedicated service account, the Pods whose templates don't set a service account run with it.
+func TestPodTemplate_WithDedicatedServiceAccount(t *testing.T) {
+	ctx := context.Background()
+	cluster := instance.DeepCopy()
+	cluster.Spec.DedicatedServiceAccount = &trueFlag
+	podName := strings.ToLower(cluster.Name + utils.DashSymbol + string(rayv1.HeadNode) + utils.DashSymbol + utils.FormatInt32(0))
+	pod := DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+	assert.Equal(t, cluster.Name, pod.Spec.ServiceAccountName)
+
+	cluster.Spec.HeadGroupSpec.Template.Spec.ServiceAccountName = "head-service-account"
+	pod = DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, podName, "6379")
+	assert.Equal(t, "head-service-account", pod.Spec.ServiceAccountName)
+
+	fqdnRayIP := utils.GenerateFQDNServiceName(ctx, *cluster, cluster.Namespace)
+	worker := cluster.Spec.WorkerGroupSpecs[0]
+	pod = DefaultWorkerPodTemplate(ctx, *cluster, *worker.DeepCopy(), podName, fqdnRayIP, "6379")
+	assert.Equal(t, cluster.Name, pod.Spec.ServiceAccountName)
+
+	// The dedicated service account doesn't exist in the namespaces of the worker groups with `namespace`.
+	worker.Namespace = "tenant"
+	pod = DefaultWorkerPodTemplate(ctx, *cluster, *worker.DeepCopy(), podName, fqdnRayIP, "6379")
+	assert.Empty(t, pod.Spec.ServiceAccountName)
+}
+
 func splitAndSort(s string) []string {
 	strs := strings.Split(s, " ")
 	result := make([]string, 0, len(strs))
@@ -1044,6 +1096,40 @@ func TestDefaultWorkerPodTemplateWithName(t *testing.T) {
 	assert.Equal(t, worker, expectedWorker)
 }
 
+func TestDefaultWorkerPodTemplateWithNamespace(t *testing.T) {
+	ctx := context.Background()
+
+	cluster := instance.DeepCopy()
+	fqdnRayIP := utils.GenerateFQDNServiceName(ctx, *cluster, cluster.Namespace)
+	worker := cluster.Spec.WorkerGroupSpecs[0]
+	podName := cluster.Name + utils.DashSymbol + string(rayv1.WorkerNode) + utils.DashSymbol + worker.GroupName + utils.DashSymbol + utils.FormatInt32(0)
+
+	// The Pods of a worker group without `namespace` are created in the namespace of the RayCluster.
+	podTemplateSpec := DefaultWorkerPodTemplate(ctx, *cluster, *worker.DeepCopy(), podName, fqdnRayIP, "6379")
+	assert.Equal(t, cluster.Namespace, podTemplateSpec.Namespace)
+	assert.NotContains(t, podTemplateSpec.Labels, utils.RayClusterNamespaceLabelKey)
+
+	worker.Namespace = "tenant"
+	podTemplateSpec = DefaultWorkerPodTemplate(ctx, *cluster, *worker.DeepCopy(), podName, fqdnRayIP, "6379")
+	assert.Equal(t, "tenant", podTemplateSpec.Namespace)
+	assert.Equal(t, cluster.Namespace, podTemplateSpec.Labels[utils.RayClusterNamespaceLabelKey])
+	assert.Equal(t, cluster.Name, podTemplateSpec.Labels[utils.RayClusterLabelKey])
+}
+
+func TestIsRemoteWorkerGroup(t *testing.T) {
+	cluster := instance.DeepCopy()
+	assert.False(t, HasRemoteWorkerGroups(cluster))
+
+	cluster.Spec.WorkerGroupSpecs[0].Namespace = cluster.Namespace
+	assert.False(t, IsRemoteWorkerGroup(cluster, cluster.Spec.WorkerGroupSpecs[0]))
+	assert.False(t, HasRemoteWorkerGroups(cluster))
+
+	cluster.Spec.WorkerGroupSpecs[0].Namespace = "tenant"
+	assert.True(t, IsRemoteWorkerGroup(cluster, cluster.Spec.WorkerGroupSpecs[0]))
+	assert.True(t, HasRemoteWorkerGroups(cluster))
+	assert.Equal(t, "tenant", GetWorkerGroupNamespace(cluster, cluster.Spec.WorkerGroupSpecs[0]))
+}
+
 func containerPortExists(ports []corev1.ContainerPort, containerPort int32) error {
 	name := utils.MetricsPortName
 	for _, port := range ports {
@@ -1381,7 +1467,7 @@ func TestInitLivenessAndReadinessProbe(t *testing.T) {
 
 	rayContainer.LivenessProbe = &httpGetProbe
 	rayContainer.ReadinessProbe = &httpGetProbe
-	initLivenessAndReadinessProbe(rayContainer, rayv1.HeadNode, "")
+	initLivenessAndReadinessProbe(rayContainer, rayv1.HeadNode, "", nil)
 	assert.NotNil(t, rayContainer.LivenessProbe.HTTPGet)
 	assert.NotNil(t, rayContainer.ReadinessProbe.HTTPGet)
 	assert.Nil(t, rayContainer.LivenessProbe.Exec)
@@ -1392,7 +1478,7 @@ func TestInitLivenessAndReadinessProbe(t *testing.T) {
 	// implying that an additional serve health check will be added to the readiness probe.
 	rayContainer.LivenessProbe = nil
 	rayContainer.ReadinessProbe = nil
-	initLivenessAndReadinessProbe(rayContainer, rayv1.WorkerNode, utils.RayServiceCRD)
+	initLivenessAndReadinessProbe(rayContainer, rayv1.WorkerNode, utils.RayServiceCRD, nil)
 	assert.NotNil(t, rayContainer.LivenessProbe.Exec)
 	assert.NotNil(t, rayContainer.ReadinessProbe.Exec)
 	assert.False(t, strings.Contains(strings.Join(rayContainer.LivenessProbe.Exec.Command, " "), utils.RayServeProxyHealthPath))
@@ -1405,7 +1491,7 @@ func TestInitLivenessAndReadinessProbe(t *testing.T) {
 	// implying that an additional serve health check will be added to the readiness probe.
 	rayContainer.LivenessProbe = nil
 	rayContainer.ReadinessProbe = nil
-	initLivenessAndReadinessProbe(rayContainer, rayv1.HeadNode, utils.RayServiceCRD)
+	initLivenessAndReadinessProbe(rayContainer, rayv1.HeadNode, utils.RayServiceCRD, nil)
 	assert.NotNil(t, rayContainer.LivenessProbe.Exec)
 	assert.NotNil(t, rayContainer.ReadinessProbe.Exec)
 	// head pod should not have Ray Serve proxy health probes
@@ -1415,6 +1501,63 @@ func TestInitLivenessAndReadinessProbe(t *testing.T) {
 	assert.Equal(t, int32(5), rayContainer.ReadinessProbe.TimeoutSeconds)
 }
 
+func TestApplyHeadHealthProbes(t *testing.T) {
+	cluster := instance.DeepCopy()
+	ctx := context.Background()
+	buildHeadPod := func() corev1.Pod {
+		// Building the Pod modifies the containers of the template.
+		cluster := cluster.DeepCopy()
+		headSpec := cluster.Spec.HeadGroupSpec.DeepCopy()
+		podTemplateSpec := DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, "head", "6379")
+		pod := BuildPod(ctx, podTemplateSpec, rayv1.HeadNode, cluster.Spec.HeadGroupSpec.RayStartParams, "6379", false, utils.GetCRDType(""), "")
+		ApplyHeadHealthProbes(&pod, *headSpec, headSpec.RayStartParams)
+		return pod
+	}
+
+	// The startup probe checks the GCS server with `ray health-check` and through the dashboard.
+	pod := buildHeadPod()
+	startupProbe := pod.Spec.Containers[utils.RayContainerIndex].StartupProbe
+	assert.NotNil(t, startupProbe)
+	assert.Equal(t, []string{
+		"bash", "-c",
+		"ray health-check --address localhost:6379 && wget -T 10 -q -O- http://localhost:8265/api/gcs_healthz | grep success",
+	}, startupProbe.Exec.Command)
+	assert.Equal(t, int32(utils.DefaultHeadStartupProbeFailureThreshold), startupProbe.FailureThreshold)
+	readinessProbe := pod.Spec.Containers[utils.RayContainerIndex].ReadinessProbe
+	assert.Equal(t, int32(utils.DefaultReadinessProbePeriodSeconds), readinessProbe.PeriodSeconds)
+
+	// The thresholds of the HealthProbes override the defaults, and the ports follow the rayStartParams.
+	cluster.Spec.HeadGroupSpec.RayStartParams = map[string]string{"port": "6380", "dashboard-port": "8266"}
+	cluster.Spec.HeadGroupSpec.HealthProbes = &rayv1.HeadHealthProbes{
+		StartupProbe:   &rayv1.HealthProbeThresholds{TimeoutSeconds: ptr.To[int32](20), FailureThreshold: ptr.To[int32](30)},
+		ReadinessProbe: &rayv1.HealthProbeThresholds{PeriodSeconds: ptr.To[int32](2), InitialDelaySeconds: ptr.To[int32](0)},
+	}
+	pod = buildHeadPod()
+	startupProbe = pod.Spec.Containers[utils.RayContainerIndex].StartupProbe
+	assert.Equal(t, "ray health-check --address localhost:6380 && wget -T 20 -q -O- http://localhost:8266/api/gcs_healthz | grep success", startupProbe.Exec.Command[2])
+	assert.Equal(t, int32(20), startupProbe.TimeoutSeconds)
+	assert.Equal(t, int32(30), startupProbe.FailureThreshold)
+	assert.Equal(t, int32(utils.DefaultHeadStartupProbePeriodSeconds), startupProbe.PeriodSeconds)
+	readinessProbe = pod.Spec.Containers[utils.RayContainerIndex].ReadinessProbe
+	assert.Equal(t, int32(2), readinessProbe.PeriodSeconds)
+	assert.Equal(t, int32(0), readinessProbe.InitialDelaySeconds)
+	assert.Equal(t, int32(utils.DefaultReadinessProbeFailureThreshold), readinessProbe.FailureThreshold)
+
+	// The probes set in the template are kept as they are.
+	userProbe := &corev1.Probe{PeriodSeconds: 7}
+	cluster.Spec.HeadGroupSpec.Template.Spec.Containers[utils.RayContainerIndex].StartupProbe = userProbe.DeepCopy()
+	cluster.Spec.HeadGroupSpec.Template.Spec.Containers[utils.RayContainerIndex].ReadinessProbe = userProbe.DeepCopy()
+	pod = buildHeadPod()
+	assert.Equal(t, userProbe, pod.Spec.Containers[utils.RayContainerIndex].StartupProbe)
+	assert.Equal(t, userProbe, pod.Spec.Containers[utils.RayContainerIndex].ReadinessProbe)
+
+	// No probe is injected if the probe injection is disabled.
+	t.Setenv(utils.ENABLE_PROBES_INJECTION, "false")
+	cluster = instance.DeepCopy()
+	pod = buildHeadPod()
+	assert.Nil(t, pod.Spec.Containers[utils.RayContainerIndex].StartupProbe)
+}
+
 func TestGenerateRayStartCommand(t *testing.T) {
 	tests := []struct {
 		rayStartParams map[string]string
@@ -1549,3 +1692,90 @@ func TestGenerateRayStartCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestSizeObjectStoreMemory(t *testing.T) {
+	resources := corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("10Gi")},
+	}
+	tests := []struct {
+		rayStartParams           map[string]string
+		objectStoreMemoryPercent *int32
+		resources                corev1.ResourceRequirements
+		expected                 map[string]string
+		name                     string
+	}{
+		{
+			name:           "Not enabled",
+			rayStartParams: map[string]string{"num-cpus": "1"},
+			resources:      resources,
+			expected:       map[string]string{"num-cpus": "1"},
+		},
+		{
+			name:                     "No memory limit",
+			rayStartParams:           map[string]string{"num-cpus": "1"},
+			objectStoreMemoryPercent: ptr.To[int32](30),
+			expected:                 map[string]string{"num-cpus": "1"},
+		},
+		{
+			name:                     "Both are computed from the memory limit",
+			objectStoreMemoryPercent: ptr.To[int32](30),
+			resources:                resources,
+			expected:                 map[string]string{ObjectStoreMemoryKey: "3221225472", "memory": "7516192768"},
+		},
+		{
+			name:                     "The object store memory set by the user is subtracted from the memory limit",
+			rayStartParams:           map[string]string{ObjectStoreMemoryKey: "1073741824"},
+			objectStoreMemoryPercent: ptr.To[int32](30),
+			resources:                resources,
+			expected:                 map[string]string{ObjectStoreMemoryKey: "1073741824", "memory": "9663676416"},
+		},
+		{
+			name:                     "The memory set by the user is kept",
+			rayStartParams:           map[string]string{"memory": "1000"},
+			objectStoreMemoryPercent: ptr.To[int32](50),
+			resources:                resources,
+			expected:                 map[string]string{ObjectStoreMemoryKey: "5368709120", "memory": "1000"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			original := maps.Clone(tc.rayStartParams)
+			assert.Equal(t, tc.expected, SizeObjectStoreMemory(tc.rayStartParams, tc.objectStoreMemoryPercent, tc.resources))
+			// The rayStartParams of the spec are never modified.
+			assert.Equal(t, original, tc.rayStartParams)
+		})
+	}
+}
+
+func TestSetSchedulingToRayClusterSpec(t *testing.T) {
+	spec := rayv1.RayClusterSpec{
+		HeadGroupSpec: rayv1.HeadGroupSpec{
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{PriorityClassName: "batch", SchedulerName: "default-scheduler"}},
+		},
+		WorkerGroupSpecs: []rayv1.WorkerGroupSpec{{}, {}},
+	}
+	SetSchedulingToRayClusterSpec(&spec, "serving", "")
+	assert.Equal(t, "serving", spec.HeadGroupSpec.Template.Spec.PriorityClassName)
+	assert.Equal(t, "default-scheduler", spec.HeadGroupSpec.Template.Spec.SchedulerName)
+	for _, worker := range spec.WorkerGroupSpecs {
+		assert.Equal(t, "serving", worker.Template.Spec.PriorityClassName)
+		assert.Empty(t, worker.Template.Spec.SchedulerName)
+	}
+}
+
+func TestAddImagePullSecretsToRayClusterSpec(t *testing.T) {
+	spec := rayv1.RayClusterSpec{
+		HeadGroupSpec: rayv1.HeadGroupSpec{
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				ImagePullSecrets: []corev1.LocalObjectReference{{Name: "head"}, {Name: "registry"}},
+			}},
+		},
+		WorkerGroupSpecs: []rayv1.WorkerGroupSpec{{}, {}},
+	}
+	AddImagePullSecretsToRayClusterSpec(&spec, []corev1.LocalObjectReference{{Name: "registry"}, {Name: "mirror"}})
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "head"}, {Name: "registry"}, {Name: "mirror"}}, spec.HeadGroupSpec.Template.Spec.ImagePullSecrets)
+	for _, worker := range spec.WorkerGroupSpecs {
+		assert.Equal(t, []corev1.LocalObjectReference{{Name: "registry"}, {Name: "mirror"}}, worker.Template.Spec.ImagePullSecrets)
+	}
+}
@@ -0,0 +1,85 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+const (
+	// defaultObjectStoreMemoryPercent is the percentage of the memory of the Ray container that Ray reserves for the
+	// object store when `object-store-memory` isn't set.
+	defaultObjectStoreMemoryPercent = 30
+	// objectStoreDrainBytesPerSecond is the throughput assumed to move the objects of a draining node to the other
+	// nodes or to spill them.
+	objectStoreDrainBytesPerSecond = 100 * 1024 * 1024
+	// minDrainTerminationGracePeriodSeconds is the grace period of the worker Pods with a small object store, which
+	// is the default grace period of Kubernetes.
+	minDrainTerminationGracePeriodSeconds = 30
+	// maxDrainTerminationGracePeriodSeconds caps the grace period of the worker Pods with a large object store.
+	maxDrainTerminationGracePeriodSeconds = 900
+	// preStopDrainMarginSeconds is the part of the grace period left to Ray to stop after the preStop hook.
+	preStopDrainMarginSeconds = 5
+)
+
+// GetObjectStoreMemory returns the size in bytes of the object store of the Ray container, which is the
+// `object-store-memory` rayStartParam, or the default share of the memory of the container.
+func GetObjectStoreMemory(rayStartParams map[string]string, container corev1.Container) int64 {
+	if value, ok := rayStartParams[ObjectStoreMemoryKey]; ok {
+		if parsed, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return parsed
+		}
+	}
+	if memory := findMemoryReqOrLimit(container); memory != nil {
+		return memory.Value() * defaultObjectStoreMemoryPercent / 100
+	}
+	return 0
+}
+
+// GetDrainTerminationGracePeriodSeconds returns the grace period needed to drain a worker Pod whose object store has
+// the given size.
+func GetDrainTerminationGracePeriodSeconds(objectStoreMemory int64) int64 {
+	seconds := minDrainTerminationGracePeriodSeconds + (objectStoreMemory+objectStoreDrainBytesPerSecond-1)/objectStoreDrainBytesPerSecond
+	return min(seconds, maxDrainTerminationGracePeriodSeconds)
+}
+
+// getPreStopDrainCommand returns the command of the preStop hook that drains the Ray node of the Pod with the given
+// deadline, and waits for the raylet to exit until the deadline.
+func getPreStopDrainCommand(deadlineSeconds int64) string {
+	return fmt.Sprintf(
+		`node_id=$(python -c 'import ray; ray.init(address="auto", logging_level="ERROR", log_to_driver=False); print(ray.get_runtime_context().get_node_id())') && `+
+			`ray drain-node --node-id="$node_id" --reason=DRAIN_NODE_REASON_PREEMPTION --reason-message="The Pod is terminating" --deadline-remaining-seconds=%d; `+
+			`timeout %d sh -c 'while pgrep -x raylet > /dev/null; do sleep 1; done'; true`,
+		deadlineSeconds, deadlineSeconds)
+}
+
+// ApplyPreStopDrain sets the terminationGracePeriodSeconds of the worker Pod for the size of its object store, unless
+// it's set, and adds a preStop hook to the Ray container that drains the Ray node within the grace period, unless the
+// container has a preStop hook.
+func ApplyPreStopDrain(pod *corev1.Pod, rayStartParams map[string]string) {
+	container := &pod.Spec.Containers[utils.RayContainerIndex]
+	if pod.Spec.TerminationGracePeriodSeconds == nil {
+		objectStoreMemory := GetObjectStoreMemory(rayStartParams, *container)
+		pod.Spec.TerminationGracePeriodSeconds = ptr.To(GetDrainTerminationGracePeriodSeconds(objectStoreMemory))
+	}
+	if container.Lifecycle != nil && container.Lifecycle.PreStop != nil {
+		return
+	}
+	deadlineSeconds := *pod.Spec.TerminationGracePeriodSeconds - preStopDrainMarginSeconds
+	if deadlineSeconds <= 0 {
+		return
+	}
+	// The lifecycle may be shared with the template of the RayCluster.
+	lifecycle := &corev1.Lifecycle{}
+	if container.Lifecycle != nil {
+		lifecycle = container.Lifecycle.DeepCopy()
+	}
+	lifecycle.PreStop = &corev1.LifecycleHandler{
+		Exec: &corev1.ExecAction{Command: []string{"/bin/sh", "-c", getPreStopDrainCommand(deadlineSeconds)}},
+	}
+	container.Lifecycle = lifecycle
+}
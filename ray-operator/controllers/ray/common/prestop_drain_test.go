@@ -0,0 +1,63 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+)
+
+func TestGetObjectStoreMemory(t *testing.T) {
+	container := corev1.Container{Resources: corev1.ResourceRequirements{
+		Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("10Gi")},
+	}}
+	assert.Equal(t, int64(3*1024*1024*1024), GetObjectStoreMemory(nil, container))
+	assert.Equal(t, int64(1000), GetObjectStoreMemory(map[string]string{ObjectStoreMemoryKey: "1000"}, container))
+	assert.Equal(t, int64(0), GetObjectStoreMemory(nil, corev1.Container{}))
+}
+
+func TestGetDrainTerminationGracePeriodSeconds(t *testing.T) {
+	assert.Equal(t, int64(30), GetDrainTerminationGracePeriodSeconds(0))
+	assert.Equal(t, int64(31), GetDrainTerminationGracePeriodSeconds(1))
+	// 3 GiB at 100 MiB/s.
+	assert.Equal(t, int64(61), GetDrainTerminationGracePeriodSeconds(3*1024*1024*1024))
+	assert.Equal(t, int64(900), GetDrainTerminationGracePeriodSeconds(1024*1024*1024*1024))
+}
+
+func TestApplyPreStopDrain(t *testing.T) {
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "ray-worker"}}}}
+	ApplyPreStopDrain(pod, map[string]string{ObjectStoreMemoryKey: "3221225472"})
+	assert.Equal(t, int64(61), *pod.Spec.TerminationGracePeriodSeconds)
+	command := pod.Spec.Containers[0].Lifecycle.PreStop.Exec.Command
+	assert.Equal(t, []string{"/bin/sh", "-c"}, command[:2])
+	assert.Contains(t, command[2], "ray drain-node")
+	assert.Contains(t, command[2], "--deadline-remaining-seconds=56")
+
+	// The grace period and the preStop hook of the user are kept.
+	userPreStop := &corev1.LifecycleHandler{Exec: &corev1.ExecAction{Command: []string{"cleanup"}}}
+	pod = &corev1.Pod{Spec: corev1.PodSpec{
+		TerminationGracePeriodSeconds: ptr.To[int64](120),
+		Containers:                    []corev1.Container{{Lifecycle: &corev1.Lifecycle{PreStop: userPreStop}}},
+	}}
+	ApplyPreStopDrain(pod, nil)
+	assert.Equal(t, int64(120), *pod.Spec.TerminationGracePeriodSeconds)
+	assert.Equal(t, userPreStop, pod.Spec.Containers[0].Lifecycle.PreStop)
+
+	// The drain uses the grace period of the user.
+	pod = &corev1.Pod{Spec: corev1.PodSpec{
+		TerminationGracePeriodSeconds: ptr.To[int64](120),
+		Containers:                    []corev1.Container{{}},
+	}}
+	ApplyPreStopDrain(pod, nil)
+	assert.Contains(t, pod.Spec.Containers[0].Lifecycle.PreStop.Exec.Command[2], "--deadline-remaining-seconds=115")
+
+	// No preStop hook is added if the grace period is too short to drain.
+	pod = &corev1.Pod{Spec: corev1.PodSpec{
+		TerminationGracePeriodSeconds: ptr.To[int64](0),
+		Containers:                    []corev1.Container{{}},
+	}}
+	ApplyPreStopDrain(pod, nil)
+	assert.Nil(t, pod.Spec.Containers[0].Lifecycle)
+}
@@ -0,0 +1,98 @@
+package common
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// ProfileTool is the profiler requested by the `ray.io/profile` annotation of a Ray Pod.
+type ProfileTool string
+
+const (
+	PySpyDumpProfileTool   ProfileTool = "py-spy-dump"
+	PySpyRecordProfileTool ProfileTool = "py-spy-record"
+	MemrayProfileTool      ProfileTool = "memray"
+)
+
+// ProfileOutputEnvVar is the environment variable of the profiling container that holds the path of the output.
+const ProfileOutputEnvVar = "PROFILE_OUTPUT"
+
+// BuildProfilingContainer builds the ephemeral container that profiles the Ray container of the Pod as requested by
+// the annotations of the Pod, and returns it with the path of the output.
+func BuildProfilingContainer(options *rayv1.ProfilingOptions, pod *corev1.Pod, name string) (corev1.EphemeralContainer, string, error) {
+	pid, err := getProfileAnnotationInt(pod, utils.RayProfilePIDAnnotationKey, utils.DefaultProfilePID)
+	if err != nil {
+		return corev1.EphemeralContainer{}, "", err
+	}
+	duration, err := getProfileAnnotationInt(pod, utils.RayProfileDurationAnnotationKey, utils.DefaultProfileDurationSeconds)
+	if err != nil {
+		return corev1.EphemeralContainer{}, "", err
+	}
+
+	outputDir := utils.DefaultProfileOutputDir
+	if options.OutputDir != nil {
+		outputDir = *options.OutputDir
+	}
+	var extension, profileCmd string
+	switch tool := ProfileTool(pod.Annotations[utils.RayProfileAnnotationKey]); tool {
+	case PySpyDumpProfileTool:
+		extension = "txt"
+		profileCmd = fmt.Sprintf("py-spy dump --subprocesses --pid %d > \"$%s\"", pid, ProfileOutputEnvVar)
+	case PySpyRecordProfileTool:
+		extension = "svg"
+		profileCmd = fmt.Sprintf("py-spy record --subprocesses --pid %d --duration %d --output \"$%s\"", pid, duration, ProfileOutputEnvVar)
+	case MemrayProfileTool:
+		extension = "bin"
+		profileCmd = fmt.Sprintf("memray attach --duration %d --output \"$%s\" %d", duration, ProfileOutputEnvVar, pid)
+	default:
+		return corev1.EphemeralContainer{}, "", fmt.Errorf("unsupported profiler %q, supported profilers are %s, %s and %s",
+			tool, PySpyDumpProfileTool, PySpyRecordProfileTool, MemrayProfileTool)
+	}
+	output := path.Join(outputDir, fmt.Sprintf("%s-%s.%s", pod.Name, name, extension))
+
+	// The upload command is passed as the positional parameters of the script, so it isn't interpreted by the shell.
+	script := fmt.Sprintf("mkdir -p \"$(dirname \"$%s\")\" && %s && if [ $# -gt 0 ]; then exec \"$@\"; fi", ProfileOutputEnvVar, profileCmd)
+	container := corev1.EphemeralContainer{
+		TargetContainerName: pod.Spec.Containers[utils.RayContainerIndex].Name,
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon{
+			Name:         name,
+			Image:        options.Image,
+			Command:      append([]string{"/bin/sh", "-c", script, "profile"}, options.UploadCommand...),
+			Env:          append([]corev1.EnvVar{{Name: ProfileOutputEnvVar, Value: output}}, options.Env...),
+			VolumeMounts: options.VolumeMounts,
+			SecurityContext: &corev1.SecurityContext{
+				Capabilities: &corev1.Capabilities{Add: []corev1.Capability{"SYS_PTRACE"}},
+			},
+			TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		},
+	}
+	return container, output, nil
+}
+
+// GetProfilingContainerState returns the terminated state of the profiling container, or nil if it hasn't terminated.
+func GetProfilingContainerState(pod *corev1.Pod, name string) *corev1.ContainerStateTerminated {
+	for _, status := range pod.Status.EphemeralContainerStatuses {
+		if status.Name == name {
+			return status.State.Terminated
+		}
+	}
+	return nil
+}
+
+func getProfileAnnotationInt(pod *corev1.Pod, key string, defaultValue int) (int, error) {
+	value, ok := pod.Annotations[key]
+	if !ok {
+		return defaultValue, nil
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil || i <= 0 {
+		return 0, fmt.Errorf("invalid value %q of annotation %s, it should be a positive integer", value, key)
+	}
+	return i, nil
+}
@@ -0,0 +1,59 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestBuildProfilingContainer(t *testing.T) {
+	options := &rayv1.ProfilingOptions{
+		Image:         "profiler",
+		OutputDir:     ptr.To("/profiles"),
+		UploadCommand: []string{"aws", "s3", "cp", "$(PROFILE_OUTPUT)", "s3://bucket/"},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "head",
+			Annotations: map[string]string{
+				utils.RayProfileAnnotationKey:         string(PySpyRecordProfileTool),
+				utils.RayProfilePIDAnnotationKey:      "42",
+				utils.RayProfileDurationAnnotationKey: "10",
+			},
+		},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "ray-head"}}},
+	}
+
+	container, output, err := BuildProfilingContainer(options, pod, "profile-1")
+	require.NoError(t, err)
+	assert.Equal(t, "/profiles/head-profile-1.svg", output)
+	assert.Equal(t, "ray-head", container.TargetContainerName)
+	assert.Equal(t, "profile-1", container.Name)
+	assert.Contains(t, container.Command[2], "py-spy record --subprocesses --pid 42 --duration 10")
+	assert.Equal(t, options.UploadCommand, container.Command[4:])
+	assert.Equal(t, corev1.EnvVar{Name: ProfileOutputEnvVar, Value: output}, container.Env[0])
+	assert.Equal(t, []corev1.Capability{"SYS_PTRACE"}, container.SecurityContext.Capabilities.Add)
+
+	// Invalid requests.
+	pod.Annotations[utils.RayProfilePIDAnnotationKey] = "-1"
+	_, _, err = BuildProfilingContainer(options, pod, "profile-1")
+	assert.Error(t, err)
+	pod.Annotations[utils.RayProfilePIDAnnotationKey] = "1"
+	pod.Annotations[utils.RayProfileAnnotationKey] = "perf"
+	_, _, err = BuildProfilingContainer(options, pod, "profile-1")
+	assert.Error(t, err)
+
+	// The state of the profiling container.
+	assert.Nil(t, GetProfilingContainerState(pod, "profile-1"))
+	pod.Status.EphemeralContainerStatuses = []corev1.ContainerStatus{
+		{Name: "profile-1", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}}},
+	}
+	assert.NotNil(t, GetProfilingContainerState(pod, "profile-1"))
+}
@@ -0,0 +1,82 @@
+package common
+
+import (
+	"fmt"
+	"regexp"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// PrometheusRuleGroupVersionKind is the GroupVersionKind of the PrometheusRule of the Prometheus Operator. KubeRay uses
+// unstructured PrometheusRules so that the Prometheus Operator CRDs are only needed when `serveAlerting` is set.
+var PrometheusRuleGroupVersionKind = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "PrometheusRule"}
+
+// BuildServeAlertsPrometheusRule builds the PrometheusRule with the alerts of the SLOs of the Serve applications of
+// the RayService. The alerts are based on the `ray_serve_deployment_replica_healthy` metric of the replicas of the
+// RayClusters of the RayService, which is labeled with the RayCluster by the PodMonitors of `prometheusMonitoring`.
+func BuildServeAlertsPrometheusRule(rayService rayv1.RayService) *unstructured.Unstructured {
+	options := rayService.Spec.ServeAlerting
+	clusterRegex := regexp.QuoteMeta(utils.RayClusterNamePrefix(rayService.Name)) + ".*"
+
+	rules := []interface{}{}
+	for _, app := range options.Applications {
+		selector := fmt.Sprintf(`ray_serve_deployment_replica_healthy{namespace=%q,ray_io_cluster=~%q,application=%q}`,
+			rayService.Namespace, clusterRegex, app.Name)
+		labels := map[string]interface{}{
+			"namespace":   rayService.Namespace,
+			"rayservice":  rayService.Name,
+			"application": app.Name,
+		}
+		if app.MaxUnhealthySeconds != nil {
+			rules = append(rules, map[string]interface{}{
+				"alert":  "RayServeApplicationUnhealthy",
+				"expr":   fmt.Sprintf("min(%s) < 1", selector),
+				"for":    fmt.Sprintf("%ds", *app.MaxUnhealthySeconds),
+				"labels": labels,
+				"annotations": map[string]interface{}{
+					"summary": fmt.Sprintf("The Serve application %s of the RayService %s/%s has unhealthy replicas for more than %d seconds.",
+						app.Name, rayService.Namespace, rayService.Name, *app.MaxUnhealthySeconds),
+				},
+			})
+		}
+		if app.MinReplicas != nil {
+			rules = append(rules, map[string]interface{}{
+				"alert":  "RayServeApplicationReplicasBelowMinimum",
+				"expr":   fmt.Sprintf("(sum(%s) or vector(0)) < %d", selector, *app.MinReplicas),
+				"labels": labels,
+				"annotations": map[string]interface{}{
+					"summary": fmt.Sprintf("The Serve application %s of the RayService %s/%s has less than %d healthy replicas.",
+						app.Name, rayService.Namespace, rayService.Name, *app.MinReplicas),
+				},
+			})
+		}
+	}
+
+	rule := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"groups": []interface{}{
+					map[string]interface{}{
+						"name":  fmt.Sprintf("%s.%s.serve", rayService.Namespace, rayService.Name),
+						"rules": rules,
+					},
+				},
+			},
+		},
+	}
+	rule.SetGroupVersionKind(PrometheusRuleGroupVersionKind)
+	rule.SetName(utils.GenerateServeAlertsRuleName(rayService.Name))
+	rule.SetNamespace(rayService.Namespace)
+	labels := map[string]string{}
+	for k, v := range options.Labels {
+		labels[k] = v
+	}
+	labels[utils.RayOriginatedFromCRNameLabelKey] = rayService.Name
+	labels[utils.RayOriginatedFromCRDLabelKey] = utils.RayOriginatedFromCRDLabelValue(utils.RayServiceCRD)
+	rule.SetLabels(labels)
+	return rule
+}
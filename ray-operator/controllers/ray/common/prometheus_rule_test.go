@@ -0,0 +1,52 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestBuildServeAlertsPrometheusRule(t *testing.T) {
+	rayService := rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-service", Namespace: "ray"},
+		Spec: rayv1.RayServiceSpec{
+			ServeAlerting: &rayv1.ServeAlertingOptions{
+				Applications: []rayv1.ServeApplicationSLO{
+					{Name: "fruit", MaxUnhealthySeconds: ptr.To[int32](60), MinReplicas: ptr.To[int32](2)},
+					{Name: "math", MinReplicas: ptr.To[int32](1)},
+				},
+				Labels: map[string]string{"release": "prometheus"},
+			},
+		},
+	}
+
+	rule := BuildServeAlertsPrometheusRule(rayService)
+	assert.Equal(t, PrometheusRuleGroupVersionKind, rule.GroupVersionKind())
+	assert.Equal(t, utils.GenerateServeAlertsRuleName(rayService.Name), rule.GetName())
+	assert.Equal(t, rayService.Namespace, rule.GetNamespace())
+	assert.Equal(t, "prometheus", rule.GetLabels()["release"])
+	assert.Equal(t, rayService.Name, rule.GetLabels()[utils.RayOriginatedFromCRNameLabelKey])
+
+	groups, _, _ := unstructured.NestedSlice(rule.Object, "spec", "groups")
+	assert.Len(t, groups, 1)
+	rules, _, _ := unstructured.NestedSlice(groups[0].(map[string]interface{}), "rules")
+	assert.Len(t, rules, 3)
+
+	unhealthy := rules[0].(map[string]interface{})
+	assert.Equal(t, "RayServeApplicationUnhealthy", unhealthy["alert"])
+	assert.Equal(t, "60s", unhealthy["for"])
+	assert.Equal(t, `min(ray_serve_deployment_replica_healthy{namespace="ray",ray_io_cluster=~"my-service-raycluster-.*",application="fruit"}) < 1`, unhealthy["expr"])
+
+	replicas := rules[1].(map[string]interface{})
+	assert.Equal(t, "RayServeApplicationReplicasBelowMinimum", replicas["alert"])
+	assert.Equal(t, `(sum(ray_serve_deployment_replica_healthy{namespace="ray",ray_io_cluster=~"my-service-raycluster-.*",application="fruit"}) or vector(0)) < 2`, replicas["expr"])
+	assert.NotContains(t, replicas, "for")
+
+	assert.Equal(t, "math", rules[2].(map[string]interface{})["labels"].(map[string]interface{})["application"])
+}
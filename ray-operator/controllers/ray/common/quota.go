@@ -0,0 +1,86 @@
+package common
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// IsRayClusterAdmittedByRayQuota returns whether the resources of the RayCluster count towards the RayQuotas of its
+// namespace. RayClusters without the RayClusterQuotaExceeded condition haven't been evaluated yet, or were created
+// before the RayQuotas.
+func IsRayClusterAdmittedByRayQuota(cluster *rayv1.RayCluster) bool {
+	if cluster.DeletionTimestamp != nil || (cluster.Spec.Suspend != nil && *cluster.Spec.Suspend) {
+		return false
+	}
+	condition := meta.FindStatusCondition(cluster.Status.Conditions, string(rayv1.RayClusterQuotaExceeded))
+	return condition == nil || condition.Status == metav1.ConditionFalse
+}
+
+// CalculateRayQuotaUsage returns the resources used by the other RayClusters in the namespace of the RayCluster.
+// RayClusters that haven't been evaluated yet are only counted if they were created before the RayCluster, so that
+// RayClusters created at the same time are admitted in order instead of blocking each other.
+func CalculateRayQuotaUsage(cluster *rayv1.RayCluster, clusters []rayv1.RayCluster) corev1.ResourceList {
+	usedList := []corev1.ResourceList{{}}
+	for i := range clusters {
+		other := &clusters[i]
+		if other.UID == cluster.UID || !IsRayClusterAdmittedByRayQuota(other) {
+			continue
+		}
+		if meta.FindStatusCondition(other.Status.Conditions, string(rayv1.RayClusterQuotaExceeded)) == nil && !isCreatedBefore(other, cluster) {
+			continue
+		}
+		usedList = append(usedList, utils.CalculateMaxResources(other))
+	}
+	return utils.SumResourceList(usedList)
+}
+
+// FindExceededRayQuota returns the first RayQuota that the requested resources would exceed, and the exceeded resource.
+func FindExceededRayQuota(quotas []rayv1.RayQuota, used, requested corev1.ResourceList) (*rayv1.RayQuota, corev1.ResourceName) {
+	for i := range quotas {
+		for name, hard := range quotas[i].Spec.Hard {
+			total := used[name].DeepCopy()
+			total.Add(requested[name])
+			if total.Cmp(hard) > 0 {
+				return &quotas[i], name
+			}
+		}
+	}
+	return nil, ""
+}
+
+// CalculateRayQuotaStatus returns the status of a RayQuota with the RayClusters of its namespace.
+func CalculateRayQuotaStatus(clusters []rayv1.RayCluster) rayv1.RayQuotaStatus {
+	status := rayv1.RayQuotaStatus{}
+	usedList := []corev1.ResourceList{{}}
+	for i := range clusters {
+		cluster := &clusters[i]
+		if IsRayClusterAdmittedByRayQuota(cluster) {
+			status.AdmittedRayClusters++
+			usedList = append(usedList, utils.CalculateMaxResources(cluster))
+		} else if condition := meta.FindStatusCondition(cluster.Status.Conditions, string(rayv1.RayClusterQuotaExceeded)); condition != nil &&
+			condition.Status == metav1.ConditionTrue && condition.Reason == rayv1.RayQuotaQueued {
+			status.QueuedRayClusters++
+		}
+	}
+	status.Used = utils.SumResourceList(usedList)
+	return status
+}
+
+// GetRayQuotaPolicy returns the policy of the RayQuota, which defaults to Queue.
+func GetRayQuotaPolicy(quota *rayv1.RayQuota) rayv1.RayQuotaPolicy {
+	if quota.Spec.Policy != nil {
+		return *quota.Spec.Policy
+	}
+	return rayv1.QueueRayQuotaPolicy
+}
+
+func isCreatedBefore(a, b *rayv1.RayCluster) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	return a.Name < b.Name
+}
@@ -0,0 +1,68 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestRayQuota(t *testing.T) {
+	newCluster := func(name string, created time.Time, condition *metav1.Condition) rayv1.RayCluster {
+		cluster := instance.DeepCopy()
+		cluster.Name = name
+		cluster.UID = types.UID(name)
+		cluster.CreationTimestamp = metav1.NewTime(created)
+		cluster.Spec.WorkerGroupSpecs = nil
+		cluster.Spec.HeadGroupSpec.Template.Spec.Containers[0].Resources = corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+		}
+		if condition != nil {
+			condition.Type = string(rayv1.RayClusterQuotaExceeded)
+			cluster.Status.Conditions = []metav1.Condition{*condition}
+		}
+		return *cluster
+	}
+	now := time.Now()
+	self := newCluster("self", now, nil)
+	clusters := []rayv1.RayCluster{
+		self,
+		newCluster("admitted", now.Add(time.Minute), &metav1.Condition{Status: metav1.ConditionFalse, Reason: rayv1.RayQuotaAdmitted}),
+		newCluster("queued", now.Add(-time.Minute), &metav1.Condition{Status: metav1.ConditionTrue, Reason: rayv1.RayQuotaQueued}),
+		// Not evaluated yet, and created before the RayCluster.
+		newCluster("older", now.Add(-time.Minute), nil),
+		// Not evaluated yet, and created after the RayCluster.
+		newCluster("newer", now.Add(time.Minute), nil),
+	}
+	suspended := newCluster("suspended", now.Add(-time.Minute), nil)
+	suspended.Spec.Suspend = ptr.To(true)
+	clusters = append(clusters, suspended)
+
+	used := CalculateRayQuotaUsage(&self, clusters)
+	assert.True(t, used.Cpu().Equal(resource.MustParse("2")))
+
+	quotas := []rayv1.RayQuota{{
+		ObjectMeta: metav1.ObjectMeta{Name: "quota"},
+		Spec:       rayv1.RayQuotaSpec{Hard: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3")}},
+	}}
+	requested := corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}
+	quota, _ := FindExceededRayQuota(quotas, used, requested)
+	assert.Nil(t, quota)
+	requested[corev1.ResourceCPU] = resource.MustParse("2")
+	quota, resourceName := FindExceededRayQuota(quotas, used, requested)
+	assert.Equal(t, "quota", quota.Name)
+	assert.Equal(t, corev1.ResourceCPU, resourceName)
+	assert.Equal(t, rayv1.QueueRayQuotaPolicy, GetRayQuotaPolicy(quota))
+
+	status := CalculateRayQuotaStatus(clusters)
+	assert.Equal(t, int32(4), status.AdmittedRayClusters)
+	assert.Equal(t, int32(1), status.QueuedRayClusters)
+	assert.True(t, status.Used.Cpu().Equal(resource.MustParse("4")))
+}
@@ -0,0 +1,83 @@
+package common
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+const (
+	RayStartHooksVolumeName = "ray-start-hooks"
+	RayStartHooksMountPath  = "/etc/ray/start-hooks"
+	PreRayStartScriptName   = "pre-ray-start.sh"
+	PostRayStartScriptName  = "post-ray-start.sh"
+)
+
+// ApplyRayStartHooks mounts the scripts of the hooks in the Ray container of the Pod and runs them around the
+// `ray start` command generated by BuildPod. The Pod is unchanged if the command of the Ray container isn't generated.
+// The post-start script waits for the Raylet to be healthy using the dashboard agent port in rayStartParams.
+func ApplyRayStartHooks(pod *corev1.Pod, hooks *rayv1.RayStartHooks, rayStartParams map[string]string) {
+	if hooks == nil || (hooks.PreRayStart == nil && hooks.PostRayStart == nil) {
+		return
+	}
+	container := &pod.Spec.Containers[utils.RayContainerIndex]
+	if len(container.Args) != 1 {
+		return
+	}
+	index := strings.Index(container.Args[0], ulimitCmd)
+	if index < 0 {
+		return
+	}
+
+	var hookCmds []string
+	for _, hook := range []struct {
+		selector   *corev1.ConfigMapKeySelector
+		scriptName string
+	}{
+		{hooks.PreRayStart, PreRayStartScriptName},
+		{hooks.PostRayStart, PostRayStartScriptName},
+	} {
+		if hook.selector == nil {
+			continue
+		}
+		volumeName := fmt.Sprintf("%s-%s", RayStartHooksVolumeName, strings.TrimSuffix(hook.scriptName, ".sh"))
+		mountPath := path.Join(RayStartHooksMountPath, hook.scriptName)
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: hook.selector.LocalObjectReference,
+					Items:                []corev1.KeyToPath{{Key: hook.selector.Key, Path: hook.scriptName}},
+					DefaultMode:          ptr.To[int32](0o755),
+					Optional:             hook.selector.Optional,
+				},
+			},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      volumeName,
+			MountPath: mountPath,
+			SubPath:   hook.scriptName,
+			ReadOnly:  true,
+		})
+
+		if hook.scriptName == PreRayStartScriptName {
+			hookCmds = append(hookCmds, fmt.Sprintf("if [ -f %[1]s ]; then bash %[1]s || exit 1; fi; ", mountPath))
+			continue
+		}
+		agentPort, ok := rayStartParams["dashboard-agent-listen-port"]
+		if !ok {
+			agentPort = fmt.Sprint(utils.DefaultDashboardAgentListenPort)
+		}
+		healthCmd := fmt.Sprintf("wget -T 2 -q -O- http://localhost:%s/%s | grep -q success", agentPort, utils.RayAgentRayletHealthPath)
+		hookCmds = append(hookCmds, fmt.Sprintf("(until %s; do sleep 1; done; if [ -f %[2]s ]; then bash %[2]s || echo \"%[2]s failed\"; fi) & ", healthCmd, mountPath))
+	}
+
+	args := container.Args[0]
+	container.Args[0] = args[:index] + strings.Join(hookCmds, "") + args[index:]
+}
@@ -0,0 +1,56 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestApplyRayStartHooks(t *testing.T) {
+	newPod := func(args string) *corev1.Pod {
+		return &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+			Name:    "ray-worker",
+			Command: []string{"/bin/bash", "-lc", "--"},
+			Args:    []string{args},
+		}}}}
+	}
+	hooks := &rayv1.RayStartHooks{
+		PreRayStart: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "hooks"},
+			Key:                  "setup.sh",
+		},
+		PostRayStart: &corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "hooks"},
+			Key:                  "warmup.sh",
+		},
+	}
+
+	pod := newPod("echo hello && ulimit -n 65536; ray start --block")
+	ApplyRayStartHooks(pod, hooks, map[string]string{"dashboard-agent-listen-port": "12345"})
+	assert.Equal(t, "echo hello && "+
+		"if [ -f /etc/ray/start-hooks/pre-ray-start.sh ]; then bash /etc/ray/start-hooks/pre-ray-start.sh || exit 1; fi; "+
+		"(until wget -T 2 -q -O- http://localhost:12345/api/local_raylet_healthz | grep -q success; do sleep 1; done; "+
+		"if [ -f /etc/ray/start-hooks/post-ray-start.sh ]; then bash /etc/ray/start-hooks/post-ray-start.sh || echo \"/etc/ray/start-hooks/post-ray-start.sh failed\"; fi) & "+
+		"ulimit -n 65536; ray start --block", pod.Spec.Containers[0].Args[0])
+	require.Len(t, pod.Spec.Volumes, 2)
+	assert.Equal(t, "hooks", pod.Spec.Volumes[0].ConfigMap.Name)
+	assert.Equal(t, []corev1.KeyToPath{{Key: "setup.sh", Path: PreRayStartScriptName}}, pod.Spec.Volumes[0].ConfigMap.Items)
+	assert.Equal(t, []corev1.KeyToPath{{Key: "warmup.sh", Path: PostRayStartScriptName}}, pod.Spec.Volumes[1].ConfigMap.Items)
+	require.Len(t, pod.Spec.Containers[0].VolumeMounts, 2)
+	assert.Equal(t, "/etc/ray/start-hooks/pre-ray-start.sh", pod.Spec.Containers[0].VolumeMounts[0].MountPath)
+
+	// The hooks aren't applied if the command of the Ray container isn't generated by KubeRay.
+	pod = newPod("ray start --block")
+	ApplyRayStartHooks(pod, hooks, nil)
+	assert.Equal(t, "ray start --block", pod.Spec.Containers[0].Args[0])
+	assert.Empty(t, pod.Spec.Volumes)
+
+	// Nothing is changed without hooks.
+	pod = newPod("ulimit -n 65536; ray start --block")
+	ApplyRayStartHooks(pod, &rayv1.RayStartHooks{}, nil)
+	assert.Equal(t, "ulimit -n 65536; ray start --block", pod.Spec.Containers[0].Args[0])
+}
@@ -26,7 +26,24 @@ func BuildServiceAccount(cluster *rayv1.RayCluster) (*corev1.ServiceAccount, err
 	return sa, nil
 }
 
-// BuildRole creates a new Role for an RayCluster resource.
+// BuildDedicatedServiceAccount creates the ServiceAccount dedicated to a RayCluster, which runs the Ray Pods whose
+// templates don't set a serviceAccountName.
+func BuildDedicatedServiceAccount(cluster *rayv1.RayCluster) *corev1.ServiceAccount {
+	return &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utils.GetDedicatedServiceAccountName(cluster),
+			Namespace: cluster.Namespace,
+			Labels: map[string]string{
+				utils.RayClusterLabelKey:                cluster.Name,
+				utils.KubernetesApplicationNameLabelKey: utils.ApplicationName,
+				utils.KubernetesCreatedByLabelKey:       utils.ComponentName,
+			},
+		},
+	}
+}
+
+// BuildRole creates a new Role for an RayCluster resource. It only grants the permissions of the autoscaler if the
+// autoscaler is enabled, so the Role of a RayCluster with a dedicated ServiceAccount grants nothing otherwise.
 func BuildRole(cluster *rayv1.RayCluster) (*rbacv1.Role, error) {
 	role := &rbacv1.Role{
 		ObjectMeta: metav1.ObjectMeta{
@@ -38,7 +55,9 @@ func BuildRole(cluster *rayv1.RayCluster) (*rbacv1.Role, error) {
 				utils.KubernetesCreatedByLabelKey:       utils.ComponentName,
 			},
 		},
-		Rules: []rbacv1.PolicyRule{
+	}
+	if utils.IsAutoscalingEnabled(cluster) {
+		role.Rules = []rbacv1.PolicyRule{
 			{
 				APIGroups: []string{""},
 				Resources: []string{"pods"},
@@ -49,7 +68,7 @@ func BuildRole(cluster *rayv1.RayCluster) (*rbacv1.Role, error) {
 				Resources: []string{"rayclusters"},
 				Verbs:     []string{"get", "patch"},
 			},
-		},
+		}
 	}
 
 	return role, nil
@@ -83,6 +102,13 @@ func BuildRoleBinding(cluster *rayv1.RayCluster) (*rbacv1.RoleBinding, error) {
 			Name: utils.CheckName(cluster.Name),
 		},
 	}
+	if utils.IsDedicatedServiceAccountEnabled(cluster) && utils.CheckName(serviceAccountName) != utils.GetDedicatedServiceAccountName(cluster) {
+		rb.Subjects = append(rb.Subjects, rbacv1.Subject{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      utils.GetDedicatedServiceAccountName(cluster),
+			Namespace: cluster.Namespace,
+		})
+	}
 
 	return rb, nil
 }
@@ -10,6 +10,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 // Test subject and role ref names in the function BuildRoleBinding.
@@ -84,3 +85,30 @@ func TestBuildRoleBindingSubjectAndRoleRefName(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildRoleBindingWithDedicatedServiceAccount(t *testing.T) {
+	cluster := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "raycluster-sample", Namespace: "default"},
+		Spec: rayv1.RayClusterSpec{
+			DedicatedServiceAccount: ptr.To(true),
+		},
+	}
+	rb, err := BuildRoleBinding(cluster)
+	assert.Nil(t, err)
+	assert.Len(t, rb.Subjects, 1)
+
+	// The ServiceAccount of the head Pod and the dedicated ServiceAccount are both bound to the Role.
+	cluster.Spec.HeadGroupSpec.Template.Spec.ServiceAccountName = "my-service-account"
+	rb, err = BuildRoleBinding(cluster)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"my-service-account", "raycluster-sample"}, []string{rb.Subjects[0].Name, rb.Subjects[1].Name})
+
+	// The Role only grants the permissions of the autoscaler if it's enabled.
+	role, err := BuildRole(cluster)
+	assert.Nil(t, err)
+	assert.Empty(t, role.Rules)
+	cluster.Spec.EnableInTreeAutoscaling = ptr.To(true)
+	role, err = BuildRole(cluster)
+	assert.Nil(t, err)
+	assert.Len(t, role.Rules, 2)
+}
@@ -32,6 +32,9 @@ func BuildRouteForHeadService(cluster rayv1.RayCluster) (*routev1.Route, error)
 	if port, ok := servicePorts["dashboard"]; ok {
 		dashboardPort = int(port)
 	}
+	if IsOAuth2ProxyEnabled(cluster) {
+		dashboardPort = utils.DefaultOAuth2ProxyPort
+	}
 
 	weight := int32(100)
 
@@ -0,0 +1,138 @@
+package common
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// defaultRoutePrefix is the route prefix of the Serve applications that don't set one.
+const defaultRoutePrefix = "/"
+
+// ValidateServeConfigV2 checks that the route prefixes of the Serve applications are unique, that the gRPC servicer
+// functions aren't listed more than once, and that the HTTP and gRPC ports of the proxies don't clash with each
+// other or with the ports of the Ray system processes of the head. All the violations are returned in one error.
+// A config that can't be parsed is not checked, since it may only become valid YAML once its variables are
+// substituted.
+func ValidateServeConfigV2(spec *rayv1.RayClusterSpec, serveConfigV2 string) error {
+	serveConfig := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(serveConfigV2), &serveConfig); err != nil {
+		return nil
+	}
+
+	var violations []string
+	violations = append(violations, duplicateRoutePrefixViolations(serveConfig)...)
+	violations = append(violations, duplicateGrpcServicerFunctionViolations(serveConfig)...)
+	violations = append(violations, servePortViolations(spec, serveConfig)...)
+	if len(violations) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s", strings.Join(violations, "; "))
+}
+
+func duplicateRoutePrefixViolations(serveConfig map[string]interface{}) []string {
+	appNamesByPrefix := map[string][]string{}
+	apps, _ := serveConfig["applications"].([]interface{})
+	for _, a := range apps {
+		app, ok := a.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		appName, _ := app["name"].(string)
+		prefix := defaultRoutePrefix
+		if value, ok := app["route_prefix"]; ok {
+			// The applications whose route prefix is null aren't exposed over HTTP.
+			if prefix, ok = value.(string); !ok {
+				continue
+			}
+		}
+		appNamesByPrefix[prefix] = append(appNamesByPrefix[prefix], appName)
+	}
+
+	var violations []string
+	for prefix, appNames := range appNamesByPrefix {
+		if len(appNames) > 1 {
+			violations = append(violations, fmt.Sprintf("the route_prefix %s is used by the applications %s", prefix, strings.Join(appNames, ", ")))
+		}
+	}
+	sort.Strings(violations)
+	return violations
+}
+
+func duplicateGrpcServicerFunctionViolations(serveConfig map[string]interface{}) []string {
+	grpcOptions, _ := serveConfig["grpc_options"].(map[string]interface{})
+	functions, _ := grpcOptions["grpc_servicer_functions"].([]interface{})
+	counts := map[string]int{}
+	for _, f := range functions {
+		if function, ok := f.(string); ok {
+			counts[function]++
+		}
+	}
+
+	var violations []string
+	for function, count := range counts {
+		if count > 1 {
+			violations = append(violations, fmt.Sprintf("the gRPC servicer function %s is listed %d times", function, count))
+		}
+	}
+	sort.Strings(violations)
+	return violations
+}
+
+func servePortViolations(spec *rayv1.RayClusterSpec, serveConfig map[string]interface{}) []string {
+	systemPorts := map[int]string{}
+	for param, defaultPort := range map[string]int{
+		"port":                        utils.DefaultGcsServerPort,
+		"dashboard-port":              utils.DefaultDashboardPort,
+		"ray-client-server-port":      utils.DefaultClientPort,
+		"metrics-export-port":         utils.DefaultMetricsPort,
+		"dashboard-agent-listen-port": utils.DefaultDashboardAgentListenPort,
+	} {
+		port := defaultPort
+		if value, ok := spec.HeadGroupSpec.RayStartParams[param]; ok {
+			if parsed, err := strconv.Atoi(value); err == nil {
+				port = parsed
+			}
+		}
+		systemPorts[port] = param
+	}
+
+	var violations []string
+	usedPorts := map[int]string{}
+	for _, options := range []string{"http_options", "grpc_options"} {
+		optionsMap, _ := serveConfig[options].(map[string]interface{})
+		port, ok := parsePort(optionsMap["port"])
+		if !ok {
+			continue
+		}
+		if param, ok := systemPorts[port]; ok {
+			violations = append(violations, fmt.Sprintf("the port %d of %s clashes with the %s rayStartParam of the head", port, options, param))
+		}
+		if other, ok := usedPorts[port]; ok {
+			violations = append(violations, fmt.Sprintf("the port %d of %s clashes with the one of %s", port, options, other))
+		}
+		usedPorts[port] = options
+	}
+	return violations
+}
+
+// parsePort returns the port of a value of the Serve config, which may be a number or a string.
+func parsePort(value interface{}) (int, bool) {
+	switch port := value.(type) {
+	case int64:
+		return int(port), true
+	case float64:
+		return int(port), true
+	case string:
+		parsed, err := strconv.Atoi(port)
+		return parsed, err == nil
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,89 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestValidateServeConfigV2(t *testing.T) {
+	spec := &rayv1.RayClusterSpec{HeadGroupSpec: rayv1.HeadGroupSpec{RayStartParams: map[string]string{"dashboard-port": "8000"}}}
+
+	tests := map[string]struct {
+		serveConfigV2 string
+		expectedError string
+	}{
+		"valid config": {
+			serveConfigV2: `
+http_options:
+  port: 8001
+grpc_options:
+  port: 9000
+  grpc_servicer_functions:
+  - protos_pb2_grpc.add_UserDefinedServiceServicer_to_server
+applications:
+- name: app1
+  route_prefix: /app1
+- name: app2
+  route_prefix: /app2
+- name: app3
+  route_prefix: null
+- name: app4
+  route_prefix: null
+`,
+		},
+		"unparsable config": {
+			serveConfigV2: "applications: [",
+		},
+		"default route prefixes": {
+			serveConfigV2: `
+applications:
+- name: app1
+- name: app2
+  route_prefix: /
+`,
+			expectedError: "the route_prefix / is used by the applications app1, app2",
+		},
+		"all violations": {
+			serveConfigV2: `
+http_options:
+  port: 6379
+grpc_options:
+  port: "6379"
+  grpc_servicer_functions:
+  - protos_pb2_grpc.add_UserDefinedServiceServicer_to_server
+  - protos_pb2_grpc.add_UserDefinedServiceServicer_to_server
+applications:
+- name: app1
+  route_prefix: /app
+- name: app2
+  route_prefix: /app
+`,
+			expectedError: "the route_prefix /app is used by the applications app1, app2; " +
+				"the gRPC servicer function protos_pb2_grpc.add_UserDefinedServiceServicer_to_server is listed 2 times; " +
+				"the port 6379 of http_options clashes with the port rayStartParam of the head; " +
+				"the port 6379 of grpc_options clashes with the port rayStartParam of the head; " +
+				"the port 6379 of grpc_options clashes with the one of http_options",
+		},
+		"overridden system port": {
+			serveConfigV2: `
+http_options:
+  port: 8000
+`,
+			expectedError: "the port 8000 of http_options clashes with the dashboard-port rayStartParam of the head",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateServeConfigV2(spec, tc.serveConfigV2)
+			if tc.expectedError == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package common
+
+import (
+	"net"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// maxEndpointsPerSlice is the maximum number of endpoints accepted by the API server in an EndpointSlice.
+const maxEndpointsPerSlice = 1000
+
+// ServeEndpointSelector returns the labels of the Pods that the serve service of the RayService sends the traffic to:
+// the maintenance responder while the RayService is in maintenance, and the serve Pods of the RayCluster otherwise.
+func ServeEndpointSelector(rayService *rayv1.RayService, rayCluster *rayv1.RayCluster) map[string]string {
+	if rayService.Spec.MaintenanceMode {
+		return MaintenanceResponderSelector(rayService)
+	}
+	return map[string]string{
+		utils.RayClusterLabelKey:               rayCluster.Name,
+		utils.RayClusterServingServiceLabelKey: utils.EnableRayClusterServingServiceTrue,
+	}
+}
+
+// BuildServeEndpointSlice builds the EndpointSlice of the serve service of a RayService whose topology routing is
+// Managed. Every endpoint is hinted to the zone of its node, which is looked up in nodeZones, so that kube-proxy keeps
+// the requests in their zone. If the zone of a node is unknown, no endpoint is hinted, since kube-proxy ignores the
+// hints unless all the endpoints have one.
+func BuildServeEndpointSlice(rayService *rayv1.RayService, service *corev1.Service, pods []corev1.Pod, nodeZones map[string]string) *discoveryv1.EndpointSlice {
+	addressType := discoveryv1.AddressTypeIPv4
+	if len(service.Spec.IPFamilies) > 0 && service.Spec.IPFamilies[0] == corev1.IPv6Protocol {
+		addressType = discoveryv1.AddressTypeIPv6
+	}
+
+	ports := make([]discoveryv1.EndpointPort, 0, len(service.Spec.Ports))
+	for _, servicePort := range service.Spec.Ports {
+		// The named target ports aren't resolved, since the Pods selected by the serve service name their ports alike.
+		port := servicePort.Port
+		if servicePort.TargetPort.Type == intstr.Int && servicePort.TargetPort.IntVal != 0 {
+			port = servicePort.TargetPort.IntVal
+		}
+		protocol := servicePort.Protocol
+		if protocol == "" {
+			protocol = corev1.ProtocolTCP
+		}
+		ports = append(ports, discoveryv1.EndpointPort{
+			Name:        ptr.To(servicePort.Name),
+			Port:        ptr.To(port),
+			Protocol:    ptr.To(protocol),
+			AppProtocol: servicePort.AppProtocol,
+		})
+	}
+
+	sortedPods := make([]*corev1.Pod, 0, len(pods))
+	for i := range pods {
+		sortedPods = append(sortedPods, &pods[i])
+	}
+	sort.Slice(sortedPods, func(i, j int) bool { return sortedPods[i].Name < sortedPods[j].Name })
+
+	endpoints := []discoveryv1.Endpoint{}
+	allZonesKnown := true
+	for _, pod := range sortedPods {
+		address := podAddress(pod, addressType)
+		if address == "" {
+			continue
+		}
+		if len(endpoints) == maxEndpointsPerSlice {
+			break
+		}
+		isRunningAndReady := utils.IsRunningAndReady(pod)
+		endpoint := discoveryv1.Endpoint{
+			Addresses: []string{address},
+			Conditions: discoveryv1.EndpointConditions{
+				Ready:       ptr.To(isRunningAndReady && pod.DeletionTimestamp == nil),
+				Serving:     ptr.To(isRunningAndReady),
+				Terminating: ptr.To(pod.DeletionTimestamp != nil),
+			},
+			TargetRef: &corev1.ObjectReference{
+				Kind:      "Pod",
+				Namespace: pod.Namespace,
+				Name:      pod.Name,
+				UID:       pod.UID,
+			},
+		}
+		if pod.Spec.NodeName != "" {
+			endpoint.NodeName = ptr.To(pod.Spec.NodeName)
+		}
+		if zone := nodeZones[pod.Spec.NodeName]; zone != "" {
+			endpoint.Zone = ptr.To(zone)
+		} else {
+			allZonesKnown = false
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+	if allZonesKnown {
+		for i := range endpoints {
+			endpoints[i].Hints = &discoveryv1.EndpointHints{ForZones: []discoveryv1.ForZone{{Name: *endpoints[i].Zone}}}
+		}
+	}
+
+	return &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      service.Name,
+			Namespace: service.Namespace,
+			Labels: map[string]string{
+				discoveryv1.LabelServiceName:          service.Name,
+				discoveryv1.LabelManagedBy:            utils.ComponentName,
+				utils.KubernetesCreatedByLabelKey:     utils.ComponentName,
+				utils.RayOriginatedFromCRNameLabelKey: rayService.Name,
+				utils.RayOriginatedFromCRDLabelKey:    utils.RayOriginatedFromCRDLabelValue(utils.RayServiceCRD),
+			},
+		},
+		AddressType: addressType,
+		Endpoints:   endpoints,
+		Ports:       ports,
+	}
+}
+
+// podAddress returns the IP of the Pod of the address type, or an empty string if the Pod has none.
+func podAddress(pod *corev1.Pod, addressType discoveryv1.AddressType) string {
+	podIPs := []string{pod.Status.PodIP}
+	for _, podIP := range pod.Status.PodIPs {
+		podIPs = append(podIPs, podIP.IP)
+	}
+	for _, podIP := range podIPs {
+		ip := net.ParseIP(podIP)
+		if ip == nil {
+			continue
+		}
+		if (ip.To4() != nil) == (addressType == discoveryv1.AddressTypeIPv4) {
+			return podIP
+		}
+	}
+	return ""
+}
@@ -0,0 +1,101 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestServeEndpointSelector(t *testing.T) {
+	rayService := &rayv1.RayService{ObjectMeta: metav1.ObjectMeta{Name: "rayservice", Namespace: "default"}}
+	rayCluster := &rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Name: "raycluster", Namespace: "default"}}
+	assert.Equal(t, map[string]string{
+		utils.RayClusterLabelKey:               "raycluster",
+		utils.RayClusterServingServiceLabelKey: utils.EnableRayClusterServingServiceTrue,
+	}, ServeEndpointSelector(rayService, rayCluster))
+
+	rayService.Spec.MaintenanceMode = true
+	assert.Equal(t, MaintenanceResponderSelector(rayService), ServeEndpointSelector(rayService, rayCluster))
+}
+
+func TestBuildServeEndpointSlice(t *testing.T) {
+	rayService := &rayv1.RayService{ObjectMeta: metav1.ObjectMeta{Name: "rayservice", Namespace: "default"}}
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "rayservice-serve-svc", Namespace: "default"},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{Name: utils.ServingPortName, Port: 8000},
+				{Name: "grpc", Port: 9000, TargetPort: intstr.FromInt32(9001), Protocol: corev1.ProtocolTCP},
+			},
+		},
+	}
+	newPod := func(name, nodeName, podIP string, ready bool) corev1.Pod {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		return corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       corev1.PodSpec{NodeName: nodeName},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				PodIP:      podIP,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+			},
+		}
+	}
+	pods := []corev1.Pod{
+		newPod("worker-b", "node-b", "10.0.0.2", false),
+		newPod("head", "node-a", "10.0.0.1", true),
+		// The Pods without an IP aren't endpoints.
+		newPod("worker-pending", "", "", false),
+	}
+	nodeZones := map[string]string{"node-a": "zone-a", "node-b": "zone-b"}
+
+	slice := BuildServeEndpointSlice(rayService, service, pods, nodeZones)
+	assert.Equal(t, service.Name, slice.Name)
+	assert.Equal(t, service.Name, slice.Labels[discoveryv1.LabelServiceName])
+	assert.Equal(t, utils.ComponentName, slice.Labels[discoveryv1.LabelManagedBy])
+	assert.Equal(t, utils.ComponentName, slice.Labels[utils.KubernetesCreatedByLabelKey])
+	assert.Equal(t, discoveryv1.AddressTypeIPv4, slice.AddressType)
+	assert.Equal(t, []discoveryv1.EndpointPort{
+		{Name: ptr.To(utils.ServingPortName), Port: ptr.To[int32](8000), Protocol: ptr.To(corev1.ProtocolTCP)},
+		{Name: ptr.To("grpc"), Port: ptr.To[int32](9001), Protocol: ptr.To(corev1.ProtocolTCP)},
+	}, slice.Ports)
+
+	// The endpoints are sorted by the names of the Pods, and hinted to the zones of their nodes.
+	assert.Len(t, slice.Endpoints, 2)
+	head := slice.Endpoints[0]
+	assert.Equal(t, []string{"10.0.0.1"}, head.Addresses)
+	assert.True(t, *head.Conditions.Ready)
+	assert.Equal(t, "node-a", *head.NodeName)
+	assert.Equal(t, "zone-a", *head.Zone)
+	assert.Equal(t, &discoveryv1.EndpointHints{ForZones: []discoveryv1.ForZone{{Name: "zone-a"}}}, head.Hints)
+	assert.Equal(t, "head", head.TargetRef.Name)
+	worker := slice.Endpoints[1]
+	assert.False(t, *worker.Conditions.Ready)
+	assert.Equal(t, &discoveryv1.EndpointHints{ForZones: []discoveryv1.ForZone{{Name: "zone-b"}}}, worker.Hints)
+
+	// No endpoint is hinted if the zone of a node is unknown.
+	slice = BuildServeEndpointSlice(rayService, service, pods, map[string]string{"node-a": "zone-a"})
+	assert.Len(t, slice.Endpoints, 2)
+	for _, endpoint := range slice.Endpoints {
+		assert.Nil(t, endpoint.Hints)
+	}
+
+	// The IPv6 addresses of the dual-stack Pods are used if the primary IP family of the serve service is IPv6.
+	service.Spec.IPFamilies = []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol}
+	pods[1].Status.PodIPs = []corev1.PodIP{{IP: "10.0.0.1"}, {IP: "fd00::1"}}
+	slice = BuildServeEndpointSlice(rayService, service, pods, nodeZones)
+	assert.Equal(t, discoveryv1.AddressTypeIPv6, slice.AddressType)
+	assert.Len(t, slice.Endpoints, 1)
+	assert.Equal(t, []string{"fd00::1"}, slice.Endpoints[0].Addresses)
+}
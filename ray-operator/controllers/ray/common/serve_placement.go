@@ -0,0 +1,115 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+const (
+	// workerGroupResourcePrefix is the prefix of the custom Ray resource advertised by a worker group that Serve
+	// applications are placed on.
+	workerGroupResourcePrefix = "worker-group-"
+	// workerGroupResourceCapacity is the amount of the worker group resource of each worker node. It's large enough
+	// not to limit the number of replicas of a node, which is limited by its other resources.
+	workerGroupResourceCapacity = 10000
+	// workerGroupResourceRequest is the amount of the worker group resource requested by each replica, which is the
+	// smallest amount of a Ray resource.
+	workerGroupResourceRequest = 0.0001
+)
+
+// WorkerGroupResourceName returns the name of the custom Ray resource advertised by the worker group.
+func WorkerGroupResourceName(groupName string) string {
+	return workerGroupResourcePrefix + groupName
+}
+
+// ValidateServeApplicationWorkerGroups checks that the worker groups that the Serve applications are placed on exist
+// and that their custom resources can be parsed.
+func ValidateServeApplicationWorkerGroups(spec *rayv1.RayClusterSpec, appWorkerGroups map[string]string) error {
+	appNames := make([]string, 0, len(appWorkerGroups))
+	for appName := range appWorkerGroups {
+		appNames = append(appNames, appName)
+	}
+	sort.Strings(appNames)
+	for _, appName := range appNames {
+		groupName := appWorkerGroups[appName]
+		index := slices.IndexFunc(spec.WorkerGroupSpecs, func(worker rayv1.WorkerGroupSpec) bool {
+			return worker.GroupName == groupName
+		})
+		if index < 0 {
+			return fmt.Errorf("the worker group %s of the Serve application %s doesn't exist", groupName, appName)
+		}
+		if _, err := getResourcesMap(spec.WorkerGroupSpecs[index].RayStartParams); err != nil {
+			return fmt.Errorf("the resources rayStartParam of the worker group %s is invalid: %w", groupName, err)
+		}
+	}
+	return nil
+}
+
+// AddWorkerGroupResources adds the custom Ray resource of each worker group that Serve applications are placed on to
+// the resources rayStartParam of the group. The groups whose resources can't be parsed are skipped, since they're
+// rejected by ValidateServeApplicationWorkerGroups.
+func AddWorkerGroupResources(spec *rayv1.RayClusterSpec, appWorkerGroups map[string]string) {
+	placedGroups := map[string]bool{}
+	for _, groupName := range appWorkerGroups {
+		placedGroups[groupName] = true
+	}
+	for i := range spec.WorkerGroupSpecs {
+		worker := &spec.WorkerGroupSpecs[i]
+		if !placedGroups[worker.GroupName] {
+			continue
+		}
+		resources, err := getResourcesMap(worker.RayStartParams)
+		if err != nil {
+			continue
+		}
+		resources[WorkerGroupResourceName(worker.GroupName)] = workerGroupResourceCapacity
+		resourcesStr, err := json.Marshal(resources)
+		if err != nil {
+			continue
+		}
+		if worker.RayStartParams == nil {
+			worker.RayStartParams = map[string]string{}
+		}
+		worker.RayStartParams["resources"] = fmt.Sprintf("'%s'", resourcesStr)
+	}
+}
+
+// getOrCreateMap returns the map of the key of the parent, which is created if it doesn't exist yet.
+func getOrCreateMap(parent map[string]interface{}, key string) map[string]interface{} {
+	child, ok := parent[key].(map[string]interface{})
+	if !ok {
+		child = map[string]interface{}{}
+		parent[key] = child
+	}
+	return child
+}
+
+// ApplyServeApplicationWorkerGroups makes the deployments of the Serve applications of the config request the custom
+// Ray resource of the worker groups that the applications are placed on, in their ray_actor_options.
+func ApplyServeApplicationWorkerGroups(serveConfig map[string]interface{}, appWorkerGroups map[string]string) {
+	apps, _ := serveConfig["applications"].([]interface{})
+	for _, a := range apps {
+		app, ok := a.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		appName, _ := app["name"].(string)
+		groupName, ok := appWorkerGroups[appName]
+		if !ok {
+			continue
+		}
+		deployments, _ := app["deployments"].([]interface{})
+		for _, d := range deployments {
+			deployment, ok := d.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			resources := getOrCreateMap(getOrCreateMap(deployment, "ray_actor_options"), "resources")
+			resources[WorkerGroupResourceName(groupName)] = workerGroupResourceRequest
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestValidateServeApplicationWorkerGroups(t *testing.T) {
+	spec := &rayv1.RayClusterSpec{WorkerGroupSpecs: []rayv1.WorkerGroupSpec{
+		{GroupName: "gpu-group"},
+		{GroupName: "cpu-group", RayStartParams: map[string]string{"resources": "not json"}},
+	}}
+	require.NoError(t, ValidateServeApplicationWorkerGroups(spec, nil))
+	require.NoError(t, ValidateServeApplicationWorkerGroups(spec, map[string]string{"llm": "gpu-group"}))
+	require.EqualError(t, ValidateServeApplicationWorkerGroups(spec, map[string]string{"llm": "tpu-group"}),
+		"the worker group tpu-group of the Serve application llm doesn't exist")
+	require.ErrorContains(t, ValidateServeApplicationWorkerGroups(spec, map[string]string{"classifier": "cpu-group"}),
+		"the resources rayStartParam of the worker group cpu-group is invalid")
+}
+
+func TestAddWorkerGroupResources(t *testing.T) {
+	spec := &rayv1.RayClusterSpec{WorkerGroupSpecs: []rayv1.WorkerGroupSpec{
+		{GroupName: "gpu-group", RayStartParams: map[string]string{"resources": `'{"accelerator": 1}'`}},
+		{GroupName: "cpu-group"},
+		{GroupName: "other-group", RayStartParams: map[string]string{}},
+	}}
+	AddWorkerGroupResources(spec, map[string]string{"llm": "gpu-group", "classifier": "cpu-group"})
+
+	assert.Equal(t, `'{"accelerator":1,"worker-group-gpu-group":10000}'`, spec.WorkerGroupSpecs[0].RayStartParams["resources"])
+	assert.Equal(t, `'{"worker-group-cpu-group":10000}'`, spec.WorkerGroupSpecs[1].RayStartParams["resources"])
+	assert.Empty(t, spec.WorkerGroupSpecs[2].RayStartParams)
+}
+
+func TestApplyServeApplicationWorkerGroups(t *testing.T) {
+	serveConfigV2 := `
+applications:
+- name: llm
+  import_path: llm:app
+  deployments:
+  - name: LLM
+    ray_actor_options:
+      num_gpus: 1
+  - name: Router
+- name: classifier
+  import_path: classifier:app
+  deployments:
+  - name: Classifier
+`
+	serveConfig := map[string]interface{}{}
+	require.NoError(t, yaml.Unmarshal([]byte(serveConfigV2), &serveConfig))
+	ApplyServeApplicationWorkerGroups(serveConfig, map[string]string{"llm": "gpu-group"})
+
+	apps := serveConfig["applications"].([]interface{})
+	deployments := apps[0].(map[string]interface{})["deployments"].([]interface{})
+	assert.Equal(t, map[string]interface{}{
+		"num_gpus":  int64(1),
+		"resources": map[string]interface{}{"worker-group-gpu-group": 0.0001},
+	}, deployments[0].(map[string]interface{})["ray_actor_options"])
+	assert.Equal(t, map[string]interface{}{
+		"resources": map[string]interface{}{"worker-group-gpu-group": 0.0001},
+	}, deployments[1].(map[string]interface{})["ray_actor_options"])
+	// The applications that aren't placed on a worker group aren't modified.
+	classifier := apps[1].(map[string]interface{})["deployments"].([]interface{})[0].(map[string]interface{})
+	assert.NotContains(t, classifier, "ray_actor_options")
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
@@ -72,10 +75,18 @@ func BuildServiceForHeadPod(ctx context.Context, cluster rayv1.RayCluster, label
 	// `portsInt` is a map of port names to port numbers, while `ports` is a list of ServicePort objects
 	portsInt := getServicePorts(cluster)
 	ports := []corev1.ServicePort{}
+	servicePorts := cluster.Spec.HeadGroupSpec.ServicePorts
 	for name, port := range portsInt {
+		if servicePorts != nil && slices.Contains(servicePorts.Exclude, name) {
+			continue
+		}
 		svcPort := corev1.ServicePort{Name: name, Port: port, AppProtocol: &defaultAppProtocol}
 		ports = append(ports, svcPort)
 	}
+	if servicePorts != nil {
+		ports = append(ports, servicePorts.Additional...)
+	}
+	ports = append(ports, getHeadContainerServicePorts(cluster, false)...)
 	if cluster.Spec.HeadGroupSpec.HeadService != nil {
 		// Use the provided "custom" HeadService.
 		// Deep copy the HeadService to avoid modifying the original object
@@ -107,6 +118,7 @@ func BuildServiceForHeadPod(ctx context.Context, cluster rayv1.RayCluster, label
 		setNameforUserProvidedService(ctx, headService, defaultName)
 		setNamespaceforUserProvidedService(ctx, headService, defaultNamespace)
 		setServiceTypeForUserProvidedService(ctx, headService, defaultType)
+		setIPFamilies(headService, cluster.Spec)
 
 		return headService, nil
 	}
@@ -124,6 +136,7 @@ func BuildServiceForHeadPod(ctx context.Context, cluster rayv1.RayCluster, label
 			Type:     defaultType,
 		},
 	}
+	setIPFamilies(headService, cluster.Spec)
 	if !getEnableRayHeadClusterIPService() && (defaultType == "" || defaultType == corev1.ServiceTypeClusterIP) {
 		// Make the head service headless by default, because a RayCluster should have at most one head Pod.
 		headService.Spec.ClusterIP = corev1.ClusterIPNone
@@ -141,6 +154,35 @@ func BuildServiceForHeadPod(ctx context.Context, cluster rayv1.RayCluster, label
 	return headService, nil
 }
 
+// ValidateExistingHeadService checks that the Service referenced by `existingHeadServiceName` can be used as the head
+// service of the RayCluster: its selector must match the labels of the head Pod, and it must expose the GCS server port
+// that the worker Pods connect to.
+func ValidateExistingHeadService(cluster rayv1.RayCluster, service *corev1.Service) error {
+	if len(service.Spec.Selector) == 0 {
+		return fmt.Errorf("the existing head service %s has no selector", service.Name)
+	}
+	headPodLabels := labelPod(rayv1.HeadNode, cluster.Name, utils.RayNodeHeadGroupLabelValue, cluster.Spec.HeadGroupSpec.Template.ObjectMeta.Labels)
+	for k, v := range service.Spec.Selector {
+		if headPodLabels[k] != v {
+			return fmt.Errorf("the selector %s=%s of the existing head service %s doesn't match the head Pod", k, v, service.Name)
+		}
+	}
+
+	gcsPort, err := strconv.ParseInt(GetHeadPort(cluster.Spec.HeadGroupSpec.RayStartParams), 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid GCS server port: %w", err)
+	}
+	for _, port := range service.Spec.Ports {
+		if port.Name == utils.GcsServerPortName || port.TargetPort.StrVal == utils.GcsServerPortName {
+			return nil
+		}
+		if port.TargetPort.IntVal == int32(gcsPort) || (port.TargetPort.IntVal == 0 && port.TargetPort.StrVal == "" && port.Port == int32(gcsPort)) {
+			return nil
+		}
+	}
+	return fmt.Errorf("the existing head service %s doesn't expose the %s port %d", service.Name, utils.GcsServerPortName, gcsPort)
+}
+
 // BuildHeadServiceForRayService Builds the service for a pod. Currently, there is only one service that allows
 // the worker nodes to connect to the head node.
 // RayService controller updates the service whenever a new RayCluster serves the traffic.
@@ -255,11 +297,15 @@ func BuildServeService(ctx context.Context, rayService rayv1.RayService, rayClus
 				}
 				serveService.Spec.Ports = ports
 			}
+			serveService.Spec.Ports = append(serveService.Spec.Ports, getHeadContainerServicePorts(rayCluster, true)...)
 
 			setLabelsforUserProvidedService(serveService, labels)
 			setNameforUserProvidedService(ctx, serveService, defaultName)
 			setNamespaceforUserProvidedService(ctx, serveService, defaultNamespace)
 			setServiceTypeForUserProvidedService(ctx, serveService, defaultType)
+			setServeSessionAffinity(serveService, rayService.Spec.ServeSessionAffinity)
+			setServeTopologyRouting(serveService, rayService.Spec.ServeTopologyRouting)
+			setIPFamilies(serveService, rayCluster.Spec)
 
 			return serveService, nil
 		}
@@ -271,6 +317,7 @@ func BuildServeService(ctx context.Context, rayService rayv1.RayService, rayClus
 			"otherwise, the Kubernetes service for Ray Serve will not be created.")
 	}
 
+	ports = append(ports, getHeadContainerServicePorts(rayCluster, true)...)
 	serveService := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      defaultName,
@@ -283,10 +330,92 @@ func BuildServeService(ctx context.Context, rayService rayv1.RayService, rayClus
 			Type:     defaultType,
 		},
 	}
+	if isRayService {
+		setServeSessionAffinity(serveService, rayService.Spec.ServeSessionAffinity)
+		setServeTopologyRouting(serveService, rayService.Spec.ServeTopologyRouting)
+	}
+	setIPFamilies(serveService, rayCluster.Spec)
 
 	return serveService, nil
 }
 
+// BuildPreviewServeServiceForRayService builds the ClusterIP service that points at the pending RayCluster during an
+// upgrade. It selects the same Pods as the serve service would after the pending RayCluster is promoted, but the type
+// and the addresses of a user-provided serve service are not copied so that an upgrade never provisions a load balancer.
+func BuildPreviewServeServiceForRayService(ctx context.Context, rayService rayv1.RayService, rayCluster rayv1.RayCluster) (*corev1.Service, error) {
+	serveService, err := BuildServeServiceForRayService(ctx, rayService, rayCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utils.GeneratePreviewServeServiceName(rayService.Name),
+			Namespace: rayService.Namespace,
+			Labels: map[string]string{
+				utils.RayOriginatedFromCRNameLabelKey: rayService.Name,
+				utils.RayOriginatedFromCRDLabelKey:    utils.RayOriginatedFromCRDLabelValue(utils.RayServiceCRD),
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			Selector:              serveService.Spec.Selector,
+			Ports:                 serveService.Spec.Ports,
+			Type:                  corev1.ServiceTypeClusterIP,
+			SessionAffinity:       serveService.Spec.SessionAffinity,
+			SessionAffinityConfig: serveService.Spec.SessionAffinityConfig,
+			IPFamilyPolicy:        serveService.Spec.IPFamilyPolicy,
+			IPFamilies:            serveService.Spec.IPFamilies,
+		},
+	}, nil
+}
+
+// setServeSessionAffinity sets the ClientIP session affinity of the serve service. The Cookie session affinity is
+// implemented by the HTTPRoute built by BuildServeHTTPRouteForRayService instead.
+func setServeSessionAffinity(service *corev1.Service, affinity *rayv1.ServeSessionAffinity) {
+	if affinity == nil || affinity.Type != rayv1.ClientIPServeSessionAffinity {
+		return
+	}
+	service.Spec.SessionAffinity = corev1.ServiceAffinityClientIP
+	service.Spec.SessionAffinityConfig = &corev1.SessionAffinityConfig{
+		ClientIP: &corev1.ClientIPConfig{TimeoutSeconds: ptr.To(GetServeSessionAffinityTimeoutSeconds(affinity))},
+	}
+}
+
+// setServeTopologyRouting sets the topology mode annotation of the serve service to Auto, unless it is set in the
+// template of the serve service. kube-proxy only follows the zone hints of the EndpointSlices of the Services with the
+// annotation, including the ones of the EndpointSlice managed by KubeRay.
+func setServeTopologyRouting(service *corev1.Service, routing *rayv1.ServeTopologyRouting) {
+	if routing == nil {
+		return
+	}
+	if _, ok := service.Annotations[corev1.AnnotationTopologyMode]; ok {
+		return
+	}
+	if service.Annotations == nil {
+		service.Annotations = map[string]string{}
+	}
+	service.Annotations[corev1.AnnotationTopologyMode] = string(rayv1.AutoServeTopologyRouting)
+}
+
+// GetServeSessionAffinityTimeoutSeconds returns the maximum duration of a session.
+func GetServeSessionAffinityTimeoutSeconds(affinity *rayv1.ServeSessionAffinity) int32 {
+	if affinity.TimeoutSeconds != nil {
+		return *affinity.TimeoutSeconds
+	}
+	return utils.DefaultServeSessionAffinityTimeoutSeconds
+}
+
+// setIPFamilies sets the IP family policy and the IP families of the RayCluster on a generated Service, unless they
+// are set in the template of the Service.
+func setIPFamilies(service *corev1.Service, spec rayv1.RayClusterSpec) {
+	if service.Spec.IPFamilyPolicy == nil && spec.IPFamilyPolicy != nil {
+		service.Spec.IPFamilyPolicy = ptr.To(*spec.IPFamilyPolicy)
+	}
+	if len(service.Spec.IPFamilies) == 0 && len(spec.IPFamilies) > 0 {
+		service.Spec.IPFamilies = slices.Clone(spec.IPFamilies)
+	}
+}
+
 // BuildHeadlessService builds the headless service for workers in multi-host worker groups to communicate
 func BuildHeadlessServiceForRayCluster(rayCluster rayv1.RayCluster) *corev1.Service {
 	name := rayCluster.Name + utils.DashSymbol + utils.HeadlessServiceSuffix
@@ -315,6 +444,7 @@ func BuildHeadlessServiceForRayCluster(rayCluster rayv1.RayCluster) *corev1.Serv
 			PublishNotReadyAddresses: true,
 		},
 	}
+	setIPFamilies(headlessService, rayCluster.Spec)
 
 	return headlessService
 }
@@ -386,9 +516,106 @@ func getServicePorts(cluster rayv1.RayCluster) map[string]int32 {
 		ports[utils.MetricsPortName] = utils.DefaultMetricsPort
 	}
 
+	// The dashboard is only exposed through oauth2-proxy, so that the authentication can't be bypassed through the
+	// head service.
+	if IsOAuth2ProxyEnabled(cluster) {
+		delete(ports, utils.DashboardPortName)
+		ports[utils.OAuth2ProxyPortName] = utils.DefaultOAuth2ProxyPort
+	}
+
+	return ports
+}
+
+// getHeadContainerServicePorts returns the service ports of the container ports listed in
+// `headGroupSpec.servicePorts.containerPorts`. If serveOnly is true, only the ports exposed on the serve service are
+// returned. The ports that can't be found in the head Pod are skipped, since they are rejected by the validation.
+func getHeadContainerServicePorts(cluster rayv1.RayCluster, serveOnly bool) []corev1.ServicePort {
+	servicePorts := cluster.Spec.HeadGroupSpec.ServicePorts
+	if servicePorts == nil {
+		return nil
+	}
+	var ports []corev1.ServicePort
+	for _, ref := range servicePorts.ContainerPorts {
+		if serveOnly && (ref.ExposeOnServeService == nil || !*ref.ExposeOnServeService) {
+			continue
+		}
+		if containerPort := findHeadContainerPort(cluster, ref); containerPort != nil {
+			ports = append(ports, corev1.ServicePort{Name: containerPort.Name, Port: containerPort.ContainerPort, Protocol: containerPort.Protocol})
+		}
+	}
 	return ports
 }
 
+// findHeadContainerPort returns the port of a container of the head Pod other than the Ray container, or nil.
+func findHeadContainerPort(cluster rayv1.RayCluster, ref rayv1.HeadContainerServicePort) *corev1.ContainerPort {
+	for i, container := range cluster.Spec.HeadGroupSpec.Template.Spec.Containers {
+		if i == utils.RayContainerIndex || container.Name != ref.ContainerName {
+			continue
+		}
+		for j := range container.Ports {
+			if container.Ports[j].Name == ref.PortName {
+				return &container.Ports[j]
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateHeadContainerServicePorts checks that the container ports listed in `headGroupSpec.servicePorts.containerPorts`
+// are declared by a container of the head Pod other than the Ray container, and that their names and numbers don't
+// clash with each other or with the other ports of the head service.
+func ValidateHeadContainerServicePorts(cluster rayv1.RayCluster) error {
+	servicePorts := cluster.Spec.HeadGroupSpec.ServicePorts
+	if servicePorts == nil || len(servicePorts.ContainerPorts) == 0 {
+		return nil
+	}
+
+	names := map[string]bool{}
+	numbers := map[string]string{}
+	addPort := func(name string, port int32, protocol corev1.Protocol) {
+		if protocol == "" {
+			protocol = corev1.ProtocolTCP
+		}
+		names[name] = true
+		numbers[fmt.Sprintf("%d/%s", port, protocol)] = name
+	}
+	for name, port := range getServicePorts(cluster) {
+		if !slices.Contains(servicePorts.Exclude, name) {
+			addPort(name, port, corev1.ProtocolTCP)
+		}
+	}
+	for _, port := range servicePorts.Additional {
+		addPort(port.Name, port.Port, port.Protocol)
+	}
+
+	for _, ref := range servicePorts.ContainerPorts {
+		containerPort := findHeadContainerPort(cluster, ref)
+		if containerPort == nil {
+			return fmt.Errorf("the container port %s of the head service isn't declared by the container %s of the head Pod, which can't be the Ray container", ref.PortName, ref.ContainerName)
+		}
+		if names[containerPort.Name] {
+			return fmt.Errorf("the container port %s of the head service clashes with another port of the head service with the same name", containerPort.Name)
+		}
+		protocol := containerPort.Protocol
+		if protocol == "" {
+			protocol = corev1.ProtocolTCP
+		}
+		if other, ok := numbers[fmt.Sprintf("%d/%s", containerPort.ContainerPort, protocol)]; ok {
+			return fmt.Errorf("the container port %s of the head service clashes with the port %s of the head service, which uses the same number %d", containerPort.Name, other, containerPort.ContainerPort)
+		}
+		addPort(containerPort.Name, containerPort.ContainerPort, protocol)
+	}
+	return nil
+}
+
+// getDashboardPort returns the dashboard port of the head Pod.
+func getDashboardPort(cluster rayv1.RayCluster) int32 {
+	if port, ok := getPortsFromCluster(cluster)[utils.DashboardPortName]; ok {
+		return port
+	}
+	return utils.DefaultDashboardPort
+}
+
 // getPortsFromCluster get the ports from head container and directly map them in service
 // It's user's responsibility to maintain rayStartParam ports and container ports mapping
 // TODO: Consider to infer ports from rayStartParams (source of truth) in the future.
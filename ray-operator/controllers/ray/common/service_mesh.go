@@ -0,0 +1,158 @@
+package common
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+const (
+	istioProxyConfigAnnotationKey          = "proxy.istio.io/config"
+	istioExcludeInboundPortsAnnotationKey  = "traffic.sidecar.istio.io/excludeInboundPorts"
+	istioExcludeOutboundPortsAnnotationKey = "traffic.sidecar.istio.io/excludeOutboundPorts"
+	istioHoldApplicationUntilProxyStarts   = `{"holdApplicationUntilProxyStarts": true}`
+	istioQuitEndpoint                      = "http://127.0.0.1:15020/quitquitquit"
+	linkerdProxyAwaitAnnotationKey         = "config.linkerd.io/proxy-await"
+	linkerdSkipInboundPortsAnnotationKey   = "config.linkerd.io/skip-inbound-ports"
+	linkerdSkipOutboundPortsAnnotationKey  = "config.linkerd.io/skip-outbound-ports"
+	linkerdProxyAwaitEnabled               = "enabled"
+	linkerdShutdownEndpoint                = "http://127.0.0.1:4191/shutdown"
+)
+
+// rayInternalPortParams are the `ray start` parameters of ports that Ray nodes use to talk to each other directly.
+// This traffic relies on Pod IPs and must bypass the service mesh sidecar.
+var rayInternalPortParams = []string{
+	"node-manager-port",
+	"object-manager-port",
+	"dashboard-agent-grpc-port",
+	"runtime-env-agent-port",
+	"metrics-export-port",
+	"redis-shard-ports",
+	"worker-port-list",
+}
+
+// configureServiceMesh adds the annotations that make the Ray container wait for the mesh sidecar to be ready,
+// and that exclude the Ray internal ports from the traffic redirection of the sidecar. Annotations set by the
+// user are kept, and port lists set by the user are extended.
+func configureServiceMesh(podTemplate *corev1.PodTemplateSpec, options *rayv1.ServiceMeshOptions, rayStartParams map[string]string, headPort string) {
+	if options == nil {
+		return
+	}
+	if podTemplate.Annotations == nil {
+		podTemplate.Annotations = map[string]string{}
+	}
+	ports := serviceMeshExcludedPorts(options, rayStartParams, headPort)
+	switch options.Type {
+	case rayv1.IstioServiceMesh:
+		setAnnotationIfMissing(podTemplate.Annotations, istioProxyConfigAnnotationKey, istioHoldApplicationUntilProxyStarts)
+		// Istio doesn't support port ranges, so a `min-worker-port`/`max-worker-port` range is not excluded.
+		// Use `worker-port-list` instead.
+		istioPorts := make([]string, 0, len(ports))
+		for _, port := range ports {
+			if !strings.Contains(port, "-") {
+				istioPorts = append(istioPorts, port)
+			}
+		}
+		appendAnnotationList(podTemplate.Annotations, istioExcludeInboundPortsAnnotationKey, istioPorts)
+		appendAnnotationList(podTemplate.Annotations, istioExcludeOutboundPortsAnnotationKey, istioPorts)
+	case rayv1.LinkerdServiceMesh:
+		setAnnotationIfMissing(podTemplate.Annotations, linkerdProxyAwaitAnnotationKey, linkerdProxyAwaitEnabled)
+		appendAnnotationList(podTemplate.Annotations, linkerdSkipInboundPortsAnnotationKey, ports)
+		appendAnnotationList(podTemplate.Annotations, linkerdSkipOutboundPortsAnnotationKey, ports)
+	}
+}
+
+// ConfigureServiceMeshSubmitter makes the submitter container wait for the mesh sidecar to be ready.
+func ConfigureServiceMeshSubmitter(podTemplate *corev1.PodTemplateSpec, options *rayv1.ServiceMeshOptions) {
+	if options == nil {
+		return
+	}
+	if podTemplate.Annotations == nil {
+		podTemplate.Annotations = map[string]string{}
+	}
+	switch options.Type {
+	case rayv1.IstioServiceMesh:
+		setAnnotationIfMissing(podTemplate.Annotations, istioProxyConfigAnnotationKey, istioHoldApplicationUntilProxyStarts)
+	case rayv1.LinkerdServiceMesh:
+		setAnnotationIfMissing(podTemplate.Annotations, linkerdProxyAwaitAnnotationKey, linkerdProxyAwaitEnabled)
+	}
+}
+
+// GetServiceMeshShutdownCommand returns the shell command that stops the mesh sidecar. Without it, the sidecar
+// keeps running after the submitter exits and the submitter Kubernetes Job never completes. The exit code of the
+// submitter is preserved. Python is used because it is always available in Ray images, unlike curl.
+func GetServiceMeshShutdownCommand(options *rayv1.ServiceMeshOptions) []string {
+	if options == nil {
+		return nil
+	}
+	var endpoint string
+	switch options.Type {
+	case rayv1.IstioServiceMesh:
+		endpoint = istioQuitEndpoint
+	case rayv1.LinkerdServiceMesh:
+		endpoint = linkerdShutdownEndpoint
+	default:
+		return nil
+	}
+	request := fmt.Sprintf("import urllib.request; urllib.request.urlopen(urllib.request.Request('%s', method='POST'))", endpoint)
+	return []string{";", "exit_code=$?", ";", "python", "-c", strconv.Quote(request), "||", "true", ";", "exit", "$exit_code"}
+}
+
+// serviceMeshExcludedPorts returns the sorted, deduplicated ports that bypass the sidecar.
+func serviceMeshExcludedPorts(options *rayv1.ServiceMeshOptions, rayStartParams map[string]string, headPort string) []string {
+	set := map[string]struct{}{}
+	if headPort != "" {
+		set[headPort] = struct{}{}
+	}
+	for _, param := range rayInternalPortParams {
+		for _, port := range strings.Split(rayStartParams[param], ",") {
+			if port = strings.TrimSpace(port); port != "" {
+				set[port] = struct{}{}
+			}
+		}
+	}
+	if minPort, maxPort := rayStartParams["min-worker-port"], rayStartParams["max-worker-port"]; minPort != "" && maxPort != "" {
+		set[minPort+"-"+maxPort] = struct{}{}
+	}
+	for _, port := range options.ExcludedPorts {
+		set[strconv.Itoa(int(port))] = struct{}{}
+	}
+	ports := make([]string, 0, len(set))
+	for port := range set {
+		ports = append(ports, port)
+	}
+	sort.Strings(ports)
+	return ports
+}
+
+func setAnnotationIfMissing(annotations map[string]string, key string, value string) {
+	if _, ok := annotations[key]; !ok {
+		annotations[key] = value
+	}
+}
+
+// appendAnnotationList adds the values to the comma separated list in the annotation, skipping existing values.
+func appendAnnotationList(annotations map[string]string, key string, values []string) {
+	if len(values) == 0 {
+		return
+	}
+	var list []string
+	existing := map[string]struct{}{}
+	for _, value := range strings.Split(annotations[key], ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			list = append(list, value)
+			existing[value] = struct{}{}
+		}
+	}
+	for _, value := range values {
+		if _, ok := existing[value]; !ok {
+			list = append(list, value)
+		}
+	}
+	annotations[key] = strings.Join(list, ",")
+}
@@ -0,0 +1,65 @@
+package common
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestConfigureServiceMeshIstio(t *testing.T) {
+	cluster := instance.DeepCopy()
+	cluster.Spec.ServiceMeshOptions = &rayv1.ServiceMeshOptions{Type: rayv1.IstioServiceMesh, ExcludedPorts: []int32{9999}}
+	worker := cluster.Spec.WorkerGroupSpecs[0].DeepCopy()
+	worker.RayStartParams = map[string]string{
+		"node-manager-port":   "8076",
+		"object-manager-port": "8077",
+		"min-worker-port":     "10002",
+		"max-worker-port":     "10010",
+	}
+	worker.Template.Annotations = map[string]string{
+		"traffic.sidecar.istio.io/excludeOutboundPorts": "443",
+		"proxy.istio.io/config":                         `{"terminationDrainDuration": "30s"}`,
+	}
+
+	ctx := context.Background()
+	fqdnRayIP := utils.GenerateFQDNServiceName(ctx, *cluster, cluster.Namespace)
+	podTemplateSpec := DefaultWorkerPodTemplate(ctx, *cluster, *worker, "worker", fqdnRayIP, "6379")
+
+	// The port range of the workers is not supported by Istio.
+	assert.Equal(t, "6379,8076,8077,8080,9999", podTemplateSpec.Annotations["traffic.sidecar.istio.io/excludeInboundPorts"])
+	assert.Equal(t, "443,6379,8076,8077,8080,9999", podTemplateSpec.Annotations["traffic.sidecar.istio.io/excludeOutboundPorts"])
+	// The proxy config set by the user is kept.
+	assert.Equal(t, `{"terminationDrainDuration": "30s"}`, podTemplateSpec.Annotations["proxy.istio.io/config"])
+}
+
+func TestConfigureServiceMeshLinkerd(t *testing.T) {
+	cluster := instance.DeepCopy()
+	cluster.Spec.ServiceMeshOptions = &rayv1.ServiceMeshOptions{Type: rayv1.LinkerdServiceMesh}
+	cluster.Spec.HeadGroupSpec.RayStartParams = map[string]string{
+		"port":             "6380",
+		"worker-port-list": "10002,10003",
+	}
+
+	ctx := context.Background()
+	headPort := GetHeadPort(cluster.Spec.HeadGroupSpec.RayStartParams)
+	podTemplateSpec := DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, "head", headPort)
+
+	assert.Equal(t, "enabled", podTemplateSpec.Annotations["config.linkerd.io/proxy-await"])
+	assert.Equal(t, "10002,10003,6380,8080", podTemplateSpec.Annotations["config.linkerd.io/skip-inbound-ports"])
+	assert.Equal(t, "10002,10003,6380,8080", podTemplateSpec.Annotations["config.linkerd.io/skip-outbound-ports"])
+}
+
+func TestConfigureServiceMeshDisabled(t *testing.T) {
+	cluster := instance.DeepCopy()
+	ctx := context.Background()
+	podTemplateSpec := DefaultHeadPodTemplate(ctx, *cluster, cluster.Spec.HeadGroupSpec, "head", "6379")
+
+	for _, key := range []string{istioProxyConfigAnnotationKey, istioExcludeInboundPortsAnnotationKey, linkerdProxyAwaitAnnotationKey, linkerdSkipInboundPortsAnnotationKey} {
+		assert.NotContains(t, podTemplateSpec.Annotations, key)
+	}
+	assert.Nil(t, GetServiceMeshShutdownCommand(nil))
+}
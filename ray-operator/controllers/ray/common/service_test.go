@@ -6,15 +6,20 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"strconv"
 	"testing"
 
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 )
 
 var (
@@ -450,6 +455,117 @@ func TestBuildServiceForHeadPodPortsOrder(t *testing.T) {
 	}
 }
 
+func TestBuildServiceForHeadPodWithServicePorts(t *testing.T) {
+	ctx := context.Background()
+	cluster := instanceWithWrongSvc.DeepCopy()
+	cluster.Spec.HeadGroupSpec.ServicePorts = &rayv1.HeadServicePorts{
+		Exclude:    []string{utils.MetricsPortName},
+		Additional: []corev1.ServicePort{{Name: "grpc", Port: 9000}},
+	}
+
+	for _, headService := range []*corev1.Service{nil, {Spec: corev1.ServiceSpec{Ports: []corev1.ServicePort{{Name: "custom", Port: 9001}}}}} {
+		cluster.Spec.HeadGroupSpec.HeadService = headService
+		svc, err := BuildServiceForHeadPod(ctx, *cluster, nil, nil)
+		assert.NoError(t, err)
+
+		ports := map[string]int32{}
+		for _, port := range svc.Spec.Ports {
+			ports[port.Name] = port.Port
+		}
+		assert.NotContains(t, ports, utils.MetricsPortName)
+		assert.Contains(t, ports, "serve")
+		assert.Equal(t, int32(9000), ports["grpc"])
+	}
+}
+
+func TestBuildServicesWithHeadContainerPorts(t *testing.T) {
+	ctx := context.Background()
+	cluster := instanceWithWrongSvc.DeepCopy()
+	cluster.Spec.HeadGroupSpec.Template.Spec.Containers = append(cluster.Spec.HeadGroupSpec.Template.Spec.Containers, corev1.Container{
+		Name: "router",
+		Ports: []corev1.ContainerPort{
+			{Name: "router", ContainerPort: 8081},
+			{Name: "token-counter", ContainerPort: 9090, Protocol: corev1.ProtocolUDP},
+		},
+	})
+	cluster.Spec.HeadGroupSpec.ServicePorts = &rayv1.HeadServicePorts{
+		ContainerPorts: []rayv1.HeadContainerServicePort{
+			{ContainerName: "router", PortName: "router", ExposeOnServeService: ptr.To(true)},
+			{ContainerName: "router", PortName: "token-counter"},
+		},
+	}
+
+	headService, err := BuildServiceForHeadPod(ctx, *cluster, nil, nil)
+	require.NoError(t, err)
+	assert.Contains(t, headService.Spec.Ports, corev1.ServicePort{Name: "router", Port: 8081})
+	assert.Contains(t, headService.Spec.Ports, corev1.ServicePort{Name: "token-counter", Port: 9090, Protocol: corev1.ProtocolUDP})
+
+	serveService, err := BuildServeServiceForRayService(ctx, *serviceInstance, *cluster)
+	require.NoError(t, err)
+	assert.Equal(t, []corev1.ServicePort{{Name: utils.ServingPortName, Port: 8000}, {Name: "router", Port: 8081}}, serveService.Spec.Ports)
+
+	require.NoError(t, ValidateHeadContainerServicePorts(*cluster))
+	cluster.Spec.HeadGroupSpec.ServicePorts.ContainerPorts[0].PortName = "unknown"
+	require.Error(t, ValidateHeadContainerServicePorts(*cluster))
+}
+
+func TestValidateExistingHeadService(t *testing.T) {
+	cluster := instanceWithWrongSvc.DeepCopy()
+	cluster.Spec.HeadGroupSpec.Template.Labels = map[string]string{"app": "ray-head"}
+	headPort, err := strconv.ParseInt(GetHeadPort(cluster.Spec.HeadGroupSpec.RayStartParams), 10, 32)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		selector    map[string]string
+		ports       []corev1.ServicePort
+		expectError bool
+	}{
+		{
+			name:     "Select the head Pod by the cluster and node type labels",
+			selector: map[string]string{utils.RayClusterLabelKey: cluster.Name, utils.RayNodeTypeLabelKey: string(rayv1.HeadNode)},
+			ports:    []corev1.ServicePort{{Name: utils.GcsServerPortName, Port: int32(headPort)}},
+		},
+		{
+			name:     "Select the head Pod by a label of the template",
+			selector: map[string]string{"app": "ray-head"},
+			ports:    []corev1.ServicePort{{Name: "gcs", Port: 80, TargetPort: intstr.FromInt32(int32(headPort))}},
+		},
+		{
+			name:        "No selector",
+			ports:       []corev1.ServicePort{{Name: utils.GcsServerPortName, Port: int32(headPort)}},
+			expectError: true,
+		},
+		{
+			name:        "Select the worker Pods",
+			selector:    map[string]string{utils.RayClusterLabelKey: cluster.Name, utils.RayNodeTypeLabelKey: string(rayv1.WorkerNode)},
+			ports:       []corev1.ServicePort{{Name: utils.GcsServerPortName, Port: int32(headPort)}},
+			expectError: true,
+		},
+		{
+			name:        "No GCS server port",
+			selector:    map[string]string{utils.RayClusterLabelKey: cluster.Name},
+			ports:       []corev1.ServicePort{{Name: utils.DashboardPortName, Port: 8265}},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{Name: "mesh-head-svc"},
+				Spec:       corev1.ServiceSpec{Selector: tt.selector, Ports: tt.ports},
+			}
+			err := ValidateExistingHeadService(*cluster, svc)
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestBuildHeadlessServiceForRayCluster(t *testing.T) {
 	svc := BuildHeadlessServiceForRayCluster(*instanceForSvc)
 
@@ -525,6 +641,122 @@ func TestBuildServeServiceForRayService(t *testing.T) {
 	validateNameAndNamespaceForUserSpecifiedService(svc, serviceInstance.ObjectMeta.Namespace, expectedName, t)
 }
 
+func TestBuildServeServiceForRayServiceWithTopologyRouting(t *testing.T) {
+	rayService := serviceInstance.DeepCopy()
+	svc, err := BuildServeServiceForRayService(context.Background(), *rayService, *instanceWithWrongSvc)
+	assert.Nil(t, err)
+	assert.NotContains(t, svc.Annotations, corev1.AnnotationTopologyMode)
+
+	rayService.Spec.ServeTopologyRouting = &rayv1.ServeTopologyRouting{Mode: ptr.To(rayv1.ManagedServeTopologyRouting)}
+	svc, err = BuildServeServiceForRayService(context.Background(), *rayService, *instanceWithWrongSvc)
+	assert.Nil(t, err)
+	assert.Equal(t, "Auto", svc.Annotations[corev1.AnnotationTopologyMode])
+
+	// The annotation set in the serveService isn't overridden.
+	rayService.Spec.ServeService = &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{corev1.AnnotationTopologyMode: "Disabled"}},
+	}
+	svc, err = BuildServeServiceForRayService(context.Background(), *rayService, *instanceWithWrongSvc)
+	assert.Nil(t, err)
+	assert.Equal(t, "Disabled", svc.Annotations[corev1.AnnotationTopologyMode])
+}
+
+func TestBuildServeServiceForRayServiceWithSessionAffinity(t *testing.T) {
+	rayService := serviceInstance.DeepCopy()
+	rayService.Spec.ServeSessionAffinity = &rayv1.ServeSessionAffinity{Type: rayv1.ClientIPServeSessionAffinity}
+	svc, err := BuildServeServiceForRayService(context.Background(), *rayService, *instanceWithWrongSvc)
+	assert.Nil(t, err)
+	assert.Equal(t, corev1.ServiceAffinityClientIP, svc.Spec.SessionAffinity)
+	assert.Equal(t, int32(utils.DefaultServeSessionAffinityTimeoutSeconds), *svc.Spec.SessionAffinityConfig.ClientIP.TimeoutSeconds)
+
+	// The Cookie session affinity is implemented by the HTTPRoute, so the serve service doesn't have a session affinity.
+	rayService.Spec.ServeSessionAffinity = &rayv1.ServeSessionAffinity{Type: rayv1.CookieServeSessionAffinity, GatewayName: "gateway", TimeoutSeconds: ptr.To[int32](60)}
+	svc, err = BuildServeServiceForRayService(context.Background(), *rayService, *instanceWithWrongSvc)
+	assert.Nil(t, err)
+	assert.Empty(t, svc.Spec.SessionAffinity)
+	assert.Nil(t, svc.Spec.SessionAffinityConfig)
+
+	route, err := BuildServeHTTPRouteForRayService(*rayService, svc, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, utils.GenerateServeHTTPRouteName(rayService.Name), route.GetName())
+	assert.Equal(t, rayService.Namespace, route.GetNamespace())
+	parentRefs, _, _ := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "gateway"}}, parentRefs)
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	assert.Len(t, rules, 1)
+	rule := rules[0].(map[string]interface{})
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": svc.Name, "port": int64(svc.Spec.Ports[0].Port)}}, rule["backendRefs"])
+	assert.Equal(t, map[string]interface{}{
+		"type":            "Cookie",
+		"sessionName":     utils.DefaultServeSessionCookieName,
+		"absoluteTimeout": "60s",
+	}, rule["sessionPersistence"])
+}
+
+func TestBuildServeHTTPRouteForRayServiceWithRequestShadowing(t *testing.T) {
+	rayService := serviceInstance.DeepCopy()
+	rayService.Spec.UpgradeStrategy = &rayv1.RayServiceUpgradeStrategy{
+		RequestShadowing: &rayv1.RequestShadowing{GatewayName: "gateway", Percent: ptr.To[int32](20)},
+	}
+	svc, err := BuildServeServiceForRayService(context.Background(), *rayService, *instanceWithWrongSvc)
+	require.NoError(t, err)
+	previewSvc, err := BuildPreviewServeServiceForRayService(context.Background(), *rayService, *instanceWithWrongSvc)
+	require.NoError(t, err)
+
+	// Without a pending RayCluster, the HTTPRoute only routes the traffic to the serve service.
+	route, err := BuildServeHTTPRouteForRayService(*rayService, svc, nil)
+	require.NoError(t, err)
+	parentRefs, _, _ := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "gateway"}}, parentRefs)
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	rule := rules[0].(map[string]interface{})
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": svc.Name, "port": int64(svc.Spec.Ports[0].Port)}}, rule["backendRefs"])
+	assert.NotContains(t, rule, "filters")
+	assert.NotContains(t, rule, "sessionPersistence")
+
+	// The requests are mirrored to the preview serve service of the pending RayCluster.
+	route, err = BuildServeHTTPRouteForRayService(*rayService, svc, previewSvc)
+	require.NoError(t, err)
+	rules, _, _ = unstructured.NestedSlice(route.Object, "spec", "rules")
+	rule = rules[0].(map[string]interface{})
+	assert.Equal(t, []interface{}{map[string]interface{}{
+		"type": "RequestMirror",
+		"requestMirror": map[string]interface{}{
+			"backendRef": map[string]interface{}{"name": previewSvc.Name, "port": int64(previewSvc.Spec.Ports[0].Port)},
+			"percent":    int64(20),
+		},
+	}}, rule["filters"])
+
+	// The HTTPRoute isn't used without the Cookie session affinity and the request shadowing.
+	rayService.Spec.UpgradeStrategy = nil
+	_, err = BuildServeHTTPRouteForRayService(*rayService, svc, nil)
+	assert.Error(t, err)
+}
+
+func TestBuildPreviewServeServiceForRayService(t *testing.T) {
+	rayService := serviceInstance.DeepCopy()
+	rayService.Spec.ServeService = &corev1.Service{
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceTypeLoadBalancer,
+			Ports:    []corev1.ServicePort{{Name: utils.ServingPortName, Port: 8000, NodePort: 30000}},
+			Selector: map[string]string{"app": "ray"},
+		},
+	}
+	svc, err := BuildPreviewServeServiceForRayService(context.Background(), *rayService, *instanceWithWrongSvc)
+	assert.Nil(t, err)
+	assert.Equal(t, fmt.Sprintf("%s-preview-serve-svc", rayService.Name), svc.Name)
+	assert.Equal(t, rayService.Namespace, svc.Namespace)
+	assert.Equal(t, map[string]string{
+		utils.RayClusterLabelKey:               instanceWithWrongSvc.Name,
+		utils.RayClusterServingServiceLabelKey: utils.EnableRayClusterServingServiceTrue,
+	}, svc.Spec.Selector)
+	// The type of the user-provided serve service is not used so that no load balancer is provisioned.
+	assert.Equal(t, corev1.ServiceTypeClusterIP, svc.Spec.Type)
+	for _, port := range svc.Spec.Ports {
+		assert.Zero(t, port.NodePort)
+	}
+}
+
 func TestBuildServeServiceForRayCluster(t *testing.T) {
 	svc, err := BuildServeServiceForRayCluster(context.Background(), *instanceForSvc)
 	assert.Nil(t, err)
@@ -683,3 +915,27 @@ func validateLabelsForUserSpecifiedService(svc *corev1.Service, userLabels map[s
 		}
 	}
 }
+
+func TestBuildServicesWithIPFamilies(t *testing.T) {
+	cluster := instanceForSvc.DeepCopy()
+	cluster.Spec.IPFamilyPolicy = ptr.To(corev1.IPFamilyPolicyRequireDualStack)
+	cluster.Spec.IPFamilies = []corev1.IPFamily{corev1.IPv6Protocol, corev1.IPv4Protocol}
+
+	headSvc, err := BuildServiceForHeadPod(context.Background(), *cluster, nil, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, ptr.To(corev1.IPFamilyPolicyRequireDualStack), headSvc.Spec.IPFamilyPolicy)
+	assert.Equal(t, cluster.Spec.IPFamilies, headSvc.Spec.IPFamilies)
+
+	headlessSvc := BuildHeadlessServiceForRayCluster(*cluster)
+	assert.Equal(t, cluster.Spec.IPFamilies, headlessSvc.Spec.IPFamilies)
+
+	// The IP families set in the template of the Service take precedence.
+	cluster.Spec.HeadGroupSpec.HeadService = &corev1.Service{Spec: corev1.ServiceSpec{
+		IPFamilyPolicy: ptr.To(corev1.IPFamilyPolicySingleStack),
+		IPFamilies:     []corev1.IPFamily{corev1.IPv6Protocol},
+	}}
+	headSvc, err = BuildServiceForHeadPod(context.Background(), *cluster, nil, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, ptr.To(corev1.IPFamilyPolicySingleStack), headSvc.Spec.IPFamilyPolicy)
+	assert.Equal(t, []corev1.IPFamily{corev1.IPv6Protocol}, headSvc.Spec.IPFamilies)
+}
@@ -0,0 +1,140 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+// ValidateIgnoredPaths checks that the ignored paths are JSON pointers to a field of the RayCluster spec.
+func ValidateIgnoredPaths(paths []string) error {
+	for _, path := range paths {
+		if !strings.HasPrefix(path, "/") || path == "/" {
+			return fmt.Errorf("the ignored path %q should be a JSON pointer to a field, for example /headGroupSpec/template/metadata/annotations", path)
+		}
+	}
+	return nil
+}
+
+// stripImageDigest removes the digest of an image that also has a tag, which admission webhooks add to pin the image.
+// An image that is only referenced by its digest is kept.
+func stripImageDigest(image string) string {
+	name, _, found := strings.Cut(image, "@")
+	if !found {
+		return image
+	}
+	if strings.Contains(name[strings.LastIndex(name, "/")+1:], ":") {
+		return name
+	}
+	return image
+}
+
+func stripContainerImageDigests(podSpec *corev1.PodSpec) {
+	for i := range podSpec.InitContainers {
+		podSpec.InitContainers[i].Image = stripImageDigest(podSpec.InitContainers[i].Image)
+	}
+	for i := range podSpec.Containers {
+		podSpec.Containers[i].Image = stripImageDigest(podSpec.Containers[i].Image)
+	}
+}
+
+// unescapeJSONPointerSegment decodes a segment of a JSON pointer, where `~1` is `/` and `~0` is `~`.
+func unescapeJSONPointerSegment(segment string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(segment, "~1", "/"), "~0", "~")
+}
+
+// removePath removes the value at the path from the JSON value. A `*` segment matches all the elements of a list or
+// all the keys of a map.
+func removePath(value interface{}, segments []string) {
+	if len(segments) == 0 {
+		return
+	}
+	segment, last := segments[0], len(segments) == 1
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key := range v {
+			if segment != "*" && key != segment {
+				continue
+			}
+			if last {
+				delete(v, key)
+			} else {
+				removePath(v[key], segments[1:])
+			}
+		}
+	case []interface{}:
+		for i := range v {
+			if segment != "*" && strconv.Itoa(i) != segment {
+				continue
+			}
+			if last {
+				// Removing an element would shift the indexes of the next ones, so it's cleared.
+				v[i] = nil
+			} else {
+				removePath(v[i], segments[1:])
+			}
+		}
+	}
+}
+
+// pruneEmpty removes the nulls and the empty maps and lists from the JSON value, since admission webhooks often
+// default fields to empty values, and returns whether the value itself is empty. The nulls of the lists are kept,
+// so that the indexes of the elements don't change.
+func pruneEmpty(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		for key, child := range v {
+			if pruneEmpty(child) {
+				delete(v, key)
+			}
+		}
+		return len(v) == 0
+	case []interface{}:
+		empty := true
+		for i, child := range v {
+			if pruneEmpty(child) {
+				v[i] = nil
+			} else {
+				empty = false
+			}
+		}
+		return empty
+	}
+	return false
+}
+
+// NormalizeRayClusterSpecForHash returns the JSON value of the spec without the changes that admission webhooks
+// commonly make, so that they don't change its hash: the digests of the images that also have a tag, the empty
+// values, and the ignored paths, which are JSON pointers into the spec.
+func NormalizeRayClusterSpecForHash(spec rayv1.RayClusterSpec, ignoredPaths []string) (interface{}, error) {
+	normalizedSpec := spec.DeepCopy()
+	stripContainerImageDigests(&normalizedSpec.HeadGroupSpec.Template.Spec)
+	for i := range normalizedSpec.WorkerGroupSpecs {
+		stripContainerImageDigests(&normalizedSpec.WorkerGroupSpecs[i].Template.Spec)
+	}
+
+	serialized, err := json.Marshal(normalizedSpec)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if err := json.Unmarshal(serialized, &value); err != nil {
+		return nil, err
+	}
+	for _, path := range ignoredPaths {
+		segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+		for i := range segments {
+			segments[i] = unescapeJSONPointerSegment(segments[i])
+		}
+		removePath(value, segments)
+	}
+	pruneEmpty(value)
+	return value, nil
+}
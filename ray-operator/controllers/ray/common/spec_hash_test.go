@@ -0,0 +1,64 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestValidateIgnoredPaths(t *testing.T) {
+	require.NoError(t, ValidateIgnoredPaths([]string{"/workerGroupSpecs/*/template/metadata/annotations"}))
+	require.Error(t, ValidateIgnoredPaths([]string{"/"}))
+	require.Error(t, ValidateIgnoredPaths([]string{"headGroupSpec"}))
+}
+
+func TestStripImageDigest(t *testing.T) {
+	assert.Equal(t, "rayproject/ray:2.9.0", stripImageDigest("rayproject/ray:2.9.0"))
+	assert.Equal(t, "rayproject/ray:2.9.0", stripImageDigest("rayproject/ray:2.9.0@sha256:abc"))
+	assert.Equal(t, "localhost:5000/ray:2.9.0", stripImageDigest("localhost:5000/ray:2.9.0@sha256:abc"))
+	// The images only referenced by their digest are kept.
+	assert.Equal(t, "rayproject/ray@sha256:abc", stripImageDigest("rayproject/ray@sha256:abc"))
+	assert.Equal(t, "localhost:5000/ray@sha256:abc", stripImageDigest("localhost:5000/ray@sha256:abc"))
+}
+
+func TestNormalizeRayClusterSpecForHash(t *testing.T) {
+	spec := rayv1.RayClusterSpec{
+		RayVersion: "2.9.0",
+		WorkerGroupSpecs: []rayv1.WorkerGroupSpec{
+			{
+				GroupName: "small-group",
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{Name: "ray-worker", Image: "rayproject/ray:2.9.0@sha256:abc"},
+							{Name: "istio-proxy", Image: "istio/proxyv2:1.20.0"},
+						},
+					},
+				},
+			},
+		},
+	}
+	value, err := NormalizeRayClusterSpecForHash(spec, []string{"/workerGroupSpecs/*/template/spec/containers/1", "/rayVersion"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"workerGroupSpecs": []interface{}{
+			map[string]interface{}{
+				"groupName": "small-group",
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"containers": []interface{}{
+							map[string]interface{}{"name": "ray-worker", "image": "rayproject/ray:2.9.0"},
+							nil,
+						},
+					},
+				},
+			},
+		},
+	}, value)
+	// The spec isn't modified.
+	assert.Equal(t, "rayproject/ray:2.9.0@sha256:abc", spec.WorkerGroupSpecs[0].Template.Spec.Containers[0].Image)
+}
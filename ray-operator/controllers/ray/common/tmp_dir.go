@@ -0,0 +1,74 @@
+package common
+
+import (
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// rayTmpDirParent is the value of RAY_TMPDIR. Ray stores its temporary directory in the "ray" subdirectory of it,
+// which is where the volume of the tmp dir policy is mounted.
+const rayTmpDirParent = "/tmp"
+
+// makeTmpDirVolumeSource returns the source of the volume that stores the Ray temporary directory.
+func makeTmpDirVolumeSource(container *corev1.Container, policy *rayv1.TmpDirPolicy) corev1.VolumeSource {
+	switch policy.Type {
+	case rayv1.TmpDirTypeMemory:
+		sizeLimit := policy.Size
+		if sizeLimit == nil {
+			sizeLimit = findMemoryReqOrLimit(*container)
+		}
+		return corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{Medium: corev1.StorageMediumMemory, SizeLimit: sizeLimit}}
+	case rayv1.TmpDirTypeEphemeralVolume:
+		claimSpec := corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: policy.StorageClassName,
+		}
+		if policy.Size != nil {
+			claimSpec.Resources.Requests = corev1.ResourceList{corev1.ResourceStorage: policy.Size.DeepCopy()}
+		}
+		return corev1.VolumeSource{Ephemeral: &corev1.EphemeralVolumeSource{
+			VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{Spec: claimSpec},
+		}}
+	default:
+		return corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{SizeLimit: policy.Size}}
+	}
+}
+
+// ApplyTmpDirPolicy stores the Ray temporary directory of the Pod on the volume described by the policy. The volume
+// replaces the emptyDir that KubeRay creates for the logs shared with the autoscaler, if any, so that the autoscaler
+// keeps reading the same directory. The Pods whose Ray container mounts a volume of the user at /tmp/ray are left
+// unchanged.
+func ApplyTmpDirPolicy(pod *corev1.Pod, policy *rayv1.TmpDirPolicy) {
+	if policy == nil {
+		return
+	}
+	container := &pod.Spec.Containers[utils.RayContainerIndex]
+	for _, mount := range container.VolumeMounts {
+		if mount.MountPath == RayLogVolumeMountPath && mount.Name != RayLogVolumeName {
+			return
+		}
+	}
+
+	volumeSource := makeTmpDirVolumeSource(container, policy)
+	if checkIfVolumeExists(pod, RayLogVolumeName) {
+		// The volumes may be shared with the template of the RayCluster.
+		pod.Spec.Volumes = slices.Clone(pod.Spec.Volumes)
+		for i := range pod.Spec.Volumes {
+			if pod.Spec.Volumes[i].Name == RayLogVolumeName {
+				pod.Spec.Volumes[i].VolumeSource = volumeSource
+			}
+		}
+	} else {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{Name: RayLogVolumeName, VolumeSource: volumeSource})
+	}
+	if !checkIfVolumeMounted(container, RayLogVolumeMountPath) {
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{Name: RayLogVolumeName, MountPath: RayLogVolumeMountPath})
+	}
+	if !utils.EnvVarExists(utils.RAY_TMPDIR, container.Env) {
+		container.Env = append(container.Env, corev1.EnvVar{Name: utils.RAY_TMPDIR, Value: rayTmpDirParent})
+	}
+}
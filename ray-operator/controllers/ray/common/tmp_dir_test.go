@@ -0,0 +1,82 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func newTmpDirTestPod() *corev1.Pod {
+	return &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{
+		Name: "ray-worker",
+		Resources: corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("8Gi")},
+		},
+	}}}}
+}
+
+func TestApplyTmpDirPolicy(t *testing.T) {
+	pod := newTmpDirTestPod()
+	ApplyTmpDirPolicy(pod, nil)
+	assert.Equal(t, newTmpDirTestPod(), pod)
+
+	// The size limit of a memory-backed volume defaults to the memory limit of the Ray container.
+	ApplyTmpDirPolicy(pod, &rayv1.TmpDirPolicy{Type: rayv1.TmpDirTypeMemory})
+	limit := resource.MustParse("8Gi")
+	assert.Equal(t, []corev1.Volume{{
+		Name: RayLogVolumeName,
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{
+			Medium:    corev1.StorageMediumMemory,
+			SizeLimit: &limit,
+		}},
+	}}, pod.Spec.Volumes)
+	container := pod.Spec.Containers[utils.RayContainerIndex]
+	assert.Equal(t, []corev1.VolumeMount{{Name: RayLogVolumeName, MountPath: RayLogVolumeMountPath}}, container.VolumeMounts)
+	assert.Equal(t, []corev1.EnvVar{{Name: utils.RAY_TMPDIR, Value: "/tmp"}}, container.Env)
+
+	// A generic ephemeral volume requests the size from the storage class.
+	pod = newTmpDirTestPod()
+	size := resource.MustParse("200Gi")
+	ApplyTmpDirPolicy(pod, &rayv1.TmpDirPolicy{Type: rayv1.TmpDirTypeEphemeralVolume, Size: &size, StorageClassName: ptr.To("local-ssd")})
+	claimSpec := pod.Spec.Volumes[0].Ephemeral.VolumeClaimTemplate.Spec
+	assert.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}, claimSpec.AccessModes)
+	assert.Equal(t, "local-ssd", *claimSpec.StorageClassName)
+	assert.True(t, size.Equal(claimSpec.Resources.Requests[corev1.ResourceStorage]))
+}
+
+func TestApplyTmpDirPolicy_ReplacesLogVolume(t *testing.T) {
+	// The head Pod with the autoscaler shares the logs volume with the autoscaler container.
+	templateVolumes := []corev1.Volume{{
+		Name:         RayLogVolumeName,
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}}
+	pod := newTmpDirTestPod()
+	pod.Spec.Volumes = templateVolumes
+	pod.Spec.Containers[0].VolumeMounts = []corev1.VolumeMount{{Name: RayLogVolumeName, MountPath: RayLogVolumeMountPath}}
+	pod.Spec.Containers[0].Env = []corev1.EnvVar{{Name: utils.RAY_TMPDIR, Value: "/tmp"}}
+
+	size := resource.MustParse("50Gi")
+	ApplyTmpDirPolicy(pod, &rayv1.TmpDirPolicy{Type: rayv1.TmpDirTypeEmptyDir, Size: &size})
+	assert.Len(t, pod.Spec.Volumes, 1)
+	assert.Equal(t, &size, pod.Spec.Volumes[0].EmptyDir.SizeLimit)
+	assert.Len(t, pod.Spec.Containers[0].VolumeMounts, 1)
+	assert.Len(t, pod.Spec.Containers[0].Env, 1)
+	// The volumes of the template aren't modified.
+	assert.Nil(t, templateVolumes[0].EmptyDir.SizeLimit)
+}
+
+func TestApplyTmpDirPolicy_UserVolume(t *testing.T) {
+	pod := newTmpDirTestPod()
+	pod.Spec.Volumes = []corev1.Volume{{Name: "scratch"}}
+	pod.Spec.Containers[0].VolumeMounts = []corev1.VolumeMount{{Name: "scratch", MountPath: RayLogVolumeMountPath}}
+	expected := pod.DeepCopy()
+
+	ApplyTmpDirPolicy(pod, &rayv1.TmpDirPolicy{Type: rayv1.TmpDirTypeMemory})
+	assert.Equal(t, expected, pod)
+}
@@ -0,0 +1,63 @@
+package common
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+var defaultRollingUpdateLimit = intstr.FromString("25%")
+
+// GetWorkerGroupUpdateStrategyType returns the update strategy of the worker group, which defaults to OnDelete.
+func GetWorkerGroupUpdateStrategyType(worker rayv1.WorkerGroupSpec) rayv1.WorkerGroupUpdateStrategyType {
+	if worker.UpdateStrategy == nil || worker.UpdateStrategy.Type == "" {
+		return rayv1.OnDeleteWorkerGroupUpdateStrategyType
+	}
+	return worker.UpdateStrategy.Type
+}
+
+// GenerateWorkerGroupTemplateHash returns the hash of the template and the rayStartParams of the worker group, which
+// are the fields of the group that determine the spec of its worker Pods.
+func GenerateWorkerGroupTemplateHash(worker rayv1.WorkerGroupSpec) (string, error) {
+	return utils.GenerateJsonHash(struct {
+		RayStartParams map[string]string
+		Template       corev1.PodTemplateSpec
+	}{
+		RayStartParams: worker.RayStartParams,
+		Template:       worker.Template,
+	})
+}
+
+// IsWorkerPodOutdated returns whether the worker Pod was created from a different template hash. Pods without the
+// template hash annotation aren't considered outdated.
+func IsWorkerPodOutdated(pod corev1.Pod, templateHash string) bool {
+	podHash, ok := pod.Annotations[utils.RayWorkerGroupTemplateHashAnnotationKey]
+	return ok && podHash != templateHash
+}
+
+// GetRollingUpdateLimits returns the maximum numbers of surge and unavailable worker Pods of a rolling update of the
+// worker group with the given desired number of Pods. At least one Pod is allowed to be unavailable if both are 0,
+// so that the update can make progress.
+func GetRollingUpdateLimits(worker rayv1.WorkerGroupSpec, desired int) (maxSurge int, maxUnavailable int, err error) {
+	surge, unavailable := defaultRollingUpdateLimit, defaultRollingUpdateLimit
+	if worker.UpdateStrategy != nil && worker.UpdateStrategy.RollingUpdate != nil {
+		if worker.UpdateStrategy.RollingUpdate.MaxSurge != nil {
+			surge = *worker.UpdateStrategy.RollingUpdate.MaxSurge
+		}
+		if worker.UpdateStrategy.RollingUpdate.MaxUnavailable != nil {
+			unavailable = *worker.UpdateStrategy.RollingUpdate.MaxUnavailable
+		}
+	}
+	if maxSurge, err = intstr.GetScaledValueFromIntOrPercent(&surge, desired, true); err != nil {
+		return 0, 0, err
+	}
+	if maxUnavailable, err = intstr.GetScaledValueFromIntOrPercent(&unavailable, desired, false); err != nil {
+		return 0, 0, err
+	}
+	if maxSurge == 0 && maxUnavailable == 0 {
+		maxUnavailable = 1
+	}
+	return maxSurge, maxUnavailable, nil
+}
@@ -0,0 +1,110 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestGenerateWorkerGroupTemplateHash(t *testing.T) {
+	worker := rayv1.WorkerGroupSpec{
+		GroupName:      "small-group",
+		Replicas:       ptr.To[int32](1),
+		RayStartParams: map[string]string{"num-cpus": "1"},
+		Template: corev1.PodTemplateSpec{
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "ray-worker", Image: "rayproject/ray:2.9.0"}}},
+		},
+	}
+	hash, err := GenerateWorkerGroupTemplateHash(worker)
+	require.NoError(t, err)
+
+	// Scaling the group doesn't change the hash.
+	worker.Replicas = ptr.To[int32](3)
+	worker.ScaleStrategy.WorkersToDelete = []string{"pod"}
+	scaledHash, err := GenerateWorkerGroupTemplateHash(worker)
+	require.NoError(t, err)
+	assert.Equal(t, hash, scaledHash)
+
+	// Changing the template or the rayStartParams changes the hash.
+	worker.Template.Spec.Containers[0].Image = "rayproject/ray:2.10.0"
+	imageHash, err := GenerateWorkerGroupTemplateHash(worker)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, imageHash)
+	worker.RayStartParams["num-cpus"] = "2"
+	paramsHash, err := GenerateWorkerGroupTemplateHash(worker)
+	require.NoError(t, err)
+	assert.NotEqual(t, imageHash, paramsHash)
+}
+
+func TestIsWorkerPodOutdated(t *testing.T) {
+	pod := corev1.Pod{}
+	assert.False(t, IsWorkerPodOutdated(pod, "hash"))
+	pod.ObjectMeta = metav1.ObjectMeta{Annotations: map[string]string{utils.RayWorkerGroupTemplateHashAnnotationKey: "hash"}}
+	assert.False(t, IsWorkerPodOutdated(pod, "hash"))
+	assert.True(t, IsWorkerPodOutdated(pod, "new-hash"))
+}
+
+func TestGetRollingUpdateLimits(t *testing.T) {
+	tests := []struct {
+		rollingUpdate       *rayv1.RollingUpdateWorkerGroup
+		name                string
+		desired             int
+		expectedSurge       int
+		expectedUnavailable int
+		expectError         bool
+	}{
+		{
+			name:                "defaults",
+			desired:             10,
+			expectedSurge:       3,
+			expectedUnavailable: 2,
+		},
+		{
+			name:                "absolute values",
+			rollingUpdate:       &rayv1.RollingUpdateWorkerGroup{MaxSurge: ptr.To(intstr.FromInt32(0)), MaxUnavailable: ptr.To(intstr.FromInt32(2))},
+			desired:             10,
+			expectedSurge:       0,
+			expectedUnavailable: 2,
+		},
+		{
+			name:                "at least one Pod can be unavailable",
+			rollingUpdate:       &rayv1.RollingUpdateWorkerGroup{MaxSurge: ptr.To(intstr.FromInt32(0)), MaxUnavailable: ptr.To(intstr.FromString("10%"))},
+			desired:             5,
+			expectedSurge:       0,
+			expectedUnavailable: 1,
+		},
+		{
+			name:          "invalid percentage",
+			rollingUpdate: &rayv1.RollingUpdateWorkerGroup{MaxSurge: ptr.To(intstr.FromString("ten"))},
+			desired:       5,
+			expectError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			worker := rayv1.WorkerGroupSpec{
+				UpdateStrategy: &rayv1.WorkerGroupUpdateStrategy{
+					Type:          rayv1.RollingUpdateWorkerGroupUpdateStrategyType,
+					RollingUpdate: tt.rollingUpdate,
+				},
+			}
+			maxSurge, maxUnavailable, err := GetRollingUpdateLimits(worker, tt.desired)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedSurge, maxSurge)
+			assert.Equal(t, tt.expectedUnavailable, maxUnavailable)
+		})
+	}
+}
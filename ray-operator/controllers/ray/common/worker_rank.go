@@ -0,0 +1,58 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// IsRankEnvEnabled returns whether the worker Pods of the group are assigned stable ranks.
+func IsRankEnvEnabled(worker rayv1.WorkerGroupSpec) bool {
+	return worker.EnableRankEnv != nil && *worker.EnableRankEnv
+}
+
+// AllocateWorkerRanks returns the n smallest ranks that aren't used by the worker Pods, so that the ranks of the
+// deleted Pods are reused first. Pods without a valid rank annotation are ignored.
+func AllocateWorkerRanks(pods []corev1.Pod, n int) []int {
+	used := map[int]bool{}
+	for _, pod := range pods {
+		if rank, err := strconv.Atoi(pod.Annotations[utils.RayWorkerRankAnnotationKey]); err == nil {
+			used[rank] = true
+		}
+	}
+	ranks := make([]int, 0, n)
+	for rank := 0; len(ranks) < n; rank++ {
+		if !used[rank] {
+			ranks = append(ranks, rank)
+		}
+	}
+	return ranks
+}
+
+// SetWorkerRank annotates the worker Pod with its rank, and injects RANK from the annotation through the downward
+// API and WORLD_SIZE into the Ray container. The environment variables set in the Pod template are kept.
+func SetWorkerRank(pod *corev1.Pod, rank int, worldSize int) {
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[utils.RayWorkerRankAnnotationKey] = strconv.Itoa(rank)
+
+	container := &pod.Spec.Containers[utils.RayContainerIndex]
+	if !utils.EnvVarExists(utils.RANK, container.Env) {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name: utils.RANK,
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: fmt.Sprintf("metadata.annotations['%s']", utils.RayWorkerRankAnnotationKey),
+				},
+			},
+		})
+	}
+	if !utils.EnvVarExists(utils.WORLD_SIZE, container.Env) {
+		container.Env = append(container.Env, corev1.EnvVar{Name: utils.WORLD_SIZE, Value: strconv.Itoa(worldSize)})
+	}
+}
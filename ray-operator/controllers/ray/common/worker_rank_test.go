@@ -0,0 +1,39 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestWorkerRank(t *testing.T) {
+	newPod := func(rank string) corev1.Pod {
+		return corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{utils.RayWorkerRankAnnotationKey: rank}}}
+	}
+	pods := []corev1.Pod{newPod("0"), newPod("2"), newPod("invalid"), {}}
+	assert.Equal(t, []int{1, 3, 4}, AllocateWorkerRanks(pods, 3))
+	assert.Empty(t, AllocateWorkerRanks(pods, 0))
+
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Env: []corev1.EnvVar{{Name: utils.WORLD_SIZE, Value: "8"}}}},
+		},
+	}
+	SetWorkerRank(&pod, 1, 4)
+	assert.Equal(t, "1", pod.Annotations[utils.RayWorkerRankAnnotationKey])
+	env := pod.Spec.Containers[utils.RayContainerIndex].Env
+	// WORLD_SIZE from the Pod template is kept.
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: utils.WORLD_SIZE, Value: "8"},
+		{
+			Name: utils.RANK,
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.annotations['ray.io/worker-rank']"},
+			},
+		},
+	}, env)
+}
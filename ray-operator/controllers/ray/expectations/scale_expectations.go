@@ -14,6 +14,12 @@ import (
 
 const HeadGroup = ""
 
+// HeadStandbyGroup is the group of the standby head Pod. It isn't a valid worker group name.
+const HeadStandbyGroup = "/head-standby"
+
+// BalloonGroup is the group of the balloon Pods. It isn't a valid worker group name.
+const BalloonGroup = "/balloon"
+
 // ScaleAction is the action of scale, like create and delete.
 type ScaleAction string
 
@@ -7,12 +7,15 @@ import (
 	"os"
 	"reflect"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/utils/ptr"
 
 	configapi "github.com/ray-project/kuberay/ray-operator/apis/config/v1alpha1"
@@ -22,6 +25,7 @@ import (
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 	"github.com/ray-project/kuberay/ray-operator/pkg/features"
 
+	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 
@@ -36,14 +40,17 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	k8sruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -53,6 +60,9 @@ type reconcileFunc func(context.Context, *rayv1.RayCluster) error
 
 var (
 	DefaultRequeueDuration = 2 * time.Second
+	// AdmissionDeniedRequeueDuration is how often the creation of an object denied by its server-side dry-run is retried.
+	// The denial lasts until the object or the policy changes, so it isn't retried with the backoff of errors.
+	AdmissionDeniedRequeueDuration = 1 * time.Minute
 
 	// Definition of a index field for pod name
 	podUIDIndexField = "metadata.uid"
@@ -116,16 +126,24 @@ func NewReconciler(ctx context.Context, mgr manager.Manager, options RayClusterR
 
 	// add schema to runtime
 	schedulerMgr.AddToScheme(mgr.GetScheme())
+	journal := utils.NewJournalEventRecorder(mgr.GetEventRecorderFor("raycluster-controller"))
 	return &RayClusterReconciler{
 		Client:            mgr.GetClient(),
 		Scheme:            mgr.GetScheme(),
-		Recorder:          mgr.GetEventRecorderFor("raycluster-controller"),
+		Recorder:          utils.NewDeduplicatingEventRecorder(journal, utils.DefaultEventDeduplicationWindow),
 		BatchSchedulerMgr: schedulerMgr,
 		IsOpenShift:       isOpenShift,
+		journal:           journal,
 
 		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(mgr.GetClient()),
 		headSidecarContainers:      options.HeadSidecarContainers,
 		workerSidecarContainers:    options.WorkerSidecarContainers,
+		injectedEnvPolicy:          options.InjectedEnvPolicy,
+		generatedPodSecurity:       options.GeneratedPodSecurity,
+		podSpecDefaults:            options.PodSpecDefaults,
+		dashboardMetrics:           options.DashboardMetrics,
+		imageArchitectures:         options.ImageArchitectures,
+		dashboardClientFunc:        rayConfigs.GetDashboardClient(mgr),
 	}
 }
 
@@ -136,9 +154,19 @@ type RayClusterReconciler struct {
 	Recorder                   record.EventRecorder
 	BatchSchedulerMgr          *batchscheduler.SchedulerManager
 	rayClusterScaleExpectation expectations.RayClusterScaleExpectation
+	journal                    *utils.JournalEventRecorder
 
 	headSidecarContainers   []corev1.Container
 	workerSidecarContainers []corev1.Container
+	injectedEnvPolicy       *configapi.InjectedEnvPolicy
+	generatedPodSecurity    *configapi.GeneratedPodSecurity
+	podSpecDefaults         *configapi.PodSpecDefaults
+	dashboardMetrics        *configapi.DashboardMetricsPolicy
+	imageArchitectures      *configapi.ImageArchitecturePolicy
+	dashboardClientFunc     func() utils.RayDashboardClientInterface
+	// autoscalerEventIDs are the IDs of the autoscaler events already seen for each RayCluster, keyed by its
+	// NamespacedName.
+	autoscalerEventIDs sync.Map
 
 	IsOpenShift bool
 }
@@ -146,6 +174,11 @@ type RayClusterReconciler struct {
 type RayClusterReconcilerOptions struct {
 	HeadSidecarContainers   []corev1.Container
 	WorkerSidecarContainers []corev1.Container
+	InjectedEnvPolicy       *configapi.InjectedEnvPolicy
+	GeneratedPodSecurity    *configapi.GeneratedPodSecurity
+	PodSpecDefaults         *configapi.PodSpecDefaults
+	DashboardMetrics        *configapi.DashboardMetricsPolicy
+	ImageArchitectures      *configapi.ImageArchitecturePolicy
 }
 
 // Reconcile reads that state of the cluster for a RayCluster object and makes changes based on it
@@ -154,19 +187,30 @@ type RayClusterReconcilerOptions struct {
 // +kubebuilder:rbac:groups=ray.io,resources=rayclusters,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=ray.io,resources=rayclusters/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=ray.io,resources=rayclusters/finalizers,verbs=update
+// +kubebuilder:rbac:groups=ray.io,resources=raynodeprovisioningconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ray.io,resources=rayplacementpolicies,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ray.io,resources=rayquotas,verbs=get;list;watch
+// +kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;delete
 // +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;create;update
 // +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;create;update;patch;delete;deletecollection
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=pods/status,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=pods/ephemeralcontainers,verbs=update;patch
+// +kubebuilder:rbac:groups=core,resources=pods/eviction,verbs=create
 // +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=services/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;create;update
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingressclasses,verbs=get;list;watch
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;delete;patch
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=podmonitors,verbs=get;create;update
 // +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=extensions,resources=ingresses,verbs=get;list;watch;create;update;delete;patch
 // +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;delete
 // +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=roles,verbs=get;list;watch;create;delete;update
-// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=get;list;watch;create;delete;update
 
 // [WARNING]: There MUST be a newline after kubebuilder markers.
 
@@ -178,13 +222,19 @@ func (r *RayClusterReconciler) Reconcile(ctx context.Context, request ctrl.Reque
 	// Try to fetch the RayCluster instance
 	instance := &rayv1.RayCluster{}
 	if err = r.Get(ctx, request.NamespacedName, instance); err == nil {
-		return r.rayClusterReconcile(ctx, instance)
+		ctx = utils.WithLogVerbosity(ctx, instance)
+		result, reconcileErr := r.rayClusterReconcile(ctx, instance)
+		if err := utils.RecordReconcileError(ctx, r.Client, request.NamespacedName, &rayv1.RayCluster{}, reconcileErr); err != nil {
+			logger.Error(err, "Failed to record the reconcile error in the RayCluster status")
+		}
+		return requeueAdmissionDenied(result, reconcileErr)
 	}
 
 	// No match found
 	if errors.IsNotFound(err) {
 		// Clear all related expectations
 		r.rayClusterScaleExpectation.Delete(instance.Name, instance.Namespace)
+		r.autoscalerEventIDs.Delete(request.NamespacedName)
 		logger.Info("Read request instance not found error!")
 	} else {
 		logger.Error(err, "Read request instance error!")
@@ -211,6 +261,92 @@ func (r *RayClusterReconciler) deleteAllPods(ctx context.Context, filters common
 	return pods, nil
 }
 
+// reconcileRemoteWorkerPodsCleanupFinalizer adds the RemoteWorkerPodsCleanupFinalizer to a RayCluster with worker groups
+// in other namespaces, whose Pods can't be garbage collected through owner references, and deletes these Pods once the
+// RayCluster is being deleted. It returns whether the RayCluster should be requeued before reconciling the rest.
+func (r *RayClusterReconciler) reconcileRemoteWorkerPodsCleanupFinalizer(ctx context.Context, instance *rayv1.RayCluster) (bool, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	if instance.DeletionTimestamp.IsZero() {
+		if !common.HasRemoteWorkerGroups(instance) || controllerutil.ContainsFinalizer(instance, utils.RemoteWorkerPodsCleanupFinalizer) {
+			return false, nil
+		}
+		logger.Info("Add a finalizer to delete the worker Pods in other namespaces when the RayCluster is deleted",
+			"finalizer", utils.RemoteWorkerPodsCleanupFinalizer)
+		controllerutil.AddFinalizer(instance, utils.RemoteWorkerPodsCleanupFinalizer)
+		if err := r.Update(ctx, instance); err != nil {
+			return true, fmt.Errorf("failed to add the finalizer %s to the RayCluster: %w", utils.RemoteWorkerPodsCleanupFinalizer, err)
+		}
+		return true, nil
+	}
+	if !controllerutil.ContainsFinalizer(instance, utils.RemoteWorkerPodsCleanupFinalizer) {
+		return false, nil
+	}
+
+	pods := corev1.PodList{}
+	if err := r.List(ctx, &pods, common.RayClusterRemoteWorkerPodsAssociationOptions(instance).ToListOptions()...); err != nil {
+		return true, err
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if err := r.Delete(ctx, pod); client.IgnoreNotFound(err) != nil {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteWorkerPod), "Failed deleting worker Pod %s/%s, %v", pod.Namespace, pod.Name, err)
+			return true, err
+		}
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedWorkerPod), "Deleted worker Pod %s/%s", pod.Namespace, pod.Name)
+	}
+	if len(pods.Items) > 0 {
+		logger.Info("Wait for the worker Pods in other namespaces to be deleted", "count", len(pods.Items))
+		return true, nil
+	}
+
+	controllerutil.RemoveFinalizer(instance, utils.RemoteWorkerPodsCleanupFinalizer)
+	if err := r.Update(ctx, instance); err != nil {
+		return true, err
+	}
+	return true, nil
+}
+
+// fastDeleteRayCluster deletes the Pods of the RayCluster, which is being deleted, without a grace period so that the Ray
+// nodes aren't drained, and removes the finalizers of KubeRay without waiting for the Pods nor cleaning up Redis.
+func (r *RayClusterReconciler) fastDeleteRayCluster(ctx context.Context, instance *rayv1.RayCluster) error {
+	logger := ctrl.LoggerFrom(ctx)
+	if err := r.deleteHeadStatefulSet(ctx, instance); err != nil {
+		return err
+	}
+	deleteOptions := append(common.RayClusterAllPodsAssociationOptions(instance).ToDeleteOptions(), client.GracePeriodSeconds(0))
+	if err := r.DeleteAllOf(ctx, &corev1.Pod{}, deleteOptions...); err != nil {
+		return err
+	}
+	if controllerutil.ContainsFinalizer(instance, utils.RemoteWorkerPodsCleanupFinalizer) {
+		pods := corev1.PodList{}
+		if err := r.List(ctx, &pods, common.RayClusterRemoteWorkerPodsAssociationOptions(instance).ToListOptions()...); err != nil {
+			return err
+		}
+		for i := range pods.Items {
+			if err := r.Delete(ctx, &pods.Items[i], client.GracePeriodSeconds(0)); client.IgnoreNotFound(err) != nil {
+				return err
+			}
+		}
+	}
+
+	if !controllerutil.ContainsFinalizer(instance, utils.GCSFaultToleranceRedisCleanupFinalizer) &&
+		!controllerutil.ContainsFinalizer(instance, utils.RemoteWorkerPodsCleanupFinalizer) {
+		return nil
+	}
+	logger.Info("Fast deletion is enabled. Remove the finalizers without cleaning up Redis nor waiting for the Pods.")
+	controllerutil.RemoveFinalizer(instance, utils.GCSFaultToleranceRedisCleanupFinalizer)
+	controllerutil.RemoveFinalizer(instance, utils.RemoteWorkerPodsCleanupFinalizer)
+	if err := r.Update(ctx, instance); err != nil {
+		return err
+	}
+	r.Recorder.Event(instance, corev1.EventTypeNormal, string(utils.SkippedGracefulDeletion),
+		"Deleted the Pods without draining them and removed the finalizers of KubeRay because fast deletion is enabled")
+	return nil
+}
+
 func validateRayClusterStatus(instance *rayv1.RayCluster) error {
 	suspending := meta.IsStatusConditionTrue(instance.Status.Conditions, string(rayv1.RayClusterSuspending))
 	suspended := meta.IsStatusConditionTrue(instance.Status.Conditions, string(rayv1.RayClusterSuspended))
@@ -226,10 +362,47 @@ func validateRayClusterSpec(instance *rayv1.RayCluster) error {
 		return fmt.Errorf("headGroupSpec should have at least one container")
 	}
 
+	workerToDeleteGroups := map[string]string{}
 	for _, workerGroup := range instance.Spec.WorkerGroupSpecs {
 		if len(workerGroup.Template.Spec.Containers) == 0 {
 			return fmt.Errorf("workerGroupSpec should have at least one container")
 		}
+		// A Pod belongs to a single worker group, so a Pod of WorkersToDelete listed by several groups reveals a bug
+		// of the autoscaler.
+		for _, podName := range workerGroup.ScaleStrategy.WorkersToDelete {
+			if podName == "" {
+				return fmt.Errorf("the workersToDelete of worker group %s contains an empty Pod name", workerGroup.GroupName)
+			}
+			if groupName, ok := workerToDeleteGroups[podName]; ok && groupName != workerGroup.GroupName {
+				return fmt.Errorf("the Pod %s is in the workersToDelete of both worker groups %s and %s", podName, groupName, workerGroup.GroupName)
+			}
+			workerToDeleteGroups[podName] = workerGroup.GroupName
+		}
+		if common.IsRemoteWorkerGroup(instance, workerGroup) && !features.Enabled(features.RayMultiNamespaceWorkerGroups) {
+			return fmt.Errorf("the namespace of worker group %s requires the %s feature gate", workerGroup.GroupName, features.RayMultiNamespaceWorkerGroups)
+		}
+		if common.GetWorkerGroupUpdateStrategyType(workerGroup) == rayv1.RollingUpdateWorkerGroupUpdateStrategyType {
+			if workerGroup.NumOfHosts > 1 {
+				return fmt.Errorf("the RollingUpdate strategy of worker group %s isn't supported with numOfHosts > 1", workerGroup.GroupName)
+			}
+			if _, _, err := common.GetRollingUpdateLimits(workerGroup, 1); err != nil {
+				return fmt.Errorf("the RollingUpdate strategy of worker group %s is invalid: %w", workerGroup.GroupName, err)
+			}
+		}
+	}
+
+	if policy := instance.Spec.TmpDirPolicy; policy != nil {
+		if policy.Type == rayv1.TmpDirTypeEphemeralVolume && policy.Size == nil {
+			return fmt.Errorf("the size of the tmpDirPolicy is required for the %s type", rayv1.TmpDirTypeEphemeralVolume)
+		}
+		if _, ok := instance.Spec.HeadGroupSpec.RayStartParams["temp-dir"]; ok {
+			return fmt.Errorf("tmpDirPolicy and the temp-dir rayStartParam of the head group are both set. " +
+				"Please use only one of them to configure the Ray temporary directory")
+		}
+	}
+
+	if err := common.ValidateMetadataSync(instance.Spec.MetadataSync); err != nil {
+		return fmt.Errorf("metadataSync is invalid: %w", err)
 	}
 
 	if instance.Annotations[utils.RayFTEnabledAnnotationKey] != "" && instance.Spec.GcsFaultToleranceOptions != nil {
@@ -268,10 +441,48 @@ func validateRayClusterSpec(instance *rayv1.RayCluster) error {
 		}
 	}
 
-	if !features.Enabled(features.RayJobDeletionPolicy) {
+	if instance.Spec.HeadGroupSpec.EnableColdStandby != nil && *instance.Spec.HeadGroupSpec.EnableColdStandby &&
+		!features.Enabled(features.RayHeadColdStandby) {
+		return fmt.Errorf("the standby head Pod is currently available when the RayHeadColdStandby feature gate is enabled")
+	}
+
+	if instance.Spec.HeadGroupSpec.StatefulSet != nil {
+		if !features.Enabled(features.RayHeadStatefulSet) {
+			return fmt.Errorf("the StatefulSet of the head Pod is currently available when the RayHeadStatefulSet feature gate is enabled")
+		}
+		if common.IsColdStandbyEnabled(instance) {
+			return fmt.Errorf("headGroupSpec.statefulSet can't be used with headGroupSpec.enableColdStandby")
+		}
+	}
+
+	if instance.Spec.HeadGroupSpec.ExistingHeadServiceName != "" &&
+		(instance.Spec.HeadGroupSpec.HeadService != nil || instance.Spec.HeadGroupSpec.ServicePorts != nil) {
+		return fmt.Errorf("headGroupSpec.existingHeadServiceName can't be used with headGroupSpec.headService or headGroupSpec.servicePorts")
+	}
+
+	if servicePorts := instance.Spec.HeadGroupSpec.ServicePorts; servicePorts != nil {
+		if slices.Contains(servicePorts.Exclude, utils.GcsServerPortName) {
+			return fmt.Errorf("the %s port can't be excluded from the head service", utils.GcsServerPortName)
+		}
+		names := map[string]bool{}
+		for _, port := range servicePorts.Additional {
+			if port.Name == "" {
+				return fmt.Errorf("the additional ports of the head service should have a name")
+			}
+			if names[port.Name] {
+				return fmt.Errorf("the additional port %s of the head service is duplicated", port.Name)
+			}
+			names[port.Name] = true
+		}
+		if err := common.ValidateHeadContainerServicePorts(*instance); err != nil {
+			return err
+		}
+	}
+
+	if !features.Enabled(features.RayJobDeletionPolicy) && !features.Enabled(features.RayWorkerGroupSuspend) {
 		for _, workerGroup := range instance.Spec.WorkerGroupSpecs {
 			if workerGroup.Suspend != nil && *workerGroup.Suspend {
-				return fmt.Errorf("suspending worker groups is currently available when the RayJobDeletionPolicy feature gate is enabled")
+				return fmt.Errorf("suspending worker groups is currently available when the RayJobDeletionPolicy or RayWorkerGroupSuspend feature gate is enabled")
 			}
 		}
 	}
@@ -284,7 +495,8 @@ func validateRayClusterSpec(instance *rayv1.RayCluster) error {
 			}
 		}
 	}
-	return nil
+
+	return rayv1.CheckRayVersionCompatibility(&instance.Spec)
 }
 
 func (r *RayClusterReconciler) rayClusterReconcile(ctx context.Context, instance *rayv1.RayCluster) (ctrl.Result, error) {
@@ -296,6 +508,21 @@ func (r *RayClusterReconciler) rayClusterReconcile(ctx context.Context, instance
 		return ctrl.Result{}, nil
 	}
 
+	// The RayClusters being deleted are still reconciled, so that their finalizers don't block the deletion.
+	if instance.DeletionTimestamp.IsZero() {
+		if selected, err := utils.IsNamespaceSelected(ctx, r.Client, instance.Namespace); err != nil || !selected {
+			if err == nil {
+				logger.Info("Skipping RayCluster in a namespace not selected by the watch namespace selector")
+			}
+			return ctrl.Result{}, err
+		}
+	}
+
+	// The spec of a clone is only a placeholder until it's copied from the source RayCluster, so it's not validated.
+	if _, ok := instance.Annotations[utils.RayCloneFromAnnotationKey]; ok {
+		return r.reconcileClone(ctx, instance)
+	}
+
 	if err := validateRayClusterSpec(instance); err != nil {
 		logger.Error(err, fmt.Sprintf("The RayCluster spec is invalid %s/%s", instance.Namespace, instance.Name))
 		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.InvalidRayClusterSpec),
@@ -313,6 +540,17 @@ func (r *RayClusterReconciler) rayClusterReconcile(ctx context.Context, instance
 	// Please do NOT modify `originalRayClusterInstance` in the following code.
 	originalRayClusterInstance := instance.DeepCopy()
 
+	if !instance.DeletionTimestamp.IsZero() && utils.IsFastDeletionEnabled(ctx, r.Client, instance.Namespace) {
+		if err := r.fastDeleteRayCluster(ctx, instance); err != nil {
+			return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+		}
+		return ctrl.Result{}, nil
+	}
+
+	if requeue, err := r.reconcileRemoteWorkerPodsCleanupFinalizer(ctx, instance); err != nil || requeue {
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+	}
+
 	// The `enableGCSFTRedisCleanup` is a feature flag introduced in KubeRay v1.0.0. It determines whether
 	// the Redis cleanup job should be activated. Users can disable the feature by setting the environment
 	// variable `ENABLE_GCS_FT_REDIS_CLEANUP` to `false`, and undertake the Redis storage namespace cleanup
@@ -340,7 +578,10 @@ func (r *RayClusterReconciler) rayClusterReconcile(ctx context.Context, instance
 				"deletionTimestamp", instance.ObjectMeta.DeletionTimestamp,
 			)
 
-			// Delete the head Pod if it exists.
+			// Delete the head Pod if it exists. The StatefulSet of the head Pod is deleted first so that it doesn't recreate the Pod.
+			if err := r.deleteHeadStatefulSet(ctx, instance); err != nil {
+				return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+			}
 			headPods, err := r.deleteAllPods(ctx, common.RayClusterHeadPodsAssociationOptions(instance))
 			if err != nil {
 				return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
@@ -420,14 +661,27 @@ func (r *RayClusterReconciler) rayClusterReconcile(ctx context.Context, instance
 	}
 
 	reconcileFuncs := []reconcileFunc{
+		r.reconcileHostNetworkPorts,
+		r.reconcileDedicatedServiceAccount,
 		r.reconcileAutoscalerServiceAccount,
 		r.reconcileAutoscalerRole,
 		r.reconcileAutoscalerRoleBinding,
 		r.reconcileIngress,
+		r.reconcileDashboardIngress,
 		r.reconcileHeadService,
 		r.reconcileHeadlessService,
 		r.reconcileServeService,
+		r.reconcilePodMonitors,
+		r.reconcileRayQuota,
+		r.reconcileImagePrePull,
+		r.reconcileMemoryFailures,
 		r.reconcilePods,
+		r.reconcileMetadataSync,
+		r.reportUnschedulableWorkerPods,
+		r.reconcilePendingResourceDemands,
+		r.reconcileAutoscalerEvents,
+		r.reconcileKarpenterConsolidation,
+		r.reconcileProfiling,
 	}
 
 	for _, fn := range reconcileFuncs {
@@ -438,6 +692,13 @@ func (r *RayClusterReconciler) rayClusterReconcile(ctx context.Context, instance
 		}
 	}
 
+	if reconcileErr == nil {
+		var deleted bool
+		if deleted, reconcileErr = r.reconcileIdleTimeout(ctx, instance); deleted {
+			return ctrl.Result{}, nil
+		}
+	}
+
 	// Calculate the new status for the RayCluster. Note that the function will deep copy `instance` instead of mutating it.
 	newInstance, calculateErr := r.calculateStatus(ctx, instance, reconcileErr)
 	var updateErr error
@@ -476,8 +737,111 @@ func (r *RayClusterReconciler) rayClusterReconcile(ctx context.Context, instance
 		)
 		requeueAfterSeconds = utils.RAYCLUSTER_DEFAULT_REQUEUE_SECONDS
 	}
-	logger.Info("Unconditional requeue after", "seconds", requeueAfterSeconds)
-	return ctrl.Result{RequeueAfter: time.Duration(requeueAfterSeconds) * time.Second}, nil
+	requeueAfter := time.Duration(requeueAfterSeconds) * time.Second
+	if features.Enabled(features.RayClusterPendingResourceDemands) && utils.IsAutoscalingEnabled(instance) {
+		requeueAfter = min(requeueAfter, utils.RayClusterPendingResourceDemandsRequeueDuration)
+	}
+	if instance.Spec.IdleTimeoutSeconds != nil {
+		requeueAfter = min(requeueAfter, utils.RayClusterIdleTimeoutRequeueDuration)
+	}
+	if features.Enabled(features.RayKarpenterConsolidation) && hasKarpenterConsolidationWorkers(ctx, instance) {
+		requeueAfter = min(requeueAfter, utils.RayClusterKarpenterConsolidationRequeueDuration)
+	}
+	if condition := meta.FindStatusCondition(instance.Status.Conditions, string(rayv1.RayClusterQuotaExceeded)); condition != nil &&
+		condition.Status == metav1.ConditionTrue && condition.Reason == rayv1.RayQuotaQueued {
+		requeueAfter = min(requeueAfter, utils.RayClusterQuotaRequeueDuration)
+	}
+	if isImagePrePullPending(instance) {
+		requeueAfter = min(requeueAfter, utils.RayClusterImagePrePullRequeueDuration)
+	}
+	if slices.ContainsFunc(instance.Spec.WorkerGroupSpecs, common.IsGracefulDrainEnabled) {
+		requeueAfter = min(requeueAfter, utils.RayClusterGracefulDrainRequeueDuration)
+	}
+	logger.Info("Unconditional requeue after", "seconds", requeueAfter.Seconds())
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// reconcileClone copies the spec and the labels of the RayCluster referenced by the RayCloneFromAnnotationKey
+// annotation to the instance. The reconciliation of the instance starts once it's updated.
+func (r *RayClusterReconciler) reconcileClone(ctx context.Context, instance *rayv1.RayCluster) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	sourceName, err := common.ParseCloneSource(instance.Annotations[utils.RayCloneFromAnnotationKey], instance.Namespace)
+	if err != nil {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToCloneRayCluster),
+			"The %s annotation is invalid: %v", utils.RayCloneFromAnnotationKey, err)
+		return ctrl.Result{}, nil
+	}
+
+	source := &rayv1.RayCluster{}
+	if err := r.Get(ctx, sourceName, source); err != nil {
+		if errors.IsNotFound(err) {
+			logger.Info("The RayCluster to clone doesn't exist yet", "source", sourceName)
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToCloneRayCluster),
+				"The RayCluster %s to clone doesn't exist", sourceName)
+			return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, nil
+		}
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+	}
+	if !common.IsCloneAllowed(source, instance.Namespace) {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToCloneRayCluster),
+			"The RayCluster %s can't be cloned into the namespace %s, which isn't listed in its %s annotation",
+			sourceName, instance.Namespace, utils.RayCloneToNamespacesAnnotationKey)
+		return ctrl.Result{}, nil
+	}
+
+	common.CloneRayCluster(instance, source)
+	if err := r.Update(ctx, instance); err != nil {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToCloneRayCluster),
+			"Failed to clone the RayCluster %s: %v", sourceName, err)
+		return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, err
+	}
+	logger.Info("Cloned the RayCluster", "source", sourceName)
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.ClonedRayCluster),
+		"Cloned the RayCluster %s", sourceName)
+	return ctrl.Result{RequeueAfter: DefaultRequeueDuration}, nil
+}
+
+// reconcileMetadataSync patches the labels and the annotations of the MetadataSync of the RayCluster onto its existing
+// Pods and Services whose values differ.
+func (r *RayClusterReconciler) reconcileMetadataSync(ctx context.Context, instance *rayv1.RayCluster) error {
+	if instance.Spec.MetadataSync == nil {
+		return nil
+	}
+	logger := ctrl.LoggerFrom(ctx)
+
+	pods := corev1.PodList{}
+	if err := r.List(ctx, &pods, common.RayClusterAllPodsAssociationOptions(instance).ToListOptions()...); err != nil {
+		return err
+	}
+	services := corev1.ServiceList{}
+	if err := r.List(ctx, &services, common.RayClusterNetworkResourcesOptions(instance).ToListOptions()...); err != nil {
+		return err
+	}
+	var objects []client.Object
+	for i := range pods.Items {
+		if pods.Items[i].DeletionTimestamp.IsZero() {
+			objects = append(objects, &pods.Items[i])
+		}
+	}
+	for i := range services.Items {
+		objects = append(objects, &services.Items[i])
+	}
+
+	for _, object := range objects {
+		original := object.DeepCopyObject().(client.Object)
+		if !common.SyncMetadata(object, instance) {
+			continue
+		}
+		if err := r.Patch(ctx, object, client.MergeFrom(original)); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("failed to sync the metadata of %s: %w", object.GetName(), err)
+		}
+		logger.Info("Synced the labels and the annotations of the RayCluster", "name", object.GetName(),
+			"kind", object.GetObjectKind().GroupVersionKind().Kind)
+	}
+	return nil
 }
 
 // Checks whether the old and new RayClusterStatus are inconsistent by comparing different fields. If the only
@@ -533,6 +897,22 @@ func (r *RayClusterReconciler) inconsistentRayClusterStatus(ctx context.Context,
 		logger.Info("inconsistentRayClusterStatus", "old conditions", oldStatus.Conditions, "new conditions", newStatus.Conditions)
 		return true
 	}
+	if !resourceDemandsEqual(oldStatus.PendingResourceDemands, newStatus.PendingResourceDemands) {
+		logger.Info("inconsistentRayClusterStatus", "oldPendingResourceDemands", oldStatus.PendingResourceDemands, "newPendingResourceDemands", newStatus.PendingResourceDemands)
+		return true
+	}
+	if !oldStatus.LastActivityTime.Equal(newStatus.LastActivityTime) {
+		logger.Info("inconsistentRayClusterStatus", "oldLastActivityTime", oldStatus.LastActivityTime, "newLastActivityTime", newStatus.LastActivityTime)
+		return true
+	}
+	if !reflect.DeepEqual(oldStatus.MemoryFailures, newStatus.MemoryFailures) {
+		logger.Info("inconsistentRayClusterStatus", "oldMemoryFailures", oldStatus.MemoryFailures, "newMemoryFailures", newStatus.MemoryFailures)
+		return true
+	}
+	if !reflect.DeepEqual(oldStatus.Journal, newStatus.Journal) {
+		logger.Info("inconsistentRayClusterStatus", "oldJournal", oldStatus.Journal, "newJournal", newStatus.Journal)
+		return true
+	}
 	return false
 }
 
@@ -613,9 +993,174 @@ func (r *RayClusterReconciler) reconcileIngressKubernetes(ctx context.Context, i
 	return nil
 }
 
+// reconcileDashboardIngress reconciles the Ingress configured by `spec.dashboardIngress`. The dashboard Ingress of a
+// RayCluster created by a RayService is managed by the RayService instead.
+func (r *RayClusterReconciler) reconcileDashboardIngress(ctx context.Context, instance *rayv1.RayCluster) error {
+	if utils.GetCRDType(instance.Labels[utils.RayOriginatedFromCRDLabelKey]) == utils.RayServiceCRD {
+		return nil
+	}
+
+	var desired *networkingv1.Ingress
+	if instance.Spec.DashboardIngress != nil {
+		headSvcName, err := utils.GenerateHeadServiceName(utils.RayClusterCRD, instance.Spec, instance.Name)
+		if err != nil {
+			return err
+		}
+		desired = common.BuildDashboardIngress(*instance, instance.Name, instance.Namespace, map[string]string{
+			utils.RayOriginatedFromCRNameLabelKey: instance.Name,
+			utils.RayOriginatedFromCRDLabelKey:    utils.RayOriginatedFromCRDLabelValue(utils.RayClusterCRD),
+			utils.KubernetesCreatedByLabelKey:     utils.ComponentName,
+		}, headSvcName)
+		if err := ctrl.SetControllerReference(instance, desired, r.Scheme); err != nil {
+			return err
+		}
+	}
+	return reconcileDashboardIngress(ctx, r.Client, r.Recorder, instance, utils.GenerateDashboardIngressName(instance.Name), desired)
+}
+
+// reconcileDashboardIngress creates or updates the dashboard Ingress of the owner to match the desired Ingress, and
+// deletes it if the desired Ingress is nil.
+func reconcileDashboardIngress(ctx context.Context, c client.Client, recorder record.EventRecorder, owner client.Object, name string, desired *networkingv1.Ingress) error {
+	logger := ctrl.LoggerFrom(ctx)
+
+	existing := &networkingv1.Ingress{}
+	err := c.Get(ctx, client.ObjectKey{Namespace: owner.GetNamespace(), Name: name}, existing)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	exists := err == nil
+	if exists && !metav1.IsControlledBy(existing, owner) {
+		return fmt.Errorf("the dashboard Ingress %s/%s isn't controlled by %s", existing.Namespace, existing.Name, owner.GetName())
+	}
+
+	if desired == nil {
+		if !exists {
+			return nil
+		}
+		logger.Info("Deleting the dashboard Ingress", "name", existing.Name)
+		return client.IgnoreNotFound(c.Delete(ctx, existing))
+	}
+
+	if !exists {
+		if err := c.Create(ctx, desired); err != nil {
+			recorder.Eventf(owner, corev1.EventTypeWarning, string(utils.FailedToCreateIngress), "Failed creating ingress %s/%s, %v", desired.Namespace, desired.Name, err)
+			return err
+		}
+		logger.Info("Created the dashboard Ingress", "name", desired.Name)
+		recorder.Eventf(owner, corev1.EventTypeNormal, string(utils.CreatedIngress), "Created ingress %s/%s", desired.Namespace, desired.Name)
+		return nil
+	}
+
+	if reflect.DeepEqual(existing.Spec, desired.Spec) && reflect.DeepEqual(existing.Annotations, desired.Annotations) {
+		return nil
+	}
+	existing.Spec = desired.Spec
+	existing.Annotations = desired.Annotations
+	if err := c.Update(ctx, existing); err != nil {
+		return err
+	}
+	logger.Info("Updated the dashboard Ingress", "name", existing.Name)
+	recorder.Eventf(owner, corev1.EventTypeNormal, string(utils.UpdatedIngress), "Updated ingress %s/%s", existing.Namespace, existing.Name)
+	return nil
+}
+
+// cleanUpControlledObjects deletes the objects controlled by the owner, which is being deleted, and returns whether the
+// cleanup is finished, i.e. all of them are gone or the cleanup timed out. The objects only need their name and
+// namespace set, and are skipped if they don't exist or their kind isn't installed. The condition of the conditionType
+// is set to the progress of the cleanup.
+func cleanUpControlledObjects(ctx context.Context, c client.Client, recorder record.EventRecorder, owner client.Object, conditions *[]metav1.Condition, conditionType string, objects ...client.Object) (bool, error) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	var remaining []string
+	for _, obj := range objects {
+		if err := c.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+				continue
+			}
+			return false, err
+		}
+		if !metav1.IsControlledBy(obj, owner) {
+			continue
+		}
+		remaining = append(remaining, obj.GetName())
+		if obj.GetDeletionTimestamp() != nil {
+			continue
+		}
+		logger.Info("Deleting the object created for the custom resource", "name", obj.GetName())
+		if err := c.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return false, err
+		}
+	}
+
+	if len(remaining) == 0 {
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:    conditionType,
+			Status:  metav1.ConditionTrue,
+			Reason:  rayv1.CleanupCompleted,
+			Message: "The objects created for the custom resource are deleted",
+		})
+		return true, nil
+	}
+	if common.IsCleanupTimedOut(owner, time.Now()) {
+		message := fmt.Sprintf("Gave up waiting for %s to be deleted after %v", strings.Join(remaining, ", "), common.GetCleanupTimeout(owner))
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:    conditionType,
+			Status:  metav1.ConditionFalse,
+			Reason:  rayv1.CleanupTimedOut,
+			Message: message,
+		})
+		recorder.Event(owner, corev1.EventTypeWarning, string(utils.CleanupTimedOut), message)
+		return true, nil
+	}
+	meta.SetStatusCondition(conditions, metav1.Condition{
+		Type:    conditionType,
+		Status:  metav1.ConditionFalse,
+		Reason:  rayv1.CleanupInProgress,
+		Message: fmt.Sprintf("Waiting for %s to be deleted", strings.Join(remaining, ", ")),
+	})
+	return false, nil
+}
+
+// dryRunCreate runs the server-side dry-run of the creation of the object if the RayDryRunChildObjects feature gate is
+// enabled, and emits an AdmissionDenied event for the owner if it's denied.
+func dryRunCreate(ctx context.Context, c client.Client, recorder record.EventRecorder, owner client.Object, obj client.Object) error {
+	if !features.Enabled(features.RayDryRunChildObjects) {
+		return nil
+	}
+	err := utils.DryRunCreate(ctx, c, obj)
+	if denied := utils.GetAdmissionDeniedError(err); denied != nil {
+		recorder.Event(owner, corev1.EventTypeWarning, string(utils.AdmissionDenied), denied.Error())
+	}
+	return err
+}
+
+// requeueAdmissionDenied returns the result of a reconciliation that returned an AdmissionDeniedError, which is
+// retried after AdmissionDeniedRequeueDuration instead of being returned. The other results are returned unchanged.
+func requeueAdmissionDenied(result ctrl.Result, reconcileErr error) (ctrl.Result, error) {
+	if utils.GetAdmissionDeniedError(reconcileErr) != nil {
+		return ctrl.Result{RequeueAfter: AdmissionDeniedRequeueDuration}, nil
+	}
+	return result, reconcileErr
+}
+
 // Return nil only when the head service successfully created or already exists.
 func (r *RayClusterReconciler) reconcileHeadService(ctx context.Context, instance *rayv1.RayCluster) error {
 	logger := ctrl.LoggerFrom(ctx)
+	if name := instance.Spec.HeadGroupSpec.ExistingHeadServiceName; name != "" {
+		// The existing head service is managed by users, so KubeRay only validates it.
+		svc := &corev1.Service{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: name}, svc); err != nil {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.InvalidExistingHeadService),
+				"Failed to get the existing head service %s/%s: %v", instance.Namespace, name, err)
+			return err
+		}
+		if err := common.ValidateExistingHeadService(*instance, svc); err != nil {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.InvalidExistingHeadService), "%v", err)
+			return err
+		}
+		return nil
+	}
+
 	services := corev1.ServiceList{}
 	filterLabels := common.RayClusterHeadServiceListOptions(instance)
 
@@ -688,12 +1233,59 @@ func (r *RayClusterReconciler) reconcileServeService(ctx context.Context, instan
 		if err := ctrl.SetControllerReference(instance, svc, r.Scheme); err != nil {
 			return err
 		}
+		if err := dryRunCreate(ctx, r.Client, r.Recorder, instance, svc); err != nil {
+			return err
+		}
 		// create service
 		return r.Create(ctx, svc)
 	}
 	return err
 }
 
+// reconcilePodMonitors creates or updates the PodMonitors of the head and worker Pods. The PodMonitors are only looked
+// up when `prometheusMonitoring` is set to avoid requests for the Prometheus Operator resources, which may not be
+// installed.
+func (r *RayClusterReconciler) reconcilePodMonitors(ctx context.Context, instance *rayv1.RayCluster) error {
+	logger := ctrl.LoggerFrom(ctx)
+	if instance.Spec.PrometheusMonitoring == nil {
+		return nil
+	}
+
+	for _, nodeType := range []rayv1.RayNodeType{rayv1.HeadNode, rayv1.WorkerNode} {
+		newMonitor := common.BuildPodMonitor(*instance, nodeType)
+		oldMonitor := &unstructured.Unstructured{}
+		oldMonitor.SetGroupVersionKind(common.PodMonitorGroupVersionKind)
+		err := r.Get(ctx, client.ObjectKey{Name: newMonitor.GetName(), Namespace: newMonitor.GetNamespace()}, oldMonitor)
+		if meta.IsNoMatchError(err) {
+			logger.Info("Skip creating the PodMonitors because the Prometheus Operator CRDs aren't installed")
+			return nil
+		}
+		if errors.IsNotFound(err) {
+			logger.Info("Create the PodMonitor", "name", newMonitor.GetName())
+			if err := ctrl.SetControllerReference(instance, newMonitor, r.Scheme); err != nil {
+				return err
+			}
+			if err := r.Create(ctx, newMonitor); err != nil {
+				return err
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if reflect.DeepEqual(oldMonitor.Object["spec"], newMonitor.Object["spec"]) && reflect.DeepEqual(oldMonitor.GetLabels(), newMonitor.GetLabels()) {
+			continue
+		}
+		logger.Info("Update the PodMonitor", "name", newMonitor.GetName())
+		oldMonitor.Object["spec"] = newMonitor.Object["spec"]
+		oldMonitor.SetLabels(newMonitor.GetLabels())
+		if err := r.Update(ctx, oldMonitor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Return nil only when the headless service for multi-host worker groups is successfully created or already exists.
 func (r *RayClusterReconciler) reconcileHeadlessService(ctx context.Context, instance *rayv1.RayCluster) error {
 	// Check if there are worker groups with NumOfHosts > 1 in the cluster
@@ -736,6 +1328,9 @@ func (r *RayClusterReconciler) reconcilePods(ctx context.Context, instance *rayv
 	statusConditionGateEnabled := features.Enabled(features.RayClusterStatusConditions)
 	if suspendStatus == rayv1.RayClusterSuspending ||
 		(!statusConditionGateEnabled && instance.Spec.Suspend != nil && *instance.Spec.Suspend) {
+		if err := r.deleteHeadStatefulSet(ctx, instance); err != nil {
+			return errstd.Join(utils.ErrFailedDeleteAllPods, err)
+		}
 		if _, err := r.deleteAllPods(ctx, common.RayClusterAllPodsAssociationOptions(instance)); err != nil {
 			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeletePodCollection),
 				"Failed deleting Pods due to suspension for RayCluster %s/%s, %v",
@@ -759,6 +1354,14 @@ func (r *RayClusterReconciler) reconcilePods(ctx context.Context, instance *rayv
 		}
 	}
 
+	if meta.IsStatusConditionTrue(instance.Status.Conditions, string(rayv1.RayClusterQuotaExceeded)) {
+		return nil // stop reconcilePods because the cluster doesn't fit in the RayQuotas of its namespace.
+	}
+
+	if isImagePrePullPending(instance) {
+		return nil // stop reconcilePods because the images of the cluster are being pre-pulled.
+	}
+
 	// check if all the pods exist
 	headPods := corev1.PodList{}
 	if err := r.List(ctx, &headPods, common.RayClusterHeadPodsAssociationOptions(instance).ToListOptions()...); err != nil {
@@ -794,21 +1397,36 @@ func (r *RayClusterReconciler) reconcilePods(ctx context.Context, instance *rayv
 					headPod.Namespace, headPod.Name, headPod.Status.Phase, headPod.Spec.RestartPolicy, getRayContainerStateTerminated(headPod), err)
 				return errstd.Join(utils.ErrFailedDeleteHeadPod, err)
 			}
-			r.rayClusterScaleExpectation.ExpectScalePod(headPod.Namespace, instance.Name, expectations.HeadGroup, headPod.Name, expectations.Delete)
+			// The StatefulSet of the head Pod recreates the Pod with the same name, so there is nothing to expect.
+			if !common.IsHeadStatefulSetEnabled(instance) {
+				r.rayClusterScaleExpectation.ExpectScalePod(headPod.Namespace, instance.Name, expectations.HeadGroup, headPod.Name, expectations.Delete)
+			}
 			r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedHeadPod),
 				"Deleted head Pod %s/%s; Pod status: %s; Pod restart policy: %s; Ray container terminated status: %v",
 				headPod.Namespace, headPod.Name, headPod.Status.Phase, headPod.Spec.RestartPolicy, getRayContainerStateTerminated(headPod))
 			return errstd.New(reason)
 		}
-	} else if len(headPods.Items) == 0 {
-		// Create head Pod if it does not exist.
-		logger.Info("reconcilePods: Found 0 head Pods; creating a head Pod for the RayCluster.")
-		common.CreatedClustersCounterInc(instance.Namespace)
-		if err := r.createHeadPod(ctx, *instance); err != nil {
-			common.FailedClustersCounterInc(instance.Namespace)
+	} else if len(headPods.Items) == 0 && common.IsHeadStatefulSetEnabled(instance) {
+		// Create the StatefulSet of the head Pod if it does not exist. Otherwise, the StatefulSet creates the head Pod.
+		if err := r.reconcileHeadStatefulSet(ctx, instance); err != nil {
 			return errstd.Join(utils.ErrFailedCreateHeadPod, err)
 		}
-		common.SuccessfulClustersCounterInc(instance.Namespace)
+	} else if len(headPods.Items) == 0 {
+		// Promote the standby head Pod if there is one, because it is already scheduled and has pulled the image.
+		promoted, err := r.promoteHeadStandbyPod(ctx, instance)
+		if err != nil {
+			return err
+		}
+		if !promoted {
+			// Create head Pod if it does not exist.
+			logger.Info("reconcilePods: Found 0 head Pods; creating a head Pod for the RayCluster.")
+			common.CreatedClustersCounterInc(instance.Namespace)
+			if err := r.createHeadPod(ctx, *instance); err != nil {
+				common.FailedClustersCounterInc(instance.Namespace)
+				return errstd.Join(utils.ErrFailedCreateHeadPod, err)
+			}
+			common.SuccessfulClustersCounterInc(instance.Namespace)
+		}
 	} else if len(headPods.Items) > 1 {
 		logger.Info("reconcilePods: Found more than one head Pods; deleting extra head Pods.", "nHeadPods", len(headPods.Items))
 		// TODO (kevin85421): In-place update may not be a good idea.
@@ -830,9 +1448,13 @@ func (r *RayClusterReconciler) reconcilePods(ctx context.Context, instance *rayv
 		}
 	}
 
+	if err := r.reconcileHeadStandbyPod(ctx, instance); err != nil {
+		return err
+	}
+
 	// Reconcile worker pods now
 	for _, worker := range instance.Spec.WorkerGroupSpecs {
-		if !r.rayClusterScaleExpectation.IsSatisfied(ctx, instance.Namespace, instance.Name, worker.GroupName) {
+		if !r.rayClusterScaleExpectation.IsSatisfied(ctx, common.GetWorkerGroupNamespace(instance, worker), instance.Name, worker.GroupName) {
 			logger.Info("reconcilePods", "worker group", worker.GroupName, "Expectation", "NotSatisfiedGroupExpectations, reconcile the group later")
 			continue
 		}
@@ -846,7 +1468,28 @@ func (r *RayClusterReconciler) reconcilePods(ctx context.Context, instance *rayv
 		}
 
 		// Delete all workers if worker group is suspended and skip reconcile
-		if worker.Suspend != nil && *worker.Suspend {
+		if worker.Suspend != nil && *worker.Suspend && common.IsGracefulDrainEnabled(worker) {
+			// The workers are drained one by one, so that the Pods are deleted as soon as they are drained.
+			for _, workerPod := range workerPods.Items {
+				if !workerPod.DeletionTimestamp.IsZero() {
+					continue
+				}
+				if drained, err := r.drainWorkerPod(ctx, instance, worker, &workerPod); err != nil || !drained {
+					if err != nil {
+						return err
+					}
+					continue
+				}
+				if err := r.Delete(ctx, &workerPod); client.IgnoreNotFound(err) != nil {
+					r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteWorkerPod),
+						"Failed deleting drained worker Pod %s/%s for suspended group %s, %v", workerPod.Namespace, workerPod.Name, worker.GroupName, err)
+					return errstd.Join(utils.ErrFailedDeleteWorkerPod, err)
+				}
+				r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedWorkerPod),
+					"Deleted drained worker Pod %s/%s for suspended group %s", workerPod.Namespace, workerPod.Name, worker.GroupName)
+			}
+			continue
+		} else if worker.Suspend != nil && *worker.Suspend {
 			if _, err := r.deleteAllPods(ctx, common.RayClusterGroupPodsAssociationOptions(instance, worker.GroupName)); err != nil {
 				r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteWorkerPodCollection),
 					"Failed deleting worker Pods for suspended group %s in RayCluster %s/%s, %v", worker.GroupName, instance.Namespace, instance.Name, err)
@@ -888,15 +1531,67 @@ func (r *RayClusterReconciler) reconcilePods(ctx context.Context, instance *rayv
 		// Always remove the specified WorkersToDelete - regardless of the value of Replicas.
 		// Essentially WorkersToDelete has to be deleted to meet the expectations of the Autoscaler.
 		logger.Info("reconcilePods", "removing the pods in the scaleStrategy of", worker.GroupName)
+		maxParallelDeletions, numDeletingPods := common.GetMaxParallelDeletions(worker), 0
+		for _, pod := range workerPods.Items {
+			if slices.Contains(worker.ScaleStrategy.WorkersToDelete, pod.Name) && isDeletingWorkerPod(pod) {
+				numDeletingPods++
+			}
+		}
 		for _, podsToDelete := range worker.ScaleStrategy.WorkersToDelete {
 			pod := corev1.Pod{}
 			pod.Name = podsToDelete
-			pod.Namespace = utils.GetNamespace(instance.ObjectMeta)
-			logger.Info("Deleting pod", "namespace", pod.Namespace, "name", pod.Name)
-			if err := r.Delete(ctx, &pod); err != nil {
-				if !errors.IsNotFound(err) {
-					logger.Info("reconcilePods", "Fail to delete Pod", pod.Name, "error", err)
-					r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteWorkerPod), "Failed deleting pod %s/%s, %v", pod.Namespace, pod.Name, err)
+			pod.Namespace = common.GetWorkerGroupNamespace(instance, worker)
+			if i := slices.IndexFunc(workerPods.Items, func(p corev1.Pod) bool { return p.Name == podsToDelete }); i >= 0 {
+				if workerPods.Items[i].DeletionTimestamp != nil {
+					deletedWorkers[pod.Name] = deleted
+					continue
+				}
+				if !isDeletingWorkerPod(workerPods.Items[i]) {
+					if maxParallelDeletions > 0 && numDeletingPods >= maxParallelDeletions {
+						// The Pod will be deleted once the Pods being deleted are gone. It isn't counted as a
+						// running Pod so that no new Pod is created in the meantime.
+						logger.Info("Postponing the deletion of the worker Pod", "pod", pod.Name, "maxParallelDeletions", maxParallelDeletions)
+						deletedWorkers[pod.Name] = deleted
+						continue
+					}
+					numDeletingPods++
+				}
+				drained, err := r.drainWorkerPod(ctx, instance, worker, &workerPods.Items[i])
+				if err != nil {
+					return err
+				}
+				if !drained {
+					// The Pod is being drained and will be deleted, so it isn't counted as a running Pod.
+					deletedWorkers[pod.Name] = deleted
+					continue
+				}
+			} else {
+				reason, err := r.getWorkerToDeleteIgnoredReason(ctx, instance, worker.GroupName, &pod)
+				if err != nil {
+					return err
+				}
+				if reason != "" {
+					// The Pod isn't deleted, since it may be a Pod of another worker group, and is reported instead of
+					// being skipped silently because it usually reveals a bug of the autoscaler.
+					logger.Info("Ignoring the Pod of WorkersToDelete", "pod", pod.Name, "group", worker.GroupName, "reason", reason)
+					r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.IgnoredWorkerToDelete),
+						"Ignored the Pod %s/%s of the workersToDelete of worker group %s: %s", pod.Namespace, pod.Name, worker.GroupName, reason)
+					common.WorkersToDeleteIgnoredCounterInc(instance.Namespace, reason)
+					continue
+				}
+			}
+			logger.Info("Deleting pod", "namespace", pod.Namespace, "name", pod.Name)
+			if err := r.deleteWorkerPod(ctx, worker, &pod); err != nil {
+				if errors.IsTooManyRequests(err) {
+					// The eviction would violate a PodDisruptionBudget, so it is retried later.
+					r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToEvictWorkerPod),
+						"Failed evicting pod %s/%s, the eviction will be retried: %v", pod.Namespace, pod.Name, err)
+					deletedWorkers[pod.Name] = deleted
+					continue
+				}
+				if !errors.IsNotFound(err) {
+					logger.Info("reconcilePods", "Fail to delete Pod", pod.Name, "error", err)
+					r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteWorkerPod), "Failed deleting pod %s/%s, %v", pod.Namespace, pod.Name, err)
 					return errstd.Join(utils.ErrFailedDeleteWorkerPod, err)
 				}
 				logger.Info("reconcilePods", "The worker Pod has already been deleted", pod.Name)
@@ -925,13 +1620,28 @@ func (r *RayClusterReconciler) reconcilePods(ctx context.Context, instance *rayv
 
 		logger.Info("reconcilePods", "workerReplicas", workerReplicas, "NumOfHosts", worker.NumOfHosts, "runningPods", len(runningPods.Items), "diff", diff)
 
+		if updating, err := r.reconcileWorkerGroupUpdate(ctx, instance, worker, runningPods.Items, numExpectedPods); err != nil || updating {
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
 		if diff > 0 {
 			// pods need to be added
 			logger.Info("reconcilePods", "Number workers to add", diff, "Worker group", worker.GroupName)
+			var ranks []int
+			if common.IsRankEnvEnabled(worker) {
+				ranks = common.AllocateWorkerRanks(runningPods.Items, diff)
+			}
 			// create all workers of this group
 			for i := 0; i < diff; i++ {
 				logger.Info("reconcilePods", "creating worker for group", worker.GroupName, "index", i, "total", diff)
-				if err := r.createWorkerPod(ctx, *instance, *worker.DeepCopy()); err != nil {
+				var rank *int
+				if ranks != nil {
+					rank = &ranks[i]
+				}
+				if err := r.createWorkerPod(ctx, *instance, *worker.DeepCopy(), rank); err != nil {
 					return errstd.Join(utils.ErrFailedCreateWorkerPod, err)
 				}
 			}
@@ -960,8 +1670,34 @@ func (r *RayClusterReconciler) reconcilePods(ctx context.Context, instance *rayv
 				// diff < 0 means that we need to delete some Pods to meet the desired number of replicas.
 				randomlyRemovedWorkers := -diff
 				logger.Info("reconcilePods", "Number workers to delete randomly", randomlyRemovedWorkers, "Worker group", worker.GroupName)
+				// Pods that are already being drained are deleted first, so that the drain isn't restarted on other Pods.
+				// The Pods of a burstable worker group on virtual nodes are deleted next, to release the burst capacity.
+				onVirtualNode := r.findPodsOnVirtualNodes(ctx, worker, runningPods.Items)
+				slices.SortStableFunc(runningPods.Items, func(a, b corev1.Pod) int {
+					_, aDraining := a.Annotations[utils.RayDrainDeadlineAnnotationKey]
+					_, bDraining := b.Annotations[utils.RayDrainDeadlineAnnotationKey]
+					if aDraining != bDraining {
+						if aDraining {
+							return -1
+						}
+						return 1
+					}
+					if onVirtualNode[a.Name] != onVirtualNode[b.Name] {
+						if onVirtualNode[a.Name] {
+							return -1
+						}
+						return 1
+					}
+					return 0
+				})
 				for i := 0; i < randomlyRemovedWorkers; i++ {
 					randomPodToDelete := runningPods.Items[i]
+					if drained, err := r.drainWorkerPod(ctx, instance, worker, &randomPodToDelete); err != nil || !drained {
+						if err != nil {
+							return err
+						}
+						continue
+					}
 					logger.Info("Randomly deleting Pod", "progress", fmt.Sprintf("%d / %d", i+1, randomlyRemovedWorkers), "with name", randomPodToDelete.Name)
 					if err := r.Delete(ctx, &randomPodToDelete); err != nil {
 						if !errors.IsNotFound(err) {
@@ -981,6 +1717,240 @@ func (r *RayClusterReconciler) reconcilePods(ctx context.Context, instance *rayv
 	return nil
 }
 
+// reconcileWorkerGroupUpdate replaces the outdated worker Pods of the group according to its update strategy, and
+// returns whether the group is being updated. The group isn't scaled to the desired number of Pods while it's being
+// updated. The outdated Pods are drained before they are deleted if `gracefulDrainSeconds` is set.
+func (r *RayClusterReconciler) reconcileWorkerGroupUpdate(ctx context.Context, instance *rayv1.RayCluster, worker rayv1.WorkerGroupSpec, pods []corev1.Pod, desired int) (bool, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	strategy := common.GetWorkerGroupUpdateStrategyType(worker)
+	if strategy == rayv1.OnDeleteWorkerGroupUpdateStrategyType {
+		return false, nil
+	}
+	templateHash, err := common.GenerateWorkerGroupTemplateHash(worker)
+	if err != nil {
+		return false, err
+	}
+
+	var outdatedPods []corev1.Pod
+	numActive, numAvailable := 0, 0
+	for _, pod := range pods {
+		if !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+		numActive++
+		if utils.IsRunningAndReady(&pod) {
+			numAvailable++
+		}
+		if common.IsWorkerPodOutdated(pod, templateHash) {
+			outdatedPods = append(outdatedPods, pod)
+		}
+	}
+	if len(outdatedPods) == 0 {
+		return false, nil
+	}
+	logger.Info("reconcileWorkerGroupUpdate", "worker group", worker.GroupName, "strategy", strategy,
+		"outdated Pods", len(outdatedPods), "active Pods", numActive, "available Pods", numAvailable, "desired Pods", desired)
+
+	podsToDelete := outdatedPods
+	if strategy == rayv1.RollingUpdateWorkerGroupUpdateStrategyType {
+		maxSurge, maxUnavailable, err := common.GetRollingUpdateLimits(worker, desired)
+		if err != nil {
+			return false, err
+		}
+		// Create new Pods as long as the total number of Pods doesn't exceed the desired number plus the surge, and the
+		// number of new Pods doesn't exceed the desired number. The terminating Pods count towards the total.
+		numToCreate := min(desired+maxSurge-len(pods), desired-(numActive-len(outdatedPods)))
+		var ranks []int
+		if common.IsRankEnvEnabled(worker) && numToCreate > 0 {
+			ranks = common.AllocateWorkerRanks(pods, numToCreate)
+		}
+		for i := 0; i < numToCreate; i++ {
+			var rank *int
+			if ranks != nil {
+				rank = &ranks[i]
+			}
+			if err := r.createWorkerPod(ctx, *instance, *worker.DeepCopy(), rank); err != nil {
+				return false, errstd.Join(utils.ErrFailedCreateWorkerPod, err)
+			}
+		}
+
+		// The unavailable outdated Pods can always be deleted, and the available ones only as long as at least the
+		// desired number minus maxUnavailable Pods stay available.
+		numDeletable := numAvailable - (desired - maxUnavailable)
+		podsToDelete = nil
+		for _, pod := range outdatedPods {
+			if !utils.IsRunningAndReady(&pod) {
+				podsToDelete = append(podsToDelete, pod)
+			} else if numDeletable > 0 {
+				podsToDelete = append(podsToDelete, pod)
+				numDeletable--
+			}
+		}
+	}
+
+	for _, pod := range podsToDelete {
+		if drained, err := r.drainWorkerPod(ctx, instance, worker, &pod); err != nil || !drained {
+			if err != nil {
+				return false, err
+			}
+			continue
+		}
+		if err := r.Delete(ctx, &pod); client.IgnoreNotFound(err) != nil {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteWorkerPod),
+				"Failed deleting outdated worker Pod %s/%s of group %s, %v", pod.Namespace, pod.Name, worker.GroupName, err)
+			return false, errstd.Join(utils.ErrFailedDeleteWorkerPod, err)
+		}
+		r.rayClusterScaleExpectation.ExpectScalePod(pod.Namespace, instance.Name, worker.GroupName, pod.Name, expectations.Delete)
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedWorkerPod),
+			"Deleted outdated worker Pod %s/%s of group %s with the %s update strategy", pod.Namespace, pod.Name, worker.GroupName, strategy)
+	}
+	return true, nil
+}
+
+// drainWorkerPod asks Ray to drain the node of the worker Pod before the Pod is deleted, and returns whether the Pod
+// can be deleted. A Pod can be deleted once its Ray node is no longer alive or the drain deadline has passed. Pods
+// that can't be drained, for example because the head Pod isn't ready, can be deleted right away.
+func (r *RayClusterReconciler) drainWorkerPod(ctx context.Context, instance *rayv1.RayCluster, worker rayv1.WorkerGroupSpec, pod *corev1.Pod) (bool, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	if !common.IsGracefulDrainEnabled(worker) || pod.Status.PodIP == "" {
+		return true, nil
+	}
+	deadlineStr, draining := pod.Annotations[utils.RayDrainDeadlineAnnotationKey]
+	if draining {
+		if deadline, err := time.Parse(time.RFC3339, deadlineStr); err != nil || !time.Now().Before(deadline) {
+			logger.Info("The drain deadline of the worker Pod has passed", "pod", pod.Name, "deadline", deadlineStr)
+			return true, nil
+		}
+	}
+
+	rayDashboardClient, nodes, err := r.listAliveRayNodes(ctx, instance)
+	if err != nil || rayDashboardClient == nil {
+		// Wait for the deadline if the Pod is being drained, and delete the Pod right away otherwise.
+		if !draining {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDrainWorkerPod),
+				"Failed to drain worker Pod %s/%s, the Pod is deleted without draining: %v", pod.Namespace, pod.Name, err)
+		}
+		return !draining, nil
+	}
+	i := slices.IndexFunc(nodes, func(node utils.RayNodeInfo) bool { return utils.IsPodIP(pod, node.NodeIP) })
+	if i < 0 {
+		logger.Info("The Ray node of the worker Pod is no longer alive", "pod", pod.Name)
+		return true, nil
+	}
+	if draining {
+		return false, nil
+	}
+
+	deadline := time.Now().Add(time.Duration(*worker.GracefulDrainSeconds) * time.Second)
+	message := fmt.Sprintf("KubeRay is deleting worker Pod %s/%s", pod.Namespace, pod.Name)
+	if err := rayDashboardClient.DrainNode(ctx, nodes[i].NodeID, message, deadline); err != nil {
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDrainWorkerPod),
+			"Failed to drain worker Pod %s/%s, the Pod is deleted without draining: %v", pod.Namespace, pod.Name, err)
+		return true, nil
+	}
+	if err := r.patchPodAnnotations(ctx, pod, map[string]string{
+		utils.RayDrainDeadlineAnnotationKey: deadline.UTC().Format(time.RFC3339),
+	}); err != nil {
+		return false, err
+	}
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DrainingWorkerPod),
+		"Draining worker Pod %s/%s, the Pod will be deleted once Ray node %s is drained or in %d seconds",
+		pod.Namespace, pod.Name, nodes[i].NodeID, *worker.GracefulDrainSeconds)
+	return false, nil
+}
+
+// isDeletingWorkerPod returns whether the worker Pod is being deleted or drained.
+func isDeletingWorkerPod(pod corev1.Pod) bool {
+	_, draining := pod.Annotations[utils.RayDrainDeadlineAnnotationKey]
+	return pod.DeletionTimestamp != nil || draining
+}
+
+// findPodsOnVirtualNodes returns the names of the Pods of a burstable worker group that run on its virtual nodes.
+// The Pods whose node can't be read are considered to run on physical nodes.
+func (r *RayClusterReconciler) findPodsOnVirtualNodes(ctx context.Context, worker rayv1.WorkerGroupSpec, pods []corev1.Pod) map[string]bool {
+	logger := ctrl.LoggerFrom(ctx)
+	if worker.Burstable == nil {
+		return nil
+	}
+	onVirtualNode := map[string]bool{}
+	isVirtualNode := map[string]bool{}
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" {
+			continue
+		}
+		virtual, ok := isVirtualNode[pod.Spec.NodeName]
+		if !ok {
+			node := &corev1.Node{}
+			if err := r.Get(ctx, client.ObjectKey{Name: pod.Spec.NodeName}, node); err != nil {
+				logger.Info("Failed to get the node of the burstable worker Pod", "pod", pod.Name, "node", pod.Spec.NodeName, "error", err)
+				continue
+			}
+			virtual = common.IsVirtualNode(node, worker.Burstable)
+			isVirtualNode[pod.Spec.NodeName] = virtual
+		}
+		onVirtualNode[pod.Name] = virtual
+	}
+	return onVirtualNode
+}
+
+// getWorkerToDeleteIgnoredReason returns why a Pod of the WorkersToDelete of the worker group that isn't one of the
+// listed worker Pods of the group is ignored, i.e. it doesn't exist or belongs to another worker group or RayCluster.
+// The reason is empty if the Pod belongs to the group, e.g. because it was just created.
+func (r *RayClusterReconciler) getWorkerToDeleteIgnoredReason(ctx context.Context, instance *rayv1.RayCluster, groupName string, pod *corev1.Pod) (string, error) {
+	existingPod := &corev1.Pod{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(pod), existingPod); err != nil {
+		if errors.IsNotFound(err) {
+			return common.WorkerToDeleteNotFound, nil
+		}
+		return "", err
+	}
+	if existingPod.Labels[utils.RayClusterLabelKey] != instance.Name || existingPod.Labels[utils.RayNodeGroupLabelKey] != groupName ||
+		existingPod.Labels[utils.RayNodeTypeLabelKey] != string(rayv1.WorkerNode) {
+		return common.WorkerToDeleteInOtherGroup, nil
+	}
+	return "", nil
+}
+
+// deleteWorkerPod deletes a worker Pod of WorkersToDelete. The Pod is evicted instead if the worker group respects
+// PodDisruptionBudgets, in which case a TooManyRequests error is returned if the eviction is refused.
+func (r *RayClusterReconciler) deleteWorkerPod(ctx context.Context, worker rayv1.WorkerGroupSpec, pod *corev1.Pod) error {
+	if !common.IsPodDisruptionBudgetRespected(worker) {
+		return r.Delete(ctx, pod)
+	}
+	return r.SubResource("eviction").Create(ctx, pod, &policyv1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+		},
+	})
+}
+
+// listAliveRayNodes returns a dashboard client and the alive Ray nodes of the RayCluster. The client is nil if the
+// head Pod isn't running and ready.
+func (r *RayClusterReconciler) listAliveRayNodes(ctx context.Context, instance *rayv1.RayCluster) (utils.RayDashboardClientInterface, []utils.RayNodeInfo, error) {
+	headPod, err := common.GetRayClusterHeadPod(ctx, r, instance)
+	if err != nil {
+		return nil, nil, err
+	}
+	if headPod == nil || !utils.IsRunningAndReady(headPod) {
+		return nil, nil, fmt.Errorf("the head Pod of RayCluster %s/%s isn't ready", instance.Namespace, instance.Name)
+	}
+
+	clientURL, err := utils.FetchHeadServiceURL(ctx, r.Client, instance, utils.DashboardPortName)
+	if err != nil {
+		return nil, nil, err
+	}
+	rayDashboardClient := r.dashboardClientFunc()
+	if err := rayDashboardClient.InitClient(ctx, clientURL, instance); err != nil {
+		return nil, nil, err
+	}
+	nodes, err := rayDashboardClient.ListAliveNodes(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rayDashboardClient, nodes, nil
+}
+
 // shouldDeletePod returns whether the Pod should be deleted and the reason
 //
 // @param pod: The Pod to be checked.
@@ -1108,6 +2078,9 @@ func (r *RayClusterReconciler) createService(ctx context.Context, svc *corev1.Se
 		return err
 	}
 
+	if err := dryRunCreate(ctx, r.Client, r.Recorder, instance, svc); err != nil {
+		return err
+	}
 	if err := r.Create(ctx, svc); err != nil {
 		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToCreateService), "Failed creating service %s/%s, %v", svc.Namespace, svc.Name, err)
 		return err
@@ -1122,6 +2095,12 @@ func (r *RayClusterReconciler) createHeadPod(ctx context.Context, instance rayv1
 
 	// build the pod then create it
 	pod := r.buildHeadPod(ctx, instance)
+	if err := r.applyNodeProvisioningConfigs(ctx, &pod); err != nil {
+		return err
+	}
+	if err := r.applyPlacementPolicies(ctx, &instance, &pod); err != nil {
+		return err
+	}
 	// check if the batch scheduler integration is enabled
 	// call the scheduler plugin if so
 	if r.BatchSchedulerMgr != nil {
@@ -1132,6 +2111,9 @@ func (r *RayClusterReconciler) createHeadPod(ctx context.Context, instance rayv1
 		}
 	}
 
+	if err := dryRunCreate(ctx, r.Client, r.Recorder, &instance, &pod); err != nil {
+		return err
+	}
 	if err := r.Create(ctx, &pod); err != nil {
 		r.Recorder.Eventf(&instance, corev1.EventTypeWarning, string(utils.FailedToCreateHeadPod), "Failed to create head Pod %s/%s, %v", pod.Namespace, pod.Name, err)
 		return err
@@ -1142,11 +2124,194 @@ func (r *RayClusterReconciler) createHeadPod(ctx context.Context, instance rayv1
 	return nil
 }
 
-func (r *RayClusterReconciler) createWorkerPod(ctx context.Context, instance rayv1.RayCluster, worker rayv1.WorkerGroupSpec) error {
+// reconcileHeadStatefulSet creates the StatefulSet of the head Pod if it doesn't exist. Like the head Pod, the
+// StatefulSet isn't updated when the RayCluster changes.
+func (r *RayClusterReconciler) reconcileHeadStatefulSet(ctx context.Context, instance *rayv1.RayCluster) error {
+	logger := ctrl.LoggerFrom(ctx)
+	statefulSet := &appsv1.StatefulSet{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: instance.Namespace, Name: utils.GenerateHeadStatefulSetName(instance.Name)}, statefulSet)
+	if err == nil {
+		logger.Info("reconcilePods: Found 0 head Pods; waiting for the StatefulSet to create the head Pod.", "statefulSet", statefulSet.Name)
+		return nil
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+
+	pod := r.buildHeadPod(ctx, *instance)
+	if err := r.applyNodeProvisioningConfigs(ctx, &pod); err != nil {
+		return err
+	}
+	if err := r.applyPlacementPolicies(ctx, instance, &pod); err != nil {
+		return err
+	}
+	if r.BatchSchedulerMgr != nil {
+		if scheduler, err := r.BatchSchedulerMgr.GetSchedulerForCluster(); err == nil {
+			scheduler.AddMetadataToPod(ctx, instance, utils.RayNodeHeadGroupLabelValue, &pod)
+		} else {
+			return err
+		}
+	}
+	if err := r.reconcileHeadStatefulSetService(ctx, instance); err != nil {
+		return err
+	}
+	statefulSet = common.BuildHeadStatefulSet(*instance, pod)
+	if err := controllerutil.SetControllerReference(instance, statefulSet, r.Scheme); err != nil {
+		return err
+	}
+
+	common.CreatedClustersCounterInc(instance.Namespace)
+	if err := r.Create(ctx, statefulSet); err != nil {
+		common.FailedClustersCounterInc(instance.Namespace)
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToCreateHeadStatefulSet), "Failed to create head StatefulSet %s/%s, %v", statefulSet.Namespace, statefulSet.Name, err)
+		return err
+	}
+	common.SuccessfulClustersCounterInc(instance.Namespace)
+	logger.Info("Created head StatefulSet for RayCluster", "name", statefulSet.Name)
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.CreatedHeadStatefulSet), "Created head StatefulSet %s/%s", statefulSet.Namespace, statefulSet.Name)
+	return nil
+}
+
+// reconcileHeadStatefulSetService creates the headless Service that governs the StatefulSet of the head Pod if it
+// doesn't exist.
+func (r *RayClusterReconciler) reconcileHeadStatefulSetService(ctx context.Context, instance *rayv1.RayCluster) error {
+	service := &corev1.Service{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: instance.Namespace, Name: utils.GenerateHeadStatefulSetName(instance.Name)}, service)
+	if err == nil || !errors.IsNotFound(err) {
+		return err
+	}
+	return r.createService(ctx, common.BuildHeadStatefulSetService(*instance), instance)
+}
+
+// deleteHeadStatefulSet deletes the StatefulSet of the head Pod if it exists, so that it doesn't recreate the head
+// Pod when the Pods of the RayCluster are deleted.
+func (r *RayClusterReconciler) deleteHeadStatefulSet(ctx context.Context, instance *rayv1.RayCluster) error {
+	if !features.Enabled(features.RayHeadStatefulSet) {
+		return nil
+	}
+	statefulSet := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{
+		Namespace: instance.Namespace,
+		Name:      utils.GenerateHeadStatefulSetName(instance.Name),
+	}}
+	return client.IgnoreNotFound(r.Delete(ctx, statefulSet))
+}
+
+// promoteHeadStandbyPod promotes a running standby head Pod to be the head Pod. It returns whether a Pod was promoted.
+func (r *RayClusterReconciler) promoteHeadStandbyPod(ctx context.Context, instance *rayv1.RayCluster) (bool, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	if !common.IsColdStandbyEnabled(instance) {
+		return false, nil
+	}
+	standbyPods := corev1.PodList{}
+	if err := r.List(ctx, &standbyPods, common.RayClusterHeadStandbyPodsAssociationOptions(instance).ToListOptions()...); err != nil {
+		return false, err
+	}
+	for _, pod := range standbyPods.Items {
+		if !common.IsHeadStandbyPodPromotable(pod) {
+			continue
+		}
+		patch := client.MergeFrom(pod.DeepCopy())
+		common.PromoteHeadStandbyPod(&pod)
+		if err := r.Patch(ctx, &pod, patch); err != nil {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToPromoteHeadStandbyPod), "Failed to promote standby head Pod %s/%s, %v", pod.Namespace, pod.Name, err)
+			return false, err
+		}
+		logger.Info("Promoted standby head Pod to head Pod", "name", pod.Name)
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.PromotedHeadStandbyPod), "Promoted standby head Pod %s/%s to head Pod", pod.Namespace, pod.Name)
+		return true, nil
+	}
+	return false, nil
+}
+
+// reconcileHeadStandbyPod makes sure there is exactly one healthy standby head Pod if cold standby is enabled, and none otherwise.
+func (r *RayClusterReconciler) reconcileHeadStandbyPod(ctx context.Context, instance *rayv1.RayCluster) error {
+	logger := ctrl.LoggerFrom(ctx)
+	if !r.rayClusterScaleExpectation.IsSatisfied(ctx, instance.Namespace, instance.Name, expectations.HeadStandbyGroup) {
+		logger.Info("reconcilePods", "Expectation", "NotSatisfiedHeadStandbyExpectations, reconcile standby head later")
+		return nil
+	}
+	standbyPods := corev1.PodList{}
+	if err := r.List(ctx, &standbyPods, common.RayClusterHeadStandbyPodsAssociationOptions(instance).ToListOptions()...); err != nil {
+		return err
+	}
+
+	var podsToDelete []corev1.Pod
+	hasStandbyPod := false
+	for _, pod := range standbyPods.Items {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		if shouldDelete, _ := shouldDeletePod(pod, rayv1.HeadNode); shouldDelete || hasStandbyPod || !common.IsColdStandbyEnabled(instance) {
+			podsToDelete = append(podsToDelete, pod)
+			continue
+		}
+		hasStandbyPod = true
+	}
+	for _, pod := range podsToDelete {
+		if err := r.Delete(ctx, &pod); err != nil {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteHeadStandbyPod), "Failed deleting standby head Pod %s/%s; Pod status: %s, %v", pod.Namespace, pod.Name, pod.Status.Phase, err)
+			return errstd.Join(utils.ErrFailedDeleteHeadPod, err)
+		}
+		r.rayClusterScaleExpectation.ExpectScalePod(pod.Namespace, instance.Name, expectations.HeadStandbyGroup, pod.Name, expectations.Delete)
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedHeadStandbyPod), "Deleted standby head Pod %s/%s; Pod status: %s", pod.Namespace, pod.Name, pod.Status.Phase)
+	}
+
+	if common.IsColdStandbyEnabled(instance) && !hasStandbyPod {
+		logger.Info("reconcilePods: Found 0 standby head Pods; creating a standby head Pod for the RayCluster.")
+		if err := r.createHeadStandbyPod(ctx, *instance); err != nil {
+			return errstd.Join(utils.ErrFailedCreateHeadPod, err)
+		}
+	}
+	return nil
+}
+
+func (r *RayClusterReconciler) createHeadStandbyPod(ctx context.Context, instance rayv1.RayCluster) error {
+	logger := ctrl.LoggerFrom(ctx)
+
+	pod := r.buildHeadPod(ctx, instance)
+	if err := common.ConfigureHeadStandbyPod(&pod); err != nil {
+		r.Recorder.Eventf(&instance, corev1.EventTypeWarning, string(utils.FailedToCreateHeadStandbyPod), "Failed to create standby head Pod for RayCluster %s/%s, %v", instance.Namespace, instance.Name, err)
+		return err
+	}
+	if err := r.applyNodeProvisioningConfigs(ctx, &pod); err != nil {
+		return err
+	}
+	if err := r.applyPlacementPolicies(ctx, &instance, &pod); err != nil {
+		return err
+	}
+	if r.BatchSchedulerMgr != nil {
+		if scheduler, err := r.BatchSchedulerMgr.GetSchedulerForCluster(); err == nil {
+			scheduler.AddMetadataToPod(ctx, &instance, utils.RayNodeHeadGroupLabelValue, &pod)
+		} else {
+			return err
+		}
+	}
+
+	if err := r.Create(ctx, &pod); err != nil {
+		r.Recorder.Eventf(&instance, corev1.EventTypeWarning, string(utils.FailedToCreateHeadStandbyPod), "Failed to create standby head Pod %s/%s, %v", pod.Namespace, pod.Name, err)
+		return err
+	}
+	r.rayClusterScaleExpectation.ExpectScalePod(pod.Namespace, instance.Name, expectations.HeadStandbyGroup, pod.Name, expectations.Create)
+	logger.Info("Created standby head Pod for RayCluster", "name", pod.Name)
+	r.Recorder.Eventf(&instance, corev1.EventTypeNormal, string(utils.CreatedHeadStandbyPod), "Created standby head Pod %s/%s", pod.Namespace, pod.Name)
+	return nil
+}
+
+// createWorkerPod creates a worker Pod. If rank isn't nil, the Pod is assigned the rank for `enableRankEnv`.
+func (r *RayClusterReconciler) createWorkerPod(ctx context.Context, instance rayv1.RayCluster, worker rayv1.WorkerGroupSpec, rank *int) error {
 	logger := ctrl.LoggerFrom(ctx)
 
 	// build the pod then create it
 	pod := r.buildWorkerPod(ctx, instance, worker)
+	if err := r.applyNodeProvisioningConfigs(ctx, &pod); err != nil {
+		return err
+	}
+	if err := r.applyPlacementPolicies(ctx, &instance, &pod); err != nil {
+		return err
+	}
+	if rank != nil {
+		worldSize := utils.GetWorkerGroupDesiredReplicas(ctx, worker) * max(worker.NumOfHosts, 1)
+		common.SetWorkerRank(&pod, *rank, int(worldSize))
+	}
 	if r.BatchSchedulerMgr != nil {
 		if scheduler, err := r.BatchSchedulerMgr.GetSchedulerForCluster(); err == nil {
 			scheduler.AddMetadataToPod(ctx, &instance, worker.GroupName, &pod)
@@ -1155,6 +2320,9 @@ func (r *RayClusterReconciler) createWorkerPod(ctx context.Context, instance ray
 		}
 	}
 
+	if err := dryRunCreate(ctx, r.Client, r.Recorder, &instance, &pod); err != nil {
+		return err
+	}
 	replica := pod
 	if err := r.Create(ctx, &replica); err != nil {
 		r.Recorder.Eventf(&instance, corev1.EventTypeWarning, string(utils.FailedToCreateWorkerPod), "Failed to create worker Pod %s/%s, %v", pod.Namespace, pod.Name, err)
@@ -1166,6 +2334,107 @@ func (r *RayClusterReconciler) createWorkerPod(ctx context.Context, instance ray
 	return nil
 }
 
+// applyNodeProvisioningConfigs applies the RayNodeProvisioningConfigs to the Pod if the RayNodeProvisioningConfig
+// feature gate is enabled. The RayNodeProvisioningConfigs are ignored if the cluster-scoped CRD isn't installed or
+// KubeRay isn't allowed to list it, for example when it's installed with namespace-scoped RBAC.
+func (r *RayClusterReconciler) applyNodeProvisioningConfigs(ctx context.Context, pod *corev1.Pod) error {
+	if !features.Enabled(features.RayNodeProvisioningConfig) {
+		return nil
+	}
+	configs := rayv1.RayNodeProvisioningConfigList{}
+	if err := r.List(ctx, &configs); err != nil {
+		if meta.IsNoMatchError(err) || errors.IsForbidden(err) {
+			ctrl.LoggerFrom(ctx).Info("Ignoring the RayNodeProvisioningConfigs since they can't be listed", "error", err.Error())
+			return nil
+		}
+		return err
+	}
+	common.ApplyNodeProvisioningConfigs(pod, configs.Items)
+	return nil
+}
+
+// applyPlacementPolicies applies the RayPlacementPolicies to the Pod if the RayPlacementPolicy feature gate is enabled.
+func (r *RayClusterReconciler) applyPlacementPolicies(ctx context.Context, instance *rayv1.RayCluster, pod *corev1.Pod) error {
+	if !features.Enabled(features.RayPlacementPolicy) {
+		return nil
+	}
+	policies := rayv1.RayPlacementPolicyList{}
+	if err := r.List(ctx, &policies); err != nil {
+		return err
+	}
+	if len(policies.Items) == 0 {
+		return nil
+	}
+	namespace := &corev1.Namespace{}
+	if err := r.Get(ctx, client.ObjectKey{Name: pod.Namespace}, namespace); err != nil {
+		return err
+	}
+	return common.ApplyPlacementPolicies(pod, instance, namespace, policies.Items)
+}
+
+// reconcileHostNetworkPorts allocates a port block to the RayCluster if it uses host networking and the
+// RayHostNetworkPortAllocation feature gate is enabled, and applies the ports of the block to the in-memory RayCluster,
+// so that the Pods and the services created by the following reconcile functions use them.
+func (r *RayClusterReconciler) reconcileHostNetworkPorts(ctx context.Context, instance *rayv1.RayCluster) error {
+	if !features.Enabled(features.RayHostNetworkPortAllocation) || !common.IsHostNetworkEnabled(instance) {
+		return nil
+	}
+	block, err := strconv.Atoi(instance.Annotations[utils.RayHostNetworkPortBlockAnnotationKey])
+	if err != nil {
+		if block, err = r.allocateHostNetworkPortBlock(ctx, instance); err != nil {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToAllocateHostNetworkPorts),
+				"Failed to allocate the host network ports: %v", err)
+			return err
+		}
+		if instance.Annotations == nil {
+			instance.Annotations = map[string]string{}
+		}
+		instance.Annotations[utils.RayHostNetworkPortBlockAnnotationKey] = strconv.Itoa(block)
+		if err := r.Update(ctx, instance); err != nil {
+			return err
+		}
+		start := utils.HostNetworkPortRangeStart + block*utils.HostNetworkPortBlockSize
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.AllocatedHostNetworkPorts),
+			"Allocated the host network ports %d-%d", start, start+utils.HostNetworkPortBlockSize-1)
+	}
+	common.ApplyHostNetworkPorts(instance, block)
+	return nil
+}
+
+// allocateHostNetworkPortBlock returns the port block of the RayCluster in the HostNetworkPortsConfigMapName ConfigMap
+// of the namespace of the operator, and allocates one if it has none. The blocks of the deleted RayClusters are freed
+// when a block is allocated. The concurrent allocations are serialized by the resource version of the ConfigMap.
+func (r *RayClusterReconciler) allocateHostNetworkPortBlock(ctx context.Context, instance *rayv1.RayCluster) (int, error) {
+	configMap := &corev1.ConfigMap{}
+	key := client.ObjectKey{Namespace: utils.GetOperatorNamespace(), Name: utils.HostNetworkPortsConfigMapName}
+	exists := true
+	if err := r.Get(ctx, key, configMap); err != nil {
+		if !errors.IsNotFound(err) {
+			return 0, err
+		}
+		exists = false
+		configMap = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name}}
+	}
+	if configMap.Data == nil {
+		configMap.Data = map[string]string{}
+	}
+
+	block, changed, err := common.AllocateHostNetworkPortBlock(configMap.Data, common.HostNetworkPortsConfigMapKey(instance), func(clusterKey string) bool {
+		namespace, name, _ := strings.Cut(clusterKey, ".")
+		err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &rayv1.RayCluster{})
+		return errors.IsNotFound(err)
+	})
+	if err != nil || !changed {
+		return block, err
+	}
+	if exists {
+		err = r.Update(ctx, configMap)
+	} else {
+		err = r.Create(ctx, configMap)
+	}
+	return block, err
+}
+
 // Build head instance pod(s).
 func (r *RayClusterReconciler) buildHeadPod(ctx context.Context, instance rayv1.RayCluster) corev1.Pod {
 	logger := ctrl.LoggerFrom(ctx)
@@ -1174,13 +2443,31 @@ func (r *RayClusterReconciler) buildHeadPod(ctx context.Context, instance rayv1.
 	// The Ray head port used by workers to connect to the cluster (GCS server port for Ray >= 1.11.0, Redis port for older Ray.)
 	headPort := common.GetHeadPort(instance.Spec.HeadGroupSpec.RayStartParams)
 	autoscalingEnabled := utils.IsAutoscalingEnabled(&instance)
+	numUserEnv := len(instance.Spec.HeadGroupSpec.Template.Spec.Containers[utils.RayContainerIndex].Env)
+	// Building the head Pod may modify the containers of the template, so the probes set by the user are kept aside.
+	userHeadGroupSpec := instance.Spec.HeadGroupSpec.DeepCopy()
 	podConf := common.DefaultHeadPodTemplate(ctx, instance, instance.Spec.HeadGroupSpec, podName, headPort)
 	if len(r.headSidecarContainers) > 0 {
 		podConf.Spec.Containers = append(podConf.Spec.Containers, r.headSidecarContainers...)
 	}
 	logger.Info("head pod labels", "labels", podConf.Labels)
 	creatorCRDType := getCreatorCRDType(instance)
-	pod := common.BuildPod(ctx, podConf, rayv1.HeadNode, instance.Spec.HeadGroupSpec.RayStartParams, headPort, autoscalingEnabled, creatorCRDType, fqdnRayIP)
+	rayStartParams := common.SizeObjectStoreMemory(instance.Spec.HeadGroupSpec.RayStartParams, instance.Spec.HeadGroupSpec.ObjectStoreMemoryPercent,
+		podConf.Spec.Containers[utils.RayContainerIndex].Resources)
+	pod := common.BuildPod(ctx, podConf, rayv1.HeadNode, rayStartParams, headPort, autoscalingEnabled, creatorCRDType, fqdnRayIP)
+	common.ApplyHeadHealthProbes(&pod, *userHeadGroupSpec, rayStartParams)
+	common.ApplyRayStartHooks(&pod, instance.Spec.RayStartHooks, rayStartParams)
+	common.ApplyGeneratedPodSecurity(&pod, r.generatedPodSecurity)
+	common.ApplyPodSpecDefaults(&pod.Spec, r.podSpecDefaults, autoscalingEnabled)
+	common.ApplyTmpDirPolicy(&pod, instance.Spec.TmpDirPolicy)
+	common.SyncMetadata(&pod, &instance)
+	if err := common.ApplyImageArchitectureAffinity(&pod.Spec, r.imageArchitectures); err != nil {
+		logger.Error(err, "Failed to require nodes of the architecture of the images of the head Pod")
+	}
+	common.ApplyDashboardMetricsEnv(&pod, r.dashboardMetrics.SettingsForNamespace(instance.Namespace))
+	if err := common.ApplyInjectedEnvPolicy(&pod, numUserEnv, r.injectedEnvPolicy.RulesForNamespace(instance.Namespace)); err != nil {
+		logger.Error(err, "Failed to apply the injected environment variable policy to the head Pod")
+	}
 	// Set raycluster instance as the owner and controller
 	if err := controllerutil.SetControllerReference(&instance, &pod, r.Scheme); err != nil {
 		logger.Error(err, "Failed to set controller reference for raycluster pod")
@@ -1196,21 +2483,59 @@ func getCreatorCRDType(instance rayv1.RayCluster) utils.CRDType {
 // Build worker instance pods.
 func (r *RayClusterReconciler) buildWorkerPod(ctx context.Context, instance rayv1.RayCluster, worker rayv1.WorkerGroupSpec) corev1.Pod {
 	logger := ctrl.LoggerFrom(ctx)
+	// The template hash is generated before the template is defaulted, because the defaulting modifies the containers
+	// of the template in place.
+	templateHash, templateHashErr := common.GenerateWorkerGroupTemplateHash(worker)
 	podName := utils.PodGenerateName(fmt.Sprintf("%s-%s", instance.Name, worker.GroupName), rayv1.WorkerNode)
 	fqdnRayIP := utils.GenerateFQDNServiceName(ctx, instance, instance.Namespace) // Fully Qualified Domain Name
 
 	// The Ray head port used by workers to connect to the cluster (GCS server port for Ray >= 1.11.0, Redis port for older Ray.)
 	headPort := common.GetHeadPort(instance.Spec.HeadGroupSpec.RayStartParams)
 	autoscalingEnabled := utils.IsAutoscalingEnabled(&instance)
+	numUserEnv := len(worker.Template.Spec.Containers[utils.RayContainerIndex].Env)
 	podTemplateSpec := common.DefaultWorkerPodTemplate(ctx, instance, worker, podName, fqdnRayIP, headPort)
 	if len(r.workerSidecarContainers) > 0 {
 		podTemplateSpec.Spec.Containers = append(podTemplateSpec.Spec.Containers, r.workerSidecarContainers...)
 	}
+	for _, status := range instance.Status.MemoryFailures {
+		if status.GroupName == worker.GroupName {
+			common.ApplyOOMPolicy(&podTemplateSpec.Spec, worker.OOMPolicy, status.MemoryIncreases)
+		}
+	}
 	creatorCRDType := getCreatorCRDType(instance)
-	pod := common.BuildPod(ctx, podTemplateSpec, rayv1.WorkerNode, worker.RayStartParams, headPort, autoscalingEnabled, creatorCRDType, fqdnRayIP)
-	// Set raycluster instance as the owner and controller
-	if err := controllerutil.SetControllerReference(&instance, &pod, r.Scheme); err != nil {
-		logger.Error(err, "Failed to set controller reference for raycluster pod")
+	rayStartParams := common.SizeObjectStoreMemory(worker.RayStartParams, worker.ObjectStoreMemoryPercent,
+		podTemplateSpec.Spec.Containers[utils.RayContainerIndex].Resources)
+	pod := common.BuildPod(ctx, podTemplateSpec, rayv1.WorkerNode, rayStartParams, headPort, autoscalingEnabled, creatorCRDType, fqdnRayIP)
+	common.ApplyRayStartHooks(&pod, instance.Spec.RayStartHooks, rayStartParams)
+	common.ApplyGeneratedPodSecurity(&pod, r.generatedPodSecurity)
+	common.ApplyPodSpecDefaults(&pod.Spec, r.podSpecDefaults, false)
+	common.ApplyBurstableOptions(&pod, worker.Burstable)
+	common.ApplyTmpDirPolicy(&pod, instance.Spec.TmpDirPolicy)
+	common.SyncMetadata(&pod, &instance)
+	if features.Enabled(features.RayWorkerPreStopDrain) {
+		common.ApplyPreStopDrain(&pod, rayStartParams)
+	}
+	if err := common.ApplyImageArchitectureAffinity(&pod.Spec, r.imageArchitectures); err != nil {
+		logger.Error(err, "Failed to require nodes of the architecture of the images of the worker Pod", "worker group", worker.GroupName)
+	}
+	if err := common.ApplyInjectedEnvPolicy(&pod, numUserEnv, r.injectedEnvPolicy.RulesForNamespace(instance.Namespace)); err != nil {
+		logger.Error(err, "Failed to apply the injected environment variable policy to the worker Pod")
+	}
+	// The hash of the template is recorded so that the Pod can be replaced when the template changes.
+	if templateHashErr != nil {
+		logger.Error(templateHashErr, "Failed to generate the template hash of the worker group", "worker group", worker.GroupName)
+	} else {
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		pod.Annotations[utils.RayWorkerGroupTemplateHashAnnotationKey] = templateHash
+	}
+	// Set raycluster instance as the owner and controller. Owner references can't cross namespaces, so the Pods of
+	// worker groups in other namespaces are deleted with the RemoteWorkerPodsCleanupFinalizer instead.
+	if !common.IsRemoteWorkerGroup(&instance, worker) {
+		if err := controllerutil.SetControllerReference(&instance, &pod, r.Scheme); err != nil {
+			logger.Error(err, "Failed to set controller reference for raycluster pod")
+		}
 	}
 
 	return pod
@@ -1238,9 +2563,10 @@ func (r *RayClusterReconciler) buildRedisCleanupJob(ctx context.Context, instanc
 			"sys.exit(1) if not cleanup_redis_storage(host=parsed.hostname, port=parsed.port, password=os.getenv('REDIS_PASSWORD', parsed.password or ''), use_ssl=parsed.scheme=='rediss', storage_namespace=os.getenv('RAY_external_storage_namespace')) else None\"",
 	}
 
-	// Disable liveness and readiness probes because the Job will not launch processes like Raylet and GCS.
+	// Disable the probes because the Job will not launch processes like Raylet and GCS.
 	pod.Spec.Containers[utils.RayContainerIndex].LivenessProbe = nil
 	pod.Spec.Containers[utils.RayContainerIndex].ReadinessProbe = nil
+	pod.Spec.Containers[utils.RayContainerIndex].StartupProbe = nil
 
 	// Set the environment variables to ensure that the cleanup Job has at least 60s.
 	pod.Spec.Containers[utils.RayContainerIndex].Env = append(pod.Spec.Containers[utils.RayContainerIndex].Env, corev1.EnvVar{
@@ -1303,6 +2629,27 @@ func (r *RayClusterReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcu
 		))).
 		Owns(&corev1.Pod{}).
 		Owns(&corev1.Service{})
+	if features.Enabled(features.RayHeadStatefulSet) {
+		// The head Pods created by a StatefulSet aren't owned by the RayCluster, so they are mapped to the RayCluster
+		// with their labels.
+		b = b.Owns(&appsv1.StatefulSet{}).
+			Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(mapHeadStatefulSetPodToRayCluster))
+	}
+	if utils.IsWatchNamespaceSelectorSet() {
+		b = b.Watches(&corev1.Namespace{}, utils.EnqueueObjectsInNamespace(mgr.GetClient(), &rayv1.RayClusterList{}),
+			builder.WithPredicates(predicate.LabelChangedPredicate{}))
+	}
+	if features.Enabled(features.RayMultiNamespaceWorkerGroups) {
+		// The worker Pods in other namespaces than the RayCluster aren't owned by it, so they are mapped to the RayCluster
+		// with their labels.
+		b = b.Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(func(_ context.Context, obj client.Object) []reconcile.Request {
+			namespace, ok := obj.GetLabels()[utils.RayClusterNamespaceLabelKey]
+			if !ok {
+				return nil
+			}
+			return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: namespace, Name: obj.GetLabels()[utils.RayClusterLabelKey]}}}
+		}))
+	}
 
 	if r.BatchSchedulerMgr != nil {
 		r.BatchSchedulerMgr.ConfigureReconciler(b)
@@ -1322,6 +2669,20 @@ func (r *RayClusterReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcu
 		Complete(r)
 }
 
+// mapHeadStatefulSetPodToRayCluster maps a head Pod created by the StatefulSet of a RayCluster to the RayCluster.
+func mapHeadStatefulSetPodToRayCluster(_ context.Context, obj client.Object) []reconcile.Request {
+	labels := obj.GetLabels()
+	clusterName, ok := labels[utils.RayClusterLabelKey]
+	if !ok || labels[utils.RayNodeTypeLabelKey] != string(rayv1.HeadNode) {
+		return nil
+	}
+	owner := metav1.GetControllerOf(obj)
+	if owner == nil || owner.Kind != "StatefulSet" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: obj.GetNamespace(), Name: clusterName}}}
+}
+
 func (r *RayClusterReconciler) calculateStatus(ctx context.Context, instance *rayv1.RayCluster, reconcileErr error) (*rayv1.RayCluster, error) {
 	// TODO: Replace this log and use reconcileErr to set the condition field.
 	logger := ctrl.LoggerFrom(ctx)
@@ -1356,6 +2717,17 @@ func (r *RayClusterReconciler) calculateStatus(ctx context.Context, instance *ra
 	if err := r.List(ctx, &runtimePods, common.RayClusterAllPodsAssociationOptions(newInstance).ToListOptions()...); err != nil {
 		return nil, err
 	}
+	if common.HasRemoteWorkerGroups(newInstance) {
+		remotePods := corev1.PodList{}
+		if err := r.List(ctx, &remotePods, common.RayClusterRemoteWorkerPodsAssociationOptions(newInstance).ToListOptions()...); err != nil {
+			return nil, err
+		}
+		runtimePods.Items = append(runtimePods.Items, remotePods.Items...)
+	}
+	// The standby head Pod doesn't run Ray, so it isn't part of the cluster.
+	runtimePods.Items = slices.DeleteFunc(runtimePods.Items, func(pod corev1.Pod) bool {
+		return pod.Labels[utils.RayNodeTypeLabelKey] == utils.RayNodeHeadStandbyLabelValue
+	})
 
 	newInstance.Status.ReadyWorkerReplicas = utils.CalculateReadyReplicas(runtimePods)
 	newInstance.Status.AvailableWorkerReplicas = utils.CalculateAvailableReplicas(runtimePods)
@@ -1477,6 +2849,8 @@ func (r *RayClusterReconciler) calculateStatus(ctx context.Context, instance *ra
 		return nil, err
 	}
 
+	newInstance.Status.Journal = r.journal.MergeJournal(newInstance.UID, newInstance.Status.Journal)
+
 	timeNow := metav1.Now()
 	newInstance.Status.LastUpdateTime = &timeNow
 
@@ -1490,9 +2864,30 @@ func (r *RayClusterReconciler) calculateStatus(ctx context.Context, instance *ra
 	return newInstance, nil
 }
 
+// listHeadServices lists the head services of the RayCluster by their labels, or returns the Service referenced by
+// `existingHeadServiceName` if it's set, because an existing head service may not have the labels.
+func (r *RayClusterReconciler) listHeadServices(ctx context.Context, instance *rayv1.RayCluster) (*corev1.ServiceList, error) {
+	services := &corev1.ServiceList{}
+	if name := instance.Spec.HeadGroupSpec.ExistingHeadServiceName; name != "" {
+		svc := corev1.Service{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: name}, &svc); err != nil {
+			if errors.IsNotFound(err) {
+				return services, nil
+			}
+			return nil, err
+		}
+		services.Items = append(services.Items, svc)
+		return services, nil
+	}
+	if err := r.List(ctx, services, common.RayClusterHeadServiceListOptions(instance)...); err != nil {
+		return nil, err
+	}
+	return services, nil
+}
+
 func (r *RayClusterReconciler) getHeadServiceIPAndName(ctx context.Context, instance *rayv1.RayCluster) (string, string, error) {
-	runtimeServices := corev1.ServiceList{}
-	if err := r.List(ctx, &runtimeServices, common.RayClusterHeadServiceListOptions(instance)...); err != nil {
+	runtimeServices, err := r.listHeadServices(ctx, instance)
+	if err != nil {
 		return "", "", err
 	}
 	if len(runtimeServices.Items) < 1 {
@@ -1521,9 +2916,9 @@ func (r *RayClusterReconciler) updateEndpoints(ctx context.Context, instance *ra
 	// TODO: (@scarlet25151) There may be several K8s Services for a RayCluster.
 	// We assume we can find the right one by filtering Services with appropriate label selectors
 	// and picking the first one. We may need to select by name in the future if the Service naming is stable.
-	rayHeadSvc := corev1.ServiceList{}
 	filterLabels := common.RayClusterHeadServiceListOptions(instance)
-	if err := r.List(ctx, &rayHeadSvc, filterLabels...); err != nil {
+	rayHeadSvc, err := r.listHeadServices(ctx, instance)
+	if err != nil {
 		return err
 	}
 
@@ -1577,6 +2972,36 @@ func (r *RayClusterReconciler) updateHeadInfo(ctx context.Context, instance *ray
 	return nil
 }
 
+// reconcileDedicatedServiceAccount creates the ServiceAccount dedicated to the RayCluster. It's owned by the
+// RayCluster, so it's deleted with it.
+func (r *RayClusterReconciler) reconcileDedicatedServiceAccount(ctx context.Context, instance *rayv1.RayCluster) error {
+	logger := ctrl.LoggerFrom(ctx)
+	if !utils.IsDedicatedServiceAccountEnabled(instance) {
+		return nil
+	}
+
+	serviceAccount := &corev1.ServiceAccount{}
+	namespacedName := types.NamespacedName{Namespace: instance.Namespace, Name: utils.GetDedicatedServiceAccountName(instance)}
+	if err := r.Get(ctx, namespacedName, serviceAccount); err == nil || !errors.IsNotFound(err) {
+		return err
+	}
+
+	serviceAccount = common.BuildDedicatedServiceAccount(instance)
+	if err := controllerutil.SetControllerReference(instance, serviceAccount, r.Scheme); err != nil {
+		return err
+	}
+	if err := r.Create(ctx, serviceAccount); err != nil {
+		if errors.IsAlreadyExists(err) {
+			return nil
+		}
+		r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToCreateServiceAccount), "Failed creating service account %s/%s, %v", serviceAccount.Namespace, serviceAccount.Name, err)
+		return err
+	}
+	logger.Info("Created the dedicated service account of the RayCluster", "name", serviceAccount.Name)
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.CreatedServiceAccount), "Created service account %s/%s", serviceAccount.Namespace, serviceAccount.Name)
+	return nil
+}
+
 func (r *RayClusterReconciler) reconcileAutoscalerServiceAccount(ctx context.Context, instance *rayv1.RayCluster) error {
 	logger := ctrl.LoggerFrom(ctx)
 	if !utils.IsAutoscalingEnabled(instance) {
@@ -1634,9 +3059,11 @@ func (r *RayClusterReconciler) reconcileAutoscalerServiceAccount(ctx context.Con
 	return nil
 }
 
+// reconcileAutoscalerRole creates the Role of the autoscaler, which is also bound to the dedicated ServiceAccount of
+// the RayCluster, and updates its rules when the autoscaler is enabled or disabled.
 func (r *RayClusterReconciler) reconcileAutoscalerRole(ctx context.Context, instance *rayv1.RayCluster) error {
 	logger := ctrl.LoggerFrom(ctx)
-	if !utils.IsAutoscalingEnabled(instance) {
+	if !utils.IsAutoscalingEnabled(instance) && !utils.IsDedicatedServiceAccountEnabled(instance) {
 		return nil
 	}
 
@@ -1673,12 +3100,26 @@ func (r *RayClusterReconciler) reconcileAutoscalerRole(ctx context.Context, inst
 		return nil
 	}
 
+	desiredRole, err := common.BuildRole(instance)
+	if err != nil {
+		return err
+	}
+	if !metav1.IsControlledBy(role, instance) || reflect.DeepEqual(role.Rules, desiredRole.Rules) {
+		return nil
+	}
+	role.Rules = desiredRole.Rules
+	if err := r.Update(ctx, role); err != nil {
+		return err
+	}
+	logger.Info("Updated the rules of the role", "name", role.Name)
 	return nil
 }
 
+// reconcileAutoscalerRoleBinding binds the Role of the autoscaler to the ServiceAccount of the head Pod and to the
+// dedicated ServiceAccount of the RayCluster.
 func (r *RayClusterReconciler) reconcileAutoscalerRoleBinding(ctx context.Context, instance *rayv1.RayCluster) error {
 	logger := ctrl.LoggerFrom(ctx)
-	if !utils.IsAutoscalingEnabled(instance) {
+	if !utils.IsAutoscalingEnabled(instance) && !utils.IsDedicatedServiceAccountEnabled(instance) {
 		return nil
 	}
 
@@ -1715,6 +3156,778 @@ func (r *RayClusterReconciler) reconcileAutoscalerRoleBinding(ctx context.Contex
 		return nil
 	}
 
+	desiredRoleBinding, err := common.BuildRoleBinding(instance)
+	if err != nil {
+		return err
+	}
+	// The role of a RoleBinding is immutable, so only the subjects are updated.
+	if !metav1.IsControlledBy(roleBinding, instance) || reflect.DeepEqual(roleBinding.Subjects, desiredRoleBinding.Subjects) {
+		return nil
+	}
+	roleBinding.Subjects = desiredRoleBinding.Subjects
+	if err := r.Update(ctx, roleBinding); err != nil {
+		return err
+	}
+	logger.Info("Updated the subjects of the role binding", "name", roleBinding.Name)
+	return nil
+}
+
+// reportUnschedulableWorkerPods records one event per scheduling failure of the worker Pods, e.g. "12 worker Pods
+// failed scheduling: Insufficient nvidia.com/gpu", instead of leaving the users to inspect the Pods one by one.
+func (r *RayClusterReconciler) reportUnschedulableWorkerPods(ctx context.Context, instance *rayv1.RayCluster) error {
+	workerPods := corev1.PodList{}
+	if err := r.List(ctx, &workerPods, common.RayClusterWorkerPodsAssociationOptions(instance).ToListOptions()...); err != nil {
+		return err
+	}
+	pods := workerPods.Items
+	if common.HasRemoteWorkerGroups(instance) {
+		remotePods := corev1.PodList{}
+		if err := r.List(ctx, &remotePods, common.RayClusterRemoteWorkerPodsAssociationOptions(instance).ToListOptions()...); err != nil {
+			return err
+		}
+		pods = append(pods, remotePods.Items...)
+	}
+	for _, message := range summarizeUnschedulablePods(pods) {
+		r.Recorder.Event(instance, corev1.EventTypeWarning, string(utils.WorkerPodsUnschedulable), message)
+	}
+	return nil
+}
+
+// reconcileMemoryFailures records the OOM kills of the Ray containers and the evictions of the Pods in the status of
+// the RayCluster, and emits an event for each of them. It runs before reconcilePods, which deletes the failed Pods.
+func (r *RayClusterReconciler) reconcileMemoryFailures(ctx context.Context, instance *rayv1.RayCluster) error {
+	pods := corev1.PodList{}
+	if err := r.List(ctx, &pods, common.RayClusterAllPodsAssociationOptions(instance).ToListOptions()...); err != nil {
+		return err
+	}
+	if common.HasRemoteWorkerGroups(instance) {
+		remotePods := corev1.PodList{}
+		if err := r.List(ctx, &remotePods, common.RayClusterRemoteWorkerPodsAssociationOptions(instance).ToListOptions()...); err != nil {
+			return err
+		}
+		pods.Items = append(pods.Items, remotePods.Items...)
+	}
+	failures := common.FindMemoryFailures(pods.Items, instance.Status.MemoryFailures)
+	for _, failure := range failures {
+		if failure.Evicted {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.RayPodEvicted),
+				"Pod %s of group %s was evicted", failure.PodName, failure.GroupName)
+		} else {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.RayContainerOOMKilled),
+				"Ray container of Pod %s of group %s was OOMKilled", failure.PodName, failure.GroupName)
+		}
+	}
+	instance.Status.MemoryFailures = common.RecordMemoryFailures(instance.Status.MemoryFailures, failures)
+
+	for i := range instance.Status.MemoryFailures {
+		status := &instance.Status.MemoryFailures[i]
+		index := slices.IndexFunc(instance.Spec.WorkerGroupSpecs, func(worker rayv1.WorkerGroupSpec) bool {
+			return worker.GroupName == status.GroupName
+		})
+		if index < 0 {
+			continue
+		}
+		increases := common.GetMemoryIncreases(instance.Spec.WorkerGroupSpecs[index].OOMPolicy, status.OOMKilled)
+		if increases > status.MemoryIncreases {
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.IncreasedWorkerGroupMemory),
+				"Increased the memory of the new Pods of worker group %s after %d OOM kills", status.GroupName, status.OOMKilled)
+		}
+		status.MemoryIncreases = increases
+	}
+	return nil
+}
+
+// summarizeUnschedulablePods groups the Pods that the scheduler failed to schedule by the reasons reported by the
+// scheduler, without the node counts, and returns one message per group.
+func summarizeUnschedulablePods(pods []corev1.Pod) []string {
+	counts := map[string]int{}
+	for _, pod := range pods {
+		if !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse && condition.Reason == corev1.PodReasonUnschedulable {
+				counts[schedulingFailureReasons(condition.Message)]++
+			}
+		}
+	}
+	messages := make([]string, 0, len(counts))
+	for reasons, count := range counts {
+		messages = append(messages, fmt.Sprintf("%d worker Pods failed scheduling: %s", count, reasons))
+	}
+	slices.Sort(messages)
+	return messages
+}
+
+// schedulingFailureReasons strips the node counts from the message of the scheduler, e.g. "0/5 nodes are available:
+// 3 Insufficient nvidia.com/gpu, 2 node(s) had untolerated taint {gpu: true}. preemption: ..." becomes
+// "Insufficient nvidia.com/gpu, node(s) had untolerated taint {gpu: true}", so that the same failure on clusters of
+// different sizes is reported once.
+func schedulingFailureReasons(message string) string {
+	message, _, _ = strings.Cut(message, " preemption:")
+	if _, after, found := strings.Cut(message, "nodes are available: "); found {
+		message = after
+	}
+	reasons := strings.Split(strings.TrimSuffix(strings.TrimSpace(message), "."), ", ")
+	for i, reason := range reasons {
+		if count, rest, found := strings.Cut(reason, " "); found {
+			if _, err := strconv.Atoi(count); err == nil {
+				reasons[i] = rest
+			}
+		}
+	}
+	return strings.Join(reasons, ", ")
+}
+
+// reconcilePendingResourceDemands publishes the pending resource demands of the Ray autoscaler in the RayCluster status,
+// and creates or deletes the balloon Pods for them.
+func (r *RayClusterReconciler) reconcilePendingResourceDemands(ctx context.Context, instance *rayv1.RayCluster) error {
+	logger := ctrl.LoggerFrom(ctx)
+	if !features.Enabled(features.RayClusterPendingResourceDemands) {
+		return nil
+	}
+
+	var demands []rayv1.ResourceDemand
+	if utils.IsAutoscalingEnabled(instance) {
+		var err error
+		if demands, err = r.getPendingResourceDemands(ctx, instance); err != nil {
+			// Keep the previous demands if the Ray dashboard is temporarily unavailable.
+			logger.Info("Failed to get the pending resource demands from the Ray autoscaler", "error", err)
+			demands = instance.Status.PendingResourceDemands
+		}
+	}
+	instance.Status.PendingResourceDemands = demands
+	return r.reconcileBalloonPods(ctx, instance)
+}
+
+// getPendingResourceDemands reads the pending resource demands from the Ray autoscaler. It returns nil if the head Pod
+// isn't running and ready.
+func (r *RayClusterReconciler) getPendingResourceDemands(ctx context.Context, instance *rayv1.RayCluster) ([]rayv1.ResourceDemand, error) {
+	headPod, err := common.GetRayClusterHeadPod(ctx, r, instance)
+	if err != nil {
+		return nil, err
+	}
+	if headPod == nil || !utils.IsRunningAndReady(headPod) {
+		return nil, nil
+	}
+
+	clientURL, err := utils.FetchHeadServiceURL(ctx, r.Client, instance, utils.DashboardPortName)
+	if err != nil {
+		return nil, err
+	}
+	rayDashboardClient := r.dashboardClientFunc()
+	if err := rayDashboardClient.InitClient(ctx, clientURL, instance); err != nil {
+		return nil, err
+	}
+	clusterStatus, err := rayDashboardClient.GetClusterStatus(ctx)
+	if err != nil || clusterStatus == nil {
+		return nil, err
+	}
+
+	return utils.ConvertResourceDemands(clusterStatus.LoadMetricsReport.ResourceDemand), nil
+}
+
+// reconcileAutoscalerEvents re-emits the scaling decisions and failures of the Ray autoscaler as events of the
+// RayCluster, so that `kubectl describe` shows why worker Pods were or weren't created. The autoscaler events that
+// exist when KubeRay first sees the RayCluster, e.g. after the operator restarts, are not re-emitted.
+func (r *RayClusterReconciler) reconcileAutoscalerEvents(ctx context.Context, instance *rayv1.RayCluster) error {
+	logger := ctrl.LoggerFrom(ctx)
+	if !features.Enabled(features.RayClusterAutoscalerEvents) || !utils.IsAutoscalingEnabled(instance) {
+		return nil
+	}
+
+	events, err := r.listAutoscalerEvents(ctx, instance)
+	if err != nil {
+		// The events are informational, so the Ray dashboard being temporarily unavailable isn't an error.
+		logger.Info("Failed to list the events of the Ray autoscaler", "error", err)
+		return nil
+	}
+	if events == nil {
+		return nil
+	}
+
+	key := types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}
+	previous, seen := r.autoscalerEventIDs.Load(key)
+	eventIDs := make(map[string]bool, len(events))
+	for _, event := range events {
+		eventIDs[event.EventID] = true
+		if !seen || previous.(map[string]bool)[event.EventID] || !isSignificantAutoscalerEvent(event) {
+			continue
+		}
+		if event.Severity == "WARNING" || event.Severity == "ERROR" {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.AutoscalerFailedToScale), "Ray autoscaler: %s", event.Message)
+		} else {
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.AutoscalerScaled), "Ray autoscaler: %s", event.Message)
+		}
+	}
+	// Only the IDs of the listed events are kept, so the memory is bounded by the number of events returned by Ray.
+	r.autoscalerEventIDs.Store(key, eventIDs)
+	return nil
+}
+
+// listAutoscalerEvents lists the events of the Ray autoscaler. It returns nil if the head Pod isn't running and ready.
+func (r *RayClusterReconciler) listAutoscalerEvents(ctx context.Context, instance *rayv1.RayCluster) ([]utils.RayClusterEventInfo, error) {
+	headPod, err := common.GetRayClusterHeadPod(ctx, r, instance)
+	if err != nil {
+		return nil, err
+	}
+	if headPod == nil || !utils.IsRunningAndReady(headPod) {
+		return nil, nil
+	}
+
+	clientURL, err := utils.FetchHeadServiceURL(ctx, r.Client, instance, utils.DashboardPortName)
+	if err != nil {
+		return nil, err
+	}
+	rayDashboardClient := r.dashboardClientFunc()
+	if err := rayDashboardClient.InitClient(ctx, clientURL, instance); err != nil {
+		return nil, err
+	}
+	events, err := rayDashboardClient.ListAutoscalerEvents(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if events == nil {
+		events = []utils.RayClusterEventInfo{}
+	}
+	return events, nil
+}
+
+// isSignificantAutoscalerEvent returns whether the autoscaler event is a scaling decision or a failure. The debug
+// events and the periodic reports of the size of the Ray cluster, e.g. "Resized to 8 CPUs.", are skipped.
+func isSignificantAutoscalerEvent(event utils.RayClusterEventInfo) bool {
+	if event.Severity == "DEBUG" || event.Severity == "TRACE" {
+		return false
+	}
+	return !strings.HasPrefix(event.Message, "Resized to ")
+}
+
+// resourceDemandsEqual compares the quantities semantically because the same quantity can have different
+// internal representations before and after being serialized.
+func resourceDemandsEqual(a, b []rayv1.ResourceDemand) bool {
+	return slices.EqualFunc(a, b, func(x, y rayv1.ResourceDemand) bool {
+		if x.Count != y.Count || len(x.Resources) != len(y.Resources) {
+			return false
+		}
+		for name, quantity := range x.Resources {
+			if other, ok := y.Resources[name]; !ok || quantity.Cmp(other) != 0 {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// reconcileRayQuota decides whether the RayCluster fits in the RayQuotas of its namespace, and sets the
+// RayClusterQuotaExceeded condition accordingly. The quotas are only enforced before the Pods are created, so an
+// admitted RayCluster keeps its Pods even if a quota is lowered later.
+func (r *RayClusterReconciler) reconcileRayQuota(ctx context.Context, instance *rayv1.RayCluster) error {
+	logger := ctrl.LoggerFrom(ctx)
+	if !features.Enabled(features.RayQuota) {
+		return nil
+	}
+	if instance.Spec.Suspend != nil && *instance.Spec.Suspend {
+		// A suspended RayCluster doesn't use the quota, so it is evaluated again when it is resumed.
+		meta.RemoveStatusCondition(&instance.Status.Conditions, string(rayv1.RayClusterQuotaExceeded))
+		return nil
+	}
+	condition := meta.FindStatusCondition(instance.Status.Conditions, string(rayv1.RayClusterQuotaExceeded))
+	if condition != nil && condition.Status == metav1.ConditionFalse {
+		return nil
+	}
+	if condition != nil && condition.Reason == rayv1.RayQuotaRejected && condition.ObservedGeneration == instance.Generation {
+		return nil
+	}
+
+	quotas := rayv1.RayQuotaList{}
+	if err := r.List(ctx, &quotas, client.InNamespace(instance.Namespace)); err != nil {
+		return err
+	}
+	if len(quotas.Items) == 0 {
+		meta.RemoveStatusCondition(&instance.Status.Conditions, string(rayv1.RayClusterQuotaExceeded))
+		return nil
+	}
+
+	admitted := metav1.Condition{
+		Type:               string(rayv1.RayClusterQuotaExceeded),
+		Status:             metav1.ConditionFalse,
+		Reason:             rayv1.RayQuotaAdmitted,
+		Message:            "The RayCluster fits in the RayQuotas of the namespace",
+		ObservedGeneration: instance.Generation,
+	}
+	if condition == nil {
+		// The RayCluster was admitted before the RayQuotas were created if it already has a head Pod.
+		headPod, err := common.GetRayClusterHeadPod(ctx, r, instance)
+		if err != nil {
+			return err
+		}
+		if headPod != nil {
+			meta.SetStatusCondition(&instance.Status.Conditions, admitted)
+			return nil
+		}
+	}
+
+	clusters := rayv1.RayClusterList{}
+	if err := r.List(ctx, &clusters, client.InNamespace(instance.Namespace)); err != nil {
+		return err
+	}
+	used := common.CalculateRayQuotaUsage(instance, clusters.Items)
+	requested := utils.CalculateMaxResources(instance)
+	quota, resourceName := common.FindExceededRayQuota(quotas.Items, used, requested)
+	if quota == nil {
+		if condition != nil {
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.AdmittedByRayQuota),
+				"RayCluster %s/%s is admitted by the RayQuotas of the namespace", instance.Namespace, instance.Name)
+		}
+		meta.SetStatusCondition(&instance.Status.Conditions, admitted)
+		return nil
+	}
+
+	reason := rayv1.RayQuotaQueued
+	if common.GetRayQuotaPolicy(quota) == rayv1.RejectRayQuotaPolicy {
+		reason = rayv1.RayQuotaRejected
+	}
+	usedQuantity, requestedQuantity, hardQuantity := used[resourceName], requested[resourceName], quota.Spec.Hard[resourceName]
+	message := fmt.Sprintf("The RayCluster exceeds the %s of RayQuota %s: used %s, requested %s, hard %s",
+		resourceName, quota.Name, usedQuantity.String(), requestedQuantity.String(), hardQuantity.String())
+	if condition == nil || condition.Reason != reason {
+		logger.Info("The RayCluster exceeds a RayQuota", "RayQuota", quota.Name, "reason", reason, "message", message)
+		r.Recorder.Event(instance, corev1.EventTypeWarning, string(utils.ExceededRayQuota), message)
+	}
+	meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:               string(rayv1.RayClusterQuotaExceeded),
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: instance.Generation,
+	})
+	return nil
+}
+
+// reconcileProfiling attaches a profiling container to the Ray Pods annotated with `ray.io/profile`, and records an
+// event with the location of the output once the profiling container terminates. A Pod is profiled by at most one
+// container at a time, and a new request waits until the previous profiling container terminates.
+func (r *RayClusterReconciler) reconcileProfiling(ctx context.Context, instance *rayv1.RayCluster) error {
+	logger := ctrl.LoggerFrom(ctx)
+	if instance.Spec.Profiling == nil {
+		return nil
+	}
+
+	pods := corev1.PodList{}
+	if err := r.List(ctx, &pods, common.RayClusterAllPodsAssociationOptions(instance).ToListOptions()...); err != nil {
+		return err
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if containerName, ok := pod.Annotations[utils.RayProfileContainerAnnotationKey]; ok {
+			state := common.GetProfilingContainerState(pod, containerName)
+			if state == nil {
+				continue
+			}
+			output := pod.Annotations[utils.RayProfileOutputAnnotationKey]
+			if state.ExitCode == 0 {
+				r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.CompletedProfiling),
+					"Completed profiling Pod %s/%s, the output is stored at %s", pod.Namespace, pod.Name, output)
+			} else {
+				r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToProfile),
+					"Failed to profile Pod %s/%s, the profiling container %s exited with code %d: %s", pod.Namespace, pod.Name, containerName, state.ExitCode, state.Message)
+			}
+			if err := r.patchPodAnnotations(ctx, pod, nil, utils.RayProfileContainerAnnotationKey, utils.RayProfileOutputAnnotationKey); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, ok := pod.Annotations[utils.RayProfileAnnotationKey]; !ok {
+			continue
+		}
+
+		requestKeys := []string{utils.RayProfileAnnotationKey, utils.RayProfilePIDAnnotationKey, utils.RayProfileDurationAnnotationKey}
+		containerName := fmt.Sprintf("%s%d", utils.ProfileContainerNamePrefix, time.Now().Unix())
+		container, output, err := common.BuildProfilingContainer(instance.Spec.Profiling, pod, containerName)
+		if err != nil {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.InvalidProfilingRequest),
+				"Invalid profiling request of Pod %s/%s: %v", pod.Namespace, pod.Name, err)
+			if err := r.patchPodAnnotations(ctx, pod, nil, requestKeys...); err != nil {
+				return err
+			}
+			continue
+		}
+		podWithContainer := pod.DeepCopy()
+		podWithContainer.Spec.EphemeralContainers = append(podWithContainer.Spec.EphemeralContainers, container)
+		if err := r.SubResource("ephemeralcontainers").Update(ctx, podWithContainer); err != nil {
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToProfile),
+				"Failed to attach the profiling container to Pod %s/%s: %v", pod.Namespace, pod.Name, err)
+			return err
+		}
+		logger.Info("Attached the profiling container", "pod", pod.Name, "container", containerName, "output", output)
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.StartedProfiling),
+			"Started profiling Pod %s/%s with %s, the output will be stored at %s", pod.Namespace, pod.Name, pod.Annotations[utils.RayProfileAnnotationKey], output)
+		if err := r.patchPodAnnotations(ctx, pod, map[string]string{
+			utils.RayProfileContainerAnnotationKey: containerName,
+			utils.RayProfileOutputAnnotationKey:    output,
+		}, requestKeys...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileKarpenterConsolidation sets the karpenter.sh/do-not-disrupt annotation on the head Pod and on the worker
+// Pods whose Ray node runs tasks or actors, and removes it from the idle worker Pods, so that Karpenter consolidates
+// the nodes of the idle worker Pods without killing Ray work. The annotations of the worker Pods are left as they are
+// while the Ray dashboard is unavailable. The groups whose template sets the annotation are left to the user.
+func (r *RayClusterReconciler) reconcileKarpenterConsolidation(ctx context.Context, instance *rayv1.RayCluster) error {
+	logger := ctrl.LoggerFrom(ctx)
+	if !features.Enabled(features.RayKarpenterConsolidation) {
+		return nil
+	}
+
+	if _, ok := instance.Spec.HeadGroupSpec.Template.Annotations[utils.KarpenterDoNotDisruptAnnotationKey]; !ok {
+		headPods := corev1.PodList{}
+		if err := r.List(ctx, &headPods, common.RayClusterHeadPodsAssociationOptions(instance).ToListOptions()...); err != nil {
+			return err
+		}
+		for i := range headPods.Items {
+			if err := r.setDoNotDisrupt(ctx, &headPods.Items[i], true); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !hasKarpenterConsolidationWorkers(ctx, instance) {
+		return nil
+	}
+	busyPodIPs, err := r.listBusyRayNodeIPs(ctx, instance)
+	if err != nil {
+		logger.Info("Failed to list the busy Ray nodes, the do-not-disrupt annotations of the worker Pods are kept", "error", err)
+		return nil
+	}
+	for _, worker := range instance.Spec.WorkerGroupSpecs {
+		if _, ok := worker.Template.Annotations[utils.KarpenterDoNotDisruptAnnotationKey]; ok {
+			continue
+		}
+		workerPods := corev1.PodList{}
+		if err := r.List(ctx, &workerPods, common.RayClusterGroupPodsAssociationOptions(instance, worker.GroupName).ToListOptions()...); err != nil {
+			return err
+		}
+		for i := range workerPods.Items {
+			pod := &workerPods.Items[i]
+			if isDeletingWorkerPod(*pod) {
+				continue
+			}
+			busy := slices.ContainsFunc(busyPodIPs, func(ip string) bool { return utils.IsPodIP(pod, ip) })
+			if err := r.setDoNotDisrupt(ctx, pod, busy); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// hasKarpenterConsolidationWorkers returns whether the RayCluster has worker Pods whose karpenter.sh/do-not-disrupt
+// annotation is managed by KubeRay, i.e. a worker group with replicas whose template doesn't set the annotation.
+func hasKarpenterConsolidationWorkers(ctx context.Context, instance *rayv1.RayCluster) bool {
+	return slices.ContainsFunc(instance.Spec.WorkerGroupSpecs, func(worker rayv1.WorkerGroupSpec) bool {
+		_, ok := worker.Template.Annotations[utils.KarpenterDoNotDisruptAnnotationKey]
+		return !ok && utils.GetWorkerGroupDesiredReplicas(ctx, worker) > 0
+	})
+}
+
+// listBusyRayNodeIPs returns the IPs of the alive Ray nodes that run tasks or actors.
+func (r *RayClusterReconciler) listBusyRayNodeIPs(ctx context.Context, instance *rayv1.RayCluster) ([]string, error) {
+	rayDashboardClient, nodes, err := r.listAliveRayNodes(ctx, instance)
+	if err != nil {
+		return nil, err
+	}
+	busyNodeIDs := map[string]bool{}
+	actors, err := rayDashboardClient.ListAliveActors(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, actor := range actors {
+		busyNodeIDs[actor.NodeID] = true
+	}
+	tasks, err := rayDashboardClient.ListRunningTasks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, task := range tasks {
+		busyNodeIDs[task.NodeID] = true
+	}
+	var busyNodeIPs []string
+	for _, node := range nodes {
+		if busyNodeIDs[node.NodeID] {
+			busyNodeIPs = append(busyNodeIPs, node.NodeIP)
+		}
+	}
+	return busyNodeIPs, nil
+}
+
+// setDoNotDisrupt sets the karpenter.sh/do-not-disrupt annotation of the Pod if doNotDisrupt is true, and removes it
+// otherwise.
+func (r *RayClusterReconciler) setDoNotDisrupt(ctx context.Context, pod *corev1.Pod, doNotDisrupt bool) error {
+	_, annotated := pod.Annotations[utils.KarpenterDoNotDisruptAnnotationKey]
+	if doNotDisrupt && pod.Annotations[utils.KarpenterDoNotDisruptAnnotationKey] != "true" {
+		return r.patchPodAnnotations(ctx, pod, map[string]string{utils.KarpenterDoNotDisruptAnnotationKey: "true"})
+	}
+	if !doNotDisrupt && annotated {
+		return r.patchPodAnnotations(ctx, pod, nil, utils.KarpenterDoNotDisruptAnnotationKey)
+	}
+	return nil
+}
+
+// patchPodAnnotations sets and removes annotations of the Pod.
+func (r *RayClusterReconciler) patchPodAnnotations(ctx context.Context, pod *corev1.Pod, set map[string]string, remove ...string) error {
+	patched := pod.DeepCopy()
+	if patched.Annotations == nil {
+		patched.Annotations = map[string]string{}
+	}
+	for k, v := range set {
+		patched.Annotations[k] = v
+	}
+	for _, k := range remove {
+		delete(patched.Annotations, k)
+	}
+	return r.Patch(ctx, patched, client.MergeFrom(pod))
+}
+
+// reconcileImagePrePull pre-pulls the images of the RayCluster with a DaemonSet for each group before the Pods of the
+// RayCluster are first created, and sets the RayClusterImagesPrePulled condition once the images are pulled or the
+// timeout expires. The DaemonSets are deleted once the condition is set.
+func (r *RayClusterReconciler) reconcileImagePrePull(ctx context.Context, instance *rayv1.RayCluster) error {
+	logger := ctrl.LoggerFrom(ctx)
+	if !isImagePrePullPending(instance) ||
+		(instance.Spec.Suspend != nil && *instance.Spec.Suspend) ||
+		meta.IsStatusConditionTrue(instance.Status.Conditions, string(rayv1.RayClusterQuotaExceeded)) {
+		return nil
+	}
+
+	setCondition := func(reason, message string) {
+		meta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+			Type:    string(rayv1.RayClusterImagesPrePulled),
+			Status:  metav1.ConditionTrue,
+			Reason:  reason,
+			Message: message,
+		})
+	}
+	headPod, err := common.GetRayClusterHeadPod(ctx, r, instance)
+	if err != nil {
+		return err
+	}
+	if headPod != nil {
+		setCondition(rayv1.ImagePrePullSkipped, "The Pods of the RayCluster were created before the images were pre-pulled")
+		return nil
+	}
+
+	timeout := time.Duration(utils.DefaultImagePrePullTimeoutSeconds) * time.Second
+	if instance.Spec.ImagePrePull.TimeoutSeconds != nil {
+		timeout = time.Duration(*instance.Spec.ImagePrePull.TimeoutSeconds) * time.Second
+	}
+	completed, timedOut := true, false
+	var daemonSets []*appsv1.DaemonSet
+	for groupName, template := range common.ImagePrePullGroups(instance) {
+		desired := common.BuildImagePrePullDaemonSet(instance, groupName, template)
+		daemonSet := &appsv1.DaemonSet{}
+		err := r.Get(ctx, client.ObjectKeyFromObject(desired), daemonSet)
+		if errors.IsNotFound(err) {
+			if err := controllerutil.SetControllerReference(instance, desired, r.Scheme); err != nil {
+				return err
+			}
+			if err := r.Create(ctx, desired); err != nil {
+				r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToCreateImagePrePullDaemonSet),
+					"Failed to create image pre-pull DaemonSet %s/%s: %v", desired.Namespace, desired.Name, err)
+				return err
+			}
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.CreatedImagePrePullDaemonSet),
+				"Created image pre-pull DaemonSet %s/%s", desired.Namespace, desired.Name)
+			completed = false
+			continue
+		} else if err != nil {
+			return err
+		}
+		daemonSets = append(daemonSets, daemonSet)
+		if !common.IsImagePrePullDaemonSetCompleted(daemonSet) {
+			completed = false
+			timedOut = timedOut || time.Since(daemonSet.CreationTimestamp.Time) >= timeout
+		}
+	}
+	if !completed && !timedOut {
+		logger.Info("Waiting for the images to be pre-pulled")
+		return nil
+	}
+
+	for _, daemonSet := range daemonSets {
+		if err := r.Delete(ctx, daemonSet, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedImagePrePullDaemonSet),
+			"Deleted image pre-pull DaemonSet %s/%s", daemonSet.Namespace, daemonSet.Name)
+	}
+	if completed {
+		setCondition(rayv1.ImagePrePullCompleted, "The images of the RayCluster are pulled on every node that can run its Pods")
+	} else {
+		setCondition(rayv1.ImagePrePullTimedOut, fmt.Sprintf("The images of the RayCluster aren't pulled after %s", timeout))
+	}
+	return nil
+}
+
+// isImagePrePullPending returns whether the Pods of the RayCluster wait for its images to be pre-pulled.
+func isImagePrePullPending(instance *rayv1.RayCluster) bool {
+	return instance.Spec.ImagePrePull != nil &&
+		meta.FindStatusCondition(instance.Status.Conditions, string(rayv1.RayClusterImagesPrePulled)) == nil
+}
+
+// reconcileIdleTimeout records the last time the RayCluster had running Ray jobs or alive actors in the status, and
+// suspends or deletes the RayCluster once it has been idle for IdleTimeoutSeconds. It returns true if the RayCluster
+// is deleted.
+func (r *RayClusterReconciler) reconcileIdleTimeout(ctx context.Context, instance *rayv1.RayCluster) (bool, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	originatedFrom := instance.Labels[utils.RayOriginatedFromCRDLabelKey]
+	if instance.Spec.IdleTimeoutSeconds == nil || (instance.Spec.Suspend != nil && *instance.Spec.Suspend) ||
+		originatedFrom == utils.RayOriginatedFromCRDLabelValue(utils.RayJobCRD) ||
+		originatedFrom == utils.RayOriginatedFromCRDLabelValue(utils.RayServiceCRD) {
+		instance.Status.LastActivityTime = nil
+		return false, nil
+	}
+
+	now := metav1.Now()
+	if instance.Status.LastActivityTime == nil {
+		instance.Status.LastActivityTime = &now
+		return false, nil
+	}
+	active, err := r.isRayClusterActive(ctx, instance)
+	if err != nil {
+		// The RayCluster is never considered idle while its activity is unknown.
+		logger.Info("Failed to get the activity of the RayCluster", "error", err)
+		return false, nil
+	}
+	idleDuration := now.Sub(instance.Status.LastActivityTime.Time)
+	if active {
+		// Avoid updating the status in every reconciliation.
+		if idleDuration >= utils.RayClusterIdleTimeoutRequeueDuration {
+			instance.Status.LastActivityTime = &now
+		}
+		return false, nil
+	}
+	if idleDuration < time.Duration(*instance.Spec.IdleTimeoutSeconds)*time.Second {
+		return false, nil
+	}
+
+	if instance.Spec.IdleAction != nil && *instance.Spec.IdleAction == rayv1.DeleteIdleAction {
+		logger.Info("Deleting the idle RayCluster", "idleDuration", idleDuration)
+		if err := r.Delete(ctx, instance); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedIdleRayCluster),
+			"Deleted RayCluster %s/%s after being idle for %s", instance.Namespace, instance.Name, idleDuration.Round(time.Second))
+		return true, nil
+	}
+
+	logger.Info("Suspending the idle RayCluster", "idleDuration", idleDuration)
+	suspended := instance.DeepCopy()
+	suspended.Spec.Suspend = ptr.To(true)
+	if err := r.Patch(ctx, suspended, client.MergeFrom(instance)); err != nil {
+		return false, err
+	}
+	instance.Spec.Suspend = suspended.Spec.Suspend
+	instance.ResourceVersion = suspended.ResourceVersion
+	instance.Status.LastActivityTime = nil
+	r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.SuspendedIdleRayCluster),
+		"Suspended RayCluster %s/%s after being idle for %s", instance.Namespace, instance.Name, idleDuration.Round(time.Second))
+	return false, nil
+}
+
+// isRayClusterActive returns whether the Ray cluster has Ray jobs that aren't terminal or alive actors. It returns an
+// error if the head Pod isn't running and ready.
+func (r *RayClusterReconciler) isRayClusterActive(ctx context.Context, instance *rayv1.RayCluster) (bool, error) {
+	headPod, err := common.GetRayClusterHeadPod(ctx, r, instance)
+	if err != nil {
+		return false, err
+	}
+	if headPod == nil || !utils.IsRunningAndReady(headPod) {
+		return false, fmt.Errorf("the head Pod of RayCluster %s/%s is not running and ready", instance.Namespace, instance.Name)
+	}
+
+	clientURL, err := utils.FetchHeadServiceURL(ctx, r.Client, instance, utils.DashboardPortName)
+	if err != nil {
+		return false, err
+	}
+	rayDashboardClient := r.dashboardClientFunc()
+	if err := rayDashboardClient.InitClient(ctx, clientURL, instance); err != nil {
+		return false, err
+	}
+	jobs, err := rayDashboardClient.ListJobs(ctx)
+	if err != nil {
+		return false, err
+	}
+	if jobs != nil {
+		for _, job := range *jobs {
+			if !rayv1.IsJobTerminal(job.JobStatus) {
+				return true, nil
+			}
+		}
+	}
+	actors, err := rayDashboardClient.ListAliveActors(ctx)
+	if err != nil {
+		return false, err
+	}
+	return len(actors) > 0, nil
+}
+
+// reconcileBalloonPods creates and deletes balloon Pods so that each worker group has as many balloon Pods as needed
+// by the pending resource demands.
+func (r *RayClusterReconciler) reconcileBalloonPods(ctx context.Context, instance *rayv1.RayCluster) error {
+	logger := ctrl.LoggerFrom(ctx)
+	if !r.rayClusterScaleExpectation.IsSatisfied(ctx, instance.Namespace, instance.Name, expectations.BalloonGroup) {
+		logger.Info("reconcileBalloonPods", "Expectation", "NotSatisfiedBalloonExpectations, reconcile balloon Pods later")
+		return nil
+	}
+	balloonPods := corev1.PodList{}
+	if err := r.List(ctx, &balloonPods, common.RayClusterBalloonPodsAssociationOptions(instance).ToListOptions()...); err != nil {
+		return err
+	}
+
+	desired := map[string]int32{}
+	if instance.Spec.AutoscalerOptions != nil && instance.Spec.AutoscalerOptions.BalloonPods != nil {
+		desired = common.CalculateBalloonPods(instance, instance.Status.PendingResourceDemands)
+	}
+
+	existing := map[string]int32{}
+	for _, pod := range balloonPods.Items {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		group := pod.Labels[utils.RayNodeGroupLabelKey]
+		if existing[group] < desired[group] {
+			existing[group]++
+			continue
+		}
+		if err := r.Delete(ctx, &pod); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToDeleteBalloonPod), "Failed deleting balloon Pod %s/%s, %v", pod.Namespace, pod.Name, err)
+			return err
+		}
+		r.rayClusterScaleExpectation.ExpectScalePod(pod.Namespace, instance.Name, expectations.BalloonGroup, pod.Name, expectations.Delete)
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.DeletedBalloonPod), "Deleted balloon Pod %s/%s", pod.Namespace, pod.Name)
+	}
+
+	for _, worker := range instance.Spec.WorkerGroupSpecs {
+		for i := existing[worker.GroupName]; i < desired[worker.GroupName]; i++ {
+			pod := common.BuildBalloonPod(instance, worker)
+			if err := controllerutil.SetControllerReference(instance, &pod, r.Scheme); err != nil {
+				return err
+			}
+			if err := r.Create(ctx, &pod); err != nil {
+				r.Recorder.Eventf(instance, corev1.EventTypeWarning, string(utils.FailedToCreateBalloonPod), "Failed to create balloon Pod for worker group %s, %v", worker.GroupName, err)
+				return err
+			}
+			r.rayClusterScaleExpectation.ExpectScalePod(pod.Namespace, instance.Name, expectations.BalloonGroup, pod.Name, expectations.Create)
+			logger.Info("Created balloon Pod for RayCluster", "name", pod.Name, "group", worker.GroupName)
+			r.Recorder.Eventf(instance, corev1.EventTypeNormal, string(utils.CreatedBalloonPod), "Created balloon Pod %s/%s", pod.Namespace, pod.Name)
+		}
+	}
 	return nil
 }
 
@@ -1727,7 +3940,10 @@ func (r *RayClusterReconciler) updateRayClusterStatus(ctx context.Context, origi
 		return inconsistent, nil
 	}
 	logger.Info("updateRayClusterStatus", "name", originalRayClusterInstance.Name, "old status", originalRayClusterInstance.Status, "new status", newInstance.Status)
-	err := r.Status().Update(ctx, newInstance)
+	// The update isn't interrupted when the operator shuts down.
+	updateCtx, cancel := utils.WithoutShutdownCancel(ctx)
+	defer cancel()
+	err := r.Status().Update(updateCtx, newInstance)
 	if err != nil {
 		logger.Info("Error updating status", "name", originalRayClusterInstance.Name, "error", err, "RayCluster", newInstance)
 	}
@@ -1746,3 +3962,64 @@ func sumGPUs(resources map[corev1.ResourceName]resource.Quantity) resource.Quant
 
 	return totalGPUs
 }
+
+// resourceUsageSampleInterval is the minimum time between two samples of the resource usage of a RayJob or a
+// RayService, so that their status isn't updated at every reconciliation.
+const resourceUsageSampleInterval = time.Minute
+
+// sampleResourceUsage samples the Pods of the RayClusters and adds the resources they requested since the last sample
+// to the usage. The Pods aren't sampled again within resourceUsageSampleInterval unless force is true.
+func sampleResourceUsage(ctx context.Context, c client.Client, usage *rayv1.ResourceUsage, rayClusters []types.NamespacedName, now time.Time, force bool) (*rayv1.ResourceUsage, error) {
+	if usage != nil && usage.LastSampleTime != nil && !force && now.Sub(usage.LastSampleTime.Time) < resourceUsageSampleInterval {
+		return usage, nil
+	}
+	var pods []corev1.Pod
+	for _, rayCluster := range rayClusters {
+		instance := &rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Name: rayCluster.Name, Namespace: rayCluster.Namespace}}
+		podList := corev1.PodList{}
+		if err := c.List(ctx, &podList, common.RayClusterAllPodsAssociationOptions(instance).ToListOptions()...); err != nil {
+			return nil, err
+		}
+		pods = append(pods, podList.Items...)
+		if features.Enabled(features.RayMultiNamespaceWorkerGroups) {
+			if err := c.List(ctx, &podList, common.RayClusterRemoteWorkerPodsAssociationOptions(instance).ToListOptions()...); err != nil {
+				return nil, err
+			}
+			pods = append(pods, podList.Items...)
+		}
+	}
+	return accumulateResourceUsage(usage, pods, now), nil
+}
+
+// accumulateResourceUsage returns the usage plus the resources requested by the ready Pods multiplied by the time since
+// the last sample. The usage starts being accounted at the first sample.
+func accumulateResourceUsage(usage *rayv1.ResourceUsage, pods []corev1.Pod, now time.Time) *rayv1.ResourceUsage {
+	// The times are serialized with a precision of one second, so the samples are too, to not account the same
+	// fractions of seconds twice.
+	now = now.Truncate(time.Second)
+	var cpuHours, gpuHours, hours float64
+	if usage != nil {
+		// The usage is only written by KubeRay, so an invalid value is only possible if it was edited, and it restarts
+		// from zero.
+		cpuHours, _ = strconv.ParseFloat(usage.CPUHours, 64)
+		gpuHours, _ = strconv.ParseFloat(usage.GPUHours, 64)
+		if usage.LastSampleTime != nil {
+			hours = max(now.Sub(usage.LastSampleTime.Time).Hours(), 0)
+		}
+	}
+	for _, pod := range pods {
+		if !utils.IsRunningAndReady(&pod) {
+			continue
+		}
+		podResource := utils.CalculatePodResource(pod.Spec)
+		cpus := podResource[corev1.ResourceCPU]
+		gpus := sumGPUs(podResource)
+		cpuHours += cpus.AsApproximateFloat64() * hours
+		gpuHours += gpus.AsApproximateFloat64() * hours
+	}
+	return &rayv1.ResourceUsage{
+		CPUHours:       strconv.FormatFloat(cpuHours, 'f', -1, 64),
+		GPUHours:       strconv.FormatFloat(gpuHours, 'f', -1, 64),
+		LastSampleTime: &metav1.Time{Time: now},
+	}
+}
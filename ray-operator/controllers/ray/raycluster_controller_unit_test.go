@@ -35,22 +35,28 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	clientFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
@@ -621,6 +627,142 @@ func TestReconcile_RemoveWorkersToDelete_NoRandomDelete(t *testing.T) {
 	}
 }
 
+func TestReconcile_RemoveWorkersToDelete_MaxParallelDeletions(t *testing.T) {
+	setupTest(t)
+	defer os.Unsetenv(utils.ENABLE_RANDOM_POD_DELETE)
+	os.Unsetenv(utils.ENABLE_RANDOM_POD_DELETE)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.EnableInTreeAutoscaling = ptr.To(true)
+	cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = []string{"pod2", "pod3"}
+	cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.MaxParallelDeletions = ptr.To[int32](1)
+
+	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods...).Build()
+	ctx := context.Background()
+	r := &RayClusterReconciler{
+		Client:                     fakeClient,
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     scheme.Scheme,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+	}
+	workerPodNames := func() []string {
+		podList := corev1.PodList{}
+		err := fakeClient.List(ctx, &podList, client.InNamespace(namespaceStr), client.MatchingLabelsSelector{Selector: workerSelector})
+		require.NoError(t, err)
+		names := []string{}
+		for _, pod := range podList.Items {
+			names = append(names, pod.Name)
+		}
+		return names
+	}
+
+	// Only pod2 is deleted, and no Pod is created to replace pod3 while its deletion is postponed.
+	err := r.reconcilePods(ctx, cluster)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"pod1", "pod3", "pod4", "pod5"}, workerPodNames())
+
+	// pod3 is deleted once pod2 is gone.
+	err = r.reconcilePods(ctx, cluster)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"pod1", "pod4", "pod5"}, workerPodNames())
+}
+
+func TestReconcile_RemoveWorkersToDelete_IgnoredPods(t *testing.T) {
+	setupTest(t)
+	defer os.Unsetenv(utils.ENABLE_RANDOM_POD_DELETE)
+	os.Unsetenv(utils.ENABLE_RANDOM_POD_DELETE)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.EnableInTreeAutoscaling = ptr.To(true)
+	cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = []string{"pod2", "other-group-pod", "NonExistentPod"}
+
+	otherGroupPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "other-group-pod",
+			Namespace: namespaceStr,
+			Labels: map[string]string{
+				utils.RayClusterLabelKey:   cluster.Name,
+				utils.RayNodeGroupLabelKey: "other-group",
+				utils.RayNodeTypeLabelKey:  string(rayv1.WorkerNode),
+			},
+		},
+	}
+	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(append(testPods, otherGroupPod)...).Build()
+	ctx := context.Background()
+	recorder := record.NewFakeRecorder(100)
+	r := &RayClusterReconciler{
+		Client:                     fakeClient,
+		Recorder:                   recorder,
+		Scheme:                     scheme.Scheme,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+	}
+
+	err := r.reconcilePods(ctx, cluster)
+	require.NoError(t, err)
+
+	// The Pod of the other worker group isn't deleted.
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(otherGroupPod), &corev1.Pod{})
+	require.NoError(t, err)
+	err = fakeClient.Get(ctx, client.ObjectKey{Name: "pod2", Namespace: namespaceStr}, &corev1.Pod{})
+	assert.True(t, k8serrors.IsNotFound(err))
+
+	var ignoredEvents []string
+	for len(recorder.Events) > 0 {
+		if event := <-recorder.Events; strings.Contains(event, string(utils.IgnoredWorkerToDelete)) {
+			ignoredEvents = append(ignoredEvents, event)
+		}
+	}
+	require.Len(t, ignoredEvents, 2)
+	assert.Contains(t, ignoredEvents[0], "other-group-pod")
+	assert.Contains(t, ignoredEvents[0], common.WorkerToDeleteInOtherGroup)
+	assert.Contains(t, ignoredEvents[1], "NonExistentPod")
+	assert.Contains(t, ignoredEvents[1], common.WorkerToDeleteNotFound)
+}
+
+func TestReconcile_RemoveWorkersToDelete_RespectPodDisruptionBudgets(t *testing.T) {
+	setupTest(t)
+	defer os.Unsetenv(utils.ENABLE_RANDOM_POD_DELETE)
+	os.Unsetenv(utils.ENABLE_RANDOM_POD_DELETE)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.EnableInTreeAutoscaling = ptr.To(true)
+	cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = []string{"pod2", "pod3"}
+	cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.RespectPodDisruptionBudgets = ptr.To(true)
+
+	ctx := context.Background()
+	evicted := []string{}
+	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods...).WithInterceptorFuncs(interceptor.Funcs{
+		SubResourceCreate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+			// Simulate a PodDisruptionBudget that only allows pod2 to be evicted.
+			if subResourceName == "eviction" && obj.GetName() == "pod3" {
+				return k8serrors.NewTooManyRequests("Cannot evict pod as it would violate the pod's disruption budget.", 0)
+			}
+			evicted = append(evicted, obj.GetName())
+			return c.SubResource(subResourceName).Create(ctx, obj, subResource, opts...)
+		},
+	}).Build()
+	r := &RayClusterReconciler{
+		Client:                     fakeClient,
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     scheme.Scheme,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+	}
+
+	err := r.reconcilePods(ctx, cluster)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"pod2"}, evicted)
+
+	// pod3 is kept until its eviction is allowed, and no Pod is created to replace it.
+	podList := corev1.PodList{}
+	err = fakeClient.List(ctx, &podList, client.InNamespace(namespaceStr), client.MatchingLabelsSelector{Selector: workerSelector})
+	require.NoError(t, err)
+	names := []string{}
+	for _, pod := range podList.Items {
+		names = append(names, pod.Name)
+	}
+	assert.ElementsMatch(t, []string{"pod1", "pod3", "pod4", "pod5"}, names)
+}
+
 func TestReconcile_RandomDelete_OK(t *testing.T) {
 	setupTest(t)
 
@@ -670,6 +812,49 @@ func TestReconcile_RandomDelete_OK(t *testing.T) {
 	}
 }
 
+func TestReconcile_RandomDelete_BurstablePodsOnVirtualNodesFirst(t *testing.T) {
+	setupTest(t)
+	testRayCluster.Spec.EnableInTreeAutoscaling = nil
+	testRayCluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = []string{}
+	testRayCluster.Spec.WorkerGroupSpecs[0].Replicas = ptr.To[int32](3)
+	testRayCluster.Spec.WorkerGroupSpecs[0].Burstable = &rayv1.BurstableOptions{}
+
+	// pod2 and pod4 run on a virtual node, and pod1 on a node that doesn't exist anymore.
+	objects := []runtime.Object{
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "physical-node"}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:   "virtual-node",
+			Labels: map[string]string{utils.DefaultVirtualNodeLabelKey: utils.DefaultVirtualNodeLabelValue},
+		}},
+	}
+	nodeNames := map[string]string{"pod1": "deleted-node", "pod2": "virtual-node", "pod3": "physical-node", "pod4": "virtual-node", "pod5": "physical-node"}
+	for _, object := range testPods {
+		pod := object.(*corev1.Pod).DeepCopy()
+		pod.Spec.NodeName = nodeNames[pod.Name]
+		objects = append(objects, pod)
+	}
+	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(objects...).Build()
+	ctx := context.Background()
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:                     fakeClient,
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     scheme.Scheme,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+	}
+
+	err := testRayClusterReconciler.reconcilePods(ctx, testRayCluster)
+	require.NoError(t, err)
+
+	podList := corev1.PodList{}
+	err = fakeClient.List(ctx, &podList, &client.ListOptions{LabelSelector: workerSelector, Namespace: namespaceStr})
+	require.NoError(t, err)
+	var names []string
+	for _, pod := range podList.Items {
+		names = append(names, pod.Name)
+	}
+	assert.ElementsMatch(t, []string{"pod1", "pod3", "pod5"}, names)
+}
+
 func TestReconcile_PodDeleted_Diff0_OK(t *testing.T) {
 	setupTest(t)
 
@@ -1081,6 +1266,117 @@ func TestReconcileHeadService(t *testing.T) {
 	assert.NotNil(t, err, "Reconciler should report an error when there are two head services")
 }
 
+func TestReconcileHeadService_ExistingHeadService(t *testing.T) {
+	setupTest(t)
+
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.HeadGroupSpec.ExistingHeadServiceName = "mesh-head-svc"
+	existingService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mesh-head-svc",
+			Namespace: cluster.Namespace,
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP: "1.2.3.4",
+			Selector: map[string]string{
+				utils.RayClusterLabelKey:  cluster.Name,
+				utils.RayNodeTypeLabelKey: string(rayv1.HeadNode),
+			},
+			Ports: []corev1.ServicePort{{Name: utils.GcsServerPortName, Port: 6379, TargetPort: intstr.FromInt32(6379)}},
+		},
+	}
+	ctx := context.TODO()
+
+	// The reconciler reports an error if the existing head service doesn't exist.
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(cluster).Build()
+	r := &RayClusterReconciler{
+		Client:                     fakeClient,
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     newScheme,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+	}
+	err := r.reconcileHeadService(ctx, cluster)
+	assert.True(t, k8serrors.IsNotFound(err))
+
+	// No head service is created if the existing head service is valid, and the status refers to it.
+	fakeClient = clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(cluster, existingService).Build()
+	r.Client = fakeClient
+	err = r.reconcileHeadService(ctx, cluster)
+	require.NoError(t, err)
+	serviceList := corev1.ServiceList{}
+	err = fakeClient.List(ctx, &serviceList, client.InNamespace(cluster.Namespace))
+	require.NoError(t, err)
+	assert.Len(t, serviceList.Items, 1)
+
+	ip, name, err := r.getHeadServiceIPAndName(ctx, cluster)
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.3.4", ip)
+	assert.Equal(t, "mesh-head-svc", name)
+	require.NoError(t, r.updateEndpoints(ctx, cluster))
+	assert.Equal(t, "6379", cluster.Status.Endpoints[utils.GcsServerPortName])
+
+	// The reconciler reports an error if the existing head service doesn't select the head Pod.
+	existingService.Spec.Selector[utils.RayClusterLabelKey] = "another-cluster"
+	require.NoError(t, fakeClient.Update(ctx, existingService))
+	err = r.reconcileHeadService(ctx, cluster)
+	assert.Error(t, err)
+}
+
+func TestReconcilePodMonitors(t *testing.T) {
+	setupTest(t)
+
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.PrometheusMonitoring = &rayv1.PrometheusMonitoringOptions{Labels: map[string]string{"release": "prometheus"}}
+	restMapper := meta.NewDefaultRESTMapper(nil)
+	restMapper.Add(common.PodMonitorGroupVersionKind, meta.RESTScopeNamespace)
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRESTMapper(restMapper).Build()
+	r := &RayClusterReconciler{
+		Client:   fakeClient,
+		Recorder: &record.FakeRecorder{},
+		Scheme:   newScheme,
+	}
+	ctx := context.TODO()
+	getMonitor := func(nodeType rayv1.RayNodeType) (*unstructured.Unstructured, error) {
+		monitor := &unstructured.Unstructured{}
+		monitor.SetGroupVersionKind(common.PodMonitorGroupVersionKind)
+		err := fakeClient.Get(ctx, client.ObjectKey{Name: utils.GeneratePodMonitorName(cluster.Name, nodeType), Namespace: cluster.Namespace}, monitor)
+		return monitor, err
+	}
+
+	// The PodMonitors of the head and worker Pods are created.
+	err := r.reconcilePodMonitors(ctx, cluster)
+	require.NoError(t, err)
+	for _, nodeType := range []rayv1.RayNodeType{rayv1.HeadNode, rayv1.WorkerNode} {
+		monitor, err := getMonitor(nodeType)
+		require.NoError(t, err)
+		assert.Equal(t, cluster.Name, monitor.GetOwnerReferences()[0].Name)
+		assert.Equal(t, "prometheus", monitor.GetLabels()["release"])
+	}
+
+	// The PodMonitors are updated when the scrape interval changes.
+	cluster.Spec.PrometheusMonitoring.Interval = ptr.To("30s")
+	err = r.reconcilePodMonitors(ctx, cluster)
+	require.NoError(t, err)
+	monitor, err := getMonitor(rayv1.WorkerNode)
+	require.NoError(t, err)
+	endpoints, _, _ := unstructured.NestedSlice(monitor.Object, "spec", "podMetricsEndpoints")
+	interval, _, _ := unstructured.NestedString(endpoints[0].(map[string]interface{}), "interval")
+	assert.Equal(t, "30s", interval)
+
+	// The Prometheus Operator CRDs are only needed with `prometheusMonitoring`.
+	r.Client = clientFake.NewClientBuilder().WithScheme(newScheme).Build()
+	err = r.reconcilePodMonitors(ctx, cluster)
+	require.NoError(t, err)
+}
+
 func TestReconcileHeadlessService(t *testing.T) {
 	setupTest(t)
 
@@ -1272,6 +1568,57 @@ func TestReconcile_AutoscalerRoleBinding(t *testing.T) {
 	assert.Nil(t, err, "Fail to get autoscaler RoleBinding after reconciliation")
 }
 
+func TestReconcile_DedicatedServiceAccount(t *testing.T) {
+	setupTest(t)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.EnableInTreeAutoscaling = ptr.To(false)
+	cluster.Spec.DedicatedServiceAccount = ptr.To(true)
+	fakeClient := clientFake.NewClientBuilder().WithRuntimeObjects(testPods...).Build()
+	ctx := context.Background()
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:                     fakeClient,
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     scheme.Scheme,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+	}
+	reconcile := func() {
+		require.NoError(t, testRayClusterReconciler.reconcileDedicatedServiceAccount(ctx, cluster))
+		require.NoError(t, testRayClusterReconciler.reconcileAutoscalerServiceAccount(ctx, cluster))
+		require.NoError(t, testRayClusterReconciler.reconcileAutoscalerRole(ctx, cluster))
+		require.NoError(t, testRayClusterReconciler.reconcileAutoscalerRoleBinding(ctx, cluster))
+	}
+	namespacedName := types.NamespacedName{Name: instanceName, Namespace: namespaceStr}
+
+	// Without the autoscaler, the dedicated ServiceAccount is bound to a Role without permissions.
+	reconcile()
+	sa := corev1.ServiceAccount{}
+	require.NoError(t, fakeClient.Get(ctx, namespacedName, &sa))
+	assert.True(t, metav1.IsControlledBy(&sa, cluster))
+	role := rbacv1.Role{}
+	require.NoError(t, fakeClient.Get(ctx, namespacedName, &role))
+	assert.Empty(t, role.Rules)
+	rb := rbacv1.RoleBinding{}
+	require.NoError(t, fakeClient.Get(ctx, namespacedName, &rb))
+	require.Len(t, rb.Subjects, 1)
+	assert.Equal(t, instanceName, rb.Subjects[0].Name)
+
+	// Enabling the autoscaler grants its permissions.
+	cluster.Spec.EnableInTreeAutoscaling = ptr.To(true)
+	reconcile()
+	require.NoError(t, fakeClient.Get(ctx, namespacedName, &role))
+	assert.Len(t, role.Rules, 2)
+
+	// The autoscaler runs with the ServiceAccount of the head Pod, which is bound to the Role too.
+	require.NoError(t, fakeClient.Create(ctx, &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "my-sa", Namespace: namespaceStr}}))
+	cluster.Spec.HeadGroupSpec.Template.Spec.ServiceAccountName = "my-sa"
+	reconcile()
+	require.NoError(t, fakeClient.Get(ctx, namespacedName, &rb))
+	require.Len(t, rb.Subjects, 2)
+	assert.Equal(t, "my-sa", rb.Subjects[0].Name)
+	assert.Equal(t, instanceName, rb.Subjects[1].Name)
+}
+
 func TestReconcile_UpdateClusterReason(t *testing.T) {
 	setupTest(t)
 
@@ -1694,6 +2041,22 @@ func TestInconsistentRayClusterStatus(t *testing.T) {
 	newStatus = oldStatus.DeepCopy()
 	meta.SetStatusCondition(&newStatus.Conditions, metav1.Condition{Type: string(rayv1.RayClusterReplicaFailure), Status: metav1.ConditionTrue})
 	assert.True(t, r.inconsistentRayClusterStatus(ctx, oldStatus, *newStatus))
+
+	// Case 13: `PendingResourceDemands` is different => return true
+	oldStatus.PendingResourceDemands = []rayv1.ResourceDemand{{Resources: map[string]resource.Quantity{"CPU": resource.MustParse("1")}, Count: 2}}
+	newStatus = oldStatus.DeepCopy()
+	newStatus.PendingResourceDemands[0].Count = 3
+	assert.True(t, r.inconsistentRayClusterStatus(ctx, oldStatus, *newStatus))
+
+	// Case 14: `PendingResourceDemands` has the same quantities with different representations => return false
+	newStatus = oldStatus.DeepCopy()
+	newStatus.PendingResourceDemands[0].Resources["CPU"] = *resource.NewMilliQuantity(1000, resource.DecimalSI)
+	assert.False(t, r.inconsistentRayClusterStatus(ctx, oldStatus, *newStatus))
+
+	// Case 15: `Journal` is different => return true
+	newStatus = oldStatus.DeepCopy()
+	newStatus.Journal = []rayv1.JournalEntry{{Time: timeNow, Type: corev1.EventTypeNormal, Action: string(utils.CreatedWorkerPod)}}
+	assert.True(t, r.inconsistentRayClusterStatus(ctx, oldStatus, *newStatus))
 }
 
 func TestCalculateStatus(t *testing.T) {
@@ -2396,52 +2759,92 @@ func Test_TerminatedHead_RestartPolicy(t *testing.T) {
 	assert.Equal(t, 1, len(podList.Items))
 }
 
-func Test_RunningPods_RayContainerTerminated(t *testing.T) {
+func Test_HeadColdStandby(t *testing.T) {
 	setupTest(t)
+	defer features.SetFeatureGateDuringTest(t, features.RayHeadColdStandby, true)()
 
-	// Create a new scheme with CRDs, Pod, Service schemes.
 	newScheme := runtime.NewScheme()
 	_ = rayv1.AddToScheme(newScheme)
 	_ = corev1.AddToScheme(newScheme)
 
-	// Only one head Pod and no worker Pods in the RayCluster.
-	runtimeObjects := testPods[0:1]
 	cluster := testRayCluster.DeepCopy()
 	cluster.Spec.WorkerGroupSpecs = nil
-	fakeClient := clientFake.NewClientBuilder().
-		WithScheme(newScheme).
-		WithRuntimeObjects(runtimeObjects...).
-		WithStatusSubresource(cluster).
-		Build()
+	cluster.Spec.HeadGroupSpec.EnableColdStandby = ptr.To(true)
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).Build()
 	ctx := context.Background()
 
-	// Get the pod list from the fake client.
-	podList := corev1.PodList{}
-	err := fakeClient.List(ctx, &podList, client.InNamespace(namespaceStr))
-	assert.Nil(t, err, "Fail to get pod list")
-	assert.Equal(t, 1, len(podList.Items))
-	assert.Equal(t, "headNode", podList.Items[0].Name)
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:                     fakeClient,
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     newScheme,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+	}
 
-	// Make sure the head Pod's restart policy is `Never`, the Pod status is `Running`,
-	// and the Ray container has terminated. The next `reconcilePods` call will delete
-	// the head Pod and will not create a new one in the same reconciliation loop.
-	podList.Items[0].Spec.RestartPolicy = corev1.RestartPolicyNever
-	err = fakeClient.Update(ctx, &podList.Items[0])
-	assert.Nil(t, err)
+	listPods := func(nodeType string) []corev1.Pod {
+		podList := corev1.PodList{}
+		err := fakeClient.List(ctx, &podList, client.InNamespace(namespaceStr), client.MatchingLabels{utils.RayNodeTypeLabelKey: nodeType})
+		require.NoError(t, err)
+		return podList.Items
+	}
 
-	podList.Items[0].Status.Phase = corev1.PodRunning
-	podList.Items[0].Status.ContainerStatuses = []corev1.ContainerStatus{
-		{
-			Name: podList.Items[0].Spec.Containers[utils.RayContainerIndex].Name,
-			State: corev1.ContainerState{
-				Terminated: &corev1.ContainerStateTerminated{},
-			},
-		},
+	// Both the head Pod and the standby head Pod are created.
+	err := testRayClusterReconciler.reconcilePods(ctx, cluster)
+	require.NoError(t, err)
+	headPods := listPods(string(rayv1.HeadNode))
+	standbyPods := listPods(utils.RayNodeHeadStandbyLabelValue)
+	require.Len(t, headPods, 1)
+	require.Len(t, standbyPods, 1)
+	standbyPod := standbyPods[0]
+	assert.Equal(t, common.HeadStandbyVolumeName, standbyPod.Spec.Volumes[len(standbyPod.Spec.Volumes)-1].Name)
+	assert.Contains(t, standbyPod.Spec.Containers[utils.RayContainerIndex].Args[0], common.HeadStandbyVolumeMountPath)
+
+	// The head Pod fails and is deleted.
+	headPod := headPods[0]
+	headPod.Status.Phase = corev1.PodFailed
+	require.NoError(t, fakeClient.Status().Update(ctx, &headPod))
+	err = testRayClusterReconciler.reconcilePods(ctx, cluster)
+	require.Error(t, err)
+	assert.Empty(t, listPods(string(rayv1.HeadNode)))
+
+	// The running standby head Pod is promoted instead of creating a new head Pod, and a new standby head Pod is created.
+	standbyPod.Status.Phase = corev1.PodRunning
+	require.NoError(t, fakeClient.Status().Update(ctx, &standbyPod))
+	err = testRayClusterReconciler.reconcilePods(ctx, cluster)
+	require.NoError(t, err)
+	headPods = listPods(string(rayv1.HeadNode))
+	require.Len(t, headPods, 1)
+	assert.Equal(t, standbyPod.Name, headPods[0].Name)
+	assert.Equal(t, "true", headPods[0].Annotations[utils.RayHeadStandbyPromotedAnnotationKey])
+	standbyPods = listPods(utils.RayNodeHeadStandbyLabelValue)
+	require.Len(t, standbyPods, 1)
+	assert.NotEqual(t, standbyPod.Name, standbyPods[0].Name)
+
+	// The standby head Pod is deleted when cold standby is disabled.
+	cluster.Spec.HeadGroupSpec.EnableColdStandby = nil
+	err = testRayClusterReconciler.reconcilePods(ctx, cluster)
+	require.NoError(t, err)
+	assert.Empty(t, listPods(utils.RayNodeHeadStandbyLabelValue))
+	assert.Len(t, listPods(string(rayv1.HeadNode)), 1)
+}
+
+func Test_HeadStatefulSet(t *testing.T) {
+	setupTest(t)
+	defer features.SetFeatureGateDuringTest(t, features.RayHeadStatefulSet, true)()
+
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	_ = appsv1.AddToScheme(newScheme)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.WorkerGroupSpecs = nil
+	cluster.Spec.HeadGroupSpec.StatefulSet = &rayv1.HeadStatefulSetOptions{
+		VolumeClaimTemplates: []corev1.PersistentVolumeClaimTemplate{{ObjectMeta: metav1.ObjectMeta{Name: "ray-session"}}},
 	}
-	err = fakeClient.Status().Update(ctx, &podList.Items[0])
-	assert.Nil(t, err)
+	require.NoError(t, validateRayClusterSpec(cluster))
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(cluster).Build()
+	ctx := context.Background()
 
-	// Initialize a new RayClusterReconciler.
 	testRayClusterReconciler := &RayClusterReconciler{
 		Client:                     fakeClient,
 		Recorder:                   &record.FakeRecorder{},
@@ -2449,36 +2852,1147 @@ func Test_RunningPods_RayContainerTerminated(t *testing.T) {
 		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
 	}
 
-	// The head Pod will be deleted and the controller will return an error
-	// instead of creating a new head Pod in the same reconcile loop.
-	err = testRayClusterReconciler.reconcilePods(ctx, cluster)
-	assert.NotNil(t, err)
-	err = fakeClient.List(ctx, &podList, client.InNamespace(namespaceStr))
-	assert.Nil(t, err, "Fail to get pod list")
-	assert.Equal(t, 0, len(podList.Items))
-
-	// The new head Pod will be created in this reconcile loop.
+	// The StatefulSet of the head Pod is created instead of the head Pod.
+	err := testRayClusterReconciler.reconcilePods(ctx, cluster)
+	require.NoError(t, err)
+	podList := corev1.PodList{}
+	require.NoError(t, fakeClient.List(ctx, &podList, client.InNamespace(namespaceStr)))
+	assert.Empty(t, podList.Items)
+
+	statefulSet := appsv1.StatefulSet{}
+	err = fakeClient.Get(ctx, client.ObjectKey{Namespace: namespaceStr, Name: utils.GenerateHeadStatefulSetName(cluster.Name)}, &statefulSet)
+	require.NoError(t, err)
+	assert.Equal(t, ptr.To[int32](1), statefulSet.Spec.Replicas)
+	assert.Equal(t, corev1.RestartPolicyAlways, statefulSet.Spec.Template.Spec.RestartPolicy)
+	assert.Equal(t, string(rayv1.HeadNode), statefulSet.Spec.Template.Labels[utils.RayNodeTypeLabelKey])
+	assert.Equal(t, "ray-session", statefulSet.Spec.VolumeClaimTemplates[0].Name)
+	assert.True(t, metav1.IsControlledBy(&statefulSet, cluster))
+
+	// The StatefulSet is governed by a headless Service, even if the head service of the RayCluster isn't headless.
+	governingService := corev1.Service{}
+	err = fakeClient.Get(ctx, client.ObjectKey{Namespace: namespaceStr, Name: statefulSet.Spec.ServiceName}, &governingService)
+	require.NoError(t, err)
+	assert.Equal(t, corev1.ClusterIPNone, governingService.Spec.ClusterIP)
+	assert.Equal(t, common.HeadStatefulSetSelector(*cluster), governingService.Spec.Selector)
+	assert.True(t, metav1.IsControlledBy(&governingService, cluster))
+
+	// The head Pod created by the StatefulSet is mapped to the RayCluster with its labels.
+	headPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:            statefulSet.Name + "-0",
+		Namespace:       namespaceStr,
+		Labels:          statefulSet.Spec.Template.Labels,
+		OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(&statefulSet, appsv1.SchemeGroupVersion.WithKind("StatefulSet"))},
+	}}
+	assert.Equal(t, []ctrl.Request{{NamespacedName: client.ObjectKeyFromObject(cluster)}}, mapHeadStatefulSetPodToRayCluster(ctx, headPod))
+	headPod.OwnerReferences = nil
+	assert.Empty(t, mapHeadStatefulSetPodToRayCluster(ctx, headPod))
+
+	// The StatefulSet is deleted when the RayCluster is suspended so that it doesn't recreate the head Pod.
+	cluster.Spec.Suspend = ptr.To(true)
+	meta.SetStatusCondition(&cluster.Status.Conditions, metav1.Condition{
+		Type:   string(rayv1.RayClusterSuspending),
+		Status: metav1.ConditionTrue,
+		Reason: string(rayv1.RayClusterSuspending),
+	})
 	err = testRayClusterReconciler.reconcilePods(ctx, cluster)
-	assert.Nil(t, err)
-	err = fakeClient.List(ctx, &podList, client.InNamespace(namespaceStr))
-	assert.Nil(t, err, "Fail to get pod list")
-	assert.Equal(t, 1, len(podList.Items))
+	require.NoError(t, err)
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(&statefulSet), &statefulSet)
+	assert.True(t, k8serrors.IsNotFound(err))
+
+	// The StatefulSet requires the feature gate and can't be used with cold standby.
+	cluster.Spec.HeadGroupSpec.EnableColdStandby = ptr.To(true)
+	require.ErrorContains(t, validateRayClusterSpec(cluster), "RayHeadColdStandby feature gate")
+	defer features.SetFeatureGateDuringTest(t, features.RayHeadColdStandby, true)()
+	require.ErrorContains(t, validateRayClusterSpec(cluster), "enableColdStandby")
+	defer features.SetFeatureGateDuringTest(t, features.RayHeadStatefulSet, false)()
+	require.ErrorContains(t, validateRayClusterSpec(cluster), "RayHeadStatefulSet feature gate")
 }
 
-func Test_ShouldDeletePod(t *testing.T) {
-	pod := corev1.Pod{
-		Spec: corev1.PodSpec{
-			Containers: []corev1.Container{
-				{
-					Name: "ray-head",
-				},
+func Test_ReconcilePendingResourceDemands(t *testing.T) {
+	setupTest(t)
+	defer features.SetFeatureGateDuringTest(t, features.RayClusterPendingResourceDemands, true)()
+
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.AutoscalerOptions = &rayv1.AutoscalerOptions{
+		BalloonPods: &rayv1.BalloonPodsOptions{PriorityClassName: "balloon"},
+	}
+	cluster.Spec.WorkerGroupSpecs[0].Template.Spec.NodeSelector = map[string]string{"pool": "cpu"}
+	headPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "headNode",
+			Namespace: namespaceStr,
+			Labels: map[string]string{
+				utils.RayClusterLabelKey:  instanceName,
+				utils.RayNodeTypeLabelKey: string(rayv1.HeadNode),
 			},
 		},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
 	}
-	tests := []struct {
-		name            string
-		restartPolicy   corev1.RestartPolicy
-		phase           corev1.PodPhase
+	runtimeObjects := append([]runtime.Object{headPod}, testServices...)
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
+	ctx := context.Background()
+
+	fakeDashboardClient := &utils.FakeRayDashboardClient{}
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:                     fakeClient,
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     newScheme,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		dashboardClientFunc: func() utils.RayDashboardClientInterface {
+			return fakeDashboardClient
+		},
+	}
+
+	listBalloonPods := func() []corev1.Pod {
+		podList := corev1.PodList{}
+		err := fakeClient.List(ctx, &podList, common.RayClusterBalloonPodsAssociationOptions(cluster).ToListOptions()...)
+		require.NoError(t, err)
+		return podList.Items
+	}
+
+	// Each worker Pod has 1 CPU, so 3 balloon Pods are needed for 3 pending tasks.
+	fakeDashboardClient.SetClusterStatus(&utils.RayClusterStatusInfo{
+		LoadMetricsReport: utils.RayLoadMetricsReport{
+			ResourceDemand: []utils.RayResourceDemand{{Resources: map[string]float64{"CPU": 1}, Count: 3}},
+		},
+	})
+	err := testRayClusterReconciler.reconcilePendingResourceDemands(ctx, cluster)
+	require.NoError(t, err)
+	require.Len(t, cluster.Status.PendingResourceDemands, 1)
+	assert.Equal(t, int32(3), cluster.Status.PendingResourceDemands[0].Count)
+	cpu := cluster.Status.PendingResourceDemands[0].Resources["CPU"]
+	assert.Equal(t, "1", cpu.String())
+	balloonPods := listBalloonPods()
+	require.Len(t, balloonPods, 3)
+	assert.Equal(t, "balloon", balloonPods[0].Spec.PriorityClassName)
+	assert.Equal(t, map[string]string{"pool": "cpu"}, balloonPods[0].Spec.NodeSelector)
+	assert.Equal(t, groupNameStr, balloonPods[0].Labels[utils.RayNodeGroupLabelKey])
+
+	// The balloon Pods are deleted once the demands are satisfied.
+	fakeDashboardClient.SetClusterStatus(&utils.RayClusterStatusInfo{})
+	err = testRayClusterReconciler.reconcilePendingResourceDemands(ctx, cluster)
+	require.NoError(t, err)
+	assert.Empty(t, cluster.Status.PendingResourceDemands)
+	assert.Empty(t, listBalloonPods())
+}
+
+func Test_ReconcileAutoscalerEvents(t *testing.T) {
+	setupTest(t)
+	defer features.SetFeatureGateDuringTest(t, features.RayClusterAutoscalerEvents, true)()
+
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.EnableInTreeAutoscaling = ptr.To(true)
+	headPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "headNode",
+			Namespace: namespaceStr,
+			Labels: map[string]string{
+				utils.RayClusterLabelKey:  instanceName,
+				utils.RayNodeTypeLabelKey: string(rayv1.HeadNode),
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	runtimeObjects := append([]runtime.Object{headPod}, testServices...)
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
+	ctx := context.Background()
+
+	recorder := record.NewFakeRecorder(10)
+	fakeDashboardClient := &utils.FakeRayDashboardClient{}
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:                     fakeClient,
+		Recorder:                   recorder,
+		Scheme:                     newScheme,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		dashboardClientFunc: func() utils.RayDashboardClientInterface {
+			return fakeDashboardClient
+		},
+	}
+
+	// The events that exist when the RayCluster is first seen are not re-emitted.
+	events := []utils.RayClusterEventInfo{{EventID: "e1", Severity: "INFO", Message: "Adding 1 node(s) of type small-group."}}
+	fakeDashboardClient.SetAutoscalerEvents(events)
+	err := testRayClusterReconciler.reconcileAutoscalerEvents(ctx, cluster)
+	require.NoError(t, err)
+	assert.Empty(t, recorder.Events)
+
+	events = append(events,
+		utils.RayClusterEventInfo{EventID: "e2", Severity: "INFO", Message: "Resized to 4 CPUs."},
+		utils.RayClusterEventInfo{EventID: "e3", Severity: "INFO", Message: "Adding 2 node(s) of type small-group."},
+		utils.RayClusterEventInfo{EventID: "e4", Severity: "ERROR", Message: "Failed to launch 2 node(s) of type gpu-group. (quota exceeded)"},
+	)
+	fakeDashboardClient.SetAutoscalerEvents(events)
+	err = testRayClusterReconciler.reconcileAutoscalerEvents(ctx, cluster)
+	require.NoError(t, err)
+	require.Len(t, recorder.Events, 2)
+	assert.Equal(t, "Normal AutoscalerScaled Ray autoscaler: Adding 2 node(s) of type small-group.", <-recorder.Events)
+	assert.Equal(t, "Warning AutoscalerFailedToScale Ray autoscaler: Failed to launch 2 node(s) of type gpu-group. (quota exceeded)", <-recorder.Events)
+
+	// The events are only emitted once.
+	err = testRayClusterReconciler.reconcileAutoscalerEvents(ctx, cluster)
+	require.NoError(t, err)
+	assert.Empty(t, recorder.Events)
+}
+
+func Test_ReconcileKarpenterConsolidation(t *testing.T) {
+	setupTest(t)
+	defer features.SetFeatureGateDuringTest(t, features.RayKarpenterConsolidation, true)()
+
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	cluster := testRayCluster.DeepCopy()
+	newPod := func(name, nodeType, group, ip string, annotations map[string]string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   namespaceStr,
+				Annotations: annotations,
+				Labels: map[string]string{
+					utils.RayClusterLabelKey:   instanceName,
+					utils.RayNodeTypeLabelKey:  nodeType,
+					utils.RayNodeGroupLabelKey: group,
+				},
+			},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				PodIP:      ip,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			},
+		}
+	}
+	doNotDisrupt := map[string]string{utils.KarpenterDoNotDisruptAnnotationKey: "true"}
+	runtimeObjects := append([]runtime.Object{
+		newPod("head", string(rayv1.HeadNode), headGroupNameStr, "10.0.0.1", nil),
+		newPod("actor-worker", string(rayv1.WorkerNode), groupNameStr, "10.0.0.2", nil),
+		newPod("task-worker", string(rayv1.WorkerNode), groupNameStr, "10.0.0.3", nil),
+		newPod("idle-worker", string(rayv1.WorkerNode), groupNameStr, "10.0.0.4", doNotDisrupt),
+	}, testServices...)
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
+	ctx := context.Background()
+
+	fakeDashboardClient := &utils.FakeRayDashboardClient{}
+	fakeDashboardClient.SetAliveNodes([]utils.RayNodeInfo{
+		{NodeID: "n1", NodeIP: "10.0.0.1"},
+		{NodeID: "n2", NodeIP: "10.0.0.2"},
+		{NodeID: "n3", NodeIP: "10.0.0.3"},
+		{NodeID: "n4", NodeIP: "10.0.0.4"},
+	})
+	fakeDashboardClient.SetAliveActors([]utils.RayActorInfo{{ActorID: "a1", NodeID: "n2"}})
+	fakeDashboardClient.SetRunningTasks([]utils.RayTaskInfo{{TaskID: "t1", NodeID: "n3"}})
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:   fakeClient,
+		Recorder: &record.FakeRecorder{},
+		Scheme:   newScheme,
+		dashboardClientFunc: func() utils.RayDashboardClientInterface {
+			return fakeDashboardClient
+		},
+	}
+
+	isAnnotated := func(name string) bool {
+		pod := &corev1.Pod{}
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: namespaceStr, Name: name}, pod))
+		return pod.Annotations[utils.KarpenterDoNotDisruptAnnotationKey] == "true"
+	}
+
+	assert.True(t, hasKarpenterConsolidationWorkers(ctx, cluster))
+	err := testRayClusterReconciler.reconcileKarpenterConsolidation(ctx, cluster)
+	require.NoError(t, err)
+	assert.True(t, isAnnotated("head"))
+	assert.True(t, isAnnotated("actor-worker"))
+	assert.True(t, isAnnotated("task-worker"))
+	assert.False(t, isAnnotated("idle-worker"))
+
+	// The task finished, so the worker can be consolidated.
+	fakeDashboardClient.SetRunningTasks(nil)
+	err = testRayClusterReconciler.reconcileKarpenterConsolidation(ctx, cluster)
+	require.NoError(t, err)
+	assert.True(t, isAnnotated("actor-worker"))
+	assert.False(t, isAnnotated("task-worker"))
+
+	// The annotations of the groups whose template sets it are left to the user.
+	cluster.Spec.WorkerGroupSpecs[0].Template.Annotations = doNotDisrupt
+	fakeDashboardClient.SetAliveActors(nil)
+	err = testRayClusterReconciler.reconcileKarpenterConsolidation(ctx, cluster)
+	require.NoError(t, err)
+	assert.True(t, isAnnotated("actor-worker"))
+	// The occupancy isn't polled without a worker group whose annotations are managed.
+	assert.False(t, hasKarpenterConsolidationWorkers(ctx, cluster))
+}
+
+func Test_ReconcileIdleTimeout(t *testing.T) {
+	setupTest(t)
+
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.IdleTimeoutSeconds = ptr.To[int32](600)
+	headPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "headNode",
+			Namespace: namespaceStr,
+			Labels: map[string]string{
+				utils.RayClusterLabelKey:  instanceName,
+				utils.RayNodeTypeLabelKey: string(rayv1.HeadNode),
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	runtimeObjects := append([]runtime.Object{headPod, cluster}, testServices...)
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
+	ctx := context.Background()
+	err := fakeClient.Get(ctx, types.NamespacedName{Namespace: namespaceStr, Name: instanceName}, cluster)
+	require.NoError(t, err)
+
+	fakeDashboardClient := &utils.FakeRayDashboardClient{}
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:   fakeClient,
+		Recorder: &record.FakeRecorder{},
+		Scheme:   newScheme,
+		dashboardClientFunc: func() utils.RayDashboardClientInterface {
+			return fakeDashboardClient
+		},
+	}
+
+	// The idle time starts when the RayCluster is first reconciled.
+	deleted, err := testRayClusterReconciler.reconcileIdleTimeout(ctx, cluster)
+	require.NoError(t, err)
+	assert.False(t, deleted)
+	require.NotNil(t, cluster.Status.LastActivityTime)
+
+	// The last activity time is refreshed while there are alive actors.
+	cluster.Status.LastActivityTime = &metav1.Time{Time: time.Now().Add(-5 * time.Minute)}
+	fakeDashboardClient.SetAliveActors([]utils.RayActorInfo{{ActorID: "actor", State: "ALIVE"}})
+	_, err = testRayClusterReconciler.reconcileIdleTimeout(ctx, cluster)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), cluster.Status.LastActivityTime.Time, time.Minute)
+
+	// The RayCluster isn't suspended before IdleTimeoutSeconds.
+	fakeDashboardClient.SetAliveActors(nil)
+	cluster.Status.LastActivityTime = &metav1.Time{Time: time.Now().Add(-5 * time.Minute)}
+	_, err = testRayClusterReconciler.reconcileIdleTimeout(ctx, cluster)
+	require.NoError(t, err)
+	assert.Nil(t, cluster.Spec.Suspend)
+
+	// The RayCluster is suspended once it has been idle for IdleTimeoutSeconds.
+	cluster.Status.LastActivityTime = &metav1.Time{Time: time.Now().Add(-11 * time.Minute)}
+	deleted, err = testRayClusterReconciler.reconcileIdleTimeout(ctx, cluster)
+	require.NoError(t, err)
+	assert.False(t, deleted)
+	assert.True(t, *cluster.Spec.Suspend)
+	assert.Nil(t, cluster.Status.LastActivityTime)
+	storedCluster := &rayv1.RayCluster{}
+	err = fakeClient.Get(ctx, types.NamespacedName{Namespace: namespaceStr, Name: instanceName}, storedCluster)
+	require.NoError(t, err)
+	assert.True(t, *storedCluster.Spec.Suspend)
+
+	// The RayCluster is deleted once it has been idle for IdleTimeoutSeconds if IdleAction is Delete.
+	cluster.Spec.Suspend = nil
+	cluster.Spec.IdleAction = ptr.To(rayv1.DeleteIdleAction)
+	cluster.Status.LastActivityTime = &metav1.Time{Time: time.Now().Add(-11 * time.Minute)}
+	deleted, err = testRayClusterReconciler.reconcileIdleTimeout(ctx, cluster)
+	require.NoError(t, err)
+	assert.True(t, deleted)
+	err = fakeClient.Get(ctx, types.NamespacedName{Namespace: namespaceStr, Name: instanceName}, storedCluster)
+	assert.True(t, k8serrors.IsNotFound(err))
+}
+
+func Test_ReconcileRayQuota(t *testing.T) {
+	setupTest(t)
+	defer features.SetFeatureGateDuringTest(t, features.RayQuota, true)()
+
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.UID = "cluster"
+	cluster.Spec.HeadGroupSpec.Template.Spec.Containers[0].Resources.Requests = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}
+	requestedCPU := utils.CalculateMaxResources(cluster)[corev1.ResourceCPU]
+	// Another RayCluster with the same resources was admitted, and the quota only has room for one of them.
+	otherCluster := cluster.DeepCopy()
+	otherCluster.Name = "other"
+	otherCluster.UID = "other"
+	otherCluster.Status.Conditions = []metav1.Condition{{
+		Type:   string(rayv1.RayClusterQuotaExceeded),
+		Status: metav1.ConditionFalse,
+		Reason: rayv1.RayQuotaAdmitted,
+	}}
+	quota := &rayv1.RayQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "quota", Namespace: namespaceStr},
+		Spec:       rayv1.RayQuotaSpec{Hard: corev1.ResourceList{corev1.ResourceCPU: requestedCPU}},
+	}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(otherCluster, quota).Build()
+	ctx := context.Background()
+
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:                     fakeClient,
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     newScheme,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+	}
+
+	// The RayCluster is queued.
+	err := testRayClusterReconciler.reconcileRayQuota(ctx, cluster)
+	require.NoError(t, err)
+	condition := meta.FindStatusCondition(cluster.Status.Conditions, string(rayv1.RayClusterQuotaExceeded))
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, rayv1.RayQuotaQueued, condition.Reason)
+
+	// No Pods are created while the RayCluster is queued.
+	err = testRayClusterReconciler.reconcilePods(ctx, cluster)
+	require.NoError(t, err)
+	podList := corev1.PodList{}
+	err = fakeClient.List(ctx, &podList, client.InNamespace(namespaceStr))
+	require.NoError(t, err)
+	assert.Empty(t, podList.Items)
+
+	// The RayCluster is admitted once the other RayCluster is deleted.
+	err = fakeClient.Delete(ctx, otherCluster)
+	require.NoError(t, err)
+	err = testRayClusterReconciler.reconcileRayQuota(ctx, cluster)
+	require.NoError(t, err)
+	assert.True(t, meta.IsStatusConditionFalse(cluster.Status.Conditions, string(rayv1.RayClusterQuotaExceeded)))
+
+	// With the Reject policy, the RayCluster is only evaluated again when its spec is updated.
+	quota.Spec.Policy = ptr.To(rayv1.RejectRayQuotaPolicy)
+	quota.Spec.Hard[corev1.ResourceCPU] = resource.MustParse("0")
+	err = fakeClient.Update(ctx, quota)
+	require.NoError(t, err)
+	cluster.Status.Conditions = nil
+	err = testRayClusterReconciler.reconcileRayQuota(ctx, cluster)
+	require.NoError(t, err)
+	condition = meta.FindStatusCondition(cluster.Status.Conditions, string(rayv1.RayClusterQuotaExceeded))
+	require.NotNil(t, condition)
+	assert.Equal(t, rayv1.RayQuotaRejected, condition.Reason)
+
+	quota.Spec.Hard[corev1.ResourceCPU] = requestedCPU
+	err = fakeClient.Update(ctx, quota)
+	require.NoError(t, err)
+	err = testRayClusterReconciler.reconcileRayQuota(ctx, cluster)
+	require.NoError(t, err)
+	assert.True(t, meta.IsStatusConditionTrue(cluster.Status.Conditions, string(rayv1.RayClusterQuotaExceeded)))
+
+	cluster.Generation++
+	err = testRayClusterReconciler.reconcileRayQuota(ctx, cluster)
+	require.NoError(t, err)
+	assert.True(t, meta.IsStatusConditionFalse(cluster.Status.Conditions, string(rayv1.RayClusterQuotaExceeded)))
+}
+
+func Test_ReconcileImagePrePull(t *testing.T) {
+	setupTest(t)
+
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	_ = appsv1.AddToScheme(newScheme)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.ImagePrePull = &rayv1.ImagePrePullOptions{}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).Build()
+	ctx := context.Background()
+
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:                     fakeClient,
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     newScheme,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+	}
+	listDaemonSets := func() []appsv1.DaemonSet {
+		daemonSets := appsv1.DaemonSetList{}
+		err := fakeClient.List(ctx, &daemonSets, client.InNamespace(namespaceStr), client.MatchingLabels{utils.RayImagePrePullClusterLabelKey: cluster.Name})
+		require.NoError(t, err)
+		return daemonSets.Items
+	}
+
+	// A DaemonSet is created for the head group and the worker group, and no Pods are created while the images are pulled.
+	err := testRayClusterReconciler.reconcileImagePrePull(ctx, cluster)
+	require.NoError(t, err)
+	assert.Len(t, listDaemonSets(), 2)
+	assert.True(t, isImagePrePullPending(cluster))
+	err = testRayClusterReconciler.reconcilePods(ctx, cluster)
+	require.NoError(t, err)
+	podList := corev1.PodList{}
+	err = fakeClient.List(ctx, &podList, client.InNamespace(namespaceStr))
+	require.NoError(t, err)
+	assert.Empty(t, podList.Items)
+
+	// The DaemonSets are deleted once the images are pulled on every node.
+	for _, daemonSet := range listDaemonSets() {
+		daemonSet.Generation = 1
+		err = fakeClient.Update(ctx, &daemonSet)
+		require.NoError(t, err)
+		daemonSet.Status = appsv1.DaemonSetStatus{ObservedGeneration: 1, DesiredNumberScheduled: 3, NumberReady: 3}
+		err = fakeClient.Status().Update(ctx, &daemonSet)
+		require.NoError(t, err)
+	}
+	err = testRayClusterReconciler.reconcileImagePrePull(ctx, cluster)
+	require.NoError(t, err)
+	assert.Empty(t, listDaemonSets())
+	assert.False(t, isImagePrePullPending(cluster))
+	condition := meta.FindStatusCondition(cluster.Status.Conditions, string(rayv1.RayClusterImagesPrePulled))
+	require.NotNil(t, condition)
+	assert.Equal(t, rayv1.ImagePrePullCompleted, condition.Reason)
+}
+
+func TestReconcile_WorkerRankEnv(t *testing.T) {
+	setupTest(t)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.EnableInTreeAutoscaling = nil
+	cluster.Spec.WorkerGroupSpecs[0].EnableRankEnv = ptr.To(true)
+	cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = nil
+	fakeClient := clientFake.NewClientBuilder().Build()
+	ctx := context.Background()
+
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:                     fakeClient,
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     scheme.Scheme,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+	}
+	listWorkerRanks := func() map[string]string {
+		podList := corev1.PodList{}
+		err := fakeClient.List(ctx, &podList, common.RayClusterGroupPodsAssociationOptions(cluster, cluster.Spec.WorkerGroupSpecs[0].GroupName).ToListOptions()...)
+		require.NoError(t, err)
+		ranks := map[string]string{}
+		for _, pod := range podList.Items {
+			ranks[pod.Annotations[utils.RayWorkerRankAnnotationKey]] = pod.Name
+			container := pod.Spec.Containers[utils.RayContainerIndex]
+			assert.True(t, utils.EnvVarExists(utils.RANK, container.Env))
+			assert.Contains(t, container.Env, corev1.EnvVar{Name: utils.WORLD_SIZE, Value: strconv.Itoa(int(expectReplicaNum))})
+		}
+		return ranks
+	}
+
+	err := testRayClusterReconciler.reconcilePods(ctx, cluster)
+	require.NoError(t, err)
+	ranks := listWorkerRanks()
+	assert.Len(t, ranks, int(expectReplicaNum))
+	for i := 0; i < int(expectReplicaNum); i++ {
+		assert.Contains(t, ranks, strconv.Itoa(i))
+	}
+
+	// The Pod that replaces a deleted Pod reuses its rank.
+	err = fakeClient.Delete(ctx, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: ranks["1"], Namespace: cluster.Namespace}})
+	require.NoError(t, err)
+	// Reset the expectations, since the deleted Pod was expected to be created.
+	testRayClusterReconciler.rayClusterScaleExpectation = expectations.NewRayClusterScaleExpectation(fakeClient)
+	err = testRayClusterReconciler.reconcilePods(ctx, cluster)
+	require.NoError(t, err)
+	newRanks := listWorkerRanks()
+	assert.Len(t, newRanks, int(expectReplicaNum))
+	assert.NotEqual(t, ranks["1"], newRanks["1"])
+	assert.Equal(t, ranks["0"], newRanks["0"])
+}
+
+func TestReconcile_SuspendWorkerGroup(t *testing.T) {
+	setupTest(t)
+	defer features.SetFeatureGateDuringTest(t, features.RayWorkerGroupSuspend, true)()
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.EnableInTreeAutoscaling = nil
+	cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = nil
+	gpuGroup := cluster.Spec.WorkerGroupSpecs[0].DeepCopy()
+	gpuGroup.GroupName = "gpu-group"
+	cluster.Spec.WorkerGroupSpecs = append(cluster.Spec.WorkerGroupSpecs, *gpuGroup)
+	fakeClient := clientFake.NewClientBuilder().Build()
+	ctx := context.Background()
+
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:                     fakeClient,
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     scheme.Scheme,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+	}
+	countGroupPods := func(groupName string) int {
+		podList := corev1.PodList{}
+		err := fakeClient.List(ctx, &podList, common.RayClusterGroupPodsAssociationOptions(cluster, groupName).ToListOptions()...)
+		require.NoError(t, err)
+		return len(podList.Items)
+	}
+
+	err := testRayClusterReconciler.reconcilePods(ctx, cluster)
+	require.NoError(t, err)
+	assert.Equal(t, int(expectReplicaNum), countGroupPods(groupNameStr))
+	assert.Equal(t, int(expectReplicaNum), countGroupPods("gpu-group"))
+
+	// Only the Pods of the suspended worker group are deleted.
+	cluster.Spec.WorkerGroupSpecs[1].Suspend = ptr.To(true)
+	require.NoError(t, validateRayClusterSpec(cluster))
+	err = testRayClusterReconciler.reconcilePods(ctx, cluster)
+	require.NoError(t, err)
+	assert.Equal(t, int(expectReplicaNum), countGroupPods(groupNameStr))
+	assert.Equal(t, 0, countGroupPods("gpu-group"))
+
+	// The Pods are recreated when the worker group is resumed.
+	cluster.Spec.WorkerGroupSpecs[1].Suspend = ptr.To(false)
+	err = testRayClusterReconciler.reconcilePods(ctx, cluster)
+	require.NoError(t, err)
+	assert.Equal(t, int(expectReplicaNum), countGroupPods(groupNameStr))
+	assert.Equal(t, int(expectReplicaNum), countGroupPods("gpu-group"))
+}
+
+func TestReconcile_RemoteWorkerGroup(t *testing.T) {
+	setupTest(t)
+	defer features.SetFeatureGateDuringTest(t, features.RayMultiNamespaceWorkerGroups, true)()
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.EnableInTreeAutoscaling = nil
+	cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = nil
+	cluster.Spec.WorkerGroupSpecs[0].Namespace = "tenant"
+	require.NoError(t, validateRayClusterSpec(cluster))
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(cluster).Build()
+	ctx := context.Background()
+
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:                     fakeClient,
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     newScheme,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+	}
+
+	// The worker Pods are created in the namespace of the worker group without owner references.
+	err := testRayClusterReconciler.reconcilePods(ctx, cluster)
+	require.NoError(t, err)
+	podList := corev1.PodList{}
+	err = fakeClient.List(ctx, &podList, common.RayClusterRemoteWorkerPodsAssociationOptions(cluster).ToListOptions()...)
+	require.NoError(t, err)
+	assert.Len(t, podList.Items, int(expectReplicaNum))
+	for _, pod := range podList.Items {
+		assert.Equal(t, "tenant", pod.Namespace)
+		assert.Empty(t, pod.OwnerReferences)
+	}
+
+	// The finalizer is added to the RayCluster.
+	requeue, err := testRayClusterReconciler.reconcileRemoteWorkerPodsCleanupFinalizer(ctx, cluster)
+	require.NoError(t, err)
+	assert.True(t, requeue)
+	assert.True(t, controllerutil.ContainsFinalizer(cluster, utils.RemoteWorkerPodsCleanupFinalizer))
+	requeue, err = testRayClusterReconciler.reconcileRemoteWorkerPodsCleanupFinalizer(ctx, cluster)
+	require.NoError(t, err)
+	assert.False(t, requeue)
+
+	// The worker Pods are deleted before the finalizer is removed.
+	require.NoError(t, fakeClient.Delete(ctx, cluster))
+	require.NoError(t, fakeClient.Get(ctx, types.NamespacedName{Namespace: cluster.Namespace, Name: cluster.Name}, cluster))
+	requeue, err = testRayClusterReconciler.reconcileRemoteWorkerPodsCleanupFinalizer(ctx, cluster)
+	require.NoError(t, err)
+	assert.True(t, requeue)
+	assert.True(t, controllerutil.ContainsFinalizer(cluster, utils.RemoteWorkerPodsCleanupFinalizer))
+	err = fakeClient.List(ctx, &podList, common.RayClusterRemoteWorkerPodsAssociationOptions(cluster).ToListOptions()...)
+	require.NoError(t, err)
+	assert.Empty(t, podList.Items)
+
+	requeue, err = testRayClusterReconciler.reconcileRemoteWorkerPodsCleanupFinalizer(ctx, cluster)
+	require.NoError(t, err)
+	assert.True(t, requeue)
+	assert.False(t, controllerutil.ContainsFinalizer(cluster, utils.RemoteWorkerPodsCleanupFinalizer))
+}
+
+func TestReconcile_RemoteWorkerGroupWorkersToDelete(t *testing.T) {
+	setupTest(t)
+	defer features.SetFeatureGateDuringTest(t, features.RayMultiNamespaceWorkerGroups, true)()
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.EnableInTreeAutoscaling = nil
+	cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = nil
+	cluster.Spec.WorkerGroupSpecs[0].Namespace = "tenant"
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(cluster).Build()
+	ctx := context.Background()
+
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:                     fakeClient,
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     newScheme,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+	}
+	listWorkerPods := func() []corev1.Pod {
+		podList := corev1.PodList{}
+		require.NoError(t, fakeClient.List(ctx, &podList, common.RayClusterRemoteWorkerPodsAssociationOptions(cluster).ToListOptions()...))
+		return podList.Items
+	}
+
+	require.NoError(t, testRayClusterReconciler.reconcilePods(ctx, cluster))
+	pods := listWorkerPods()
+	require.Len(t, pods, int(expectReplicaNum))
+
+	// The Pods of WorkersToDelete are deleted from the namespace of the worker group. The replicas are kept, so that
+	// only the Pod of WorkersToDelete can be deleted, and it is replaced.
+	podToDelete := pods[len(pods)-1]
+	cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = []string{podToDelete.Name}
+	require.NoError(t, testRayClusterReconciler.reconcilePods(ctx, cluster))
+	err := fakeClient.Get(ctx, client.ObjectKeyFromObject(&podToDelete), &corev1.Pod{})
+	assert.True(t, k8serrors.IsNotFound(err))
+	assert.Len(t, listWorkerPods(), int(expectReplicaNum))
+}
+
+func TestValidateRayClusterSpecWorkersToDelete(t *testing.T) {
+	cluster := testRayCluster.DeepCopy()
+	otherGroup := cluster.Spec.WorkerGroupSpecs[0].DeepCopy()
+	otherGroup.GroupName = "other-group"
+	cluster.Spec.WorkerGroupSpecs = append(cluster.Spec.WorkerGroupSpecs, *otherGroup)
+	groupName := cluster.Spec.WorkerGroupSpecs[0].GroupName
+
+	cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = []string{"pod1", "pod1"}
+	cluster.Spec.WorkerGroupSpecs[1].ScaleStrategy.WorkersToDelete = []string{"pod2"}
+	require.NoError(t, validateRayClusterSpec(cluster))
+
+	cluster.Spec.WorkerGroupSpecs[1].ScaleStrategy.WorkersToDelete = []string{"pod1"}
+	require.EqualError(t, validateRayClusterSpec(cluster), "the Pod pod1 is in the workersToDelete of both worker groups "+groupName+" and other-group")
+
+	cluster.Spec.WorkerGroupSpecs[1].ScaleStrategy.WorkersToDelete = []string{""}
+	require.EqualError(t, validateRayClusterSpec(cluster), "the workersToDelete of worker group other-group contains an empty Pod name")
+}
+
+func TestValidateRayClusterSpecWorkerGroupNamespace(t *testing.T) {
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.WorkerGroupSpecs[0].Namespace = "tenant"
+
+	defer features.SetFeatureGateDuringTest(t, features.RayMultiNamespaceWorkerGroups, false)()
+	err := validateRayClusterSpec(cluster)
+	assert.EqualError(t, err, "the namespace of worker group "+cluster.Spec.WorkerGroupSpecs[0].GroupName+" requires the RayMultiNamespaceWorkerGroups feature gate")
+
+	// A worker group in the namespace of the RayCluster doesn't require the feature gate.
+	cluster.Spec.WorkerGroupSpecs[0].Namespace = cluster.Namespace
+	assert.NoError(t, validateRayClusterSpec(cluster))
+}
+
+func TestReconcile_NodeProvisioningConfig(t *testing.T) {
+	setupTest(t)
+	defer features.SetFeatureGateDuringTest(t, features.RayNodeProvisioningConfig, true)()
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.EnableInTreeAutoscaling = nil
+	cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = nil
+	cluster.Spec.WorkerGroupSpecs[0].Template.Spec.Containers[utils.RayContainerIndex].Resources.Limits = corev1.ResourceList{
+		"nvidia.com/gpu": resource.MustParse("1"),
+	}
+	gpuToleration := corev1.Toleration{Key: "nvidia.com/gpu", Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule}
+	config := &rayv1.RayNodeProvisioningConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "gpu"},
+		Spec: rayv1.RayNodeProvisioningConfigSpec{Accelerators: []rayv1.AcceleratorProvisioningRule{{
+			ResourceName: "nvidia.com/gpu",
+			Tolerations:  []corev1.Toleration{gpuToleration},
+			NodeSelector: map[string]string{"pool": "gpu"},
+		}}},
+	}
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithObjects(config).Build()
+	ctx := context.Background()
+
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:                     fakeClient,
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     newScheme,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+	}
+
+	err := testRayClusterReconciler.reconcilePods(ctx, cluster)
+	require.NoError(t, err)
+
+	// Only the worker Pods requesting GPUs get the settings of the RayNodeProvisioningConfig.
+	podList := corev1.PodList{}
+	err = fakeClient.List(ctx, &podList, common.RayClusterGroupPodsAssociationOptions(cluster, groupNameStr).ToListOptions()...)
+	require.NoError(t, err)
+	require.Len(t, podList.Items, int(expectReplicaNum))
+	for _, pod := range podList.Items {
+		assert.Contains(t, pod.Spec.Tolerations, gpuToleration)
+		assert.Equal(t, "gpu", pod.Spec.NodeSelector["pool"])
+	}
+	err = fakeClient.List(ctx, &podList, common.RayClusterHeadPodsAssociationOptions(cluster).ToListOptions()...)
+	require.NoError(t, err)
+	require.Len(t, podList.Items, 1)
+	assert.NotContains(t, podList.Items[0].Spec.Tolerations, gpuToleration)
+
+	// The RayNodeProvisioningConfigs are ignored if the CRD isn't installed or KubeRay isn't allowed to list it.
+	for _, listErr := range []error{
+		&meta.NoKindMatchError{GroupKind: schema.GroupKind{Group: rayv1.GroupVersion.Group, Kind: "RayNodeProvisioningConfig"}},
+		k8serrors.NewForbidden(schema.GroupResource{Group: rayv1.GroupVersion.Group, Resource: "raynodeprovisioningconfigs"}, "", fmt.Errorf("forbidden")),
+	} {
+		testRayClusterReconciler.Client = clientFake.NewClientBuilder().WithScheme(newScheme).WithInterceptorFuncs(interceptor.Funcs{
+			List: func(ctx context.Context, client client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				if _, ok := list.(*rayv1.RayNodeProvisioningConfigList); ok {
+					return listErr
+				}
+				return client.List(ctx, list, opts...)
+			},
+		}).Build()
+		pod := corev1.Pod{}
+		require.NoError(t, testRayClusterReconciler.applyNodeProvisioningConfigs(ctx, &pod))
+	}
+}
+
+func TestReconcile_PlacementPolicy(t *testing.T) {
+	setupTest(t)
+	defer features.SetFeatureGateDuringTest(t, features.RayPlacementPolicy, true)()
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.EnableInTreeAutoscaling = nil
+	cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = nil
+	cluster.Labels = map[string]string{utils.RayOriginatedFromCRDLabelKey: utils.RayOriginatedFromCRDLabelValue(utils.RayServiceCRD)}
+	toleration := corev1.Toleration{Key: "pool", Operator: corev1.TolerationOpEqual, Value: "serving", Effect: corev1.TaintEffectNoSchedule}
+	policy := &rayv1.RayPlacementPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "serving"},
+		Spec: rayv1.RayPlacementPolicySpec{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"env": "prod"}},
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{
+				utils.RayOriginatedFromCRDLabelKey: utils.RayOriginatedFromCRDLabelValue(utils.RayServiceCRD),
+				utils.RayNodeTypeLabelKey:          string(rayv1.WorkerNode),
+			}},
+			NodeSelector: map[string]string{"pool": "serving"},
+			Tolerations:  []corev1.Toleration{toleration},
+		},
+	}
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespaceStr, Labels: map[string]string{"env": "prod"}}}
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithObjects(policy, namespace).Build()
+	ctx := context.Background()
+
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:                     fakeClient,
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     newScheme,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+	}
+
+	err := testRayClusterReconciler.reconcilePods(ctx, cluster)
+	require.NoError(t, err)
+
+	// Only the worker Pods are selected by the RayPlacementPolicy.
+	podList := corev1.PodList{}
+	err = fakeClient.List(ctx, &podList, common.RayClusterGroupPodsAssociationOptions(cluster, groupNameStr).ToListOptions()...)
+	require.NoError(t, err)
+	require.Len(t, podList.Items, int(expectReplicaNum))
+	for _, pod := range podList.Items {
+		assert.Contains(t, pod.Spec.Tolerations, toleration)
+		assert.Equal(t, "serving", pod.Spec.NodeSelector["pool"])
+	}
+	err = fakeClient.List(ctx, &podList, common.RayClusterHeadPodsAssociationOptions(cluster).ToListOptions()...)
+	require.NoError(t, err)
+	require.Len(t, podList.Items, 1)
+	assert.NotContains(t, podList.Items[0].Spec.Tolerations, toleration)
+}
+
+func TestReconcile_HostNetworkPorts(t *testing.T) {
+	setupTest(t)
+	defer features.SetFeatureGateDuringTest(t, features.RayHostNetworkPortAllocation, true)()
+	t.Setenv("POD_NAMESPACE", "ray-system")
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.EnableInTreeAutoscaling = nil
+	cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = nil
+	cluster.Spec.HeadGroupSpec.Template.Spec.HostNetwork = true
+	cluster.Spec.WorkerGroupSpecs[0].Template.Spec.HostNetwork = true
+	delete(cluster.Spec.HeadGroupSpec.RayStartParams, "port")
+	// The block of the deleted RayCluster is freed, and the one of the existing RayCluster is kept.
+	other := testRayCluster.DeepCopy()
+	other.Name = "other"
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ray-system", Name: utils.HostNetworkPortsConfigMapName},
+		Data:       map[string]string{namespaceStr + ".deleted": "0", namespaceStr + ".other": "1"},
+	}
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithObjects(cluster, other, configMap).Build()
+	ctx := context.Background()
+
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:                     fakeClient,
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     newScheme,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+	}
+
+	err := testRayClusterReconciler.reconcileHostNetworkPorts(ctx, cluster)
+	require.NoError(t, err)
+	assert.Equal(t, "0", cluster.Annotations[utils.RayHostNetworkPortBlockAnnotationKey])
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(configMap), configMap)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{namespaceStr + ".other": "1", namespaceStr + "." + cluster.Name: "0"}, configMap.Data)
+
+	err = testRayClusterReconciler.reconcilePods(ctx, cluster)
+	require.NoError(t, err)
+	podList := corev1.PodList{}
+	err = fakeClient.List(ctx, &podList, common.RayClusterHeadPodsAssociationOptions(cluster).ToListOptions()...)
+	require.NoError(t, err)
+	require.Len(t, podList.Items, 1)
+	assert.Contains(t, podList.Items[0].Spec.Containers[utils.RayContainerIndex].Args[0], "--port=20000")
+	err = fakeClient.List(ctx, &podList, common.RayClusterGroupPodsAssociationOptions(cluster, groupNameStr).ToListOptions()...)
+	require.NoError(t, err)
+	require.Len(t, podList.Items, int(expectReplicaNum))
+	for _, pod := range podList.Items {
+		assert.Contains(t, pod.Spec.Containers[utils.RayContainerIndex].Args[0], ":20000")
+		assert.Contains(t, pod.Spec.Containers[utils.RayContainerIndex].Args[0], "--node-manager-port=20004")
+	}
+
+	// The block recorded in the annotation is used without reading the ConfigMap.
+	cluster = testRayCluster.DeepCopy()
+	cluster.Annotations = map[string]string{utils.RayHostNetworkPortBlockAnnotationKey: "3"}
+	cluster.Spec.HeadGroupSpec.Template.Spec.HostNetwork = true
+	delete(cluster.Spec.HeadGroupSpec.RayStartParams, "port")
+	err = testRayClusterReconciler.reconcileHostNetworkPorts(ctx, cluster)
+	require.NoError(t, err)
+	assert.Equal(t, "20600", cluster.Spec.HeadGroupSpec.RayStartParams["port"])
+}
+
+func TestReconcile_WorkerGroupUpdateStrategy(t *testing.T) {
+	setupTest(t)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.EnableInTreeAutoscaling = nil
+	cluster.Spec.WorkerGroupSpecs[0].ScaleStrategy.WorkersToDelete = nil
+	fakeClient := clientFake.NewClientBuilder().Build()
+	ctx := context.Background()
+
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:                     fakeClient,
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     scheme.Scheme,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+	}
+	listWorkerPods := func() []corev1.Pod {
+		podList := corev1.PodList{}
+		err := fakeClient.List(ctx, &podList, common.RayClusterGroupPodsAssociationOptions(cluster, groupNameStr).ToListOptions()...)
+		require.NoError(t, err)
+		return podList.Items
+	}
+	countOutdatedPods := func() int {
+		templateHash, err := common.GenerateWorkerGroupTemplateHash(cluster.Spec.WorkerGroupSpecs[0])
+		require.NoError(t, err)
+		count := 0
+		for _, pod := range listWorkerPods() {
+			if common.IsWorkerPodOutdated(pod, templateHash) {
+				count++
+			}
+		}
+		return count
+	}
+	setPodsReady := func() {
+		for _, pod := range listWorkerPods() {
+			pod.Status.Phase = corev1.PodRunning
+			pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+			err := fakeClient.Status().Update(ctx, &pod)
+			require.NoError(t, err)
+		}
+	}
+
+	err := testRayClusterReconciler.reconcilePods(ctx, cluster)
+	require.NoError(t, err)
+	assert.Len(t, listWorkerPods(), int(expectReplicaNum))
+	setPodsReady()
+
+	// The outdated Pods are kept with the default OnDelete strategy.
+	cluster.Spec.WorkerGroupSpecs[0].Template.Spec.Containers[0].Image = "rayproject/ray:2.10.0"
+	err = testRayClusterReconciler.reconcilePods(ctx, cluster)
+	require.NoError(t, err)
+	assert.Len(t, listWorkerPods(), int(expectReplicaNum))
+	assert.Equal(t, int(expectReplicaNum), countOutdatedPods())
+
+	// With the RollingUpdate strategy, a new Pod is created first, and an outdated Pod is deleted once the new Pod is available.
+	cluster.Spec.WorkerGroupSpecs[0].UpdateStrategy = &rayv1.WorkerGroupUpdateStrategy{
+		Type: rayv1.RollingUpdateWorkerGroupUpdateStrategyType,
+		RollingUpdate: &rayv1.RollingUpdateWorkerGroup{
+			MaxSurge:       ptr.To(intstr.FromInt32(1)),
+			MaxUnavailable: ptr.To(intstr.FromInt32(0)),
+		},
+	}
+	err = testRayClusterReconciler.reconcilePods(ctx, cluster)
+	require.NoError(t, err)
+	assert.Len(t, listWorkerPods(), int(expectReplicaNum)+1)
+	assert.Equal(t, int(expectReplicaNum), countOutdatedPods())
+	err = testRayClusterReconciler.reconcilePods(ctx, cluster)
+	require.NoError(t, err)
+	assert.Len(t, listWorkerPods(), int(expectReplicaNum)+1)
+	setPodsReady()
+	err = testRayClusterReconciler.reconcilePods(ctx, cluster)
+	require.NoError(t, err)
+	assert.Len(t, listWorkerPods(), int(expectReplicaNum))
+	assert.Equal(t, int(expectReplicaNum)-1, countOutdatedPods())
+
+	// With the Recreate strategy, all the outdated Pods are deleted before new Pods are created.
+	cluster.Spec.WorkerGroupSpecs[0].UpdateStrategy = &rayv1.WorkerGroupUpdateStrategy{Type: rayv1.RecreateWorkerGroupUpdateStrategyType}
+	err = testRayClusterReconciler.reconcilePods(ctx, cluster)
+	require.NoError(t, err)
+	assert.Len(t, listWorkerPods(), 1)
+	assert.Equal(t, 0, countOutdatedPods())
+	testRayClusterReconciler.rayClusterScaleExpectation = expectations.NewRayClusterScaleExpectation(fakeClient)
+	err = testRayClusterReconciler.reconcilePods(ctx, cluster)
+	require.NoError(t, err)
+	assert.Len(t, listWorkerPods(), int(expectReplicaNum))
+	assert.Equal(t, 0, countOutdatedPods())
+}
+
+func Test_ReconcileDashboardIngress(t *testing.T) {
+	setupTest(t)
+
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	_ = networkingv1.AddToScheme(newScheme)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.DashboardIngress = &rayv1.DashboardIngressOptions{Host: "ray.example.com"}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).Build()
+	ctx := context.Background()
+
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:   fakeClient,
+		Recorder: &record.FakeRecorder{},
+		Scheme:   newScheme,
+	}
+	key := client.ObjectKey{Namespace: cluster.Namespace, Name: utils.GenerateDashboardIngressName(cluster.Name)}
+
+	// The Ingress is created.
+	err := testRayClusterReconciler.reconcileDashboardIngress(ctx, cluster)
+	require.NoError(t, err)
+	ingress := &networkingv1.Ingress{}
+	err = fakeClient.Get(ctx, key, ingress)
+	require.NoError(t, err)
+	assert.Equal(t, "ray.example.com", ingress.Spec.Rules[0].Host)
+	// The Ingress doesn't have the RayCluster label, so it isn't mistaken for the Ingress of `enableIngress`.
+	assert.NotContains(t, ingress.Labels, utils.RayClusterLabelKey)
+
+	// The Ingress is updated when the options change.
+	cluster.Spec.DashboardIngress.Host = "dashboard.example.com"
+	err = testRayClusterReconciler.reconcileDashboardIngress(ctx, cluster)
+	require.NoError(t, err)
+	err = fakeClient.Get(ctx, key, ingress)
+	require.NoError(t, err)
+	assert.Equal(t, "dashboard.example.com", ingress.Spec.Rules[0].Host)
+
+	// The Ingress of a RayCluster created by a RayService is managed by the RayService.
+	serviceCluster := cluster.DeepCopy()
+	serviceCluster.Labels = map[string]string{utils.RayOriginatedFromCRDLabelKey: utils.RayOriginatedFromCRDLabelValue(utils.RayServiceCRD)}
+	serviceCluster.Spec.DashboardIngress = nil
+	err = testRayClusterReconciler.reconcileDashboardIngress(ctx, serviceCluster)
+	require.NoError(t, err)
+	err = fakeClient.Get(ctx, key, ingress)
+	require.NoError(t, err)
+
+	// The Ingress is deleted when the options are removed.
+	cluster.Spec.DashboardIngress = nil
+	err = testRayClusterReconciler.reconcileDashboardIngress(ctx, cluster)
+	require.NoError(t, err)
+	err = fakeClient.Get(ctx, key, ingress)
+	assert.True(t, k8serrors.IsNotFound(err))
+}
+
+func Test_RunningPods_RayContainerTerminated(t *testing.T) {
+	setupTest(t)
+
+	// Create a new scheme with CRDs, Pod, Service schemes.
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	// Only one head Pod and no worker Pods in the RayCluster.
+	runtimeObjects := testPods[0:1]
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.WorkerGroupSpecs = nil
+	fakeClient := clientFake.NewClientBuilder().
+		WithScheme(newScheme).
+		WithRuntimeObjects(runtimeObjects...).
+		WithStatusSubresource(cluster).
+		Build()
+	ctx := context.Background()
+
+	// Get the pod list from the fake client.
+	podList := corev1.PodList{}
+	err := fakeClient.List(ctx, &podList, client.InNamespace(namespaceStr))
+	assert.Nil(t, err, "Fail to get pod list")
+	assert.Equal(t, 1, len(podList.Items))
+	assert.Equal(t, "headNode", podList.Items[0].Name)
+
+	// Make sure the head Pod's restart policy is `Never`, the Pod status is `Running`,
+	// and the Ray container has terminated. The next `reconcilePods` call will delete
+	// the head Pod and will not create a new one in the same reconciliation loop.
+	podList.Items[0].Spec.RestartPolicy = corev1.RestartPolicyNever
+	err = fakeClient.Update(ctx, &podList.Items[0])
+	assert.Nil(t, err)
+
+	podList.Items[0].Status.Phase = corev1.PodRunning
+	podList.Items[0].Status.ContainerStatuses = []corev1.ContainerStatus{
+		{
+			Name: podList.Items[0].Spec.Containers[utils.RayContainerIndex].Name,
+			State: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{},
+			},
+		},
+	}
+	err = fakeClient.Status().Update(ctx, &podList.Items[0])
+	assert.Nil(t, err)
+
+	// Initialize a new RayClusterReconciler.
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:                     fakeClient,
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     newScheme,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+	}
+
+	// The head Pod will be deleted and the controller will return an error
+	// instead of creating a new head Pod in the same reconcile loop.
+	err = testRayClusterReconciler.reconcilePods(ctx, cluster)
+	assert.NotNil(t, err)
+	err = fakeClient.List(ctx, &podList, client.InNamespace(namespaceStr))
+	assert.Nil(t, err, "Fail to get pod list")
+	assert.Equal(t, 0, len(podList.Items))
+
+	// The new head Pod will be created in this reconcile loop.
+	err = testRayClusterReconciler.reconcilePods(ctx, cluster)
+	assert.Nil(t, err)
+	err = fakeClient.List(ctx, &podList, client.InNamespace(namespaceStr))
+	assert.Nil(t, err, "Fail to get pod list")
+	assert.Equal(t, 1, len(podList.Items))
+}
+
+func Test_ShouldDeletePod(t *testing.T) {
+	pod := corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name: "ray-head",
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name            string
+		restartPolicy   corev1.RestartPolicy
+		phase           corev1.PodPhase
 		containerStatus []corev1.ContainerStatus
 		shouldDelete    bool
 	}{
@@ -2598,6 +4112,7 @@ func Test_RedisCleanupFeatureFlag(t *testing.T) {
 	newScheme := runtime.NewScheme()
 	_ = rayv1.AddToScheme(newScheme)
 	_ = corev1.AddToScheme(newScheme)
+	_ = networkingv1.AddToScheme(newScheme)
 
 	// Prepare a RayCluster with the GCS FT enabled and Autoscaling disabled.
 	gcsFTEnabledCluster := testRayCluster.DeepCopy()
@@ -3239,6 +4754,61 @@ func TestSumGPUs(t *testing.T) {
 	}
 }
 
+func TestSampleResourceUsage(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(newScheme)
+
+	newPod := func(name string, ready corev1.ConditionStatus, requests corev1.ResourceList) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    map[string]string{utils.RayClusterLabelKey: "raycluster"},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "ray", Resources: corev1.ResourceRequirements{Requests: requests}}},
+			},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}},
+			},
+		}
+	}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(
+		newPod("head", corev1.ConditionTrue, corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")}),
+		newPod("worker", corev1.ConditionTrue, corev1.ResourceList{
+			corev1.ResourceCPU:                    resource.MustParse("500m"),
+			corev1.ResourceName("nvidia.com/gpu"): resource.MustParse("1"),
+		}),
+		// The Pods that aren't ready aren't accounted.
+		newPod("starting", corev1.ConditionFalse, corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")}),
+	).Build()
+	ctx := context.Background()
+	rayClusters := []types.NamespacedName{{Namespace: "default", Name: "raycluster"}}
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// The usage starts being accounted at the first sample.
+	usage, err := sampleResourceUsage(ctx, fakeClient, nil, rayClusters, now, false)
+	require.NoError(t, err)
+	assert.Equal(t, &rayv1.ResourceUsage{CPUHours: "0", GPUHours: "0", LastSampleTime: &metav1.Time{Time: now}}, usage)
+
+	// The Pods aren't sampled again within the sample interval, unless it's forced.
+	sameUsage, err := sampleResourceUsage(ctx, fakeClient, usage, rayClusters, now.Add(resourceUsageSampleInterval/2), false)
+	require.NoError(t, err)
+	assert.Same(t, usage, sameUsage)
+
+	usage, err = sampleResourceUsage(ctx, fakeClient, usage, rayClusters, now.Add(30*time.Minute), true)
+	require.NoError(t, err)
+	assert.Equal(t, "1.25", usage.CPUHours)
+	assert.Equal(t, "0.5", usage.GPUHours)
+
+	usage, err = sampleResourceUsage(ctx, fakeClient, usage, rayClusters, now.Add(2*time.Hour), false)
+	require.NoError(t, err)
+	assert.Equal(t, "5", usage.CPUHours)
+	assert.Equal(t, "2", usage.GPUHours)
+	assert.Equal(t, now.Add(2*time.Hour), usage.LastSampleTime.Time)
+}
+
 func TestDeleteAllPods(t *testing.T) {
 	newScheme := runtime.NewScheme()
 	_ = corev1.AddToScheme(newScheme)
@@ -3416,6 +4986,7 @@ func Test_ReconcileManagedBy(t *testing.T) {
 	newScheme := runtime.NewScheme()
 	_ = rayv1.AddToScheme(newScheme)
 	_ = corev1.AddToScheme(newScheme)
+	_ = networkingv1.AddToScheme(newScheme)
 	_ = batchv1.AddToScheme(newScheme)
 
 	tests := map[string]struct {
@@ -3609,7 +5180,74 @@ func TestValidateRayClusterSpecGcsFaultToleranceOptions(t *testing.T) {
 			err := validateRayClusterSpec(rayCluster)
 			if tt.expectError {
 				assert.Error(t, err)
-				assert.EqualError(t, err, tt.errorMessage)
+				assert.EqualError(t, err, tt.errorMessage)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateRayClusterSpecRedisPassword(t *testing.T) {
+	tests := []struct {
+		gcsFaultToleranceOptions *rayv1.GcsFaultToleranceOptions
+		name                     string
+		rayStartParams           map[string]string
+		envVars                  []corev1.EnvVar
+		expectError              bool
+	}{
+		{
+			name:                     "GcsFaultToleranceOptions is set and `redis-password` is also set in rayStartParams",
+			gcsFaultToleranceOptions: &rayv1.GcsFaultToleranceOptions{},
+			rayStartParams: map[string]string{
+				"redis-password": "password",
+			},
+			expectError: true,
+		},
+		{
+			name:                     "GcsFaultToleranceOptions is set and `REDIS_PASSWORD` env var is also set in the head Pod",
+			gcsFaultToleranceOptions: &rayv1.GcsFaultToleranceOptions{},
+			envVars: []corev1.EnvVar{
+				{
+					Name:  utils.REDIS_PASSWORD,
+					Value: "password",
+				},
+			},
+			expectError: true,
+		},
+		{
+			name: "GcsFaultToleranceOptions.RedisPassword is set",
+			gcsFaultToleranceOptions: &rayv1.GcsFaultToleranceOptions{
+				RedisPassword: &rayv1.RedisCredential{
+					Value: "password",
+				},
+			},
+			expectError: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rayCluster := &rayv1.RayCluster{
+				Spec: rayv1.RayClusterSpec{
+					GcsFaultToleranceOptions: tt.gcsFaultToleranceOptions,
+					HeadGroupSpec: rayv1.HeadGroupSpec{
+						RayStartParams: tt.rayStartParams,
+						Template: corev1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{
+									{
+										Env: tt.envVars,
+									},
+								},
+							},
+						},
+					},
+				},
+			}
+			err := validateRayClusterSpec(rayCluster)
+			if tt.expectError {
+				assert.Error(t, err)
 			} else {
 				assert.Nil(t, err)
 			}
@@ -3617,56 +5255,91 @@ func TestValidateRayClusterSpecGcsFaultToleranceOptions(t *testing.T) {
 	}
 }
 
-func TestValidateRayClusterSpecRedisPassword(t *testing.T) {
+func TestValidateRayClusterSpecHeadServicePorts(t *testing.T) {
 	tests := []struct {
-		gcsFaultToleranceOptions *rayv1.GcsFaultToleranceOptions
-		name                     string
-		rayStartParams           map[string]string
-		envVars                  []corev1.EnvVar
-		expectError              bool
+		servicePorts *rayv1.HeadServicePorts
+		name         string
+		expectError  bool
 	}{
 		{
-			name:                     "GcsFaultToleranceOptions is set and `redis-password` is also set in rayStartParams",
-			gcsFaultToleranceOptions: &rayv1.GcsFaultToleranceOptions{},
-			rayStartParams: map[string]string{
-				"redis-password": "password",
+			name: "Exclude the metrics port and add a custom port",
+			servicePorts: &rayv1.HeadServicePorts{
+				Exclude:    []string{utils.MetricsPortName},
+				Additional: []corev1.ServicePort{{Name: "grpc", Port: 9000}},
 			},
-			expectError: true,
+			expectError: false,
 		},
 		{
-			name:                     "GcsFaultToleranceOptions is set and `REDIS_PASSWORD` env var is also set in the head Pod",
-			gcsFaultToleranceOptions: &rayv1.GcsFaultToleranceOptions{},
-			envVars: []corev1.EnvVar{
-				{
-					Name:  utils.REDIS_PASSWORD,
-					Value: "password",
-				},
+			name:         "Exclude the gcs-server port",
+			servicePorts: &rayv1.HeadServicePorts{Exclude: []string{utils.GcsServerPortName}},
+			expectError:  true,
+		},
+		{
+			name:         "Additional port without a name",
+			servicePorts: &rayv1.HeadServicePorts{Additional: []corev1.ServicePort{{Port: 9000}}},
+			expectError:  true,
+		},
+		{
+			name: "Duplicated additional ports",
+			servicePorts: &rayv1.HeadServicePorts{
+				Additional: []corev1.ServicePort{{Name: "grpc", Port: 9000}, {Name: "grpc", Port: 9001}},
 			},
 			expectError: true,
 		},
 		{
-			name: "GcsFaultToleranceOptions.RedisPassword is set",
-			gcsFaultToleranceOptions: &rayv1.GcsFaultToleranceOptions{
-				RedisPassword: &rayv1.RedisCredential{
-					Value: "password",
-				},
+			name: "Port of a sidecar container",
+			servicePorts: &rayv1.HeadServicePorts{
+				ContainerPorts: []rayv1.HeadContainerServicePort{{ContainerName: "router", PortName: "router", ExposeOnServeService: ptr.To(true)}},
 			},
 			expectError: false,
 		},
+		{
+			name: "Port not declared by the sidecar container",
+			servicePorts: &rayv1.HeadServicePorts{
+				ContainerPorts: []rayv1.HeadContainerServicePort{{ContainerName: "router", PortName: "unknown"}},
+			},
+			expectError: true,
+		},
+		{
+			name: "Port of the Ray container",
+			servicePorts: &rayv1.HeadServicePorts{
+				ContainerPorts: []rayv1.HeadContainerServicePort{{ContainerName: "ray-head", PortName: "router"}},
+			},
+			expectError: true,
+		},
+		{
+			name: "Container port with the name of an additional port",
+			servicePorts: &rayv1.HeadServicePorts{
+				Additional:     []corev1.ServicePort{{Name: "router", Port: 9000}},
+				ContainerPorts: []rayv1.HeadContainerServicePort{{ContainerName: "router", PortName: "router"}},
+			},
+			expectError: true,
+		},
+		{
+			name: "Container port with the number of the gcs-server port",
+			servicePorts: &rayv1.HeadServicePorts{
+				ContainerPorts: []rayv1.HeadContainerServicePort{{ContainerName: "router", PortName: "clash"}},
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			rayCluster := &rayv1.RayCluster{
 				Spec: rayv1.RayClusterSpec{
-					GcsFaultToleranceOptions: tt.gcsFaultToleranceOptions,
 					HeadGroupSpec: rayv1.HeadGroupSpec{
-						RayStartParams: tt.rayStartParams,
+						ServicePorts: tt.servicePorts,
 						Template: corev1.PodTemplateSpec{
 							Spec: corev1.PodSpec{
 								Containers: []corev1.Container{
+									{Name: "ray-head"},
 									{
-										Env: tt.envVars,
+										Name: "router",
+										Ports: []corev1.ContainerPort{
+											{Name: "router", ContainerPort: 8081},
+											{Name: "clash", ContainerPort: utils.DefaultGcsServerPort},
+										},
 									},
 								},
 							},
@@ -3684,6 +5357,19 @@ func TestValidateRayClusterSpecRedisPassword(t *testing.T) {
 	}
 }
 
+func TestValidateRayClusterSpecExistingHeadService(t *testing.T) {
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.HeadGroupSpec.ExistingHeadServiceName = "mesh-head-svc"
+	assert.NoError(t, validateRayClusterSpec(cluster))
+
+	cluster.Spec.HeadGroupSpec.ServicePorts = &rayv1.HeadServicePorts{Exclude: []string{utils.MetricsPortName}}
+	assert.EqualError(t, validateRayClusterSpec(cluster), "headGroupSpec.existingHeadServiceName can't be used with headGroupSpec.headService or headGroupSpec.servicePorts")
+
+	cluster.Spec.HeadGroupSpec.ServicePorts = nil
+	cluster.Spec.HeadGroupSpec.HeadService = &corev1.Service{}
+	assert.Error(t, validateRayClusterSpec(cluster))
+}
+
 func TestValidateRayClusterSpecEmptyContainers(t *testing.T) {
 	headGroupSpecWithOneContainer := rayv1.HeadGroupSpec{
 		Template: corev1.PodTemplateSpec{
@@ -3774,11 +5460,12 @@ func TestValidateRayClusterSpecSuspendingWorkerGroup(t *testing.T) {
 	workerGroupSpecSuspended.Suspend = ptr.To[bool](true)
 
 	tests := []struct {
-		rayCluster   *rayv1.RayCluster
-		name         string
-		errorMessage string
-		expectError  bool
-		featureGate  bool
+		rayCluster             *rayv1.RayCluster
+		name                   string
+		errorMessage           string
+		expectError            bool
+		featureGate            bool
+		workerGroupSuspendGate bool
 	}{
 		{
 			name: "suspend without autoscaler and the feature gate",
@@ -3790,7 +5477,7 @@ func TestValidateRayClusterSpecSuspendingWorkerGroup(t *testing.T) {
 			},
 			featureGate:  false,
 			expectError:  true,
-			errorMessage: "suspending worker groups is currently available when the RayJobDeletionPolicy feature gate is enabled",
+			errorMessage: "suspending worker groups is currently available when the RayJobDeletionPolicy or RayWorkerGroupSuspend feature gate is enabled",
 		},
 		{
 			name: "suspend without autoscaler",
@@ -3803,6 +5490,17 @@ func TestValidateRayClusterSpecSuspendingWorkerGroup(t *testing.T) {
 			featureGate: true,
 			expectError: false,
 		},
+		{
+			name: "suspend with the RayWorkerGroupSuspend feature gate",
+			rayCluster: &rayv1.RayCluster{
+				Spec: rayv1.RayClusterSpec{
+					HeadGroupSpec:    headGroupSpec,
+					WorkerGroupSpecs: []rayv1.WorkerGroupSpec{workerGroupSpecSuspended},
+				},
+			},
+			workerGroupSuspendGate: true,
+			expectError:            false,
+		},
 		{
 			// TODO (rueian): This can be supported in future Ray. We should check the RayVersion once we know the version.
 			name: "suspend with autoscaler",
@@ -3822,6 +5520,7 @@ func TestValidateRayClusterSpecSuspendingWorkerGroup(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			defer features.SetFeatureGateDuringTest(t, features.RayJobDeletionPolicy, tt.featureGate)()
+			defer features.SetFeatureGateDuringTest(t, features.RayWorkerGroupSuspend, tt.workerGroupSuspendGate)()
 			err := validateRayClusterSpec(tt.rayCluster)
 			if tt.expectError {
 				assert.Error(t, err)
@@ -3911,3 +5610,442 @@ func TestValidateRayClusterStatus(t *testing.T) {
 		})
 	}
 }
+
+func Test_ReconcileProfiling(t *testing.T) {
+	setupTest(t)
+
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.Profiling = &rayv1.ProfilingOptions{Image: "profiler"}
+	headPod := testPods[0].(*corev1.Pod).DeepCopy()
+	headPod.Annotations = map[string]string{
+		utils.RayProfileAnnotationKey:         "py-spy-record",
+		utils.RayProfileDurationAnnotationKey: "5",
+	}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(headPod).Build()
+	recorder := record.NewFakeRecorder(10)
+	ctx := context.Background()
+
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:   fakeClient,
+		Recorder: recorder,
+		Scheme:   newScheme,
+	}
+	key := client.ObjectKeyFromObject(headPod)
+
+	// The profiling container is attached, and the request annotations are replaced with the container and the output.
+	err := testRayClusterReconciler.reconcileProfiling(ctx, cluster)
+	require.NoError(t, err)
+	pod := &corev1.Pod{}
+	err = fakeClient.Get(ctx, key, pod)
+	require.NoError(t, err)
+	assert.NotContains(t, pod.Annotations, utils.RayProfileAnnotationKey)
+	assert.NotContains(t, pod.Annotations, utils.RayProfileDurationAnnotationKey)
+	containerName := pod.Annotations[utils.RayProfileContainerAnnotationKey]
+	assert.True(t, strings.HasPrefix(containerName, utils.ProfileContainerNamePrefix))
+	assert.Equal(t, utils.DefaultProfileOutputDir+"/"+pod.Name+"-"+containerName+".svg", pod.Annotations[utils.RayProfileOutputAnnotationKey])
+	assert.Contains(t, <-recorder.Events, string(utils.StartedProfiling))
+
+	// The profiling container is still running.
+	err = testRayClusterReconciler.reconcileProfiling(ctx, cluster)
+	require.NoError(t, err)
+	assert.Empty(t, recorder.Events)
+
+	// An event is recorded once the profiling container terminates.
+	pod.Status.EphemeralContainerStatuses = []corev1.ContainerStatus{
+		{Name: containerName, State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}}},
+	}
+	err = fakeClient.Status().Update(ctx, pod)
+	require.NoError(t, err)
+	err = testRayClusterReconciler.reconcileProfiling(ctx, cluster)
+	require.NoError(t, err)
+	assert.Contains(t, <-recorder.Events, string(utils.CompletedProfiling))
+	err = fakeClient.Get(ctx, key, pod)
+	require.NoError(t, err)
+	assert.NotContains(t, pod.Annotations, utils.RayProfileContainerAnnotationKey)
+	assert.NotContains(t, pod.Annotations, utils.RayProfileOutputAnnotationKey)
+
+	// An invalid request is rejected and removed.
+	pod.Annotations = map[string]string{utils.RayProfileAnnotationKey: "perf"}
+	err = fakeClient.Update(ctx, pod)
+	require.NoError(t, err)
+	err = testRayClusterReconciler.reconcileProfiling(ctx, cluster)
+	require.NoError(t, err)
+	assert.Contains(t, <-recorder.Events, string(utils.InvalidProfilingRequest))
+	err = fakeClient.Get(ctx, key, pod)
+	require.NoError(t, err)
+	assert.NotContains(t, pod.Annotations, utils.RayProfileAnnotationKey)
+	assert.NotContains(t, pod.Annotations, utils.RayProfileContainerAnnotationKey)
+}
+
+func Test_ReconcilePods_GracefulDrain(t *testing.T) {
+	setupTest(t)
+
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.EnableInTreeAutoscaling = ptr.To(false)
+	cluster.Spec.WorkerGroupSpecs[0].Replicas = ptr.To[int32](0)
+	cluster.Spec.WorkerGroupSpecs[0].MinReplicas = ptr.To[int32](0)
+	cluster.Spec.WorkerGroupSpecs[0].GracefulDrainSeconds = ptr.To[int32](60)
+	headPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "headNode",
+			Namespace: namespaceStr,
+			Labels: map[string]string{
+				utils.RayClusterLabelKey:  instanceName,
+				utils.RayNodeTypeLabelKey: string(rayv1.HeadNode),
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers:    []corev1.Container{{Name: "ray-head"}},
+			RestartPolicy: corev1.RestartPolicyAlways,
+		},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	workerPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "workerNode",
+			Namespace: namespaceStr,
+			Labels: map[string]string{
+				utils.RayClusterLabelKey:   instanceName,
+				utils.RayNodeTypeLabelKey:  string(rayv1.WorkerNode),
+				utils.RayNodeGroupLabelKey: groupNameStr,
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers:    []corev1.Container{{Name: "ray-worker"}},
+			RestartPolicy: corev1.RestartPolicyAlways,
+		},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			PodIP:      "10.0.0.2",
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	runtimeObjects := append([]runtime.Object{headPod, workerPod}, testServices...)
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
+	ctx := context.Background()
+
+	fakeDashboardClient := &utils.FakeRayDashboardClient{}
+	fakeDashboardClient.SetAliveNodes([]utils.RayNodeInfo{{NodeID: "worker-node-id", NodeIP: "10.0.0.2", State: "ALIVE"}})
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:                     fakeClient,
+		Recorder:                   &record.FakeRecorder{},
+		Scheme:                     newScheme,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+		dashboardClientFunc: func() utils.RayDashboardClientInterface {
+			return fakeDashboardClient
+		},
+	}
+	key := client.ObjectKeyFromObject(workerPod)
+
+	// The node of the worker Pod is drained instead of deleting the Pod.
+	err := testRayClusterReconciler.reconcilePods(ctx, cluster)
+	require.NoError(t, err)
+	pod := &corev1.Pod{}
+	err = fakeClient.Get(ctx, key, pod)
+	require.NoError(t, err)
+	assert.Contains(t, pod.Annotations, utils.RayDrainDeadlineAnnotationKey)
+	assert.Contains(t, fakeDashboardClient.GetDrainedNodes(), "worker-node-id")
+
+	// The worker Pod is kept while the node is being drained.
+	err = testRayClusterReconciler.reconcilePods(ctx, cluster)
+	require.NoError(t, err)
+	err = fakeClient.Get(ctx, key, pod)
+	require.NoError(t, err)
+
+	// The worker Pod is deleted once the node is drained.
+	fakeDashboardClient.SetAliveNodes(nil)
+	err = testRayClusterReconciler.reconcilePods(ctx, cluster)
+	require.NoError(t, err)
+	err = fakeClient.Get(ctx, key, pod)
+	assert.True(t, k8serrors.IsNotFound(err))
+}
+
+func TestSummarizeUnschedulablePods(t *testing.T) {
+	unschedulablePod := func(message string) corev1.Pod {
+		return corev1.Pod{
+			Status: corev1.PodStatus{
+				Phase: corev1.PodPending,
+				Conditions: []corev1.PodCondition{
+					{Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: corev1.PodReasonUnschedulable, Message: message},
+				},
+			},
+		}
+	}
+	pods := []corev1.Pod{
+		unschedulablePod("0/5 nodes are available: 5 Insufficient nvidia.com/gpu. preemption: 0/5 nodes are available: 5 No preemption victims found for incoming pod."),
+		unschedulablePod("0/6 nodes are available: 6 Insufficient nvidia.com/gpu."),
+		unschedulablePod("0/5 nodes are available: 3 Insufficient cpu, 2 node(s) had untolerated taint {gpu: true}."),
+		{Status: corev1.PodStatus{Phase: corev1.PodRunning}},
+	}
+
+	assert.Equal(t, []string{
+		"1 worker Pods failed scheduling: Insufficient cpu, node(s) had untolerated taint {gpu: true}",
+		"2 worker Pods failed scheduling: Insufficient nvidia.com/gpu",
+	}, summarizeUnschedulablePods(pods))
+}
+
+func TestValidateRayClusterSpecTmpDirPolicy(t *testing.T) {
+	setupTest(t)
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.TmpDirPolicy = &rayv1.TmpDirPolicy{Type: rayv1.TmpDirTypeEphemeralVolume}
+	assert.EqualError(t, validateRayClusterSpec(cluster), "the size of the tmpDirPolicy is required for the EphemeralVolume type")
+
+	size := resource.MustParse("100Gi")
+	cluster.Spec.TmpDirPolicy.Size = &size
+	assert.NoError(t, validateRayClusterSpec(cluster))
+
+	cluster.Spec.HeadGroupSpec.RayStartParams = map[string]string{"temp-dir": "/data/ray"}
+	assert.ErrorContains(t, validateRayClusterSpec(cluster), "tmpDirPolicy and the temp-dir rayStartParam of the head group are both set")
+}
+
+func TestReconcileMemoryFailures(t *testing.T) {
+	setupTest(t)
+
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Spec.WorkerGroupSpecs[0].OOMPolicy = &rayv1.OOMPolicy{MemoryIncreasePercent: 50, OOMKillsPerIncrease: ptr.To[int32](1)}
+	cluster.Spec.WorkerGroupSpecs[0].Template.Spec.Containers[utils.RayContainerIndex].Resources.Requests = corev1.ResourceList{
+		corev1.ResourceMemory: resource.MustParse("2Gi"),
+	}
+	workerPod := testPods[1].(*corev1.Pod).DeepCopy()
+	oomKilledTime := metav1.NewTime(time.Now().Truncate(time.Second))
+	workerPod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name: workerPod.Spec.Containers[utils.RayContainerIndex].Name,
+		LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+			Reason:     "OOMKilled",
+			FinishedAt: oomKilledTime,
+		}},
+	}}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(testPods[0], workerPod).Build()
+	recorder := record.NewFakeRecorder(10)
+	ctx := context.Background()
+
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:   fakeClient,
+		Recorder: recorder,
+		Scheme:   newScheme,
+	}
+
+	err := testRayClusterReconciler.reconcileMemoryFailures(ctx, cluster)
+	require.NoError(t, err)
+	assert.Equal(t, []rayv1.GroupMemoryFailures{{
+		GroupName:         groupNameStr,
+		OOMKilled:         1,
+		LastOOMKilledTime: &oomKilledTime,
+		MemoryIncreases:   1,
+	}}, cluster.Status.MemoryFailures)
+	assert.Contains(t, <-recorder.Events, string(utils.RayContainerOOMKilled))
+	assert.Contains(t, <-recorder.Events, string(utils.IncreasedWorkerGroupMemory))
+
+	// The OOM kill is only counted once.
+	err = testRayClusterReconciler.reconcileMemoryFailures(ctx, cluster)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), cluster.Status.MemoryFailures[0].OOMKilled)
+	assert.Empty(t, recorder.Events)
+
+	// The new worker Pods request more memory.
+	pod := testRayClusterReconciler.buildWorkerPod(ctx, *cluster, cluster.Spec.WorkerGroupSpecs[0])
+	assert.Equal(t, "3Gi", pod.Spec.Containers[utils.RayContainerIndex].Resources.Requests.Memory().String())
+	assert.Equal(t, "2Gi", cluster.Spec.WorkerGroupSpecs[0].Template.Spec.Containers[utils.RayContainerIndex].Resources.Requests.Memory().String())
+}
+
+func TestReconcileClone(t *testing.T) {
+	setupTest(t)
+
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	source := testRayCluster.DeepCopy()
+	source.Namespace = "production"
+	source.Labels = map[string]string{"team": "ml", "env": "production"}
+	clone := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "debug-cluster",
+			Namespace:   "debug",
+			Labels:      map[string]string{"env": "debug"},
+			Annotations: map[string]string{utils.RayCloneFromAnnotationKey: "production/" + source.Name},
+		},
+	}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(source, clone).Build()
+	recorder := record.NewFakeRecorder(10)
+	ctx := context.Background()
+
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:   fakeClient,
+		Recorder: recorder,
+		Scheme:   newScheme,
+	}
+
+	// The source can't be cloned into another namespace without the RayCloneToNamespacesAnnotationKey annotation.
+	_, err := testRayClusterReconciler.rayClusterReconcile(ctx, clone.DeepCopy())
+	require.NoError(t, err)
+	assert.Contains(t, <-recorder.Events, string(utils.FailedToCloneRayCluster))
+
+	source.Annotations = map[string]string{utils.RayCloneToNamespacesAnnotationKey: "staging, debug"}
+	require.NoError(t, fakeClient.Update(ctx, source))
+	_, err = testRayClusterReconciler.rayClusterReconcile(ctx, clone.DeepCopy())
+	require.NoError(t, err)
+	assert.Contains(t, <-recorder.Events, string(utils.ClonedRayCluster))
+
+	cloned := &rayv1.RayCluster{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(clone), cloned))
+	assert.Equal(t, source.Spec, cloned.Spec)
+	assert.Equal(t, map[string]string{"team": "ml", "env": "debug"}, cloned.Labels)
+	assert.Equal(t, map[string]string{utils.RayClonedFromAnnotationKey: "production/" + source.Name}, cloned.Annotations)
+}
+
+func TestReconcileMetadataSync(t *testing.T) {
+	setupTest(t)
+
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Labels = map[string]string{"cost-center": "1234"}
+	cluster.Spec.MetadataSync = &rayv1.MetadataSync{Labels: []string{"cost-center"}}
+	headService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "head-svc",
+			Namespace: namespaceStr,
+			Labels:    map[string]string{utils.RayClusterLabelKey: instanceName},
+		},
+	}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(testPods[0], testPods[1], headService).Build()
+	ctx := context.Background()
+
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:   fakeClient,
+		Recorder: &record.FakeRecorder{},
+		Scheme:   newScheme,
+	}
+
+	err := testRayClusterReconciler.reconcileMetadataSync(ctx, cluster)
+	require.NoError(t, err)
+
+	pods := corev1.PodList{}
+	require.NoError(t, fakeClient.List(ctx, &pods, common.RayClusterAllPodsAssociationOptions(cluster).ToListOptions()...))
+	require.Len(t, pods.Items, 2)
+	for _, pod := range pods.Items {
+		assert.Equal(t, "1234", pod.Labels["cost-center"])
+		assert.Equal(t, instanceName, pod.Labels[utils.RayClusterLabelKey])
+	}
+	service := &corev1.Service{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(headService), service))
+	assert.Equal(t, "1234", service.Labels["cost-center"])
+}
+
+func TestCreateHeadPodDryRunDenied(t *testing.T) {
+	setupTest(t)
+	defer features.SetFeatureGateDuringTest(t, features.RayDryRunChildObjects, true)()
+
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	cluster := testRayCluster.DeepCopy()
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(cluster).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			// Simulate an admission webhook that denies the Pods without resource limits.
+			createOptions := &client.CreateOptions{}
+			createOptions.ApplyOptions(opts)
+			if _, ok := obj.(*corev1.Pod); ok && len(createOptions.DryRun) > 0 {
+				return k8serrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "",
+					errors.New(`admission webhook "validation.gatekeeper.sh" denied the request: container limits are required`))
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+	}).Build()
+	ctx := context.Background()
+	recorder := record.NewFakeRecorder(10)
+
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:                     fakeClient,
+		Recorder:                   recorder,
+		Scheme:                     newScheme,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+	}
+
+	err := testRayClusterReconciler.createHeadPod(ctx, *cluster)
+	denied := utils.GetAdmissionDeniedError(err)
+	require.NotNil(t, denied)
+	assert.Equal(t, rayv1.AdmissionRejected, denied.Reason)
+	assert.Equal(t, "Pod", denied.Kind)
+
+	// The Pod isn't created, and the denial is reported with an event.
+	podList := corev1.PodList{}
+	require.NoError(t, fakeClient.List(ctx, &podList, client.InNamespace(namespaceStr)))
+	assert.Empty(t, podList.Items)
+	require.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, string(utils.AdmissionDenied))
+
+	// The denied creation is retried later instead of with the backoff of errors.
+	result, err := requeueAdmissionDenied(ctrl.Result{}, errors.Join(utils.ErrFailedCreateHeadPod, err))
+	require.NoError(t, err)
+	assert.Equal(t, AdmissionDeniedRequeueDuration, result.RequeueAfter)
+}
+
+func TestFastDeleteRayCluster(t *testing.T) {
+	setupTest(t)
+
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	_ = batchv1.AddToScheme(newScheme)
+
+	cluster := testRayCluster.DeepCopy()
+	cluster.Finalizers = []string{utils.GCSFaultToleranceRedisCleanupFinalizer}
+	cluster.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:        namespaceStr,
+		Annotations: map[string]string{utils.RayFastDeletionAnnotationKey: "true"},
+	}}
+	var gracePeriodSeconds *int64
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(cluster, namespace, testPods[0], testPods[1]).WithInterceptorFuncs(interceptor.Funcs{
+		DeleteAllOf: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteAllOfOption) error {
+			deleteOptions := &client.DeleteAllOfOptions{}
+			deleteOptions.ApplyOptions(opts)
+			gracePeriodSeconds = deleteOptions.GracePeriodSeconds
+			return c.DeleteAllOf(ctx, obj, opts...)
+		},
+	}).Build()
+	ctx := context.Background()
+	recorder := record.NewFakeRecorder(10)
+
+	testRayClusterReconciler := &RayClusterReconciler{
+		Client:                     fakeClient,
+		Recorder:                   recorder,
+		Scheme:                     newScheme,
+		rayClusterScaleExpectation: expectations.NewRayClusterScaleExpectation(fakeClient),
+	}
+
+	// The Redis cleanup Job isn't created, and the RayCluster is deleted once its finalizer is removed.
+	_, err := testRayClusterReconciler.rayClusterReconcile(ctx, cluster)
+	require.NoError(t, err)
+	// The Pods are deleted without a grace period so that they aren't drained.
+	assert.Equal(t, ptr.To[int64](0), gracePeriodSeconds)
+	pods := corev1.PodList{}
+	require.NoError(t, fakeClient.List(ctx, &pods, client.InNamespace(namespaceStr)))
+	assert.Empty(t, pods.Items)
+	jobs := batchv1.JobList{}
+	require.NoError(t, fakeClient.List(ctx, &jobs, client.InNamespace(namespaceStr)))
+	assert.Empty(t, jobs.Items)
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(cluster), &rayv1.RayCluster{})
+	assert.True(t, k8serrors.IsNotFound(err))
+	assert.Contains(t, <-recorder.Events, string(utils.SkippedGracefulDeletion))
+}
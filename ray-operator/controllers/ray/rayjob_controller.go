@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"reflect"
+	"slices"
 	"strings"
 	"time"
 
@@ -17,14 +19,17 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
+	configapi "github.com/ray-project/kuberay/ray-operator/apis/config/v1alpha1"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/common"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 	"github.com/ray-project/kuberay/ray-operator/pkg/features"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
@@ -42,17 +47,32 @@ type RayJobReconciler struct {
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
 
-	dashboardClientFunc func() utils.RayDashboardClientInterface
+	dashboardClientFunc  func() utils.RayDashboardClientInterface
+	generatedPodSecurity *configapi.GeneratedPodSecurity
+	podSpecDefaults      *configapi.PodSpecDefaults
+	imageArchitectures   *configapi.ImageArchitecturePolicy
+	journal              *utils.JournalEventRecorder
+}
+
+type RayJobReconcilerOptions struct {
+	GeneratedPodSecurity *configapi.GeneratedPodSecurity
+	PodSpecDefaults      *configapi.PodSpecDefaults
+	ImageArchitectures   *configapi.ImageArchitecturePolicy
 }
 
 // NewRayJobReconciler returns a new reconcile.Reconciler
-func NewRayJobReconciler(_ context.Context, mgr manager.Manager, provider utils.ClientProvider) *RayJobReconciler {
+func NewRayJobReconciler(_ context.Context, mgr manager.Manager, options RayJobReconcilerOptions, provider utils.ClientProvider) *RayJobReconciler {
 	dashboardClientFunc := provider.GetDashboardClient(mgr)
+	journal := utils.NewJournalEventRecorder(mgr.GetEventRecorderFor("rayjob-controller"))
 	return &RayJobReconciler{
-		Client:              mgr.GetClient(),
-		Scheme:              mgr.GetScheme(),
-		Recorder:            mgr.GetEventRecorderFor("rayjob-controller"),
-		dashboardClientFunc: dashboardClientFunc,
+		Client:               mgr.GetClient(),
+		Scheme:               mgr.GetScheme(),
+		Recorder:             utils.NewDeduplicatingEventRecorder(journal, utils.DefaultEventDeduplicationWindow),
+		dashboardClientFunc:  dashboardClientFunc,
+		generatedPodSecurity: options.GeneratedPodSecurity,
+		podSpecDefaults:      options.PodSpecDefaults,
+		imageArchitectures:   options.ImageArchitectures,
+		journal:              journal,
 	}
 }
 
@@ -68,7 +88,7 @@ func NewRayJobReconciler(_ context.Context, mgr manager.Manager, provider utils.
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;create;update
 // +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;delete
 // +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=roles,verbs=get;list;watch;create;delete;update
-// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=get;list;watch;create;delete;update
 // +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete
 
 // [WARNING]: There MUST be a newline after kubebuilder markers.
@@ -77,6 +97,15 @@ func NewRayJobReconciler(_ context.Context, mgr manager.Manager, provider utils.
 // Automatically generate RBAC rules to allow the Controller to read and write workloads
 // Reconcile used to bridge the desired state with the current state
 func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	result, reconcileErr := r.rayJobReconcile(ctx, request)
+	if err := utils.RecordReconcileError(ctx, r.Client, request.NamespacedName, &rayv1.RayJob{}, reconcileErr); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Failed to record the reconcile error in the RayJob status")
+	}
+	return requeueAdmissionDenied(result, reconcileErr)
+}
+
+// rayJobReconcile reconciles the RayJob and returns the error to be recorded in its status.
+func (r *RayJobReconciler) rayJobReconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
 	logger := ctrl.LoggerFrom(ctx)
 
 	// Get RayJob instance
@@ -92,42 +121,62 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 		logger.Error(err, "Failed to get RayJob")
 		return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
 	}
+	ctx = utils.WithLogVerbosity(ctx, rayJobInstance)
+	logger = ctrl.LoggerFrom(ctx)
 
 	if manager := utils.ManagedByExternalController(rayJobInstance.Spec.ManagedBy); manager != nil {
 		logger.Info("Skipping RayJob managed by a custom controller", "managed-by", manager)
 		return ctrl.Result{}, nil
 	}
 
+	// The RayJobs being deleted are still reconciled, so that their finalizers don't block the deletion.
+	if rayJobInstance.ObjectMeta.DeletionTimestamp.IsZero() {
+		if selected, err := utils.IsNamespaceSelected(ctx, r.Client, rayJobInstance.Namespace); err != nil || !selected {
+			if err == nil {
+				logger.Info("Skipping RayJob in a namespace not selected by the watch namespace selector")
+			}
+			return ctrl.Result{}, err
+		}
+	}
+
 	if !rayJobInstance.ObjectMeta.DeletionTimestamp.IsZero() {
 		logger.Info("RayJob is being deleted", "DeletionTimestamp", rayJobInstance.ObjectMeta.DeletionTimestamp)
-		// If the JobStatus is not terminal, it is possible that the Ray job is still running. This includes
-		// the case where JobStatus is JobStatusNew.
-		if !rayv1.IsJobTerminal(rayJobInstance.Status.JobStatus) {
-			rayClusterNamespacedName := common.RayJobRayClusterNamespacedName(rayJobInstance)
-			rayClusterInstance := &rayv1.RayCluster{}
-			if err := r.Get(ctx, rayClusterNamespacedName, rayClusterInstance); err != nil {
-				logger.Error(err, "Failed to get RayCluster")
-			}
+		if controllerutil.ContainsFinalizer(rayJobInstance, utils.RayJobStopJobFinalizer) {
+			// If the JobStatus is not terminal, it is possible that the Ray job is still running. This includes
+			// the case where JobStatus is JobStatusNew.
+			// With fast deletion, the Ray job isn't stopped if it runs on the RayCluster of the RayJob, which is deleted.
+			if !rayv1.IsJobTerminal(rayJobInstance.Status.JobStatus) &&
+				(len(rayJobInstance.Spec.ClusterSelector) != 0 || !utils.IsFastDeletionEnabled(ctx, r.Client, rayJobInstance.Namespace)) {
+				rayClusterNamespacedName := common.RayJobRayClusterNamespacedName(rayJobInstance)
+				rayClusterInstance := &rayv1.RayCluster{}
+				if err := r.Get(ctx, rayClusterNamespacedName, rayClusterInstance); err != nil {
+					logger.Error(err, "Failed to get RayCluster")
+				}
 
-			rayDashboardClient := r.dashboardClientFunc()
-			err = rayDashboardClient.InitClient(ctx, rayJobInstance.Status.DashboardURL, rayClusterInstance)
-			if err != nil {
-				logger.Error(err, "Failed to initialize dashboard client")
+				rayDashboardClient := r.dashboardClientFunc()
+				err = rayDashboardClient.InitClient(ctx, rayJobInstance.Status.DashboardURL, rayClusterInstance)
+				if err != nil {
+					logger.Error(err, "Failed to initialize dashboard client")
+				}
+				err = rayDashboardClient.StopJob(ctx, rayJobInstance.Status.JobId)
+				if err != nil {
+					logger.Error(err, "Failed to stop job for RayJob")
+				}
 			}
-			err = rayDashboardClient.StopJob(ctx, rayJobInstance.Status.JobId)
+
+			logger.Info("Remove the finalizer no matter StopJob() succeeds or not.", "finalizer", utils.RayJobStopJobFinalizer)
+			controllerutil.RemoveFinalizer(rayJobInstance, utils.RayJobStopJobFinalizer)
+			err := r.Update(ctx, rayJobInstance)
 			if err != nil {
-				logger.Error(err, "Failed to stop job for RayJob")
+				logger.Error(err, "Failed to remove finalizer for RayJob")
+				return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
 			}
-		}
-
-		logger.Info("Remove the finalizer no matter StopJob() succeeds or not.", "finalizer", utils.RayJobStopJobFinalizer)
-		controllerutil.RemoveFinalizer(rayJobInstance, utils.RayJobStopJobFinalizer)
-		err := r.Update(ctx, rayJobInstance)
-		if err != nil {
-			logger.Error(err, "Failed to remove finalizer for RayJob")
 			return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
 		}
-		return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+		if controllerutil.ContainsFinalizer(rayJobInstance, utils.RayCleanupFinalizer) {
+			return r.cleanUpRayJob(ctx, rayJobInstance)
+		}
+		return ctrl.Result{}, nil
 	}
 
 	if err := validateRayJobSpec(rayJobInstance); err != nil {
@@ -150,9 +199,14 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 	logger.Info("RayJob", "JobStatus", rayJobInstance.Status.JobStatus, "JobDeploymentStatus", rayJobInstance.Status.JobDeploymentStatus, "SubmissionMode", rayJobInstance.Spec.SubmissionMode)
 	switch rayJobInstance.Status.JobDeploymentStatus {
 	case rayv1.JobDeploymentStatusNew:
-		if !controllerutil.ContainsFinalizer(rayJobInstance, utils.RayJobStopJobFinalizer) {
+		needsCleanupFinalizer := features.Enabled(features.RayCleanupFinalizer) && !controllerutil.ContainsFinalizer(rayJobInstance, utils.RayCleanupFinalizer)
+		if !controllerutil.ContainsFinalizer(rayJobInstance, utils.RayJobStopJobFinalizer) || needsCleanupFinalizer {
 			logger.Info("Add a finalizer", "finalizer", utils.RayJobStopJobFinalizer)
 			controllerutil.AddFinalizer(rayJobInstance, utils.RayJobStopJobFinalizer)
+			if needsCleanupFinalizer {
+				logger.Info("Add a finalizer", "finalizer", utils.RayCleanupFinalizer)
+				controllerutil.AddFinalizer(rayJobInstance, utils.RayCleanupFinalizer)
+			}
 			if err := r.Update(ctx, rayJobInstance); err != nil {
 				logger.Error(err, "Failed to update RayJob with finalizer")
 				return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
@@ -192,13 +246,39 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 			rayJobInstance.Status.DashboardURL = clientURL
 		}
 
+		if rayJobInstance.Spec.SubmissionMode == rayv1.InteractiveSessionMode {
+			var headServiceURL string
+			if headServiceURL, err = utils.FetchHeadServiceURL(ctx, r.Client, rayClusterInstance, utils.ClientPortName); err != nil || headServiceURL == "" {
+				logger.Error(err, "Failed to get the Ray Client URL after the RayCluster is ready!", "RayCluster", rayClusterInstance.Name)
+				return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+			}
+			logger.Info("SubmissionMode is InteractiveSession and the RayCluster is ready. Transition the status from `Initializing` to `Running`.")
+			rayJobInstance.Status.Session = &rayv1.InteractiveSessionStatus{
+				ClientURL:        "ray://" + headServiceURL,
+				LastActivityTime: &metav1.Time{Time: time.Now()},
+			}
+			rayJobInstance.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusRunning
+			break
+		}
+
 		if rayJobInstance.Spec.SubmissionMode == rayv1.InteractiveMode {
 			logger.Info("SubmissionMode is InteractiveMode and the RayCluster is created. Transition the status from `Initializing` to `Waiting`.")
 			rayJobInstance.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusWaiting
 			break
 		}
 
-		if rayJobInstance.Spec.SubmissionMode == rayv1.K8sJobMode {
+		if rayJobInstance.Spec.Checkpointing != nil {
+			succeeded, err := r.checkSucceededJobAndUpdateStatusIfNeeded(ctx, rayJobInstance, rayClusterInstance)
+			if err != nil {
+				return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+			}
+			if succeeded {
+				break
+			}
+		}
+
+		// When the submitter Pod is reused, the Ray job is submitted through the dashboard in the `Running` status.
+		if rayJobInstance.Spec.SubmissionMode == rayv1.K8sJobMode && !common.IsSubmitterPodReused(rayJobInstance) {
 			if err := r.createK8sJobIfNeed(ctx, rayJobInstance, rayClusterInstance); err != nil {
 				return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
 			}
@@ -224,8 +304,15 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 			break
 		}
 
+		if rayJobInstance.Spec.SubmissionMode == rayv1.InteractiveSessionMode {
+			if err := r.reconcileInteractiveSession(ctx, rayJobInstance); err != nil {
+				return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+			}
+			break
+		}
+
 		job := &batchv1.Job{}
-		if rayJobInstance.Spec.SubmissionMode == rayv1.K8sJobMode {
+		if rayJobInstance.Spec.SubmissionMode == rayv1.K8sJobMode && !common.IsSubmitterPodReused(rayJobInstance) {
 			// If the submitting Kubernetes Job reaches the backoff limit, transition the status to `Complete` or `Failed`.
 			// This is because, beyond this point, it becomes impossible for the submitter to submit any further Ray jobs.
 			// For light-weight mode, we don't transition the status to `Complete` or `Failed` based on the number of failed
@@ -257,7 +344,7 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 		jobInfo, err := rayDashboardClient.GetJobInfo(ctx, rayJobInstance.Status.JobId)
 		if err != nil {
 			// If the Ray job was not found, GetJobInfo returns a BadRequest error.
-			if rayJobInstance.Spec.SubmissionMode == rayv1.HTTPMode && errors.IsBadRequest(err) {
+			if (rayJobInstance.Spec.SubmissionMode == rayv1.HTTPMode || common.IsSubmitterPodReused(rayJobInstance)) && errors.IsBadRequest(err) {
 				logger.Info("The Ray job was not found. Submit a Ray job via an HTTP request.", "JobId", rayJobInstance.Status.JobId)
 				if _, err := rayDashboardClient.SubmitJob(ctx, rayJobInstance); err != nil {
 					logger.Error(err, "Failed to submit the Ray job", "JobId", rayJobInstance.Status.JobId)
@@ -278,7 +365,7 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 		isJobTerminal := rayv1.IsJobTerminal(jobInfo.JobStatus)
 		// If in K8sJobMode, further refine the terminal condition by checking if the submitter Job has finished.
 		// See https://github.com/ray-project/kuberay/pull/1919 for reasons.
-		if rayJobInstance.Spec.SubmissionMode == rayv1.K8sJobMode {
+		if rayJobInstance.Spec.SubmissionMode == rayv1.K8sJobMode && !common.IsSubmitterPodReused(rayJobInstance) {
 			_, finished := utils.IsJobFinished(job)
 			isJobTerminal = isJobTerminal && finished
 		}
@@ -327,6 +414,7 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 		rayJobInstance.Status.JobId = ""
 		rayJobInstance.Status.Message = ""
 		rayJobInstance.Status.Reason = ""
+		rayJobInstance.Status.Session = nil
 		// Reset the JobStatus to JobStatusNew and transition the JobDeploymentStatus to `Suspended`.
 		rayJobInstance.Status.JobStatus = rayv1.JobStatusNew
 
@@ -413,6 +501,20 @@ func (r *RayJobReconciler) Reconcile(ctx context.Context, request ctrl.Request)
 		logger.Info("Unknown JobDeploymentStatus", "JobDeploymentStatus", rayJobInstance.Status.JobDeploymentStatus)
 		return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, nil
 	}
+	// The RayCluster selected by a clusterSelector is shared, so its usage isn't accounted to the RayJob.
+	if features.Enabled(features.RayResourceUsageAccounting) && len(rayJobInstance.Spec.ClusterSelector) == 0 && rayJobInstance.Status.RayClusterName != "" {
+		// The RayJob only reaches this point in the Complete and Failed statuses when it transitions to them. The Pods
+		// are sampled then regardless of the last sample, so that the reported usage is complete.
+		isCompleted := rayJobInstance.Status.JobDeploymentStatus == rayv1.JobDeploymentStatusComplete || rayJobInstance.Status.JobDeploymentStatus == rayv1.JobDeploymentStatusFailed
+		rayClusters := []client.ObjectKey{common.RayJobRayClusterNamespacedName(rayJobInstance)}
+		if rayJobInstance.Status.ResourceUsage, err = sampleResourceUsage(ctx, r.Client, rayJobInstance.Status.ResourceUsage, rayClusters, time.Now(), isCompleted); err != nil {
+			return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+		}
+		if isCompleted {
+			r.Recorder.Eventf(rayJobInstance, corev1.EventTypeNormal, string(utils.ReportedResourceUsage),
+				"The RayJob used %s CPU-hours and %s GPU-hours", rayJobInstance.Status.ResourceUsage.CPUHours, rayJobInstance.Status.ResourceUsage.GPUHours)
+		}
+	}
 	checkBackoffLimitAndUpdateStatusIfNeeded(ctx, rayJobInstance)
 
 	// This is the only place where we update the RayJob status. Please do NOT add any code
@@ -466,6 +568,61 @@ func checkBackoffLimitAndUpdateStatusIfNeeded(ctx context.Context, rayJob *rayv1
 	}
 }
 
+// reconcileInteractiveSession refreshes the status of the RayCluster of a RayJob in the InteractiveSession mode from
+// the Ray jobs the user submitted to it, and completes the RayJob once the session has been idle for too long.
+func (r *RayJobReconciler) reconcileInteractiveSession(ctx context.Context, rayJobInstance *rayv1.RayJob) error {
+	rayClusterInstance, err := r.getOrCreateRayClusterInstance(ctx, rayJobInstance)
+	if err != nil {
+		return err
+	}
+	rayDashboardClient := r.dashboardClientFunc()
+	if err := rayDashboardClient.InitClient(ctx, rayJobInstance.Status.DashboardURL, rayClusterInstance); err != nil {
+		return err
+	}
+	jobs, err := rayDashboardClient.ListJobs(ctx)
+	if err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Failed to list the Ray jobs of the interactive session")
+		return err
+	}
+	var jobInfos []utils.RayJobInfo
+	if jobs != nil {
+		jobInfos = *jobs
+	}
+	rayJobInstance.Status.RayClusterStatus = rayClusterInstance.Status
+	checkSessionIdleAndUpdateStatusIfNeeded(ctx, rayJobInstance, jobInfos, time.Now())
+	return nil
+}
+
+// checkSucceededJobAndUpdateStatusIfNeeded looks up the Ray job by its submission ID before submitting it, and
+// transitions the status to `Complete` if it has already succeeded, e.g. if the operator restarted after the Ray job
+// finished but before the status was updated, so that a RayJob with checkpointing enabled never runs twice.
+func (r *RayJobReconciler) checkSucceededJobAndUpdateStatusIfNeeded(ctx context.Context, rayJobInstance *rayv1.RayJob, rayClusterInstance *rayv1.RayCluster) (bool, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	rayDashboardClient := r.dashboardClientFunc()
+	if err := rayDashboardClient.InitClient(ctx, rayJobInstance.Status.DashboardURL, rayClusterInstance); err != nil {
+		return false, err
+	}
+	jobInfo, err := rayDashboardClient.GetJobInfo(ctx, rayJobInstance.Status.JobId)
+	if err != nil {
+		// If the Ray job was not found, GetJobInfo returns a BadRequest error.
+		if errors.IsBadRequest(err) {
+			return false, nil
+		}
+		logger.Error(err, "Failed to get job info", "JobId", rayJobInstance.Status.JobId)
+		return false, err
+	}
+	if jobInfo.JobStatus != rayv1.JobStatusSucceeded {
+		return false, nil
+	}
+
+	logger.Info("The Ray job has already succeeded. Transition the status from `Initializing` to `Complete` without resubmitting it.", "JobId", rayJobInstance.Status.JobId)
+	rayJobInstance.Status.RayClusterStatus = rayClusterInstance.Status
+	rayJobInstance.Status.JobStatus = jobInfo.JobStatus
+	rayJobInstance.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusComplete
+	rayJobInstance.Status.Message = jobInfo.Message
+	return true, nil
+}
+
 // createK8sJobIfNeed creates a Kubernetes Job for the RayJob if it doesn't exist.
 func (r *RayJobReconciler) createK8sJobIfNeed(ctx context.Context, rayJobInstance *rayv1.RayJob, rayClusterInstance *rayv1.RayCluster) error {
 	logger := ctrl.LoggerFrom(ctx)
@@ -473,10 +630,13 @@ func (r *RayJobReconciler) createK8sJobIfNeed(ctx context.Context, rayJobInstanc
 	namespacedName := common.RayJobK8sJobNamespacedName(rayJobInstance)
 	if err := r.Client.Get(ctx, namespacedName, job); err != nil {
 		if errors.IsNotFound(err) {
-			submitterTemplate, err := getSubmitterTemplate(ctx, rayJobInstance, rayClusterInstance)
+			submitterTemplate, err := getSubmitterTemplate(ctx, rayJobInstance, rayClusterInstance, r.generatedPodSecurity, r.podSpecDefaults)
 			if err != nil {
 				return err
 			}
+			if err := common.ApplyImageArchitectureAffinity(&submitterTemplate.Spec, r.imageArchitectures); err != nil {
+				logger.Error(err, "Failed to require nodes of the architecture of the images of the submitter Pod")
+			}
 			return r.createNewK8sJob(ctx, rayJobInstance, submitterTemplate)
 		}
 		return err
@@ -486,35 +646,61 @@ func (r *RayJobReconciler) createK8sJobIfNeed(ctx context.Context, rayJobInstanc
 	return nil
 }
 
-// getSubmitterTemplate builds the submitter pod template for the Ray job.
-func getSubmitterTemplate(ctx context.Context, rayJobInstance *rayv1.RayJob, rayClusterInstance *rayv1.RayCluster) (corev1.PodTemplateSpec, error) {
+// getBaseSubmitterTemplate builds the submitter pod template for the Ray job without the parts specific to a Ray job
+// submission, i.e. the submission command and environment variables.
+func getBaseSubmitterTemplate(ctx context.Context, rayJobInstance *rayv1.RayJob, rayClusterInstance *rayv1.RayCluster, generatedPodSecurity *configapi.GeneratedPodSecurity, podSpecDefaults *configapi.PodSpecDefaults) corev1.PodTemplateSpec {
 	logger := ctrl.LoggerFrom(ctx)
 	var submitterTemplate corev1.PodTemplateSpec
 
 	// Set the default value for the optional field SubmitterPodTemplate if not provided.
 	if rayJobInstance.Spec.SubmitterPodTemplate == nil {
 		submitterTemplate = common.GetDefaultSubmitterTemplate(rayClusterInstance)
+		common.ApplyGeneratedPodSecurityToSubmitter(&submitterTemplate, generatedPodSecurity)
 		logger.Info("default submitter template is used")
 	} else {
 		submitterTemplate = *rayJobInstance.Spec.SubmitterPodTemplate.DeepCopy()
-		logger.Info("user-provided submitter template is used; the first container is assumed to be the submitter")
+		logger.Info("user-provided submitter template is used")
+		// Use the image of the Ray head to be defensive against version mismatch issues, as in the default template.
+		submitter := &submitterTemplate.Spec.Containers[common.GetSubmitterContainerIndex(submitterTemplate.Spec)]
+		if submitter.Image == "" && rayClusterInstance != nil {
+			submitter.Image = rayClusterInstance.Spec.HeadGroupSpec.Template.Spec.Containers[utils.RayContainerIndex].Image
+		}
+	}
+	common.ApplyPodSpecDefaults(&submitterTemplate.Spec, podSpecDefaults, false)
+	if rayClusterInstance != nil {
+		common.ConfigureServiceMeshSubmitter(&submitterTemplate, rayClusterInstance.Spec.ServiceMeshOptions)
+	}
+	common.AddImagePullSecrets(&submitterTemplate.Spec, rayJobInstance.Spec.ImagePullSecrets)
+	return submitterTemplate
+}
+
+// getSubmitterTemplate builds the submitter pod template for the Ray job.
+func getSubmitterTemplate(ctx context.Context, rayJobInstance *rayv1.RayJob, rayClusterInstance *rayv1.RayCluster, generatedPodSecurity *configapi.GeneratedPodSecurity, podSpecDefaults *configapi.PodSpecDefaults) (corev1.PodTemplateSpec, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	var serviceMeshOptions *rayv1.ServiceMeshOptions
+	if rayClusterInstance != nil {
+		serviceMeshOptions = rayClusterInstance.Spec.ServiceMeshOptions
 	}
+	submitterTemplate := getBaseSubmitterTemplate(ctx, rayJobInstance, rayClusterInstance, generatedPodSecurity, podSpecDefaults)
+	submitter := &submitterTemplate.Spec.Containers[common.GetSubmitterContainerIndex(submitterTemplate.Spec)]
 
 	// If the command in the submitter pod template isn't set, use the default command.
-	if len(submitterTemplate.Spec.Containers[utils.RayContainerIndex].Command) == 0 {
+	if len(submitter.Command) == 0 {
 		k8sJobCommand, err := common.GetK8sJobCommand(rayJobInstance)
 		if err != nil {
 			return corev1.PodTemplateSpec{}, err
 		}
-		submitterTemplate.Spec.Containers[utils.RayContainerIndex].Command = []string{"/bin/sh"}
-		submitterTemplate.Spec.Containers[utils.RayContainerIndex].Args = []string{"-c", strings.Join(k8sJobCommand, " ")}
+		// Stop the service mesh sidecar when the submitter exits, otherwise the Kubernetes Job never completes.
+		k8sJobCommand = append(k8sJobCommand, common.GetServiceMeshShutdownCommand(serviceMeshOptions)...)
+		submitter.Command = []string{"/bin/sh"}
+		submitter.Args = []string{"-c", strings.Join(k8sJobCommand, " ")}
 		logger.Info("No command is specified in the user-provided template. Default command is used", "command", k8sJobCommand)
 	} else {
-		logger.Info("User-provided command is used", "command", submitterTemplate.Spec.Containers[utils.RayContainerIndex].Command)
+		logger.Info("User-provided command is used", "command", submitter.Command)
 	}
 
 	// Set PYTHONUNBUFFERED=1 for real-time logging
-	submitterTemplate.Spec.Containers[utils.RayContainerIndex].Env = append(submitterTemplate.Spec.Containers[utils.RayContainerIndex].Env, corev1.EnvVar{
+	submitter.Env = append(submitter.Env, corev1.EnvVar{
 		Name:  PythonUnbufferedEnvVarName,
 		Value: "1",
 	})
@@ -522,11 +708,11 @@ func getSubmitterTemplate(ctx context.Context, rayJobInstance *rayv1.RayJob, ray
 	// Users can use `RAY_DASHBOARD_ADDRESS` to specify the dashboard address and `RAY_JOB_SUBMISSION_ID` to specify the job id to avoid
 	// double submission in the `ray job submit` command. For example:
 	// ray job submit --address=http://$RAY_DASHBOARD_ADDRESS --submission-id=$RAY_JOB_SUBMISSION_ID ...
-	submitterTemplate.Spec.Containers[utils.RayContainerIndex].Env = append(submitterTemplate.Spec.Containers[utils.RayContainerIndex].Env, corev1.EnvVar{
+	submitter.Env = append(submitter.Env, corev1.EnvVar{
 		Name:  utils.RAY_DASHBOARD_ADDRESS,
 		Value: rayJobInstance.Status.DashboardURL,
 	})
-	submitterTemplate.Spec.Containers[utils.RayContainerIndex].Env = append(submitterTemplate.Spec.Containers[utils.RayContainerIndex].Env, corev1.EnvVar{
+	submitter.Env = append(submitter.Env, corev1.EnvVar{
 		Name:  utils.RAY_JOB_SUBMISSION_ID,
 		Value: rayJobInstance.Status.JobId,
 	})
@@ -580,7 +766,7 @@ func (r *RayJobReconciler) createNewK8sJob(ctx context.Context, rayJobInstance *
 // deleteSubmitterJob deletes the submitter Job associated with the RayJob.
 func (r *RayJobReconciler) deleteSubmitterJob(ctx context.Context, rayJobInstance *rayv1.RayJob) (bool, error) {
 	logger := ctrl.LoggerFrom(ctx)
-	if rayJobInstance.Spec.SubmissionMode == rayv1.HTTPMode {
+	if rayJobInstance.Spec.SubmissionMode == rayv1.HTTPMode || common.IsSubmitterPodReused(rayJobInstance) {
 		return true, nil
 	}
 	var isJobDeleted bool
@@ -674,13 +860,59 @@ func (r *RayJobReconciler) suspendWorkerGroups(ctx context.Context, rayJobInstan
 	return nil
 }
 
+// cleanUpRayJob deletes the RayCluster and the submitter Job created for the RayJob, which is being deleted, and removes
+// the cleanup finalizer once they are gone or the cleanup timed out. The RayCluster is waited for so that its own
+// finalizers, e.g. the Redis cleanup of GCS fault tolerance, complete first, unless fast deletion is enabled.
+func (r *RayJobReconciler) cleanUpRayJob(ctx context.Context, rayJobInstance *rayv1.RayJob) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	logger.Info("The RayJob is being deleted. Clean up the objects created for it.", "finalizer", utils.RayCleanupFinalizer)
+
+	objects := []client.Object{&batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+		Name:      common.RayJobK8sJobNamespacedName(rayJobInstance).Name,
+		Namespace: rayJobInstance.Namespace,
+	}}}
+	// The RayCluster selected with the clusterSelector isn't controlled by the RayJob, so it's skipped.
+	if rayJobInstance.Status.RayClusterName != "" {
+		objects = append(objects, &rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{
+			Name:      rayJobInstance.Status.RayClusterName,
+			Namespace: rayJobInstance.Namespace,
+		}})
+	}
+
+	originalConditions := slices.Clone(rayJobInstance.Status.Conditions)
+	done, err := cleanUpControlledObjects(ctx, r.Client, r.Recorder, rayJobInstance, &rayJobInstance.Status.Conditions, string(rayv1.RayJobCleanupSucceeded), objects...)
+	if err != nil {
+		return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+	}
+	if !reflect.DeepEqual(originalConditions, rayJobInstance.Status.Conditions) {
+		if err := r.updateStatus(ctx, rayJobInstance); err != nil {
+			return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+		}
+	}
+	if !done && !utils.IsFastDeletionEnabled(ctx, r.Client, rayJobInstance.Namespace) {
+		return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, nil
+	}
+
+	logger.Info("Remove the finalizer", "finalizer", utils.RayCleanupFinalizer)
+	controllerutil.RemoveFinalizer(rayJobInstance, utils.RayCleanupFinalizer)
+	if err := r.Update(ctx, rayJobInstance); err != nil {
+		return ctrl.Result{RequeueAfter: RayJobDefaultRequeueDuration}, err
+	}
+	return ctrl.Result{}, nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *RayJobReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcurrency int) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&rayv1.RayJob{}).
 		Owns(&rayv1.RayCluster{}).
 		Owns(&corev1.Service{}).
-		Owns(&batchv1.Job{}).
+		Owns(&batchv1.Job{})
+	if utils.IsWatchNamespaceSelectorSet() {
+		b = b.Watches(&corev1.Namespace{}, utils.EnqueueObjectsInNamespace(mgr.GetClient(), &rayv1.RayJobList{}),
+			builder.WithPredicates(predicate.LabelChangedPredicate{}))
+	}
+	return b.
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: reconcileConcurrency,
 			LogConstructor: func(request *reconcile.Request) logr.Logger {
@@ -694,10 +926,17 @@ func (r *RayJobReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcurren
 		Complete(r)
 }
 
+// updateStatus updates the status of the RayJob. The update isn't interrupted when the operator shuts down.
+func (r *RayJobReconciler) updateStatus(ctx context.Context, rayJobInstance *rayv1.RayJob) error {
+	ctx, cancel := utils.WithoutShutdownCancel(ctx)
+	defer cancel()
+	return r.Status().Update(ctx, rayJobInstance)
+}
+
 // This function is the sole place where `JobDeploymentStatusInitializing` is defined. It initializes `Status.JobId` and `Status.RayClusterName`
 // prior to job submissions and RayCluster creations. This is used to avoid duplicate job submissions and cluster creations. In addition, this
 // function also sets `Status.StartTime` to support `ActiveDeadlineSeconds`.
-// This function will set or generate JobId if SubmissionMode is not InteractiveMode.
+// This function will set or generate JobId if SubmissionMode is neither InteractiveMode nor InteractiveSession.
 func initRayJobStatusIfNeed(ctx context.Context, rayJob *rayv1.RayJob) error {
 	logger := ctrl.LoggerFrom(ctx)
 	shouldUpdateStatus := rayJob.Status.JobId == "" || rayJob.Status.RayClusterName == "" || rayJob.Status.JobStatus == ""
@@ -707,7 +946,7 @@ func initRayJobStatusIfNeed(ctx context.Context, rayJob *rayv1.RayJob) error {
 		return nil
 	}
 
-	if rayJob.Spec.SubmissionMode != rayv1.InteractiveMode && rayJob.Status.JobId == "" {
+	if rayJob.Spec.SubmissionMode != rayv1.InteractiveMode && rayJob.Spec.SubmissionMode != rayv1.InteractiveSessionMode && rayJob.Status.JobId == "" {
 		if rayJob.Spec.JobId != "" {
 			rayJob.Status.JobId = rayJob.Spec.JobId
 		} else {
@@ -733,6 +972,12 @@ func initRayJobStatusIfNeed(ctx context.Context, rayJob *rayv1.RayJob) error {
 	if rayJob.Status.JobStatus == "" {
 		rayJob.Status.JobStatus = rayv1.JobStatusNew
 	}
+	// The retries of a RayJob with checkpointing enabled resume from the checkpoints of the failed attempts.
+	rayJob.Status.ResumedFromCheckpointURI = ""
+	if checkpointing := rayJob.Spec.Checkpointing; checkpointing != nil && checkpointing.ResumePolicy != rayv1.NeverResumeFromCheckpoint &&
+		rayJob.Status.Failed != nil && *rayJob.Status.Failed > 0 {
+		rayJob.Status.ResumedFromCheckpointURI = checkpointing.URI
+	}
 	rayJob.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusInitializing
 	rayJob.Status.StartTime = &metav1.Time{Time: time.Now()}
 	return nil
@@ -743,18 +988,25 @@ func (r *RayJobReconciler) updateRayJobStatus(ctx context.Context, oldRayJob *ra
 	oldRayJobStatus := oldRayJob.Status
 	newRayJobStatus := newRayJob.Status
 	logger.Info("updateRayJobStatus", "oldRayJobStatus", oldRayJobStatus, "newRayJobStatus", newRayJobStatus)
+	newRayJob.Status.Journal = r.journal.MergeJournal(newRayJob.UID, newRayJob.Status.Journal)
 	// If a status field is crucial for the RayJob state machine, it MUST be
 	// updated with a distinct JobStatus or JobDeploymentStatus value.
-	if oldRayJobStatus.JobStatus != newRayJobStatus.JobStatus ||
-		oldRayJobStatus.JobDeploymentStatus != newRayJobStatus.JobDeploymentStatus {
-
+	stateChanged := oldRayJobStatus.JobStatus != newRayJobStatus.JobStatus ||
+		oldRayJobStatus.JobDeploymentStatus != newRayJobStatus.JobDeploymentStatus
+	if stateChanged {
 		if newRayJobStatus.JobDeploymentStatus == rayv1.JobDeploymentStatusComplete || newRayJobStatus.JobDeploymentStatus == rayv1.JobDeploymentStatusFailed {
 			newRayJob.Status.EndTime = &metav1.Time{Time: time.Now()}
 		}
 
 		logger.Info("updateRayJobStatus", "old JobStatus", oldRayJobStatus.JobStatus, "new JobStatus", newRayJobStatus.JobStatus,
 			"old JobDeploymentStatus", oldRayJobStatus.JobDeploymentStatus, "new JobDeploymentStatus", newRayJobStatus.JobDeploymentStatus)
-		if err := r.Status().Update(ctx, newRayJob); err != nil {
+	}
+	// The journal, the interactive session and the resource usage aren't part of the state machine, so they're updated
+	// with the other fields when they change.
+	if stateChanged || !reflect.DeepEqual(oldRayJobStatus.Journal, newRayJob.Status.Journal) ||
+		!reflect.DeepEqual(oldRayJobStatus.Session, newRayJobStatus.Session) ||
+		!reflect.DeepEqual(oldRayJobStatus.ResourceUsage, newRayJobStatus.ResourceUsage) {
+		if err := r.updateStatus(ctx, newRayJob); err != nil {
 			return err
 		}
 	}
@@ -780,6 +1032,9 @@ func (r *RayJobReconciler) getOrCreateRayClusterInstance(ctx context.Context, ra
 			if err != nil {
 				return nil, err
 			}
+			if err := dryRunCreate(ctx, r.Client, r.Recorder, rayJobInstance, rayClusterInstance); err != nil {
+				return nil, err
+			}
 			if err := r.Create(ctx, rayClusterInstance); err != nil {
 				r.Recorder.Eventf(rayJobInstance, corev1.EventTypeWarning, string(utils.FailedToCreateRayCluster), "Failed to create RayCluster %s/%s: %v", rayClusterInstance.Namespace, rayClusterInstance.Name, err)
 				return nil, err
@@ -793,7 +1048,7 @@ func (r *RayJobReconciler) getOrCreateRayClusterInstance(ctx context.Context, ra
 
 	// Verify that RayJob is not in cluster selector mode first to avoid nil pointer dereference error during spec comparison.
 	// This is checked by ensuring len(rayJobInstance.Spec.ClusterSelector) equals 0.
-	if len(rayJobInstance.Spec.ClusterSelector) == 0 && !utils.CompareJsonStruct(rayClusterInstance.Spec, *rayJobInstance.Spec.RayClusterSpec) {
+	if len(rayJobInstance.Spec.ClusterSelector) == 0 && !utils.CompareJsonStruct(rayClusterInstance.Spec, rayClusterSpecForRayJob(rayJobInstance)) {
 		logger.Info("Disregard changes in RayClusterSpec of RayJob")
 	}
 
@@ -814,7 +1069,7 @@ func (r *RayJobReconciler) constructRayClusterForRayJob(rayJobInstance *rayv1.Ra
 			Name:        rayClusterName,
 			Namespace:   rayJobInstance.Namespace,
 		},
-		Spec: *rayJobInstance.Spec.RayClusterSpec.DeepCopy(),
+		Spec: rayClusterSpecForRayJob(rayJobInstance),
 	}
 
 	// Set the ownership in order to do the garbage collection by k8s.
@@ -825,6 +1080,13 @@ func (r *RayJobReconciler) constructRayClusterForRayJob(rayJobInstance *rayv1.Ra
 	return rayCluster, nil
 }
 
+// rayClusterSpecForRayJob returns the spec of the RayCluster created for the RayJob.
+func rayClusterSpecForRayJob(rayJobInstance *rayv1.RayJob) rayv1.RayClusterSpec {
+	spec := rayJobInstance.Spec.RayClusterSpec.DeepCopy()
+	common.AddImagePullSecretsToRayClusterSpec(spec, rayJobInstance.Spec.ImagePullSecrets)
+	return *spec
+}
+
 func updateStatusToSuspendingIfNeeded(ctx context.Context, rayJob *rayv1.RayJob) bool {
 	logger := ctrl.LoggerFrom(ctx)
 	if !rayJob.Spec.Suspend {
@@ -878,6 +1140,51 @@ func checkActiveDeadlineAndUpdateStatusIfNeeded(ctx context.Context, rayJob *ray
 	return true
 }
 
+// checkSessionIdleAndUpdateStatusIfNeeded moves the last activity time of the interactive session to the end of its
+// last Ray job, and transitions the status to `Complete` if no Ray job has run for longer than the idle timeout.
+func checkSessionIdleAndUpdateStatusIfNeeded(ctx context.Context, rayJob *rayv1.RayJob, jobs []utils.RayJobInfo, now time.Time) bool {
+	logger := ctrl.LoggerFrom(ctx)
+	session := rayJob.Status.Session
+	if session == nil {
+		session = &rayv1.InteractiveSessionStatus{}
+		rayJob.Status.Session = session
+	}
+	if session.LastActivityTime == nil {
+		session.LastActivityTime = &metav1.Time{Time: now}
+	}
+
+	lastActivityTime := session.LastActivityTime.Time
+	for _, job := range jobs {
+		if !rayv1.IsJobTerminal(job.JobStatus) {
+			// The session isn't idle while a Ray job runs, so there's no need to track the time.
+			return false
+		}
+		// The dashboard reports the end time in milliseconds since the epoch.
+		if endTime := time.UnixMilli(int64(job.EndTime)); endTime.After(lastActivityTime) {
+			lastActivityTime = endTime
+		}
+	}
+	if lastActivityTime.After(session.LastActivityTime.Time) {
+		session.LastActivityTime = &metav1.Time{Time: lastActivityTime}
+	}
+
+	options := rayJob.Spec.InteractiveSession
+	if options == nil || options.IdleTimeoutSeconds == nil {
+		return false
+	}
+	idleTimeout := time.Duration(*options.IdleTimeoutSeconds) * time.Second
+	if now.Before(lastActivityTime.Add(idleTimeout)) {
+		return false
+	}
+
+	logger.Info("The interactive session has been idle for longer than the idle timeout. Transition the status to `Complete`.",
+		"LastActivityTime", session.LastActivityTime, "IdleTimeoutSeconds", *options.IdleTimeoutSeconds)
+	rayJob.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusComplete
+	rayJob.Status.Message = fmt.Sprintf("The interactive session has been idle since %v for longer than the idle timeout of %d seconds",
+		session.LastActivityTime, *options.IdleTimeoutSeconds)
+	return true
+}
+
 func validateRayJobSpec(rayJob *rayv1.RayJob) error {
 	// KubeRay has some limitations for the suspend operation. The limitations are a subset of the limitations of
 	// Kueue (https://kueue.sigs.k8s.io/docs/tasks/run_rayjobs/#c-limitations). For example, KubeRay allows users
@@ -904,6 +1211,41 @@ func validateRayJobSpec(rayJob *rayv1.RayJob) error {
 	if rayJob.Spec.BackoffLimit != nil && *rayJob.Spec.BackoffLimit < 0 {
 		return fmt.Errorf("backoffLimit must be a positive integer")
 	}
+	if rayJob.Spec.SubmitterConfig != nil && rayJob.Spec.SubmitterConfig.ReuseSubmitterPod != nil && *rayJob.Spec.SubmitterConfig.ReuseSubmitterPod &&
+		rayJob.Spec.SubmissionMode != rayv1.K8sJobMode {
+		return fmt.Errorf("reuseSubmitterPod is only supported in K8sJobMode")
+	}
+	if rayJob.Spec.Checkpointing != nil {
+		if rayJob.Spec.SubmissionMode == rayv1.InteractiveMode || rayJob.Spec.SubmissionMode == rayv1.InteractiveSessionMode {
+			return fmt.Errorf("checkpointing isn't supported in the %s submission mode", rayJob.Spec.SubmissionMode)
+		}
+		if rayJob.Spec.Checkpointing.URI == "" {
+			return fmt.Errorf("checkpointing.uri must be set")
+		}
+		if _, err := utils.GetRayJobRuntimeEnv(rayJob); err != nil {
+			return err
+		}
+	}
+	if rayJob.Spec.SubmissionMode == rayv1.InteractiveSessionMode && rayJob.Spec.Entrypoint != "" {
+		return fmt.Errorf("entrypoint must not be set in the InteractiveSession submission mode")
+	}
+	if rayJob.Spec.InteractiveSession != nil {
+		if rayJob.Spec.SubmissionMode != rayv1.InteractiveSessionMode {
+			return fmt.Errorf("interactiveSession is only supported in the InteractiveSession submission mode")
+		}
+		if timeout := rayJob.Spec.InteractiveSession.IdleTimeoutSeconds; timeout != nil && *timeout <= 0 {
+			return fmt.Errorf("interactiveSession.idleTimeoutSeconds must be a positive integer")
+		}
+	}
+	if rayJob.Spec.RayClusterSpec != nil {
+		var requiredFeatures []rayv1.RayVersionFeature
+		if len(rayJob.Spec.Metadata) > 0 {
+			requiredFeatures = append(requiredFeatures, rayv1.JobMetadataRayVersionFeature)
+		}
+		if err := rayv1.CheckRayVersionCompatibility(rayJob.Spec.RayClusterSpec, requiredFeatures...); err != nil {
+			return err
+		}
+	}
 	if !features.Enabled(features.RayJobDeletionPolicy) && rayJob.Spec.DeletionPolicy != nil {
 		return fmt.Errorf("RayJobDeletionPolicy feature gate must be enabled to use the DeletionPolicy feature")
 	}
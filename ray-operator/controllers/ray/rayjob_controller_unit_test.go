@@ -5,20 +5,27 @@ import (
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	clientFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
+	configapi "github.com/ray-project/kuberay/ray-operator/apis/config/v1alpha1"
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/common"
 	utils "github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 	"github.com/ray-project/kuberay/ray-operator/pkg/client/clientset/versioned/scheme"
 	"github.com/ray-project/kuberay/ray-operator/pkg/features"
@@ -158,26 +165,26 @@ func TestGetSubmitterTemplate(t *testing.T) {
 	ctx := context.Background()
 
 	// Test 1: User provided template with command
-	submitterTemplate, err := getSubmitterTemplate(ctx, rayJobInstanceWithTemplate, nil)
+	submitterTemplate, err := getSubmitterTemplate(ctx, rayJobInstanceWithTemplate, nil, nil, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, "user-command", submitterTemplate.Spec.Containers[utils.RayContainerIndex].Command[0])
 
 	// Test 2: User provided template without command
 	rayJobInstanceWithTemplate.Spec.SubmitterPodTemplate.Spec.Containers[utils.RayContainerIndex].Command = []string{}
-	submitterTemplate, err = getSubmitterTemplate(ctx, rayJobInstanceWithTemplate, nil)
+	submitterTemplate, err = getSubmitterTemplate(ctx, rayJobInstanceWithTemplate, nil, nil, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, []string{"/bin/sh"}, submitterTemplate.Spec.Containers[utils.RayContainerIndex].Command)
 	assert.Equal(t, []string{"-c", "if ray job status --address http://test-url test-job-id >/dev/null 2>&1 ; then ray job logs --address http://test-url --follow test-job-id ; else ray job submit --address http://test-url --submission-id test-job-id -- echo hello world ; fi"}, submitterTemplate.Spec.Containers[utils.RayContainerIndex].Args)
 
 	// Test 3: User did not provide template, should use the image of the Ray Head
-	submitterTemplate, err = getSubmitterTemplate(ctx, rayJobInstanceWithoutTemplate, rayClusterInstance)
+	submitterTemplate, err = getSubmitterTemplate(ctx, rayJobInstanceWithoutTemplate, rayClusterInstance, nil, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, []string{"/bin/sh"}, submitterTemplate.Spec.Containers[utils.RayContainerIndex].Command)
 	assert.Equal(t, []string{"-c", "if ray job status --address http://test-url test-job-id >/dev/null 2>&1 ; then ray job logs --address http://test-url --follow test-job-id ; else ray job submit --address http://test-url --submission-id test-job-id -- echo hello world ; fi"}, submitterTemplate.Spec.Containers[utils.RayContainerIndex].Args)
 	assert.Equal(t, "rayproject/ray:custom-version", submitterTemplate.Spec.Containers[utils.RayContainerIndex].Image)
 
 	// Test 4: Check default PYTHONUNBUFFERED setting
-	submitterTemplate, err = getSubmitterTemplate(ctx, rayJobInstanceWithoutTemplate, rayClusterInstance)
+	submitterTemplate, err = getSubmitterTemplate(ctx, rayJobInstanceWithoutTemplate, rayClusterInstance, nil, nil)
 	assert.NoError(t, err)
 
 	envVar, found := utils.EnvVarByName(PythonUnbufferedEnvVarName, submitterTemplate.Spec.Containers[utils.RayContainerIndex].Env)
@@ -185,7 +192,7 @@ func TestGetSubmitterTemplate(t *testing.T) {
 	assert.Equal(t, "1", envVar.Value)
 
 	// Test 5: Check default RAY_DASHBOARD_ADDRESS env var
-	submitterTemplate, err = getSubmitterTemplate(ctx, rayJobInstanceWithTemplate, nil)
+	submitterTemplate, err = getSubmitterTemplate(ctx, rayJobInstanceWithTemplate, nil, nil, nil)
 	assert.NoError(t, err)
 
 	envVar, found = utils.EnvVarByName(utils.RAY_DASHBOARD_ADDRESS, submitterTemplate.Spec.Containers[utils.RayContainerIndex].Env)
@@ -196,6 +203,120 @@ func TestGetSubmitterTemplate(t *testing.T) {
 	envVar, found = utils.EnvVarByName(utils.RAY_JOB_SUBMISSION_ID, submitterTemplate.Spec.Containers[utils.RayContainerIndex].Env)
 	assert.True(t, found)
 	assert.Equal(t, "test-job-id", envVar.Value)
+
+	// Test 7: The submitter stops the service mesh sidecar when it exits
+	meshRayClusterInstance := rayClusterInstance.DeepCopy()
+	meshRayClusterInstance.Spec.ServiceMeshOptions = &rayv1.ServiceMeshOptions{Type: rayv1.IstioServiceMesh}
+	submitterTemplate, err = getSubmitterTemplate(ctx, rayJobInstanceWithoutTemplate, meshRayClusterInstance, nil, nil)
+	assert.NoError(t, err)
+	assert.Contains(t, submitterTemplate.Spec.Containers[utils.RayContainerIndex].Args[1], "; fi ; exit_code=$? ; python -c")
+	assert.Contains(t, submitterTemplate.Spec.Containers[utils.RayContainerIndex].Args[1], "http://127.0.0.1:15020/quitquitquit")
+	assert.True(t, strings.HasSuffix(submitterTemplate.Spec.Containers[utils.RayContainerIndex].Args[1], "exit $exit_code"))
+	assert.Equal(t, `{"holdApplicationUntilProxyStarts": true}`, submitterTemplate.Annotations["proxy.istio.io/config"])
+
+	// Test 8: The image pull secrets of the RayJob are added to the submitter
+	rayJobInstanceWithSecrets := rayJobInstanceWithoutTemplate.DeepCopy()
+	rayJobInstanceWithSecrets.Spec.ImagePullSecrets = []corev1.LocalObjectReference{{Name: "registry"}}
+	submitterTemplate, err = getSubmitterTemplate(ctx, rayJobInstanceWithSecrets, rayClusterInstance, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "registry"}}, submitterTemplate.Spec.ImagePullSecrets)
+
+	// Test 9: The Pod spec defaults of the operator are applied to the submitter
+	podSpecDefaults := &configapi.PodSpecDefaults{EnableServiceLinks: ptr.To(false), AutomountServiceAccountToken: ptr.To(false)}
+	submitterTemplate, err = getSubmitterTemplate(ctx, rayJobInstanceWithTemplate, nil, nil, podSpecDefaults)
+	assert.NoError(t, err)
+	assert.Equal(t, ptr.To(false), submitterTemplate.Spec.EnableServiceLinks)
+	assert.Equal(t, ptr.To(false), submitterTemplate.Spec.AutomountServiceAccountToken)
+
+	// Test 10: The container named ray-job-submitter runs the submission and defaults to the image of the Ray head
+	rayJobInstanceWithSidecar := rayJobInstanceWithTemplate.DeepCopy()
+	rayJobInstanceWithSidecar.Spec.SubmitterPodTemplate.Spec.Containers = []corev1.Container{
+		{Name: "sidecar", Image: "sidecar:latest"},
+		{Name: common.SubmitterContainerName},
+	}
+	submitterTemplate, err = getSubmitterTemplate(ctx, rayJobInstanceWithSidecar, rayClusterInstance, nil, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, submitterTemplate.Spec.Containers[0].Command)
+	assert.Equal(t, "sidecar:latest", submitterTemplate.Spec.Containers[0].Image)
+	assert.Equal(t, []string{"/bin/sh"}, submitterTemplate.Spec.Containers[1].Command)
+	assert.Equal(t, "rayproject/ray:custom-version", submitterTemplate.Spec.Containers[1].Image)
+}
+
+// submitRecordingDashboardClient records the Ray jobs submitted through the dashboard.
+type submitRecordingDashboardClient struct {
+	utils.FakeRayDashboardClient
+	submittedJobIds []string
+}
+
+func (r *submitRecordingDashboardClient) SubmitJob(_ context.Context, rayJob *rayv1.RayJob) (string, error) {
+	r.submittedJobIds = append(r.submittedJobIds, rayJob.Status.JobId)
+	return rayJob.Status.JobId, nil
+}
+
+func TestReuseSubmitterPodSubmitsThroughDashboard(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = batchv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	rayCluster := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-raycluster", Namespace: "default"},
+	}
+	rayJob := &rayv1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "test-rayjob",
+			Namespace:  "default",
+			Finalizers: []string{utils.RayJobStopJobFinalizer},
+		},
+		Spec: rayv1.RayJobSpec{
+			Entrypoint:      "echo hello world",
+			SubmissionMode:  rayv1.K8sJobMode,
+			SubmitterConfig: &rayv1.SubmitterConfig{ReuseSubmitterPod: ptr.To(true)},
+			ClusterSelector: map[string]string{RayJobDefaultClusterSelectorKey: "test-raycluster"},
+		},
+		Status: rayv1.RayJobStatus{
+			JobDeploymentStatus: rayv1.JobDeploymentStatusRunning,
+			RayClusterName:      "test-raycluster",
+			DashboardURL:        "test-url",
+			JobId:               "test-job-id",
+		},
+	}
+
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(rayCluster, rayJob).WithStatusSubresource(rayJob).Build()
+	dashboardClient := &submitRecordingDashboardClient{}
+	r := &RayJobReconciler{
+		Client:              fakeClient,
+		Scheme:              newScheme,
+		Recorder:            &record.FakeRecorder{},
+		dashboardClientFunc: func() utils.RayDashboardClientInterface { return dashboardClient },
+	}
+	ctx := context.Background()
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Name: rayJob.Name, Namespace: rayJob.Namespace}}
+
+	// The Ray job isn't found, so it is submitted through the dashboard.
+	getJobInfo := func(context.Context, string) (*utils.RayJobInfo, error) {
+		return nil, k8serrors.NewBadRequest("job not found")
+	}
+	dashboardClient.GetJobInfoMock.Store(&getJobInfo)
+	_, err := r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"test-job-id"}, dashboardClient.submittedJobIds)
+
+	// Once the Ray job exists, it isn't submitted again.
+	getJobInfo = func(context.Context, string) (*utils.RayJobInfo, error) {
+		return &utils.RayJobInfo{JobStatus: rayv1.JobStatusRunning}, nil
+	}
+	_, err = r.Reconcile(ctx, request)
+	require.NoError(t, err)
+	assert.Len(t, dashboardClient.submittedJobIds, 1)
+
+	// Neither a submitter Kubernetes Job nor a submitter Pod is created.
+	jobs := batchv1.JobList{}
+	require.NoError(t, fakeClient.List(ctx, &jobs))
+	assert.Empty(t, jobs.Items)
+	pods := corev1.PodList{}
+	require.NoError(t, fakeClient.List(ctx, &pods))
+	assert.Empty(t, pods.Items)
 }
 
 func TestUpdateStatusToSuspendingIfNeeded(t *testing.T) {
@@ -320,6 +441,52 @@ func TestUpdateRayJobStatus(t *testing.T) {
 	}
 }
 
+func TestUpdateRayJobStatusJournal(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+
+	endTime := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	oldRayJob := &rayv1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-rayjob",
+			Namespace: "default",
+			UID:       "uid",
+		},
+		Status: rayv1.RayJobStatus{
+			JobDeploymentStatus: rayv1.JobDeploymentStatusComplete,
+			JobStatus:           rayv1.JobStatusSucceeded,
+			EndTime:             &endTime,
+		},
+	}
+	fakeClient := clientFake.NewClientBuilder().
+		WithScheme(newScheme).
+		WithRuntimeObjects(oldRayJob).
+		WithStatusSubresource(oldRayJob).Build()
+	ctx := context.Background()
+
+	journal := utils.NewJournalEventRecorder(record.NewFakeRecorder(10))
+	testRayJobReconciler := &RayJobReconciler{
+		Client:   fakeClient,
+		Recorder: journal,
+		Scheme:   newScheme,
+		journal:  journal,
+	}
+
+	newRayJob := &rayv1.RayJob{}
+	err := fakeClient.Get(ctx, types.NamespacedName{Namespace: oldRayJob.Namespace, Name: oldRayJob.Name}, newRayJob)
+	require.NoError(t, err)
+	testRayJobReconciler.Recorder.Event(newRayJob, corev1.EventTypeNormal, string(utils.DeletedRayCluster), "Deleted RayCluster")
+
+	// The status is updated when only the journal changes, without resetting the end time.
+	err = testRayJobReconciler.updateRayJobStatus(ctx, oldRayJob, newRayJob)
+	require.NoError(t, err)
+	err = fakeClient.Get(ctx, types.NamespacedName{Namespace: newRayJob.Namespace, Name: newRayJob.Name}, newRayJob)
+	require.NoError(t, err)
+	require.Len(t, newRayJob.Status.Journal, 1)
+	assert.Equal(t, string(utils.DeletedRayCluster), newRayJob.Status.Journal[0].Action)
+	assert.True(t, endTime.Equal(newRayJob.Status.EndTime))
+}
+
 func TestValidateRayJobSpec(t *testing.T) {
 	err := validateRayJobSpec(&rayv1.RayJob{})
 	assert.ErrorContains(t, err, "one of RayClusterSpec or ClusterSelector must be set")
@@ -431,6 +598,206 @@ func TestValidateRayJobSpec(t *testing.T) {
 		},
 	})
 	assert.ErrorContains(t, err, "shutdownAfterJobFinshes is set to 'true' while deletion policy is 'DeleteNone'")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			Metadata:       map[string]string{"owner": "ray"},
+			RayClusterSpec: &rayv1.RayClusterSpec{RayVersion: "2.5.0"},
+		},
+	})
+	assert.ErrorContains(t, err, "RayJob metadata requires Ray 2.6.0 or later")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			Metadata:       map[string]string{"owner": "ray"},
+			RayClusterSpec: &rayv1.RayClusterSpec{RayVersion: "2.6.0"},
+		},
+	})
+	assert.NoError(t, err)
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			SubmissionMode:  rayv1.HTTPMode,
+			SubmitterConfig: &rayv1.SubmitterConfig{ReuseSubmitterPod: ptr.To(true)},
+			RayClusterSpec:  &rayv1.RayClusterSpec{},
+		},
+	})
+	assert.ErrorContains(t, err, "reuseSubmitterPod is only supported in K8sJobMode")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			SubmissionMode: rayv1.InteractiveMode,
+			Checkpointing:  &rayv1.CheckpointingOptions{URI: "s3://bucket/checkpoints"},
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+		},
+	})
+	assert.ErrorContains(t, err, "checkpointing isn't supported in the InteractiveMode submission mode")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			Checkpointing:  &rayv1.CheckpointingOptions{URI: "s3://bucket/checkpoints"},
+			RuntimeEnvYAML: "env_vars: [FOO]",
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+		},
+	})
+	assert.ErrorContains(t, err, "env_vars of the RuntimeEnvYAML must be a map")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			Checkpointing:  &rayv1.CheckpointingOptions{URI: "s3://bucket/checkpoints"},
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+		},
+	})
+	assert.NoError(t, err)
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			SubmissionMode: rayv1.InteractiveSessionMode,
+			Entrypoint:     "python script.py",
+			RayClusterSpec: &rayv1.RayClusterSpec{},
+		},
+	})
+	assert.ErrorContains(t, err, "entrypoint must not be set in the InteractiveSession submission mode")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			InteractiveSession: &rayv1.InteractiveSessionOptions{IdleTimeoutSeconds: ptr.To[int32](60)},
+			RayClusterSpec:     &rayv1.RayClusterSpec{},
+		},
+	})
+	assert.ErrorContains(t, err, "interactiveSession is only supported in the InteractiveSession submission mode")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			SubmissionMode:     rayv1.InteractiveSessionMode,
+			InteractiveSession: &rayv1.InteractiveSessionOptions{IdleTimeoutSeconds: ptr.To[int32](0)},
+			RayClusterSpec:     &rayv1.RayClusterSpec{},
+		},
+	})
+	assert.ErrorContains(t, err, "interactiveSession.idleTimeoutSeconds must be a positive integer")
+
+	err = validateRayJobSpec(&rayv1.RayJob{
+		Spec: rayv1.RayJobSpec{
+			SubmissionMode:     rayv1.InteractiveSessionMode,
+			InteractiveSession: &rayv1.InteractiveSessionOptions{IdleTimeoutSeconds: ptr.To[int32](60)},
+			RayClusterSpec:     &rayv1.RayClusterSpec{},
+		},
+	})
+	assert.NoError(t, err)
+}
+
+func TestInitRayJobStatusResumesFromCheckpoint(t *testing.T) {
+	tests := map[string]struct {
+		resumePolicy                     rayv1.CheckpointResumePolicy
+		failed                           *int32
+		expectedResumedFromCheckpointURI string
+	}{
+		"The first attempt doesn't resume": {
+			failed:                           nil,
+			expectedResumedFromCheckpointURI: "",
+		},
+		"A retry resumes by default": {
+			failed:                           ptr.To[int32](1),
+			expectedResumedFromCheckpointURI: "s3://bucket/checkpoints",
+		},
+		"A retry with the Never policy doesn't resume": {
+			resumePolicy:                     rayv1.NeverResumeFromCheckpoint,
+			failed:                           ptr.To[int32](1),
+			expectedResumedFromCheckpointURI: "",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			rayJob := &rayv1.RayJob{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-rayjob",
+					Namespace: "default",
+				},
+				Spec: rayv1.RayJobSpec{
+					Checkpointing: &rayv1.CheckpointingOptions{
+						URI:          "s3://bucket/checkpoints",
+						ResumePolicy: tc.resumePolicy,
+					},
+				},
+				Status: rayv1.RayJobStatus{
+					Failed: tc.failed,
+				},
+			}
+
+			err := initRayJobStatusIfNeed(context.Background(), rayJob)
+			assert.NoError(t, err)
+			assert.Equal(t, rayv1.JobDeploymentStatusInitializing, rayJob.Status.JobDeploymentStatus)
+			assert.Equal(t, tc.expectedResumedFromCheckpointURI, rayJob.Status.ResumedFromCheckpointURI)
+		})
+	}
+}
+
+func TestCheckSessionIdleAndUpdateStatusIfNeeded(t *testing.T) {
+	now := time.Now()
+	startTime := now.Add(-10 * time.Minute)
+	tests := map[string]struct {
+		idleTimeoutSeconds       *int32
+		jobs                     []utils.RayJobInfo
+		expectedShouldUpdate     bool
+		expectedLastActivityTime time.Time
+	}{
+		"No idle timeout": {
+			idleTimeoutSeconds:       nil,
+			expectedShouldUpdate:     false,
+			expectedLastActivityTime: startTime,
+		},
+		"Idle since the session started": {
+			idleTimeoutSeconds:       ptr.To[int32](300),
+			expectedShouldUpdate:     true,
+			expectedLastActivityTime: startTime,
+		},
+		"A Ray job is running": {
+			idleTimeoutSeconds: ptr.To[int32](300),
+			jobs: []utils.RayJobInfo{
+				{JobStatus: rayv1.JobStatusSucceeded, EndTime: uint64(startTime.Add(time.Minute).UnixMilli())},
+				{JobStatus: rayv1.JobStatusRunning},
+			},
+			expectedShouldUpdate:     false,
+			expectedLastActivityTime: startTime,
+		},
+		"The last Ray job ended recently": {
+			idleTimeoutSeconds: ptr.To[int32](300),
+			jobs: []utils.RayJobInfo{
+				{JobStatus: rayv1.JobStatusFailed, EndTime: uint64(now.Add(-8 * time.Minute).UnixMilli())},
+				{JobStatus: rayv1.JobStatusSucceeded, EndTime: uint64(now.Add(-time.Minute).UnixMilli())},
+			},
+			expectedShouldUpdate:     false,
+			expectedLastActivityTime: time.UnixMilli(now.Add(-time.Minute).UnixMilli()),
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			rayJob := &rayv1.RayJob{
+				Spec: rayv1.RayJobSpec{
+					SubmissionMode:     rayv1.InteractiveSessionMode,
+					InteractiveSession: &rayv1.InteractiveSessionOptions{IdleTimeoutSeconds: tc.idleTimeoutSeconds},
+				},
+				Status: rayv1.RayJobStatus{
+					JobDeploymentStatus: rayv1.JobDeploymentStatusRunning,
+					Session: &rayv1.InteractiveSessionStatus{
+						ClientURL:        "ray://test-raycluster-head-svc.default.svc.cluster.local:10001",
+						LastActivityTime: &metav1.Time{Time: startTime},
+					},
+				},
+			}
+
+			shouldUpdate := checkSessionIdleAndUpdateStatusIfNeeded(context.Background(), rayJob, tc.jobs, now)
+			assert.Equal(t, tc.expectedShouldUpdate, shouldUpdate)
+			assert.True(t, tc.expectedLastActivityTime.Equal(rayJob.Status.Session.LastActivityTime.Time))
+			if tc.expectedShouldUpdate {
+				assert.Equal(t, rayv1.JobDeploymentStatusComplete, rayJob.Status.JobDeploymentStatus)
+			} else {
+				assert.Equal(t, rayv1.JobDeploymentStatusRunning, rayJob.Status.JobDeploymentStatus)
+			}
+		})
+	}
 }
 
 func TestFailedToCreateRayJobSubmitterEvent(t *testing.T) {
@@ -632,3 +999,69 @@ func TestFailedDeleteRayClusterEvent(t *testing.T) {
 
 	assert.Truef(t, foundFailureEvent, "Expected event to be generated for cluster deletion failure, got events: %s", strings.Join(events, "\n"))
 }
+
+func TestCleanUpRayJob(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = batchv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	rayJob := &rayv1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test-rayjob",
+			Namespace:         "default",
+			UID:               "rayjob-uid",
+			Finalizers:        []string{utils.RayCleanupFinalizer},
+			DeletionTimestamp: &metav1.Time{Time: time.Now()},
+		},
+		Status: rayv1.RayJobStatus{RayClusterName: "test-rayjob-raycluster"},
+	}
+	ownerReference := *metav1.NewControllerRef(rayJob, rayv1.SchemeGroupVersion.WithKind("RayJob"))
+	rayCluster := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            rayJob.Status.RayClusterName,
+			Namespace:       rayJob.Namespace,
+			OwnerReferences: []metav1.OwnerReference{ownerReference},
+		},
+	}
+	submitterJob := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            rayJob.Name,
+			Namespace:       rayJob.Namespace,
+			OwnerReferences: []metav1.OwnerReference{ownerReference},
+		},
+	}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).
+		WithRuntimeObjects(rayJob, rayCluster, submitterJob).
+		WithStatusSubresource(rayJob).Build()
+	r := &RayJobReconciler{
+		Client:   fakeClient,
+		Scheme:   newScheme,
+		Recorder: &record.FakeRecorder{},
+	}
+	ctx := context.Background()
+
+	instance := &rayv1.RayJob{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(rayJob), instance))
+	_, err := r.cleanUpRayJob(ctx, instance)
+	require.NoError(t, err)
+
+	// The RayCluster and the submitter Job are deleted, and the finalizer is kept until they are confirmed to be gone.
+	condition := meta.FindStatusCondition(instance.Status.Conditions, string(rayv1.RayJobCleanupSucceeded))
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, rayv1.CleanupInProgress, condition.Reason)
+	assert.Contains(t, instance.Finalizers, utils.RayCleanupFinalizer)
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(rayCluster), &rayv1.RayCluster{})
+	assert.True(t, k8serrors.IsNotFound(err))
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(submitterJob), &batchv1.Job{})
+	assert.True(t, k8serrors.IsNotFound(err))
+
+	_, err = r.cleanUpRayJob(ctx, instance)
+	require.NoError(t, err)
+	condition = meta.FindStatusCondition(instance.Status.Conditions, string(rayv1.RayJobCleanupSucceeded))
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, rayv1.CleanupCompleted, condition.Reason)
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(rayJob), &rayv1.RayJob{})
+	assert.True(t, k8serrors.IsNotFound(err))
+}
@@ -0,0 +1,93 @@
+package ray
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/common"
+)
+
+// RayQuotaReconciler reconciles a RayQuota object. The quotas are enforced by the RayCluster controller, and this
+// controller only keeps the status of the RayQuotas up to date.
+type RayQuotaReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+// NewRayQuotaReconciler returns a new reconcile.Reconciler
+func NewRayQuotaReconciler(mgr manager.Manager) *RayQuotaReconciler {
+	return &RayQuotaReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}
+}
+
+// +kubebuilder:rbac:groups=ray.io,resources=rayquotas,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ray.io,resources=rayquotas/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=ray.io,resources=rayclusters,verbs=get;list;watch
+
+// Reconcile updates the resources used by the RayClusters admitted by the RayQuota.
+func (r *RayQuotaReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	quota := &rayv1.RayQuota{}
+	if err := r.Get(ctx, request.NamespacedName, quota); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	clusters := rayv1.RayClusterList{}
+	if err := r.List(ctx, &clusters, client.InNamespace(quota.Namespace)); err != nil {
+		return ctrl.Result{}, err
+	}
+	status := common.CalculateRayQuotaStatus(clusters.Items)
+	if equality.Semantic.DeepEqual(quota.Status, status) {
+		return ctrl.Result{}, nil
+	}
+
+	logger.Info("Updating the RayQuota status", "used", status.Used, "admitted", status.AdmittedRayClusters, "queued", status.QueuedRayClusters)
+	quota.Status = status
+	return ctrl.Result{}, r.Status().Update(ctx, quota)
+}
+
+// rayQuotasForRayCluster enqueues the RayQuotas in the namespace of the RayCluster.
+func (r *RayQuotaReconciler) rayQuotasForRayCluster(ctx context.Context, obj client.Object) []reconcile.Request {
+	quotas := rayv1.RayQuotaList{}
+	if err := r.List(ctx, &quotas, client.InNamespace(obj.GetNamespace())); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Failed to list the RayQuotas", "namespace", obj.GetNamespace())
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(quotas.Items))
+	for _, quota := range quotas.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: quota.Namespace, Name: quota.Name}})
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *RayQuotaReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcurrency int) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&rayv1.RayQuota{}).
+		Watches(&rayv1.RayCluster{}, handler.EnqueueRequestsFromMapFunc(r.rayQuotasForRayCluster)).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: reconcileConcurrency,
+			LogConstructor: func(request *reconcile.Request) logr.Logger {
+				logger := ctrl.Log.WithName("controllers").WithName("RayQuota")
+				if request != nil {
+					logger = logger.WithValues("RayQuota", request.NamespacedName)
+				}
+				return logger
+			},
+		}).
+		Complete(r)
+}
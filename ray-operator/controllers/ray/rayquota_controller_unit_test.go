@@ -0,0 +1,76 @@
+package ray
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	clientFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestRayQuotaReconcile(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	namespace := "default"
+	newCluster := func(name string, conditionStatus metav1.ConditionStatus, reason string) *rayv1.RayCluster {
+		return &rayv1.RayCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: rayv1.RayClusterSpec{
+				HeadGroupSpec: rayv1.HeadGroupSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{
+								Name: "ray-head",
+								Resources: corev1.ResourceRequirements{
+									Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+								},
+							}},
+						},
+					},
+				},
+			},
+			Status: rayv1.RayClusterStatus{
+				Conditions: []metav1.Condition{{Type: string(rayv1.RayClusterQuotaExceeded), Status: conditionStatus, Reason: reason}},
+			},
+		}
+	}
+	quota := &rayv1.RayQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "quota", Namespace: namespace},
+		Spec:       rayv1.RayQuotaSpec{Hard: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3")}},
+	}
+	fakeClient := clientFake.NewClientBuilder().
+		WithScheme(newScheme).
+		WithRuntimeObjects(
+			quota,
+			newCluster("admitted", metav1.ConditionFalse, rayv1.RayQuotaAdmitted),
+			newCluster("queued", metav1.ConditionTrue, rayv1.RayQuotaQueued),
+		).
+		WithStatusSubresource(quota).
+		Build()
+
+	r := &RayQuotaReconciler{Client: fakeClient, Scheme: newScheme}
+	ctx := context.Background()
+	namespacedName := types.NamespacedName{Namespace: namespace, Name: quota.Name}
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: namespacedName})
+	require.NoError(t, err)
+
+	err = fakeClient.Get(ctx, namespacedName, quota)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), quota.Status.AdmittedRayClusters)
+	assert.Equal(t, int32(1), quota.Status.QueuedRayClusters)
+	assert.True(t, quota.Status.Used.Cpu().Equal(resource.MustParse("2")))
+
+	requests := r.rayQuotasForRayCluster(ctx, newCluster("new", metav1.ConditionUnknown, ""))
+	assert.Equal(t, []ctrl.Request{{NamespacedName: namespacedName}}, requests)
+}
@@ -4,32 +4,48 @@ import (
 	"context"
 	errstd "errors"
 	"fmt"
+	"maps"
 	"math"
+	"net/url"
 	"os"
+	"reflect"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/json"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/utils/lru"
+	"k8s.io/utils/ptr"
 
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/common"
 	"github.com/ray-project/kuberay/ray-operator/pkg/features"
+	"github.com/ray-project/kuberay/ray-operator/pkg/servemetrics"
 
 	cmap "github.com/orcaman/concurrent-map/v2"
 
 	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -42,9 +58,16 @@ import (
 )
 
 const (
-	ServiceDefaultRequeueDuration   = 2 * time.Second
+	// Deprecated: The interval between the reconciliations of a RayService is configurable and jittered. Use
+	// utils.GetRayServiceRequeueInterval instead.
+	ServiceDefaultRequeueDuration   = utils.RayServiceDefaultRequeueInterval
 	RayClusterDeletionDelayDuration = 60 * time.Second
 	ENABLE_ZERO_DOWNTIME            = "ENABLE_ZERO_DOWNTIME"
+	// ServeReplicaStuckStartingDuration is how long a Serve replica can be in the STARTING state before it is
+	// considered stuck.
+	ServeReplicaStuckStartingDuration = 5 * time.Minute
+	// maxConcurrentServeProxyHealthChecks is the number of Ray Serve proxies of worker Pods checked at the same time.
+	maxConcurrentServeProxyHealthChecks = 16
 )
 
 // RayServiceReconciler reconciles a RayService object
@@ -55,26 +78,38 @@ type RayServiceReconciler struct {
 	// Currently, the Ray dashboard doesn't cache the Serve application config.
 	// To avoid reapplying the same config repeatedly, cache the config in this map.
 	// Cache key is the combination of RayService namespace and name.
-	// Cache value is map of RayCluster name to Serve application config.
-	ServeConfigs                 *lru.Cache
-	RayClusterDeletionTimestamps cmap.ConcurrentMap[string, time.Time]
-	dashboardClientFunc          func() utils.RayDashboardClientInterface
-	httpProxyClientFunc          func() utils.RayHttpProxyClientInterface
+	// Cache value is map of RayCluster name to Serve application config. The large configs are gzipped.
+	ServeConfigs        *lru.Cache
+	dashboardClientFunc func() utils.RayDashboardClientInterface
+	httpProxyClientFunc func() utils.RayHttpProxyClientInterface
+	// serveRequestCountsFunc returns the counters of the HTTP requests of the Ray Serve proxies of a RayCluster.
+	serveRequestCountsFunc func(ctx context.Context, rayCluster *rayv1.RayCluster) (rayv1.ServeRequestCounts, error)
+	// serveMetricSumFunc returns the sum of a metric exported by the Ray Pods of a RayCluster.
+	serveMetricSumFunc func(ctx context.Context, rayCluster *rayv1.RayCluster, metric string) (float64, error)
+	journal            *utils.JournalEventRecorder
+	// nodeZones caches the zones of the nodes by node name for the serve EndpointSlices. The zone of a node doesn't
+	// change during its lifetime.
+	nodeZones *lru.Cache
 }
 
 // NewRayServiceReconciler returns a new reconcile.Reconciler
 func NewRayServiceReconciler(_ context.Context, mgr manager.Manager, provider utils.ClientProvider) *RayServiceReconciler {
 	dashboardClientFunc := provider.GetDashboardClient(mgr)
 	httpProxyClientFunc := provider.GetHttpProxyClient(mgr)
+	journal := utils.NewJournalEventRecorder(mgr.GetEventRecorderFor("rayservice-controller"))
+	serveMetricsProvider := servemetrics.NewProvider(mgr.GetClient())
 	return &RayServiceReconciler{
-		Client:                       mgr.GetClient(),
-		Scheme:                       mgr.GetScheme(),
-		Recorder:                     mgr.GetEventRecorderFor("rayservice-controller"),
-		ServeConfigs:                 lru.New(utils.ServeConfigLRUSize),
-		RayClusterDeletionTimestamps: cmap.New[time.Time](),
-
-		dashboardClientFunc: dashboardClientFunc,
-		httpProxyClientFunc: httpProxyClientFunc,
+		Client:       mgr.GetClient(),
+		Scheme:       mgr.GetScheme(),
+		Recorder:     utils.NewDeduplicatingEventRecorder(journal, utils.DefaultEventDeduplicationWindow),
+		ServeConfigs: lru.New(utils.ServeConfigLRUSize),
+
+		dashboardClientFunc:    dashboardClientFunc,
+		httpProxyClientFunc:    httpProxyClientFunc,
+		serveRequestCountsFunc: serveMetricsProvider.GetRayClusterServeRequestCounts,
+		serveMetricSumFunc:     serveMetricsProvider.GetRayClusterMetricSum,
+		journal:                journal,
+		nodeZones:              lru.New(utils.NodeZoneLRUSize),
 	}
 }
 
@@ -89,13 +124,20 @@ func NewRayServiceReconciler(_ context.Context, mgr manager.Manager, provider ut
 // +kubebuilder:rbac:groups=core,resources=pods/status,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=pods/proxy,verbs=get;update;patch
 // +kubebuilder:rbac:groups=core,resources=endpoints,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;create;update;delete
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;create;delete
 // +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=services/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=core,resources=services/proxy,verbs=get;update;patch
 // +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;list;create;update
 // +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;delete
 // +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=roles,verbs=get;list;watch;create;delete;update
-// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=get;list;watch;create;delete;update
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;create;update;delete
+// +kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheusrules,verbs=get;create;update;delete
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch;create;update;delete;deletecollection
 
 // [WARNING]: There MUST be a newline after kubebuilder markers.
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -106,6 +148,15 @@ func NewRayServiceReconciler(_ context.Context, mgr manager.Manager, provider ut
 // For more details, check Reconcile and its Result here:
 // - https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.11.2/pkg/reconcile
 func (r *RayServiceReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+	result, reconcileErr := r.rayServiceReconcile(ctx, request)
+	if err := utils.RecordReconcileError(ctx, r.Client, request.NamespacedName, &rayv1.RayService{}, reconcileErr); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Failed to record the reconcile error in the RayService status")
+	}
+	return requeueAdmissionDenied(result, reconcileErr)
+}
+
+// rayServiceReconcile reconciles the RayService and returns the error to be recorded in its status.
+func (r *RayServiceReconciler) rayServiceReconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
 	logger := ctrl.LoggerFrom(ctx)
 
 	var rayServiceInstance *rayv1.RayService
@@ -115,16 +166,49 @@ func (r *RayServiceReconciler) Reconcile(ctx context.Context, request ctrl.Reque
 	if rayServiceInstance, err = r.getRayServiceInstance(ctx, request); err != nil {
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
+	ctx = utils.WithLogVerbosity(ctx, rayServiceInstance)
+	logger = ctrl.LoggerFrom(ctx)
 	originalRayServiceInstance := rayServiceInstance.DeepCopy()
 
+	// The RayServices being deleted are still reconciled, so that their finalizers don't block the deletion.
+	if rayServiceInstance.DeletionTimestamp.IsZero() {
+		if selected, err := utils.IsNamespaceSelected(ctx, r.Client, rayServiceInstance.Namespace); err != nil || !selected {
+			if err == nil {
+				logger.Info("Skipping RayService in a namespace not selected by the watch namespace selector")
+			}
+			return ctrl.Result{}, err
+		}
+	}
+
+	if !rayServiceInstance.DeletionTimestamp.IsZero() && controllerutil.ContainsFinalizer(rayServiceInstance, utils.RayCleanupFinalizer) {
+		return r.cleanUpRayService(ctx, rayServiceInstance)
+	}
+	if features.Enabled(features.RayCleanupFinalizer) && rayServiceInstance.DeletionTimestamp.IsZero() &&
+		!controllerutil.ContainsFinalizer(rayServiceInstance, utils.RayCleanupFinalizer) {
+		logger.Info("Add a finalizer", "finalizer", utils.RayCleanupFinalizer)
+		controllerutil.AddFinalizer(rayServiceInstance, utils.RayCleanupFinalizer)
+		if err := r.Update(ctx, rayServiceInstance); err != nil {
+			return ctrl.Result{RequeueAfter: utils.GetRayServiceRequeueInterval(false)}, err
+		}
+		return ctrl.Result{RequeueAfter: utils.GetRayServiceRequeueInterval(false)}, nil
+	}
+
 	if err := validateRayServiceSpec(rayServiceInstance); err != nil {
 		r.Recorder.Eventf(rayServiceInstance, corev1.EventTypeWarning, string(utils.InvalidRayServiceSpec),
 			"The RayService spec is invalid %s/%s: %v", rayServiceInstance.Namespace, rayServiceInstance.Name, err)
-		return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, err
+		return ctrl.Result{RequeueAfter: utils.GetRayServiceRequeueInterval(false)}, err
+	}
+
+	if err := r.reconcileServeConfigSource(ctx, rayServiceInstance, time.Now()); err != nil {
+		return ctrl.Result{RequeueAfter: utils.GetRayServiceRequeueInterval(false)}, err
 	}
 
 	r.cleanUpServeConfigCache(ctx, rayServiceInstance)
 
+	if err := r.reconcileMaintenance(ctx, rayServiceInstance); err != nil {
+		return ctrl.Result{RequeueAfter: utils.GetRayServiceRequeueInterval(false)}, err
+	}
+
 	// TODO (kevin85421): ObservedGeneration should be used to determine whether to update this CR or not.
 	rayServiceInstance.Status.ObservedGeneration = rayServiceInstance.ObjectMeta.Generation
 
@@ -132,18 +216,22 @@ func (r *RayServiceReconciler) Reconcile(ctx context.Context, request ctrl.Reque
 	var activeRayClusterInstance *rayv1.RayCluster
 	var pendingRayClusterInstance *rayv1.RayCluster
 	if activeRayClusterInstance, pendingRayClusterInstance, err = r.reconcileRayCluster(ctx, rayServiceInstance); err != nil {
-		return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, client.IgnoreNotFound(err)
+		return ctrl.Result{RequeueAfter: utils.GetRayServiceRequeueInterval(false)}, client.IgnoreNotFound(err)
 	}
 
 	// Check if we need to create pending RayCluster.
 	if rayServiceInstance.Status.PendingServiceStatus.RayClusterName != "" && pendingRayClusterInstance == nil {
 		// Update RayService Status since reconcileRayCluster may mark RayCluster restart.
-		if errStatus := r.Status().Update(ctx, rayServiceInstance); errStatus != nil {
+		if errStatus := r.updateStatus(ctx, rayServiceInstance); errStatus != nil {
 			logger.Error(errStatus, "Fail to update status of RayService after RayCluster changes", "rayServiceInstance", rayServiceInstance)
-			return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, nil
+			return ctrl.Result{RequeueAfter: utils.GetRayServiceRequeueInterval(false)}, nil
 		}
 		logger.Info("Done reconcileRayCluster update status, enter next loop to create new ray cluster.")
-		return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, nil
+		return ctrl.Result{RequeueAfter: utils.GetRayServiceRequeueInterval(false)}, nil
+	}
+
+	if err := r.reconcileDebugRayCluster(ctx, rayServiceInstance, activeRayClusterInstance); err != nil {
+		logger.Error(err, "Failed to reconcile the debug RayCluster.")
 	}
 
 	/*
@@ -164,7 +252,7 @@ func (r *RayServiceReconciler) Reconcile(ctx context.Context, request ctrl.Reque
 		rayServiceInstance.Status.PendingServiceStatus = rayv1.RayServiceStatus{}
 		if isActiveClusterReady, err = r.reconcileServe(ctx, rayServiceInstance, activeRayClusterInstance, true); err != nil {
 			logger.Error(err, "Fail to reconcileServe.")
-			return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, nil
+			return ctrl.Result{RequeueAfter: utils.GetRayServiceRequeueInterval(false)}, nil
 		}
 	} else if activeRayClusterInstance != nil && pendingRayClusterInstance != nil {
 		logger.Info("Reconciling the Serve component. Active and pending Ray clusters exist.")
@@ -175,25 +263,53 @@ func (r *RayServiceReconciler) Reconcile(ctx context.Context, request ctrl.Reque
 
 		if isPendingClusterReady, err = r.reconcileServe(ctx, rayServiceInstance, pendingRayClusterInstance, false); err != nil {
 			logger.Error(err, "Fail to reconcileServe.")
-			return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, nil
+			return ctrl.Result{RequeueAfter: utils.GetRayServiceRequeueInterval(false)}, nil
 		}
 	} else if activeRayClusterInstance == nil && pendingRayClusterInstance != nil {
 		rayServiceInstance.Status.ActiveServiceStatus = rayv1.RayServiceStatus{}
 		if isPendingClusterReady, err = r.reconcileServe(ctx, rayServiceInstance, pendingRayClusterInstance, false); err != nil {
 			logger.Error(err, "Fail to reconcileServe.")
-			return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, nil
+			return ctrl.Result{RequeueAfter: utils.GetRayServiceRequeueInterval(false)}, nil
 		}
 	}
 
+	if isPendingClusterReady, err = r.reconcileServeTraffic(ctx, rayServiceInstance, activeRayClusterInstance, pendingRayClusterInstance, isPendingClusterReady); err != nil {
+		return ctrl.Result{RequeueAfter: utils.GetRayServiceRequeueInterval(false)}, err
+	}
+
 	if !isActiveClusterReady && !isPendingClusterReady {
 		logger.Info("Ray Serve applications are not ready to serve requests")
-		return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, nil
+		// Without a pending RayCluster, the active RayCluster doesn't serve, so the failover policy is evaluated here as
+		// well. The RayService would otherwise never fail over. The conditions of the maintenance mode are updated here as
+		// well, since the maintenance may be planned precisely while the RayClusters aren't ready.
+		inMaintenance := rayServiceInstance.Spec.MaintenanceMode ||
+			meta.FindStatusCondition(originalRayServiceInstance.Status.Conditions, string(rayv1.RayServiceMaintenanceActive)) != nil
+		if (rayServiceInstance.Spec.FailoverPolicy != nil && pendingRayClusterInstance == nil) || inMaintenance {
+			setReadyCondition(rayServiceInstance, time.Now())
+			if err := r.reconcileFailover(ctx, rayServiceInstance, time.Now()); err != nil {
+				logger.Error(err, "Failed to reconcile the failover policy.")
+			}
+			if inconsistentRayServiceStatuses(ctx, originalRayServiceInstance.Status, rayServiceInstance.Status) {
+				if errStatus := r.updateStatus(ctx, rayServiceInstance); errStatus != nil {
+					return ctrl.Result{RequeueAfter: utils.GetRayServiceRequeueInterval(false)}, errStatus
+				}
+			}
+		}
+		return ctrl.Result{RequeueAfter: utils.GetRayServiceRequeueInterval(false)}, nil
 	}
 
 	// Switch pending cluster to active cluster if pending cluster is ready
 	// to serve requests.
 	if isPendingClusterReady {
 		promotePendingClusterToActiveCluster(ctx, rayServiceInstance)
+		if activeRayClusterInstance != nil && utils.IsManualPromotionEnabled(rayServiceInstance) {
+			// Remove the approval so that the next upgrade needs to be approved again.
+			if err := r.removeRayServiceAnnotation(ctx, rayServiceInstance, utils.RayServicePromoteAnnotationKey); err != nil {
+				return ctrl.Result{RequeueAfter: utils.GetRayServiceRequeueInterval(false)}, err
+			}
+			r.Recorder.Eventf(rayServiceInstance, corev1.EventTypeNormal, string(utils.PromotedPendingRayCluster),
+				"Promoted the pending RayCluster %s/%s", pendingRayClusterInstance.Namespace, pendingRayClusterInstance.Name)
+		}
 	}
 
 	// Get the ready Ray cluster instance for service update.
@@ -208,35 +324,82 @@ func (r *RayServiceReconciler) Reconcile(ctx context.Context, request ctrl.Reque
 			"on the active Ray cluster. No pending Ray cluster found.")
 	}
 
-	if err := r.reconcileServices(ctx, rayServiceInstance, rayClusterInstance, utils.HeadService); err != nil {
-		return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, err
+	headSvcDrift, err := r.reconcileServices(ctx, rayServiceInstance, rayClusterInstance, utils.HeadService)
+	if err != nil {
+		return ctrl.Result{RequeueAfter: utils.GetRayServiceRequeueInterval(false)}, err
+	}
+	if err := r.updateHeadPodServeLabel(ctx, rayClusterInstance, rayServiceInstance.Spec.ExcludeHeadPodFromServeSvc, rayServiceInstance.Spec.ServeProxyHealthCheck); err != nil {
+		return ctrl.Result{RequeueAfter: utils.GetRayServiceRequeueInterval(false)}, err
+	}
+	if err := r.updateWorkerPodsServeLabel(ctx, rayClusterInstance, rayServiceInstance.Spec.WorkerServeProxyHealthCheck, rayServiceInstance.Spec.ServeProxyHealthCheck); err != nil {
+		return ctrl.Result{RequeueAfter: utils.GetRayServiceRequeueInterval(false)}, err
+	}
+	serveSvcDrift, err := r.reconcileServices(ctx, rayServiceInstance, rayClusterInstance, utils.ServingService)
+	if err != nil {
+		return ctrl.Result{RequeueAfter: utils.GetRayServiceRequeueInterval(false)}, err
+	}
+	if err := r.reconcileServeEndpointSlice(ctx, rayServiceInstance, common.ServeEndpointSelector(rayServiceInstance, rayClusterInstance)); err != nil {
+		return ctrl.Result{RequeueAfter: utils.GetRayServiceRequeueInterval(false)}, err
 	}
-	if err := r.updateHeadPodServeLabel(ctx, rayClusterInstance, rayServiceInstance.Spec.ExcludeHeadPodFromServeSvc); err != nil {
-		return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, err
+	setServiceDriftCondition(rayServiceInstance, headSvcDrift, serveSvcDrift)
+	if err := r.reconcileDashboardIngress(ctx, rayServiceInstance, rayClusterInstance); err != nil {
+		return ctrl.Result{RequeueAfter: utils.GetRayServiceRequeueInterval(false)}, err
 	}
-	if err := r.reconcileServices(ctx, rayServiceInstance, rayClusterInstance, utils.ServingService); err != nil {
-		return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, err
+	if err := r.reconcileServeAlertsPrometheusRule(ctx, rayServiceInstance); err != nil {
+		return ctrl.Result{RequeueAfter: utils.GetRayServiceRequeueInterval(false)}, err
 	}
 
 	if err := r.calculateStatus(ctx, rayServiceInstance); err != nil {
-		return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, err
+		return ctrl.Result{RequeueAfter: utils.GetRayServiceRequeueInterval(false)}, err
 	}
+	if features.Enabled(features.RayResourceUsageAccounting) {
+		var rayClusters []client.ObjectKey
+		for _, name := range []string{rayServiceInstance.Status.ActiveServiceStatus.RayClusterName, rayServiceInstance.Status.PendingServiceStatus.RayClusterName} {
+			if name != "" {
+				rayClusters = append(rayClusters, client.ObjectKey{Namespace: rayServiceInstance.Namespace, Name: name})
+			}
+		}
+		usage, err := sampleResourceUsage(ctx, r.Client, rayServiceInstance.Status.ResourceUsage, rayClusters, time.Now(), false)
+		if err != nil {
+			return ctrl.Result{RequeueAfter: utils.GetRayServiceRequeueInterval(false)}, err
+		}
+		rayServiceInstance.Status.ResourceUsage = usage
+	}
+	setReadyCondition(rayServiceInstance, time.Now())
+	if err := r.reconcileFailover(ctx, rayServiceInstance, time.Now()); err != nil {
+		logger.Error(err, "Failed to reconcile the failover policy.")
+	}
+	rayServiceInstance.Status.Journal = r.journal.MergeJournal(rayServiceInstance.UID, rayServiceInstance.Status.Journal)
 
 	// Final status update for any CR modification.
 	if inconsistentRayServiceStatuses(ctx, originalRayServiceInstance.Status, rayServiceInstance.Status) {
 		rayServiceInstance.Status.LastUpdateTime = &metav1.Time{Time: time.Now()}
-		if errStatus := r.Status().Update(ctx, rayServiceInstance); errStatus != nil {
-			return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, errStatus
+		if errStatus := r.updateStatus(ctx, rayServiceInstance); errStatus != nil {
+			return ctrl.Result{RequeueAfter: utils.GetRayServiceRequeueInterval(false)}, errStatus
 		}
 	}
 
-	return ctrl.Result{RequeueAfter: ServiceDefaultRequeueDuration}, nil
+	requeueAfter := utils.GetRayServiceRequeueInterval(isRayServiceStable(rayServiceInstance))
+	if rayServiceInstance.Spec.ServeConfigSource != nil {
+		requeueAfter = min(requeueAfter, serveConfigSourcePollInterval(rayServiceInstance))
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// isRayServiceStable returns whether the Serve applications of the RayService are running and no RayCluster is being
+// prepared, so that the RayService is reconciled less often.
+func isRayServiceStable(rayService *rayv1.RayService) bool {
+	return rayService.Status.ServiceStatus == rayv1.Running && rayService.Status.PendingServiceStatus.RayClusterName == ""
 }
 
 func validateRayServiceSpec(rayService *rayv1.RayService) error {
 	if headSvc := rayService.Spec.RayClusterSpec.HeadGroupSpec.HeadService; headSvc != nil && headSvc.Name != "" {
 		return fmt.Errorf("spec.rayClusterConfig.headGroupSpec.headService.metadata.name should not be set")
 	}
+	if rayService.Spec.RayClusterSpec.HeadGroupSpec.ExistingHeadServiceName != "" {
+		// The active and pending RayClusters can't share a head service.
+		return fmt.Errorf("spec.rayClusterConfig.headGroupSpec.existingHeadServiceName should not be set")
+	}
 
 	// only NewCluster and None are valid upgradeType
 	if rayService.Spec.UpgradeStrategy != nil &&
@@ -245,20 +408,121 @@ func validateRayServiceSpec(rayService *rayv1.RayService) error {
 		*rayService.Spec.UpgradeStrategy.Type != rayv1.NewCluster {
 		return fmt.Errorf("Spec.UpgradeStrategy.Type value %s is invalid, valid options are %s or %s", *rayService.Spec.UpgradeStrategy.Type, rayv1.NewCluster, rayv1.None)
 	}
-	return nil
-}
 
-func (r *RayServiceReconciler) calculateStatus(ctx context.Context, rayServiceInstance *rayv1.RayService) error {
-	serveEndPoints := &corev1.Endpoints{}
-	if err := r.Get(ctx, common.RayServiceServeServiceNamespacedName(rayServiceInstance), serveEndPoints); err != nil && !errors.IsNotFound(err) {
+	if err := common.ValidateIgnoredPaths(getIgnoredPaths(rayService)); err != nil {
+		return fmt.Errorf("spec.upgradeStrategy.ignoredPaths is invalid: %w", err)
+	}
+
+	if err := common.ValidateServeApplicationWorkerGroups(&rayService.Spec.RayClusterSpec, rayService.Spec.ServeApplicationWorkerGroups); err != nil {
+		return fmt.Errorf("spec.serveApplicationWorkerGroups is invalid: %w", err)
+	}
+
+	if affinity := rayService.Spec.ServeSessionAffinity; affinity != nil {
+		if affinity.Type == rayv1.CookieServeSessionAffinity && affinity.GatewayName == "" {
+			return fmt.Errorf("spec.serveSessionAffinity.gatewayName is required by the %s session affinity", rayv1.CookieServeSessionAffinity)
+		}
+		if affinity.Type == rayv1.ClientIPServeSessionAffinity && affinity.CookieName != nil {
+			return fmt.Errorf("spec.serveSessionAffinity.cookieName is only supported by the %s session affinity", rayv1.CookieServeSessionAffinity)
+		}
+	}
+
+	if shadowing := utils.GetRequestShadowing(rayService); shadowing != nil {
+		if shadowing.GatewayName == "" {
+			return fmt.Errorf("spec.upgradeStrategy.requestShadowing.gatewayName is required")
+		}
+		if affinity := rayService.Spec.ServeSessionAffinity; affinity != nil && affinity.Type == rayv1.CookieServeSessionAffinity &&
+			affinity.GatewayName != shadowing.GatewayName {
+			return fmt.Errorf("spec.upgradeStrategy.requestShadowing.gatewayName must match spec.serveSessionAffinity.gatewayName")
+		}
+	}
+
+	if alerting := rayService.Spec.ServeAlerting; alerting != nil {
+		for i, app := range alerting.Applications {
+			if app.MaxUnhealthySeconds == nil && app.MinReplicas == nil {
+				return fmt.Errorf("spec.serveAlerting.applications[%d] must set at least one of maxUnhealthySeconds and minReplicas", i)
+			}
+		}
+	}
+
+	if policy := rayService.Spec.FailoverPolicy; policy != nil && policy.WebhookURL != nil {
+		if webhookURL, err := url.Parse(*policy.WebhookURL); err != nil || (webhookURL.Scheme != "http" && webhookURL.Scheme != "https") || webhookURL.Host == "" {
+			return fmt.Errorf("spec.failoverPolicy.webhookURL %q is not an HTTP or HTTPS URL", *policy.WebhookURL)
+		}
+	}
+
+	if err := utils.ValidateServeProxyHealthCheck(rayService.Spec.ServeProxyHealthCheck); err != nil {
+		return fmt.Errorf("spec.serveProxyHealthCheck is invalid: %w", err)
+	}
+
+	if err := validateServeReadiness(rayService); err != nil {
 		return err
 	}
 
+	for key, value := range rayService.Spec.QueueLabels {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("spec.queueLabels has an invalid label key %q: %s", key, strings.Join(errs, ", "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return fmt.Errorf("spec.queueLabels has an invalid value %q for the label %s: %s", value, key, strings.Join(errs, ", "))
+		}
+	}
+
+	for i, source := range rayService.Spec.ServeConfigV2Variables {
+		if (source.ConfigMapRef == nil) == (source.SecretRef == nil) {
+			return fmt.Errorf("spec.serveConfigV2Variables[%d] must set exactly one of configMapRef and secretRef", i)
+		}
+	}
+
+	if source := rayService.Spec.ServeConfigSource; source != nil {
+		if !features.Enabled(features.RayServiceServeConfigSource) {
+			return fmt.Errorf("spec.serveConfigSource is currently available when the RayServiceServeConfigSource feature gate is enabled")
+		}
+		if rayService.Spec.ServeConfigV2 != "" {
+			return fmt.Errorf("spec.serveConfigV2 and spec.serveConfigSource can't both be set")
+		}
+		if sourceURL, err := url.Parse(source.URL); err != nil || (sourceURL.Scheme != "http" && sourceURL.Scheme != "https") || sourceURL.Host == "" {
+			return fmt.Errorf("spec.serveConfigSource.url %q is not an HTTP or HTTPS URL", source.URL)
+		}
+	}
+
+	if len(rayService.Spec.ServeConfigV2) > utils.MaxServeConfigV2Size {
+		return fmt.Errorf("spec.serveConfigV2 is %d bytes, which exceeds the limit of %d bytes", len(rayService.Spec.ServeConfigV2), utils.MaxServeConfigV2Size)
+	}
+
+	if err := common.ValidateServeConfigV2(&rayService.Spec.RayClusterSpec, rayService.Spec.ServeConfigV2); err != nil {
+		return fmt.Errorf("spec.serveConfigV2 is invalid: %w", err)
+	}
+
+	var requiredFeatures []rayv1.RayVersionFeature
+	if rayService.Spec.ServeConfigV2 != "" || rayService.Spec.ServeConfigSource != nil {
+		requiredFeatures = append(requiredFeatures, rayv1.ServeConfigV2RayVersionFeature)
+	}
+	return rayv1.CheckRayVersionCompatibility(&rayService.Spec.RayClusterSpec, requiredFeatures...)
+}
+
+func (r *RayServiceReconciler) calculateStatus(ctx context.Context, rayServiceInstance *rayv1.RayService) error {
 	numServeEndpoints := 0
-	// Ray Pod addresses are categorized into subsets based on the IPs they share.
-	// subset.Addresses contains a list of Ray Pod addresses with ready serve port.
-	for _, subset := range serveEndPoints.Subsets {
-		numServeEndpoints += len(subset.Addresses)
+	if utils.IsManagedServeTopologyRouting(rayServiceInstance) {
+		// The serve service has no selector, so Kubernetes doesn't maintain its Endpoints.
+		serveEndpointSlice := &discoveryv1.EndpointSlice{}
+		if err := r.Get(ctx, common.RayServiceServeServiceNamespacedName(rayServiceInstance), serveEndpointSlice); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		for _, endpoint := range serveEndpointSlice.Endpoints {
+			if ptr.Deref(endpoint.Conditions.Ready, false) {
+				numServeEndpoints++
+			}
+		}
+	} else {
+		serveEndPoints := &corev1.Endpoints{}
+		if err := r.Get(ctx, common.RayServiceServeServiceNamespacedName(rayServiceInstance), serveEndPoints); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		// Ray Pod addresses are categorized into subsets based on the IPs they share.
+		// subset.Addresses contains a list of Ray Pod addresses with ready serve port.
+		for _, subset := range serveEndPoints.Subsets {
+			numServeEndpoints += len(subset.Addresses)
+		}
 	}
 	if numServeEndpoints > math.MaxInt32 {
 		return errstd.New("numServeEndpoints exceeds math.MaxInt32")
@@ -267,6 +531,13 @@ func (r *RayServiceReconciler) calculateStatus(ctx context.Context, rayServiceIn
 	return nil
 }
 
+// updateStatus updates the status of the RayService. The update isn't interrupted when the operator shuts down.
+func (r *RayServiceReconciler) updateStatus(ctx context.Context, rayServiceInstance *rayv1.RayService) error {
+	ctx, cancel := utils.WithoutShutdownCancel(ctx)
+	defer cancel()
+	return r.Status().Update(ctx, rayServiceInstance)
+}
+
 // Checks whether the old and new RayServiceStatus are inconsistent by comparing different fields.
 // If the only difference between the old and new status is the HealthLastUpdateTime field,
 // the status update will not be triggered.
@@ -296,6 +567,9 @@ func inconsistentRayServiceStatus(ctx context.Context, oldStatus rayv1.RayServic
 		} else if oldAppStatus.Message != newAppStatus.Message {
 			logger.Info("inconsistentRayServiceStatus RayService application status message changed", "appName", appName, "oldStatus", oldAppStatus.Message, "newStatus", newAppStatus.Message)
 			return true
+		} else if oldAppStatus.UpgradeStatus != newAppStatus.UpgradeStatus || oldAppStatus.ServeConfigHash != newAppStatus.ServeConfigHash {
+			logger.Info("inconsistentRayServiceStatus RayService application upgrade status changed", "appName", appName, "oldUpgradeStatus", oldAppStatus.UpgradeStatus, "newUpgradeStatus", newAppStatus.UpgradeStatus)
+			return true
 		}
 
 		if len(oldAppStatus.Deployments) != len(newAppStatus.Deployments) {
@@ -330,6 +604,11 @@ func inconsistentRayServiceStatuses(ctx context.Context, oldStatus rayv1.RayServ
 		return true
 	}
 
+	if oldStatus.HeadServiceName != newStatus.HeadServiceName || oldStatus.ServeServiceName != newStatus.ServeServiceName {
+		logger.Info("inconsistentRayServiceStatus RayService service names changed", "oldHeadServiceName", oldStatus.HeadServiceName, "newHeadServiceName", newStatus.HeadServiceName, "oldServeServiceName", oldStatus.ServeServiceName, "newServeServiceName", newStatus.ServeServiceName)
+		return true
+	}
+
 	if oldStatus.NumServeEndpoints != newStatus.NumServeEndpoints {
 		logger.Info("inconsistentRayServiceStatus RayService NumServeEndpoints changed", "oldNumServeEndpoints", oldStatus.NumServeEndpoints, "newNumServeEndpoints", newStatus.NumServeEndpoints)
 		return true
@@ -345,12 +624,37 @@ func inconsistentRayServiceStatuses(ctx context.Context, oldStatus rayv1.RayServ
 		return true
 	}
 
+	if !reflect.DeepEqual(oldStatus.Conditions, newStatus.Conditions) {
+		logger.Info("inconsistentRayServiceStatus RayService Conditions changed")
+		return true
+	}
+
+	if !reflect.DeepEqual(oldStatus.Journal, newStatus.Journal) {
+		logger.Info("inconsistentRayServiceStatus RayService Journal changed")
+		return true
+	}
+
+	if !reflect.DeepEqual(oldStatus.RequestShadowing, newStatus.RequestShadowing) {
+		logger.Info("inconsistentRayServiceStatus RayService RequestShadowing changed")
+		return true
+	}
+
+	if !reflect.DeepEqual(oldStatus.ServeConfigSource, newStatus.ServeConfigSource) {
+		logger.Info("inconsistentRayServiceStatus RayService ServeConfigSource changed")
+		return true
+	}
+
+	if !reflect.DeepEqual(oldStatus.ResourceUsage, newStatus.ResourceUsage) {
+		logger.Info("inconsistentRayServiceStatus RayService ResourceUsage changed")
+		return true
+	}
+
 	return false
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *RayServiceReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcurrency int) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&rayv1.RayService{}, builder.WithPredicates(predicate.Or(
 			predicate.GenerationChangedPredicate{},
 			predicate.LabelChangedPredicate{},
@@ -358,6 +662,15 @@ func (r *RayServiceReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcu
 		))).
 		Owns(&rayv1.RayCluster{}).
 		Owns(&corev1.Service{}).
+		Owns(&discoveryv1.EndpointSlice{}).
+		// The serve EndpointSlices of the topology routing follow the serve Pods.
+		Watches(&corev1.Pod{}, handler.EnqueueRequestsFromMapFunc(r.rayServicesForServePod),
+			builder.WithPredicates(servePodEndpointChangedPredicate()))
+	if utils.IsWatchNamespaceSelectorSet() {
+		b = b.Watches(&corev1.Namespace{}, utils.EnqueueObjectsInNamespace(mgr.GetClient(), &rayv1.RayServiceList{}),
+			builder.WithPredicates(predicate.LabelChangedPredicate{}))
+	}
+	return b.
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: reconcileConcurrency,
 			LogConstructor: func(request *reconcile.Request) logr.Logger {
@@ -371,6 +684,122 @@ func (r *RayServiceReconciler) SetupWithManager(mgr ctrl.Manager, reconcileConcu
 		Complete(r)
 }
 
+// rayServicesForServePod enqueues the RayService whose serve EndpointSlice includes the Pod, i.e. the RayService of the
+// RayCluster of a serve Pod or the RayService of a Pod of the maintenance responder, if its topology routing is Managed.
+func (r *RayServiceReconciler) rayServicesForServePod(ctx context.Context, obj client.Object) []reconcile.Request {
+	labels := obj.GetLabels()
+	name, ok := labels[utils.RayServiceMaintenanceLabelKey]
+	if !ok {
+		rayCluster := &rayv1.RayCluster{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: obj.GetNamespace(), Name: labels[utils.RayClusterLabelKey]}, rayCluster); err != nil {
+			return nil
+		}
+		if rayCluster.Labels[utils.RayOriginatedFromCRDLabelKey] != utils.RayOriginatedFromCRDLabelValue(utils.RayServiceCRD) {
+			return nil
+		}
+		name = rayCluster.Labels[utils.RayOriginatedFromCRNameLabelKey]
+	}
+	rayService := &rayv1.RayService{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: obj.GetNamespace(), Name: name}, rayService); err != nil {
+		return nil
+	}
+	if !utils.IsManagedServeTopologyRouting(rayService) {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: client.ObjectKeyFromObject(rayService)}}
+}
+
+// servePodEndpointChangedPredicate filters the events of the serve Pods and of the Pods of the maintenance responders
+// to the changes of their endpoints in the serve EndpointSlice.
+func servePodEndpointChangedPredicate() predicate.Predicate {
+	isServePod := func(obj client.Object) bool {
+		_, isServe := obj.GetLabels()[utils.RayClusterServingServiceLabelKey]
+		_, isMaintenance := obj.GetLabels()[utils.RayServiceMaintenanceLabelKey]
+		return isServe || isMaintenance
+	}
+	return predicate.Funcs{
+		CreateFunc:  func(e event.CreateEvent) bool { return isServePod(e.Object) },
+		DeleteFunc:  func(e event.DeleteEvent) bool { return isServePod(e.Object) },
+		GenericFunc: func(_ event.GenericEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldPod, okOld := e.ObjectOld.(*corev1.Pod)
+			newPod, okNew := e.ObjectNew.(*corev1.Pod)
+			if !okOld || !okNew || (!isServePod(oldPod) && !isServePod(newPod)) {
+				return false
+			}
+			return oldPod.Labels[utils.RayClusterServingServiceLabelKey] != newPod.Labels[utils.RayClusterServingServiceLabelKey] ||
+				utils.IsRunningAndReady(oldPod) != utils.IsRunningAndReady(newPod) ||
+				oldPod.Status.PodIP != newPod.Status.PodIP ||
+				oldPod.Spec.NodeName != newPod.Spec.NodeName ||
+				(oldPod.DeletionTimestamp == nil) != (newPod.DeletionTimestamp == nil)
+		},
+	}
+}
+
+// cleanUpRayService deletes the RayClusters, Services, HTTPRoute and Ingress created for the RayService, which is being
+// deleted, and removes the cleanup finalizer once they are gone or the cleanup timed out. The RayClusters are waited for
+// so that their own finalizers, e.g. the Redis cleanup of GCS fault tolerance, complete first, unless fast deletion is
+// enabled.
+func (r *RayServiceReconciler) cleanUpRayService(ctx context.Context, rayServiceInstance *rayv1.RayService) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	logger.Info("The RayService is being deleted. Clean up the objects created for it.", "finalizer", utils.RayCleanupFinalizer)
+
+	rayClusterList := rayv1.RayClusterList{}
+	if err := r.List(ctx, &rayClusterList, common.RayServiceRayClustersAssociationOptions(rayServiceInstance).ToListOptions()...); err != nil {
+		return ctrl.Result{RequeueAfter: utils.GetRayServiceRequeueInterval(false)}, err
+	}
+	var objects []client.Object
+	for i := range rayClusterList.Items {
+		objects = append(objects, &rayClusterList.Items[i])
+	}
+	headSvcName, err := utils.GenerateHeadServiceName(utils.RayServiceCRD, rayServiceInstance.Spec.RayClusterSpec, rayServiceInstance.Name)
+	if err != nil {
+		return ctrl.Result{RequeueAfter: utils.GetRayServiceRequeueInterval(false)}, err
+	}
+	serveSvcName := common.RayServiceServeServiceNamespacedName(rayServiceInstance).Name
+	for _, name := range []string{headSvcName, serveSvcName, utils.GeneratePreviewServeServiceName(rayServiceInstance.Name)} {
+		objects = append(objects, &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: rayServiceInstance.Namespace}})
+	}
+	objects = append(objects, &networkingv1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: utils.GenerateDashboardIngressName(rayServiceInstance.Name), Namespace: rayServiceInstance.Namespace}})
+	// The HTTPRoute is only looked up with the session affinity, because the Gateway API resources may not be installed.
+	if rayServiceInstance.Spec.ServeSessionAffinity != nil {
+		route := &unstructured.Unstructured{}
+		route.SetGroupVersionKind(common.HTTPRouteGroupVersionKind)
+		route.SetName(utils.GenerateServeHTTPRouteName(rayServiceInstance.Name))
+		route.SetNamespace(rayServiceInstance.Namespace)
+		objects = append(objects, route)
+	}
+	// The PrometheusRule is only looked up with the alerting, because the Prometheus Operator may not be installed.
+	if rayServiceInstance.Spec.ServeAlerting != nil {
+		rule := &unstructured.Unstructured{}
+		rule.SetGroupVersionKind(common.PrometheusRuleGroupVersionKind)
+		rule.SetName(utils.GenerateServeAlertsRuleName(rayServiceInstance.Name))
+		rule.SetNamespace(rayServiceInstance.Namespace)
+		objects = append(objects, rule)
+	}
+
+	originalConditions := slices.Clone(rayServiceInstance.Status.Conditions)
+	done, err := cleanUpControlledObjects(ctx, r.Client, r.Recorder, rayServiceInstance, &rayServiceInstance.Status.Conditions, string(rayv1.CleanupSucceeded), objects...)
+	if err != nil {
+		return ctrl.Result{RequeueAfter: utils.GetRayServiceRequeueInterval(false)}, err
+	}
+	if !reflect.DeepEqual(originalConditions, rayServiceInstance.Status.Conditions) {
+		if err := r.updateStatus(ctx, rayServiceInstance); err != nil {
+			return ctrl.Result{RequeueAfter: utils.GetRayServiceRequeueInterval(false)}, err
+		}
+	}
+	if !done && !utils.IsFastDeletionEnabled(ctx, r.Client, rayServiceInstance.Namespace) {
+		return ctrl.Result{RequeueAfter: utils.GetRayServiceRequeueInterval(false)}, nil
+	}
+
+	logger.Info("Remove the finalizer", "finalizer", utils.RayCleanupFinalizer)
+	controllerutil.RemoveFinalizer(rayServiceInstance, utils.RayCleanupFinalizer)
+	if err := r.Update(ctx, rayServiceInstance); err != nil {
+		return ctrl.Result{RequeueAfter: utils.GetRayServiceRequeueInterval(false)}, err
+	}
+	return ctrl.Result{}, nil
+}
+
 func (r *RayServiceReconciler) getRayServiceInstance(ctx context.Context, request ctrl.Request) (*rayv1.RayService, error) {
 	logger := ctrl.LoggerFrom(ctx)
 	rayServiceInstance := &rayv1.RayService{}
@@ -418,6 +847,11 @@ func isZeroDowntimeUpgradeEnabled(ctx context.Context, rayService *rayv1.RayServ
 func (r *RayServiceReconciler) reconcileRayCluster(ctx context.Context, rayServiceInstance *rayv1.RayService) (*rayv1.RayCluster, *rayv1.RayCluster, error) {
 	logger := ctrl.LoggerFrom(ctx)
 	var err error
+	if isRayServiceUpgradeAborted(rayServiceInstance) && rayServiceInstance.Status.ActiveServiceStatus.RayClusterName != "" {
+		if err = r.abortRayServiceUpgrade(ctx, rayServiceInstance); err != nil {
+			return nil, nil, err
+		}
+	}
 	if err = r.cleanUpRayClusterInstance(ctx, rayServiceInstance); err != nil {
 		return nil, nil, err
 	}
@@ -434,6 +868,7 @@ func (r *RayServiceReconciler) reconcileRayCluster(ctx context.Context, rayServi
 	}
 
 	clusterAction := decideClusterAction(ctx, rayServiceInstance, activeRayCluster, pendingRayCluster)
+	setKubeRayVersionUpdateDeferredCondition(rayServiceInstance, activeRayCluster)
 	switch clusterAction {
 	case GeneratePendingClusterName:
 		markRestartAndAddPendingClusterName(ctx, rayServiceInstance)
@@ -461,6 +896,11 @@ func (r *RayServiceReconciler) reconcileRayCluster(ctx context.Context, rayServi
 		if err := r.updateRayClusterInstance(ctx, activeRayCluster); err != nil {
 			return nil, nil, err
 		}
+		// Remove the approval so that the next upgrade of KubeRay needs to be approved again.
+		if err := r.removeRayServiceAnnotation(ctx, rayServiceInstance, utils.RayServiceKubeRayVersionUpdateAnnotationKey); err != nil {
+			return nil, nil, err
+		}
+		setKubeRayVersionUpdateDeferredCondition(rayServiceInstance, activeRayCluster)
 		return activeRayCluster, nil, nil
 	case DoNothing:
 		return activeRayCluster, pendingRayCluster, nil
@@ -469,7 +909,77 @@ func (r *RayServiceReconciler) reconcileRayCluster(ctx context.Context, rayServi
 	}
 }
 
+// isRayServiceUpgradeAborted returns whether the upgrade of a RayService with the Manual promotion is aborted.
+func isRayServiceUpgradeAborted(rayServiceInstance *rayv1.RayService) bool {
+	return utils.IsManualPromotionEnabled(rayServiceInstance) && rayServiceInstance.Annotations[utils.RayServiceAbortAnnotationKey] == "true"
+}
+
+// isKubeRayVersionUpdateDeferred returns whether the update of the active RayCluster to the version of KubeRay waits
+// for an approval.
+func isKubeRayVersionUpdateDeferred(rayServiceInstance *rayv1.RayService) bool {
+	return utils.IsManualKubeRayVersionPolicy(rayServiceInstance) &&
+		rayServiceInstance.Annotations[utils.RayServiceKubeRayVersionUpdateAnnotationKey] != "true"
+}
+
+// setKubeRayVersionUpdateDeferredCondition sets the KubeRayVersionUpdateDeferred condition while the active RayCluster
+// waits for the approval of its update to the version of KubeRay, and removes it otherwise.
+func setKubeRayVersionUpdateDeferredCondition(rayServiceInstance *rayv1.RayService, activeRayCluster *rayv1.RayCluster) {
+	if activeRayCluster == nil || activeRayCluster.Annotations[utils.KubeRayVersion] == utils.KUBERAY_VERSION ||
+		!isKubeRayVersionUpdateDeferred(rayServiceInstance) {
+		meta.RemoveStatusCondition(&rayServiceInstance.Status.Conditions, string(rayv1.KubeRayVersionUpdateDeferred))
+		return
+	}
+	meta.SetStatusCondition(&rayServiceInstance.Status.Conditions, metav1.Condition{
+		Type:   string(rayv1.KubeRayVersionUpdateDeferred),
+		Status: metav1.ConditionTrue,
+		Reason: rayv1.KubeRayVersionMismatch,
+		Message: fmt.Sprintf("The active RayCluster %s was created by KubeRay %s. Annotate the RayService with %s: \"true\" to update it to KubeRay %s",
+			activeRayCluster.Name, activeRayCluster.Annotations[utils.KubeRayVersion], utils.RayServiceKubeRayVersionUpdateAnnotationKey, utils.KUBERAY_VERSION),
+	})
+}
+
+// abortRayServiceUpgrade deletes the pending RayCluster and keeps serving the traffic with the active RayCluster. No new
+// pending RayCluster is prepared until the abort annotation is removed.
+func (r *RayServiceReconciler) abortRayServiceUpgrade(ctx context.Context, rayServiceInstance *rayv1.RayService) error {
+	logger := ctrl.LoggerFrom(ctx)
+	pendingClusterKey := common.RayServicePendingRayClusterNamespacedName(rayServiceInstance)
+	if pendingClusterKey.Name == "" {
+		return nil
+	}
+
+	logger.Info("The upgrade is aborted. Deleting the pending RayCluster.", "rayCluster", pendingClusterKey.Name)
+	pendingRayCluster := &rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Name: pendingClusterKey.Name, Namespace: pendingClusterKey.Namespace}}
+	if err := r.Delete(ctx, pendingRayCluster, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+		r.Recorder.Eventf(rayServiceInstance, corev1.EventTypeWarning, string(utils.FailedToAbortRayServiceUpgrade),
+			"Failed to delete the pending RayCluster %s/%s: %v", pendingClusterKey.Namespace, pendingClusterKey.Name, err)
+		return err
+	}
+	r.Recorder.Eventf(rayServiceInstance, corev1.EventTypeNormal, string(utils.AbortedRayServiceUpgrade),
+		"Aborted the upgrade and deleted the pending RayCluster %s/%s", pendingClusterKey.Namespace, pendingClusterKey.Name)
+	rayServiceInstance.Status.PendingServiceStatus = rayv1.RayServiceStatus{}
+	rayServiceInstance.Status.ServiceStatus = rayv1.Running
+	return nil
+}
+
+// removeRayServiceAnnotation removes an annotation from the RayService. The patch is applied to a copy of the RayService
+// so that the in-memory status, which is updated at the end of the reconciliation, is not overwritten.
+func (r *RayServiceReconciler) removeRayServiceAnnotation(ctx context.Context, rayServiceInstance *rayv1.RayService, key string) error {
+	if _, ok := rayServiceInstance.Annotations[key]; !ok {
+		return nil
+	}
+	patchedRayService := rayServiceInstance.DeepCopy()
+	delete(patchedRayService.Annotations, key)
+	if err := r.Patch(ctx, patchedRayService, client.MergeFrom(rayServiceInstance)); err != nil {
+		return err
+	}
+	rayServiceInstance.Annotations = patchedRayService.Annotations
+	rayServiceInstance.ResourceVersion = patchedRayService.ResourceVersion
+	return nil
+}
+
 // cleanUpRayClusterInstance cleans up all the dangling RayCluster instances that are owned by the RayService instance.
+// A dangling RayCluster is annotated with the time it's deleted at, RayClusterDeletionDelayDuration after it's found,
+// so that the delay isn't restarted when the operator restarts.
 func (r *RayServiceReconciler) cleanUpRayClusterInstance(ctx context.Context, rayServiceInstance *rayv1.RayService) error {
 	logger := ctrl.LoggerFrom(ctx)
 	rayClusterList := rayv1.RayClusterList{}
@@ -481,11 +991,31 @@ func (r *RayServiceReconciler) cleanUpRayClusterInstance(ctx context.Context, ra
 
 	// Clean up RayCluster instances. Each instance is deleted 60 seconds
 	for _, rayClusterInstance := range rayClusterList.Items {
-		if rayClusterInstance.Name != rayServiceInstance.Status.ActiveServiceStatus.RayClusterName && rayClusterInstance.Name != rayServiceInstance.Status.PendingServiceStatus.RayClusterName {
-			cachedTimestamp, exists := r.RayClusterDeletionTimestamps.Get(rayClusterInstance.Name)
-			if !exists {
+		if !rayClusterInstance.DeletionTimestamp.IsZero() {
+			continue
+		}
+		value, exists := rayClusterInstance.Annotations[utils.RayServiceClusterDeletionTimestampAnnotationKey]
+		if rayClusterInstance.Name == rayServiceInstance.Status.ActiveServiceStatus.RayClusterName || rayClusterInstance.Name == rayServiceInstance.Status.PendingServiceStatus.RayClusterName {
+			// The deletion of a RayCluster that is active or pending again, e.g. after a rollback, is canceled.
+			if exists {
+				patchedRayCluster := rayClusterInstance.DeepCopy()
+				delete(patchedRayCluster.Annotations, utils.RayServiceClusterDeletionTimestampAnnotationKey)
+				if err := r.Patch(ctx, patchedRayCluster, client.MergeFrom(&rayClusterInstance)); err != nil {
+					return err
+				}
+			}
+		} else {
+			scheduledTimestamp, parseErr := time.Parse(time.RFC3339, value)
+			if !exists || parseErr != nil {
 				deletionTimestamp := metav1.Now().Add(RayClusterDeletionDelayDuration)
-				r.RayClusterDeletionTimestamps.Set(rayClusterInstance.Name, deletionTimestamp)
+				patchedRayCluster := rayClusterInstance.DeepCopy()
+				if patchedRayCluster.Annotations == nil {
+					patchedRayCluster.Annotations = map[string]string{}
+				}
+				patchedRayCluster.Annotations[utils.RayServiceClusterDeletionTimestampAnnotationKey] = deletionTimestamp.UTC().Format(time.RFC3339)
+				if err := r.Patch(ctx, patchedRayCluster, client.MergeFrom(&rayClusterInstance)); err != nil {
+					return err
+				}
 				logger.Info(
 					"Scheduled dangling RayCluster for deletion",
 					"rayClusterName", rayClusterInstance.Name,
@@ -493,10 +1023,10 @@ func (r *RayServiceReconciler) cleanUpRayClusterInstance(ctx context.Context, ra
 				)
 			} else {
 				reasonForDeletion := ""
-				if time.Since(cachedTimestamp) > 0*time.Second {
+				if time.Since(scheduledTimestamp) > 0*time.Second {
 					reasonForDeletion = fmt.Sprintf("Deletion timestamp %s "+
 						"for RayCluster %s has passed. Deleting cluster "+
-						"immediately.", cachedTimestamp, rayClusterInstance.Name)
+						"immediately.", scheduledTimestamp, rayClusterInstance.Name)
 				}
 
 				if reasonForDeletion != "" {
@@ -512,6 +1042,81 @@ func (r *RayServiceReconciler) cleanUpRayClusterInstance(ctx context.Context, ra
 	return nil
 }
 
+// reconcileDebugRayCluster creates the debug RayCluster of a RayService annotated with
+// RayServiceDebugClusterAnnotationKey from its active RayCluster, applies the Serve config to it once its head Pod is
+// ready, and deletes it once the annotation is removed. The debug RayCluster is a snapshot of the active RayCluster, so
+// it isn't updated with it.
+func (r *RayServiceReconciler) reconcileDebugRayCluster(ctx context.Context, rayServiceInstance *rayv1.RayService, activeRayClusterInstance *rayv1.RayCluster) error {
+	logger := ctrl.LoggerFrom(ctx)
+	key := client.ObjectKey{Name: utils.GenerateDebugRayClusterName(rayServiceInstance.Name), Namespace: rayServiceInstance.Namespace}
+	debugRayCluster := &rayv1.RayCluster{}
+	err := r.Get(ctx, key, debugRayCluster)
+	if client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	exists := err == nil
+	if exists && !metav1.IsControlledBy(debugRayCluster, rayServiceInstance) {
+		return fmt.Errorf("the RayCluster %s/%s isn't the debug RayCluster of the RayService", key.Namespace, key.Name)
+	}
+
+	if rayServiceInstance.Annotations[utils.RayServiceDebugClusterAnnotationKey] != "true" {
+		if !exists || !debugRayCluster.DeletionTimestamp.IsZero() {
+			return nil
+		}
+		logger.Info("Deleting the debug RayCluster", "rayCluster", key.Name)
+		if err := r.Delete(ctx, debugRayCluster, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		r.Recorder.Eventf(rayServiceInstance, corev1.EventTypeNormal, string(utils.DeletedDebugRayCluster),
+			"Deleted the debug RayCluster %s/%s", key.Namespace, key.Name)
+		return nil
+	}
+
+	if !exists {
+		if activeRayClusterInstance == nil {
+			// The debug RayCluster is created once there is an active RayCluster to copy.
+			return nil
+		}
+		debugRayCluster = common.BuildDebugRayClusterForRayService(rayServiceInstance, activeRayClusterInstance)
+		if err := ctrl.SetControllerReference(rayServiceInstance, debugRayCluster, r.Scheme); err != nil {
+			return err
+		}
+		logger.Info("Creating the debug RayCluster", "rayCluster", key.Name, "activeRayCluster", activeRayClusterInstance.Name)
+		if err := r.Create(ctx, debugRayCluster); err != nil {
+			r.Recorder.Eventf(rayServiceInstance, corev1.EventTypeWarning, string(utils.FailedToCreateRayCluster),
+				"Failed to create the debug RayCluster %s/%s: %v", key.Namespace, key.Name, err)
+			return err
+		}
+		r.Recorder.Eventf(rayServiceInstance, corev1.EventTypeNormal, string(utils.CreatedDebugRayCluster),
+			"Created the debug RayCluster %s/%s from the active RayCluster %s", key.Namespace, key.Name, activeRayClusterInstance.Name)
+		return nil
+	}
+	if !debugRayCluster.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	serveConfigV2, err := r.resolveServeConfigV2(ctx, rayServiceInstance)
+	if err != nil {
+		return err
+	}
+	if r.getServeConfigFromCache(rayServiceInstance, key.Name) == serveConfigV2 {
+		return nil
+	}
+	headPod, err := common.GetRayClusterHeadPod(ctx, r, debugRayCluster)
+	if err != nil {
+		return err
+	}
+	if headPod == nil || !utils.IsRunningAndReady(headPod) {
+		logger.Info("Skipping the update of the Serve applications of the debug RayCluster because its head Pod is not ready", "rayCluster", key.Name)
+		return nil
+	}
+	rayDashboardClient, err := r.newServeClient(ctx, rayServiceInstance, debugRayCluster)
+	if err != nil {
+		return err
+	}
+	return r.updateServeDeployment(ctx, rayServiceInstance, rayDashboardClient, key.Name, serveConfigV2)
+}
+
 func (r *RayServiceReconciler) getRayClusterByNamespacedName(ctx context.Context, clusterKey client.ObjectKey) (*rayv1.RayCluster, error) {
 	if clusterKey.Name == "" {
 		return nil, nil
@@ -538,8 +1143,9 @@ func (r *RayServiceReconciler) cleanUpServeConfigCache(ctx context.Context, rayS
 	}
 	clusterNameToServeConfig := cacheValue.(cmap.ConcurrentMap[string, string])
 
+	debugRayClusterName := utils.GenerateDebugRayClusterName(rayServiceInstance.Name)
 	for key := range clusterNameToServeConfig.Items() {
-		if key == activeRayClusterName || key == pendingRayClusterName {
+		if key == activeRayClusterName || key == pendingRayClusterName || key == debugRayClusterName {
 			continue
 		}
 		logger.Info("Remove stale serve application config", "remove key", key, "activeRayClusterName", activeRayClusterName, "pendingRayClusterName", pendingRayClusterName)
@@ -567,10 +1173,14 @@ func decideClusterAction(ctx context.Context, rayServiceInstance *rayv1.RayServi
 	// Handle pending RayCluster cases.
 	if rayServiceInstance.Status.PendingServiceStatus.RayClusterName != "" {
 		oldSpec := pendingRayCluster.Spec
-		newSpec := rayServiceInstance.Spec.RayClusterSpec
+		newSpec := rayClusterSpecForRayService(rayServiceInstance)
 		// If everything is identical except for the Replicas and WorkersToDelete of
 		// each WorkerGroup, then do nothing.
-		sameHash, err := compareRayClusterJsonHash(oldSpec, newSpec, generateHashWithoutReplicasAndWorkersToDelete)
+		ignoredPaths := getIgnoredPaths(rayServiceInstance)
+		hashFunc := func(spec rayv1.RayClusterSpec) (string, error) {
+			return generateHashWithoutReplicasAndWorkersToDelete(spec, ignoredPaths)
+		}
+		sameHash, err := compareRayClusterJsonHash(oldSpec, newSpec, hashFunc)
 		if err != nil || sameHash {
 			return DoNothing
 		}
@@ -582,7 +1192,7 @@ func decideClusterAction(ctx context.Context, rayServiceInstance *rayv1.RayServi
 			// Remove the new worker groups from the new spec.
 			newSpecWithAddedWorkerGroupsStripped.WorkerGroupSpecs = newSpecWithAddedWorkerGroupsStripped.WorkerGroupSpecs[:len(oldSpec.WorkerGroupSpecs)]
 
-			sameHash, err = compareRayClusterJsonHash(oldSpec, *newSpecWithAddedWorkerGroupsStripped, generateHashWithoutReplicasAndWorkersToDelete)
+			sameHash, err = compareRayClusterJsonHash(oldSpec, *newSpecWithAddedWorkerGroupsStripped, hashFunc)
 			if err != nil {
 				return DoNothing
 			}
@@ -603,6 +1213,13 @@ func decideClusterAction(ctx context.Context, rayServiceInstance *rayv1.RayServi
 	// If the KubeRay version has changed, update the RayCluster to get the cluster hash and new KubeRay version.
 	activeKubeRayVersion := activeRayCluster.ObjectMeta.Annotations[utils.KubeRayVersion]
 	if activeKubeRayVersion != utils.KUBERAY_VERSION {
+		// The hash of the active RayCluster may be computed differently by the previous version of KubeRay, so the
+		// RayCluster isn't compared with the spec until the update is approved.
+		if isKubeRayVersionUpdateDeferred(rayServiceInstance) {
+			logger.Info("The update of the active RayCluster to the KubeRay version is deferred until the RayService is annotated with "+
+				utils.RayServiceKubeRayVersionUpdateAnnotationKey, "activeKubeRayVersion", activeKubeRayVersion, "kubeRayVersion", utils.KUBERAY_VERSION)
+			return DoNothing
+		}
 		logger.Info("Active RayCluster config doesn't match goal config due to mismatched KubeRay versions. Updating RayCluster.")
 		return UpdateActiveCluster
 	}
@@ -610,7 +1227,7 @@ func decideClusterAction(ctx context.Context, rayServiceInstance *rayv1.RayServi
 	// If everything is identical except for the Replicas and WorkersToDelete of
 	// each WorkerGroup, then do nothing.
 	activeClusterHash := activeRayCluster.ObjectMeta.Annotations[utils.HashWithoutReplicasAndWorkersToDeleteKey]
-	goalClusterHash, err := generateHashWithoutReplicasAndWorkersToDelete(rayServiceInstance.Spec.RayClusterSpec)
+	goalClusterHash, err := generateHashWithoutReplicasAndWorkersToDelete(rayClusterSpecForRayService(rayServiceInstance), getIgnoredPaths(rayServiceInstance))
 	errContextFailedToSerialize := "Failed to serialize new RayCluster config. " +
 		"Manual config updates will NOT be tracked accurately. " +
 		"Please manually tear down the cluster and apply a new config."
@@ -636,11 +1253,11 @@ func decideClusterAction(ctx context.Context, rayServiceInstance *rayv1.RayServi
 	if goalNumWorkerGroups > activeClusterNumWorkerGroups {
 
 		// Remove the new workergroup(s) from the end before calculating the hash.
-		goalClusterSpec := rayServiceInstance.Spec.RayClusterSpec.DeepCopy()
+		goalClusterSpec := rayClusterSpecForRayService(rayServiceInstance)
 		goalClusterSpec.WorkerGroupSpecs = goalClusterSpec.WorkerGroupSpecs[:activeClusterNumWorkerGroups]
 
 		// Generate the hash of the old worker group specs.
-		goalClusterHash, err = generateHashWithoutReplicasAndWorkersToDelete(*goalClusterSpec)
+		goalClusterHash, err = generateHashWithoutReplicasAndWorkersToDelete(goalClusterSpec, getIgnoredPaths(rayServiceInstance))
 		if err != nil {
 			logger.Error(err, errContextFailedToSerialize)
 			return DoNothing
@@ -652,6 +1269,11 @@ func decideClusterAction(ctx context.Context, rayServiceInstance *rayv1.RayServi
 		}
 	}
 
+	if isRayServiceUpgradeAborted(rayServiceInstance) {
+		logger.Info("The upgrade is aborted by the " + utils.RayServiceAbortAnnotationKey + " annotation. Skip preparing a new RayCluster.")
+		return DoNothing
+	}
+
 	// Otherwise, rollout a new cluster if zero-downtime upgrade is enabled.
 	if isZeroDowntimeUpgradeEnabled(ctx, rayServiceInstance) {
 		logger.Info(
@@ -690,9 +1312,17 @@ func (r *RayServiceReconciler) updateRayClusterInstance(ctx context.Context, ray
 	// Update the fetched RayCluster with new changes
 	currentRayCluster.Spec = rayClusterInstance.Spec
 
-	// Update the labels and annotations
+	// Update the labels and annotations. The hash of the applied Serve config is kept, since the Serve config isn't
+	// applied again when the RayCluster is updated.
 	currentRayCluster.Labels = rayClusterInstance.Labels
+	serveConfigHash, hasServeConfigHash := currentRayCluster.Annotations[utils.RayServiceServeConfigHashAnnotationKey]
 	currentRayCluster.Annotations = rayClusterInstance.Annotations
+	if hasServeConfigHash {
+		if currentRayCluster.Annotations == nil {
+			currentRayCluster.Annotations = map[string]string{}
+		}
+		currentRayCluster.Annotations[utils.RayServiceServeConfigHashAnnotationKey] = serveConfigHash
+	}
 
 	// Update the RayCluster
 	return r.Update(ctx, currentRayCluster)
@@ -714,7 +1344,9 @@ func (r *RayServiceReconciler) createRayClusterInstance(ctx context.Context, ray
 
 	// If RayCluster exists, it means the config is updated. Delete the previous RayCluster first.
 	if err == nil {
-		logger.Info("Ray cluster already exists, config changes. Need to recreate. Delete the pending one now.", "key", rayClusterKey.String(), "rayClusterInstance.Spec", rayClusterInstance.Spec, "rayServiceInstance.Spec.RayClusterSpec", rayServiceInstance.Spec.RayClusterSpec)
+		logger.Info("Ray cluster already exists, config changes. Need to recreate. Delete the pending one now.", "key", rayClusterKey.String())
+		logger.V(1).Info("Ray cluster already exists, config changes.", "rayClusterInstance.Spec", utils.RedactRayClusterSpec(&rayClusterInstance.Spec),
+			"rayServiceInstance.Spec.RayClusterSpec", utils.RedactRayClusterSpec(&rayServiceInstance.Spec.RayClusterSpec))
 		delErr := r.Delete(ctx, rayClusterInstance, client.PropagationPolicy(metav1.DeletePropagationBackground))
 		if delErr == nil {
 			// Go to next loop and check if the ray cluster is deleted.
@@ -733,10 +1365,14 @@ func (r *RayServiceReconciler) createRayClusterInstance(ctx context.Context, ray
 	if err != nil {
 		return nil, err
 	}
+	if err = dryRunCreate(ctx, r.Client, r.Recorder, rayServiceInstance, rayClusterInstance); err != nil {
+		return nil, err
+	}
 	if err = r.Create(ctx, rayClusterInstance); err != nil {
 		return nil, err
 	}
-	logger.Info("created rayCluster for rayService", "rayCluster", rayClusterInstance)
+	logger.Info("created rayCluster for rayService", "rayCluster", rayClusterInstance.Name)
+	logger.V(1).Info("created rayCluster for rayService", "rayCluster.Spec", utils.RedactRayClusterSpec(&rayClusterInstance.Spec))
 
 	return rayClusterInstance, nil
 }
@@ -749,6 +1385,12 @@ func (r *RayServiceReconciler) constructRayClusterForRayService(ctx context.Cont
 	for k, v := range rayService.Labels {
 		rayClusterLabel[k] = v
 	}
+	for k, v := range rayService.Spec.QueueLabels {
+		rayClusterLabel[k] = v
+	}
+	if rayService.Spec.PriorityClassName != "" {
+		rayClusterLabel[utils.RayPriorityClassName] = rayService.Spec.PriorityClassName
+	}
 	rayClusterLabel[utils.RayOriginatedFromCRNameLabelKey] = rayService.Name
 	rayClusterLabel[utils.RayOriginatedFromCRDLabelKey] = utils.RayOriginatedFromCRDLabelValue(utils.RayServiceCRD)
 
@@ -759,7 +1401,8 @@ func (r *RayServiceReconciler) constructRayClusterForRayService(ctx context.Cont
 	errContext := "Failed to serialize RayCluster config. " +
 		"Manual config updates will NOT be tracked accurately. " +
 		"Please tear down the cluster and apply a new config."
-	rayClusterAnnotations[utils.HashWithoutReplicasAndWorkersToDeleteKey], err = generateHashWithoutReplicasAndWorkersToDelete(rayService.Spec.RayClusterSpec)
+	rayClusterSpec := rayClusterSpecForRayService(rayService)
+	rayClusterAnnotations[utils.HashWithoutReplicasAndWorkersToDeleteKey], err = generateHashWithoutReplicasAndWorkersToDelete(rayClusterSpec, getIgnoredPaths(rayService))
 	if err != nil {
 		logger.Error(err, errContext)
 		return nil, err
@@ -776,7 +1419,7 @@ func (r *RayServiceReconciler) constructRayClusterForRayService(ctx context.Cont
 			Name:        rayClusterName,
 			Namespace:   rayService.Namespace,
 		},
-		Spec: rayService.Spec.RayClusterSpec,
+		Spec: rayClusterSpec,
 	}
 
 	// Set the ownership in order to do the garbage collection by k8s.
@@ -787,7 +1430,18 @@ func (r *RayServiceReconciler) constructRayClusterForRayService(ctx context.Cont
 	return rayCluster, nil
 }
 
-func (r *RayServiceReconciler) checkIfNeedSubmitServeDeployment(ctx context.Context, rayServiceInstance *rayv1.RayService, rayClusterInstance *rayv1.RayCluster, serveStatus *rayv1.RayServiceStatus) bool {
+// rayClusterSpecForRayService returns the spec of the RayClusters created for the RayService.
+func rayClusterSpecForRayService(rayService *rayv1.RayService) rayv1.RayClusterSpec {
+	spec := rayService.Spec.RayClusterSpec.DeepCopy()
+	common.AddImagePullSecretsToRayClusterSpec(spec, rayService.Spec.ImagePullSecrets)
+	common.SetSchedulingToRayClusterSpec(spec, rayService.Spec.PriorityClassName, rayService.Spec.SchedulerName)
+	common.AddWorkerGroupResources(spec, rayService.Spec.ServeApplicationWorkerGroups)
+	return *spec
+}
+
+// checkIfNeedSubmitServeDeployment returns whether the Serve config, with its variables substituted, needs to be
+// submitted to the RayCluster.
+func (r *RayServiceReconciler) checkIfNeedSubmitServeDeployment(ctx context.Context, rayServiceInstance *rayv1.RayService, rayClusterInstance *rayv1.RayCluster, serveStatus *rayv1.RayServiceStatus, serveConfigV2 string) bool {
 	logger := ctrl.LoggerFrom(ctx)
 
 	// If the Serve config has not been cached, update the Serve config.
@@ -821,30 +1475,35 @@ func (r *RayServiceReconciler) checkIfNeedSubmitServeDeployment(ctx context.Cont
 	reason := fmt.Sprintf("Current Serve config matches cached Serve config, "+
 		"and some deployments have been deployed for cluster %s", rayClusterInstance.Name)
 
-	if cachedServeConfigV2 != rayServiceInstance.Spec.ServeConfigV2 {
+	if cachedServeConfigV2 != serveConfigV2 {
 		shouldUpdate = true
 		reason = fmt.Sprintf("Current V2 Serve config doesn't match cached Serve config for cluster %s", rayClusterInstance.Name)
 	}
-	logger.Info("shouldUpdate", "shouldUpdateServe", shouldUpdate, "reason", reason, "cachedServeConfig", cachedServeConfigV2, "current Serve config", rayServiceInstance.Spec.ServeConfigV2)
+	// The cached config isn't logged because the substituted variables may come from Secrets.
+	logger.Info("shouldUpdate", "shouldUpdateServe", shouldUpdate, "reason", reason)
+	logger.V(2).Info("shouldUpdate", "current Serve config", utils.RedactServeConfigV2(rayServiceInstance.Spec.ServeConfigV2))
 
 	return shouldUpdate
 }
 
-func (r *RayServiceReconciler) updateServeDeployment(ctx context.Context, rayServiceInstance *rayv1.RayService, rayDashboardClient utils.RayDashboardClientInterface, clusterName string) error {
+func (r *RayServiceReconciler) updateServeDeployment(ctx context.Context, rayServiceInstance *rayv1.RayService, rayDashboardClient utils.RayDashboardClientInterface, clusterName string, serveConfigV2 string) error {
 	logger := ctrl.LoggerFrom(ctx)
-	logger.Info("updateServeDeployment", "V2 config", rayServiceInstance.Spec.ServeConfigV2)
+	logger.V(2).Info("updateServeDeployment", "V2 config", utils.RedactServeConfigV2(rayServiceInstance.Spec.ServeConfigV2))
 
 	serveConfig := make(map[string]interface{})
-	if err := yaml.Unmarshal([]byte(rayServiceInstance.Spec.ServeConfigV2), &serveConfig); err != nil {
+	if err := yaml.Unmarshal([]byte(serveConfigV2), &serveConfig); err != nil {
 		return err
 	}
+	common.ApplyServeApplicationWorkerGroups(serveConfig, rayServiceInstance.Spec.ServeApplicationWorkerGroups)
 
 	configJson, err := json.Marshal(serveConfig)
 	if err != nil {
 		return fmt.Errorf("failed to marshal converted serve config into bytes: %w", err)
 	}
-	logger.Info("updateServeDeployment", "MULTI_APP json config", string(configJson))
-	if err := rayDashboardClient.UpdateDeployments(ctx, configJson); err != nil {
+	// The submission of the Serve config isn't interrupted when the operator shuts down.
+	submitCtx, cancel := utils.WithoutShutdownCancel(ctx)
+	defer cancel()
+	if err := rayDashboardClient.UpdateDeployments(submitCtx, configJson); err != nil {
 		err = fmt.Errorf(
 			"fail to create / update Serve applications. If you observe this error consistently, "+
 				"please check \"Issue 5: Fail to create / update Serve applications.\" in "+
@@ -853,7 +1512,7 @@ func (r *RayServiceReconciler) updateServeDeployment(ctx context.Context, raySer
 		return err
 	}
 
-	r.cacheServeConfig(rayServiceInstance, clusterName)
+	r.cacheServeConfig(rayServiceInstance, clusterName, serveConfigV2)
 	logger.Info("updateServeDeployment", "message", "Cached Serve config for Ray cluster with the key", "rayClusterName", clusterName)
 	return nil
 }
@@ -876,7 +1535,7 @@ func getAndCheckServeStatus(ctx context.Context, dashboardClient utils.RayDashbo
 		return false, err
 	}
 
-	logger.Info("getAndCheckServeStatus", "prev statuses", rayServiceServeStatus.Applications, "serve statuses", serveAppStatuses)
+	logger.V(1).Info("getAndCheckServeStatus", "prev statuses", rayServiceServeStatus.Applications, "serve statuses", serveAppStatuses)
 
 	isReady := true
 	timeNow := metav1.Now()
@@ -920,6 +1579,7 @@ func getAndCheckServeStatus(ctx context.Context, dashboardClient utils.RayDashbo
 				Status:               deployment.Status,
 				Message:              deployment.Message,
 				HealthLastUpdateTime: &timeNow,
+				Replicas:             summarizeServeReplicas(deployment.Replicas, timeNow.Time),
 			}
 
 			if deployment.Status == rayv1.DeploymentStatusEnum.UNHEALTHY {
@@ -940,27 +1600,297 @@ func getAndCheckServeStatus(ctx context.Context, dashboardClient utils.RayDashbo
 		isReady = false
 	}
 	rayServiceServeStatus.Applications = newApplications
-	logger.Info("getAndCheckServeStatus", "new statuses", rayServiceServeStatus.Applications)
+	logger.V(1).Info("getAndCheckServeStatus", "new statuses", rayServiceServeStatus.Applications)
 	return isReady, nil
 }
 
-func (r *RayServiceReconciler) getServeConfigFromCache(rayServiceInstance *rayv1.RayService, clusterName string) string {
-	cacheKey := rayServiceInstance.Namespace + "/" + rayServiceInstance.Name
-	cacheValue, exist := r.ServeConfigs.Get(cacheKey)
-	if !exist {
-		return ""
+// serveApplicationConfigHashes returns the hash of the entry of each application in the Serve config, keyed by the name
+// of the application.
+func serveApplicationConfigHashes(serveConfigV2 string) (map[string]string, error) {
+	serveConfig := struct {
+		Applications []map[string]interface{} `json:"applications"`
+	}{}
+	if err := yaml.Unmarshal([]byte(serveConfigV2), &serveConfig); err != nil {
+		return nil, err
 	}
-	serveConfigs := cacheValue.(cmap.ConcurrentMap[string, string])
-	serveConfig, exist := serveConfigs.Get(clusterName)
-	if !exist {
-		return ""
+	hashes := make(map[string]string, len(serveConfig.Applications))
+	for _, app := range serveConfig.Applications {
+		appName, _ := app["name"].(string)
+		if appName == "" {
+			appName = utils.DefaultServeAppName
+		}
+		hash, err := utils.GenerateJsonHash(app)
+		if err != nil {
+			return nil, err
+		}
+		hashes[appName] = hash
 	}
-	return serveConfig
+	return hashes, nil
 }
 
-func (r *RayServiceReconciler) cacheServeConfig(rayServiceInstance *rayv1.RayService, clusterName string) {
-	serveConfig := rayServiceInstance.Spec.ServeConfigV2
-	if serveConfig == "" {
+// updateServeApplicationUpgradeStatuses sets the upgrade status of each Serve application: an application whose entry
+// in the submitted Serve config changed is upgrading until it is running, and its upgrade fails if it can't be deployed.
+func (r *RayServiceReconciler) updateServeApplicationUpgradeStatuses(rayServiceInstance *rayv1.RayService, rayClusterInstance *rayv1.RayCluster, prevApplications, applications map[string]rayv1.AppStatus, appConfigHashes map[string]string) {
+	for appName, app := range applications {
+		prevApp, hasPrevApp := prevApplications[appName]
+		app.ServeConfigHash = appConfigHashes[appName]
+		app.UpgradeStatus = prevApp.UpgradeStatus
+		if !hasPrevApp || prevApp.ServeConfigHash != app.ServeConfigHash {
+			app.UpgradeStatus = rayv1.ServeApplicationUpgrading
+			if hasPrevApp {
+				r.Recorder.Eventf(rayServiceInstance, corev1.EventTypeNormal, string(utils.UpgradingServeApplication),
+					"Upgrading the Serve application %s on the RayCluster %s/%s", appName, rayClusterInstance.Namespace, rayClusterInstance.Name)
+			}
+		}
+		switch {
+		case app.Status == rayv1.ApplicationStatusEnum.RUNNING && app.UpgradeStatus != rayv1.ServeApplicationUpgraded:
+			app.UpgradeStatus = rayv1.ServeApplicationUpgraded
+			if hasPrevApp {
+				r.Recorder.Eventf(rayServiceInstance, corev1.EventTypeNormal, string(utils.UpgradedServeApplication),
+					"Upgraded the Serve application %s on the RayCluster %s/%s", appName, rayClusterInstance.Namespace, rayClusterInstance.Name)
+			}
+		case app.Status == rayv1.ApplicationStatusEnum.DEPLOY_FAILED && app.UpgradeStatus == rayv1.ServeApplicationUpgrading:
+			app.UpgradeStatus = rayv1.ServeApplicationUpgradeFailed
+			r.Recorder.Eventf(rayServiceInstance, corev1.EventTypeWarning, string(utils.FailedToUpgradeServeApplication),
+				"Failed to upgrade the Serve application %s on the RayCluster %s/%s: %s", appName, rayClusterInstance.Namespace, rayClusterInstance.Name, app.Message)
+		}
+		applications[appName] = app
+	}
+}
+
+// areServeApplicationsReadyOrUpgrading returns whether every Serve application is running or being upgraded, and at
+// least one application is running.
+func areServeApplicationsReadyOrUpgrading(applications map[string]rayv1.AppStatus) bool {
+	hasRunningApp := false
+	for _, app := range applications {
+		switch {
+		case app.Status == rayv1.ApplicationStatusEnum.RUNNING:
+			hasRunningApp = true
+		case app.UpgradeStatus != rayv1.ServeApplicationUpgrading && app.UpgradeStatus != rayv1.ServeApplicationUpgradeFailed:
+			return false
+		}
+	}
+	return hasRunningApp
+}
+
+// summarizeServeReplicas counts the replicas of a Serve deployment by state and by node, and counts the replicas that
+// have been in the STARTING state for longer than ServeReplicaStuckStartingDuration.
+func summarizeServeReplicas(replicas []utils.ServeReplicaDetails, now time.Time) *rayv1.ServeReplicasSummary {
+	if len(replicas) == 0 {
+		return nil
+	}
+	summary := &rayv1.ServeReplicasSummary{
+		States: map[string]int32{},
+		Nodes:  map[string]int32{},
+	}
+	for _, replica := range replicas {
+		summary.States[replica.State]++
+		if replica.NodeIP != "" {
+			summary.Nodes[replica.NodeIP]++
+		}
+		startTime := time.UnixMilli(int64(replica.StartTimeS * 1000))
+		if replica.State == utils.ServeReplicaStartingState && replica.StartTimeS > 0 && now.Sub(startTime) > ServeReplicaStuckStartingDuration {
+			summary.StuckStarting++
+		}
+	}
+	return summary
+}
+
+// resolveServeConfigV2 returns the Serve config of the RayService with the values of its variables substituted.
+// The values are read at every reconciliation, so that changing them updates the Serve applications.
+func (r *RayServiceReconciler) resolveServeConfigV2(ctx context.Context, rayServiceInstance *rayv1.RayService) (string, error) {
+	serveConfigV2, err := r.getServeConfigV2(ctx, rayServiceInstance)
+	if err != nil {
+		return "", err
+	}
+	if len(rayServiceInstance.Spec.ServeConfigV2Variables) == 0 {
+		return serveConfigV2, nil
+	}
+	variables, err := utils.GetServeConfigV2Variables(ctx, r.Client, rayServiceInstance.Namespace, rayServiceInstance.Spec.ServeConfigV2Variables)
+	if err != nil {
+		return "", err
+	}
+	return utils.SubstituteServeConfigV2Variables(serveConfigV2, variables)
+}
+
+// getServeConfigV2 returns the serveConfigV2 of the RayService, or the Serve config last fetched from its
+// serveConfigSource.
+func (r *RayServiceReconciler) getServeConfigV2(ctx context.Context, rayServiceInstance *rayv1.RayService) (string, error) {
+	if rayServiceInstance.Spec.ServeConfigSource == nil {
+		return rayServiceInstance.Spec.ServeConfigV2, nil
+	}
+	configMap := &corev1.ConfigMap{}
+	name := client.ObjectKey{Namespace: rayServiceInstance.Namespace, Name: utils.GenerateServeConfigSourceConfigMapName(rayServiceInstance.Name)}
+	if err := r.Get(ctx, name, configMap); err != nil {
+		return "", fmt.Errorf("failed to get the Serve config fetched from the serveConfigSource: %w", err)
+	}
+	return configMap.Data[utils.ServeConfigSourceConfigMapKey], nil
+}
+
+// serveConfigSourcePollInterval returns how often the serveConfigSource of the RayService is polled.
+func serveConfigSourcePollInterval(rayServiceInstance *rayv1.RayService) time.Duration {
+	if seconds := rayServiceInstance.Spec.ServeConfigSource.PollIntervalSeconds; seconds != nil {
+		return time.Duration(*seconds) * time.Second
+	}
+	return utils.DefaultServeConfigSourcePollIntervalSeconds * time.Second
+}
+
+// reconcileServeConfigSource fetches the Serve config from the serveConfigSource of the RayService once per poll
+// interval, and stores it in the ConfigMap of the RayService if its content changed. The Serve applications are then
+// updated because the Serve config differs from the one cached for the RayClusters. If the source can't be fetched or
+// returns an invalid Serve config, the last fetched Serve config keeps being used, and an error is only returned if
+// there is none yet.
+func (r *RayServiceReconciler) reconcileServeConfigSource(ctx context.Context, rayServiceInstance *rayv1.RayService, now time.Time) error {
+	logger := ctrl.LoggerFrom(ctx)
+	source := rayServiceInstance.Spec.ServeConfigSource
+	if source == nil {
+		rayServiceInstance.Status.ServeConfigSource = nil
+		return nil
+	}
+	status := rayServiceInstance.Status.ServeConfigSource
+	if status != nil && status.LastFetchTime != nil && now.Sub(status.LastFetchTime.Time) < serveConfigSourcePollInterval(rayServiceInstance) {
+		return nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	configMapName := client.ObjectKey{Namespace: rayServiceInstance.Namespace, Name: utils.GenerateServeConfigSourceConfigMapName(rayServiceInstance.Name)}
+	err := r.Get(ctx, configMapName, configMap)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	configMapExists := err == nil
+	// A ConfigMap with the same name that the RayService didn't create isn't overwritten.
+	if configMapExists && !metav1.IsControlledBy(configMap, rayServiceInstance) {
+		return fmt.Errorf("the ConfigMap %s/%s of the Serve config already exists and isn't owned by the RayService", configMap.Namespace, configMap.Name)
+	}
+
+	fetched, err := r.fetchServeConfig(ctx, rayServiceInstance, configMapExists)
+	if err != nil {
+		r.Recorder.Eventf(rayServiceInstance, corev1.EventTypeWarning, string(utils.FailedToFetchServeConfig),
+			"Failed to fetch the Serve config from %s: %v", source.URL, err)
+		if !configMapExists {
+			return fmt.Errorf("failed to fetch the Serve config from the serveConfigSource: %w", err)
+		}
+		// The source is polled again after the poll interval rather than at every reconciliation.
+		logger.Info("Keep the last Serve config fetched from the serveConfigSource", "error", err.Error())
+		newStatus := status.DeepCopy()
+		if newStatus == nil {
+			newStatus = &rayv1.ServeConfigSourceStatus{}
+		}
+		newStatus.LastFetchTime = &metav1.Time{Time: now}
+		rayServiceInstance.Status.ServeConfigSource = newStatus
+		return nil
+	}
+	if fetched == nil {
+		// The Serve config didn't change since the last fetch.
+		newStatus := status.DeepCopy()
+		newStatus.LastFetchTime = &metav1.Time{Time: now}
+		rayServiceInstance.Status.ServeConfigSource = newStatus
+		return nil
+	}
+
+	revision := fetched.ETag
+	if revision == "" {
+		revision = fetched.ContentHash
+	}
+	if !configMapExists || configMap.Data[utils.ServeConfigSourceConfigMapKey] != fetched.Content {
+		configMap.Name = configMapName.Name
+		configMap.Namespace = configMapName.Namespace
+		configMap.Data = map[string]string{utils.ServeConfigSourceConfigMapKey: fetched.Content}
+		if configMapExists {
+			err = r.Update(ctx, configMap)
+		} else {
+			if err := ctrl.SetControllerReference(rayServiceInstance, configMap, r.Scheme); err != nil {
+				return err
+			}
+			err = r.Create(ctx, configMap)
+		}
+		if err != nil {
+			return err
+		}
+		logger.Info("Fetched a new Serve config from the serveConfigSource", "revision", revision)
+		r.Recorder.Eventf(rayServiceInstance, corev1.EventTypeNormal, string(utils.FetchedServeConfig),
+			"Fetched the revision %s of the Serve config from %s", revision, source.URL)
+	}
+	rayServiceInstance.Status.ServeConfigSource = &rayv1.ServeConfigSourceStatus{
+		Revision:      revision,
+		ContentHash:   fetched.ContentHash,
+		LastFetchTime: &metav1.Time{Time: now},
+	}
+	return nil
+}
+
+// fetchServeConfig fetches the Serve config from the serveConfigSource of the RayService and validates it. It returns
+// nil if the Serve config didn't change since the last fetch, which is only checked if withETag is true.
+func (r *RayServiceReconciler) fetchServeConfig(ctx context.Context, rayServiceInstance *rayv1.RayService, withETag bool) (*utils.ServeConfigSourceResponse, error) {
+	source := rayServiceInstance.Spec.ServeConfigSource
+	authorization := ""
+	if ref := source.AuthSecretRef; ref != nil {
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, client.ObjectKey{Namespace: rayServiceInstance.Namespace, Name: ref.Name}, secret); err != nil {
+			return nil, fmt.Errorf("failed to get the Secret %s of the authorization: %w", ref.Name, err)
+		}
+		value, ok := secret.Data[ref.Key]
+		if !ok {
+			return nil, fmt.Errorf("the Secret %s has no key %s", ref.Name, ref.Key)
+		}
+		authorization = string(value)
+	}
+	// The revision is the content hash if the source doesn't return ETags.
+	etag := ""
+	if status := rayServiceInstance.Status.ServeConfigSource; withETag && status != nil && status.Revision != status.ContentHash {
+		etag = status.Revision
+	}
+	fetched, err := utils.FetchServeConfig(ctx, source.URL, authorization, etag)
+	if err != nil || fetched == nil {
+		return fetched, err
+	}
+	if err := common.ValidateServeConfigV2(&rayServiceInstance.Spec.RayClusterSpec, fetched.Content); err != nil {
+		return nil, fmt.Errorf("the Serve config is invalid: %w", err)
+	}
+	return fetched, nil
+}
+
+// updateServeConfigHashAnnotation annotates the RayCluster with the hash of the Serve config applied to it.
+func (r *RayServiceReconciler) updateServeConfigHashAnnotation(ctx context.Context, rayClusterInstance *rayv1.RayCluster, serveConfig string) error {
+	hash, err := utils.GenerateJsonHash(serveConfig)
+	if err != nil {
+		return err
+	}
+	if rayClusterInstance.Annotations[utils.RayServiceServeConfigHashAnnotationKey] == hash {
+		return nil
+	}
+	patchedRayCluster := rayClusterInstance.DeepCopy()
+	if patchedRayCluster.Annotations == nil {
+		patchedRayCluster.Annotations = map[string]string{}
+	}
+	patchedRayCluster.Annotations[utils.RayServiceServeConfigHashAnnotationKey] = hash
+	if err := r.Patch(ctx, patchedRayCluster, client.MergeFrom(rayClusterInstance)); err != nil {
+		return err
+	}
+	rayClusterInstance.Annotations = patchedRayCluster.Annotations
+	rayClusterInstance.ResourceVersion = patchedRayCluster.ResourceVersion
+	return nil
+}
+
+func (r *RayServiceReconciler) getServeConfigFromCache(rayServiceInstance *rayv1.RayService, clusterName string) string {
+	cacheKey := rayServiceInstance.Namespace + "/" + rayServiceInstance.Name
+	cacheValue, exist := r.ServeConfigs.Get(cacheKey)
+	if !exist {
+		return ""
+	}
+	serveConfigs := cacheValue.(cmap.ConcurrentMap[string, string])
+	serveConfig, exist := serveConfigs.Get(clusterName)
+	if !exist {
+		return ""
+	}
+	// A config that can't be decompressed is a cache miss, so that the config is reapplied.
+	serveConfig, _ = utils.DecompressServeConfig(serveConfig)
+	return serveConfig
+}
+
+func (r *RayServiceReconciler) cacheServeConfig(rayServiceInstance *rayv1.RayService, clusterName string, serveConfig string) {
+	if serveConfig == "" {
 		return
 	}
 	cacheKey := rayServiceInstance.Namespace + "/" + rayServiceInstance.Name
@@ -972,14 +1902,15 @@ func (r *RayServiceReconciler) cacheServeConfig(rayServiceInstance *rayv1.RaySer
 	} else {
 		rayServiceServeConfigs = cacheValue.(cmap.ConcurrentMap[string, string])
 	}
-	rayServiceServeConfigs.Set(clusterName, serveConfig)
+	rayServiceServeConfigs.Set(clusterName, utils.CompressServeConfig(serveConfig))
 }
 
 func markRestartAndAddPendingClusterName(ctx context.Context, rayServiceInstance *rayv1.RayService) {
 	logger := ctrl.LoggerFrom(ctx)
 
 	// Generate RayCluster name for pending cluster.
-	logger.Info("Current cluster is unhealthy, prepare to restart.", "Status", rayServiceInstance.Status)
+	logger.Info("Current cluster is unhealthy, prepare to restart.")
+	logger.V(1).Info("Current cluster is unhealthy, prepare to restart.", "Status", rayServiceInstance.Status)
 	rayServiceInstance.Status.ServiceStatus = rayv1.Restarting
 	rayServiceInstance.Status.PendingServiceStatus = rayv1.RayServiceStatus{
 		RayClusterName: utils.GenerateRayClusterName(rayServiceInstance.Name),
@@ -1000,7 +1931,9 @@ func promotePendingClusterToActiveCluster(ctx context.Context, rayServiceInstanc
 	rayServiceInstance.Status.ServiceStatus = rayv1.Running
 }
 
-func (r *RayServiceReconciler) reconcileServices(ctx context.Context, rayServiceInstance *rayv1.RayService, rayClusterInstance *rayv1.RayCluster, serviceType utils.ServiceType) error {
+// reconcileServices creates or updates the head or serve Service of the RayService. It returns a description of the
+// modifications of the Service made outside of KubeRay that are kept because the ServiceReconcileMode is Observe.
+func (r *RayServiceReconciler) reconcileServices(ctx context.Context, rayServiceInstance *rayv1.RayService, rayClusterInstance *rayv1.RayCluster, serviceType utils.ServiceType) (string, error) {
 	logger := ctrl.LoggerFrom(ctx)
 	logger.Info(
 		"reconcileServices", "serviceType", serviceType,
@@ -1014,24 +1947,53 @@ func (r *RayServiceReconciler) reconcileServices(ctx context.Context, rayService
 		newSvc, err = common.BuildHeadServiceForRayService(ctx, *rayServiceInstance, *rayClusterInstance)
 	case utils.ServingService:
 		newSvc, err = common.BuildServeServiceForRayService(ctx, *rayServiceInstance, *rayClusterInstance)
+		if err == nil && rayServiceInstance.Spec.MaintenanceMode {
+			newSvc.Spec.Selector = common.MaintenanceResponderSelector(rayServiceInstance)
+		}
+		if err == nil && utils.IsManagedServeTopologyRouting(rayServiceInstance) {
+			// The EndpointSlice of the serve service is managed by KubeRay rather than by Kubernetes, see
+			// reconcileServeEndpointSlice.
+			newSvc.Spec.Selector = nil
+		}
 	default:
-		return fmt.Errorf("unknown service type %v", serviceType)
+		return "", fmt.Errorf("unknown service type %v", serviceType)
 	}
 
 	if err != nil {
-		return err
+		return "", err
 	}
-	logger.Info("reconcileServices", "newSvc", newSvc)
+	if serviceType == utils.HeadService {
+		rayServiceInstance.Status.HeadServiceName = newSvc.Name
+	} else {
+		rayServiceInstance.Status.ServeServiceName = newSvc.Name
+	}
+	setGeneratedPortsAnnotation(newSvc)
+	logger.V(1).Info("reconcileServices", "newSvc", newSvc)
 
 	// Retrieve the Service from the Kubernetes cluster with the name and namespace.
 	oldSvc := &corev1.Service{}
 	err = r.Get(ctx, client.ObjectKey{Name: newSvc.Name, Namespace: rayServiceInstance.Namespace}, oldSvc)
 
 	if err == nil {
-		// Only update the service if the RayCluster switches.
-		if newSvc.Spec.Selector[utils.RayClusterLabelKey] == oldSvc.Spec.Selector[utils.RayClusterLabelKey] {
-			logger.Info("Service has already exists in the RayCluster, skip Update", "rayCluster", newSvc.Spec.Selector[utils.RayClusterLabelKey], "serviceType", serviceType)
-			return nil
+		// The ports added to the Service by users or other controllers, e.g. service meshes, are neither a drift nor
+		// removed when the RayCluster switches.
+		newSvc.Spec.Ports = mergeServicePorts(oldSvc, newSvc.Spec.Ports)
+
+		// Only update the service if the RayCluster switches or the session affinity or the topology routing changes.
+		if newSvc.Spec.Selector[utils.RayClusterLabelKey] == oldSvc.Spec.Selector[utils.RayClusterLabelKey] &&
+			(serviceType != utils.ServingService || (isSameSessionAffinity(oldSvc, newSvc) && isSameTopologyMode(oldSvc, newSvc))) {
+			drift := getServiceDrift(oldSvc, newSvc)
+			if drift == "" {
+				logger.Info("Service has already exists in the RayCluster, skip Update", "rayCluster", newSvc.Spec.Selector[utils.RayClusterLabelKey], "serviceType", serviceType)
+				return "", nil
+			}
+			if !isServiceReconcileModeEnforce(rayServiceInstance) {
+				logger.Info("The Service was modified outside of KubeRay, keep the modifications", "serviceType", serviceType, "drift", drift)
+				return drift, nil
+			}
+			logger.Info("The Service was modified outside of KubeRay, revert the modifications", "serviceType", serviceType, "drift", drift)
+			r.Recorder.Eventf(rayServiceInstance, corev1.EventTypeNormal, string(utils.RevertedServiceDrift),
+				"Reverted %s to match the RayService", drift)
 		}
 
 		// ClusterIP is immutable. Starting from Kubernetes v1.21.5, if the new service does not specify a ClusterIP,
@@ -1039,45 +2001,856 @@ func (r *RayServiceReconciler) reconcileServices(ctx context.Context, rayService
 		// with older versions of Kubernetes, we need to assign the ClusterIP here.
 		newSvc.Spec.ClusterIP = oldSvc.Spec.ClusterIP
 
-		// TODO (kevin85421): Consider not only the updates of the Spec but also the ObjectMeta.
 		oldSvc.Spec = *newSvc.Spec.DeepCopy()
+		// The labels and annotations are merged so that the ones added by users or other controllers are kept.
+		oldSvc.Labels = mergeStringMaps(oldSvc.Labels, newSvc.Labels)
+		oldSvc.Annotations = mergeStringMaps(oldSvc.Annotations, newSvc.Annotations)
+		if _, ok := newSvc.Annotations[corev1.AnnotationTopologyMode]; serviceType == utils.ServingService && !ok {
+			delete(oldSvc.Annotations, corev1.AnnotationTopologyMode)
+		}
 		logger.Info("Update Kubernetes Service", "serviceType", serviceType)
 		if updateErr := r.Update(ctx, oldSvc); updateErr != nil {
-			return updateErr
+			return "", updateErr
 		}
 	} else if errors.IsNotFound(err) {
 		logger.Info("Create a Kubernetes Service", "serviceType", serviceType)
 		if err := ctrl.SetControllerReference(rayServiceInstance, newSvc, r.Scheme); err != nil {
-			return err
+			return "", err
+		}
+		if err := dryRunCreate(ctx, r.Client, r.Recorder, rayServiceInstance, newSvc); err != nil {
+			return "", err
 		}
 		if createErr := r.Create(ctx, newSvc); createErr != nil {
 			if errors.IsAlreadyExists(createErr) {
 				logger.Info("The Kubernetes Service already exists, no need to create.")
-				return nil
+				return "", nil
 			}
-			return createErr
+			return "", createErr
 		}
 	} else {
+		return "", err
+	}
+
+	return "", nil
+}
+
+// reconcilePreviewServeService creates the preview serve service that points at the pending RayCluster if the preview
+// service or the request shadowing is enabled, and deletes the preview serve service if there is no pending RayCluster
+// to preview.
+func (r *RayServiceReconciler) reconcilePreviewServeService(ctx context.Context, rayServiceInstance *rayv1.RayService, pendingRayClusterInstance *rayv1.RayCluster) error {
+	logger := ctrl.LoggerFrom(ctx)
+	oldSvc := &corev1.Service{}
+	err := r.Get(ctx, client.ObjectKey{Name: utils.GeneratePreviewServeServiceName(rayServiceInstance.Name), Namespace: rayServiceInstance.Namespace}, oldSvc)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	svcExists := err == nil
+
+	if pendingRayClusterInstance == nil || (!utils.IsPreviewServiceEnabled(rayServiceInstance) && utils.GetRequestShadowing(rayServiceInstance) == nil) {
+		if svcExists && metav1.IsControlledBy(oldSvc, rayServiceInstance) {
+			logger.Info("Delete the preview serve service", "name", oldSvc.Name)
+			return client.IgnoreNotFound(r.Delete(ctx, oldSvc))
+		}
+		return nil
+	}
+
+	newSvc, err := common.BuildPreviewServeServiceForRayService(ctx, *rayServiceInstance, *pendingRayClusterInstance)
+	if err != nil {
+		return err
+	}
+	if !svcExists {
+		logger.Info("Create the preview serve service", "name", newSvc.Name, "rayCluster", pendingRayClusterInstance.Name)
+		if err := ctrl.SetControllerReference(rayServiceInstance, newSvc, r.Scheme); err != nil {
+			return err
+		}
+		if err := dryRunCreate(ctx, r.Client, r.Recorder, rayServiceInstance, newSvc); err != nil {
+			return err
+		}
+		if err := r.Create(ctx, newSvc); err != nil {
+			return err
+		}
+	} else if newSvc.Spec.Selector[utils.RayClusterLabelKey] != oldSvc.Spec.Selector[utils.RayClusterLabelKey] {
+		// The pending RayCluster changes if the spec is updated again during the upgrade.
+		logger.Info("Update the preview serve service", "name", newSvc.Name, "rayCluster", pendingRayClusterInstance.Name)
+		oldSvc.Spec.Selector = newSvc.Spec.Selector
+		oldSvc.Spec.Ports = newSvc.Spec.Ports
+		if err := r.Update(ctx, oldSvc); err != nil {
+			return err
+		}
+	}
+
+	// The serve label of the head Pod is only managed for the RayCluster that the serve service points at, so it's
+	// also updated here for the preview serve service to include the head Pod.
+	if err := r.updateHeadPodServeLabel(ctx, pendingRayClusterInstance, rayServiceInstance.Spec.ExcludeHeadPodFromServeSvc, rayServiceInstance.Spec.ServeProxyHealthCheck); err != nil {
+		return err
+	}
+	return r.updateWorkerPodsServeLabel(ctx, pendingRayClusterInstance, rayServiceInstance.Spec.WorkerServeProxyHealthCheck, rayServiceInstance.Spec.ServeProxyHealthCheck)
+}
+
+// servicePortKey is a port of a Service with the fields defaulted by Kubernetes filled in.
+type servicePortKey struct {
+	Name       string
+	Protocol   corev1.Protocol
+	TargetPort intstr.IntOrString
+	Port       int32
+}
+
+func servicePortKeys(ports []corev1.ServicePort) map[servicePortKey]bool {
+	keys := make(map[servicePortKey]bool, len(ports))
+	for _, port := range ports {
+		key := servicePortKey{Name: port.Name, Port: port.Port, Protocol: port.Protocol, TargetPort: port.TargetPort}
+		if key.Protocol == "" {
+			key.Protocol = corev1.ProtocolTCP
+		}
+		if key.TargetPort == (intstr.IntOrString{}) {
+			key.TargetPort = intstr.FromInt32(port.Port)
+		}
+		keys[key] = true
+	}
+	return keys
+}
+
+// setGeneratedPortsAnnotation annotates the Service generated by KubeRay with the names of its ports.
+func setGeneratedPortsAnnotation(svc *corev1.Service) {
+	names := make([]string, 0, len(svc.Spec.Ports))
+	for _, port := range svc.Spec.Ports {
+		names = append(names, port.Name)
+	}
+	if svc.Annotations == nil {
+		svc.Annotations = map[string]string{}
+	}
+	svc.Annotations[utils.RayServiceGeneratedPortsAnnotationKey] = strings.Join(names, ",")
+}
+
+// mergeServicePorts returns the ports generated by KubeRay followed by the ports of the existing Service that were
+// not generated by KubeRay, unless their name or port conflicts with a generated one. The ports that KubeRay
+// generated previously, e.g. the ones removed from the RayService since, are dropped. The ports of Services created
+// before KubeRay annotated them with the generated ports are unknown, so they are all replaced.
+func mergeServicePorts(oldSvc *corev1.Service, ports []corev1.ServicePort) []corev1.ServicePort {
+	annotation, ok := oldSvc.Annotations[utils.RayServiceGeneratedPortsAnnotationKey]
+	if !ok {
+		return ports
+	}
+	generated := strings.Split(annotation, ",")
+	merged := slices.Clone(ports)
+	for _, oldPort := range oldSvc.Spec.Ports {
+		if slices.Contains(generated, oldPort.Name) {
+			continue
+		}
+		if slices.ContainsFunc(ports, func(port corev1.ServicePort) bool {
+			return port.Name == oldPort.Name || (port.Port == oldPort.Port && isSameProtocol(port.Protocol, oldPort.Protocol))
+		}) {
+			continue
+		}
+		merged = append(merged, oldPort)
+	}
+	return merged
+}
+
+// isSameProtocol returns whether the protocols of ports are the same. Kubernetes defaults the protocol to TCP.
+func isSameProtocol(a, b corev1.Protocol) bool {
+	if a == "" {
+		a = corev1.ProtocolTCP
+	}
+	if b == "" {
+		b = corev1.ProtocolTCP
+	}
+	return a == b
+}
+
+// mergeStringMaps returns the entries of existing overridden by the ones of generated.
+func mergeStringMaps(existing, generated map[string]string) map[string]string {
+	if existing == nil {
+		return maps.Clone(generated)
+	}
+	maps.Copy(existing, generated)
+	return existing
+}
+
+// getServiceDrift returns a description of the differences between the selector and ports of the existing Service
+// and the Service generated by KubeRay, or an empty string if there are none. The node ports allocated by Kubernetes
+// are ignored.
+func getServiceDrift(oldSvc, newSvc *corev1.Service) string {
+	var fields []string
+	if !maps.Equal(oldSvc.Spec.Selector, newSvc.Spec.Selector) {
+		fields = append(fields, "selector")
+	}
+	if !maps.Equal(servicePortKeys(oldSvc.Spec.Ports), servicePortKeys(newSvc.Spec.Ports)) {
+		fields = append(fields, "ports")
+	}
+	if len(fields) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("the %s of the Service %s/%s", strings.Join(fields, " and "), oldSvc.Namespace, oldSvc.Name)
+}
+
+func isServiceReconcileModeEnforce(rayService *rayv1.RayService) bool {
+	return rayService.Spec.ServiceReconcileMode != nil && *rayService.Spec.ServiceReconcileMode == rayv1.EnforceServiceReconcileMode
+}
+
+// setServiceDriftCondition sets the ServiceDriftDetected condition from the kept modifications of the Services.
+func setServiceDriftCondition(rayService *rayv1.RayService, drifts ...string) {
+	drifts = slices.DeleteFunc(drifts, func(drift string) bool { return drift == "" })
+	if len(drifts) == 0 {
+		meta.SetStatusCondition(&rayService.Status.Conditions, metav1.Condition{
+			Type:    string(rayv1.ServiceDriftDetected),
+			Status:  metav1.ConditionFalse,
+			Reason:  rayv1.ServicesMatchRayService,
+			Message: "The head and serve Services match the RayService",
+		})
+		return
+	}
+	meta.SetStatusCondition(&rayService.Status.Conditions, metav1.Condition{
+		Type:   string(rayv1.ServiceDriftDetected),
+		Status: metav1.ConditionTrue,
+		Reason: rayv1.ServicesModified,
+		Message: fmt.Sprintf("%s don't match the RayService, e.g. because they were modified outside of KubeRay. "+
+			"Set serviceReconcileMode to %s to revert the modifications", strings.Join(drifts, " and "), rayv1.EnforceServiceReconcileMode),
+	})
+}
+
+// setReadyCondition sets the Ready condition from the status of the Serve applications of the active RayCluster and
+// the SLOs of serveAlerting.
+func setReadyCondition(rayService *rayv1.RayService, now time.Time) {
+	if rayService.Spec.MaintenanceMode {
+		meta.SetStatusCondition(&rayService.Status.Conditions, metav1.Condition{
+			Type:    string(rayv1.RayServiceReady),
+			Status:  metav1.ConditionFalse,
+			Reason:  rayv1.InMaintenance,
+			Message: "The RayService is in maintenance mode, the serve service answers every request with 503",
+		})
+		return
+	}
+	// The condition is reset after a maintenance, so that the unhealthy time of the failover policy starts at its end.
+	if ready := meta.FindStatusCondition(rayService.Status.Conditions, string(rayv1.RayServiceReady)); ready != nil && ready.Reason == rayv1.InMaintenance {
+		meta.RemoveStatusCondition(&rayService.Status.Conditions, string(rayv1.RayServiceReady))
+	}
+	if rayService.Status.ServiceStatus != rayv1.Running {
+		meta.SetStatusCondition(&rayService.Status.Conditions, metav1.Condition{
+			Type:    string(rayv1.RayServiceReady),
+			Status:  metav1.ConditionFalse,
+			Reason:  rayv1.ServeApplicationsNotReady,
+			Message: "The Serve applications are not ready to serve requests",
+		})
+		return
+	}
+	if violations := serveApplicationSLOViolations(rayService, now); len(violations) > 0 {
+		meta.SetStatusCondition(&rayService.Status.Conditions, metav1.Condition{
+			Type:    string(rayv1.RayServiceReady),
+			Status:  metav1.ConditionFalse,
+			Reason:  rayv1.ServeApplicationSLOViolated,
+			Message: strings.Join(violations, "; "),
+		})
+		return
+	}
+	meta.SetStatusCondition(&rayService.Status.Conditions, metav1.Condition{
+		Type:    string(rayv1.RayServiceReady),
+		Status:  metav1.ConditionTrue,
+		Reason:  rayv1.ServeApplicationsRunning,
+		Message: "The Serve applications are running",
+	})
+}
+
+// reconcileFailover sets the FailedOver condition from how long the Ready condition has been false, and notifies the
+// webhook of the failover policy when the condition changes. The condition only changes once the webhook accepted the
+// notification, so that a failed notification is sent again on the next reconciliation.
+func (r *RayServiceReconciler) reconcileFailover(ctx context.Context, rayServiceInstance *rayv1.RayService, now time.Time) error {
+	policy := rayServiceInstance.Spec.FailoverPolicy
+	if policy == nil {
+		meta.RemoveStatusCondition(&rayServiceInstance.Status.Conditions, string(rayv1.RayServiceFailedOver))
+		return nil
+	}
+	if rayServiceInstance.Spec.MaintenanceMode {
+		// A planned downtime isn't an outage, so the RayService neither fails over nor recovers during a maintenance.
+		return nil
+	}
+	threshold := time.Duration(utils.DefaultFailoverUnhealthyThresholdSeconds) * time.Second
+	if policy.UnhealthyThresholdSeconds != nil {
+		threshold = time.Duration(*policy.UnhealthyThresholdSeconds) * time.Second
+	}
+
+	ready := meta.FindStatusCondition(rayServiceInstance.Status.Conditions, string(rayv1.RayServiceReady))
+	failedOver := meta.IsStatusConditionTrue(rayServiceInstance.Status.Conditions, string(rayv1.RayServiceFailedOver))
+	if ready == nil {
+		// The Ready condition is only set once the RayService was reconciled up to its Services.
+		return nil
+	}
+	shouldFailOver := ready.Status != metav1.ConditionTrue && now.Sub(ready.LastTransitionTime.Time) >= threshold
+
+	condition := metav1.Condition{
+		Type:    string(rayv1.RayServiceFailedOver),
+		Status:  metav1.ConditionFalse,
+		Reason:  rayv1.UnhealthyThresholdNotExceeded,
+		Message: "The RayService is ready",
+	}
+	if shouldFailOver {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = rayv1.UnhealthyThresholdExceeded
+		condition.Message = fmt.Sprintf("The RayService has not been ready for more than %d seconds: %s", int(threshold.Seconds()), ready.Message)
+	} else if ready.Status != metav1.ConditionTrue {
+		condition.Message = fmt.Sprintf("The RayService has not been ready for less than %d seconds", int(threshold.Seconds()))
+	}
+
+	if shouldFailOver != failedOver {
+		state, eventType, reason := utils.FailoverStateRecovered, corev1.EventTypeNormal, utils.RecoveredRayService
+		if shouldFailOver {
+			state, eventType, reason = utils.FailoverStateFailedOver, corev1.EventTypeWarning, utils.FailedOverRayService
+		}
+		if policy.WebhookURL != nil {
+			notification := utils.FailoverNotification{
+				Namespace: rayServiceInstance.Namespace,
+				Name:      rayServiceInstance.Name,
+				State:     state,
+				Reason:    condition.Reason,
+				Message:   condition.Message,
+				Time:      now,
+			}
+			if err := utils.SendFailoverNotification(ctx, *policy.WebhookURL, notification); err != nil {
+				r.Recorder.Eventf(rayServiceInstance, corev1.EventTypeWarning, string(utils.FailedToCallFailoverWebhook),
+					"Failed to notify the failover webhook that the RayService %s/%s is %s: %v", rayServiceInstance.Namespace, rayServiceInstance.Name, state, err)
+				return err
+			}
+		}
+		r.Recorder.Eventf(rayServiceInstance, eventType, string(reason), "The RayService %s/%s is %s: %s",
+			rayServiceInstance.Namespace, rayServiceInstance.Name, state, condition.Message)
+	}
+	meta.SetStatusCondition(&rayServiceInstance.Status.Conditions, condition)
+	return r.reconcileExternalDNSHostname(ctx, rayServiceInstance, shouldFailOver)
+}
+
+// reconcileMaintenance creates the maintenance responder of the RayService and points the serve service at it while
+// maintenanceMode is set, and deletes the responder once maintenanceMode is unset. The serve service then points at
+// the RayCluster again once it is ready, see reconcileServices.
+func (r *RayServiceReconciler) reconcileMaintenance(ctx context.Context, rayServiceInstance *rayv1.RayService) error {
+	logger := ctrl.LoggerFrom(ctx)
+	configMap, deployment := common.BuildMaintenanceResponder(rayServiceInstance)
+	if !rayServiceInstance.Spec.MaintenanceMode {
+		if meta.FindStatusCondition(rayServiceInstance.Status.Conditions, string(rayv1.RayServiceMaintenanceActive)) == nil {
+			return nil
+		}
+		for _, object := range []client.Object{deployment, configMap} {
+			if err := r.Delete(ctx, object); client.IgnoreNotFound(err) != nil {
+				return err
+			}
+		}
+		logger.Info("Deleted the maintenance responder", "name", deployment.Name)
+		meta.RemoveStatusCondition(&rayServiceInstance.Status.Conditions, string(rayv1.RayServiceMaintenanceActive))
+		r.Recorder.Eventf(rayServiceInstance, corev1.EventTypeNormal, string(utils.EndedMaintenance),
+			"The RayService %s/%s is no longer in maintenance mode", rayServiceInstance.Namespace, rayServiceInstance.Name)
+		return nil
+	}
+
+	if err := r.Get(ctx, client.ObjectKeyFromObject(deployment), &appsv1.Deployment{}); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		for _, object := range []client.Object{configMap, deployment} {
+			if err := ctrl.SetControllerReference(rayServiceInstance, object, r.Scheme); err != nil {
+				return err
+			}
+			if err := r.Create(ctx, object); err != nil && !errors.IsAlreadyExists(err) {
+				return err
+			}
+		}
+		logger.Info("Created the maintenance responder", "name", deployment.Name)
+	}
+
+	// The serve service is repointed here rather than only in reconcileServices, which isn't reached while no RayCluster
+	// is ready.
+	if utils.IsManagedServeTopologyRouting(rayServiceInstance) {
+		if err := r.reconcileServeEndpointSlice(ctx, rayServiceInstance, common.MaintenanceResponderSelector(rayServiceInstance)); err != nil {
+			return err
+		}
+	} else if err := r.repointServeServiceToMaintenanceResponder(ctx, rayServiceInstance); err != nil {
 		return err
 	}
 
+	if !meta.IsStatusConditionTrue(rayServiceInstance.Status.Conditions, string(rayv1.RayServiceMaintenanceActive)) {
+		r.Recorder.Eventf(rayServiceInstance, corev1.EventTypeNormal, string(utils.StartedMaintenance),
+			"The RayService %s/%s is in maintenance mode, the serve service points at the maintenance responder %s/%s",
+			rayServiceInstance.Namespace, rayServiceInstance.Name, deployment.Namespace, deployment.Name)
+	}
+	meta.SetStatusCondition(&rayServiceInstance.Status.Conditions, metav1.Condition{
+		Type:    string(rayv1.RayServiceMaintenanceActive),
+		Status:  metav1.ConditionTrue,
+		Reason:  rayv1.MaintenanceModeEnabled,
+		Message: fmt.Sprintf("The serve service points at the maintenance responder %s, which answers every request with 503", deployment.Name),
+	})
 	return nil
 }
 
-func (r *RayServiceReconciler) updateStatusForActiveCluster(ctx context.Context, rayServiceInstance *rayv1.RayService, rayClusterInstance *rayv1.RayCluster) error {
+// repointServeServiceToMaintenanceResponder sets the selector of the serve service to the Pods of the maintenance
+// responder.
+func (r *RayServiceReconciler) repointServeServiceToMaintenanceResponder(ctx context.Context, rayServiceInstance *rayv1.RayService) error {
+	svc := &corev1.Service{}
+	if err := r.Get(ctx, common.RayServiceServeServiceNamespacedName(rayServiceInstance), svc); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if maps.Equal(svc.Spec.Selector, common.MaintenanceResponderSelector(rayServiceInstance)) {
+		return nil
+	}
+	svc.Spec.Selector = common.MaintenanceResponderSelector(rayServiceInstance)
+	if err := r.Update(ctx, svc); err != nil {
+		return err
+	}
+	ctrl.LoggerFrom(ctx).Info("Pointed the serve service at the maintenance responder", "serveService", svc.Name)
+	return nil
+}
+
+// reconcileExternalDNSHostname sets the ExternalDNS hostname annotation of the serve service to the externalDNSHostname
+// of the failover policy while the RayService hasn't failed over, and removes it once it fails over.
+func (r *RayServiceReconciler) reconcileExternalDNSHostname(ctx context.Context, rayServiceInstance *rayv1.RayService, failedOver bool) error {
+	hostname := rayServiceInstance.Spec.FailoverPolicy.ExternalDNSHostname
+	if hostname == nil {
+		return nil
+	}
+	svc := &corev1.Service{}
+	if err := r.Get(ctx, common.RayServiceServeServiceNamespacedName(rayServiceInstance), svc); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	value, ok := svc.Annotations[utils.ExternalDNSHostnameAnnotationKey]
+	switch {
+	case failedOver && ok:
+		delete(svc.Annotations, utils.ExternalDNSHostnameAnnotationKey)
+	case !failedOver && value != *hostname:
+		if svc.Annotations == nil {
+			svc.Annotations = map[string]string{}
+		}
+		svc.Annotations[utils.ExternalDNSHostnameAnnotationKey] = *hostname
+	default:
+		return nil
+	}
+	ctrl.LoggerFrom(ctx).Info("Update the ExternalDNS hostname of the serve service", "failedOver", failedOver)
+	return r.Update(ctx, svc)
+}
+
+// serveApplicationSLOViolations returns the SLOs of serveAlerting that the Serve applications of the active RayCluster
+// don't meet.
+func serveApplicationSLOViolations(rayService *rayv1.RayService, now time.Time) []string {
+	if rayService.Spec.ServeAlerting == nil {
+		return nil
+	}
+	var violations []string
+	for _, slo := range rayService.Spec.ServeAlerting.Applications {
+		app, ok := rayService.Status.ActiveServiceStatus.Applications[slo.Name]
+		if !ok {
+			violations = append(violations, fmt.Sprintf("the Serve application %s is not deployed", slo.Name))
+			continue
+		}
+		if slo.MaxUnhealthySeconds != nil && isServeAppUnhealthyOrDeployedFailed(app.Status) && app.HealthLastUpdateTime != nil {
+			if unhealthy := now.Sub(app.HealthLastUpdateTime.Time); unhealthy > time.Duration(*slo.MaxUnhealthySeconds)*time.Second {
+				violations = append(violations, fmt.Sprintf("the Serve application %s has been %s for more than %d seconds",
+					slo.Name, app.Status, *slo.MaxUnhealthySeconds))
+			}
+		}
+		if slo.MinReplicas != nil {
+			var running int32
+			for _, deployment := range app.Deployments {
+				if deployment.Replicas != nil {
+					running += deployment.Replicas.States[utils.ServeReplicaRunningState]
+				}
+			}
+			if running < *slo.MinReplicas {
+				violations = append(violations, fmt.Sprintf("the Serve application %s has %d running replicas, less than the minimum of %d",
+					slo.Name, running, *slo.MinReplicas))
+			}
+		}
+	}
+	return violations
+}
+
+// isSameSessionAffinity returns whether the services have the same session affinity. Kubernetes defaults the
+// session affinity to None, so an empty session affinity is the same as None.
+func isSameSessionAffinity(oldSvc, newSvc *corev1.Service) bool {
+	if newSvc.Spec.SessionAffinity == "" || newSvc.Spec.SessionAffinity == corev1.ServiceAffinityNone {
+		return oldSvc.Spec.SessionAffinity == "" || oldSvc.Spec.SessionAffinity == corev1.ServiceAffinityNone
+	}
+	return newSvc.Spec.SessionAffinity == oldSvc.Spec.SessionAffinity &&
+		reflect.DeepEqual(newSvc.Spec.SessionAffinityConfig, oldSvc.Spec.SessionAffinityConfig)
+}
+
+// isSameTopologyMode returns whether the serve service has the topology mode annotation of the RayService, which KubeRay
+// manages unless it is set in the serveService.
+func isSameTopologyMode(oldSvc, newSvc *corev1.Service) bool {
+	return oldSvc.Annotations[corev1.AnnotationTopologyMode] == newSvc.Annotations[corev1.AnnotationTopologyMode]
+}
+
+// reconcileServeEndpointSlice builds the EndpointSlice of the serve service from the Pods matching the selector if the
+// topology routing of the RayService is Managed, and deletes it otherwise. The RayService is reconciled when the
+// endpoints of the serve Pods change, see rayServicesForServePod.
+func (r *RayServiceReconciler) reconcileServeEndpointSlice(ctx context.Context, rayServiceInstance *rayv1.RayService, selector map[string]string) error {
 	logger := ctrl.LoggerFrom(ctx)
-	rayServiceInstance.Status.ActiveServiceStatus.RayClusterStatus = rayClusterInstance.Status
+	serveServiceKey := common.RayServiceServeServiceNamespacedName(rayServiceInstance)
+	oldSlice := &discoveryv1.EndpointSlice{}
+	err := r.Get(ctx, serveServiceKey, oldSlice)
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	sliceExists := err == nil
+
+	if !utils.IsManagedServeTopologyRouting(rayServiceInstance) {
+		if sliceExists && metav1.IsControlledBy(oldSlice, rayServiceInstance) {
+			logger.Info("Delete the EndpointSlice of the serve service", "name", oldSlice.Name)
+			return client.IgnoreNotFound(r.Delete(ctx, oldSlice))
+		}
+		return nil
+	}
+
+	serveService := &corev1.Service{}
+	if err := r.Get(ctx, serveServiceKey, serveService); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	pods := corev1.PodList{}
+	if err := r.List(ctx, &pods, client.InNamespace(rayServiceInstance.Namespace), client.MatchingLabels(selector)); err != nil {
+		return err
+	}
+	nodeZones := map[string]string{}
+	for _, pod := range pods.Items {
+		if _, ok := nodeZones[pod.Spec.NodeName]; ok || pod.Spec.NodeName == "" {
+			continue
+		}
+		zone, err := r.getNodeZone(ctx, pod.Spec.NodeName)
+		if err != nil {
+			return err
+		}
+		nodeZones[pod.Spec.NodeName] = zone
+	}
+	newSlice := common.BuildServeEndpointSlice(rayServiceInstance, serveService, pods.Items, nodeZones)
+
+	// The address type of an EndpointSlice is immutable.
+	if sliceExists && oldSlice.AddressType != newSlice.AddressType {
+		if err := r.Delete(ctx, oldSlice); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		sliceExists = false
+	}
+	if !sliceExists {
+		// The EndpointSlices created by Kubernetes while the serve service had a selector aren't deleted by Kubernetes.
+		if err := r.DeleteAllOf(ctx, &discoveryv1.EndpointSlice{}, client.InNamespace(rayServiceInstance.Namespace), client.MatchingLabels{
+			discoveryv1.LabelServiceName: serveService.Name,
+			discoveryv1.LabelManagedBy:   utils.KubernetesEndpointSliceControllerName,
+		}); err != nil {
+			return err
+		}
+		if err := ctrl.SetControllerReference(rayServiceInstance, newSlice, r.Scheme); err != nil {
+			return err
+		}
+		logger.Info("Create the EndpointSlice of the serve service", "name", newSlice.Name, "endpoints", len(newSlice.Endpoints))
+		return r.Create(ctx, newSlice)
+	}
 
+	if reflect.DeepEqual(oldSlice.Endpoints, newSlice.Endpoints) && reflect.DeepEqual(oldSlice.Ports, newSlice.Ports) &&
+		oldSlice.Labels[discoveryv1.LabelServiceName] == serveService.Name {
+		return nil
+	}
+	oldSlice.Endpoints = newSlice.Endpoints
+	oldSlice.Ports = newSlice.Ports
+	oldSlice.Labels = mergeStringMaps(oldSlice.Labels, newSlice.Labels)
+	logger.Info("Update the EndpointSlice of the serve service", "name", oldSlice.Name, "endpoints", len(newSlice.Endpoints))
+	return r.Update(ctx, oldSlice)
+}
+
+// getNodeZone returns the zone of the node, or an empty string if the node is gone or has no zone. The known zones are
+// cached, so that the nodes aren't looked up at every reconciliation.
+func (r *RayServiceReconciler) getNodeZone(ctx context.Context, nodeName string) (string, error) {
+	if r.nodeZones != nil {
+		if zone, ok := r.nodeZones.Get(nodeName); ok {
+			return zone.(string), nil
+		}
+	}
+	node := &corev1.Node{}
+	if err := r.Get(ctx, client.ObjectKey{Name: nodeName}, node); err != nil {
+		return "", client.IgnoreNotFound(err)
+	}
+	zone := node.Labels[corev1.LabelTopologyZone]
+	if zone != "" && r.nodeZones != nil {
+		r.nodeZones.Add(nodeName, zone)
+	}
+	return zone, nil
+}
+
+// reconcileServeHTTPRoute creates or updates the HTTPRoute of the Cookie session affinity and of the request
+// shadowing, and deletes it when neither is used anymore. If mirrorRayClusterInstance isn't nil, the requests are
+// mirrored to its preview serve service. The HTTPRoute is only looked up when the session affinity or the request
+// shadowing is set to avoid requests for the Gateway API resources, which may not be installed. Only the Cookie
+// session affinity and the mirroring require them.
+func (r *RayServiceReconciler) reconcileServeHTTPRoute(ctx context.Context, rayServiceInstance *rayv1.RayService, rayClusterInstance *rayv1.RayCluster, mirrorRayClusterInstance *rayv1.RayCluster) error {
+	logger := ctrl.LoggerFrom(ctx)
+	affinity := rayServiceInstance.Spec.ServeSessionAffinity
+	if affinity == nil && utils.GetRequestShadowing(rayServiceInstance) == nil {
+		return nil
+	}
+	isCookieAffinity := affinity != nil && affinity.Type == rayv1.CookieServeSessionAffinity
+
+	oldRoute := &unstructured.Unstructured{}
+	oldRoute.SetGroupVersionKind(common.HTTPRouteGroupVersionKind)
+	err := r.Get(ctx, client.ObjectKey{Name: utils.GenerateServeHTTPRouteName(rayServiceInstance.Name), Namespace: rayServiceInstance.Namespace}, oldRoute)
+	if err != nil && !errors.IsNotFound(err) {
+		if !isCookieAffinity && mirrorRayClusterInstance == nil && meta.IsNoMatchError(err) {
+			return nil
+		}
+		return err
+	}
+	routeExists := err == nil
+
+	if !common.IsServeHTTPRouteNeeded(*rayServiceInstance) {
+		if routeExists {
+			logger.Info("Delete the HTTPRoute of the serve service", "name", oldRoute.GetName())
+			return client.IgnoreNotFound(r.Delete(ctx, oldRoute))
+		}
+		return nil
+	}
+
+	serveSvc, err := common.BuildServeServiceForRayService(ctx, *rayServiceInstance, *rayClusterInstance)
+	if err != nil {
+		return err
+	}
+	var mirrorSvc *corev1.Service
+	if mirrorRayClusterInstance != nil {
+		if mirrorSvc, err = common.BuildPreviewServeServiceForRayService(ctx, *rayServiceInstance, *mirrorRayClusterInstance); err != nil {
+			return err
+		}
+	}
+	newRoute, err := common.BuildServeHTTPRouteForRayService(*rayServiceInstance, serveSvc, mirrorSvc)
+	if err != nil {
+		return err
+	}
+	if !routeExists {
+		logger.Info("Create the HTTPRoute of the serve service", "name", newRoute.GetName())
+		if err := ctrl.SetControllerReference(rayServiceInstance, newRoute, r.Scheme); err != nil {
+			return err
+		}
+		return r.Create(ctx, newRoute)
+	}
+	if reflect.DeepEqual(oldRoute.Object["spec"], newRoute.Object["spec"]) {
+		return nil
+	}
+	logger.Info("Update the HTTPRoute of the serve service", "name", newRoute.GetName())
+	oldRoute.Object["spec"] = newRoute.Object["spec"]
+	return r.Update(ctx, oldRoute)
+}
+
+// reconcileServeTraffic holds back the promotion of the ready pending RayCluster during the request shadowing and
+// until the manual promotion is approved, and reconciles the preview serve service and the HTTPRoute of the serve
+// service accordingly. It returns whether the pending RayCluster is ready to be promoted.
+func (r *RayServiceReconciler) reconcileServeTraffic(ctx context.Context, rayServiceInstance *rayv1.RayService, activeRayClusterInstance, pendingRayClusterInstance *rayv1.RayCluster, isPendingClusterReady bool) (bool, error) {
+	logger := ctrl.LoggerFrom(ctx)
 	var err error
-	var clientURL string
-	rayServiceStatus := &rayServiceInstance.Status.ActiveServiceStatus
 
-	if clientURL, err = utils.FetchHeadServiceURL(ctx, r.Client, rayClusterInstance, utils.DashboardPortName); err != nil || clientURL == "" {
+	// With the request shadowing, the ready pending RayCluster is treated as not ready until it passes the shadowing,
+	// and the requests are mirrored to it in the meantime.
+	var mirrorRayClusterInstance *rayv1.RayCluster
+	if utils.GetRequestShadowing(rayServiceInstance) == nil {
+		rayServiceInstance.Status.RequestShadowing = nil
+	} else if isPendingClusterReady && activeRayClusterInstance != nil {
+		if isPendingClusterReady, err = r.shadowServeRequests(ctx, rayServiceInstance, activeRayClusterInstance, pendingRayClusterInstance); err != nil {
+			logger.Error(err, "Failed to shadow the requests to the pending RayCluster.")
+		}
+		if !isPendingClusterReady {
+			mirrorRayClusterInstance = pendingRayClusterInstance
+		}
+	}
+
+	// With the Manual promotion, the ready pending RayCluster is treated as not ready until the promotion is approved.
+	if isPendingClusterReady && activeRayClusterInstance != nil && utils.IsManualPromotionEnabled(rayServiceInstance) &&
+		rayServiceInstance.Annotations[utils.RayServicePromoteAnnotationKey] != "true" {
+		logger.Info("The pending RayCluster is ready and waits for the promotion to be approved.", "rayCluster", pendingRayClusterInstance.Name)
+		r.Recorder.Eventf(rayServiceInstance, corev1.EventTypeNormal, string(utils.WaitingForPromotion),
+			"The pending RayCluster %s/%s is ready. Annotate the RayService with %s: \"true\" to switch over the traffic",
+			pendingRayClusterInstance.Namespace, pendingRayClusterInstance.Name, utils.RayServicePromoteAnnotationKey)
+		isPendingClusterReady = false
+	}
+
+	// The preview service only exists while the pending RayCluster is prepared next to the active RayCluster.
+	var previewRayClusterInstance *rayv1.RayCluster
+	if activeRayClusterInstance != nil && !isPendingClusterReady {
+		previewRayClusterInstance = pendingRayClusterInstance
+	}
+	if err := r.reconcilePreviewServeService(ctx, rayServiceInstance, previewRayClusterInstance); err != nil {
+		logger.Error(err, "Failed to reconcile the preview serve service.")
+	}
+
+	// The HTTPRoute routes the requests to the serve service of the RayCluster serving them, i.e. the ready pending
+	// RayCluster about to be promoted or else the active RayCluster, and mirrors them to the shadowed RayCluster.
+	servingRayClusterInstance := activeRayClusterInstance
+	if isPendingClusterReady {
+		servingRayClusterInstance = pendingRayClusterInstance
+	}
+	if servingRayClusterInstance != nil {
+		if err := r.reconcileServeHTTPRoute(ctx, rayServiceInstance, servingRayClusterInstance, mirrorRayClusterInstance); err != nil {
+			return false, err
+		}
+	}
+	return isPendingClusterReady, nil
+}
+
+// shadowServeRequests records the requests and errors of the active and the ready pending RayClusters since the start
+// of the shadowing in the status of the RayService, and returns whether the pending RayCluster passed the shadowing.
+// The requests are mirrored to the pending RayCluster by the HTTPRoute of the serve service while it is shadowed. The
+// shadowing is skipped if the Gateway API CRDs aren't installed.
+func (r *RayServiceReconciler) shadowServeRequests(ctx context.Context, rayServiceInstance *rayv1.RayService, activeRayClusterInstance, pendingRayClusterInstance *rayv1.RayCluster) (bool, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	shadowing := utils.GetRequestShadowing(rayServiceInstance)
+	status := rayServiceInstance.Status.RequestShadowing
+	if status != nil && status.RayClusterName == pendingRayClusterInstance.Name && status.Passed {
+		return true, nil
+	}
+
+	gvk := common.HTTPRouteGroupVersionKind
+	if _, err := r.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+		if meta.IsNoMatchError(err) {
+			r.Recorder.Eventf(rayServiceInstance, corev1.EventTypeWarning, string(utils.RequestShadowingUnavailable),
+				"The Gateway API CRDs aren't installed, so the requests aren't mirrored to the pending RayCluster %s/%s",
+				pendingRayClusterInstance.Namespace, pendingRayClusterInstance.Name)
+			return true, nil
+		}
+		return false, err
+	}
+	activeCounts, err := r.serveRequestCountsFunc(ctx, activeRayClusterInstance)
+	if err != nil {
+		return false, err
+	}
+	pendingCounts, err := r.serveRequestCountsFunc(ctx, pendingRayClusterInstance)
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	if status == nil || status.RayClusterName != pendingRayClusterInstance.Name {
+		status = &rayv1.RequestShadowingStatus{
+			RayClusterName:  pendingRayClusterInstance.Name,
+			StartTime:       &metav1.Time{Time: now},
+			ActiveBaseline:  activeCounts,
+			PendingBaseline: pendingCounts,
+		}
+		rayServiceInstance.Status.RequestShadowing = status
+		r.Recorder.Eventf(rayServiceInstance, corev1.EventTypeNormal, string(utils.ShadowingServeRequests),
+			"Mirroring %d%% of the requests to the pending RayCluster %s/%s", common.GetRequestShadowingPercent(shadowing),
+			pendingRayClusterInstance.Namespace, pendingRayClusterInstance.Name)
+	}
+	status.Active = serveRequestCountsSince(activeCounts, status.ActiveBaseline)
+	status.Pending = serveRequestCountsSince(pendingCounts, status.PendingBaseline)
+	errorRateDelta := serveErrorRatePercent(status.Pending) - serveErrorRatePercent(status.Active)
+	status.ErrorRateDeltaPercent = strconv.FormatFloat(errorRateDelta, 'f', 2, 64)
+
+	if now.Sub(status.StartTime.Time) < time.Duration(common.GetRequestShadowingDurationSeconds(shadowing))*time.Second {
+		logger.Info("The requests are mirrored to the pending RayCluster.", "rayCluster", pendingRayClusterInstance.Name,
+			"errorRateDeltaPercent", status.ErrorRateDeltaPercent)
+		return false, nil
+	}
+	if maxIncrease := common.GetRequestShadowingMaxErrorRateIncreasePercent(shadowing); errorRateDelta > float64(maxIncrease) {
+		r.Recorder.Eventf(rayServiceInstance, corev1.EventTypeWarning, string(utils.ShadowingErrorRateExceeded),
+			"The error rate of the pending RayCluster %s/%s exceeds the one of the active RayCluster by %s percentage points, more than the %d allowed",
+			pendingRayClusterInstance.Namespace, pendingRayClusterInstance.Name, status.ErrorRateDeltaPercent, maxIncrease)
+		return false, nil
+	}
+	status.Passed = true
+	return true, nil
+}
+
+// serveRequestCountsSince returns the requests counted since the baseline. The counters decrease when Ray Pods are
+// restarted or can't be scraped, in which case the requests are counted from zero.
+func serveRequestCountsSince(counts, baseline rayv1.ServeRequestCounts) rayv1.ServeRequestCounts {
+	return rayv1.ServeRequestCounts{
+		Requests: max(counts.Requests-baseline.Requests, 0),
+		Errors:   max(counts.Errors-baseline.Errors, 0),
+	}
+}
+
+// serveErrorRatePercent returns the percentage of the requests that failed.
+func serveErrorRatePercent(counts rayv1.ServeRequestCounts) float64 {
+	if counts.Requests == 0 {
+		return 0
+	}
+	return float64(counts.Errors) * 100 / float64(counts.Requests)
+}
+
+// reconcileServeAlertsPrometheusRule creates or updates the PrometheusRule with the alerts of serveAlerting. The
+// PrometheusRule is only looked up when serveAlerting is set to avoid requests for the Prometheus Operator resources,
+// which may not be installed, and it is skipped if they aren't.
+func (r *RayServiceReconciler) reconcileServeAlertsPrometheusRule(ctx context.Context, rayServiceInstance *rayv1.RayService) error {
+	logger := ctrl.LoggerFrom(ctx)
+	if rayServiceInstance.Spec.ServeAlerting == nil {
+		return nil
+	}
+
+	oldRule := &unstructured.Unstructured{}
+	oldRule.SetGroupVersionKind(common.PrometheusRuleGroupVersionKind)
+	err := r.Get(ctx, client.ObjectKey{Name: utils.GenerateServeAlertsRuleName(rayServiceInstance.Name), Namespace: rayServiceInstance.Namespace}, oldRule)
+	if meta.IsNoMatchError(err) {
+		logger.Info("Skip creating the PrometheusRule because the Prometheus Operator CRDs aren't installed")
+		return nil
+	}
+	if err != nil && !errors.IsNotFound(err) {
 		return err
 	}
 
+	newRule := common.BuildServeAlertsPrometheusRule(*rayServiceInstance)
+	if errors.IsNotFound(err) {
+		logger.Info("Create the PrometheusRule of the Serve applications", "name", newRule.GetName())
+		if err := ctrl.SetControllerReference(rayServiceInstance, newRule, r.Scheme); err != nil {
+			return err
+		}
+		return r.Create(ctx, newRule)
+	}
+	if reflect.DeepEqual(oldRule.Object["spec"], newRule.Object["spec"]) && reflect.DeepEqual(oldRule.GetLabels(), newRule.GetLabels()) {
+		return nil
+	}
+	logger.Info("Update the PrometheusRule of the Serve applications", "name", newRule.GetName())
+	oldRule.Object["spec"] = newRule.Object["spec"]
+	oldRule.SetLabels(newRule.GetLabels())
+	return r.Update(ctx, oldRule)
+}
+
+// reconcileDashboardIngress reconciles the dashboard Ingress of the RayService. The Ingress routes to the head service
+// of the RayService and is built from the RayCluster that the head service points at, so the Ingress is updated
+// together with the services when the RayService switches to a new RayCluster.
+func (r *RayServiceReconciler) reconcileDashboardIngress(ctx context.Context, rayServiceInstance *rayv1.RayService, rayClusterInstance *rayv1.RayCluster) error {
+	var desired *networkingv1.Ingress
+	if rayClusterInstance.Spec.DashboardIngress != nil {
+		headSvcName, err := utils.GenerateHeadServiceName(utils.RayServiceCRD, rayServiceInstance.Spec.RayClusterSpec, rayServiceInstance.Name)
+		if err != nil {
+			return err
+		}
+		desired = common.BuildDashboardIngress(*rayClusterInstance, rayServiceInstance.Name, rayServiceInstance.Namespace, map[string]string{
+			utils.RayOriginatedFromCRNameLabelKey: rayServiceInstance.Name,
+			utils.RayOriginatedFromCRDLabelKey:    utils.RayOriginatedFromCRDLabelValue(utils.RayServiceCRD),
+			utils.KubernetesCreatedByLabelKey:     utils.ComponentName,
+		}, headSvcName)
+		if err := ctrl.SetControllerReference(rayServiceInstance, desired, r.Scheme); err != nil {
+			return err
+		}
+	}
+	return reconcileDashboardIngress(ctx, r.Client, r.Recorder, rayServiceInstance, utils.GenerateDashboardIngressName(rayServiceInstance.Name), desired)
+}
+
+// newServeClient returns the client that deploys the Serve applications of the RayCluster and reports their statuses.
+// It's the Ray dashboard client, unless the dashboard of the RayService is disabled.
+func (r *RayServiceReconciler) newServeClient(ctx context.Context, rayServiceInstance *rayv1.RayService, rayClusterInstance *rayv1.RayCluster) (utils.RayDashboardClientInterface, error) {
+	portName := utils.DashboardPortName
 	rayDashboardClient := r.dashboardClientFunc()
+	if utils.IsDashboardDisabled(rayServiceInstance) {
+		portName = utils.GcsServerPortName
+		rayDashboardClient = utils.NewDashboardlessClient(r.Client, r.httpProxyClientFunc(), rayServiceInstance.Spec.ServeProxyHealthCheck)
+	}
+	clientURL, err := utils.FetchHeadServiceURL(ctx, r.Client, rayClusterInstance, portName)
+	if err != nil {
+		return nil, err
+	}
 	if err := rayDashboardClient.InitClient(ctx, clientURL, rayClusterInstance); err != nil {
+		return nil, err
+	}
+	return rayDashboardClient, nil
+}
+
+func (r *RayServiceReconciler) updateStatusForActiveCluster(ctx context.Context, rayServiceInstance *rayv1.RayService, rayClusterInstance *rayv1.RayCluster) error {
+	logger := ctrl.LoggerFrom(ctx)
+	rayServiceInstance.Status.ActiveServiceStatus.RayClusterStatus = rayClusterInstance.Status
+
+	rayServiceStatus := &rayServiceInstance.Status.ActiveServiceStatus
+
+	rayDashboardClient, err := r.newServeClient(ctx, rayServiceInstance, rayClusterInstance)
+	if err != nil {
 		return err
 	}
 
@@ -1097,7 +2870,6 @@ func (r *RayServiceReconciler) reconcileServe(ctx context.Context, rayServiceIns
 	logger := ctrl.LoggerFrom(ctx)
 	rayServiceInstance.Status.ActiveServiceStatus.RayClusterStatus = rayClusterInstance.Status
 	var err error
-	var clientURL string
 	var rayServiceStatus *rayv1.RayServiceStatus
 
 	// Pick up service status to be updated.
@@ -1132,42 +2904,85 @@ func (r *RayServiceReconciler) reconcileServe(ctx context.Context, rayServiceIns
 		}
 	}
 
-	if clientURL, err = utils.FetchHeadServiceURL(ctx, r.Client, rayClusterInstance, utils.DashboardPortName); err != nil || clientURL == "" {
+	rayDashboardClient, err := r.newServeClient(ctx, rayServiceInstance, rayClusterInstance)
+	if err != nil {
 		return false, err
 	}
 
-	rayDashboardClient := r.dashboardClientFunc()
-	if err := rayDashboardClient.InitClient(ctx, clientURL, rayClusterInstance); err != nil {
+	serveConfigV2, err := r.resolveServeConfigV2(ctx, rayServiceInstance)
+	if err != nil {
+		r.Recorder.Eventf(rayServiceInstance, corev1.EventTypeWarning, string(utils.InvalidServeConfigV2Variables),
+			"Failed to substitute the variables of serveConfigV2: %v", err)
 		return false, err
 	}
-
-	shouldUpdate := r.checkIfNeedSubmitServeDeployment(ctx, rayServiceInstance, rayClusterInstance, rayServiceStatus)
+	shouldUpdate := r.checkIfNeedSubmitServeDeployment(ctx, rayServiceInstance, rayClusterInstance, rayServiceStatus, serveConfigV2)
 	if shouldUpdate {
-		if err = r.updateServeDeployment(ctx, rayServiceInstance, rayDashboardClient, rayClusterInstance.Name); err != nil {
+		if err = r.updateServeDeployment(ctx, rayServiceInstance, rayDashboardClient, rayClusterInstance.Name, serveConfigV2); err != nil {
+			return false, err
+		}
+	}
+	if appliedServeConfig := r.getServeConfigFromCache(rayServiceInstance, rayClusterInstance.Name); appliedServeConfig != "" {
+		if err = r.updateServeConfigHashAnnotation(ctx, rayClusterInstance, appliedServeConfig); err != nil {
 			return false, err
 		}
 	}
 
-	var isReady bool
-	if isReady, err = getAndCheckServeStatus(ctx, rayDashboardClient, rayServiceStatus); err != nil {
+	prevApplications := rayServiceStatus.Applications
+	if _, err = getAndCheckServeStatus(ctx, rayDashboardClient, rayServiceStatus); err != nil {
 		return false, err
 	}
+	if utils.IsApplicationScopedUpgradeEnabled(rayServiceInstance) {
+		appConfigHashes, err := serveApplicationConfigHashes(r.getServeConfigFromCache(rayServiceInstance, rayClusterInstance.Name))
+		if err != nil {
+			return false, err
+		}
+		r.updateServeApplicationUpgradeStatuses(rayServiceInstance, rayClusterInstance, prevApplications, rayServiceStatus.Applications, appConfigHashes)
+	}
+
+	for appName, app := range rayServiceStatus.Applications {
+		for deploymentName, deployment := range app.Deployments {
+			if deployment.Replicas != nil && deployment.Replicas.StuckStarting > 0 {
+				r.Recorder.Eventf(rayServiceInstance, corev1.EventTypeWarning, string(utils.ServeReplicasStuckStarting),
+					"%d replicas of the Serve deployment %s of the application %s on the RayCluster %s/%s have been starting for more than %s",
+					deployment.Replicas.StuckStarting, deploymentName, appName, rayClusterInstance.Namespace, rayClusterInstance.Name, ServeReplicaStuckStartingDuration)
+			}
+		}
+	}
 
+	isReady := r.checkServeReadiness(ctx, rayServiceInstance, rayClusterInstance, rayServiceStatus, isActive)
 	logger.Info("Check serve health", "isReady", isReady, "isActive", isActive)
 
 	if !isReady {
 		// TODO (kevin85421): avoid always updating status if the serve applications are not ready.
 		rayServiceInstance.Status.ServiceStatus = rayv1.WaitForServeDeploymentReady
-		if err := r.Status().Update(ctx, rayServiceInstance); err != nil {
+		if err := r.updateStatus(ctx, rayServiceInstance); err != nil {
 			return false, err
 		}
-		logger.Info("Mark cluster as waiting for Serve applications", "rayCluster", rayClusterInstance)
+		logger.Info("Mark cluster as waiting for Serve applications", "rayCluster", rayClusterInstance.Name)
 	}
 
 	return isReady, nil
 }
 
-func (r *RayServiceReconciler) updateHeadPodServeLabel(ctx context.Context, rayClusterInstance *rayv1.RayCluster, excludeHeadPodFromServeSvc bool) error {
+// probeServeEndpoint sends the serve probe to the Ray Serve proxy on the head Pod of the RayCluster.
+func (r *RayServiceReconciler) probeServeEndpoint(ctx context.Context, rayServiceInstance *rayv1.RayService, rayClusterInstance *rayv1.RayCluster) error {
+	headPod, err := common.GetRayClusterHeadPod(ctx, r, rayClusterInstance)
+	if err != nil {
+		return err
+	}
+	if headPod == nil {
+		return fmt.Errorf("found 0 head. cluster name %s, namespace %v", rayClusterInstance.Name, rayClusterInstance.Namespace)
+	}
+
+	client := r.httpProxyClientFunc()
+	client.InitClient()
+	rayContainer := headPod.Spec.Containers[utils.RayContainerIndex]
+	servingPort := utils.FindContainerPort(&rayContainer, utils.ServingPortName, utils.DefaultServingPort)
+	client.SetHostIp(headPod.Status.PodIP, headPod.Namespace, headPod.Name, servingPort)
+	return client.ProbeServeEndpoint(ctx, rayServiceInstance.Spec.ServeProbe)
+}
+
+func (r *RayServiceReconciler) updateHeadPodServeLabel(ctx context.Context, rayClusterInstance *rayv1.RayCluster, excludeHeadPodFromServeSvc bool, healthCheck *rayv1.ServeProxyHealthCheck) error {
 	// `updateHeadPodServeLabel` updates the head Pod's serve label based on the health status of the proxy actor.
 	// If `excludeHeadPodFromServeSvc` is true, the head Pod will not be used to serve requests, regardless of proxy actor health.
 	// If `excludeHeadPodFromServeSvc` is false, the head Pod's serve label will be set based on the health check result.
@@ -1197,7 +3012,7 @@ func (r *RayServiceReconciler) updateHeadPodServeLabel(ctx context.Context, rayC
 	// no matter whether the proxy actor is healthy or not. Therefore, only send the health
 	// check request if excludeHeadPodFromServeSvc is false.
 	if !excludeHeadPodFromServeSvc {
-		isHealthy := client.CheckProxyActorHealth(ctx) == nil
+		isHealthy := client.CheckProxyActorHealth(ctx, healthCheck) == nil
 		newLabel = strconv.FormatBool(isHealthy)
 	}
 
@@ -1211,7 +3026,62 @@ func (r *RayServiceReconciler) updateHeadPodServeLabel(ctx context.Context, rayC
 	return nil
 }
 
-func generateHashWithoutReplicasAndWorkersToDelete(rayClusterSpec rayv1.RayClusterSpec) (string, error) {
+// updateWorkerPodsServeLabel sets the serve label of the running worker Pods to whether their Ray Serve proxy actor is
+// healthy if workerServeProxyHealthCheck is true, so that the worker Pods with a wedged proxy are removed from the serve
+// service. The proxies are checked concurrently, since each check may take up to the timeout of the HTTP client.
+func (r *RayServiceReconciler) updateWorkerPodsServeLabel(ctx context.Context, rayClusterInstance *rayv1.RayCluster, workerServeProxyHealthCheck bool, healthCheck *rayv1.ServeProxyHealthCheck) error {
+	if !workerServeProxyHealthCheck {
+		return nil
+	}
+	logger := ctrl.LoggerFrom(ctx)
+	workerPods := corev1.PodList{}
+	if err := r.List(ctx, &workerPods, common.RayClusterWorkerPodsAssociationOptions(rayClusterInstance).ToListOptions()...); err != nil {
+		return err
+	}
+
+	healthy := make([]bool, len(workerPods.Items))
+	checked := make([]bool, len(workerPods.Items))
+	semaphore := make(chan struct{}, maxConcurrentServeProxyHealthChecks)
+	var wg sync.WaitGroup
+	for i := range workerPods.Items {
+		pod := &workerPods.Items[i]
+		if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" || !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+		checked[i] = true
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			client := r.httpProxyClientFunc()
+			client.InitClient()
+			rayContainer := pod.Spec.Containers[utils.RayContainerIndex]
+			servingPort := utils.FindContainerPort(&rayContainer, utils.ServingPortName, utils.DefaultServingPort)
+			client.SetHostIp(pod.Status.PodIP, pod.Namespace, pod.Name, servingPort)
+			healthy[i] = client.CheckProxyActorHealth(ctx, healthCheck) == nil
+		}()
+	}
+	wg.Wait()
+
+	for i := range workerPods.Items {
+		pod := &workerPods.Items[i]
+		newLabel := strconv.FormatBool(healthy[i])
+		if !checked[i] || pod.Labels[utils.RayClusterServingServiceLabelKey] == newLabel {
+			continue
+		}
+		logger.Info("Update the serve label of the worker Pod", "pod", pod.Name, "healthy", healthy[i])
+		original := pod.DeepCopy()
+		pod.Labels[utils.RayClusterServingServiceLabelKey] = newLabel
+		if err := r.Patch(ctx, pod, client.MergeFrom(original)); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func generateHashWithoutReplicasAndWorkersToDelete(rayClusterSpec rayv1.RayClusterSpec, ignoredPaths []string) (string, error) {
 	// Mute certain fields that will not trigger new RayCluster preparation. For example,
 	// Autoscaler will update `Replicas` and `WorkersToDelete` when scaling up/down.
 	updatedRayClusterSpec := rayClusterSpec.DeepCopy()
@@ -1222,8 +3092,23 @@ func generateHashWithoutReplicasAndWorkersToDelete(rayClusterSpec rayv1.RayClust
 		updatedRayClusterSpec.WorkerGroupSpecs[i].ScaleStrategy.WorkersToDelete = nil
 	}
 
+	// Admission webhooks may also mutate the RayCluster, e.g. to pin the images to their digests, so these changes and
+	// the ignored paths of the upgrade strategy are normalized too.
+	normalizedRayClusterSpec, err := common.NormalizeRayClusterSpecForHash(*updatedRayClusterSpec, ignoredPaths)
+	if err != nil {
+		return "", err
+	}
+
 	// Generate a hash for the RayClusterSpec.
-	return utils.GenerateJsonHash(updatedRayClusterSpec)
+	return utils.GenerateJsonHash(normalizedRayClusterSpec)
+}
+
+// getIgnoredPaths returns the paths of the RayCluster spec whose changes don't trigger an upgrade of the RayService.
+func getIgnoredPaths(rayService *rayv1.RayService) []string {
+	if rayService.Spec.UpgradeStrategy == nil {
+		return nil
+	}
+	return rayService.Spec.UpgradeStrategy.IgnoredPaths
 }
 
 func compareRayClusterJsonHash(spec1 rayv1.RayClusterSpec, spec2 rayv1.RayClusterSpec, hashFunc func(rayv1.RayClusterSpec) (string, error)) (bool, error) {
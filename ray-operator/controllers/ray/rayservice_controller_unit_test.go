@@ -2,27 +2,42 @@ package ray
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/lru"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	clientFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/common"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
 	"github.com/ray-project/kuberay/ray-operator/pkg/client/clientset/versioned/scheme"
+	"github.com/ray-project/kuberay/ray-operator/pkg/features"
 	"github.com/ray-project/kuberay/ray-operator/test/support"
 )
 
@@ -42,11 +57,29 @@ func TestValidateRayServiceSpec(t *testing.T) {
 	})
 	assert.Error(t, err, "spec.rayClusterConfig.headGroupSpec.headService.metadata.name should not be set")
 
+	err = validateRayServiceSpec(&rayv1.RayService{
+		Spec: rayv1.RayServiceSpec{
+			RayClusterSpec: rayv1.RayClusterSpec{
+				HeadGroupSpec: rayv1.HeadGroupSpec{
+					ExistingHeadServiceName: "my-head-service",
+				},
+			},
+		},
+	})
+	assert.Error(t, err, "spec.rayClusterConfig.headGroupSpec.existingHeadServiceName should not be set")
+
 	err = validateRayServiceSpec(&rayv1.RayService{
 		Spec: rayv1.RayServiceSpec{},
 	})
 	assert.NoError(t, err, "The RayService spec is valid.")
 
+	err = validateRayServiceSpec(&rayv1.RayService{
+		Spec: rayv1.RayServiceSpec{
+			ServeApplicationWorkerGroups: map[string]string{"llm": "gpu-group"},
+		},
+	})
+	assert.EqualError(t, err, "spec.serveApplicationWorkerGroups is invalid: the worker group gpu-group of the Serve application llm doesn't exist")
+
 	var upgradeStrat rayv1.RayServiceUpgradeType = "invalidStrategy"
 	err = validateRayServiceSpec(&rayv1.RayService{
 		Spec: rayv1.RayServiceSpec{
@@ -56,6 +89,143 @@ func TestValidateRayServiceSpec(t *testing.T) {
 		},
 	})
 	assert.Error(t, err, "spec.UpgradeSpec.Type is invalid")
+
+	err = validateRayServiceSpec(&rayv1.RayService{
+		Spec: rayv1.RayServiceSpec{
+			ServeReadiness: &rayv1.ServeReadiness{Type: rayv1.ProbeServeReadinessCheck},
+		},
+	})
+	assert.EqualError(t, err, "spec.serveProbe is required by the Probe serve readiness check")
+
+	err = validateRayServiceSpec(&rayv1.RayService{
+		Spec: rayv1.RayServiceSpec{
+			ServeReadiness: &rayv1.ServeReadiness{Type: rayv1.MetricsServeReadinessCheck},
+		},
+	})
+	assert.EqualError(t, err, "spec.serveReadiness.metric.name is required by the Metrics serve readiness check")
+
+	err = validateRayServiceSpec(&rayv1.RayService{
+		Spec: rayv1.RayServiceSpec{
+			ServeReadiness: &rayv1.ServeReadiness{Type: "ModelLoaded"},
+		},
+	})
+	assert.EqualError(t, err, "spec.serveReadiness.type ModelLoaded is neither a built-in serve readiness check nor a registered one")
+
+	err = validateRayServiceSpec(&rayv1.RayService{
+		Spec: rayv1.RayServiceSpec{
+			ServeSessionAffinity: &rayv1.ServeSessionAffinity{Type: rayv1.CookieServeSessionAffinity},
+		},
+	})
+	assert.Error(t, err, "spec.serveSessionAffinity.gatewayName is required by the Cookie session affinity")
+
+	err = validateRayServiceSpec(&rayv1.RayService{
+		Spec: rayv1.RayServiceSpec{
+			ServeSessionAffinity: &rayv1.ServeSessionAffinity{Type: rayv1.ClientIPServeSessionAffinity, CookieName: ptr.To("session")},
+		},
+	})
+	assert.Error(t, err, "spec.serveSessionAffinity.cookieName is only supported by the Cookie session affinity")
+
+	err = validateRayServiceSpec(&rayv1.RayService{
+		Spec: rayv1.RayServiceSpec{
+			UpgradeStrategy: &rayv1.RayServiceUpgradeStrategy{RequestShadowing: &rayv1.RequestShadowing{}},
+		},
+	})
+	assert.EqualError(t, err, "spec.upgradeStrategy.requestShadowing.gatewayName is required")
+
+	err = validateRayServiceSpec(&rayv1.RayService{
+		Spec: rayv1.RayServiceSpec{
+			ServeSessionAffinity: &rayv1.ServeSessionAffinity{Type: rayv1.CookieServeSessionAffinity, GatewayName: "gateway"},
+			UpgradeStrategy:      &rayv1.RayServiceUpgradeStrategy{RequestShadowing: &rayv1.RequestShadowing{GatewayName: "other-gateway"}},
+		},
+	})
+	assert.EqualError(t, err, "spec.upgradeStrategy.requestShadowing.gatewayName must match spec.serveSessionAffinity.gatewayName")
+
+	err = validateRayServiceSpec(&rayv1.RayService{
+		Spec: rayv1.RayServiceSpec{
+			ServeConfigV2:  "applications: []",
+			RayClusterSpec: rayv1.RayClusterSpec{RayVersion: "1.13.0"},
+		},
+	})
+	assert.ErrorContains(t, err, "serveConfigV2 requires Ray 2.0.0 or later")
+
+	err = validateRayServiceSpec(&rayv1.RayService{
+		Spec: rayv1.RayServiceSpec{
+			ServeConfigV2: "applications: [{name: app1}, {name: app2}]",
+		},
+	})
+	assert.ErrorContains(t, err, "spec.serveConfigV2 is invalid: the route_prefix / is used by the applications app1, app2")
+
+	err = validateRayServiceSpec(&rayv1.RayService{
+		Spec: rayv1.RayServiceSpec{
+			ServeConfigV2: "applications:\n" + strings.Repeat("- name: app\n", utils.MaxServeConfigV2Size/12),
+		},
+	})
+	assert.ErrorContains(t, err, "exceeds the limit of 1048576 bytes")
+
+	err = validateRayServiceSpec(&rayv1.RayService{
+		Spec: rayv1.RayServiceSpec{
+			QueueLabels: map[string]string{"kueue.x-k8s.io/queue-name": "not a queue"},
+		},
+	})
+	assert.ErrorContains(t, err, "spec.queueLabels has an invalid value \"not a queue\" for the label kueue.x-k8s.io/queue-name")
+
+	err = validateRayServiceSpec(&rayv1.RayService{
+		Spec: rayv1.RayServiceSpec{
+			FailoverPolicy: &rayv1.FailoverPolicy{WebhookURL: ptr.To("ftp://example.com")},
+		},
+	})
+	assert.ErrorContains(t, err, "spec.failoverPolicy.webhookURL \"ftp://example.com\" is not an HTTP or HTTPS URL")
+
+	err = validateRayServiceSpec(&rayv1.RayService{
+		Spec: rayv1.RayServiceSpec{
+			ServeConfigSource: &rayv1.ServeConfigSource{URL: "https://registry.example.com/serve-config"},
+		},
+	})
+	assert.ErrorContains(t, err, "spec.serveConfigSource is currently available when the RayServiceServeConfigSource feature gate is enabled")
+
+	defer features.SetFeatureGateDuringTest(t, features.RayServiceServeConfigSource, true)()
+	err = validateRayServiceSpec(&rayv1.RayService{
+		Spec: rayv1.RayServiceSpec{
+			ServeConfigV2:     "applications: []",
+			ServeConfigSource: &rayv1.ServeConfigSource{URL: "https://registry.example.com/serve-config"},
+		},
+	})
+	assert.ErrorContains(t, err, "spec.serveConfigV2 and spec.serveConfigSource can't both be set")
+
+	err = validateRayServiceSpec(&rayv1.RayService{
+		Spec: rayv1.RayServiceSpec{
+			ServeConfigSource: &rayv1.ServeConfigSource{URL: "s3://bucket/serve-config"},
+		},
+	})
+	assert.ErrorContains(t, err, "spec.serveConfigSource.url \"s3://bucket/serve-config\" is not an HTTP or HTTPS URL")
+
+	err = validateRayServiceSpec(&rayv1.RayService{
+		Spec: rayv1.RayServiceSpec{
+			ServeConfigV2Variables: []corev1.EnvFromSource{{Prefix: "APP_"}},
+		},
+	})
+	assert.ErrorContains(t, err, "spec.serveConfigV2Variables[0] must set exactly one of configMapRef and secretRef")
+
+	err = validateRayServiceSpec(&rayv1.RayService{
+		Spec: rayv1.RayServiceSpec{
+			ServeAlerting: &rayv1.ServeAlertingOptions{Applications: []rayv1.ServeApplicationSLO{{Name: "app"}}},
+		},
+	})
+	assert.ErrorContains(t, err, "spec.serveAlerting.applications[0] must set at least one of maxUnhealthySeconds and minReplicas")
+
+	err = validateRayServiceSpec(&rayv1.RayService{
+		Spec: rayv1.RayServiceSpec{
+			ServeProxyHealthCheck: &rayv1.ServeProxyHealthCheck{ExpectedStatusCodes: []int32{0}},
+		},
+	})
+	assert.ErrorContains(t, err, "spec.serveProxyHealthCheck is invalid")
+
+	err = validateRayServiceSpec(&rayv1.RayService{
+		Spec: rayv1.RayServiceSpec{
+			UpgradeStrategy: &rayv1.RayServiceUpgradeStrategy{IgnoredPaths: []string{"headGroupSpec/template"}},
+		},
+	})
+	assert.ErrorContains(t, err, "spec.upgradeStrategy.ignoredPaths is invalid")
 }
 
 func TestGenerateHashWithoutReplicasAndWorkersToDelete(t *testing.T) {
@@ -78,26 +248,54 @@ func TestGenerateHashWithoutReplicasAndWorkersToDelete(t *testing.T) {
 		},
 	}
 
-	hash1, err := generateHashWithoutReplicasAndWorkersToDelete(cluster.Spec)
+	hash1, err := generateHashWithoutReplicasAndWorkersToDelete(cluster.Spec, nil)
 	assert.Nil(t, err)
 
 	*cluster.Spec.WorkerGroupSpecs[0].Replicas++
-	hash2, err := generateHashWithoutReplicasAndWorkersToDelete(cluster.Spec)
+	hash2, err := generateHashWithoutReplicasAndWorkersToDelete(cluster.Spec, nil)
 	assert.Nil(t, err)
 	assert.Equal(t, hash1, hash2)
 
 	// RayVersion will not be muted, so `hash3` should not be equal to `hash1`.
 	cluster.Spec.RayVersion = "2.100.0"
-	hash3, err := generateHashWithoutReplicasAndWorkersToDelete(cluster.Spec)
+	hash3, err := generateHashWithoutReplicasAndWorkersToDelete(cluster.Spec, nil)
 	assert.Nil(t, err)
 	assert.NotEqual(t, hash1, hash3)
 }
 
+func TestGenerateHashWithoutReplicasAndWorkersToDeleteWebhookMutations(t *testing.T) {
+	spec := rayv1.RayClusterSpec{
+		HeadGroupSpec: rayv1.HeadGroupSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "ray-head", Image: "rayproject/ray:2.9.0"}}},
+			},
+		},
+	}
+	ignoredPaths := []string{"/headGroupSpec/template/metadata/annotations/sidecar.istio.io~1status"}
+	hash1, err := generateHashWithoutReplicasAndWorkersToDelete(spec, ignoredPaths)
+	require.NoError(t, err)
+
+	// The digest of the image, the empty values and the ignored annotation added by webhooks don't change the hash.
+	mutatedSpec := spec.DeepCopy()
+	mutatedSpec.HeadGroupSpec.Template.Spec.Containers[0].Image = "rayproject/ray:2.9.0@sha256:0123456789abcdef"
+	mutatedSpec.HeadGroupSpec.Template.Spec.Containers[0].SecurityContext = &corev1.SecurityContext{}
+	mutatedSpec.HeadGroupSpec.Template.Annotations = map[string]string{"sidecar.istio.io/status": "{}"}
+	hash2, err := generateHashWithoutReplicasAndWorkersToDelete(*mutatedSpec, ignoredPaths)
+	require.NoError(t, err)
+	assert.Equal(t, hash1, hash2)
+
+	// The other annotations change the hash.
+	mutatedSpec.HeadGroupSpec.Template.Annotations["team"] = "ml"
+	hash3, err := generateHashWithoutReplicasAndWorkersToDelete(*mutatedSpec, ignoredPaths)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash1, hash3)
+}
+
 func TestDecideClusterAction(t *testing.T) {
 	ctx := context.TODO()
 
 	fillAnnotations := func(rayCluster *rayv1.RayCluster) {
-		hash, _ := generateHashWithoutReplicasAndWorkersToDelete(rayCluster.Spec)
+		hash, _ := generateHashWithoutReplicasAndWorkersToDelete(rayCluster.Spec, nil)
 		rayCluster.ObjectMeta.Annotations[utils.HashWithoutReplicasAndWorkersToDeleteKey] = hash
 		rayCluster.ObjectMeta.Annotations[utils.NumWorkerGroupsKey] = strconv.Itoa(len(rayCluster.Spec.WorkerGroupSpecs))
 	}
@@ -253,6 +451,31 @@ func TestDecideClusterAction(t *testing.T) {
 			pendingRayCluster: nil,
 			expectedAction:    UpdateActiveCluster,
 		},
+		{
+			name: "Active cluster has different KubeRay version and the update waits for an approval",
+			rayService: &rayv1.RayService{
+				Spec: rayv1.RayServiceSpec{
+					UpgradeStrategy: &rayv1.RayServiceUpgradeStrategy{KubeRayVersionPolicy: ptr.To(rayv1.ManualKubeRayVersionPolicy)},
+				},
+			},
+			activeRayCluster:  rayClusterDifferentKubeRayVersion,
+			pendingRayCluster: nil,
+			expectedAction:    DoNothing,
+		},
+		{
+			name: "Active cluster has different KubeRay version and the update is approved",
+			rayService: &rayv1.RayService{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{utils.RayServiceKubeRayVersionUpdateAnnotationKey: "true"},
+				},
+				Spec: rayv1.RayServiceSpec{
+					UpgradeStrategy: &rayv1.RayServiceUpgradeStrategy{KubeRayVersionPolicy: ptr.To(rayv1.ManualKubeRayVersionPolicy)},
+				},
+			},
+			activeRayCluster:  rayClusterDifferentKubeRayVersion,
+			pendingRayCluster: nil,
+			expectedAction:    UpdateActiveCluster,
+		},
 		{
 			name: "No pending cluster name and cluster spec is the same",
 			rayService: &rayv1.RayService{
@@ -319,6 +542,21 @@ func TestDecideClusterAction(t *testing.T) {
 			pendingRayCluster: nil,
 			expectedAction:    GeneratePendingClusterName,
 		},
+		{
+			name: "No pending cluster name, cluster spec has different worker group name, and the upgrade is aborted",
+			rayService: &rayv1.RayService{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{utils.RayServiceAbortAnnotationKey: "true"},
+				},
+				Spec: rayv1.RayServiceSpec{
+					RayClusterSpec:  rayClusterDifferentWorkerGroup.Spec,
+					UpgradeStrategy: &rayv1.RayServiceUpgradeStrategy{Promotion: ptr.To(rayv1.ManualPromotion)},
+				},
+			},
+			activeRayCluster:  rayClusterBase,
+			pendingRayCluster: nil,
+			expectedAction:    DoNothing,
+		},
 	}
 
 	for _, tt := range tests {
@@ -329,6 +567,74 @@ func TestDecideClusterAction(t *testing.T) {
 	}
 }
 
+func TestConstructRayClusterForRayServiceWithScheduling(t *testing.T) {
+	ctx := context.TODO()
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	r := &RayServiceReconciler{Scheme: newScheme}
+
+	rayService := &rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{Name: "rayservice", Namespace: "default"},
+		Spec: rayv1.RayServiceSpec{
+			PriorityClassName: "serving",
+			SchedulerName:     "volcano",
+			QueueLabels:       map[string]string{"volcano.sh/queue-name": "serving-queue"},
+			RayClusterSpec: rayv1.RayClusterSpec{
+				HeadGroupSpec: rayv1.HeadGroupSpec{
+					Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{PriorityClassName: "batch"}},
+				},
+				WorkerGroupSpecs: []rayv1.WorkerGroupSpec{{GroupName: "worker-group-1"}},
+			},
+		},
+	}
+	rayCluster, err := r.constructRayClusterForRayService(ctx, rayService, "rayservice-raycluster")
+	require.NoError(t, err)
+	for _, podSpec := range []corev1.PodSpec{rayCluster.Spec.HeadGroupSpec.Template.Spec, rayCluster.Spec.WorkerGroupSpecs[0].Template.Spec} {
+		assert.Equal(t, "serving", podSpec.PriorityClassName)
+		assert.Equal(t, "volcano", podSpec.SchedulerName)
+	}
+	assert.Equal(t, "serving-queue", rayCluster.Labels["volcano.sh/queue-name"])
+	assert.Equal(t, "serving", rayCluster.Labels[utils.RayPriorityClassName])
+	assert.Equal(t, "batch", rayService.Spec.RayClusterSpec.HeadGroupSpec.Template.Spec.PriorityClassName)
+
+	// Changing the priority class upgrades the RayCluster.
+	assert.Equal(t, DoNothing, decideClusterAction(ctx, rayService, rayCluster, nil))
+	rayService.Spec.PriorityClassName = "critical"
+	assert.Equal(t, GeneratePendingClusterName, decideClusterAction(ctx, rayService, rayCluster, nil))
+}
+
+func TestConstructRayClusterForRayServiceWithImagePullSecrets(t *testing.T) {
+	ctx := context.TODO()
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	r := &RayServiceReconciler{Scheme: newScheme}
+
+	rayService := &rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{Name: "rayservice", Namespace: "default"},
+		Spec: rayv1.RayServiceSpec{
+			ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry"}},
+			RayClusterSpec: rayv1.RayClusterSpec{
+				HeadGroupSpec: rayv1.HeadGroupSpec{
+					Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+						ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry"}},
+					}},
+				},
+				WorkerGroupSpecs: []rayv1.WorkerGroupSpec{{GroupName: "worker-group-1"}},
+			},
+		},
+	}
+	rayCluster, err := r.constructRayClusterForRayService(ctx, rayService, "rayservice-raycluster")
+	assert.NoError(t, err)
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "registry"}}, rayCluster.Spec.HeadGroupSpec.Template.Spec.ImagePullSecrets)
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "registry"}}, rayCluster.Spec.WorkerGroupSpecs[0].Template.Spec.ImagePullSecrets)
+	assert.Empty(t, rayService.Spec.RayClusterSpec.WorkerGroupSpecs[0].Template.Spec.ImagePullSecrets)
+
+	// The RayCluster isn't updated or recreated because of the added image pull secrets.
+	assert.Equal(t, DoNothing, decideClusterAction(ctx, rayService, rayCluster, nil))
+	rayService.Status.PendingServiceStatus.RayClusterName = rayCluster.Name
+	assert.Equal(t, DoNothing, decideClusterAction(ctx, rayService, nil, rayCluster))
+}
+
 func TestInconsistentRayServiceStatuses(t *testing.T) {
 	timeNow := metav1.Now()
 	oldStatus := rayv1.RayServiceStatuses{
@@ -412,181 +718,1664 @@ func TestInconsistentRayServiceStatus(t *testing.T) {
 		},
 	}
 
-	ctx := context.Background()
+	ctx := context.Background()
+
+	// Test 1: Only HealthLastUpdateTime is updated.
+	newStatus := oldStatus.DeepCopy()
+	for appName, application := range newStatus.Applications {
+		application.HealthLastUpdateTime = &metav1.Time{Time: timeNow.Add(1)}
+		newStatus.Applications[appName] = application
+	}
+	assert.False(t, inconsistentRayServiceStatus(ctx, oldStatus, *newStatus))
+}
+
+func TestIsHeadPodRunningAndReady(t *testing.T) {
+	// Create a new scheme with CRDs, Pod, Service schemes.
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	// Mock data
+	cluster := rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster",
+			Namespace: "default",
+		},
+	}
+
+	headPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "head-pod",
+			Namespace: cluster.ObjectMeta.Namespace,
+			Labels: map[string]string{
+				utils.RayClusterLabelKey:  cluster.ObjectMeta.Name,
+				utils.RayNodeTypeLabelKey: string(rayv1.HeadNode),
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			Conditions: []corev1.PodCondition{
+				{
+					Type:   corev1.PodScheduled,
+					Status: corev1.ConditionTrue,
+				},
+			},
+		},
+	}
+
+	// Initialize a fake client with newScheme and runtimeObjects.
+	runtimeObjects := []runtime.Object{}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
+	ctx := context.TODO()
+
+	// Initialize RayService reconciler.
+	r := &RayServiceReconciler{
+		Client:   fakeClient,
+		Recorder: &record.FakeRecorder{},
+		Scheme:   scheme.Scheme,
+	}
+
+	// Test 1: There is no head pod. `isHeadPodRunningAndReady` should return false.
+	// In addition, an error should be returned if the number of head pods is not 1.
+	isReady, err := r.isHeadPodRunningAndReady(ctx, &cluster)
+	assert.NotNil(t, err)
+	assert.False(t, isReady)
+
+	// Test 2: There is one head pod, but the pod is not running and ready.
+	// `isHeadPodRunningAndReady` should return false, and no error should be returned.
+	runtimeObjects = []runtime.Object{headPod}
+	fakeClient = clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
+	r.Client = fakeClient
+	isReady, err = r.isHeadPodRunningAndReady(ctx, &cluster)
+	assert.Nil(t, err)
+	assert.False(t, isReady)
+
+	// Test 3: There is one head pod, and the pod is running and ready.
+	// `isHeadPodRunningAndReady` should return true, and no error should be returned.
+	runningHeadPod := headPod.DeepCopy()
+	runningHeadPod.Status = corev1.PodStatus{
+		Phase: corev1.PodRunning,
+		Conditions: []corev1.PodCondition{
+			{
+				Type:   corev1.PodReady,
+				Status: corev1.ConditionTrue,
+			},
+		},
+	}
+	runtimeObjects = []runtime.Object{runningHeadPod}
+	fakeClient = clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
+	r.Client = fakeClient
+	isReady, err = r.isHeadPodRunningAndReady(ctx, &cluster)
+	assert.Nil(t, err)
+	assert.True(t, isReady)
+}
+
+func TestReconcileServices_UpdateService(t *testing.T) {
+	// Create a new scheme with CRDs, Pod, Service schemes.
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	// Mock data
+	namespace := "ray"
+	cluster := rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster",
+			Namespace: namespace,
+		},
+		Spec: rayv1.RayClusterSpec{
+			HeadGroupSpec: rayv1.HeadGroupSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name:  "ray-head",
+								Ports: []corev1.ContainerPort{},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	rayService := rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-service",
+			Namespace: cluster.ObjectMeta.Namespace,
+		},
+	}
+
+	// Initialize a fake client with newScheme and runtimeObjects.
+	runtimeObjects := []runtime.Object{}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
+
+	// Initialize RayCluster reconciler.
+	r := &RayServiceReconciler{
+		Client:   fakeClient,
+		Recorder: &record.FakeRecorder{},
+		Scheme:   scheme.Scheme,
+	}
+
+	ctx := context.TODO()
+	// Create a head service.
+	_, err := r.reconcileServices(ctx, &rayService, &cluster, utils.HeadService)
+	assert.Nil(t, err, "Fail to reconcile service")
+	assert.Equal(t, "test-service-head-svc", rayService.Status.HeadServiceName)
+
+	svcList := corev1.ServiceList{}
+	err = fakeClient.List(ctx, &svcList, client.InNamespace(namespace))
+	assert.Nil(t, err, "Fail to get service list")
+	assert.Equal(t, 1, len(svcList.Items), "Service list should have one item")
+	oldSvc := svcList.Items[0].DeepCopy()
+
+	// Test 1: When the service for the RayCluster already exists, it should not be updated.
+	cluster.Spec.HeadGroupSpec.Template.Spec.Containers[0].Ports = []corev1.ContainerPort{
+		{
+			Name:          "test-port",
+			ContainerPort: 9999,
+		},
+	}
+	drift, err := r.reconcileServices(ctx, &rayService, &cluster, utils.HeadService)
+	assert.Nil(t, err, "Fail to reconcile service")
+	assert.Equal(t, "the ports of the Service ray/test-service-head-svc", drift)
+
+	svcList = corev1.ServiceList{}
+	err = fakeClient.List(ctx, &svcList, client.InNamespace(namespace))
+	assert.Nil(t, err, "Fail to get service list")
+	assert.Equal(t, 1, len(svcList.Items), "Service list should have one item")
+	assert.True(t, reflect.DeepEqual(*oldSvc, svcList.Items[0]))
+
+	// Test 2: When the RayCluster switches, the service should be updated.
+	cluster.Name = "new-cluster"
+	drift, err = r.reconcileServices(ctx, &rayService, &cluster, utils.HeadService)
+	assert.Nil(t, err, "Fail to reconcile service")
+	assert.Empty(t, drift)
+
+	svcList = corev1.ServiceList{}
+	err = fakeClient.List(ctx, &svcList, client.InNamespace(namespace))
+	assert.Nil(t, err, "Fail to get service list")
+	assert.Equal(t, 1, len(svcList.Items), "Service list should have one item")
+	assert.False(t, reflect.DeepEqual(*oldSvc, svcList.Items[0]))
+
+	// Test 3: The selector of the Service is modified manually. The modification is kept in the Observe mode, and
+	// reverted in the Enforce mode.
+	modifiedSvc := svcList.Items[0].DeepCopy()
+	modifiedSvc.Spec.Selector["app"] = "canary"
+	err = fakeClient.Update(ctx, modifiedSvc)
+	assert.Nil(t, err, "Fail to update service")
+
+	drift, err = r.reconcileServices(ctx, &rayService, &cluster, utils.HeadService)
+	assert.Nil(t, err, "Fail to reconcile service")
+	assert.Equal(t, "the selector of the Service ray/test-service-head-svc", drift)
+	setServiceDriftCondition(&rayService, drift, "")
+	assert.True(t, meta.IsStatusConditionTrue(rayService.Status.Conditions, string(rayv1.ServiceDriftDetected)))
+
+	rayService.Spec.ServiceReconcileMode = ptr.To(rayv1.EnforceServiceReconcileMode)
+	drift, err = r.reconcileServices(ctx, &rayService, &cluster, utils.HeadService)
+	assert.Nil(t, err, "Fail to reconcile service")
+	assert.Empty(t, drift)
+	setServiceDriftCondition(&rayService, drift, "")
+	assert.True(t, meta.IsStatusConditionFalse(rayService.Status.Conditions, string(rayv1.ServiceDriftDetected)))
+
+	svc := &corev1.Service{}
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(modifiedSvc), svc)
+	assert.Nil(t, err, "Fail to get service")
+	assert.NotContains(t, svc.Spec.Selector, "app")
+}
+
+func TestReconcileServices_KeepExtraPorts(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	namespace := "ray"
+	cluster := rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster",
+			Namespace: namespace,
+		},
+		Spec: rayv1.RayClusterSpec{
+			HeadGroupSpec: rayv1.HeadGroupSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name:  "ray-head",
+								Ports: []corev1.ContainerPort{{Name: utils.ServingPortName, ContainerPort: utils.DefaultServingPort}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	rayService := rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-service",
+			Namespace: namespace,
+		},
+	}
+
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).Build()
+	r := &RayServiceReconciler{
+		Client:   fakeClient,
+		Recorder: &record.FakeRecorder{},
+		Scheme:   scheme.Scheme,
+	}
+
+	ctx := context.TODO()
+	_, err := r.reconcileServices(ctx, &rayService, &cluster, utils.ServingService)
+	require.NoError(t, err)
+
+	// Another controller adds a port and a label to the serve Service.
+	svc := &corev1.Service{}
+	err = fakeClient.Get(ctx, client.ObjectKey{Name: rayService.Status.ServeServiceName, Namespace: namespace}, svc)
+	require.NoError(t, err)
+	assert.Equal(t, utils.ServingPortName, svc.Annotations[utils.RayServiceGeneratedPortsAnnotationKey])
+	meshPort := corev1.ServicePort{Name: "mesh", Port: 15020, Protocol: corev1.ProtocolTCP}
+	svc.Spec.Ports = append(svc.Spec.Ports, meshPort)
+	svc.Labels["mesh"] = "true"
+	err = fakeClient.Update(ctx, svc)
+	require.NoError(t, err)
+
+	// The added port isn't a drift.
+	drift, err := r.reconcileServices(ctx, &rayService, &cluster, utils.ServingService)
+	require.NoError(t, err)
+	assert.Empty(t, drift)
+
+	// The added port and label are kept when the RayCluster switches.
+	cluster.Name = "new-cluster"
+	_, err = r.reconcileServices(ctx, &rayService, &cluster, utils.ServingService)
+	require.NoError(t, err)
+
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(svc), svc)
+	require.NoError(t, err)
+	assert.Equal(t, "new-cluster", svc.Spec.Selector[utils.RayClusterLabelKey])
+	portNames := make([]string, 0, len(svc.Spec.Ports))
+	for _, port := range svc.Spec.Ports {
+		portNames = append(portNames, port.Name)
+	}
+	assert.Equal(t, []string{utils.ServingPortName, "mesh"}, portNames)
+	assert.Equal(t, "true", svc.Labels["mesh"])
+	assert.Equal(t, utils.ServingPortName, svc.Annotations[utils.RayServiceGeneratedPortsAnnotationKey])
+}
+
+func TestReconcileServeHTTPRoute(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	namespace := "ray"
+	cluster := rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster",
+			Namespace: namespace,
+		},
+		Spec: rayv1.RayClusterSpec{
+			HeadGroupSpec: rayv1.HeadGroupSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name:  "ray-head",
+								Ports: []corev1.ContainerPort{{Name: utils.ServingPortName, ContainerPort: utils.DefaultServingPort}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	rayService := rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-service",
+			Namespace: namespace,
+		},
+		Spec: rayv1.RayServiceSpec{
+			ServeSessionAffinity: &rayv1.ServeSessionAffinity{Type: rayv1.CookieServeSessionAffinity, GatewayName: "gateway"},
+		},
+	}
+
+	restMapper := meta.NewDefaultRESTMapper(nil)
+	restMapper.Add(common.HTTPRouteGroupVersionKind, meta.RESTScopeNamespace)
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRESTMapper(restMapper).Build()
+	r := &RayServiceReconciler{
+		Client:   fakeClient,
+		Recorder: &record.FakeRecorder{},
+		Scheme:   newScheme,
+	}
+	ctx := context.TODO()
+	getRoute := func() (*unstructured.Unstructured, error) {
+		route := &unstructured.Unstructured{}
+		route.SetGroupVersionKind(common.HTTPRouteGroupVersionKind)
+		err := fakeClient.Get(ctx, client.ObjectKey{Name: utils.GenerateServeHTTPRouteName(rayService.Name), Namespace: namespace}, route)
+		return route, err
+	}
+
+	// The HTTPRoute is created for the Cookie session affinity.
+	err := r.reconcileServeHTTPRoute(ctx, &rayService, &cluster, nil)
+	assert.Nil(t, err)
+	route, err := getRoute()
+	assert.Nil(t, err)
+	assert.Equal(t, rayService.Name, route.GetOwnerReferences()[0].Name)
+
+	// The HTTPRoute is updated when the cookie name changes.
+	rayService.Spec.ServeSessionAffinity.CookieName = ptr.To("session")
+	err = r.reconcileServeHTTPRoute(ctx, &rayService, &cluster, nil)
+	assert.Nil(t, err)
+	route, err = getRoute()
+	assert.Nil(t, err)
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	sessionName, _, _ := unstructured.NestedString(rules[0].(map[string]interface{}), "sessionPersistence", "sessionName")
+	assert.Equal(t, "session", sessionName)
+
+	// The HTTPRoute is deleted when the session affinity changes to ClientIP.
+	rayService.Spec.ServeSessionAffinity = &rayv1.ServeSessionAffinity{Type: rayv1.ClientIPServeSessionAffinity}
+	err = r.reconcileServeHTTPRoute(ctx, &rayService, &cluster, nil)
+	assert.Nil(t, err)
+	_, err = getRoute()
+	assert.True(t, errors.IsNotFound(err))
+
+	// The Gateway API CRDs are only needed by the Cookie session affinity.
+	r.Client = clientFake.NewClientBuilder().WithScheme(newScheme).Build()
+	err = r.reconcileServeHTTPRoute(ctx, &rayService, &cluster, nil)
+	assert.Nil(t, err)
+}
+
+func TestSetKubeRayVersionUpdateDeferredCondition(t *testing.T) {
+	rayService := &rayv1.RayService{
+		Spec: rayv1.RayServiceSpec{
+			UpgradeStrategy: &rayv1.RayServiceUpgradeStrategy{KubeRayVersionPolicy: ptr.To(rayv1.ManualKubeRayVersionPolicy)},
+		},
+	}
+	activeRayCluster := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "active",
+			Annotations: map[string]string{utils.KubeRayVersion: "some-other-version"},
+		},
+	}
+
+	// The condition is set while the update of the active RayCluster waits for an approval.
+	setKubeRayVersionUpdateDeferredCondition(rayService, activeRayCluster)
+	condition := meta.FindStatusCondition(rayService.Status.Conditions, string(rayv1.KubeRayVersionUpdateDeferred))
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, rayv1.KubeRayVersionMismatch, condition.Reason)
+
+	// The condition is removed once the update is approved.
+	rayService.Annotations = map[string]string{utils.RayServiceKubeRayVersionUpdateAnnotationKey: "true"}
+	setKubeRayVersionUpdateDeferredCondition(rayService, activeRayCluster)
+	assert.Nil(t, meta.FindStatusCondition(rayService.Status.Conditions, string(rayv1.KubeRayVersionUpdateDeferred)))
+
+	// The condition isn't set if the versions match.
+	rayService.Annotations = nil
+	activeRayCluster.Annotations[utils.KubeRayVersion] = utils.KUBERAY_VERSION
+	setKubeRayVersionUpdateDeferredCondition(rayService, activeRayCluster)
+	assert.Empty(t, rayService.Status.Conditions)
+}
+
+func TestShadowServeRequests(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	namespace := "ray"
+	newCluster := func(name string) *rayv1.RayCluster {
+		return &rayv1.RayCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: rayv1.RayClusterSpec{
+				HeadGroupSpec: rayv1.HeadGroupSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{
+								Name:  "ray-head",
+								Ports: []corev1.ContainerPort{{Name: utils.ServingPortName, ContainerPort: utils.DefaultServingPort}},
+							}},
+						},
+					},
+				},
+			},
+		}
+	}
+	activeCluster, pendingCluster := newCluster("active"), newCluster("pending")
+	rayService := rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-service", Namespace: namespace},
+		Spec: rayv1.RayServiceSpec{
+			UpgradeStrategy: &rayv1.RayServiceUpgradeStrategy{
+				RequestShadowing: &rayv1.RequestShadowing{GatewayName: "gateway", DurationSeconds: ptr.To[int32](60)},
+			},
+		},
+	}
+
+	counts := map[string]rayv1.ServeRequestCounts{
+		"active":  {Requests: 1000, Errors: 10},
+		"pending": {Requests: 0, Errors: 0},
+	}
+	restMapper := meta.NewDefaultRESTMapper(nil)
+	restMapper.Add(common.HTTPRouteGroupVersionKind, meta.RESTScopeNamespace)
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRESTMapper(restMapper).Build()
+	recorder := record.NewFakeRecorder(10)
+	r := &RayServiceReconciler{
+		Client:   fakeClient,
+		Recorder: recorder,
+		Scheme:   newScheme,
+		serveRequestCountsFunc: func(_ context.Context, rayCluster *rayv1.RayCluster) (rayv1.ServeRequestCounts, error) {
+			return counts[rayCluster.Name], nil
+		},
+	}
+	ctx := context.TODO()
+
+	// The shadowing starts with the counters of both RayClusters as baselines.
+	passed, err := r.shadowServeRequests(ctx, &rayService, activeCluster, pendingCluster)
+	require.NoError(t, err)
+	assert.False(t, passed)
+	status := rayService.Status.RequestShadowing
+	require.NotNil(t, status)
+	assert.Equal(t, "pending", status.RayClusterName)
+	assert.Equal(t, counts["active"], status.ActiveBaseline)
+	assert.Contains(t, <-recorder.Events, string(utils.ShadowingServeRequests))
+
+	// The pending RayCluster isn't promoted before the end of the shadowing.
+	counts["active"] = rayv1.ServeRequestCounts{Requests: 2000, Errors: 20}
+	counts["pending"] = rayv1.ServeRequestCounts{Requests: 100, Errors: 5}
+	passed, err = r.shadowServeRequests(ctx, &rayService, activeCluster, pendingCluster)
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Equal(t, rayv1.ServeRequestCounts{Requests: 1000, Errors: 10}, status.Active)
+	assert.Equal(t, rayv1.ServeRequestCounts{Requests: 100, Errors: 5}, status.Pending)
+	assert.Equal(t, "4.00", status.ErrorRateDeltaPercent)
+
+	// The pending RayCluster isn't promoted while its error rate is too high.
+	status.StartTime = &metav1.Time{Time: time.Now().Add(-2 * time.Minute)}
+	passed, err = r.shadowServeRequests(ctx, &rayService, activeCluster, pendingCluster)
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Contains(t, <-recorder.Events, string(utils.ShadowingErrorRateExceeded))
+
+	// The pending RayCluster passes the shadowing once its error rate is close to the one of the active RayCluster.
+	counts["pending"] = rayv1.ServeRequestCounts{Requests: 1000, Errors: 15}
+	passed, err = r.shadowServeRequests(ctx, &rayService, activeCluster, pendingCluster)
+	require.NoError(t, err)
+	assert.True(t, passed)
+	assert.True(t, status.Passed)
+	assert.Equal(t, "0.50", status.ErrorRateDeltaPercent)
+
+	// A new pending RayCluster is shadowed again.
+	passed, err = r.shadowServeRequests(ctx, &rayService, activeCluster, newCluster("pending-2"))
+	require.NoError(t, err)
+	assert.False(t, passed)
+	assert.Equal(t, "pending-2", rayService.Status.RequestShadowing.RayClusterName)
+
+	// The shadowing is skipped if the Gateway API CRDs aren't installed.
+	r.Client = clientFake.NewClientBuilder().WithScheme(newScheme).Build()
+	passed, err = r.shadowServeRequests(ctx, &rayService, activeCluster, newCluster("pending-3"))
+	require.NoError(t, err)
+	assert.True(t, passed)
+}
+
+func TestReconcileServeTraffic_RequestShadowing(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	namespace := "ray"
+	newCluster := func(name string) *rayv1.RayCluster {
+		return &rayv1.RayCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: rayv1.RayClusterSpec{
+				HeadGroupSpec: rayv1.HeadGroupSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{
+								Name:  "ray-head",
+								Ports: []corev1.ContainerPort{{Name: utils.ServingPortName, ContainerPort: utils.DefaultServingPort}},
+							}},
+						},
+					},
+				},
+			},
+		}
+	}
+	activeCluster, pendingCluster := newCluster("active"), newCluster("pending")
+	rayService := rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-service", Namespace: namespace},
+		Spec: rayv1.RayServiceSpec{
+			UpgradeStrategy: &rayv1.RayServiceUpgradeStrategy{
+				RequestShadowing: &rayv1.RequestShadowing{GatewayName: "gateway", DurationSeconds: ptr.To[int32](60)},
+			},
+		},
+	}
+
+	restMapper := meta.NewDefaultRESTMapper(nil)
+	restMapper.Add(common.HTTPRouteGroupVersionKind, meta.RESTScopeNamespace)
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRESTMapper(restMapper).Build()
+	r := &RayServiceReconciler{
+		Client:   fakeClient,
+		Recorder: record.NewFakeRecorder(10),
+		Scheme:   newScheme,
+		serveRequestCountsFunc: func(_ context.Context, _ *rayv1.RayCluster) (rayv1.ServeRequestCounts, error) {
+			return rayv1.ServeRequestCounts{Requests: 1000, Errors: 10}, nil
+		},
+	}
+	ctx := context.TODO()
+	getMirrorBackendNames := func() []string {
+		route := &unstructured.Unstructured{}
+		route.SetGroupVersionKind(common.HTTPRouteGroupVersionKind)
+		err := fakeClient.Get(ctx, client.ObjectKey{Name: utils.GenerateServeHTTPRouteName(rayService.Name), Namespace: namespace}, route)
+		require.NoError(t, err)
+		rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+		filters, _, _ := unstructured.NestedSlice(rules[0].(map[string]interface{}), "filters")
+		names := []string{}
+		for _, filter := range filters {
+			if name, found, _ := unstructured.NestedString(filter.(map[string]interface{}), "requestMirror", "backendRef", "name"); found {
+				names = append(names, name)
+			}
+		}
+		return names
+	}
+
+	// The requests are mirrored to the preview serve service of the pending RayCluster at the end of every
+	// reconciliation while it is shadowed.
+	for range 2 {
+		ready, err := r.reconcileServeTraffic(ctx, &rayService, activeCluster, pendingCluster, true)
+		require.NoError(t, err)
+		assert.False(t, ready)
+		assert.Equal(t, []string{utils.GeneratePreviewServeServiceName(rayService.Name)}, getMirrorBackendNames())
+	}
+
+	// The requests aren't mirrored anymore once the pending RayCluster passed the shadowing.
+	rayService.Status.RequestShadowing.StartTime = &metav1.Time{Time: time.Now().Add(-2 * time.Minute)}
+	ready, err := r.reconcileServeTraffic(ctx, &rayService, activeCluster, pendingCluster, true)
+	require.NoError(t, err)
+	assert.True(t, ready)
+	assert.Empty(t, getMirrorBackendNames())
+}
+
+func TestReconcileServeAlertsPrometheusRule(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+
+	namespace := "ray"
+	rayService := rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-service",
+			Namespace: namespace,
+		},
+		Spec: rayv1.RayServiceSpec{
+			ServeAlerting: &rayv1.ServeAlertingOptions{
+				Applications: []rayv1.ServeApplicationSLO{{Name: "app", MinReplicas: ptr.To[int32](1)}},
+			},
+		},
+	}
+
+	restMapper := meta.NewDefaultRESTMapper(nil)
+	restMapper.Add(common.PrometheusRuleGroupVersionKind, meta.RESTScopeNamespace)
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRESTMapper(restMapper).Build()
+	r := &RayServiceReconciler{
+		Client:   fakeClient,
+		Recorder: &record.FakeRecorder{},
+		Scheme:   newScheme,
+	}
+	ctx := context.TODO()
+	getRule := func() (*unstructured.Unstructured, error) {
+		rule := &unstructured.Unstructured{}
+		rule.SetGroupVersionKind(common.PrometheusRuleGroupVersionKind)
+		err := fakeClient.Get(ctx, client.ObjectKey{Name: utils.GenerateServeAlertsRuleName(rayService.Name), Namespace: namespace}, rule)
+		return rule, err
+	}
+
+	// The PrometheusRule is created with the alerts of the applications.
+	err := r.reconcileServeAlertsPrometheusRule(ctx, &rayService)
+	require.NoError(t, err)
+	rule, err := getRule()
+	require.NoError(t, err)
+	assert.Equal(t, rayService.Name, rule.GetOwnerReferences()[0].Name)
+
+	// The PrometheusRule is updated when the SLOs change.
+	rayService.Spec.ServeAlerting.Applications[0].MaxUnhealthySeconds = ptr.To[int32](30)
+	err = r.reconcileServeAlertsPrometheusRule(ctx, &rayService)
+	require.NoError(t, err)
+	rule, err = getRule()
+	require.NoError(t, err)
+	groups, _, _ := unstructured.NestedSlice(rule.Object, "spec", "groups")
+	rules, _, _ := unstructured.NestedSlice(groups[0].(map[string]interface{}), "rules")
+	assert.Len(t, rules, 2)
+
+	// The PrometheusRule is skipped if the Prometheus Operator CRDs aren't installed.
+	r.Client = clientFake.NewClientBuilder().WithScheme(newScheme).Build()
+	err = r.reconcileServeAlertsPrometheusRule(ctx, &rayService)
+	assert.NoError(t, err)
+}
+
+func TestSetReadyCondition(t *testing.T) {
+	now := time.Now()
+	rayService := rayv1.RayService{
+		Spec: rayv1.RayServiceSpec{
+			ServeAlerting: &rayv1.ServeAlertingOptions{
+				Applications: []rayv1.ServeApplicationSLO{
+					{Name: "app", MaxUnhealthySeconds: ptr.To[int32](60), MinReplicas: ptr.To[int32](2)},
+				},
+			},
+		},
+		Status: rayv1.RayServiceStatuses{
+			ServiceStatus: rayv1.WaitForServeDeploymentReady,
+		},
+	}
+
+	setReadyCondition(&rayService, now)
+	condition := meta.FindStatusCondition(rayService.Status.Conditions, string(rayv1.RayServiceReady))
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, rayv1.ServeApplicationsNotReady, condition.Reason)
+
+	// The application meets its SLOs.
+	rayService.Status.ServiceStatus = rayv1.Running
+	rayService.Status.ActiveServiceStatus.Applications = map[string]rayv1.AppStatus{
+		"app": {
+			Status:               rayv1.ApplicationStatusEnum.RUNNING,
+			HealthLastUpdateTime: &metav1.Time{Time: now},
+			Deployments: map[string]rayv1.ServeDeploymentStatus{
+				"a": {Replicas: &rayv1.ServeReplicasSummary{States: map[string]int32{utils.ServeReplicaRunningState: 1}}},
+				"b": {Replicas: &rayv1.ServeReplicasSummary{States: map[string]int32{utils.ServeReplicaRunningState: 1, utils.ServeReplicaStartingState: 1}}},
+			},
+		},
+	}
+	setReadyCondition(&rayService, now)
+	assert.True(t, meta.IsStatusConditionTrue(rayService.Status.Conditions, string(rayv1.RayServiceReady)))
+
+	// The application is unhealthy, but not for longer than maxUnhealthySeconds.
+	app := rayService.Status.ActiveServiceStatus.Applications["app"]
+	app.Status = rayv1.ApplicationStatusEnum.UNHEALTHY
+	app.HealthLastUpdateTime = &metav1.Time{Time: now.Add(-30 * time.Second)}
+	rayService.Status.ActiveServiceStatus.Applications["app"] = app
+	setReadyCondition(&rayService, now)
+	assert.True(t, meta.IsStatusConditionTrue(rayService.Status.Conditions, string(rayv1.RayServiceReady)))
+
+	// The application is unhealthy for too long and has too few running replicas.
+	app.HealthLastUpdateTime = &metav1.Time{Time: now.Add(-2 * time.Minute)}
+	delete(app.Deployments, "b")
+	rayService.Status.ActiveServiceStatus.Applications["app"] = app
+	setReadyCondition(&rayService, now)
+	condition = meta.FindStatusCondition(rayService.Status.Conditions, string(rayv1.RayServiceReady))
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, rayv1.ServeApplicationSLOViolated, condition.Reason)
+	assert.Contains(t, condition.Message, "has been UNHEALTHY for more than 60 seconds")
+	assert.Contains(t, condition.Message, "has 1 running replicas, less than the minimum of 2")
+
+	// An application of the SLOs that isn't deployed violates them.
+	rayService.Status.ActiveServiceStatus.Applications = map[string]rayv1.AppStatus{}
+	setReadyCondition(&rayService, now)
+	condition = meta.FindStatusCondition(rayService.Status.Conditions, string(rayv1.RayServiceReady))
+	assert.Equal(t, "the Serve application app is not deployed", condition.Message)
+}
+
+func TestReconcileFailover(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	var notifications []utils.FailoverNotification
+	webhookStatus := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var notification utils.FailoverNotification
+		if err := json.NewDecoder(r.Body).Decode(&notification); err == nil && webhookStatus == http.StatusOK {
+			notifications = append(notifications, notification)
+		}
+		w.WriteHeader(webhookStatus)
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	rayService := &rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{Name: "rayservice", Namespace: "default"},
+		Spec: rayv1.RayServiceSpec{
+			FailoverPolicy: &rayv1.FailoverPolicy{
+				UnhealthyThresholdSeconds: ptr.To[int32](60),
+				WebhookURL:                ptr.To(server.URL),
+				ExternalDNSHostname:       ptr.To("serve.example.com"),
+			},
+		},
+	}
+	serveSvc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+		Name:      common.RayServiceServeServiceNamespacedName(rayService).Name,
+		Namespace: "default",
+	}}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(serveSvc).Build()
+	recorder := record.NewFakeRecorder(10)
+	r := &RayServiceReconciler{Client: fakeClient, Recorder: recorder, Scheme: newScheme}
+	ctx := context.Background()
+	setReady := func(status metav1.ConditionStatus, since time.Time) {
+		rayService.Status.Conditions = []metav1.Condition{{
+			Type: string(rayv1.RayServiceReady), Status: status, Reason: rayv1.ServeApplicationsNotReady,
+			Message: "The Serve applications are not ready to serve requests", LastTransitionTime: metav1.NewTime(since),
+		}}
+	}
+	getHostname := func() (string, bool) {
+		svc := &corev1.Service{}
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(serveSvc), svc))
+		hostname, ok := svc.Annotations[utils.ExternalDNSHostnameAnnotationKey]
+		return hostname, ok
+	}
+
+	// The ready RayService is served under the hostname.
+	setReady(metav1.ConditionTrue, now.Add(-time.Hour))
+	require.NoError(t, r.reconcileFailover(ctx, rayService, now))
+	assert.False(t, meta.IsStatusConditionTrue(rayService.Status.Conditions, string(rayv1.RayServiceFailedOver)))
+	hostname, _ := getHostname()
+	assert.Equal(t, "serve.example.com", hostname)
+	assert.Empty(t, notifications)
+
+	// The RayService doesn't fail over before the threshold.
+	setReady(metav1.ConditionFalse, now.Add(-30*time.Second))
+	require.NoError(t, r.reconcileFailover(ctx, rayService, now))
+	assert.False(t, meta.IsStatusConditionTrue(rayService.Status.Conditions, string(rayv1.RayServiceFailedOver)))
+	assert.Empty(t, notifications)
+
+	// The failover is retried until the webhook accepts it.
+	setReady(metav1.ConditionFalse, now.Add(-2*time.Minute))
+	webhookStatus = http.StatusInternalServerError
+	require.Error(t, r.reconcileFailover(ctx, rayService, now))
+	assert.False(t, meta.IsStatusConditionTrue(rayService.Status.Conditions, string(rayv1.RayServiceFailedOver)))
+	assert.Contains(t, <-recorder.Events, string(utils.FailedToCallFailoverWebhook))
+
+	webhookStatus = http.StatusOK
+	require.NoError(t, r.reconcileFailover(ctx, rayService, now))
+	condition := meta.FindStatusCondition(rayService.Status.Conditions, string(rayv1.RayServiceFailedOver))
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, rayv1.UnhealthyThresholdExceeded, condition.Reason)
+	require.Len(t, notifications, 1)
+	assert.Equal(t, utils.FailoverStateFailedOver, notifications[0].State)
+	assert.Equal(t, "rayservice", notifications[0].Name)
+	assert.Contains(t, <-recorder.Events, string(utils.FailedOverRayService))
+	_, ok := getHostname()
+	assert.False(t, ok)
+
+	// The RayService recovers once it is ready.
+	setReady(metav1.ConditionTrue, now)
+	rayService.Status.Conditions = append(rayService.Status.Conditions, *condition)
+	require.NoError(t, r.reconcileFailover(ctx, rayService, now))
+	assert.False(t, meta.IsStatusConditionTrue(rayService.Status.Conditions, string(rayv1.RayServiceFailedOver)))
+	require.Len(t, notifications, 2)
+	assert.Equal(t, utils.FailoverStateRecovered, notifications[1].State)
+	assert.Contains(t, <-recorder.Events, string(utils.RecoveredRayService))
+	hostname, _ = getHostname()
+	assert.Equal(t, "serve.example.com", hostname)
+
+	// The condition is removed with the policy.
+	rayService.Spec.FailoverPolicy = nil
+	require.NoError(t, r.reconcileFailover(ctx, rayService, now))
+	assert.Nil(t, meta.FindStatusCondition(rayService.Status.Conditions, string(rayv1.RayServiceFailedOver)))
+}
+
+func TestReconcileMaintenance(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	_ = appsv1.AddToScheme(newScheme)
+
+	rayService := &rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{Name: "rayservice", Namespace: "default"},
+		Spec:       rayv1.RayServiceSpec{MaintenanceMode: true},
+		Status: rayv1.RayServiceStatuses{
+			ServiceStatus: rayv1.Running,
+			Conditions: []metav1.Condition{{
+				Type: string(rayv1.RayServiceReady), Status: metav1.ConditionTrue, Reason: rayv1.ServeApplicationsRunning,
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Hour)),
+			}},
+		},
+	}
+	clusterSelector := map[string]string{utils.RayClusterLabelKey: "rayservice-abcde", utils.RayClusterServingServiceLabelKey: utils.EnableRayClusterServingServiceTrue}
+	serveSvc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: common.RayServiceServeServiceNamespacedName(rayService).Name, Namespace: "default"},
+		Spec:       corev1.ServiceSpec{Selector: clusterSelector},
+	}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(serveSvc).Build()
+	recorder := record.NewFakeRecorder(10)
+	r := &RayServiceReconciler{Client: fakeClient, Recorder: recorder, Scheme: newScheme}
+	ctx := context.Background()
+	name := common.MaintenanceResponderName(rayService)
+	getSelector := func() map[string]string {
+		svc := &corev1.Service{}
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(serveSvc), svc))
+		return svc.Spec.Selector
+	}
+
+	// The serve service points at the maintenance responder, and the RayService is neither ready nor failed over.
+	require.NoError(t, r.reconcileMaintenance(ctx, rayService))
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, &appsv1.Deployment{}))
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, &corev1.ConfigMap{}))
+	assert.Equal(t, common.MaintenanceResponderSelector(rayService), getSelector())
+	assert.True(t, meta.IsStatusConditionTrue(rayService.Status.Conditions, string(rayv1.RayServiceMaintenanceActive)))
+	assert.Equal(t, "Normal StartedMaintenance The RayService default/rayservice is in maintenance mode, the serve service points at the maintenance responder default/rayservice-maintenance", <-recorder.Events)
+	setReadyCondition(rayService, time.Now())
+	ready := meta.FindStatusCondition(rayService.Status.Conditions, string(rayv1.RayServiceReady))
+	assert.Equal(t, rayv1.InMaintenance, ready.Reason)
+	rayService.Spec.FailoverPolicy = &rayv1.FailoverPolicy{UnhealthyThresholdSeconds: ptr.To[int32](0)}
+	require.NoError(t, r.reconcileFailover(ctx, rayService, time.Now().Add(time.Hour)))
+	assert.False(t, meta.IsStatusConditionTrue(rayService.Status.Conditions, string(rayv1.RayServiceFailedOver)))
+
+	// The maintenance is reconciled idempotently.
+	require.NoError(t, r.reconcileMaintenance(ctx, rayService))
+	assert.Empty(t, recorder.Events)
+
+	// Once the maintenance ends, the responder is deleted and the Ready condition starts over.
+	rayService.Spec.MaintenanceMode = false
+	require.NoError(t, r.reconcileMaintenance(ctx, rayService))
+	err := fakeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, &appsv1.Deployment{})
+	assert.True(t, errors.IsNotFound(err))
+	err = fakeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, &corev1.ConfigMap{})
+	assert.True(t, errors.IsNotFound(err))
+	assert.Nil(t, meta.FindStatusCondition(rayService.Status.Conditions, string(rayv1.RayServiceMaintenanceActive)))
+	assert.Equal(t, "Normal EndedMaintenance The RayService default/rayservice is no longer in maintenance mode", <-recorder.Events)
+	now := time.Now()
+	setReadyCondition(rayService, now)
+	ready = meta.FindStatusCondition(rayService.Status.Conditions, string(rayv1.RayServiceReady))
+	assert.Equal(t, metav1.ConditionTrue, ready.Status)
+	assert.False(t, ready.LastTransitionTime.Before(&metav1.Time{Time: now.Add(-time.Second)}))
+}
+
+func TestReconcileServeEndpointSlice(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	_ = discoveryv1.AddToScheme(newScheme)
+
+	rayService := &rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{Name: "rayservice", Namespace: "default", UID: "rayservice-uid"},
+		Spec: rayv1.RayServiceSpec{
+			ServeTopologyRouting: &rayv1.ServeTopologyRouting{Mode: ptr.To(rayv1.ManagedServeTopologyRouting)},
+		},
+	}
+	rayCluster := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "rayservice-abcde", Namespace: "default"},
+		Spec: rayv1.RayClusterSpec{
+			HeadGroupSpec: rayv1.HeadGroupSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "ray-head"}}},
+				},
+			},
+		},
+	}
+	selector := common.ServeEndpointSelector(rayService, rayCluster)
+	newPod := func(name, nodeName, podIP string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: selector},
+			Spec:       corev1.PodSpec{NodeName: nodeName},
+			Status: corev1.PodStatus{
+				Phase:      corev1.PodRunning,
+				PodIP:      podIP,
+				Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+			},
+		}
+	}
+	newNode := func(name, zone string) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{corev1.LabelTopologyZone: zone}}}
+	}
+	serveSvcName := common.RayServiceServeServiceNamespacedName(rayService).Name
+	// The EndpointSlice created by Kubernetes while the serve service had a selector.
+	staleSlice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      serveSvcName + "-xyz",
+			Namespace: "default",
+			Labels: map[string]string{
+				discoveryv1.LabelServiceName: serveSvcName,
+				discoveryv1.LabelManagedBy:   utils.KubernetesEndpointSliceControllerName,
+			},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+	}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(
+		newPod("head", "node-a", "10.0.0.1"),
+		newPod("worker", "node-b", "10.0.0.2"),
+		newNode("node-a", "zone-a"),
+		newNode("node-b", "zone-b"),
+		staleSlice,
+	).Build()
+	r := &RayServiceReconciler{Client: fakeClient, Recorder: &record.FakeRecorder{}, Scheme: newScheme, nodeZones: lru.New(utils.NodeZoneLRUSize)}
+	ctx := context.Background()
+	getSlice := func() (*discoveryv1.EndpointSlice, error) {
+		slice := &discoveryv1.EndpointSlice{}
+		err := fakeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: serveSvcName}, slice)
+		return slice, err
+	}
+
+	// The serve service has neither a selector nor Kubernetes-managed EndpointSlices in the Managed mode.
+	_, err := r.reconcileServices(ctx, rayService, rayCluster, utils.ServingService)
+	require.NoError(t, err)
+	serveSvc := &corev1.Service{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: serveSvcName}, serveSvc))
+	assert.Nil(t, serveSvc.Spec.Selector)
+	assert.Equal(t, "Auto", serveSvc.Annotations[corev1.AnnotationTopologyMode])
+
+	require.NoError(t, r.reconcileServeEndpointSlice(ctx, rayService, selector))
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(staleSlice), &discoveryv1.EndpointSlice{})
+	assert.True(t, errors.IsNotFound(err))
+	slice, err := getSlice()
+	require.NoError(t, err)
+	assert.True(t, metav1.IsControlledBy(slice, rayService))
+	require.Len(t, slice.Endpoints, 2)
+	assert.Equal(t, []string{"10.0.0.1"}, slice.Endpoints[0].Addresses)
+	assert.Equal(t, &discoveryv1.EndpointHints{ForZones: []discoveryv1.ForZone{{Name: "zone-a"}}}, slice.Endpoints[0].Hints)
+	assert.Equal(t, &discoveryv1.EndpointHints{ForZones: []discoveryv1.ForZone{{Name: "zone-b"}}}, slice.Endpoints[1].Hints)
+	require.NoError(t, r.calculateStatus(ctx, rayService))
+	assert.Equal(t, int32(2), rayService.Status.NumServeEndpoints)
+
+	// The EndpointSlice follows the readiness of the Pods.
+	require.NoError(t, fakeClient.Delete(ctx, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-b"}}))
+	worker := &corev1.Pod{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "worker"}, worker))
+	worker.Status.Conditions[0].Status = corev1.ConditionFalse
+	require.NoError(t, fakeClient.Status().Update(ctx, worker))
+	require.NoError(t, r.reconcileServeEndpointSlice(ctx, rayService, selector))
+	slice, err = getSlice()
+	require.NoError(t, err)
+	assert.False(t, *slice.Endpoints[1].Conditions.Ready)
+	// The zones of the nodes are cached.
+	assert.Equal(t, &discoveryv1.EndpointHints{ForZones: []discoveryv1.ForZone{{Name: "zone-b"}}}, slice.Endpoints[1].Hints)
+	require.NoError(t, r.calculateStatus(ctx, rayService))
+	assert.Equal(t, int32(1), rayService.Status.NumServeEndpoints)
+
+	// The EndpointSlice is deleted once the topology routing isn't Managed anymore.
+	rayService.Spec.ServeTopologyRouting.Mode = ptr.To(rayv1.AutoServeTopologyRouting)
+	require.NoError(t, r.reconcileServeEndpointSlice(ctx, rayService, selector))
+	_, err = getSlice()
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestRayServicesForServePod(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	newRayService := func(name string, mode rayv1.ServeTopologyRoutingMode) *rayv1.RayService {
+		return &rayv1.RayService{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: rayv1.RayServiceSpec{
+				ServeTopologyRouting: &rayv1.ServeTopologyRouting{Mode: ptr.To(mode)},
+			},
+		}
+	}
+	newRayCluster := func(name, rayServiceName string) *rayv1.RayCluster {
+		return &rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				utils.RayOriginatedFromCRNameLabelKey: rayServiceName,
+				utils.RayOriginatedFromCRDLabelKey:    utils.RayOriginatedFromCRDLabelValue(utils.RayServiceCRD),
+			},
+		}}
+	}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(
+		newRayService("managed", rayv1.ManagedServeTopologyRouting),
+		newRayService("auto", rayv1.AutoServeTopologyRouting),
+		newRayCluster("managed-abcde", "managed"),
+		newRayCluster("auto-abcde", "auto"),
+		&rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Name: "standalone", Namespace: "default"}},
+	).Build()
+	r := &RayServiceReconciler{Client: fakeClient, Scheme: newScheme}
+	ctx := context.Background()
+	newPod := func(labels map[string]string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "default", Labels: labels}}
+	}
+
+	tests := []struct {
+		pod      *corev1.Pod
+		name     string
+		expected []reconcile.Request
+	}{
+		{
+			name:     "Serve Pod of a RayService whose topology routing is Managed",
+			pod:      newPod(map[string]string{utils.RayClusterLabelKey: "managed-abcde", utils.RayClusterServingServiceLabelKey: "true"}),
+			expected: []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "default", Name: "managed"}}},
+		},
+		{
+			name:     "Pod of the maintenance responder of a RayService whose topology routing is Managed",
+			pod:      newPod(map[string]string{utils.RayServiceMaintenanceLabelKey: "managed"}),
+			expected: []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "default", Name: "managed"}}},
+		},
+		{
+			name: "Serve Pod of a RayService whose topology routing is Auto",
+			pod:  newPod(map[string]string{utils.RayClusterLabelKey: "auto-abcde", utils.RayClusterServingServiceLabelKey: "true"}),
+		},
+		{
+			name: "Serve Pod of a RayCluster without RayService",
+			pod:  newPod(map[string]string{utils.RayClusterLabelKey: "standalone", utils.RayClusterServingServiceLabelKey: "true"}),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, r.rayServicesForServePod(ctx, tc.pod))
+		})
+	}
+
+	// Only the changes of the endpoints of the serve Pods are passed.
+	servePredicate := servePodEndpointChangedPredicate()
+	oldPod := newPod(map[string]string{utils.RayClusterLabelKey: "managed-abcde", utils.RayClusterServingServiceLabelKey: "true"})
+	newServePod := oldPod.DeepCopy()
+	newServePod.Annotations = map[string]string{"key": "value"}
+	assert.False(t, servePredicate.Update(event.UpdateEvent{ObjectOld: oldPod, ObjectNew: newServePod}))
+	newServePod.Labels[utils.RayClusterServingServiceLabelKey] = "false"
+	assert.True(t, servePredicate.Update(event.UpdateEvent{ObjectOld: oldPod, ObjectNew: newServePod}))
+	assert.False(t, servePredicate.Create(event.CreateEvent{Object: newPod(map[string]string{utils.RayClusterLabelKey: "managed-abcde"})}))
+}
+
+func TestCleanUpRayClusterInstance(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+
+	rayService := &rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{Name: "rayservice", Namespace: "default"},
+		Status: rayv1.RayServiceStatuses{
+			ActiveServiceStatus: rayv1.RayServiceStatus{RayClusterName: "active"},
+		},
+	}
+	newRayCluster := func(name string, deletionTimestamp time.Time) *rayv1.RayCluster {
+		rayCluster := &rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				utils.RayOriginatedFromCRNameLabelKey: rayService.Name,
+				utils.RayOriginatedFromCRDLabelKey:    utils.RayOriginatedFromCRDLabelValue(utils.RayServiceCRD),
+			},
+		}}
+		if !deletionTimestamp.IsZero() {
+			rayCluster.Annotations = map[string]string{
+				utils.RayServiceClusterDeletionTimestampAnnotationKey: deletionTimestamp.UTC().Format(time.RFC3339),
+			}
+		}
+		return rayCluster
+	}
+	// The deletion of the "expired" RayCluster was scheduled before the operator restarted, and the "active"
+	// RayCluster was dangling before a rollback.
+	now := time.Now()
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(
+		newRayCluster("active", now.Add(-time.Minute)),
+		newRayCluster("expired", now.Add(-time.Minute)),
+		newRayCluster("scheduled", now.Add(time.Minute)),
+		newRayCluster("dangling", time.Time{}),
+	).Build()
+	r := &RayServiceReconciler{Client: fakeClient, Scheme: newScheme}
+	ctx := context.Background()
+	getAnnotation := func(name string) (string, bool) {
+		rayCluster := &rayv1.RayCluster{}
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, rayCluster))
+		value, ok := rayCluster.Annotations[utils.RayServiceClusterDeletionTimestampAnnotationKey]
+		return value, ok
+	}
+
+	require.NoError(t, r.cleanUpRayClusterInstance(ctx, rayService))
+	err := fakeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "expired"}, &rayv1.RayCluster{})
+	assert.True(t, errors.IsNotFound(err))
+	_, ok := getAnnotation("active")
+	assert.False(t, ok)
+	value, _ := getAnnotation("scheduled")
+	assert.Equal(t, now.Add(time.Minute).UTC().Format(time.RFC3339), value)
+	value, ok = getAnnotation("dangling")
+	require.True(t, ok)
+	deletionTimestamp, err := time.Parse(time.RFC3339, value)
+	require.NoError(t, err)
+	assert.WithinDuration(t, now.Add(RayClusterDeletionDelayDuration), deletionTimestamp, 5*time.Second)
+
+	// The deletion timestamps survive an operator restart, i.e. a new reconciler doesn't reset them.
+	r = &RayServiceReconciler{Client: fakeClient, Scheme: newScheme}
+	require.NoError(t, r.cleanUpRayClusterInstance(ctx, rayService))
+	newValue, _ := getAnnotation("dangling")
+	assert.Equal(t, value, newValue)
+	newValue, _ = getAnnotation("scheduled")
+	assert.Equal(t, now.Add(time.Minute).UTC().Format(time.RFC3339), newValue)
+}
+
+func TestReconcileServeConfigSource(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	serveConfig := "applications:\n- name: app1\n  import_path: fruit.deployment_graph\n"
+	etag := `"v1"`
+	sourceStatus := http.StatusOK
+	var authorizations []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+		if sourceStatus != http.StatusOK {
+			w.WriteHeader(sourceStatus)
+			return
+		}
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		_, _ = w.Write([]byte(serveConfig))
+	}))
+	defer server.Close()
+
+	rayService := &rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{Name: "rayservice", Namespace: "default"},
+		Spec: rayv1.RayServiceSpec{
+			ServeConfigSource: &rayv1.ServeConfigSource{
+				URL:                 server.URL,
+				PollIntervalSeconds: ptr.To[int32](60),
+				AuthSecretRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "registry-token"},
+					Key:                  "authorization",
+				},
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "registry-token", Namespace: "default"},
+		Data:       map[string][]byte{"authorization": []byte("Bearer token")},
+	}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(secret).Build()
+	recorder := record.NewFakeRecorder(10)
+	r := &RayServiceReconciler{Client: fakeClient, Recorder: recorder, Scheme: newScheme}
+	ctx := context.Background()
+	now := time.Now()
+
+	// The fetched Serve config is stored in the ConfigMap and used as the Serve config of the RayService.
+	require.NoError(t, r.reconcileServeConfigSource(ctx, rayService, now))
+	assert.Equal(t, []string{"Bearer token"}, authorizations)
+	assert.Contains(t, <-recorder.Events, string(utils.FetchedServeConfig))
+	resolved, err := r.resolveServeConfigV2(ctx, rayService)
+	require.NoError(t, err)
+	assert.Equal(t, serveConfig, resolved)
+	status := rayService.Status.ServeConfigSource
+	require.NotNil(t, status)
+	assert.Equal(t, etag, status.Revision)
+	assert.NotEmpty(t, status.ContentHash)
+
+	// The source isn't polled before the poll interval.
+	require.NoError(t, r.reconcileServeConfigSource(ctx, rayService, now.Add(30*time.Second)))
+	assert.Len(t, authorizations, 1)
+
+	// An unchanged Serve config only updates the fetch time.
+	now = now.Add(time.Minute)
+	require.NoError(t, r.reconcileServeConfigSource(ctx, rayService, now))
+	assert.Len(t, authorizations, 2)
+	assert.Equal(t, etag, rayService.Status.ServeConfigSource.Revision)
+	assert.Equal(t, now.Unix(), rayService.Status.ServeConfigSource.LastFetchTime.Unix())
+	assert.Empty(t, recorder.Events)
+
+	// A changed Serve config updates the ConfigMap and the revision.
+	serveConfig = "applications:\n- name: app2\n  import_path: fruit.deployment_graph\n"
+	etag = `"v2"`
+	now = now.Add(time.Minute)
+	require.NoError(t, r.reconcileServeConfigSource(ctx, rayService, now))
+	assert.Contains(t, <-recorder.Events, string(utils.FetchedServeConfig))
+	resolved, err = r.resolveServeConfigV2(ctx, rayService)
+	require.NoError(t, err)
+	assert.Equal(t, serveConfig, resolved)
+	assert.Equal(t, etag, rayService.Status.ServeConfigSource.Revision)
+	assert.NotEqual(t, status.ContentHash, rayService.Status.ServeConfigSource.ContentHash)
+
+	// The last fetched Serve config keeps being used when the source fails.
+	sourceStatus = http.StatusInternalServerError
+	now = now.Add(time.Minute)
+	require.NoError(t, r.reconcileServeConfigSource(ctx, rayService, now))
+	assert.Contains(t, <-recorder.Events, string(utils.FailedToFetchServeConfig))
+	assert.Equal(t, `"v2"`, rayService.Status.ServeConfigSource.Revision)
+	resolved, err = r.resolveServeConfigV2(ctx, rayService)
+	require.NoError(t, err)
+	assert.Equal(t, serveConfig, resolved)
+
+	// Without a fetched Serve config, the failure is an error.
+	other := rayService.DeepCopy()
+	other.Name = "other"
+	other.Status.ServeConfigSource = nil
+	require.Error(t, r.reconcileServeConfigSource(ctx, other, now))
+
+	// A ConfigMap that the RayService doesn't own isn't overwritten, and the source isn't fetched.
+	foreign := rayService.DeepCopy()
+	foreign.Name = "foreign"
+	foreign.UID = "foreign-uid"
+	foreign.Status.ServeConfigSource = nil
+	foreignConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: utils.GenerateServeConfigSourceConfigMapName(foreign.Name), Namespace: "default"},
+		Data:       map[string]string{"config": "value"},
+	}
+	require.NoError(t, fakeClient.Create(ctx, foreignConfigMap))
+	fetches := len(authorizations)
+	require.ErrorContains(t, r.reconcileServeConfigSource(ctx, foreign, now), "isn't owned by the RayService")
+	assert.Len(t, authorizations, fetches)
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(foreignConfigMap), foreignConfigMap))
+	assert.Equal(t, map[string]string{"config": "value"}, foreignConfigMap.Data)
+
+	// Removing the source clears its status.
+	rayService.Spec.ServeConfigSource = nil
+	require.NoError(t, r.reconcileServeConfigSource(ctx, rayService, now))
+	assert.Nil(t, rayService.Status.ServeConfigSource)
+}
+
+func TestReconcilePreviewServeService(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	namespace := "ray"
+	pendingCluster := rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pending-cluster",
+			Namespace: namespace,
+		},
+		Spec: rayv1.RayClusterSpec{
+			HeadGroupSpec: rayv1.HeadGroupSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{
+							{
+								Name:  "ray-head",
+								Ports: []corev1.ContainerPort{{Name: utils.ServingPortName, ContainerPort: utils.DefaultServingPort}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	rayService := rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-service",
+			Namespace: namespace,
+		},
+		Spec: rayv1.RayServiceSpec{
+			UpgradeStrategy: &rayv1.RayServiceUpgradeStrategy{EnablePreviewService: ptr.To(true)},
+		},
+	}
+	headPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "head-pod",
+			Namespace: namespace,
+			Labels: map[string]string{
+				utils.RayClusterLabelKey:  pendingCluster.Name,
+				utils.RayNodeTypeLabelKey: string(rayv1.HeadNode),
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "ray-head"}},
+		},
+	}
+
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(headPod).Build()
+	fakeRayHttpProxyClient := initFakeRayHttpProxyClient(true)
+	r := &RayServiceReconciler{
+		Client:   fakeClient,
+		Recorder: &record.FakeRecorder{},
+		Scheme:   newScheme,
+		httpProxyClientFunc: func() utils.RayHttpProxyClientInterface {
+			return fakeRayHttpProxyClient
+		},
+	}
+	ctx := context.TODO()
+	previewSvcKey := client.ObjectKey{Name: utils.GeneratePreviewServeServiceName(rayService.Name), Namespace: namespace}
+
+	// The preview serve service points at the pending RayCluster, and the head Pod of the pending RayCluster is labeled.
+	err := r.reconcilePreviewServeService(ctx, &rayService, &pendingCluster)
+	assert.Nil(t, err)
+	svc := &corev1.Service{}
+	err = fakeClient.Get(ctx, previewSvcKey, svc)
+	assert.Nil(t, err)
+	assert.Equal(t, pendingCluster.Name, svc.Spec.Selector[utils.RayClusterLabelKey])
+	assert.Equal(t, corev1.ServiceTypeClusterIP, svc.Spec.Type)
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(headPod), headPod)
+	assert.Nil(t, err)
+	assert.Equal(t, utils.EnableRayClusterServingServiceTrue, headPod.Labels[utils.RayClusterServingServiceLabelKey])
+
+	// The preview serve service is deleted once there is no pending RayCluster to preview.
+	err = r.reconcilePreviewServeService(ctx, &rayService, nil)
+	assert.Nil(t, err)
+	err = fakeClient.Get(ctx, previewSvcKey, svc)
+	assert.True(t, errors.IsNotFound(err))
+
+	// The preview serve service isn't created if it's not enabled.
+	rayService.Spec.UpgradeStrategy.EnablePreviewService = nil
+	err = r.reconcilePreviewServeService(ctx, &rayService, &pendingCluster)
+	assert.Nil(t, err)
+	err = fakeClient.Get(ctx, previewSvcKey, svc)
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestAbortRayServiceUpgrade(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	namespace := "ray"
+	pendingCluster := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pending-cluster",
+			Namespace: namespace,
+		},
+	}
+	rayService := &rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-service",
+			Namespace:   namespace,
+			Annotations: map[string]string{utils.RayServiceAbortAnnotationKey: "true"},
+		},
+		Spec: rayv1.RayServiceSpec{
+			UpgradeStrategy: &rayv1.RayServiceUpgradeStrategy{Promotion: ptr.To(rayv1.ManualPromotion)},
+		},
+		Status: rayv1.RayServiceStatuses{
+			ActiveServiceStatus:  rayv1.RayServiceStatus{RayClusterName: "active-cluster"},
+			PendingServiceStatus: rayv1.RayServiceStatus{RayClusterName: pendingCluster.Name},
+		},
+	}
+	assert.True(t, isRayServiceUpgradeAborted(rayService))
+
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(pendingCluster).Build()
+	r := &RayServiceReconciler{
+		Client:   fakeClient,
+		Recorder: &record.FakeRecorder{},
+		Scheme:   newScheme,
+	}
+	ctx := context.TODO()
+
+	err := r.abortRayServiceUpgrade(ctx, rayService)
+	assert.Nil(t, err)
+	assert.Empty(t, rayService.Status.PendingServiceStatus.RayClusterName)
+	assert.Equal(t, "active-cluster", rayService.Status.ActiveServiceStatus.RayClusterName)
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(pendingCluster), &rayv1.RayCluster{})
+	assert.True(t, errors.IsNotFound(err))
+
+	// The abort annotation is ignored with the Automatic promotion.
+	rayService.Spec.UpgradeStrategy.Promotion = ptr.To(rayv1.AutomaticPromotion)
+	assert.False(t, isRayServiceUpgradeAborted(rayService))
+}
+
+func TestUpdateServeConfigHashAnnotation(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+
+	rayCluster := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-cluster",
+			Namespace:   "ray",
+			Annotations: map[string]string{utils.KubeRayVersion: utils.KUBERAY_VERSION},
+		},
+	}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(rayCluster.DeepCopy()).Build()
+	r := &RayServiceReconciler{
+		Client:   fakeClient,
+		Recorder: &record.FakeRecorder{},
+		Scheme:   newScheme,
+	}
+	ctx := context.TODO()
+
+	err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rayCluster), rayCluster)
+	require.NoError(t, err)
+	err = r.updateServeConfigHashAnnotation(ctx, rayCluster, "applications: []")
+	require.NoError(t, err)
+	hash, err := utils.GenerateJsonHash("applications: []")
+	require.NoError(t, err)
+	assert.Equal(t, hash, rayCluster.Annotations[utils.RayServiceServeConfigHashAnnotationKey])
+
+	latest := &rayv1.RayCluster{}
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(rayCluster), latest)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{utils.KubeRayVersion: utils.KUBERAY_VERSION, utils.RayServiceServeConfigHashAnnotationKey: hash}, latest.Annotations)
+
+	// The hash is kept when the RayCluster is updated.
+	updatedRayCluster := rayCluster.DeepCopy()
+	updatedRayCluster.Annotations = map[string]string{utils.KubeRayVersion: utils.KUBERAY_VERSION}
+	err = r.updateRayClusterInstance(ctx, updatedRayCluster)
+	require.NoError(t, err)
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(rayCluster), latest)
+	require.NoError(t, err)
+	assert.Equal(t, hash, latest.Annotations[utils.RayServiceServeConfigHashAnnotationKey])
+}
+
+func TestReconcileDebugRayCluster(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	rayService := &rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-service",
+			Namespace:   "ray",
+			UID:         "test-uid",
+			Annotations: map[string]string{utils.RayServiceDebugClusterAnnotationKey: "true"},
+		},
+		Spec: rayv1.RayServiceSpec{ServeConfigV2: "applications: []"},
+	}
+	activeRayCluster := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-service-raycluster-abcde", Namespace: "ray"},
+		Spec: rayv1.RayClusterSpec{
+			WorkerGroupSpecs: []rayv1.WorkerGroupSpec{{GroupName: "cpu", Replicas: ptr.To[int32](3)}},
+		},
+	}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).Build()
+	r := &RayServiceReconciler{
+		Client:       fakeClient,
+		Recorder:     &record.FakeRecorder{},
+		Scheme:       newScheme,
+		ServeConfigs: lru.New(utils.ServeConfigLRUSize),
+	}
+	ctx := context.TODO()
+	key := client.ObjectKey{Name: utils.GenerateDebugRayClusterName(rayService.Name), Namespace: "ray"}
+
+	// The debug RayCluster isn't created until there is an active RayCluster.
+	err := r.reconcileDebugRayCluster(ctx, rayService, nil)
+	require.NoError(t, err)
+	err = fakeClient.Get(ctx, key, &rayv1.RayCluster{})
+	assert.True(t, errors.IsNotFound(err))
+
+	err = r.reconcileDebugRayCluster(ctx, rayService, activeRayCluster)
+	require.NoError(t, err)
+	debugRayCluster := &rayv1.RayCluster{}
+	err = fakeClient.Get(ctx, key, debugRayCluster)
+	require.NoError(t, err)
+	assert.True(t, metav1.IsControlledBy(debugRayCluster, rayService))
+	assert.Equal(t, int32(1), *debugRayCluster.Spec.WorkerGroupSpecs[0].Replicas)
+
+	// The Serve config isn't applied until the head Pod is ready.
+	err = r.reconcileDebugRayCluster(ctx, rayService, activeRayCluster)
+	require.NoError(t, err)
+	assert.Empty(t, r.getServeConfigFromCache(rayService, key.Name))
+
+	// The debug RayCluster is deleted once the annotation is removed.
+	rayService.Annotations = nil
+	err = r.reconcileDebugRayCluster(ctx, rayService, activeRayCluster)
+	require.NoError(t, err)
+	err = fakeClient.Get(ctx, key, &rayv1.RayCluster{})
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestRemoveRayServiceAnnotation(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
 
-	// Test 1: Only HealthLastUpdateTime is updated.
-	newStatus := oldStatus.DeepCopy()
-	for appName, application := range newStatus.Applications {
-		application.HealthLastUpdateTime = &metav1.Time{Time: timeNow.Add(1)}
-		newStatus.Applications[appName] = application
+	rayService := &rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-service",
+			Namespace:   "ray",
+			Annotations: map[string]string{utils.RayServicePromoteAnnotationKey: "true", "foo": "bar"},
+		},
 	}
-	assert.False(t, inconsistentRayServiceStatus(ctx, oldStatus, *newStatus))
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(rayService.DeepCopy()).Build()
+	r := &RayServiceReconciler{
+		Client:   fakeClient,
+		Recorder: &record.FakeRecorder{},
+		Scheme:   newScheme,
+	}
+	ctx := context.TODO()
+
+	err := fakeClient.Get(ctx, client.ObjectKeyFromObject(rayService), rayService)
+	assert.Nil(t, err)
+	// The in-memory status is kept.
+	rayService.Status.ServiceStatus = rayv1.Running
+	err = r.removeRayServiceAnnotation(ctx, rayService, utils.RayServicePromoteAnnotationKey)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"foo": "bar"}, rayService.Annotations)
+	assert.Equal(t, rayv1.Running, rayService.Status.ServiceStatus)
+
+	latest := &rayv1.RayService{}
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(rayService), latest)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"foo": "bar"}, latest.Annotations)
+	assert.Equal(t, latest.ResourceVersion, rayService.ResourceVersion)
 }
 
-func TestIsHeadPodRunningAndReady(t *testing.T) {
-	// Create a new scheme with CRDs, Pod, Service schemes.
+func TestProbeServeEndpoint(t *testing.T) {
 	newScheme := runtime.NewScheme()
 	_ = rayv1.AddToScheme(newScheme)
 	_ = corev1.AddToScheme(newScheme)
 
-	// Mock data
+	namespace := "ray"
 	cluster := rayv1.RayCluster{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "test-cluster",
-			Namespace: "default",
+			Name:      "pending-cluster",
+			Namespace: namespace,
+		},
+	}
+	rayService := rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-service",
+			Namespace: namespace,
+		},
+		Spec: rayv1.RayServiceSpec{
+			ServeProbe: &rayv1.ServeProbe{Path: "/app/healthz"},
 		},
 	}
-
 	headPod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      "head-pod",
-			Namespace: cluster.ObjectMeta.Namespace,
+			Namespace: namespace,
 			Labels: map[string]string{
-				utils.RayClusterLabelKey:  cluster.ObjectMeta.Name,
+				utils.RayClusterLabelKey:  cluster.Name,
 				utils.RayNodeTypeLabelKey: string(rayv1.HeadNode),
 			},
 		},
-		Status: corev1.PodStatus{
-			Phase: corev1.PodPending,
-			Conditions: []corev1.PodCondition{
-				{
-					Type:   corev1.PodScheduled,
-					Status: corev1.ConditionTrue,
-				},
-			},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "ray-head"}},
 		},
 	}
 
-	// Initialize a fake client with newScheme and runtimeObjects.
-	runtimeObjects := []runtime.Object{}
-	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
-	ctx := context.TODO()
-
-	// Initialize RayService reconciler.
+	fakeRayHttpProxyClient := &utils.FakeRayHttpProxyClient{IsHealthy: true}
 	r := &RayServiceReconciler{
-		Client:   fakeClient,
+		Client:   clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(headPod).Build(),
 		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
+		Scheme:   newScheme,
+		httpProxyClientFunc: func() utils.RayHttpProxyClientInterface {
+			return fakeRayHttpProxyClient
+		},
 	}
+	ctx := context.TODO()
 
-	// Test 1: There is no head pod. `isHeadPodRunningAndReady` should return false.
-	// In addition, an error should be returned if the number of head pods is not 1.
-	isReady, err := r.isHeadPodRunningAndReady(ctx, &cluster)
-	assert.NotNil(t, err)
-	assert.False(t, isReady)
+	err := r.probeServeEndpoint(ctx, &rayService, &cluster)
+	assert.NoError(t, err)
 
-	// Test 2: There is one head pod, but the pod is not running and ready.
-	// `isHeadPodRunningAndReady` should return false, and no error should be returned.
-	runtimeObjects = []runtime.Object{headPod}
-	fakeClient = clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
-	r.Client = fakeClient
-	isReady, err = r.isHeadPodRunningAndReady(ctx, &cluster)
-	assert.Nil(t, err)
-	assert.False(t, isReady)
+	fakeRayHttpProxyClient.ServeProbeErr = fmt.Errorf("status code: 500")
+	err = r.probeServeEndpoint(ctx, &rayService, &cluster)
+	assert.Error(t, err)
 
-	// Test 3: There is one head pod, and the pod is running and ready.
-	// `isHeadPodRunningAndReady` should return true, and no error should be returned.
-	runningHeadPod := headPod.DeepCopy()
-	runningHeadPod.Status = corev1.PodStatus{
-		Phase: corev1.PodRunning,
-		Conditions: []corev1.PodCondition{
-			{
-				Type:   corev1.PodReady,
-				Status: corev1.ConditionTrue,
-			},
-		},
-	}
-	runtimeObjects = []runtime.Object{runningHeadPod}
-	fakeClient = clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
-	r.Client = fakeClient
-	isReady, err = r.isHeadPodRunningAndReady(ctx, &cluster)
-	assert.Nil(t, err)
-	assert.True(t, isReady)
+	// The probe fails if the head Pod doesn't exist.
+	cluster.Name = "another-cluster"
+	fakeRayHttpProxyClient.ServeProbeErr = nil
+	err = r.probeServeEndpoint(ctx, &rayService, &cluster)
+	assert.Error(t, err)
 }
 
-func TestReconcileServices_UpdateService(t *testing.T) {
-	// Create a new scheme with CRDs, Pod, Service schemes.
+func TestCheckServeReadiness(t *testing.T) {
 	newScheme := runtime.NewScheme()
 	_ = rayv1.AddToScheme(newScheme)
 	_ = corev1.AddToScheme(newScheme)
 
-	// Mock data
 	namespace := "ray"
-	cluster := rayv1.RayCluster{
+	cluster := &rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Name: "pending-cluster", Namespace: namespace}}
+	headPod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      "test-cluster",
+			Name:      "head-pod",
 			Namespace: namespace,
-		},
-		Spec: rayv1.RayClusterSpec{
-			HeadGroupSpec: rayv1.HeadGroupSpec{
-				Template: corev1.PodTemplateSpec{
-					Spec: corev1.PodSpec{
-						Containers: []corev1.Container{
-							{
-								Name:  "ray-head",
-								Ports: []corev1.ContainerPort{},
-							},
-						},
-					},
-				},
+			Labels: map[string]string{
+				utils.RayClusterLabelKey:  cluster.Name,
+				utils.RayNodeTypeLabelKey: string(rayv1.HeadNode),
 			},
 		},
-	}
-	rayService := rayv1.RayService{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "test-service",
-			Namespace: cluster.ObjectMeta.Namespace,
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "ray-head"}},
 		},
 	}
+	runningStatus := &rayv1.RayServiceStatus{
+		Applications: map[string]rayv1.AppStatus{"app": {Status: rayv1.ApplicationStatusEnum.RUNNING}},
+	}
+	deployingStatus := &rayv1.RayServiceStatus{
+		Applications: map[string]rayv1.AppStatus{"app": {Status: rayv1.ApplicationStatusEnum.DEPLOYING}},
+	}
 
-	// Initialize a fake client with newScheme and runtimeObjects.
-	runtimeObjects := []runtime.Object{}
-	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(runtimeObjects...).Build()
-
-	// Initialize RayCluster reconciler.
+	fakeRayHttpProxyClient := &utils.FakeRayHttpProxyClient{IsHealthy: true}
+	metricSum := 0.0
+	recorder := record.NewFakeRecorder(10)
 	r := &RayServiceReconciler{
-		Client:   fakeClient,
-		Recorder: &record.FakeRecorder{},
-		Scheme:   scheme.Scheme,
+		Client:   clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(headPod).Build(),
+		Recorder: recorder,
+		Scheme:   newScheme,
+		httpProxyClientFunc: func() utils.RayHttpProxyClientInterface {
+			return fakeRayHttpProxyClient
+		},
+		serveMetricSumFunc: func(_ context.Context, _ *rayv1.RayCluster, metric string) (float64, error) {
+			assert.Equal(t, "ray_model_loaded", metric)
+			return metricSum, nil
+		},
 	}
-
 	ctx := context.TODO()
-	// Create a head service.
-	err := r.reconcileServices(ctx, &rayService, &cluster, utils.HeadService)
-	assert.Nil(t, err, "Fail to reconcile service")
-
-	svcList := corev1.ServiceList{}
-	err = fakeClient.List(ctx, &svcList, client.InNamespace(namespace))
-	assert.Nil(t, err, "Fail to get service list")
-	assert.Equal(t, 1, len(svcList.Items), "Service list should have one item")
-	oldSvc := svcList.Items[0].DeepCopy()
-
-	// Test 1: When the service for the RayCluster already exists, it should not be updated.
-	cluster.Spec.HeadGroupSpec.Template.Spec.Containers[0].Ports = []corev1.ContainerPort{
-		{
-			Name:          "test-port",
-			ContainerPort: 9999,
-		},
+	rayService := &rayv1.RayService{ObjectMeta: metav1.ObjectMeta{Name: "test-service", Namespace: namespace}}
+
+	// The Serve applications must be RUNNING, and the Dashboard check doesn't emit events.
+	assert.False(t, r.checkServeReadiness(ctx, rayService, cluster, deployingStatus, false))
+	assert.False(t, r.checkServeReadiness(ctx, rayService, cluster, &rayv1.RayServiceStatus{}, false))
+	assert.True(t, r.checkServeReadiness(ctx, rayService, cluster, runningStatus, false))
+	assert.Empty(t, recorder.Events)
+
+	// The RayServices that only set the serveProbe probe the pending RayCluster.
+	rayService.Spec.ServeProbe = &rayv1.ServeProbe{Path: "/app/healthz"}
+	fakeRayHttpProxyClient.ServeProbeErr = fmt.Errorf("status code: 500")
+	assert.False(t, r.checkServeReadiness(ctx, rayService, cluster, runningStatus, false))
+	assert.Contains(t, <-recorder.Events, string(utils.ServeProbeFailed))
+	assert.True(t, r.checkServeReadiness(ctx, rayService, cluster, runningStatus, true))
+	fakeRayHttpProxyClient.ServeProbeErr = nil
+	assert.True(t, r.checkServeReadiness(ctx, rayService, cluster, runningStatus, false))
+
+	// The Metrics check waits for the sum of the metric to reach the minimum.
+	rayService.Spec.ServeReadiness = &rayv1.ServeReadiness{
+		Type:   rayv1.MetricsServeReadinessCheck,
+		Metric: &rayv1.ServeReadinessMetric{Name: "ray_model_loaded", MinValue: ptr.To[int32](2)},
 	}
-	err = r.reconcileServices(ctx, &rayService, &cluster, utils.HeadService)
-	assert.Nil(t, err, "Fail to reconcile service")
-
-	svcList = corev1.ServiceList{}
-	err = fakeClient.List(ctx, &svcList, client.InNamespace(namespace))
-	assert.Nil(t, err, "Fail to get service list")
-	assert.Equal(t, 1, len(svcList.Items), "Service list should have one item")
-	assert.True(t, reflect.DeepEqual(*oldSvc, svcList.Items[0]))
-
-	// Test 2: When the RayCluster switches, the service should be updated.
-	cluster.Name = "new-cluster"
-	err = r.reconcileServices(ctx, &rayService, &cluster, utils.HeadService)
-	assert.Nil(t, err, "Fail to reconcile service")
-
-	svcList = corev1.ServiceList{}
-	err = fakeClient.List(ctx, &svcList, client.InNamespace(namespace))
-	assert.Nil(t, err, "Fail to get service list")
-	assert.Equal(t, 1, len(svcList.Items), "Service list should have one item")
-	assert.False(t, reflect.DeepEqual(*oldSvc, svcList.Items[0]))
+	metricSum = 1
+	assert.False(t, r.checkServeReadiness(ctx, rayService, cluster, runningStatus, false))
+	assert.Contains(t, <-recorder.Events, string(utils.ServeReadinessCheckFailed))
+	assert.True(t, r.checkServeReadiness(ctx, rayService, cluster, runningStatus, true))
+	metricSum = 2
+	assert.True(t, r.checkServeReadiness(ctx, rayService, cluster, runningStatus, false))
+
+	// A registered checker applies to both the active and the pending RayClusters.
+	var checkedActive []bool
+	require.NoError(t, RegisterServeReadinessChecker("ModelLoaded", ServeReadinessCheckerFunc(func(_ context.Context, input ServeReadinessInput) error {
+		checkedActive = append(checkedActive, input.IsActive)
+		if input.RayCluster.Annotations["model-loaded"] != "true" {
+			return fmt.Errorf("the model isn't loaded")
+		}
+		return nil
+	})))
+	t.Cleanup(func() {
+		registeredServeReadinessCheckersMu.Lock()
+		defer registeredServeReadinessCheckersMu.Unlock()
+		delete(registeredServeReadinessCheckers, "ModelLoaded")
+	})
+	require.Error(t, RegisterServeReadinessChecker("ModelLoaded", ServeReadinessCheckerFunc(checkDashboardServeReadiness)))
+	require.Error(t, RegisterServeReadinessChecker(rayv1.DashboardServeReadinessCheck, ServeReadinessCheckerFunc(checkDashboardServeReadiness)))
+
+	rayService.Spec.ServeReadiness = &rayv1.ServeReadiness{Type: "ModelLoaded"}
+	require.NoError(t, validateServeReadiness(rayService))
+	assert.False(t, r.checkServeReadiness(ctx, rayService, cluster, runningStatus, true))
+	assert.Contains(t, <-recorder.Events, "the model isn't loaded")
+	cluster.Annotations = map[string]string{"model-loaded": "true"}
+	assert.True(t, r.checkServeReadiness(ctx, rayService, cluster, runningStatus, false))
+	assert.Equal(t, []bool{true, false}, checkedActive)
+	// The registered checker only runs once the Serve applications are RUNNING.
+	assert.False(t, r.checkServeReadiness(ctx, rayService, cluster, deployingStatus, false))
+	assert.Len(t, checkedActive, 2)
 }
 
 func TestFetchHeadServiceURL(t *testing.T) {
@@ -637,6 +2426,32 @@ func TestFetchHeadServiceURL(t *testing.T) {
 	url, err := utils.FetchHeadServiceURL(ctx, r.Client, &cluster, utils.DashboardPortName)
 	assert.Nil(t, err, "Fail to fetch head service url")
 	assert.Equal(t, fmt.Sprintf("test-cluster-head-svc.%s.svc.cluster.local:%d", namespace, dashboardPort), url, "Head service url is not correct")
+
+	// The name of the head service in the status of the RayCluster is preferred over the generated one, so that
+	// head services created with a different name derivation are still found.
+	legacyHeadSvc := corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "legacy-head-svc", Namespace: namespace},
+		Spec:       headSvc.Spec,
+	}
+	cluster.Status.Head.ServiceName = legacyHeadSvc.Name
+	err = fakeClient.Create(ctx, &legacyHeadSvc)
+	require.NoError(t, err)
+	url, err = utils.FetchHeadServiceURL(ctx, r.Client, &cluster, utils.DashboardPortName)
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("legacy-head-svc.%s.svc.cluster.local:%d", namespace, dashboardPort), url)
+
+	// The head service doesn't expose the dashboard port when the dashboard is behind oauth2-proxy, so the dashboard
+	// is accessed through the head Pod.
+	legacyHeadSvc.Spec.Ports = []corev1.ServicePort{{Name: utils.OAuth2ProxyPortName, Port: utils.DefaultOAuth2ProxyPort}}
+	require.NoError(t, fakeClient.Update(ctx, &legacyHeadSvc))
+	cluster.Spec.DashboardIngress = &rayv1.DashboardIngressOptions{OAuth2Proxy: &rayv1.OAuth2ProxyOptions{Image: "quay.io/oauth2-proxy/oauth2-proxy"}}
+	cluster.Spec.HeadGroupSpec.Template.Spec.Containers = []corev1.Container{{Name: "ray-head"}}
+	_, err = utils.FetchHeadServiceURL(ctx, r.Client, &cluster, utils.DashboardPortName)
+	require.Error(t, err)
+	cluster.Status.Head.PodIP = "10.0.0.1"
+	url, err = utils.FetchHeadServiceURL(ctx, r.Client, &cluster, utils.DashboardPortName)
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("10.0.0.1:%d", utils.DefaultDashboardPort), url)
 }
 
 func TestGetAndCheckServeStatus(t *testing.T) {
@@ -835,14 +2650,14 @@ applications:
 	// `r.ServeConfigs`.
 	serveConfig := r.getServeConfigFromCache(&rayService, cluster.Name)
 	assert.Empty(t, serveConfig)
-	shouldCreate := r.checkIfNeedSubmitServeDeployment(ctx, &rayService, &cluster, &rayv1.RayServiceStatus{})
+	shouldCreate := r.checkIfNeedSubmitServeDeployment(ctx, &rayService, &cluster, &rayv1.RayServiceStatus{}, rayService.Spec.ServeConfigV2)
 	assert.True(t, shouldCreate)
 
 	// Test 2: The RayCluster is not new, but the head Pod without GCS FT-enabled crashes and restarts.
 	// Hence, the RayService's Serve application status is empty, but the KubeRay operator has cached the Serve
 	// application's configuration.
-	r.cacheServeConfig(&rayService, cluster.Name) // Simulate the Serve application's configuration has been cached.
-	shouldCreate = r.checkIfNeedSubmitServeDeployment(ctx, &rayService, &cluster, &rayv1.RayServiceStatus{})
+	r.cacheServeConfig(&rayService, cluster.Name, rayService.Spec.ServeConfigV2) // Simulate the Serve application's configuration has been cached.
+	shouldCreate = r.checkIfNeedSubmitServeDeployment(ctx, &rayService, &cluster, &rayv1.RayServiceStatus{}, rayService.Spec.ServeConfigV2)
 	assert.True(t, shouldCreate)
 
 	// Test 3: The Serve application has been created, and the RayService's status has been updated.
@@ -855,7 +2670,7 @@ applications:
 			},
 		},
 	}
-	shouldCreate = r.checkIfNeedSubmitServeDeployment(ctx, &rayService, &cluster, &serveStatus)
+	shouldCreate = r.checkIfNeedSubmitServeDeployment(ctx, &rayService, &cluster, &serveStatus, rayService.Spec.ServeConfigV2)
 	assert.False(t, shouldCreate)
 
 	// Test 4: The Serve application has been created, but the Serve config has been updated.
@@ -864,10 +2679,52 @@ applications:
 applications:
 - name: new_app_name
   import_path: fruit.deployment_graph`
-	shouldCreate = r.checkIfNeedSubmitServeDeployment(ctx, &rayService, &cluster, &serveStatus)
+	shouldCreate = r.checkIfNeedSubmitServeDeployment(ctx, &rayService, &cluster, &serveStatus, rayService.Spec.ServeConfigV2)
 	assert.True(t, shouldCreate)
 }
 
+func TestResolveServeConfigV2(t *testing.T) {
+	ctx := context.Background()
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "serve-values", Namespace: "ray"},
+		Data:       map[string]string{"NUM_REPLICAS": "1"},
+	}
+	fakeClient := clientFake.NewClientBuilder().WithObjects(configMap).Build()
+	r := RayServiceReconciler{
+		Client:       fakeClient,
+		ServeConfigs: lru.New(utils.ServeConfigLRUSize),
+	}
+	rayService := rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-service", Namespace: "ray"},
+		Spec: rayv1.RayServiceSpec{
+			ServeConfigV2: "applications:\n- name: myapp\n  deployments:\n  - num_replicas: ${NUM_REPLICAS}",
+			ServeConfigV2Variables: []corev1.EnvFromSource{
+				{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "serve-values"}}},
+			},
+		},
+	}
+	cluster := rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "ray"}}
+	serveStatus := rayv1.RayServiceStatus{Applications: map[string]rayv1.AppStatus{"myapp": {Status: rayv1.ApplicationStatusEnum.RUNNING}}}
+
+	serveConfigV2, err := r.resolveServeConfigV2(ctx, &rayService)
+	require.NoError(t, err)
+	assert.Equal(t, "applications:\n- name: myapp\n  deployments:\n  - num_replicas: 1", serveConfigV2)
+	r.cacheServeConfig(&rayService, cluster.Name, serveConfigV2)
+	assert.False(t, r.checkIfNeedSubmitServeDeployment(ctx, &rayService, &cluster, &serveStatus, serveConfigV2))
+
+	// Changing the value of a variable updates the Serve applications.
+	configMap.Data["NUM_REPLICAS"] = "2"
+	require.NoError(t, fakeClient.Update(ctx, configMap))
+	serveConfigV2, err = r.resolveServeConfigV2(ctx, &rayService)
+	require.NoError(t, err)
+	assert.True(t, r.checkIfNeedSubmitServeDeployment(ctx, &rayService, &cluster, &serveStatus, serveConfigV2))
+
+	// Undefined variables are reported.
+	rayService.Spec.ServeConfigV2 += "\n    max_ongoing_requests: ${MAX_ONGOING_REQUESTS}"
+	_, err = r.resolveServeConfigV2(ctx, &rayService)
+	assert.ErrorContains(t, err, "MAX_ONGOING_REQUESTS")
+}
+
 func TestReconcileRayCluster(t *testing.T) {
 	defer os.Unsetenv(ENABLE_ZERO_DOWNTIME)
 	// Create a new scheme with CRDs schemes.
@@ -884,7 +2741,7 @@ func TestReconcileRayCluster(t *testing.T) {
 		Status: rayv1.RayServiceStatuses{},
 	}
 
-	hash, err := generateHashWithoutReplicasAndWorkersToDelete(rayService.Spec.RayClusterSpec)
+	hash, err := generateHashWithoutReplicasAndWorkersToDelete(rayService.Spec.RayClusterSpec, nil)
 	assert.Nil(t, err)
 	activeCluster := rayv1.RayCluster{
 		ObjectMeta: metav1.ObjectMeta{
@@ -1131,7 +2988,7 @@ func TestLabelHeadPodForServeStatus(t *testing.T) {
 				},
 			}
 
-			err := r.updateHeadPodServeLabel(ctx, &cluster, tc.excludeHeadPodFromServeSvc)
+			err := r.updateHeadPodServeLabel(ctx, &cluster, tc.excludeHeadPodFromServeSvc, nil)
 			assert.NoError(t, err)
 			// Get latest headPod status
 			headPod, err = common.GetRayClusterHeadPod(ctx, r, &cluster)
@@ -1140,3 +2997,271 @@ func TestLabelHeadPodForServeStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateWorkerPodsServeLabel(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(newScheme)
+
+	cluster := rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: "default"}}
+	newWorkerPod := func(name string, phase corev1.PodPhase, serveLabel string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: cluster.Namespace,
+				Labels: map[string]string{
+					utils.RayClusterLabelKey:               cluster.Name,
+					utils.RayNodeTypeLabelKey:              string(rayv1.WorkerNode),
+					utils.RayClusterServingServiceLabelKey: serveLabel,
+				},
+			},
+			Spec:   corev1.PodSpec{Containers: []corev1.Container{{Name: "ray-worker"}}},
+			Status: corev1.PodStatus{Phase: phase, PodIP: "10.0.0.1"},
+		}
+	}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(
+		newWorkerPod("healthy", corev1.PodRunning, utils.EnableRayClusterServingServiceTrue),
+		newWorkerPod("wedged", corev1.PodRunning, utils.EnableRayClusterServingServiceTrue),
+		newWorkerPod("recovered", corev1.PodRunning, utils.EnableRayClusterServingServiceFalse),
+		newWorkerPod("pending", corev1.PodPending, utils.EnableRayClusterServingServiceTrue),
+	).Build()
+	ctx := context.Background()
+	r := &RayServiceReconciler{
+		Client:   fakeClient,
+		Recorder: &record.FakeRecorder{},
+		Scheme:   newScheme,
+		httpProxyClientFunc: func() utils.RayHttpProxyClientInterface {
+			return &utils.FakeRayHttpProxyClient{IsHealthy: true, UnhealthyPods: []string{"wedged", "pending"}}
+		},
+	}
+	getServeLabel := func(name string) string {
+		pod := &corev1.Pod{}
+		require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: name}, pod))
+		return pod.Labels[utils.RayClusterServingServiceLabelKey]
+	}
+
+	// The labels aren't changed without the health check of the worker proxies.
+	require.NoError(t, r.updateWorkerPodsServeLabel(ctx, &cluster, false, nil))
+	assert.Equal(t, utils.EnableRayClusterServingServiceTrue, getServeLabel("wedged"))
+
+	require.NoError(t, r.updateWorkerPodsServeLabel(ctx, &cluster, true, nil))
+	assert.Equal(t, utils.EnableRayClusterServingServiceTrue, getServeLabel("healthy"))
+	assert.Equal(t, utils.EnableRayClusterServingServiceFalse, getServeLabel("wedged"))
+	assert.Equal(t, utils.EnableRayClusterServingServiceTrue, getServeLabel("recovered"))
+	// The Pods that aren't running are left to their readiness probe.
+	assert.Equal(t, utils.EnableRayClusterServingServiceTrue, getServeLabel("pending"))
+}
+
+func TestSummarizeServeReplicas(t *testing.T) {
+	now := time.Now()
+	startTimeS := func(d time.Duration) float64 {
+		return float64(now.Add(-d).UnixMilli()) / 1000
+	}
+
+	assert.Nil(t, summarizeServeReplicas(nil, now))
+
+	summary := summarizeServeReplicas([]utils.ServeReplicaDetails{
+		{ReplicaID: "r1", State: "RUNNING", NodeIP: "10.0.0.1", StartTimeS: startTimeS(time.Hour)},
+		{ReplicaID: "r2", State: "RUNNING", NodeIP: "10.0.0.2", StartTimeS: startTimeS(time.Hour)},
+		{ReplicaID: "r3", State: utils.ServeReplicaStartingState, NodeIP: "10.0.0.2", StartTimeS: startTimeS(time.Minute)},
+		{ReplicaID: "r4", State: utils.ServeReplicaStartingState, StartTimeS: startTimeS(ServeReplicaStuckStartingDuration + time.Minute)},
+	}, now)
+	assert.Equal(t, &rayv1.ServeReplicasSummary{
+		States:        map[string]int32{"RUNNING": 2, utils.ServeReplicaStartingState: 2},
+		Nodes:         map[string]int32{"10.0.0.1": 1, "10.0.0.2": 2},
+		StuckStarting: 1,
+	}, summary)
+}
+
+func TestCleanUpRayService(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	_ = networkingv1.AddToScheme(newScheme)
+
+	namespace := "ray"
+	rayService := &rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test-service",
+			Namespace:         namespace,
+			UID:               "rayservice-uid",
+			Finalizers:        []string{utils.RayCleanupFinalizer},
+			DeletionTimestamp: &metav1.Time{Time: time.Now()},
+		},
+	}
+	rayServiceGVK := rayv1.SchemeGroupVersion.WithKind("RayService")
+	ownerReference := *metav1.NewControllerRef(rayService, rayServiceGVK)
+	rayCluster := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "test-service-raycluster-abcde",
+			Namespace:       namespace,
+			Labels:          map[string]string{utils.RayOriginatedFromCRNameLabelKey: rayService.Name, utils.RayOriginatedFromCRDLabelKey: utils.RayOriginatedFromCRDLabelValue(utils.RayServiceCRD)},
+			OwnerReferences: []metav1.OwnerReference{ownerReference},
+			// The finalizer of the RayCluster blocks its deletion, e.g. until Redis is cleaned up.
+			Finalizers: []string{utils.GCSFaultToleranceRedisCleanupFinalizer},
+		},
+	}
+	headSvcName, err := utils.GenerateHeadServiceName(utils.RayServiceCRD, rayService.Spec.RayClusterSpec, rayService.Name)
+	require.NoError(t, err)
+	headService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            headSvcName,
+			Namespace:       namespace,
+			OwnerReferences: []metav1.OwnerReference{ownerReference},
+		},
+	}
+	// Services that aren't controlled by the RayService are kept.
+	serveService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utils.GenerateServeServiceName(rayService.Name),
+			Namespace: namespace,
+		},
+	}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).
+		WithRuntimeObjects(rayService, rayCluster, headService, serveService).
+		WithStatusSubresource(rayService).Build()
+	r := &RayServiceReconciler{
+		Client:   fakeClient,
+		Recorder: &record.FakeRecorder{},
+		Scheme:   newScheme,
+	}
+	ctx := context.Background()
+
+	// The RayCluster is being deleted and the finalizer of the RayService is kept until it is gone.
+	instance := &rayv1.RayService{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(rayService), instance))
+	_, err = r.cleanUpRayService(ctx, instance)
+	require.NoError(t, err)
+	condition := meta.FindStatusCondition(instance.Status.Conditions, string(rayv1.CleanupSucceeded))
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, rayv1.CleanupInProgress, condition.Reason)
+	assert.Contains(t, condition.Message, rayCluster.Name)
+	assert.Contains(t, instance.Finalizers, utils.RayCleanupFinalizer)
+
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(rayCluster), rayCluster))
+	assert.NotNil(t, rayCluster.DeletionTimestamp)
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(headService), &corev1.Service{})
+	assert.True(t, errors.IsNotFound(err))
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(serveService), &corev1.Service{}))
+
+	// The finalizer of the RayService is removed once the RayCluster is gone.
+	rayCluster.Finalizers = nil
+	require.NoError(t, fakeClient.Update(ctx, rayCluster))
+	_, err = r.cleanUpRayService(ctx, instance)
+	require.NoError(t, err)
+	condition = meta.FindStatusCondition(instance.Status.Conditions, string(rayv1.CleanupSucceeded))
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, rayv1.CleanupCompleted, condition.Reason)
+	err = fakeClient.Get(ctx, client.ObjectKeyFromObject(rayService), &rayv1.RayService{})
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestCleanUpRayService_Timeout(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	_ = networkingv1.AddToScheme(newScheme)
+
+	rayService := &rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test-service",
+			Namespace:         "ray",
+			UID:               "rayservice-uid",
+			Annotations:       map[string]string{utils.RayCleanupTimeoutAnnotationKey: "60"},
+			Finalizers:        []string{utils.RayCleanupFinalizer},
+			DeletionTimestamp: &metav1.Time{Time: time.Now().Add(-2 * time.Minute)},
+		},
+	}
+	rayCluster := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "test-service-raycluster-abcde",
+			Namespace:       rayService.Namespace,
+			Labels:          map[string]string{utils.RayOriginatedFromCRNameLabelKey: rayService.Name, utils.RayOriginatedFromCRDLabelKey: utils.RayOriginatedFromCRDLabelValue(utils.RayServiceCRD)},
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(rayService, rayv1.SchemeGroupVersion.WithKind("RayService"))},
+			Finalizers:      []string{utils.GCSFaultToleranceRedisCleanupFinalizer},
+		},
+	}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).
+		WithRuntimeObjects(rayService, rayCluster).
+		WithStatusSubresource(rayService).Build()
+	recorder := record.NewFakeRecorder(10)
+	r := &RayServiceReconciler{
+		Client:   fakeClient,
+		Recorder: recorder,
+		Scheme:   newScheme,
+	}
+	ctx := context.Background()
+
+	// The cleanup is given up because the RayCluster isn't gone after the timeout.
+	instance := &rayv1.RayService{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(rayService), instance))
+	_, err := r.cleanUpRayService(ctx, instance)
+	require.NoError(t, err)
+	condition := meta.FindStatusCondition(instance.Status.Conditions, string(rayv1.CleanupSucceeded))
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, rayv1.CleanupTimedOut, condition.Reason)
+	assert.NotContains(t, instance.Finalizers, utils.RayCleanupFinalizer)
+	assert.Contains(t, <-recorder.Events, string(utils.CleanupTimedOut))
+}
+
+func TestServeApplicationConfigHashes(t *testing.T) {
+	serveConfigV2 := `applications:
+  - name: app1
+    import_path: app1.main:app
+  - import_path: default.main:app
+`
+	hashes, err := serveApplicationConfigHashes(serveConfigV2)
+	require.NoError(t, err)
+	assert.Len(t, hashes, 2)
+	assert.NotEmpty(t, hashes["app1"])
+	assert.NotEmpty(t, hashes[utils.DefaultServeAppName])
+
+	// Only the hash of the changed application changes.
+	newHashes, err := serveApplicationConfigHashes(strings.Replace(serveConfigV2, "app1.main:app", "app1.main:app_v2", 1))
+	require.NoError(t, err)
+	assert.NotEqual(t, hashes["app1"], newHashes["app1"])
+	assert.Equal(t, hashes[utils.DefaultServeAppName], newHashes[utils.DefaultServeAppName])
+}
+
+func TestUpdateServeApplicationUpgradeStatuses(t *testing.T) {
+	rayService := &rayv1.RayService{ObjectMeta: metav1.ObjectMeta{Name: "rayservice", Namespace: "default"}}
+	rayCluster := &rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Name: "raycluster", Namespace: "default"}}
+	recorder := record.NewFakeRecorder(10)
+	r := &RayServiceReconciler{Recorder: recorder}
+
+	prevApplications := map[string]rayv1.AppStatus{
+		"unchanged": {Status: rayv1.ApplicationStatusEnum.RUNNING, ServeConfigHash: "a", UpgradeStatus: rayv1.ServeApplicationUpgraded},
+		"changed":   {Status: rayv1.ApplicationStatusEnum.RUNNING, ServeConfigHash: "b", UpgradeStatus: rayv1.ServeApplicationUpgraded},
+		"failing":   {Status: rayv1.ApplicationStatusEnum.DEPLOYING, ServeConfigHash: "c", UpgradeStatus: rayv1.ServeApplicationUpgrading},
+	}
+	applications := map[string]rayv1.AppStatus{
+		"unchanged": {Status: rayv1.ApplicationStatusEnum.RUNNING},
+		"changed":   {Status: rayv1.ApplicationStatusEnum.DEPLOYING},
+		"failing":   {Status: rayv1.ApplicationStatusEnum.DEPLOY_FAILED, Message: "import error"},
+		"new":       {Status: rayv1.ApplicationStatusEnum.DEPLOYING},
+	}
+	appConfigHashes := map[string]string{"unchanged": "a", "changed": "b2", "failing": "c", "new": "d"}
+	r.updateServeApplicationUpgradeStatuses(rayService, rayCluster, prevApplications, applications, appConfigHashes)
+
+	assert.Equal(t, rayv1.ServeApplicationUpgraded, applications["unchanged"].UpgradeStatus)
+	assert.Equal(t, rayv1.ServeApplicationUpgrading, applications["changed"].UpgradeStatus)
+	assert.Equal(t, "b2", applications["changed"].ServeConfigHash)
+	assert.Equal(t, rayv1.ServeApplicationUpgradeFailed, applications["failing"].UpgradeStatus)
+	assert.Equal(t, rayv1.ServeApplicationUpgrading, applications["new"].UpgradeStatus)
+	assert.Len(t, recorder.Events, 2)
+
+	// Only the applications being upgraded aren't running, so the other applications keep serving.
+	assert.True(t, areServeApplicationsReadyOrUpgrading(applications))
+	applications["unchanged"] = rayv1.AppStatus{Status: rayv1.ApplicationStatusEnum.UNHEALTHY, UpgradeStatus: rayv1.ServeApplicationUpgraded}
+	assert.False(t, areServeApplicationsReadyOrUpgrading(applications))
+	assert.False(t, areServeApplicationsReadyOrUpgrading(map[string]rayv1.AppStatus{
+		"new": {Status: rayv1.ApplicationStatusEnum.DEPLOYING, UpgradeStatus: rayv1.ServeApplicationUpgrading},
+	}))
+
+	// The upgrade completes once the application is running.
+	prevApplications = applications
+	applications = map[string]rayv1.AppStatus{"changed": {Status: rayv1.ApplicationStatusEnum.RUNNING}}
+	r.updateServeApplicationUpgradeStatuses(rayService, rayCluster, prevApplications, applications, appConfigHashes)
+	assert.Equal(t, rayv1.ServeApplicationUpgraded, applications["changed"].UpgradeStatus)
+}
@@ -0,0 +1,209 @@
+package ray
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// ServeReadinessInput is what a ServeReadinessChecker decides the readiness of the Serve applications of a RayCluster
+// from.
+type ServeReadinessInput struct {
+	RayService *rayv1.RayService
+	RayCluster *rayv1.RayCluster
+	// ServeStatus holds the statuses of the Serve applications of the RayCluster read from the Ray dashboard.
+	ServeStatus *rayv1.RayServiceStatus
+	// IsActive is whether the RayCluster serves the traffic, rather than being the pending RayCluster of an upgrade.
+	IsActive bool
+	// Client reads the Kubernetes objects, for example the Pods of the RayCluster.
+	Client client.Reader
+}
+
+// ServeReadinessChecker decides whether the Serve applications of a RayCluster are ready to serve the traffic. The
+// checker selected by the `serveReadiness.type` of a RayService only runs once the Serve applications are RUNNING.
+type ServeReadinessChecker interface {
+	// CheckServeReadiness returns nil if the Serve applications are ready, and the reason why they aren't otherwise.
+	CheckServeReadiness(ctx context.Context, input ServeReadinessInput) error
+}
+
+// ServeReadinessCheckerFunc adapts a function to the ServeReadinessChecker interface.
+type ServeReadinessCheckerFunc func(ctx context.Context, input ServeReadinessInput) error
+
+func (f ServeReadinessCheckerFunc) CheckServeReadiness(ctx context.Context, input ServeReadinessInput) error {
+	return f(ctx, input)
+}
+
+var (
+	registeredServeReadinessCheckersMu sync.RWMutex
+	registeredServeReadinessCheckers   = map[rayv1.ServeReadinessCheckType]ServeReadinessChecker{}
+)
+
+// RegisterServeReadinessChecker registers a custom checker, selected by the RayServices whose `serveReadiness.type` is
+// checkType, for example to wait for the models to be loaded into the GPUs. It is meant to be called by the operators
+// built on top of KubeRay before the RayService controller starts. The built-in checks can't be replaced.
+func RegisterServeReadinessChecker(checkType rayv1.ServeReadinessCheckType, checker ServeReadinessChecker) error {
+	switch checkType {
+	case "", rayv1.DashboardServeReadinessCheck, rayv1.ProbeServeReadinessCheck, rayv1.MetricsServeReadinessCheck:
+		return fmt.Errorf("the serve readiness check %q is reserved", checkType)
+	}
+	registeredServeReadinessCheckersMu.Lock()
+	defer registeredServeReadinessCheckersMu.Unlock()
+	if _, ok := registeredServeReadinessCheckers[checkType]; ok {
+		return fmt.Errorf("the serve readiness check %s is already registered", checkType)
+	}
+	registeredServeReadinessCheckers[checkType] = checker
+	return nil
+}
+
+func getRegisteredServeReadinessChecker(checkType rayv1.ServeReadinessCheckType) (ServeReadinessChecker, bool) {
+	registeredServeReadinessCheckersMu.RLock()
+	defer registeredServeReadinessCheckersMu.RUnlock()
+	checker, ok := registeredServeReadinessCheckers[checkType]
+	return checker, ok
+}
+
+// getServeReadinessCheckType returns the serve readiness check of the RayService. The RayServices that only set the
+// serveProbe keep probing the pending RayCluster.
+func getServeReadinessCheckType(rayService *rayv1.RayService) rayv1.ServeReadinessCheckType {
+	if readiness := rayService.Spec.ServeReadiness; readiness != nil && readiness.Type != "" {
+		return readiness.Type
+	}
+	if rayService.Spec.ServeProbe != nil {
+		return rayv1.ProbeServeReadinessCheck
+	}
+	return rayv1.DashboardServeReadinessCheck
+}
+
+func validateServeReadiness(rayService *rayv1.RayService) error {
+	switch checkType := getServeReadinessCheckType(rayService); checkType {
+	case rayv1.DashboardServeReadinessCheck:
+	case rayv1.ProbeServeReadinessCheck:
+		if rayService.Spec.ServeProbe == nil {
+			return fmt.Errorf("spec.serveProbe is required by the %s serve readiness check", checkType)
+		}
+	case rayv1.MetricsServeReadinessCheck:
+		if metric := rayService.Spec.ServeReadiness.Metric; metric == nil || metric.Name == "" {
+			return fmt.Errorf("spec.serveReadiness.metric.name is required by the %s serve readiness check", checkType)
+		}
+	default:
+		if _, ok := getRegisteredServeReadinessChecker(checkType); !ok {
+			return fmt.Errorf("spec.serveReadiness.type %s is neither a built-in serve readiness check nor a registered one", checkType)
+		}
+	}
+	return nil
+}
+
+func (r *RayServiceReconciler) getServeReadinessChecker(checkType rayv1.ServeReadinessCheckType) (ServeReadinessChecker, bool) {
+	switch checkType {
+	case rayv1.DashboardServeReadinessCheck:
+		return ServeReadinessCheckerFunc(checkDashboardServeReadiness), true
+	case rayv1.ProbeServeReadinessCheck:
+		return ServeReadinessCheckerFunc(r.checkProbeServeReadiness), true
+	case rayv1.MetricsServeReadinessCheck:
+		return ServeReadinessCheckerFunc(r.checkMetricsServeReadiness), true
+	}
+	return getRegisteredServeReadinessChecker(checkType)
+}
+
+// checkServeReadiness returns whether the Serve applications of the RayCluster are ready. The Dashboard check runs
+// first, and then the check selected by the RayService. Unlike the Serve applications that aren't RUNNING yet, the
+// failures of the selected check are reported with events.
+func (r *RayServiceReconciler) checkServeReadiness(ctx context.Context, rayServiceInstance *rayv1.RayService, rayClusterInstance *rayv1.RayCluster, rayServiceStatus *rayv1.RayServiceStatus, isActive bool) bool {
+	logger := ctrl.LoggerFrom(ctx)
+	input := ServeReadinessInput{
+		RayService:  rayServiceInstance,
+		RayCluster:  rayClusterInstance,
+		ServeStatus: rayServiceStatus,
+		IsActive:    isActive,
+		Client:      r.Client,
+	}
+	if err := checkDashboardServeReadiness(ctx, input); err != nil {
+		logger.Info("The Serve applications aren't ready.", "rayCluster", rayClusterInstance.Name, "reason", err.Error())
+		return false
+	}
+
+	checkType := getServeReadinessCheckType(rayServiceInstance)
+	if checkType == rayv1.DashboardServeReadinessCheck {
+		return true
+	}
+	var err error
+	if checker, ok := r.getServeReadinessChecker(checkType); ok {
+		err = checker.CheckServeReadiness(ctx, input)
+	} else {
+		err = fmt.Errorf("the serve readiness check %s isn't registered", checkType)
+	}
+	if err != nil {
+		logger.Info("The serve readiness check failed.", "rayCluster", rayClusterInstance.Name, "type", checkType, "error", err.Error())
+		eventType := utils.ServeReadinessCheckFailed
+		if checkType == rayv1.ProbeServeReadinessCheck {
+			eventType = utils.ServeProbeFailed
+		}
+		r.Recorder.Eventf(rayServiceInstance, corev1.EventTypeWarning, string(eventType),
+			"The %s serve readiness check of the RayCluster %s/%s failed: %v", checkType, rayClusterInstance.Namespace, rayClusterInstance.Name, err)
+		return false
+	}
+	return true
+}
+
+// checkDashboardServeReadiness requires the Serve applications to be RUNNING. Under the application-scoped upgrades,
+// the applications being upgraded in place don't make the active RayCluster unready, since the other applications keep
+// serving.
+func checkDashboardServeReadiness(_ context.Context, input ServeReadinessInput) error {
+	applications := input.ServeStatus.Applications
+	if len(applications) == 0 {
+		return fmt.Errorf("no Serve application found")
+	}
+	if input.IsActive && utils.IsApplicationScopedUpgradeEnabled(input.RayService) {
+		if !areServeApplicationsReadyOrUpgrading(applications) {
+			return fmt.Errorf("the Serve applications that aren't being upgraded aren't all RUNNING")
+		}
+		return nil
+	}
+	appNames := make([]string, 0, len(applications))
+	for appName := range applications {
+		appNames = append(appNames, appName)
+	}
+	sort.Strings(appNames)
+	for _, appName := range appNames {
+		if status := applications[appName].Status; status != rayv1.ApplicationStatusEnum.RUNNING {
+			return fmt.Errorf("the Serve application %s is %s", appName, status)
+		}
+	}
+	return nil
+}
+
+// checkProbeServeReadiness sends the serveProbe to the pending RayCluster. The active RayCluster isn't probed, so that a
+// flaky probe doesn't make the RayService unready.
+func (r *RayServiceReconciler) checkProbeServeReadiness(ctx context.Context, input ServeReadinessInput) error {
+	if input.IsActive {
+		return nil
+	}
+	return r.probeServeEndpoint(ctx, input.RayService, input.RayCluster)
+}
+
+// checkMetricsServeReadiness requires the sum of the metric over the Ray Pods of the pending RayCluster to reach the
+// minimum. Like the serve probe, it doesn't apply to the active RayCluster, whose replicas come and go with autoscaling.
+func (r *RayServiceReconciler) checkMetricsServeReadiness(ctx context.Context, input ServeReadinessInput) error {
+	if input.IsActive {
+		return nil
+	}
+	metric := input.RayService.Spec.ServeReadiness.Metric
+	minValue := ptr.Deref(metric.MinValue, utils.DefaultServeReadinessMetricMinValue)
+	sum, err := r.serveMetricSumFunc(ctx, input.RayCluster, metric.Name)
+	if err != nil {
+		return err
+	}
+	if sum < float64(minValue) {
+		return fmt.Errorf("the metric %s is %v, which is below the minimum %d", metric.Name, sum, minValue)
+	}
+	return nil
+}
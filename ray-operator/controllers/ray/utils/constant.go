@@ -1,6 +1,9 @@
 package utils
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 const (
 
@@ -45,9 +48,140 @@ const (
 	// `KUBERAY_GEN_RAY_START_CMD`.
 	RayOverwriteContainerCmdAnnotationKey = "ray.io/overwrite-container-cmd"
 
+	// The standby head Pod of a RayCluster with `enableColdStandby` has this value for the `ray.io/node-type` label,
+	// so it is neither selected by the head service nor counted as a head Pod. KubeRay promotes it by changing the label
+	// to `head` and setting the RayHeadStandbyPromotedAnnotationKey annotation to "true".
+	RayNodeHeadStandbyLabelValue        = "head-standby"
+	RayHeadStandbyPromotedAnnotationKey = "ray.io/head-standby-promoted"
+
+	// With the Manual promotion of a RayService, the ready pending RayCluster is only promoted after the RayService is
+	// annotated with `ray.io/promote: "true"`, and the upgrade is aborted if it is annotated with `ray.io/abort: "true"`.
+	// KubeRay removes the promote annotation after the promotion so that the next upgrade needs a new approval.
+	RayServicePromoteAnnotationKey = "ray.io/promote"
+	RayServiceAbortAnnotationKey   = "ray.io/abort"
+
+	// With the Manual KubeRayVersionPolicy of a RayService, the active RayCluster is only updated to the version of
+	// KubeRay after the RayService is annotated with `ray.io/update-kuberay-version: "true"`. KubeRay removes the
+	// annotation after the update so that the next upgrade of KubeRay needs a new approval.
+	RayServiceKubeRayVersionUpdateAnnotationKey = "ray.io/update-kuberay-version"
+
+	// The head and serve Services of a RayService are annotated with the comma-separated names of the ports generated
+	// by KubeRay, so that the ports added by users or other controllers are kept when KubeRay updates the Services.
+	RayServiceGeneratedPortsAnnotationKey = "ray.io/generated-ports"
+
+	// The RayClusters of a RayService are annotated with the hash of the Serve config most recently applied to them, so
+	// that external tools can tell whether a RayCluster runs the latest Serve config without querying its dashboard.
+	RayServiceServeConfigHashAnnotationKey = "ray.io/serve-config-hash"
+
+	// The RayClusters of a RayService that are neither active nor pending are annotated with the time they're deleted
+	// at, in RFC 3339 format, so that their deletion delay isn't restarted when the operator restarts.
+	RayServiceClusterDeletionTimestampAnnotationKey = "ray.io/scheduled-deletion-timestamp"
+
+	// A RayService annotated with `ray.io/debug-cluster: "true"` gets a debug RayCluster, which is a copy of its
+	// active RayCluster with a single worker and the same Serve config that doesn't serve any traffic. The debug
+	// RayCluster is annotated with RayServiceDebugClusterOfAnnotationKey, and deleted once the annotation is removed.
+	RayServiceDebugClusterAnnotationKey   = "ray.io/debug-cluster"
+	RayServiceDebugClusterOfAnnotationKey = "ray.io/debug-cluster-of"
+
+	// The logs of the reconciliation of a RayCluster, RayJob, or RayService annotated with `ray.io/log-verbosity`
+	// use the verbosity of the annotation, e.g. "2", instead of the one of the `--log-verbosity` flag.
+	RayLogVerbosityAnnotationKey = "ray.io/log-verbosity"
+
+	// Balloon Pods are placeholder Pods for the pending resource demands of the Ray autoscaler. They are labeled with
+	// the RayCluster name using this key instead of RayClusterLabelKey, so they are not selected as Ray Pods.
+	RayBalloonPodClusterLabelKey = "ray.io/balloon-pod-cluster"
+	DefaultBalloonPodImage       = "registry.k8s.io/pause:3.9"
+	DefaultBalloonPodMaxPods     = 10
+
+	// RayImagePrePullClusterLabelKey is the label of the image pre-pull DaemonSets of a RayCluster. The Pods of the
+	// DaemonSets don't have the RayClusterLabelKey label, so that KubeRay never treats them as Ray Pods.
+	RayImagePrePullClusterLabelKey    = "ray.io/image-pre-pull-cluster"
+	DefaultImagePrePullTimeoutSeconds = 600
+	DefaultImagePrePullPauseImage     = "registry.k8s.io/pause:3.9"
+
+	// RayWorkerRankAnnotationKey is the rank of a worker Pod of a worker group with `enableRankEnv`. The rank of a
+	// deleted worker Pod is reused by the next worker Pod created for the group.
+	RayWorkerRankAnnotationKey = "ray.io/worker-rank"
+
+	// RayDrainDeadlineAnnotationKey is set on a worker Pod of a worker group with `gracefulDrainSeconds` when KubeRay
+	// asks Ray to drain the node of the Pod. The Pod is deleted once the node is drained or the deadline, in RFC 3339
+	// format, has passed.
+	RayDrainDeadlineAnnotationKey = "ray.io/drain-deadline"
+
+	// RayBurstableLabelKey is set to "true" on the worker Pods of a worker group with `burstable`.
+	RayBurstableLabelKey = "ray.io/burstable"
+
+	// DefaultVirtualNodeLabelKey and DefaultVirtualNodeLabelValue are the label of the virtual nodes registered by
+	// virtual-kubelet, and VirtualKubeletTaintKey is the key of their taint.
+	DefaultVirtualNodeLabelKey   = "type"
+	DefaultVirtualNodeLabelValue = "virtual-kubelet"
+	VirtualKubeletTaintKey       = "virtual-kubelet.io/provider"
+
+	// RayWorkerGroupTemplateHashAnnotationKey is the hash of the template and the rayStartParams of the worker group
+	// that a worker Pod was created from. It's used to find the outdated Pods of a worker group with an update strategy.
+	RayWorkerGroupTemplateHashAnnotationKey = "ray.io/worker-group-template-hash"
+
+	// MaxReconcileErrorMessageLength is the maximum length of the error message recorded in the LastReconcileError
+	// of the status of a custom resource.
+	MaxReconcileErrorMessageLength = 1024
+	// ReconcileErrorRecordInterval is the minimum interval between two updates of the LastReconcileError of a custom
+	// resource for the same error, so that the status updates don't trigger reconciliations in a hot loop.
+	ReconcileErrorRecordInterval = 1 * time.Minute
+
+	// A Ray Pod of a RayCluster with `profiling` is profiled when it's annotated with RayProfileAnnotationKey. KubeRay
+	// removes the request annotations when the profiling container is attached, and sets the profiling container and
+	// output annotations until the profiling container terminates.
+	RayProfileAnnotationKey          = "ray.io/profile"
+	RayProfilePIDAnnotationKey       = "ray.io/profile-pid"
+	RayProfileDurationAnnotationKey  = "ray.io/profile-duration-seconds"
+	RayProfileContainerAnnotationKey = "ray.io/profile-container"
+	RayProfileOutputAnnotationKey    = "ray.io/profile-output"
+	DefaultProfileOutputDir          = "/tmp/ray/profiles"
+	DefaultProfilePID                = 1
+	DefaultProfileDurationSeconds    = 30
+	ProfileContainerNamePrefix       = "profile-"
+
+	// A RayCluster annotated with RayCloneFromAnnotationKey, whose value is the `[<namespace>/]<name>` of another
+	// RayCluster, gets a copy of the spec and the labels of that RayCluster. KubeRay then replaces the annotation with
+	// RayClonedFromAnnotationKey. A RayCluster can only be cloned into another namespace if its
+	// RayCloneToNamespacesAnnotationKey annotation lists the namespace, or is "*".
+	RayCloneFromAnnotationKey         = "ray.io/clone-from"
+	RayClonedFromAnnotationKey        = "ray.io/cloned-from"
+	RayCloneToNamespacesAnnotationKey = "ray.io/clone-to-namespaces"
+
+	// The custom resources in a Namespace with the RayFastDeletionAnnotationKey annotation set to "true" are deleted
+	// without the graceful paths of KubeRay, e.g. in CI and development namespaces.
+	RayFastDeletionAnnotationKey = "ray.io/fast-deletion"
+
+	// The RayClusters with host networking are allocated a block of HostNetworkPortBlockSize ports from
+	// HostNetworkPortRangeStart, which is recorded in the HostNetworkPortsConfigMapName ConfigMap of the namespace of
+	// the operator and in the RayHostNetworkPortBlockAnnotationKey annotation of the RayCluster. The range stops below
+	// the default ephemeral ports of Linux.
+	RayHostNetworkPortBlockAnnotationKey = "ray.io/host-network-port-block"
+	HostNetworkPortsConfigMapName        = "kuberay-host-network-ports"
+	HostNetworkPortRangeStart            = 20000
+	HostNetworkPortBlockSize             = 200
+	HostNetworkPortBlocks                = 60
+
+	// The serve service of a RayService with `maintenanceMode` selects the Pods of its maintenance responder, which
+	// have the RayServiceMaintenanceLabelKey label set to the name of the RayService and answer every request with a
+	// 503 from an nginx server.
+	RayServiceMaintenanceLabelKey    = "ray.io/maintenance-responder"
+	DefaultMaintenanceResponderImage = "nginxinc/nginx-unprivileged:1.27-alpine"
+
+	// KarpenterDoNotDisruptAnnotationKey set to "true" on a Pod prevents Karpenter from voluntarily disrupting its node,
+	// e.g. to consolidate it.
+	KarpenterDoNotDisruptAnnotationKey = "karpenter.sh/do-not-disrupt"
+
 	// Finalizers for GCS fault tolerance
 	GCSFaultToleranceRedisCleanupFinalizer = "ray.io/gcs-ft-redis-cleanup-finalizer"
 
+	// The worker Pods of a worker group with a namespace other than the one of the RayCluster have the
+	// RayClusterNamespaceLabelKey label instead of an owner reference to the RayCluster. They are deleted before the
+	// RemoteWorkerPodsCleanupFinalizer finalizer of the RayCluster is removed.
+	RayClusterNamespaceLabelKey      = "ray.io/cluster-namespace"
+	RemoteWorkerPodsCleanupFinalizer = "ray.io/remote-worker-pods-cleanup-finalizer"
+
 	// EnableServeServiceKey is exclusively utilized to indicate if a RayCluster is directly used for serving.
 	// See https://github.com/ray-project/kuberay/pull/1672 for more details.
 	EnableServeServiceKey  = "ray.io/enable-serve-service"
@@ -69,12 +203,23 @@ const (
 	DefaultMetricsPort              = 8080
 	DefaultDashboardAgentListenPort = 52365
 	DefaultServingPort              = 8000
+	DefaultOAuth2ProxyPort          = 4180
 
 	ClientPortName    = "client"
 	GcsServerPortName = "gcs-server"
 	DashboardPortName = "dashboard"
 	MetricsPortName   = "metrics"
 	ServingPortName   = "serve"
+	// OAuth2ProxyPortName is the name of the port of the oauth2-proxy sidecar that authenticates the dashboard access.
+	OAuth2ProxyPortName = "oauth2-proxy"
+	// OAuth2ProxyContainerName is the name of the oauth2-proxy sidecar of the head Pod.
+	OAuth2ProxyContainerName = "oauth2-proxy"
+	// MetricsExporterContainerName is the name of the sidecar that remote-writes the Ray metrics of the Pod.
+	MetricsExporterContainerName = "ray-metrics-exporter"
+	// DefaultMetricsExporterImage is the image of the metrics exporter sidecar if MetricsOptions.Image isn't set.
+	DefaultMetricsExporterImage = "prom/prometheus:v2.53.0"
+	// DefaultMetricsScrapeIntervalSeconds is how often the metrics exporter sidecar scrapes the Ray metrics by default.
+	DefaultMetricsScrapeIntervalSeconds = 15
 
 	// The default AppProtocol for Kubernetes service
 	DefaultServiceAppProtocol = "tcp"
@@ -85,6 +230,34 @@ const (
 	// The default name for kuberay operator
 	ComponentName = "kuberay-operator"
 
+	// The defaults of the Ray Serve session affinity
+	DefaultServeSessionAffinityTimeoutSeconds = 10800
+	DefaultServeSessionCookieName             = "ray-serve-session"
+
+	// The default latency threshold of the serve probe of a pending RayCluster
+	DefaultServeProbeLatencyThresholdMilliseconds = 1000
+
+	// The default minimum of the metric of the Metrics serve readiness check
+	DefaultServeReadinessMetricMinValue = 1
+
+	// The default timeout of each attempt of the health check of a Ray Serve proxy
+	DefaultServeProxyHealthCheckTimeoutSeconds = 2
+
+	// The defaults of the request shadowing of the pending RayCluster of a RayService
+	DefaultRequestShadowingPercent                     = 10
+	DefaultRequestShadowingDurationSeconds             = 300
+	DefaultRequestShadowingMaxErrorRateIncreasePercent = 1
+
+	// The default duration the Ready condition of a RayService with a failover policy stays false before it fails over
+	DefaultFailoverUnhealthyThresholdSeconds = 60
+
+	// ExternalDNSHostnameAnnotationKey is the annotation of the Services that ExternalDNS creates the DNS records for.
+	ExternalDNSHostnameAnnotationKey = "external-dns.alpha.kubernetes.io/hostname"
+
+	// KubernetesEndpointSliceControllerName is the `endpointslice.kubernetes.io/managed-by` label of the EndpointSlices
+	// managed by the EndpointSlice controller of Kubernetes.
+	KubernetesEndpointSliceControllerName = "endpointslice-controller.k8s.io"
+
 	// The default suffix for Headless Service for multi-host worker groups.
 	// The full name will be of the form "${RayCluster_Name}-headless-worker-svc".
 	HeadlessServiceSuffix = "headless-worker-svc"
@@ -105,15 +278,51 @@ const (
 	RAY_SERVE_KV_TIMEOUT_S                  = "RAY_SERVE_KV_TIMEOUT_S"
 	RAY_USAGE_STATS_KUBERAY_IN_USE          = "RAY_USAGE_STATS_KUBERAY_IN_USE"
 	RAY_USAGE_STATS_EXTRA_TAGS              = "RAY_USAGE_STATS_EXTRA_TAGS"
+	RAY_TMPDIR                              = "RAY_TMPDIR"
 	RAYCLUSTER_DEFAULT_REQUEUE_SECONDS_ENV  = "RAYCLUSTER_DEFAULT_REQUEUE_SECONDS_ENV"
 	RAYCLUSTER_DEFAULT_REQUEUE_SECONDS      = 300
 	KUBERAY_GEN_RAY_START_CMD               = "KUBERAY_GEN_RAY_START_CMD"
 
+	// The reconciliations of a RayCluster are requeued after the shortest of RAYCLUSTER_DEFAULT_REQUEUE_SECONDS and the
+	// following durations of the features that it uses.
+
+	// RayClusterPendingResourceDemandsRequeueDuration is how often the pending resource demands of the Ray autoscaler
+	// are refreshed when the RayClusterPendingResourceDemands feature gate is enabled.
+	RayClusterPendingResourceDemandsRequeueDuration = 10 * time.Second
+	// RayClusterIdleTimeoutRequeueDuration is how often the activity of a RayCluster is polled when IdleTimeoutSeconds
+	// is set.
+	RayClusterIdleTimeoutRequeueDuration = 1 * time.Minute
+	// RayClusterKarpenterConsolidationRequeueDuration is how often the occupancy of the Ray nodes is polled when the
+	// RayKarpenterConsolidation feature gate is enabled and the RayCluster has worker Pods whose do-not-disrupt
+	// annotation is managed by KubeRay.
+	RayClusterKarpenterConsolidationRequeueDuration = 30 * time.Second
+	// RayClusterQuotaRequeueDuration is how often a RayCluster queued by a RayQuota checks whether the quota has enough
+	// capacity.
+	RayClusterQuotaRequeueDuration = 10 * time.Second
+	// RayClusterImagePrePullRequeueDuration is how often the image pre-pull DaemonSets of a RayCluster are checked.
+	RayClusterImagePrePullRequeueDuration = 5 * time.Second
+	// RayClusterGracefulDrainRequeueDuration is how often the draining worker Pods of a RayCluster are checked.
+	RayClusterGracefulDrainRequeueDuration = 5 * time.Second
+
+	// Environment variables of the head Pod for the metrics views of the Ray dashboard.
+	RAY_GRAFANA_HOST        = "RAY_GRAFANA_HOST"
+	RAY_GRAFANA_IFRAME_HOST = "RAY_GRAFANA_IFRAME_HOST"
+	RAY_PROMETHEUS_HOST     = "RAY_PROMETHEUS_HOST"
+	RAY_PROMETHEUS_NAME     = "RAY_PROMETHEUS_NAME"
+
 	// Environment variables for RayJob submitter Kubernetes Job.
 	// Example: ray job submit --address=http://$RAY_DASHBOARD_ADDRESS --submission-id=$RAY_JOB_SUBMISSION_ID ...
 	RAY_DASHBOARD_ADDRESS = "RAY_DASHBOARD_ADDRESS"
 	RAY_JOB_SUBMISSION_ID = "RAY_JOB_SUBMISSION_ID"
 
+	// Environment variables of the entrypoint of a RayJob with checkpointing enabled.
+	RAY_JOB_CHECKPOINT_URI             = "RAY_JOB_CHECKPOINT_URI"
+	RAY_JOB_RESUME_FROM_CHECKPOINT_URI = "RAY_JOB_RESUME_FROM_CHECKPOINT_URI"
+
+	// Environment variables for the worker Pods of a worker group with `enableRankEnv`.
+	RANK       = "RANK"
+	WORLD_SIZE = "WORLD_SIZE"
+
 	// Environment variables for Ray Autoscaler V2.
 	// The value of RAY_CLOUD_INSTANCE_ID is the Pod name for Autoscaler V2 alpha. This may change in the future.
 	RAY_CLOUD_INSTANCE_ID = "RAY_CLOUD_INSTANCE_ID"
@@ -165,6 +374,14 @@ const (
 	DefaultLivenessProbeSuccessThreshold   = 1
 	DefaultLivenessProbeFailureThreshold   = 120
 
+	// Ray default startup probe values of the head Pod. The timeout is longer because `ray health-check` starts a Python
+	// process, and the GCS server has as long to start as the liveness probe allows.
+	DefaultHeadStartupProbeInitialDelaySeconds = 0
+	DefaultHeadStartupProbeTimeoutSeconds      = 10
+	DefaultHeadStartupProbePeriodSeconds       = 5
+	DefaultHeadStartupProbeSuccessThreshold    = 1
+	DefaultHeadStartupProbeFailureThreshold    = 120
+
 	// Ray health check related configurations
 	// Note: Since the Raylet process and the dashboard agent process are fate-sharing,
 	// only one of them needs to be checked. So, RayAgentRayletHealthPath accesses the dashboard agent's API endpoint
@@ -174,10 +391,19 @@ const (
 	RayDashboardGCSHealthPath = "api/gcs_healthz"
 	RayServeProxyHealthPath   = "-/healthz"
 	BaseWgetHealthCommand     = "wget -T %d -q -O- http://localhost:%d/%s | grep success"
+	BaseRayHealthCheckCommand = "ray health-check --address localhost:%d"
 
 	// Finalizers for RayJob
 	RayJobStopJobFinalizer = "ray.io/rayjob-finalizer"
 
+	// RayCleanupFinalizer is added to RayServices and RayJobs with the RayCleanupFinalizer feature gate. The objects
+	// created for the custom resource are deleted, and the RayClusters waited for so that their own cleanup, e.g. of
+	// the Redis storage namespace, completes, before the finalizer is removed. The cleanup is given up after the
+	// number of seconds of the RayCleanupTimeoutAnnotationKey annotation since the deletion of the custom resource.
+	RayCleanupFinalizer            = "ray.io/cleanup-finalizer"
+	RayCleanupTimeoutAnnotationKey = "ray.io/cleanup-timeout-seconds"
+	DefaultCleanupTimeoutSeconds   = 600
+
 	// RayNodeHeadGroupLabelValue is the value for the RayNodeGroupLabelKey label on a head node
 	RayNodeHeadGroupLabelValue = "headgroup"
 
@@ -191,6 +417,23 @@ const (
 	KubeRayController = "ray.io/kuberay-operator"
 
 	ServeConfigLRUSize = 1000
+
+	// NodeZoneLRUSize is the number of nodes whose zone is cached for the serve EndpointSlices of the RayServices whose
+	// topology routing is Managed.
+	NodeZoneLRUSize = 1000
+
+	// MaxServeConfigV2Size is the maximum size in bytes of the serveConfigV2 of a RayService, so that the RayService
+	// and the annotations of its RayClusters stay below the 1.5 MiB request limit of etcd.
+	MaxServeConfigV2Size = 1024 * 1024
+	// ServeConfigCompressionThreshold is the size in bytes above which the Serve configs are gzipped in the cache of
+	// the RayService controller, and streamed to the Ray dashboard with the chunked transfer encoding.
+	ServeConfigCompressionThreshold = 64 * 1024
+
+	// DefaultServeConfigSourcePollIntervalSeconds is how often the serveConfigSource of a RayService is polled by default.
+	DefaultServeConfigSourcePollIntervalSeconds = 300
+	// ServeConfigSourceConfigMapKey is the key of the Serve config fetched from the serveConfigSource of a RayService in
+	// its ConfigMap.
+	ServeConfigSourceConfigMapKey = "serveConfigV2"
 )
 
 type ServiceType string
@@ -238,23 +481,90 @@ const (
 	// RayCluster event list
 	InvalidRayClusterStatus K8sEventType = "InvalidRayClusterStatus"
 	InvalidRayClusterSpec   K8sEventType = "InvalidRayClusterSpec"
+	// Head service event list
+	InvalidExistingHeadService K8sEventType = "InvalidExistingHeadService"
 	// Head Pod event list
 	CreatedHeadPod        K8sEventType = "CreatedHeadPod"
 	FailedToCreateHeadPod K8sEventType = "FailedToCreateHeadPod"
 	DeletedHeadPod        K8sEventType = "DeletedHeadPod"
 	FailedToDeleteHeadPod K8sEventType = "FailedToDeleteHeadPod"
 
+	// Head StatefulSet event list
+	CreatedHeadStatefulSet        K8sEventType = "CreatedHeadStatefulSet"
+	FailedToCreateHeadStatefulSet K8sEventType = "FailedToCreateHeadStatefulSet"
+
+	// Head standby Pod event list
+	CreatedHeadStandbyPod         K8sEventType = "CreatedHeadStandbyPod"
+	FailedToCreateHeadStandbyPod  K8sEventType = "FailedToCreateHeadStandbyPod"
+	PromotedHeadStandbyPod        K8sEventType = "PromotedHeadStandbyPod"
+	FailedToPromoteHeadStandbyPod K8sEventType = "FailedToPromoteHeadStandbyPod"
+	DeletedHeadStandbyPod         K8sEventType = "DeletedHeadStandbyPod"
+	FailedToDeleteHeadStandbyPod  K8sEventType = "FailedToDeleteHeadStandbyPod"
+
 	// Worker Pod event list
 	CreatedWorkerPod                  K8sEventType = "CreatedWorkerPod"
 	FailedToCreateWorkerPod           K8sEventType = "FailedToCreateWorkerPod"
 	DeletedWorkerPod                  K8sEventType = "DeletedWorkerPod"
 	FailedToDeleteWorkerPod           K8sEventType = "FailedToDeleteWorkerPod"
 	FailedToDeleteWorkerPodCollection K8sEventType = "FailedToDeleteWorkerPodCollection"
+	DrainingWorkerPod                 K8sEventType = "DrainingWorkerPod"
+	FailedToDrainWorkerPod            K8sEventType = "FailedToDrainWorkerPod"
+	FailedToEvictWorkerPod            K8sEventType = "FailedToEvictWorkerPod"
+	IgnoredWorkerToDelete             K8sEventType = "IgnoredWorkerToDelete"
+	WorkerPodsUnschedulable           K8sEventType = "WorkerPodsUnschedulable"
+
+	// Autoscaler event list
+	AutoscalerScaled        K8sEventType = "AutoscalerScaled"
+	AutoscalerFailedToScale K8sEventType = "AutoscalerFailedToScale"
+
+	// Host network event list
+	AllocatedHostNetworkPorts        K8sEventType = "AllocatedHostNetworkPorts"
+	FailedToAllocateHostNetworkPorts K8sEventType = "FailedToAllocateHostNetworkPorts"
+
+	// Memory failure event list
+	RayContainerOOMKilled      K8sEventType = "RayContainerOOMKilled"
+	RayPodEvicted              K8sEventType = "RayPodEvicted"
+	IncreasedWorkerGroupMemory K8sEventType = "IncreasedWorkerGroupMemory"
+
+	// Balloon Pod event list
+	CreatedBalloonPod        K8sEventType = "CreatedBalloonPod"
+	FailedToCreateBalloonPod K8sEventType = "FailedToCreateBalloonPod"
+	DeletedBalloonPod        K8sEventType = "DeletedBalloonPod"
+	FailedToDeleteBalloonPod K8sEventType = "FailedToDeleteBalloonPod"
+
+	// Idle RayCluster event list
+	SuspendedIdleRayCluster K8sEventType = "SuspendedIdleRayCluster"
+	DeletedIdleRayCluster   K8sEventType = "DeletedIdleRayCluster"
+
+	// RayQuota event list
+	ExceededRayQuota   K8sEventType = "ExceededRayQuota"
+	AdmittedByRayQuota K8sEventType = "AdmittedByRayQuota"
+
+	// Image pre-pull DaemonSet event list
+	CreatedImagePrePullDaemonSet        K8sEventType = "CreatedImagePrePullDaemonSet"
+	FailedToCreateImagePrePullDaemonSet K8sEventType = "FailedToCreateImagePrePullDaemonSet"
+	DeletedImagePrePullDaemonSet        K8sEventType = "DeletedImagePrePullDaemonSet"
+
+	// RayCluster cloning event list
+	ClonedRayCluster        K8sEventType = "ClonedRayCluster"
+	FailedToCloneRayCluster K8sEventType = "FailedToCloneRayCluster"
 
 	// Redis Cleanup Job event list
 	CreatedRedisCleanupJob        K8sEventType = "CreatedRedisCleanupJob"
 	FailedToCreateRedisCleanupJob K8sEventType = "FailedToCreateRedisCleanupJob"
 
+	// Cleanup finalizer event list
+	CleanupTimedOut K8sEventType = "CleanupTimedOut"
+
+	// Fast deletion event list
+	SkippedGracefulDeletion K8sEventType = "SkippedGracefulDeletion"
+
+	// Dry-run event list
+	AdmissionDenied K8sEventType = "AdmissionDenied"
+
+	// Resource usage accounting event list
+	ReportedResourceUsage K8sEventType = "ReportedResourceUsage"
+
 	// RayJob event list
 	InvalidRayJobSpec             K8sEventType = "InvalidRayJobSpec"
 	InvalidRayJobStatus           K8sEventType = "InvalidRayJobStatus"
@@ -262,6 +572,7 @@ const (
 	DeletedRayJobSubmitter        K8sEventType = "DeletedRayJobSubmitter"
 	FailedToCreateRayJobSubmitter K8sEventType = "FailedToCreateRayJobSubmitter"
 	FailedToDeleteRayJobSubmitter K8sEventType = "FailedToDeleteRayJobSubmitter"
+	FailedToSubmitRayJob          K8sEventType = "FailedToSubmitRayJob"
 	CreatedRayCluster             K8sEventType = "CreatedRayCluster"
 	UpdatedRayCluster             K8sEventType = "UpdatedRayCluster"
 	DeletedRayCluster             K8sEventType = "DeletedRayCluster"
@@ -270,16 +581,47 @@ const (
 	FailedToUpdateRayCluster      K8sEventType = "FailedToUpdateRayCluster"
 
 	// RayService event list
-	InvalidRayServiceSpec K8sEventType = "InvalidRayServiceSpec"
+	InvalidRayServiceSpec           K8sEventType = "InvalidRayServiceSpec"
+	WaitingForPromotion             K8sEventType = "WaitingForPromotion"
+	PromotedPendingRayCluster       K8sEventType = "PromotedPendingRayCluster"
+	AbortedRayServiceUpgrade        K8sEventType = "AbortedRayServiceUpgrade"
+	FailedToAbortRayServiceUpgrade  K8sEventType = "FailedToAbortRayServiceUpgrade"
+	ServeProbeFailed                K8sEventType = "ServeProbeFailed"
+	ServeReadinessCheckFailed       K8sEventType = "ServeReadinessCheckFailed"
+	ServeReplicasStuckStarting      K8sEventType = "ServeReplicasStuckStarting"
+	InvalidServeConfigV2Variables   K8sEventType = "InvalidServeConfigV2Variables"
+	RevertedServiceDrift            K8sEventType = "RevertedServiceDrift"
+	UpgradingServeApplication       K8sEventType = "UpgradingServeApplication"
+	UpgradedServeApplication        K8sEventType = "UpgradedServeApplication"
+	FailedToUpgradeServeApplication K8sEventType = "FailedToUpgradeServeApplication"
+	ShadowingServeRequests          K8sEventType = "ShadowingServeRequests"
+	ShadowingErrorRateExceeded      K8sEventType = "ShadowingErrorRateExceeded"
+	RequestShadowingUnavailable     K8sEventType = "RequestShadowingUnavailable"
+	CreatedDebugRayCluster          K8sEventType = "CreatedDebugRayCluster"
+	DeletedDebugRayCluster          K8sEventType = "DeletedDebugRayCluster"
+	FailedOverRayService            K8sEventType = "FailedOverRayService"
+	RecoveredRayService             K8sEventType = "RecoveredRayService"
+	FailedToCallFailoverWebhook     K8sEventType = "FailedToCallFailoverWebhook"
+	FetchedServeConfig              K8sEventType = "FetchedServeConfig"
+	FailedToFetchServeConfig        K8sEventType = "FailedToFetchServeConfig"
+	StartedMaintenance              K8sEventType = "StartedMaintenance"
+	EndedMaintenance                K8sEventType = "EndedMaintenance"
 
 	// Generic Pod event list
 	DeletedPod                  K8sEventType = "DeletedPod"
 	FailedToDeletePod           K8sEventType = "FailedToDeletePod"
 	FailedToDeletePodCollection K8sEventType = "FailedToDeletePodCollection"
 
+	// Profiling event list
+	StartedProfiling        K8sEventType = "StartedProfiling"
+	CompletedProfiling      K8sEventType = "CompletedProfiling"
+	FailedToProfile         K8sEventType = "FailedToProfile"
+	InvalidProfilingRequest K8sEventType = "InvalidProfilingRequest"
+
 	// Ingress event list
 	CreatedIngress        K8sEventType = "CreatedIngress"
 	FailedToCreateIngress K8sEventType = "FailedToCreateIngress"
+	UpdatedIngress        K8sEventType = "UpdatedIngress"
 
 	// Route event list
 	CreatedRoute        K8sEventType = "CreatedRoute"
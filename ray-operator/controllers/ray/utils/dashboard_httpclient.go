@@ -5,7 +5,10 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"math"
+	"net"
 	"net/http"
+	"strconv"
 	"time"
 
 	"k8s.io/apimachinery/pkg/util/yaml"
@@ -16,6 +19,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 
 	"k8s.io/apimachinery/pkg/util/json"
 
@@ -28,6 +32,17 @@ var (
 	DeployPathV2     = "/api/serve/applications/"
 	// Job URL paths
 	JobPath = "/api/jobs/"
+	// Autoscaler URL paths
+	ClusterStatusPath = "/api/cluster_status"
+	// State API URL paths
+	AliveActorsPath = "/api/v0/actors?filter_keys=state&filter_predicates=%3D&filter_values=ALIVE"
+	AliveNodesPath  = "/api/v0/nodes?filter_keys=state&filter_predicates=%3D&filter_values=ALIVE"
+	// RunningTasksPath lists the tasks that are running, excluding the actor creation tasks of the alive actors.
+	RunningTasksPath = "/api/v0/tasks?filter_keys=state&filter_predicates=%3D&filter_values=RUNNING"
+	// AutoscalerEventsPath lists the cluster events reported by the Ray autoscaler.
+	AutoscalerEventsPath = "/api/v0/cluster_events?filter_keys=source_type&filter_predicates=%3D&filter_values=AUTOSCALER"
+	// Node URL paths
+	DrainNodePath = "/api/v0/nodes/drain"
 )
 
 type RayDashboardClientInterface interface {
@@ -43,6 +58,12 @@ type RayDashboardClientInterface interface {
 	GetJobLog(ctx context.Context, jobName string) (*string, error)
 	StopJob(ctx context.Context, jobName string) error
 	DeleteJob(ctx context.Context, jobName string) error
+	GetClusterStatus(ctx context.Context) (*RayClusterStatusInfo, error)
+	ListAliveActors(ctx context.Context) ([]RayActorInfo, error)
+	ListAliveNodes(ctx context.Context) ([]RayNodeInfo, error)
+	ListRunningTasks(ctx context.Context) ([]RayTaskInfo, error)
+	ListAutoscalerEvents(ctx context.Context) ([]RayClusterEventInfo, error)
+	DrainNode(ctx context.Context, nodeID string, message string, deadline time.Time) error
 }
 
 type BaseDashboardClient struct {
@@ -75,6 +96,11 @@ func FetchHeadServiceURL(ctx context.Context, cli client.Client, rayCluster *ray
 		log.Error(err, "Failed to generate head service name", "RayCluster name", rayCluster.Name, "RayCluster spec", rayCluster.Spec)
 		return "", err
 	}
+	// Prefer the name of the head service found by the RayCluster controller, which is the name the service was
+	// created with even if the name derivation changed since.
+	if rayCluster.Status.Head.ServiceName != "" {
+		headSvcName = rayCluster.Status.Head.ServiceName
+	}
 
 	if err = cli.Get(ctx, client.ObjectKey{Name: headSvcName, Namespace: rayCluster.Namespace}, headSvc); err != nil {
 		if errors.IsNotFound(err) {
@@ -95,19 +121,39 @@ func FetchHeadServiceURL(ctx context.Context, cli client.Client, rayCluster *ray
 	}
 
 	if port == int32(-1) {
+		// The head service doesn't expose the dashboard port when the dashboard is behind oauth2-proxy, so KubeRay
+		// accesses the dashboard through the head Pod instead.
+		if defaultPortName == DashboardPortName && IsDashboardBehindOAuth2Proxy(rayCluster) {
+			return headPodDashboardURL(rayCluster)
+		}
 		return "", fmtErrors.Errorf("%s port is not found", defaultPortName)
 	}
 
-	domainName := GetClusterDomainName()
-	headServiceURL := fmt.Sprintf("%s.%s.svc.%s:%v",
-		headSvc.Name,
-		headSvc.Namespace,
-		domainName,
-		port)
+	domainName := GetRayClusterDomainName(rayCluster.Spec)
+	headServiceURL := net.JoinHostPort(fmt.Sprintf("%s.%s.svc.%s", headSvc.Name, headSvc.Namespace, domainName),
+		strconv.Itoa(int(port)))
 	log.Info("FetchHeadServiceURL", "head service URL", headServiceURL)
 	return headServiceURL, nil
 }
 
+// IsDashboardBehindOAuth2Proxy returns whether the dashboard of the RayCluster is only exposed through the oauth2-proxy
+// sidecar of the head Pod.
+func IsDashboardBehindOAuth2Proxy(rayCluster *rayv1.RayCluster) bool {
+	return rayCluster.Spec.DashboardIngress != nil && rayCluster.Spec.DashboardIngress.OAuth2Proxy != nil
+}
+
+// headPodDashboardURL returns the address of the dashboard on the head Pod.
+func headPodDashboardURL(rayCluster *rayv1.RayCluster) (string, error) {
+	if rayCluster.Status.Head.PodIP == "" {
+		return "", fmtErrors.Errorf("the IP of the head Pod of RayCluster %s is not found", rayCluster.Name)
+	}
+	return net.JoinHostPort(rayCluster.Status.Head.PodIP, strconv.Itoa(headPodDashboardPort(rayCluster))), nil
+}
+
+func headPodDashboardPort(rayCluster *rayv1.RayCluster) int {
+	return FindContainerPort(&rayCluster.Spec.HeadGroupSpec.Template.Spec.Containers[RayContainerIndex], DashboardPortName, DefaultDashboardPort)
+}
+
 func (r *RayDashboardClient) InitClient(ctx context.Context, url string, rayCluster *rayv1.RayCluster) error {
 	log := ctrl.LoggerFrom(ctx)
 
@@ -123,6 +169,13 @@ func (r *RayDashboardClient) InitClient(ctx context.Context, url string, rayClus
 		}
 
 		r.client = r.mgr.GetHTTPClient()
+		if IsDashboardBehindOAuth2Proxy(rayCluster) {
+			if rayCluster.Status.Head.PodName == "" {
+				return fmtErrors.Errorf("the head Pod of RayCluster %s is not found", rayCluster.Name)
+			}
+			r.dashboardURL = fmt.Sprintf("%s/api/v1/namespaces/%s/pods/%s:%d/proxy", r.mgr.GetConfig().Host, rayCluster.Namespace, rayCluster.Status.Head.PodName, headPodDashboardPort(rayCluster))
+			return nil
+		}
 		r.dashboardURL = fmt.Sprintf("%s/api/v1/namespaces/%s/services/%s:dashboard/proxy", r.mgr.GetConfig().Host, rayCluster.Namespace, headSvcName)
 		return nil
 	}
@@ -144,6 +197,11 @@ func (r *RayDashboardClient) UpdateDeployments(ctx context.Context, configJson [
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	// Stream the large configs in chunks instead of announcing their length upfront, so that the dashboard reads
+	// them as they are sent.
+	if len(configJson) > ServeConfigCompressionThreshold {
+		req.ContentLength = -1
+	}
 
 	resp, err := r.client.Do(req)
 	if err != nil {
@@ -252,6 +310,163 @@ type RayJobLogsResponse struct {
 	Logs string `json:"logs,omitempty"`
 }
 
+// RayClusterStatusInfo is the status reported by the Ray autoscaler.
+// Reference to https://github.com/ray-project/ray/blob/master/dashboard/modules/reporter/reporter_head.py
+type RayClusterStatusInfo struct {
+	LoadMetricsReport RayLoadMetricsReport `json:"loadMetricsReport"`
+}
+
+type RayLoadMetricsReport struct {
+	// ResourceDemand are the resource shapes of the tasks and actors that are waiting for resources.
+	ResourceDemand []RayResourceDemand `json:"resourceDemand,omitempty"`
+	// UsageByNode is the `[used, total]` amount of each resource of each node, keyed by the node ID.
+	UsageByNode map[string]map[string][]float64 `json:"usageByNode,omitempty"`
+}
+
+// RayResourceDemand is a resource shape and the number of pending requests with this shape.
+// The autoscaler reports it as a `[shape, count]` pair.
+type RayResourceDemand struct {
+	Resources map[string]float64
+	Count     int32
+}
+
+func (d *RayResourceDemand) UnmarshalJSON(data []byte) error {
+	var pair []interface{}
+	if err := json.Unmarshal(data, &pair); err != nil {
+		return err
+	}
+	if len(pair) != 2 {
+		return fmt.Errorf("invalid resource demand: %s", string(data))
+	}
+	shape, err := json.Marshal(pair[0])
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(shape, &d.Resources); err != nil {
+		return err
+	}
+	count, err := json.Marshal(pair[1])
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(count, &d.Count)
+}
+
+// ConvertResourceDemands converts the resource demands reported by the Ray autoscaler to the RayCluster API,
+// dropping the shapes without pending requests.
+func ConvertResourceDemands(rayDemands []RayResourceDemand) []rayv1.ResourceDemand {
+	var demands []rayv1.ResourceDemand
+	for _, demand := range rayDemands {
+		if demand.Count <= 0 {
+			continue
+		}
+		resources := make(map[string]resource.Quantity, len(demand.Resources))
+		for name, value := range demand.Resources {
+			resources[name] = *resource.NewMilliQuantity(int64(math.Round(value*1000)), resource.DecimalSI)
+		}
+		demands = append(demands, rayv1.ResourceDemand{Resources: resources, Count: demand.Count})
+	}
+	return demands
+}
+
+type rayClusterStatusResponse struct {
+	Data struct {
+		ClusterStatus *RayClusterStatusInfo `json:"clusterStatus"`
+	} `json:"data"`
+	Result bool `json:"result"`
+}
+
+// RayActorInfo is an actor returned by the Ray state API.
+// Reference to https://github.com/ray-project/ray/blob/master/python/ray/util/state/common.py
+type RayActorInfo struct {
+	ActorID   string `json:"actor_id"`
+	ClassName string `json:"class_name"`
+	State     string `json:"state"`
+	NodeID    string `json:"node_id"`
+}
+
+type rayActorListResponse struct {
+	Data struct {
+		Result struct {
+			Result []RayActorInfo `json:"result"`
+		} `json:"result"`
+	} `json:"data"`
+	Msg    string `json:"msg"`
+	Result bool   `json:"result"`
+}
+
+type RayNodeInfo struct {
+	NodeID string `json:"node_id"`
+	NodeIP string `json:"node_ip"`
+	State  string `json:"state"`
+}
+
+type rayNodeListResponse struct {
+	Data struct {
+		Result struct {
+			Result []RayNodeInfo `json:"result"`
+		} `json:"result"`
+	} `json:"data"`
+	Msg    string `json:"msg"`
+	Result bool   `json:"result"`
+}
+
+// RayTaskInfo is a task returned by the Ray state API.
+// Reference to https://github.com/ray-project/ray/blob/master/python/ray/util/state/common.py
+type RayTaskInfo struct {
+	TaskID string `json:"task_id"`
+	Name   string `json:"name"`
+	State  string `json:"state"`
+	NodeID string `json:"node_id"`
+}
+
+type rayTaskListResponse struct {
+	Data struct {
+		Result struct {
+			Result []RayTaskInfo `json:"result"`
+		} `json:"result"`
+	} `json:"data"`
+	Msg    string `json:"msg"`
+	Result bool   `json:"result"`
+}
+
+// RayClusterEventInfo is a cluster event returned by the Ray state API, e.g. a scaling decision of the autoscaler.
+// Reference to https://github.com/ray-project/ray/blob/master/python/ray/util/state/common.py
+type RayClusterEventInfo struct {
+	EventID    string `json:"event_id"`
+	Severity   string `json:"severity"`
+	SourceType string `json:"source_type"`
+	Message    string `json:"message"`
+	Time       string `json:"time"`
+}
+
+type rayClusterEventListResponse struct {
+	Data struct {
+		Result struct {
+			Result []RayClusterEventInfo `json:"result"`
+		} `json:"result"`
+	} `json:"data"`
+	Msg    string `json:"msg"`
+	Result bool   `json:"result"`
+}
+
+// RayDrainNodeRequest is the request to drain a Ray node. Ray preempts the node at the deadline, and doesn't schedule
+// new tasks or actors on the node in the meantime.
+type RayDrainNodeRequest struct {
+	NodeID              string `json:"node_id"`
+	Reason              string `json:"reason"`
+	ReasonMessage       string `json:"reason_message,omitempty"`
+	DeadlineTimestampMs int64  `json:"deadline_timestamp_ms"`
+}
+
+type rayDrainNodeResponse struct {
+	Msg    string `json:"msg"`
+	Result bool   `json:"result"`
+	Data   struct {
+		IsAccepted bool `json:"is_accepted"`
+	} `json:"data"`
+}
+
 // Note that RayJobInfo and error can't be nil at the same time.
 // Please make sure if the Ray job with JobId can't be found. Return a BadRequest error.
 func (r *RayDashboardClient) GetJobInfo(ctx context.Context, jobId string) (*RayJobInfo, error) {
@@ -314,6 +529,218 @@ func (r *RayDashboardClient) ListJobs(ctx context.Context) (*[]RayJobInfo, error
 	return &jobInfo, nil
 }
 
+// GetClusterStatus returns the status reported by the Ray autoscaler. It returns nil if the autoscaler hasn't
+// reported its status yet.
+func (r *RayDashboardClient) GetClusterStatus(ctx context.Context) (*RayClusterStatusInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", r.dashboardURL+ClusterStatusPath+"?format=0", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("GetClusterStatus fail: %s %s", resp.Status, string(body))
+	}
+
+	var statusResp rayClusterStatusResponse
+	if err = json.Unmarshal(body, &statusResp); err != nil {
+		return nil, fmt.Errorf("GetClusterStatus failed. Failed to unmarshal bytes: %s", string(body))
+	}
+	if !statusResp.Result {
+		return nil, fmt.Errorf("GetClusterStatus fail: %s", string(body))
+	}
+
+	return statusResp.Data.ClusterStatus, nil
+}
+
+// ListAliveActors returns the alive actors of the Ray cluster.
+func (r *RayDashboardClient) ListAliveActors(ctx context.Context) ([]RayActorInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", r.dashboardURL+AliveActorsPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("ListAliveActors fail: %s %s", resp.Status, string(body))
+	}
+
+	var actorsResp rayActorListResponse
+	if err = json.Unmarshal(body, &actorsResp); err != nil {
+		return nil, fmt.Errorf("ListAliveActors failed. Failed to unmarshal bytes: %s", string(body))
+	}
+	if !actorsResp.Result {
+		return nil, fmt.Errorf("ListAliveActors fail: %s", actorsResp.Msg)
+	}
+
+	return actorsResp.Data.Result.Result, nil
+}
+
+// ListAliveNodes returns the alive nodes of the Ray cluster.
+func (r *RayDashboardClient) ListAliveNodes(ctx context.Context) ([]RayNodeInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", r.dashboardURL+AliveNodesPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("ListAliveNodes fail: %s %s", resp.Status, string(body))
+	}
+
+	var nodesResp rayNodeListResponse
+	if err = json.Unmarshal(body, &nodesResp); err != nil {
+		return nil, fmt.Errorf("ListAliveNodes failed. Failed to unmarshal bytes: %s", string(body))
+	}
+	if !nodesResp.Result {
+		return nil, fmt.Errorf("ListAliveNodes fail: %s", nodesResp.Msg)
+	}
+
+	return nodesResp.Data.Result.Result, nil
+}
+
+// ListRunningTasks returns the running tasks of the Ray cluster.
+func (r *RayDashboardClient) ListRunningTasks(ctx context.Context) ([]RayTaskInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", r.dashboardURL+RunningTasksPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("ListRunningTasks fail: %s %s", resp.Status, string(body))
+	}
+
+	var tasksResp rayTaskListResponse
+	if err = json.Unmarshal(body, &tasksResp); err != nil {
+		return nil, fmt.Errorf("ListRunningTasks failed. Failed to unmarshal bytes: %s", string(body))
+	}
+	if !tasksResp.Result {
+		return nil, fmt.Errorf("ListRunningTasks fail: %s", tasksResp.Msg)
+	}
+
+	return tasksResp.Data.Result.Result, nil
+}
+
+// ListAutoscalerEvents returns the cluster events reported by the Ray autoscaler, from the oldest to the newest.
+func (r *RayDashboardClient) ListAutoscalerEvents(ctx context.Context) ([]RayClusterEventInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", r.dashboardURL+AutoscalerEventsPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("ListAutoscalerEvents fail: %s %s", resp.Status, string(body))
+	}
+
+	var eventsResp rayClusterEventListResponse
+	if err = json.Unmarshal(body, &eventsResp); err != nil {
+		return nil, fmt.Errorf("ListAutoscalerEvents failed. Failed to unmarshal bytes: %s", string(body))
+	}
+	if !eventsResp.Result {
+		return nil, fmt.Errorf("ListAutoscalerEvents fail: %s", eventsResp.Msg)
+	}
+
+	return eventsResp.Data.Result.Result, nil
+}
+
+// DrainNode asks Ray to drain the node before the deadline. It returns an error if Ray rejects the request.
+func (r *RayDashboardClient) DrainNode(ctx context.Context, nodeID string, message string, deadline time.Time) error {
+	log := ctrl.LoggerFrom(ctx)
+	log.Info("Drain a Ray node", "nodeID", nodeID, "deadline", deadline)
+
+	drainReq, err := json.Marshal(RayDrainNodeRequest{
+		NodeID:              nodeID,
+		Reason:              "DRAIN_NODE_REASON_PREEMPTION",
+		ReasonMessage:       message,
+		DeadlineTimestampMs: deadline.UnixMilli(),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.dashboardURL+DrainNodePath, bytes.NewBuffer(drainReq))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("DrainNode fail: %s %s", resp.Status, string(body))
+	}
+
+	var drainResp rayDrainNodeResponse
+	if err = json.Unmarshal(body, &drainResp); err != nil {
+		return fmt.Errorf("DrainNode failed. Failed to unmarshal bytes: %s", string(body))
+	}
+	if !drainResp.Result || !drainResp.Data.IsAccepted {
+		return fmt.Errorf("DrainNode fail: the request to drain node %s is rejected: %s", nodeID, drainResp.Msg)
+	}
+	return nil
+}
+
 func (r *RayDashboardClient) SubmitJob(ctx context.Context, rayJob *rayv1.RayJob) (jobId string, err error) {
 	request, err := ConvertRayJobToReq(rayJob)
 	if err != nil {
@@ -455,13 +882,11 @@ func ConvertRayJobToReq(rayJob *rayv1.RayJob) (*RayJobRequest, error) {
 		SubmissionId: rayJob.Status.JobId,
 		Metadata:     rayJob.Spec.Metadata,
 	}
-	if len(rayJob.Spec.RuntimeEnvYAML) != 0 {
-		runtimeEnv, err := UnmarshalRuntimeEnvYAML(rayJob.Spec.RuntimeEnvYAML)
-		if err != nil {
-			return nil, err
-		}
-		req.RuntimeEnv = runtimeEnv
+	runtimeEnv, err := GetRayJobRuntimeEnv(rayJob)
+	if err != nil {
+		return nil, err
 	}
+	req.RuntimeEnv = runtimeEnv
 	req.NumCpus = rayJob.Spec.EntrypointNumCpus
 	req.NumGpus = rayJob.Spec.EntrypointNumGpus
 	if rayJob.Spec.EntrypointResources != "" {
@@ -472,6 +897,34 @@ func ConvertRayJobToReq(rayJob *rayv1.RayJob) (*RayJobRequest, error) {
 	return req, nil
 }
 
+// GetRayJobRuntimeEnv returns the runtime environment of the Ray job of the RayJob. If checkpointing is enabled, the
+// location of the checkpoints is added to the environment variables of the entrypoint.
+func GetRayJobRuntimeEnv(rayJob *rayv1.RayJob) (RuntimeEnvType, error) {
+	runtimeEnv, err := UnmarshalRuntimeEnvYAML(rayJob.Spec.RuntimeEnvYAML)
+	if err != nil || rayJob.Spec.Checkpointing == nil {
+		return runtimeEnv, err
+	}
+	if runtimeEnv == nil {
+		runtimeEnv = RuntimeEnvType{}
+	}
+	envVars := map[string]interface{}{}
+	if existing, ok := runtimeEnv["env_vars"]; ok && existing != nil {
+		existingEnvVars, ok := existing.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("env_vars of the RuntimeEnvYAML must be a map, got %T", existing)
+		}
+		for key, value := range existingEnvVars {
+			envVars[key] = value
+		}
+	}
+	envVars[RAY_JOB_CHECKPOINT_URI] = rayJob.Spec.Checkpointing.URI
+	if rayJob.Status.ResumedFromCheckpointURI != "" {
+		envVars[RAY_JOB_RESUME_FROM_CHECKPOINT_URI] = rayJob.Status.ResumedFromCheckpointURI
+	}
+	runtimeEnv["env_vars"] = envVars
+	return runtimeEnv, nil
+}
+
 func UnmarshalRuntimeEnvYAML(runtimeEnvYAML string) (RuntimeEnvType, error) {
 	var runtimeEnv RuntimeEnvType
 	err := yaml.Unmarshal([]byte(runtimeEnvYAML), &runtimeEnv)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"time"
 
 	"github.com/jarcoal/httpmock"
 	. "github.com/onsi/ginkgo/v2"
@@ -171,4 +172,121 @@ var _ = Describe("RayFrameworkGenerator", func() {
 		err := rayDashboardClient.StopJob(context.TODO(), "stop-job-1")
 		Expect(err).ToNot(HaveOccurred())
 	})
+
+	It("Test getting the cluster status", func() {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		httpmock.RegisterResponder("GET", rayDashboardClient.dashboardURL+ClusterStatusPath+"?format=0",
+			httpmock.NewStringResponder(200, `{"result": true, "msg": "Got cluster status.", "data": {"clusterStatus": {
+				"loadMetricsReport": {"resourceDemand": [[{"CPU": 1.0, "GPU": 0.5}, 3], [{"memory": 1073741824.0}, 1]]}}}}`))
+
+		status, err := rayDashboardClient.GetClusterStatus(context.TODO())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(status.LoadMetricsReport.ResourceDemand).To(Equal([]RayResourceDemand{
+			{Resources: map[string]float64{"CPU": 1, "GPU": 0.5}, Count: 3},
+			{Resources: map[string]float64{"memory": 1073741824}, Count: 1},
+		}))
+	})
+
+	It("Test getting the replicas of the Serve deployments", func() {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		httpmock.RegisterResponder("GET", rayDashboardClient.dashboardURL+ServeDetailsPath,
+			httpmock.NewStringResponder(200, `{"applications": {"app1": {"name": "app1", "status": "RUNNING", "deployments": {
+				"Model": {"name": "Model", "status": "UPDATING", "replicas": [
+					{"replica_id": "r1", "state": "RUNNING", "node_id": "n1", "node_ip": "10.0.0.1", "start_time_s": 1700000000.5},
+					{"replica_id": "r2", "state": "STARTING", "node_id": "n2", "node_ip": "10.0.0.2", "start_time_s": 1700000100}]}}}}}`))
+
+		statuses, err := rayDashboardClient.GetMultiApplicationStatus(context.TODO())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(statuses["app1"].Deployments["Model"].Replicas).To(Equal([]ServeReplicaDetails{
+			{ReplicaID: "r1", State: "RUNNING", NodeID: "n1", NodeIP: "10.0.0.1", StartTimeS: 1700000000.5},
+			{ReplicaID: "r2", State: "STARTING", NodeID: "n2", NodeIP: "10.0.0.2", StartTimeS: 1700000100},
+		}))
+	})
+
+	It("Test listing the alive actors", func() {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		httpmock.RegisterResponder("GET", rayDashboardClient.dashboardURL+AliveActorsPath,
+			httpmock.NewStringResponder(200, `{"result": true, "msg": "", "data": {"result": {"total": 1, "num_after_truncation": 1,
+				"result": [{"actor_id": "a1", "class_name": "Counter", "state": "ALIVE"}]}}}`))
+
+		actors, err := rayDashboardClient.ListAliveActors(context.TODO())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(actors).To(Equal([]RayActorInfo{{ActorID: "a1", ClassName: "Counter", State: "ALIVE"}}))
+	})
+
+	It("Test listing the alive nodes", func() {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		httpmock.RegisterResponder("GET", rayDashboardClient.dashboardURL+AliveNodesPath,
+			httpmock.NewStringResponder(200, `{"result": true, "msg": "", "data": {"result": {"total": 1, "num_after_truncation": 1,
+				"result": [{"node_id": "n1", "node_ip": "10.0.0.1", "state": "ALIVE"}]}}}`))
+
+		nodes, err := rayDashboardClient.ListAliveNodes(context.TODO())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(nodes).To(Equal([]RayNodeInfo{{NodeID: "n1", NodeIP: "10.0.0.1", State: "ALIVE"}}))
+	})
+
+	It("Test listing the running tasks", func() {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		httpmock.RegisterResponder("GET", rayDashboardClient.dashboardURL+RunningTasksPath,
+			httpmock.NewStringResponder(200, `{"result": true, "msg": "", "data": {"result": {"total": 1, "num_after_truncation": 1,
+				"result": [{"task_id": "t1", "name": "train", "state": "RUNNING", "node_id": "n1"}]}}}`))
+
+		tasks, err := rayDashboardClient.ListRunningTasks(context.TODO())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tasks).To(Equal([]RayTaskInfo{{TaskID: "t1", Name: "train", State: "RUNNING", NodeID: "n1"}}))
+	})
+
+	It("Test listing the autoscaler events", func() {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		httpmock.RegisterResponder("GET", rayDashboardClient.dashboardURL+AutoscalerEventsPath,
+			httpmock.NewStringResponder(200, `{"result": true, "msg": "", "data": {"result": {"total": 1, "num_after_truncation": 1,
+				"result": [{"event_id": "e1", "severity": "INFO", "source_type": "AUTOSCALER", "message": "Adding 1 node(s) of type small-group.", "time": "2024-01-01 00:00:00"}]}}}`))
+
+		events, err := rayDashboardClient.ListAutoscalerEvents(context.TODO())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(events).To(Equal([]RayClusterEventInfo{{
+			EventID: "e1", Severity: "INFO", SourceType: "AUTOSCALER", Message: "Adding 1 node(s) of type small-group.", Time: "2024-01-01 00:00:00",
+		}}))
+	})
+
+	It("Test streaming a large Serve config", func() {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		var contentLength int64
+		httpmock.RegisterResponder("PUT", rayDashboardClient.dashboardURL+DeployPathV2,
+			func(req *http.Request) (*http.Response, error) {
+				contentLength = req.ContentLength
+				return httpmock.NewStringResponse(200, ""), nil
+			})
+
+		err := rayDashboardClient.UpdateDeployments(context.TODO(), []byte(`{"applications": []}`))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(contentLength).To(Equal(int64(len(`{"applications": []}`))))
+
+		err = rayDashboardClient.UpdateDeployments(context.TODO(), make([]byte, ServeConfigCompressionThreshold+1))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(contentLength).To(Equal(int64(-1)))
+	})
+
+	It("Test draining a node", func() {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		httpmock.RegisterResponder("POST", rayDashboardClient.dashboardURL+DrainNodePath,
+			httpmock.NewStringResponder(200, `{"result": true, "msg": "", "data": {"is_accepted": true}}`))
+
+		err := rayDashboardClient.DrainNode(context.TODO(), "n1", "scale down", time.Now().Add(time.Minute))
+		Expect(err).ToNot(HaveOccurred())
+
+		httpmock.RegisterResponder("POST", rayDashboardClient.dashboardURL+DrainNodePath,
+			httpmock.NewStringResponder(200, `{"result": true, "msg": "the node is busy", "data": {"is_accepted": false}}`))
+
+		err = rayDashboardClient.DrainNode(context.TODO(), "n1", "scale down", time.Now().Add(time.Minute))
+		Expect(err).To(HaveOccurred())
+	})
 })
@@ -0,0 +1,324 @@
+package utils
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+const (
+	// ServeDeployJobLabelKey is set on the Jobs that deploy the Serve configs of the RayClusters whose dashboard is
+	// disabled.
+	ServeDeployJobLabelKey = "ray.io/serve-deploy"
+	// ServeDeployApplicationsAnnotationKey is the JSON list of the names of the applications of the Serve config
+	// deployed by a serve deploy Job.
+	ServeDeployApplicationsAnnotationKey = "ray.io/serve-applications"
+	// serveDeployConfigKey is the key of the Serve config in the Secret of a serve deploy Job. The Serve config is
+	// stored in a Secret because its variables may be substituted from Secrets.
+	serveDeployConfigKey       = "serve-config.json"
+	serveDeployConfigMountPath = "/tmp/serve-config"
+	// gcsDialTimeout bounds the time the GCS of the head Pod takes to accept a connection.
+	gcsDialTimeout = 2 * time.Second
+)
+
+// ErrDashboardDisabled is returned by the methods of the DashboardlessClient that need the Ray dashboard.
+var ErrDashboardDisabled = errors.New("the Ray dashboard is disabled")
+
+// DashboardlessClient is the RayDashboardClientInterface of the RayServices whose dashboard is disabled, so that
+// KubeRay never sends requests to the dashboard. The Serve configs are deployed with `serve deploy` in a Job in the
+// namespace of the RayCluster, and the Serve applications are running once the Job succeeded, the GCS of the head Pod
+// accepts connections and the Serve proxy of the head Pod is healthy. The job and state APIs aren't supported.
+type DashboardlessClient struct {
+	client          client.Client
+	httpProxyClient RayHttpProxyClientInterface
+	healthCheck     *rayv1.ServeProxyHealthCheck
+	rayCluster      *rayv1.RayCluster
+	gcsAddress      string
+}
+
+var _ RayDashboardClientInterface = &DashboardlessClient{}
+
+// NewDashboardlessClient returns a DashboardlessClient that checks the Serve proxy of the head Pod with the HTTP proxy
+// client and the health check.
+func NewDashboardlessClient(c client.Client, httpProxyClient RayHttpProxyClientInterface, healthCheck *rayv1.ServeProxyHealthCheck) *DashboardlessClient {
+	return &DashboardlessClient{
+		client:          c,
+		httpProxyClient: httpProxyClient,
+		healthCheck:     healthCheck,
+	}
+}
+
+// InitClient takes the address of the GCS server of the head Service, instead of the address of the dashboard.
+func (c *DashboardlessClient) InitClient(_ context.Context, url string, rayCluster *rayv1.RayCluster) error {
+	c.rayCluster = rayCluster
+	c.gcsAddress = url
+	return nil
+}
+
+// UpdateDeployments creates the Job that runs `serve deploy` with the Serve config, and the Secret that holds the
+// Serve config, unless they already exist. The Jobs and Secrets of the previous Serve configs are deleted.
+func (c *DashboardlessClient) UpdateDeployments(ctx context.Context, configJson []byte) error {
+	appNames, err := serveConfigApplicationNames(configJson)
+	if err != nil {
+		return err
+	}
+	hash := sha256.Sum256(configJson)
+	name := CheckName(fmt.Sprintf("%s-serve-deploy-%s", c.rayCluster.Name, hex.EncodeToString(hash[:])[:8]))
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: c.rayCluster.Namespace,
+			Labels:    c.serveDeployLabels(),
+		},
+		Data: map[string][]byte{serveDeployConfigKey: configJson},
+	}
+	job := c.buildServeDeployJob(name, appNames)
+	for _, object := range []client.Object{secret, job} {
+		if err := ctrl.SetControllerReference(c.rayCluster, object, c.client.Scheme()); err != nil {
+			return err
+		}
+		if err := c.client.Create(ctx, object); err != nil && !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+
+	jobs, err := c.listServeDeployJobs(ctx)
+	if err != nil {
+		return err
+	}
+	for i := range jobs {
+		if jobs[i].Name == name {
+			continue
+		}
+		if err := c.client.Delete(ctx, &jobs[i], client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+		oldSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: jobs[i].Name, Namespace: jobs[i].Namespace}}
+		if err := c.client.Delete(ctx, oldSecret); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *DashboardlessClient) serveDeployLabels() map[string]string {
+	return map[string]string{
+		RayClusterLabelKey:          c.rayCluster.Name,
+		ServeDeployJobLabelKey:      "true",
+		KubernetesCreatedByLabelKey: ComponentName,
+	}
+}
+
+func (c *DashboardlessClient) buildServeDeployJob(name string, appNames []string) *batchv1.Job {
+	headTemplate := c.rayCluster.Spec.HeadGroupSpec.Template
+	rayContainer := headTemplate.Spec.Containers[RayContainerIndex]
+	// `serve deploy` sends the Serve config to the head from the namespace of the RayCluster, not from KubeRay.
+	host, _, _ := net.SplitHostPort(c.gcsAddress)
+	dashboardAddress := "http://" + net.JoinHostPort(host, strconv.Itoa(FindContainerPort(&rayContainer, DashboardPortName, DefaultDashboardPort)))
+	// The names are strings, so they can't fail to be marshaled.
+	appNamesJSON, _ := json.Marshal(appNames)
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   c.rayCluster.Namespace,
+			Labels:      c.serveDeployLabels(),
+			Annotations: map[string]string{ServeDeployApplicationsAnnotationKey: string(appNamesJSON)},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.To[int32](2),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ImagePullSecrets:   headTemplate.Spec.ImagePullSecrets,
+					ServiceAccountName: headTemplate.Spec.ServiceAccountName,
+					Containers: []corev1.Container{{
+						Name:            "serve-deploy",
+						Image:           rayContainer.Image,
+						ImagePullPolicy: rayContainer.ImagePullPolicy,
+						Command:         []string{"serve", "deploy", serveDeployConfigMountPath + "/" + serveDeployConfigKey},
+						Env:             []corev1.EnvVar{{Name: RAY_DASHBOARD_ADDRESS, Value: dashboardAddress}},
+						VolumeMounts:    []corev1.VolumeMount{{Name: "serve-config", MountPath: serveDeployConfigMountPath, ReadOnly: true}},
+					}},
+					Volumes: []corev1.Volume{{
+						Name:         "serve-config",
+						VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: name}},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func (c *DashboardlessClient) listServeDeployJobs(ctx context.Context) ([]batchv1.Job, error) {
+	jobs := batchv1.JobList{}
+	if err := c.client.List(ctx, &jobs, client.InNamespace(c.rayCluster.Namespace), client.MatchingLabels{
+		RayClusterLabelKey:     c.rayCluster.Name,
+		ServeDeployJobLabelKey: "true",
+	}); err != nil {
+		return nil, err
+	}
+	return jobs.Items, nil
+}
+
+// GetMultiApplicationStatus returns the statuses of the applications of the Serve config of the latest serve deploy
+// Job, or no application if there is no Job.
+func (c *DashboardlessClient) GetMultiApplicationStatus(ctx context.Context) (map[string]*ServeApplicationStatus, error) {
+	conn, err := (&net.Dialer{Timeout: gcsDialTimeout}).DialContext(ctx, "tcp", c.gcsAddress)
+	if err != nil {
+		return nil, fmt.Errorf("the GCS server of the RayCluster %s/%s is unreachable: %w", c.rayCluster.Namespace, c.rayCluster.Name, err)
+	}
+	_ = conn.Close()
+
+	jobs, err := c.listServeDeployJobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// The Jobs of the previous Serve configs may not be deleted yet.
+	jobs = slices.DeleteFunc(jobs, func(job batchv1.Job) bool { return !job.DeletionTimestamp.IsZero() })
+	if len(jobs) == 0 {
+		return map[string]*ServeApplicationStatus{}, nil
+	}
+	job := slices.MaxFunc(jobs, func(a, b batchv1.Job) int {
+		return a.CreationTimestamp.Compare(b.CreationTimestamp.Time)
+	})
+
+	status := rayv1.ApplicationStatusEnum.DEPLOYING
+	message := fmt.Sprintf("The serve deploy Job %s is running", job.Name)
+	if conditionType, finished := IsJobFinished(&job); finished {
+		if conditionType == batchv1.JobFailed {
+			status = rayv1.ApplicationStatusEnum.DEPLOY_FAILED
+			message = fmt.Sprintf("The serve deploy Job %s failed, see the logs of its Pods", job.Name)
+		} else if err := c.checkHeadServeProxyHealth(ctx); err != nil {
+			message = fmt.Sprintf("The Serve proxy of the head Pod isn't healthy: %v", err)
+		} else {
+			status = rayv1.ApplicationStatusEnum.RUNNING
+			message = ""
+		}
+	}
+
+	var appNames []string
+	if err := json.Unmarshal([]byte(job.Annotations[ServeDeployApplicationsAnnotationKey]), &appNames); err != nil {
+		return nil, fmt.Errorf("failed to parse the applications of the Job %s: %w", job.Name, err)
+	}
+	apps := make(map[string]*ServeApplicationStatus, len(appNames))
+	for _, appName := range appNames {
+		apps[appName] = &ServeApplicationStatus{
+			Name:        appName,
+			Status:      status,
+			Message:     message,
+			Deployments: map[string]ServeDeploymentStatus{},
+		}
+	}
+	return apps, nil
+}
+
+func (c *DashboardlessClient) checkHeadServeProxyHealth(ctx context.Context) error {
+	pods := corev1.PodList{}
+	if err := c.client.List(ctx, &pods, client.InNamespace(c.rayCluster.Namespace), client.MatchingLabels{
+		RayClusterLabelKey:  c.rayCluster.Name,
+		RayNodeTypeLabelKey: string(rayv1.HeadNode),
+	}); err != nil {
+		return err
+	}
+	if len(pods.Items) != 1 {
+		return fmt.Errorf("found %d head Pods", len(pods.Items))
+	}
+	headPod := pods.Items[0]
+	rayContainer := headPod.Spec.Containers[RayContainerIndex]
+	c.httpProxyClient.InitClient()
+	c.httpProxyClient.SetHostIp(headPod.Status.PodIP, headPod.Namespace, headPod.Name, FindContainerPort(&rayContainer, ServingPortName, DefaultServingPort))
+	return c.httpProxyClient.CheckProxyActorHealth(ctx, c.healthCheck)
+}
+
+// serveConfigApplicationNames returns the names of the applications of the Serve config.
+func serveConfigApplicationNames(configJson []byte) ([]string, error) {
+	var serveConfig struct {
+		Applications []struct {
+			Name string `json:"name"`
+		} `json:"applications"`
+	}
+	if err := json.Unmarshal(configJson, &serveConfig); err != nil {
+		return nil, fmt.Errorf("failed to parse the Serve config: %w", err)
+	}
+	names := make([]string, 0, len(serveConfig.Applications))
+	for _, app := range serveConfig.Applications {
+		if app.Name == "" {
+			app.Name = DefaultServeAppName
+		}
+		names = append(names, app.Name)
+	}
+	return names, nil
+}
+
+func (c *DashboardlessClient) GetServeDetails(_ context.Context) (*ServeDetails, error) {
+	return nil, ErrDashboardDisabled
+}
+
+func (c *DashboardlessClient) GetJobInfo(_ context.Context, _ string) (*RayJobInfo, error) {
+	return nil, ErrDashboardDisabled
+}
+
+func (c *DashboardlessClient) ListJobs(_ context.Context) (*[]RayJobInfo, error) {
+	return nil, ErrDashboardDisabled
+}
+
+func (c *DashboardlessClient) SubmitJob(_ context.Context, _ *rayv1.RayJob) (string, error) {
+	return "", ErrDashboardDisabled
+}
+
+func (c *DashboardlessClient) SubmitJobReq(_ context.Context, _ *RayJobRequest, _ *string) (string, error) {
+	return "", ErrDashboardDisabled
+}
+
+func (c *DashboardlessClient) GetJobLog(_ context.Context, _ string) (*string, error) {
+	return nil, ErrDashboardDisabled
+}
+
+func (c *DashboardlessClient) StopJob(_ context.Context, _ string) error {
+	return ErrDashboardDisabled
+}
+
+func (c *DashboardlessClient) DeleteJob(_ context.Context, _ string) error {
+	return ErrDashboardDisabled
+}
+
+func (c *DashboardlessClient) GetClusterStatus(_ context.Context) (*RayClusterStatusInfo, error) {
+	return nil, ErrDashboardDisabled
+}
+
+func (c *DashboardlessClient) ListAliveActors(_ context.Context) ([]RayActorInfo, error) {
+	return nil, ErrDashboardDisabled
+}
+
+func (c *DashboardlessClient) ListAliveNodes(_ context.Context) ([]RayNodeInfo, error) {
+	return nil, ErrDashboardDisabled
+}
+
+func (c *DashboardlessClient) ListRunningTasks(_ context.Context) ([]RayTaskInfo, error) {
+	return nil, ErrDashboardDisabled
+}
+
+func (c *DashboardlessClient) ListAutoscalerEvents(_ context.Context) ([]RayClusterEventInfo, error) {
+	return nil, ErrDashboardDisabled
+}
+
+func (c *DashboardlessClient) DrainNode(_ context.Context, _ string, _ string, _ time.Time) error {
+	return ErrDashboardDisabled
+}
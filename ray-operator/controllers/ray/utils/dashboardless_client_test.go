@@ -0,0 +1,137 @@
+package utils
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestDashboardlessClient(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, batchv1.AddToScheme(scheme))
+	require.NoError(t, rayv1.AddToScheme(scheme))
+
+	// The listener stands for the GCS server of the head Pod.
+	gcs, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer gcs.Close()
+
+	rayCluster := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "raycluster", Namespace: "default"},
+		Spec: rayv1.RayClusterSpec{
+			HeadGroupSpec: rayv1.HeadGroupSpec{
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "ray-head", Image: "rayproject/ray:2.46.0"}},
+					},
+				},
+			},
+		},
+	}
+	headPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "raycluster-head",
+			Namespace: "default",
+			Labels: map[string]string{
+				RayClusterLabelKey:  "raycluster",
+				RayNodeTypeLabelKey: string(rayv1.HeadNode),
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "ray-head"}},
+		},
+	}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(scheme).WithObjects(rayCluster, headPod).WithStatusSubresource(&batchv1.Job{}).Build()
+	httpProxyClient := &FakeRayHttpProxyClient{IsHealthy: true}
+	dashboardlessClient := NewDashboardlessClient(fakeClient, httpProxyClient, nil)
+	require.NoError(t, dashboardlessClient.InitClient(ctx, gcs.Addr().String(), rayCluster))
+
+	listJobs := func() []batchv1.Job {
+		jobs := batchv1.JobList{}
+		require.NoError(t, fakeClient.List(ctx, &jobs, client.InNamespace("default")))
+		return jobs.Items
+	}
+
+	// There is no application until a Serve config is deployed.
+	apps, err := dashboardlessClient.GetMultiApplicationStatus(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, apps)
+
+	config := []byte(`{"applications": [{"name": "app1", "import_path": "app1:app"}, {"import_path": "app2:app"}]}`)
+	require.NoError(t, dashboardlessClient.UpdateDeployments(ctx, config))
+	// Deploying the same Serve config again doesn't create another Job.
+	require.NoError(t, dashboardlessClient.UpdateDeployments(ctx, config))
+	jobs := listJobs()
+	require.Len(t, jobs, 1)
+	job := jobs[0]
+	assert.Equal(t, "true", job.Labels[ServeDeployJobLabelKey])
+	assert.Equal(t, []string{"serve", "deploy", "/tmp/serve-config/serve-config.json"}, job.Spec.Template.Spec.Containers[0].Command)
+	assert.Equal(t, "http://127.0.0.1:8265", job.Spec.Template.Spec.Containers[0].Env[0].Value)
+	secret := &corev1.Secret{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: job.Name}, secret))
+	assert.Equal(t, config, secret.Data[serveDeployConfigKey])
+
+	apps, err = dashboardlessClient.GetMultiApplicationStatus(ctx)
+	require.NoError(t, err)
+	require.Len(t, apps, 2)
+	assert.Equal(t, rayv1.ApplicationStatusEnum.DEPLOYING, apps["app1"].Status)
+	assert.Equal(t, rayv1.ApplicationStatusEnum.DEPLOYING, apps[DefaultServeAppName].Status)
+
+	setJobCondition := func(conditionType batchv1.JobConditionType) {
+		job.Status.Conditions = []batchv1.JobCondition{{Type: conditionType, Status: corev1.ConditionTrue}}
+		require.NoError(t, fakeClient.Status().Update(ctx, &job))
+	}
+
+	setJobCondition(batchv1.JobFailed)
+	apps, err = dashboardlessClient.GetMultiApplicationStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, rayv1.ApplicationStatusEnum.DEPLOY_FAILED, apps["app1"].Status)
+
+	// The applications aren't running until the Serve proxy of the head Pod is healthy.
+	setJobCondition(batchv1.JobComplete)
+	httpProxyClient.IsHealthy = false
+	apps, err = dashboardlessClient.GetMultiApplicationStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, rayv1.ApplicationStatusEnum.DEPLOYING, apps["app1"].Status)
+
+	httpProxyClient.IsHealthy = true
+	apps, err = dashboardlessClient.GetMultiApplicationStatus(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, rayv1.ApplicationStatusEnum.RUNNING, apps["app1"].Status)
+	assert.Equal(t, rayv1.ApplicationStatusEnum.RUNNING, apps[DefaultServeAppName].Status)
+
+	// A new Serve config replaces the Job and the Secret of the previous one.
+	newConfig := []byte(`{"applications": [{"name": "app3", "import_path": "app3:app"}]}`)
+	require.NoError(t, dashboardlessClient.UpdateDeployments(ctx, newConfig))
+	jobs = listJobs()
+	require.Len(t, jobs, 1)
+	assert.NotEqual(t, job.Name, jobs[0].Name)
+	err = fakeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: job.Name}, &corev1.Secret{})
+	assert.True(t, apierrors.IsNotFound(err))
+	apps, err = dashboardlessClient.GetMultiApplicationStatus(ctx)
+	require.NoError(t, err)
+	require.Len(t, apps, 1)
+	assert.Equal(t, rayv1.ApplicationStatusEnum.DEPLOYING, apps["app3"].Status)
+
+	_, err = dashboardlessClient.GetServeDetails(ctx)
+	require.ErrorIs(t, err, ErrDashboardDisabled)
+
+	// The applications can't be checked while the GCS server is unreachable.
+	require.NoError(t, gcs.Close())
+	_, err = dashboardlessClient.GetMultiApplicationStatus(ctx)
+	require.Error(t, err)
+}
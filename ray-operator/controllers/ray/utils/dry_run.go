@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+// AdmissionDeniedError is returned when the server-side dry-run of the creation of an object is denied, for example by
+// a validating admission webhook such as OPA Gatekeeper, a ValidatingAdmissionPolicy, or a ResourceQuota. The object
+// can't be created until the object or the policy changes, so retrying right away doesn't help.
+type AdmissionDeniedError struct {
+	// Reason is the reason of the AdmissionDenied condition, AdmissionRejected or ResourceQuotaExceeded.
+	Reason string
+	Kind   string
+	Name   string
+	Err    error
+}
+
+func (e *AdmissionDeniedError) Error() string {
+	return fmt.Sprintf("the creation of the %s %s was denied: %v", e.Kind, e.Name, e.Err)
+}
+
+func (e *AdmissionDeniedError) Unwrap() error {
+	return e.Err
+}
+
+// GetAdmissionDeniedError returns the AdmissionDeniedError in the chain of the error, or nil if there is none.
+func GetAdmissionDeniedError(err error) *AdmissionDeniedError {
+	var denied *AdmissionDeniedError
+	if errors.As(err, &denied) {
+		return denied
+	}
+	return nil
+}
+
+// DryRunCreate sends the creation of a copy of the object to the API server as a server-side dry-run, so that the
+// admission webhooks, the admission policies and the quotas are evaluated without persisting it. A denial is returned as
+// an AdmissionDeniedError. The other errors are ignored, since the actual creation reports them.
+func DryRunCreate(ctx context.Context, c client.Client, obj client.Object) error {
+	err := c.Create(ctx, obj.DeepCopyObject().(client.Object), client.DryRunAll)
+	if !apierrors.IsForbidden(err) && !apierrors.IsInvalid(err) {
+		return nil
+	}
+	reason := rayv1.AdmissionRejected
+	if apierrors.IsForbidden(err) && strings.Contains(err.Error(), "exceeded quota") {
+		reason = rayv1.ResourceQuotaExceeded
+	}
+	name := obj.GetName()
+	if name == "" {
+		name = obj.GetGenerateName()
+	}
+	return &AdmissionDeniedError{
+		Reason: reason,
+		Kind:   reflect.Indirect(reflect.ValueOf(obj)).Type().Name(),
+		Name:   obj.GetNamespace() + "/" + name,
+		Err:    err,
+	}
+}
@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestDryRunCreate(t *testing.T) {
+	ctx := context.Background()
+	podResource := schema.GroupResource{Resource: "pods"}
+	tests := []struct {
+		dryRunErr      error
+		name           string
+		expectedReason string
+	}{
+		{
+			name: "admitted",
+		},
+		{
+			name:           "denied by a webhook",
+			dryRunErr:      apierrors.NewForbidden(podResource, "", errors.New(`admission webhook "validation.gatekeeper.sh" denied the request`)),
+			expectedReason: rayv1.AdmissionRejected,
+		},
+		{
+			name:           "exceeds a ResourceQuota",
+			dryRunErr:      apierrors.NewForbidden(podResource, "", errors.New("exceeded quota: compute, requested: cpu=4, used: cpu=8, limited: cpu=10")),
+			expectedReason: rayv1.ResourceQuotaExceeded,
+		},
+		{
+			name:           "invalid",
+			dryRunErr:      apierrors.NewInvalid(schema.GroupKind{Kind: "Pod"}, "", nil),
+			expectedReason: rayv1.AdmissionRejected,
+		},
+		{
+			name:      "other errors are left to the creation",
+			dryRunErr: apierrors.NewServiceUnavailable("unavailable"),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var dryRuns int
+			fakeClient := clientFake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					createOptions := &client.CreateOptions{}
+					createOptions.ApplyOptions(opts)
+					if slices.Contains(createOptions.DryRun, metav1.DryRunAll) {
+						dryRuns++
+						if tc.dryRunErr != nil {
+							return tc.dryRunErr
+						}
+					}
+					return c.Create(ctx, obj, opts...)
+				},
+			}).Build()
+			pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{GenerateName: "raycluster-worker-", Namespace: "default"}}
+
+			err := DryRunCreate(ctx, fakeClient, pod)
+			assert.Equal(t, 1, dryRuns)
+			// The object isn't created nor modified.
+			assert.Empty(t, pod.Name)
+			pods := &corev1.PodList{}
+			require.NoError(t, fakeClient.List(ctx, pods))
+			assert.Empty(t, pods.Items)
+
+			denied := GetAdmissionDeniedError(err)
+			if tc.expectedReason == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.NotNil(t, denied)
+			assert.Equal(t, tc.expectedReason, denied.Reason)
+			assert.Equal(t, "Pod", denied.Kind)
+			assert.Equal(t, "default/raycluster-worker-", denied.Name)
+			assert.ErrorIs(t, err, tc.dryRunErr)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package utils
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+const (
+	// MaxJournalEntries is the number of the last decisions kept in the journal of the status of a custom resource.
+	MaxJournalEntries = 20
+	// MaxJournalMessageLength is the maximum length of the message of a journal entry.
+	MaxJournalMessageLength = 256
+	// pendingJournalEntryTTL is how long an entry is kept in memory to be merged into the journal of the status. The
+	// status is updated at the end of each reconciliation, so it's much longer than needed.
+	pendingJournalEntryTTL = 10 * time.Minute
+)
+
+// JournalEventRecorder is an EventRecorder that keeps the events of each object in memory, so that the controllers
+// merge them into the journal of the status of their custom resources. Unlike the events, which expire after an hour,
+// the journal keeps the last decisions of KubeRay about a custom resource for as long as it exists.
+type JournalEventRecorder struct {
+	record.EventRecorder
+	clock   clock.PassiveClock
+	pending map[types.UID][]rayv1.JournalEntry
+	mu      sync.Mutex
+}
+
+var _ record.EventRecorder = &JournalEventRecorder{}
+
+// NewJournalEventRecorder wraps the recorder so that the events are also recorded in the journals.
+func NewJournalEventRecorder(recorder record.EventRecorder) *JournalEventRecorder {
+	return newJournalEventRecorderWithClock(recorder, clock.RealClock{})
+}
+
+func newJournalEventRecorderWithClock(recorder record.EventRecorder, clock clock.PassiveClock) *JournalEventRecorder {
+	return &JournalEventRecorder{
+		EventRecorder: recorder,
+		clock:         clock,
+		pending:       map[types.UID][]rayv1.JournalEntry{},
+	}
+}
+
+func (r *JournalEventRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	r.record(object, eventtype, reason, message)
+	r.EventRecorder.Event(object, eventtype, reason, message)
+}
+
+func (r *JournalEventRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *JournalEventRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	message := fmt.Sprintf(messageFmt, args...)
+	r.record(object, eventtype, reason, message)
+	r.EventRecorder.AnnotatedEventf(object, annotations, eventtype, reason, "%s", message)
+}
+
+// record keeps the event until it expires. The entries of all the objects older than the TTL are forgotten, so the
+// memory is bounded by the rate of events, including the events of the objects that are deleted.
+func (r *JournalEventRecorder) record(object runtime.Object, eventtype, reason, message string) {
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return
+	}
+	if len(message) > MaxJournalMessageLength {
+		message = message[:MaxJournalMessageLength]
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.clock.Now()
+	for uid, entries := range r.pending {
+		i := sort.Search(len(entries), func(i int) bool {
+			return now.Sub(entries[i].Time.Time) < pendingJournalEntryTTL
+		})
+		if i == len(entries) {
+			delete(r.pending, uid)
+		} else {
+			r.pending[uid] = entries[i:]
+		}
+	}
+	// The time is truncated to seconds like in the status, so that the entries already merged into the journal are
+	// recognized.
+	entry := rayv1.JournalEntry{
+		Time:    metav1.NewTime(now).Rfc3339Copy(),
+		Type:    eventtype,
+		Action:  reason,
+		Message: message,
+	}
+	entries := append(r.pending[accessor.GetUID()], entry)
+	if len(entries) > MaxJournalEntries {
+		entries = entries[len(entries)-MaxJournalEntries:]
+	}
+	r.pending[accessor.GetUID()] = entries
+}
+
+// MergeJournal returns the journal with the recent events of the object that it doesn't contain yet, keeping the last
+// MaxJournalEntries entries. The events are kept until they expire, so the entries that are merged into a status that
+// fails to be updated are merged again by the next reconciliation. It returns the journal unchanged if the recorder
+// is nil.
+func (r *JournalEventRecorder) MergeJournal(uid types.UID, journal []rayv1.JournalEntry) []rayv1.JournalEntry {
+	if r == nil {
+		return journal
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var newEntries []rayv1.JournalEntry
+	for _, entry := range r.pending[uid] {
+		if !containsJournalEntry(journal, entry) {
+			newEntries = append(newEntries, entry)
+		}
+	}
+	if len(newEntries) == 0 {
+		return journal
+	}
+	merged := append(slices.Clone(journal), newEntries...)
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Time.Before(&merged[j].Time)
+	})
+	if len(merged) > MaxJournalEntries {
+		merged = merged[len(merged)-MaxJournalEntries:]
+	}
+	return merged
+}
+
+func containsJournalEntry(journal []rayv1.JournalEntry, entry rayv1.JournalEntry) bool {
+	for _, e := range journal {
+		if e.Time.Equal(&entry.Time) && e.Type == entry.Type && e.Action == entry.Action && e.Message == entry.Message {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestJournalEventRecorder(t *testing.T) {
+	fakeRecorder := record.NewFakeRecorder(100)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fakeClock := clocktesting.NewFakePassiveClock(start)
+	recorder := newJournalEventRecorderWithClock(fakeRecorder, fakeClock)
+	cluster := &rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster", UID: "uid-1"}}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", UID: "uid-2"}}
+
+	recorder.Eventf(cluster, corev1.EventTypeNormal, "CreatedPod", "Created worker Pod %s", "pod")
+	recorder.Event(pod, corev1.EventTypeWarning, "Failed", "failed")
+	// The events are also emitted.
+	assert.Len(t, fakeRecorder.Events, 2)
+
+	journal := recorder.MergeJournal(cluster.UID, nil)
+	assert.Equal(t, []rayv1.JournalEntry{{
+		Time:    metav1.NewTime(start),
+		Type:    corev1.EventTypeNormal,
+		Action:  "CreatedPod",
+		Message: "Created worker Pod pod",
+	}}, journal)
+	// The entries already in the journal aren't merged again, e.g. after the status failed to be updated.
+	assert.Equal(t, journal, recorder.MergeJournal(cluster.UID, journal))
+
+	// The journal keeps the last entries, from the oldest to the newest.
+	for i := 0; i < MaxJournalEntries; i++ {
+		fakeClock.SetTime(fakeClock.Now().Add(time.Second))
+		recorder.Eventf(cluster, corev1.EventTypeNormal, "DeletedPod", "Deleted worker Pod %d", i)
+	}
+	journal = recorder.MergeJournal(cluster.UID, journal)
+	assert.Len(t, journal, MaxJournalEntries)
+	assert.Equal(t, "Deleted worker Pod 0", journal[0].Message)
+	assert.Equal(t, fmt.Sprintf("Deleted worker Pod %d", MaxJournalEntries-1), journal[MaxJournalEntries-1].Message)
+
+	// The entries are forgotten once they expire.
+	fakeClock.SetTime(fakeClock.Now().Add(pendingJournalEntryTTL))
+	recorder.Event(cluster, corev1.EventTypeNormal, "CreatedPod", "Created worker Pod pod")
+	assert.Len(t, recorder.pending, 1)
+	assert.Len(t, recorder.pending[cluster.UID], 1)
+
+	// A nil recorder returns the journal unchanged.
+	var nilRecorder *JournalEventRecorder
+	assert.Equal(t, journal, nilRecorder.MergeJournal(cluster.UID, journal))
+}
@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/clock"
+)
+
+// DefaultEventDeduplicationWindow is how long an identical warning event isn't recorded again.
+const DefaultEventDeduplicationWindow = 5 * time.Minute
+
+type eventKey struct {
+	uid     types.UID
+	reason  string
+	message string
+}
+
+// DeduplicatingEventRecorder is an EventRecorder that drops a warning event if an identical event, with the same
+// object, reason, and message, was recorded within the window. The reconcile loops retry failed operations every few
+// seconds, so without it large clusters flood etcd with the same warnings. Normal events are always recorded.
+type DeduplicatingEventRecorder struct {
+	record.EventRecorder
+	clock    clock.PassiveClock
+	lastSeen map[eventKey]time.Time
+	window   time.Duration
+	mu       sync.Mutex
+}
+
+var _ record.EventRecorder = &DeduplicatingEventRecorder{}
+
+// NewDeduplicatingEventRecorder wraps the recorder so that identical warning events are recorded at most once per window.
+func NewDeduplicatingEventRecorder(recorder record.EventRecorder, window time.Duration) *DeduplicatingEventRecorder {
+	return newDeduplicatingEventRecorderWithClock(recorder, window, clock.RealClock{})
+}
+
+func newDeduplicatingEventRecorderWithClock(recorder record.EventRecorder, window time.Duration, clock clock.PassiveClock) *DeduplicatingEventRecorder {
+	return &DeduplicatingEventRecorder{
+		EventRecorder: recorder,
+		clock:         clock,
+		lastSeen:      map[eventKey]time.Time{},
+		window:        window,
+	}
+}
+
+func (r *DeduplicatingEventRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	if r.isDuplicate(object, eventtype, reason, message) {
+		return
+	}
+	r.EventRecorder.Event(object, eventtype, reason, message)
+}
+
+func (r *DeduplicatingEventRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	r.Event(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+func (r *DeduplicatingEventRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	message := fmt.Sprintf(messageFmt, args...)
+	if r.isDuplicate(object, eventtype, reason, message) {
+		return
+	}
+	r.EventRecorder.AnnotatedEventf(object, annotations, eventtype, reason, "%s", message)
+}
+
+// isDuplicate returns whether an identical warning event was recorded within the window, and remembers the event
+// otherwise. The events older than the window are forgotten, so the memory is bounded by the rate of distinct events.
+func (r *DeduplicatingEventRecorder) isDuplicate(object runtime.Object, eventtype, reason, message string) bool {
+	if eventtype != corev1.EventTypeWarning {
+		return false
+	}
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return false
+	}
+	key := eventKey{uid: accessor.GetUID(), reason: reason, message: message}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.clock.Now()
+	for k, seen := range r.lastSeen {
+		if now.Sub(seen) >= r.window {
+			delete(r.lastSeen, k)
+		}
+	}
+	if _, ok := r.lastSeen[key]; ok {
+		return true
+	}
+	r.lastSeen[key] = now
+	return false
+}
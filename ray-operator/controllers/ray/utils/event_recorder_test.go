@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func TestDeduplicatingEventRecorder(t *testing.T) {
+	fakeRecorder := record.NewFakeRecorder(10)
+	fakeClock := clocktesting.NewFakePassiveClock(time.Now())
+	recorder := newDeduplicatingEventRecorderWithClock(fakeRecorder, time.Minute, fakeClock)
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", UID: "uid-1"}}
+	otherPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "other-pod", UID: "uid-2"}}
+
+	recorder.Eventf(pod, corev1.EventTypeWarning, "Failed", "failed %d", 1)
+	// Identical warning events are dropped within the window.
+	recorder.Eventf(pod, corev1.EventTypeWarning, "Failed", "failed %d", 1)
+	recorder.Event(pod, corev1.EventTypeWarning, "Failed", "failed 1")
+	// Events with another object, reason, or message are recorded.
+	recorder.Eventf(otherPod, corev1.EventTypeWarning, "Failed", "failed %d", 1)
+	recorder.Eventf(pod, corev1.EventTypeWarning, "Failed", "failed %d", 2)
+	// Normal events are never dropped.
+	recorder.Event(pod, corev1.EventTypeNormal, "Created", "created")
+	recorder.Event(pod, corev1.EventTypeNormal, "Created", "created")
+	assert.Len(t, fakeRecorder.Events, 5)
+
+	// The event is recorded again once the window has passed.
+	fakeClock.SetTime(fakeClock.Now().Add(time.Minute))
+	recorder.Eventf(pod, corev1.EventTypeWarning, "Failed", "failed %d", 1)
+	assert.Len(t, fakeRecorder.Events, 6)
+	assert.Len(t, recorder.lastSeen, 1)
+}
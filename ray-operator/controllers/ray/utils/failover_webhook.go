@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// The states of a RayService reported to the webhook of its failover policy.
+const (
+	FailoverStateFailedOver = "FailedOver"
+	FailoverStateRecovered  = "Recovered"
+)
+
+// failoverWebhookClient sends the failover notifications. The timeout bounds the time a reconciliation waits for the
+// webhook.
+var failoverWebhookClient = &http.Client{Timeout: 10 * time.Second}
+
+// FailoverNotification is the JSON body POSTed to the webhook of the failover policy of a RayService.
+type FailoverNotification struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// State is FailedOver when the RayService fails over, and Recovered when it is ready again.
+	State   string `json:"state"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+	// Time is when KubeRay observed the transition.
+	Time time.Time `json:"time"`
+}
+
+// SendFailoverNotification POSTs the notification to the webhook URL. A response whose status isn't 2xx is an error.
+func SendFailoverNotification(ctx context.Context, url string, notification FailoverNotification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := failoverWebhookClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("the failover webhook returned the status %d: %s", resp.StatusCode, string(respBody))
+	}
+	return nil
+}
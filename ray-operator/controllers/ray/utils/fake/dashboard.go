@@ -0,0 +1,351 @@
+package fake
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// ServeStatusStep is a step of a Serve status timeline.
+type ServeStatusStep struct {
+	// Applications are the Serve applications reported by the dashboard during the step.
+	Applications map[string]*utils.ServeApplicationStatus
+	// Polls is the number of GetMultiApplicationStatus and GetServeDetails calls that the step lasts. The last step
+	// of a timeline lasts forever.
+	Polls int
+}
+
+// RayDashboardClient is a scriptable fake of the Ray dashboard client. Its zero value reports no Serve
+// applications, no jobs, and succeeds every call.
+type RayDashboardClient struct {
+	Script
+
+	jobs            map[string]*utils.RayJobInfo
+	clusterStatus   *utils.RayClusterStatusInfo
+	drainedNodes    map[string]time.Time
+	url             string
+	timeline        []ServeStatusStep
+	deployedConfigs [][]byte
+	aliveActors     []utils.RayActorInfo
+	aliveNodes      []utils.RayNodeInfo
+	runningTasks    []utils.RayTaskInfo
+	events          []utils.RayClusterEventInfo
+	polls           int
+	mu              sync.Mutex
+}
+
+var _ utils.RayDashboardClientInterface = (*RayDashboardClient)(nil)
+
+// NewRayDashboardClient returns a fake dashboard client that reports the Serve applications.
+func NewRayDashboardClient(applications map[string]*utils.ServeApplicationStatus) *RayDashboardClient {
+	client := &RayDashboardClient{}
+	client.SetServeStatusTimeline(ServeStatusStep{Applications: applications})
+	return client
+}
+
+// ServeApplications returns the statuses of Serve applications with the names and the status, each with a deployment
+// that is HEALTHY if the status is RUNNING, or UNHEALTHY otherwise.
+func ServeApplications(status string, names ...string) map[string]*utils.ServeApplicationStatus {
+	deploymentStatus := rayv1.DeploymentStatusEnum.HEALTHY
+	if status != rayv1.ApplicationStatusEnum.RUNNING {
+		deploymentStatus = rayv1.DeploymentStatusEnum.UNHEALTHY
+	}
+	applications := make(map[string]*utils.ServeApplicationStatus, len(names))
+	for _, name := range names {
+		applications[name] = &utils.ServeApplicationStatus{
+			Name:   name,
+			Status: status,
+			Deployments: map[string]utils.ServeDeploymentStatus{
+				"deployment": {Name: "deployment", Status: deploymentStatus},
+			},
+		}
+	}
+	return applications
+}
+
+// SetServeStatusTimeline replaces the Serve status timeline and restarts it from its first step. For example, the
+// following timeline simulates an upgrade whose application fails to deploy after 3 polls:
+//
+//	client.SetServeStatusTimeline(
+//		fake.ServeStatusStep{Applications: fake.ServeApplications("DEPLOYING", "app"), Polls: 3},
+//		fake.ServeStatusStep{Applications: fake.ServeApplications("DEPLOY_FAILED", "app")},
+//	)
+func (r *RayDashboardClient) SetServeStatusTimeline(steps ...ServeStatusStep) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.timeline = steps
+	r.polls = 0
+}
+
+// pollServeApplications returns the Serve applications of the current step of the timeline and advances it.
+func (r *RayDashboardClient) pollServeApplications() map[string]*utils.ServeApplicationStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	poll := r.polls
+	r.polls++
+	for i, step := range r.timeline {
+		if poll < step.Polls || i == len(r.timeline)-1 {
+			return step.Applications
+		}
+		poll -= step.Polls
+	}
+	return nil
+}
+
+// URL returns the dashboard URL that the client was initialized with.
+func (r *RayDashboardClient) URL() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.url
+}
+
+// DeployedConfigs returns the Serve configs passed to the successful UpdateDeployments calls, in order.
+func (r *RayDashboardClient) DeployedConfigs() [][]byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([][]byte(nil), r.deployedConfigs...)
+}
+
+// SetJobInfo sets the job returned by GetJobInfo and ListJobs for its submission ID.
+func (r *RayDashboardClient) SetJobInfo(info utils.RayJobInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.jobs == nil {
+		r.jobs = map[string]*utils.RayJobInfo{}
+	}
+	r.jobs[info.SubmissionId] = &info
+}
+
+// SetClusterStatus sets the status returned by GetClusterStatus.
+func (r *RayDashboardClient) SetClusterStatus(status *utils.RayClusterStatusInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.clusterStatus = status
+}
+
+// SetAliveActors sets the actors returned by ListAliveActors.
+func (r *RayDashboardClient) SetAliveActors(actors []utils.RayActorInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.aliveActors = actors
+}
+
+// SetAliveNodes sets the nodes returned by ListAliveNodes.
+func (r *RayDashboardClient) SetAliveNodes(nodes []utils.RayNodeInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.aliveNodes = nodes
+}
+
+// SetRunningTasks sets the tasks returned by ListRunningTasks.
+func (r *RayDashboardClient) SetRunningTasks(tasks []utils.RayTaskInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.runningTasks = tasks
+}
+
+// SetAutoscalerEvents sets the events returned by ListAutoscalerEvents.
+func (r *RayDashboardClient) SetAutoscalerEvents(events []utils.RayClusterEventInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = events
+}
+
+// DrainedNodes returns the deadlines of the nodes requested to be drained, keyed by the node ID.
+func (r *RayDashboardClient) DrainedNodes() map[string]time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	drainedNodes := make(map[string]time.Time, len(r.drainedNodes))
+	for nodeID, deadline := range r.drainedNodes {
+		drainedNodes[nodeID] = deadline
+	}
+	return drainedNodes
+}
+
+func (r *RayDashboardClient) InitClient(_ context.Context, url string, _ *rayv1.RayCluster) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.url = url
+	return nil
+}
+
+func (r *RayDashboardClient) UpdateDeployments(ctx context.Context, configJson []byte) error {
+	if err := r.call(ctx, UpdateDeployments); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deployedConfigs = append(r.deployedConfigs, append([]byte(nil), configJson...))
+	return nil
+}
+
+func (r *RayDashboardClient) GetServeDetails(ctx context.Context) (*utils.ServeDetails, error) {
+	if err := r.call(ctx, GetServeDetails); err != nil {
+		return nil, err
+	}
+	details := &utils.ServeDetails{Applications: map[string]utils.ServeApplicationDetails{}}
+	for name, application := range r.pollServeApplications() {
+		deployments := make(map[string]utils.ServeDeploymentDetails, len(application.Deployments))
+		for deploymentName, deployment := range application.Deployments {
+			deployments[deploymentName] = utils.ServeDeploymentDetails{ServeDeploymentStatus: deployment}
+		}
+		details.Applications[name] = utils.ServeApplicationDetails{
+			ServeApplicationStatus: *application,
+			Deployments:            deployments,
+		}
+	}
+	return details, nil
+}
+
+func (r *RayDashboardClient) GetMultiApplicationStatus(ctx context.Context) (map[string]*utils.ServeApplicationStatus, error) {
+	if err := r.call(ctx, GetMultiApplicationStatus); err != nil {
+		return nil, err
+	}
+	return r.pollServeApplications(), nil
+}
+
+func (r *RayDashboardClient) GetJobInfo(ctx context.Context, jobId string) (*utils.RayJobInfo, error) {
+	if err := r.call(ctx, GetJobInfo); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if info, ok := r.jobs[jobId]; ok {
+		info := *info
+		return &info, nil
+	}
+	// The real client returns a BadRequest error when the dashboard responds with 404.
+	return nil, errors.NewBadRequest("Job " + jobId + " does not exist on the cluster")
+}
+
+func (r *RayDashboardClient) ListJobs(ctx context.Context) (*[]utils.RayJobInfo, error) {
+	if err := r.call(ctx, ListJobs); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	jobs := make([]utils.RayJobInfo, 0, len(r.jobs))
+	for _, info := range r.jobs {
+		jobs = append(jobs, *info)
+	}
+	return &jobs, nil
+}
+
+func (r *RayDashboardClient) SubmitJob(ctx context.Context, rayJob *rayv1.RayJob) (string, error) {
+	request, err := utils.ConvertRayJobToReq(rayJob)
+	if err != nil {
+		return "", err
+	}
+	return r.SubmitJobReq(ctx, request, &rayJob.Name)
+}
+
+func (r *RayDashboardClient) SubmitJobReq(ctx context.Context, request *utils.RayJobRequest, _ *string) (string, error) {
+	if err := r.call(ctx, SubmitJob); err != nil {
+		return "", err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.jobs == nil {
+		r.jobs = map[string]*utils.RayJobInfo{}
+	}
+	r.jobs[request.SubmissionId] = &utils.RayJobInfo{
+		SubmissionId: request.SubmissionId,
+		Entrypoint:   request.Entrypoint,
+		Metadata:     request.Metadata,
+		JobStatus:    rayv1.JobStatusPending,
+	}
+	return request.SubmissionId, nil
+}
+
+func (r *RayDashboardClient) GetJobLog(ctx context.Context, _ string) (*string, error) {
+	if err := r.call(ctx, GetJobLog); err != nil {
+		return nil, err
+	}
+	log := ""
+	return &log, nil
+}
+
+func (r *RayDashboardClient) StopJob(ctx context.Context, jobName string) error {
+	if err := r.call(ctx, StopJob); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if info, ok := r.jobs[jobName]; ok && !rayv1.IsJobTerminal(info.JobStatus) {
+		info.JobStatus = rayv1.JobStatusStopped
+	}
+	return nil
+}
+
+func (r *RayDashboardClient) DeleteJob(ctx context.Context, jobName string) error {
+	if err := r.call(ctx, DeleteJob); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.jobs, jobName)
+	return nil
+}
+
+func (r *RayDashboardClient) GetClusterStatus(ctx context.Context) (*utils.RayClusterStatusInfo, error) {
+	if err := r.call(ctx, GetClusterStatus); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.clusterStatus, nil
+}
+
+func (r *RayDashboardClient) ListAliveActors(ctx context.Context) ([]utils.RayActorInfo, error) {
+	if err := r.call(ctx, ListAliveActors); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.aliveActors, nil
+}
+
+func (r *RayDashboardClient) ListAliveNodes(ctx context.Context) ([]utils.RayNodeInfo, error) {
+	if err := r.call(ctx, ListAliveNodes); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.aliveNodes, nil
+}
+
+func (r *RayDashboardClient) ListRunningTasks(ctx context.Context) ([]utils.RayTaskInfo, error) {
+	if err := r.call(ctx, ListRunningTasks); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.runningTasks, nil
+}
+
+func (r *RayDashboardClient) ListAutoscalerEvents(ctx context.Context) ([]utils.RayClusterEventInfo, error) {
+	if err := r.call(ctx, ListAutoscalerEvents); err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.events, nil
+}
+
+func (r *RayDashboardClient) DrainNode(ctx context.Context, nodeID string, _ string, deadline time.Time) error {
+	if err := r.call(ctx, DrainNode); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.drainedNodes == nil {
+		r.drainedNodes = map[string]time.Time{}
+	}
+	r.drainedNodes[nodeID] = deadline
+	return nil
+}
@@ -0,0 +1,173 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func TestScriptFailures(t *testing.T) {
+	ctx := context.Background()
+	errUnavailable := errors.New("unavailable")
+	errTimeout := errors.New("timeout")
+	script := &Script{}
+
+	script.FailNext(UpdateDeployments, errUnavailable, nil, errTimeout)
+	script.SetError(UpdateDeployments, errUnavailable)
+	require.ErrorIs(t, script.call(ctx, UpdateDeployments), errUnavailable)
+	require.NoError(t, script.call(ctx, UpdateDeployments))
+	require.ErrorIs(t, script.call(ctx, UpdateDeployments), errTimeout)
+	// The sticky error is returned once the queue is drained.
+	require.ErrorIs(t, script.call(ctx, UpdateDeployments), errUnavailable)
+	script.SetError(UpdateDeployments, nil)
+	require.NoError(t, script.call(ctx, UpdateDeployments))
+
+	// Other operations are not affected.
+	require.NoError(t, script.call(ctx, GetServeDetails))
+	assert.Equal(t, 5, script.Calls(UpdateDeployments))
+	assert.Equal(t, 1, script.Calls(GetServeDetails))
+	assert.Equal(t, 0, script.Calls(DrainNode))
+}
+
+func TestScriptLatency(t *testing.T) {
+	script := &Script{}
+	script.SetLatency(GetMultiApplicationStatus, 20*time.Millisecond)
+
+	start := time.Now()
+	require.NoError(t, script.call(context.Background(), GetMultiApplicationStatus))
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+	// The latency is cut short by the context.
+	script.SetLatency(GetMultiApplicationStatus, time.Hour)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, script.call(ctx, GetMultiApplicationStatus), context.DeadlineExceeded)
+}
+
+func TestRayDashboardClientServeStatusTimeline(t *testing.T) {
+	ctx := context.Background()
+	client := &RayDashboardClient{}
+	statuses, err := client.GetMultiApplicationStatus(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, statuses)
+
+	client.SetServeStatusTimeline(
+		ServeStatusStep{Applications: ServeApplications(rayv1.ApplicationStatusEnum.DEPLOYING, "app"), Polls: 2},
+		ServeStatusStep{Applications: ServeApplications(rayv1.ApplicationStatusEnum.DEPLOY_FAILED, "app")},
+	)
+	var observed []string
+	for i := 0; i < 4; i++ {
+		statuses, err := client.GetMultiApplicationStatus(ctx)
+		require.NoError(t, err)
+		observed = append(observed, statuses["app"].Status)
+	}
+	assert.Equal(t, []string{"DEPLOYING", "DEPLOYING", "DEPLOY_FAILED", "DEPLOY_FAILED"}, observed)
+
+	// GetServeDetails follows the same timeline.
+	client.SetServeStatusTimeline(ServeStatusStep{Applications: ServeApplications(rayv1.ApplicationStatusEnum.RUNNING, "app")})
+	details, err := client.GetServeDetails(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, rayv1.ApplicationStatusEnum.RUNNING, details.Applications["app"].Status)
+	assert.Equal(t, rayv1.DeploymentStatusEnum.HEALTHY, details.Applications["app"].Deployments["deployment"].Status)
+}
+
+func TestRayDashboardClientUpdateDeployments(t *testing.T) {
+	ctx := context.Background()
+	client := NewRayDashboardClient(nil)
+	client.FailNext(UpdateDeployments, errors.New("dashboard is not ready"))
+
+	require.Error(t, client.UpdateDeployments(ctx, []byte(`{"applications": []}`)))
+	require.NoError(t, client.UpdateDeployments(ctx, []byte(`{"applications": [{"name": "app"}]}`)))
+	assert.Equal(t, [][]byte{[]byte(`{"applications": [{"name": "app"}]}`)}, client.DeployedConfigs())
+}
+
+func TestRayDashboardClientJobs(t *testing.T) {
+	ctx := context.Background()
+	client := &RayDashboardClient{}
+	_, err := client.GetJobInfo(ctx, "missing")
+	require.Error(t, err)
+
+	rayJob := &rayv1.RayJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "job"},
+		Spec:       rayv1.RayJobSpec{Entrypoint: "python script.py"},
+		Status:     rayv1.RayJobStatus{JobId: "job-id"},
+	}
+	jobID, err := client.SubmitJob(ctx, rayJob)
+	require.NoError(t, err)
+	assert.Equal(t, "job-id", jobID)
+	info, err := client.GetJobInfo(ctx, jobID)
+	require.NoError(t, err)
+	assert.Equal(t, rayv1.JobStatusPending, info.JobStatus)
+
+	client.SetJobInfo(utils.RayJobInfo{SubmissionId: jobID, JobStatus: rayv1.JobStatusRunning})
+	require.NoError(t, client.StopJob(ctx, jobID))
+	info, err = client.GetJobInfo(ctx, jobID)
+	require.NoError(t, err)
+	assert.Equal(t, rayv1.JobStatusStopped, info.JobStatus)
+
+	require.NoError(t, client.DeleteJob(ctx, jobID))
+	jobs, err := client.ListJobs(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, *jobs)
+}
+
+func TestRayHttpProxyClient(t *testing.T) {
+	ctx := context.Background()
+	client := &RayHttpProxyClient{}
+	require.NoError(t, client.CheckProxyActorHealth(ctx, nil))
+	client.SetHealthy(false)
+	require.Error(t, client.CheckProxyActorHealth(ctx, nil))
+	client.SetHealthy(true)
+	require.NoError(t, client.CheckProxyActorHealth(ctx, nil))
+
+	client.SetHostIp("10.0.0.1", "default", "head", 8000)
+	hostIP, port := client.Address()
+	assert.Equal(t, "10.0.0.1", hostIP)
+	assert.Equal(t, 8000, port)
+}
+
+func TestClientProviderRoutesByRayClusterAndPod(t *testing.T) {
+	ctx := context.Background()
+	provider := &ClientProvider{
+		NewRayDashboardClient: func(_ types.NamespacedName) *RayDashboardClient {
+			return NewRayDashboardClient(ServeApplications(rayv1.ApplicationStatusEnum.RUNNING, "app"))
+		},
+	}
+	active := types.NamespacedName{Namespace: "default", Name: "active"}
+	pending := types.NamespacedName{Namespace: "default", Name: "pending"}
+	// Script the upgrade of the pending RayCluster to fail before the controllers use its client.
+	provider.RayDashboardClient(pending).SetServeStatusTimeline(ServeStatusStep{Applications: ServeApplications(rayv1.ApplicationStatusEnum.DEPLOY_FAILED, "app")})
+
+	newDashboardClient := provider.GetDashboardClient(nil)
+	for cluster, expected := range map[types.NamespacedName]string{
+		active:  rayv1.ApplicationStatusEnum.RUNNING,
+		pending: rayv1.ApplicationStatusEnum.DEPLOY_FAILED,
+	} {
+		client := newDashboardClient()
+		rayCluster := &rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Namespace: cluster.Namespace, Name: cluster.Name}}
+		require.NoError(t, client.InitClient(ctx, cluster.Name+"-head-svc:8265", rayCluster))
+		statuses, err := client.GetMultiApplicationStatus(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, expected, statuses["app"].Status, cluster.Name)
+	}
+	assert.Equal(t, "active-head-svc:8265", provider.RayDashboardClient(active).URL())
+	assert.Equal(t, 1, provider.RayDashboardClient(pending).Calls(GetMultiApplicationStatus))
+
+	provider.RayHttpProxyClient(types.NamespacedName{Namespace: "default", Name: "pending-head"}).SetHealthy(false)
+	proxyClient := provider.GetHttpProxyClient(nil)()
+	proxyClient.InitClient()
+	proxyClient.SetHostIp("10.0.0.1", "default", "active-head", 8000)
+	require.NoError(t, proxyClient.CheckProxyActorHealth(ctx, nil))
+	proxyClient = provider.GetHttpProxyClient(nil)()
+	proxyClient.SetHostIp("10.0.0.2", "default", "pending-head", 8000)
+	require.Error(t, proxyClient.CheckProxyActorHealth(ctx, nil))
+}
@@ -0,0 +1,83 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// RayHttpProxyClient is a scriptable fake of the Ray HTTP proxy client. Its zero value is healthy.
+type RayHttpProxyClient struct {
+	Script
+
+	hostIP       string
+	probes       []rayv1.ServeProbe
+	healthChecks []*rayv1.ServeProxyHealthCheck
+	port         int
+	mu           sync.Mutex
+}
+
+var _ utils.RayHttpProxyClientInterface = (*RayHttpProxyClient)(nil)
+
+// SetHealthy makes the proxy actor health checks succeed, or fail with a generic error.
+func (fc *RayHttpProxyClient) SetHealthy(healthy bool) {
+	var err error
+	if !healthy {
+		err = fmt.Errorf("fake proxy actor is not healthy")
+	}
+	fc.SetError(CheckProxyActorHealth, err)
+}
+
+// Address returns the host IP and the port that the client was pointed to by SetHostIp.
+func (fc *RayHttpProxyClient) Address() (string, int) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return fc.hostIP, fc.port
+}
+
+// Probes returns the Serve probes sent by the successful ProbeServeEndpoint calls, in order.
+func (fc *RayHttpProxyClient) Probes() []rayv1.ServeProbe {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return append([]rayv1.ServeProbe(nil), fc.probes...)
+}
+
+// HealthChecks returns the health checks passed to the successful CheckProxyActorHealth calls, in order.
+func (fc *RayHttpProxyClient) HealthChecks() []*rayv1.ServeProxyHealthCheck {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return append([]*rayv1.ServeProxyHealthCheck(nil), fc.healthChecks...)
+}
+
+func (fc *RayHttpProxyClient) InitClient() {}
+
+func (fc *RayHttpProxyClient) SetHostIp(hostIp, _, _ string, port int) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.hostIP, fc.port = hostIp, port
+}
+
+func (fc *RayHttpProxyClient) CheckProxyActorHealth(ctx context.Context, healthCheck *rayv1.ServeProxyHealthCheck) error {
+	if err := fc.call(ctx, CheckProxyActorHealth); err != nil {
+		return err
+	}
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	fc.healthChecks = append(fc.healthChecks, healthCheck)
+	return nil
+}
+
+func (fc *RayHttpProxyClient) ProbeServeEndpoint(ctx context.Context, probe *rayv1.ServeProbe) error {
+	if err := fc.call(ctx, ProbeServeEndpoint); err != nil {
+		return err
+	}
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	if probe != nil {
+		fc.probes = append(fc.probes, *probe)
+	}
+	return nil
+}
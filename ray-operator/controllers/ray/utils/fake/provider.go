@@ -0,0 +1,105 @@
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// ClientProvider implements utils.ClientProvider with fake clients. Each RayCluster gets its own fake dashboard
+// client and each head Pod its own fake HTTP proxy client, so that, for example, the pending RayCluster of a
+// RayService upgrade can fail while the active one keeps serving.
+type ClientProvider struct {
+	// NewRayDashboardClient creates the fake dashboard client of a RayCluster the first time it's used. By default,
+	// the fake reports no Serve applications.
+	NewRayDashboardClient func(cluster types.NamespacedName) *RayDashboardClient
+	// NewRayHttpProxyClient creates the fake HTTP proxy client of a head Pod the first time it's used. By default, the
+	// fake is healthy.
+	NewRayHttpProxyClient func(pod types.NamespacedName) *RayHttpProxyClient
+
+	dashboards map[types.NamespacedName]*RayDashboardClient
+	proxies    map[types.NamespacedName]*RayHttpProxyClient
+	mu         sync.Mutex
+}
+
+var _ utils.ClientProvider = (*ClientProvider)(nil)
+
+// RayDashboardClient returns the fake dashboard client of the RayCluster, so that a test can script it before or
+// after the controllers use it.
+func (p *ClientProvider) RayDashboardClient(cluster types.NamespacedName) *RayDashboardClient {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if client, ok := p.dashboards[cluster]; ok {
+		return client
+	}
+	client := &RayDashboardClient{}
+	if p.NewRayDashboardClient != nil {
+		client = p.NewRayDashboardClient(cluster)
+	}
+	if p.dashboards == nil {
+		p.dashboards = map[types.NamespacedName]*RayDashboardClient{}
+	}
+	p.dashboards[cluster] = client
+	return client
+}
+
+// RayHttpProxyClient returns the fake HTTP proxy client of the head Pod, so that a test can script it before or after
+// the controllers use it.
+func (p *ClientProvider) RayHttpProxyClient(pod types.NamespacedName) *RayHttpProxyClient {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if client, ok := p.proxies[pod]; ok {
+		return client
+	}
+	client := &RayHttpProxyClient{}
+	if p.NewRayHttpProxyClient != nil {
+		client = p.NewRayHttpProxyClient(pod)
+	}
+	if p.proxies == nil {
+		p.proxies = map[types.NamespacedName]*RayHttpProxyClient{}
+	}
+	p.proxies[pod] = client
+	return client
+}
+
+func (p *ClientProvider) GetDashboardClient(_ manager.Manager) func() utils.RayDashboardClientInterface {
+	return func() utils.RayDashboardClientInterface {
+		return &dashboardClientRouter{provider: p}
+	}
+}
+
+func (p *ClientProvider) GetHttpProxyClient(_ manager.Manager) func() utils.RayHttpProxyClientInterface {
+	return func() utils.RayHttpProxyClientInterface {
+		return &httpProxyClientRouter{provider: p}
+	}
+}
+
+// dashboardClientRouter forwards the calls to the fake dashboard client of the RayCluster passed to InitClient, like
+// the real client sends them to the dashboard of that RayCluster.
+type dashboardClientRouter struct {
+	*RayDashboardClient
+	provider *ClientProvider
+}
+
+func (r *dashboardClientRouter) InitClient(ctx context.Context, url string, rayCluster *rayv1.RayCluster) error {
+	r.RayDashboardClient = r.provider.RayDashboardClient(types.NamespacedName{Namespace: rayCluster.Namespace, Name: rayCluster.Name})
+	return r.RayDashboardClient.InitClient(ctx, url, rayCluster)
+}
+
+// httpProxyClientRouter forwards the calls to the fake HTTP proxy client of the Pod passed to SetHostIp.
+type httpProxyClientRouter struct {
+	*RayHttpProxyClient
+	provider *ClientProvider
+}
+
+func (r *httpProxyClientRouter) InitClient() {}
+
+func (r *httpProxyClientRouter) SetHostIp(hostIp, podNamespace, podName string, port int) {
+	r.RayHttpProxyClient = r.provider.RayHttpProxyClient(types.NamespacedName{Namespace: podNamespace, Name: podName})
+	r.RayHttpProxyClient.SetHostIp(hostIp, podNamespace, podName, port)
+}
@@ -0,0 +1,108 @@
+// Package fake provides scriptable fakes of the Ray dashboard and HTTP proxy clients, so that controller integrators
+// and e2e tests can simulate slow or failing Ray clusters and Serve upgrades deterministically.
+package fake
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Operation is a method of the fake clients whose behavior can be scripted.
+type Operation string
+
+const (
+	UpdateDeployments         Operation = "UpdateDeployments"
+	GetServeDetails           Operation = "GetServeDetails"
+	GetMultiApplicationStatus Operation = "GetMultiApplicationStatus"
+	GetJobInfo                Operation = "GetJobInfo"
+	ListJobs                  Operation = "ListJobs"
+	SubmitJob                 Operation = "SubmitJob"
+	GetJobLog                 Operation = "GetJobLog"
+	StopJob                   Operation = "StopJob"
+	DeleteJob                 Operation = "DeleteJob"
+	GetClusterStatus          Operation = "GetClusterStatus"
+	ListAliveActors           Operation = "ListAliveActors"
+	ListAliveNodes            Operation = "ListAliveNodes"
+	ListRunningTasks          Operation = "ListRunningTasks"
+	ListAutoscalerEvents      Operation = "ListAutoscalerEvents"
+	DrainNode                 Operation = "DrainNode"
+	CheckProxyActorHealth     Operation = "CheckProxyActorHealth"
+	ProbeServeEndpoint        Operation = "ProbeServeEndpoint"
+)
+
+// Script programs the latencies and the failures of the operations of a fake client, and counts its calls. It's safe
+// for concurrent use.
+type Script struct {
+	latencies map[Operation]time.Duration
+	failures  map[Operation][]error
+	errors    map[Operation]error
+	calls     map[Operation]int
+	mu        sync.Mutex
+}
+
+// SetLatency delays every call of the operation by the latency, or until the context of the call is done.
+func (s *Script) SetLatency(operation Operation, latency time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.latencies == nil {
+		s.latencies = map[Operation]time.Duration{}
+	}
+	s.latencies[operation] = latency
+}
+
+// FailNext queues the errors to be returned by the next calls of the operation, one per call in order. A nil error
+// lets its call succeed, so `FailNext(op, err, nil, err)` fails every other call. Once the queue is drained, the calls
+// return the error set by SetError.
+func (s *Script) FailNext(operation Operation, errs ...error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failures == nil {
+		s.failures = map[Operation][]error{}
+	}
+	s.failures[operation] = append(s.failures[operation], errs...)
+}
+
+// SetError makes every call of the operation return the error, after the errors queued by FailNext. A nil error
+// makes the calls succeed again.
+func (s *Script) SetError(operation Operation, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.errors == nil {
+		s.errors = map[Operation]error{}
+	}
+	s.errors[operation] = err
+}
+
+// Calls returns the number of calls of the operation, including the failed ones.
+func (s *Script) Calls(operation Operation) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls[operation]
+}
+
+// call records a call of the operation, waits for its latency, and returns its scripted error.
+func (s *Script) call(ctx context.Context, operation Operation) error {
+	s.mu.Lock()
+	if s.calls == nil {
+		s.calls = map[Operation]int{}
+	}
+	s.calls[operation]++
+	latency := s.latencies[operation]
+	err := s.errors[operation]
+	if queue := s.failures[operation]; len(queue) > 0 {
+		err, s.failures[operation] = queue[0], queue[1:]
+	}
+	s.mu.Unlock()
+
+	if latency > 0 {
+		timer := time.NewTimer(latency)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return err
+}
@@ -3,19 +3,32 @@ package utils
 import (
 	"context"
 	"fmt"
+	"slices"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 )
 
 type FakeRayHttpProxyClient struct {
-	IsHealthy bool
+	ServeProbeErr error
+	// UnhealthyPods are the Pods whose proxy actor is unhealthy even if IsHealthy is true.
+	UnhealthyPods []string
+	podName       string
+	IsHealthy     bool
 }
 
 func (fc *FakeRayHttpProxyClient) InitClient() {}
 
-func (fc *FakeRayHttpProxyClient) SetHostIp(_, _, _ string, _ int) {}
+func (fc *FakeRayHttpProxyClient) SetHostIp(_, _, podName string, _ int) {
+	fc.podName = podName
+}
 
-func (fc *FakeRayHttpProxyClient) CheckProxyActorHealth(_ context.Context) error {
-	if !fc.IsHealthy {
+func (fc *FakeRayHttpProxyClient) CheckProxyActorHealth(_ context.Context, _ *rayv1.ServeProxyHealthCheck) error {
+	if !fc.IsHealthy || slices.Contains(fc.UnhealthyPods, fc.podName) {
 		return fmt.Errorf("fake proxy actor is not healthy")
 	}
 	return nil
 }
+
+func (fc *FakeRayHttpProxyClient) ProbeServeEndpoint(_ context.Context, _ *rayv1.ServeProbe) error {
+	return fc.ServeProbeErr
+}
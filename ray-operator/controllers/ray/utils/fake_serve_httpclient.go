@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"sync/atomic"
+	"time"
 
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 )
@@ -12,6 +13,12 @@ import (
 type FakeRayDashboardClient struct {
 	multiAppStatuses map[string]*ServeApplicationStatus
 	GetJobInfoMock   atomic.Pointer[func(context.Context, string) (*RayJobInfo, error)]
+	clusterStatus    *RayClusterStatusInfo
+	aliveActors      []RayActorInfo
+	aliveNodes       []RayNodeInfo
+	runningTasks     []RayTaskInfo
+	autoscalerEvents []RayClusterEventInfo
+	drainedNodes     map[string]time.Time
 	BaseDashboardClient
 	serveDetails ServeDetails
 }
@@ -79,3 +86,56 @@ func (r *FakeRayDashboardClient) StopJob(_ context.Context, _ string) (err error
 func (r *FakeRayDashboardClient) DeleteJob(_ context.Context, _ string) error {
 	return nil
 }
+
+func (r *FakeRayDashboardClient) GetClusterStatus(_ context.Context) (*RayClusterStatusInfo, error) {
+	return r.clusterStatus, nil
+}
+
+func (r *FakeRayDashboardClient) SetClusterStatus(status *RayClusterStatusInfo) {
+	r.clusterStatus = status
+}
+
+func (r *FakeRayDashboardClient) ListAliveActors(_ context.Context) ([]RayActorInfo, error) {
+	return r.aliveActors, nil
+}
+
+func (r *FakeRayDashboardClient) SetAliveActors(actors []RayActorInfo) {
+	r.aliveActors = actors
+}
+
+func (r *FakeRayDashboardClient) ListAliveNodes(_ context.Context) ([]RayNodeInfo, error) {
+	return r.aliveNodes, nil
+}
+
+func (r *FakeRayDashboardClient) SetAliveNodes(nodes []RayNodeInfo) {
+	r.aliveNodes = nodes
+}
+
+func (r *FakeRayDashboardClient) ListRunningTasks(_ context.Context) ([]RayTaskInfo, error) {
+	return r.runningTasks, nil
+}
+
+func (r *FakeRayDashboardClient) SetRunningTasks(tasks []RayTaskInfo) {
+	r.runningTasks = tasks
+}
+
+func (r *FakeRayDashboardClient) ListAutoscalerEvents(_ context.Context) ([]RayClusterEventInfo, error) {
+	return r.autoscalerEvents, nil
+}
+
+func (r *FakeRayDashboardClient) SetAutoscalerEvents(events []RayClusterEventInfo) {
+	r.autoscalerEvents = events
+}
+
+func (r *FakeRayDashboardClient) DrainNode(_ context.Context, nodeID string, _ string, deadline time.Time) error {
+	if r.drainedNodes == nil {
+		r.drainedNodes = map[string]time.Time{}
+	}
+	r.drainedNodes[nodeID] = deadline
+	return nil
+}
+
+// GetDrainedNodes returns the deadlines of the nodes requested to be drained, keyed by the node ID.
+func (r *FakeRayDashboardClient) GetDrainedNodes() map[string]time.Time {
+	return r.drainedNodes
+}
@@ -4,23 +4,34 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 )
 
 type RayHttpProxyClientInterface interface {
 	InitClient()
-	CheckProxyActorHealth(ctx context.Context) error
+	CheckProxyActorHealth(ctx context.Context, healthCheck *rayv1.ServeProxyHealthCheck) error
+	ProbeServeEndpoint(ctx context.Context, probe *rayv1.ServeProbe) error
 	SetHostIp(hostIp, podNamespace, podName string, port int)
 }
 
-func GetRayHttpProxyClientFunc(mgr ctrl.Manager, useKubernetesProxy bool) func() RayHttpProxyClientInterface {
+// GetRayHttpProxyClientFunc returns a constructor of HTTP proxy clients. The fields of defaultHealthCheck, the health
+// check of the operator configuration, apply to the health checks that don't set them.
+func GetRayHttpProxyClientFunc(mgr ctrl.Manager, useKubernetesProxy bool, defaultHealthCheck *rayv1.ServeProxyHealthCheck) func() RayHttpProxyClientInterface {
 	return func() RayHttpProxyClientInterface {
 		return &RayHttpProxyClient{
 			mgr:                mgr,
 			useKubernetesProxy: useKubernetesProxy,
+			defaultHealthCheck: defaultHealthCheck,
 		}
 	}
 }
@@ -28,6 +39,7 @@ func GetRayHttpProxyClientFunc(mgr ctrl.Manager, useKubernetesProxy bool) func()
 type RayHttpProxyClient struct {
 	client             *http.Client
 	mgr                ctrl.Manager
+	defaultHealthCheck *rayv1.ServeProxyHealthCheck
 	httpProxyURL       string
 	useKubernetesProxy bool
 }
@@ -42,28 +54,156 @@ func (r *RayHttpProxyClient) SetHostIp(hostIp, podNamespace, podName string, por
 	if r.useKubernetesProxy {
 		r.client = r.mgr.GetHTTPClient()
 		r.httpProxyURL = fmt.Sprintf("%s/api/v1/namespaces/%s/pods/%s:%d/proxy/", r.mgr.GetConfig().Host, podNamespace, podName, port)
+		return
+	}
+
+	// JoinHostPort brackets IPv6 Pod IPs.
+	r.httpProxyURL = fmt.Sprintf("http://%s/", net.JoinHostPort(hostIp, strconv.Itoa(port)))
+}
+
+// CheckProxyActorHealth checks the health status of the Ray Serve proxy actor. Each attempt has its own timeout, and
+// the failed attempts are retried up to the number of retries of the health check.
+func (r *RayHttpProxyClient) CheckProxyActorHealth(ctx context.Context, healthCheck *rayv1.ServeProxyHealthCheck) error {
+	healthCheck = MergeServeProxyHealthCheck(r.defaultHealthCheck, healthCheck)
+	path := RayServeProxyHealthPath
+	if healthCheck.Path != nil {
+		path = strings.TrimPrefix(*healthCheck.Path, "/")
+	}
+	timeout := time.Duration(DefaultServeProxyHealthCheckTimeoutSeconds) * time.Second
+	if healthCheck.TimeoutSeconds != nil {
+		timeout = time.Duration(*healthCheck.TimeoutSeconds) * time.Second
+	}
+	expectedStatusCodes := healthCheck.ExpectedStatusCodes
+	if len(expectedStatusCodes) == 0 {
+		expectedStatusCodes = []int32{http.StatusOK}
 	}
 
-	r.httpProxyURL = fmt.Sprintf("http://%s:%d/", hostIp, port)
+	// The timeout of the health check replaces the default timeout of the client.
+	client := *r.client
+	client.Timeout = timeout
+	var err error
+	for attempt := int32(0); attempt <= ptr.Deref(healthCheck.Retries, 0); attempt++ {
+		if err = r.checkProxyActorHealth(ctx, &client, path, expectedStatusCodes); err == nil || ctx.Err() != nil {
+			return err
+		}
+	}
+	return err
 }
 
-// CheckProxyActorHealth checks the health status of the Ray Serve proxy actor.
-func (r *RayHttpProxyClient) CheckProxyActorHealth(ctx context.Context) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.httpProxyURL+RayServeProxyHealthPath, nil)
+func (r *RayHttpProxyClient) checkProxyActorHealth(ctx context.Context, client *http.Client, path string, expectedStatusCodes []int32) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.httpProxyURL+path, nil)
 	if err != nil {
 		return err
 	}
-	resp, err := r.client.Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
 
 	body, _ := io.ReadAll(resp.Body)
-	if resp.StatusCode != 200 {
+	if !slices.Contains(expectedStatusCodes, int32(resp.StatusCode)) { //nolint:gosec // HTTP status codes fit in an int32.
 		err := fmt.Errorf("CheckProxyActorHealth fails. status code: %d, status: %s, body: %s", resp.StatusCode, resp.Status, string(body))
 		return err
 	}
 
 	return nil
 }
+
+// MergeServeProxyHealthCheck returns the health check whose fields are the ones of override, or the ones of base if
+// they aren't set in override. The result is never nil.
+func MergeServeProxyHealthCheck(base, override *rayv1.ServeProxyHealthCheck) *rayv1.ServeProxyHealthCheck {
+	merged := &rayv1.ServeProxyHealthCheck{}
+	for _, healthCheck := range []*rayv1.ServeProxyHealthCheck{base, override} {
+		if healthCheck == nil {
+			continue
+		}
+		if healthCheck.Path != nil {
+			merged.Path = healthCheck.Path
+		}
+		if healthCheck.TimeoutSeconds != nil {
+			merged.TimeoutSeconds = healthCheck.TimeoutSeconds
+		}
+		if healthCheck.Retries != nil {
+			merged.Retries = healthCheck.Retries
+		}
+		if len(healthCheck.ExpectedStatusCodes) > 0 {
+			merged.ExpectedStatusCodes = healthCheck.ExpectedStatusCodes
+		}
+	}
+	return merged
+}
+
+// ValidateServeProxyHealthCheck checks the fields of the health check that the CRD validation doesn't cover, since the
+// health check of the operator configuration isn't validated by the CRD.
+func ValidateServeProxyHealthCheck(healthCheck *rayv1.ServeProxyHealthCheck) error {
+	if healthCheck == nil {
+		return nil
+	}
+	if healthCheck.Path != nil && !strings.HasPrefix(*healthCheck.Path, "/") {
+		return fmt.Errorf("path %q must start with /", *healthCheck.Path)
+	}
+	if healthCheck.TimeoutSeconds != nil && *healthCheck.TimeoutSeconds < 1 {
+		return fmt.Errorf("timeoutSeconds must be at least 1, got %d", *healthCheck.TimeoutSeconds)
+	}
+	if healthCheck.Retries != nil && (*healthCheck.Retries < 0 || *healthCheck.Retries > 10) {
+		return fmt.Errorf("retries must be between 0 and 10, got %d", *healthCheck.Retries)
+	}
+	for _, code := range healthCheck.ExpectedStatusCodes {
+		if code < 100 || code > 599 {
+			return fmt.Errorf("expected status code %d is invalid", code)
+		}
+	}
+	return nil
+}
+
+// ProbeServeEndpoint sends the request of the ServeProbe to the Ray Serve proxy. It fails if the response doesn't have
+// the expected status code or isn't received within the latency threshold.
+func (r *RayHttpProxyClient) ProbeServeEndpoint(ctx context.Context, probe *rayv1.ServeProbe) error {
+	method := http.MethodGet
+	var reqBody io.Reader
+	if probe.Body != nil {
+		method = http.MethodPost
+		reqBody = strings.NewReader(*probe.Body)
+	}
+	if probe.Method != nil {
+		method = *probe.Method
+	}
+	expectedStatusCode := http.StatusOK
+	if probe.ExpectedStatusCode != nil {
+		expectedStatusCode = int(*probe.ExpectedStatusCode)
+	}
+	latencyThreshold := time.Duration(DefaultServeProbeLatencyThresholdMilliseconds) * time.Millisecond
+	if probe.LatencyThresholdMilliseconds != nil {
+		latencyThreshold = time.Duration(*probe.LatencyThresholdMilliseconds) * time.Millisecond
+	}
+
+	// The latency threshold replaces the default timeout of the client.
+	ctx, cancel := context.WithTimeout(ctx, latencyThreshold)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, method, r.httpProxyURL+strings.TrimPrefix(probe.Path, "/"), reqBody)
+	if err != nil {
+		return err
+	}
+	for key, value := range probe.Headers {
+		req.Header.Set(key, value)
+	}
+
+	client := *r.client
+	client.Timeout = 0
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("ProbeServeEndpoint fails. %s %s: %w", method, probe.Path, err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if latency := time.Since(start); latency > latencyThreshold {
+		return fmt.Errorf("ProbeServeEndpoint fails. %s %s: latency %v exceeds the threshold %v", method, probe.Path, latency, latencyThreshold)
+	}
+	if resp.StatusCode != expectedStatusCode {
+		return fmt.Errorf("ProbeServeEndpoint fails. %s %s: status code: %d, expected status code: %d, body: %s", method, probe.Path, resp.StatusCode, expectedStatusCode, string(body))
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package utils
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestProbeServeEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/app/predict":
+			body, _ := io.ReadAll(r.Body)
+			if r.Method != http.MethodPost || string(body) != `{"x": 1}` || r.Header.Get("Content-Type") != "application/json" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		case "/app/slow":
+			time.Sleep(100 * time.Millisecond)
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := &RayHttpProxyClient{}
+	client.InitClient()
+	client.httpProxyURL = server.URL + "/"
+	ctx := context.Background()
+
+	// The method defaults to POST if the body is set.
+	err := client.ProbeServeEndpoint(ctx, &rayv1.ServeProbe{
+		Path:    "/app/predict",
+		Body:    ptr.To(`{"x": 1}`),
+		Headers: map[string]string{"Content-Type": "application/json"},
+	})
+	assert.NoError(t, err)
+
+	err = client.ProbeServeEndpoint(ctx, &rayv1.ServeProbe{Path: "/app/predict", Method: ptr.To(http.MethodGet)})
+	assert.ErrorContains(t, err, "status code: 400")
+
+	err = client.ProbeServeEndpoint(ctx, &rayv1.ServeProbe{Path: "/not-found", ExpectedStatusCode: ptr.To[int32](http.StatusNotFound)})
+	assert.NoError(t, err)
+
+	err = client.ProbeServeEndpoint(ctx, &rayv1.ServeProbe{Path: "/app/slow", LatencyThresholdMilliseconds: ptr.To[int32](10)})
+	assert.Error(t, err)
+	err = client.ProbeServeEndpoint(ctx, &rayv1.ServeProbe{Path: "/app/slow"})
+	assert.NoError(t, err)
+}
+
+func TestCheckProxyActorHealth(t *testing.T) {
+	var flakyRequests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/-/healthz":
+			w.WriteHeader(http.StatusOK)
+		case "/custom/healthz":
+			w.WriteHeader(http.StatusNoContent)
+		case "/flaky":
+			flakyRequests++
+			if flakyRequests%3 != 0 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := &RayHttpProxyClient{}
+	client.InitClient()
+	client.httpProxyURL = server.URL + "/"
+	ctx := context.Background()
+
+	assert.NoError(t, client.CheckProxyActorHealth(ctx, nil))
+
+	healthCheck := &rayv1.ServeProxyHealthCheck{Path: ptr.To("/custom/healthz")}
+	assert.ErrorContains(t, client.CheckProxyActorHealth(ctx, healthCheck), "status code: 204")
+	healthCheck.ExpectedStatusCodes = []int32{http.StatusOK, http.StatusNoContent}
+	assert.NoError(t, client.CheckProxyActorHealth(ctx, healthCheck))
+
+	// The third attempt succeeds.
+	healthCheck = &rayv1.ServeProxyHealthCheck{Path: ptr.To("/flaky"), Retries: ptr.To[int32](1)}
+	assert.Error(t, client.CheckProxyActorHealth(ctx, healthCheck))
+	flakyRequests = 0
+	healthCheck.Retries = ptr.To[int32](2)
+	assert.NoError(t, client.CheckProxyActorHealth(ctx, healthCheck))
+	assert.Equal(t, 3, flakyRequests)
+
+	// The health check of the operator configuration applies to the fields that aren't set.
+	client.defaultHealthCheck = &rayv1.ServeProxyHealthCheck{Path: ptr.To("/custom/healthz"), ExpectedStatusCodes: []int32{http.StatusNoContent}}
+	assert.NoError(t, client.CheckProxyActorHealth(ctx, nil))
+	assert.ErrorContains(t, client.CheckProxyActorHealth(ctx, &rayv1.ServeProxyHealthCheck{Path: ptr.To("/-/healthz")}), "status code: 200")
+}
+
+func TestMergeServeProxyHealthCheck(t *testing.T) {
+	assert.Equal(t, &rayv1.ServeProxyHealthCheck{}, MergeServeProxyHealthCheck(nil, nil))
+
+	base := &rayv1.ServeProxyHealthCheck{
+		Path:                ptr.To("/healthz"),
+		TimeoutSeconds:      ptr.To[int32](5),
+		ExpectedStatusCodes: []int32{200},
+	}
+	override := &rayv1.ServeProxyHealthCheck{
+		TimeoutSeconds: ptr.To[int32](10),
+		Retries:        ptr.To[int32](3),
+	}
+	assert.Equal(t, &rayv1.ServeProxyHealthCheck{
+		Path:                ptr.To("/healthz"),
+		TimeoutSeconds:      ptr.To[int32](10),
+		Retries:             ptr.To[int32](3),
+		ExpectedStatusCodes: []int32{200},
+	}, MergeServeProxyHealthCheck(base, override))
+}
+
+func TestValidateServeProxyHealthCheck(t *testing.T) {
+	assert.NoError(t, ValidateServeProxyHealthCheck(nil))
+	assert.NoError(t, ValidateServeProxyHealthCheck(&rayv1.ServeProxyHealthCheck{
+		Path:                ptr.To("/-/healthz"),
+		TimeoutSeconds:      ptr.To[int32](5),
+		Retries:             ptr.To[int32](3),
+		ExpectedStatusCodes: []int32{200, 204},
+	}))
+	assert.Error(t, ValidateServeProxyHealthCheck(&rayv1.ServeProxyHealthCheck{Path: ptr.To("healthz")}))
+	assert.Error(t, ValidateServeProxyHealthCheck(&rayv1.ServeProxyHealthCheck{TimeoutSeconds: ptr.To[int32](0)}))
+	assert.Error(t, ValidateServeProxyHealthCheck(&rayv1.ServeProxyHealthCheck{Retries: ptr.To[int32](11)}))
+	assert.Error(t, ValidateServeProxyHealthCheck(&rayv1.ServeProxyHealthCheck{ExpectedStatusCodes: []int32{600}}))
+}
+
+func TestSetHostIp(t *testing.T) {
+	client := &RayHttpProxyClient{}
+	client.SetHostIp("10.0.0.1", "default", "head", 8000)
+	assert.Equal(t, "http://10.0.0.1:8000/", client.httpProxyURL)
+
+	// IPv6 Pod IPs are bracketed.
+	client.SetHostIp("fd00::1", "default", "head", 8000)
+	assert.Equal(t, "http://[fd00::1]:8000/", client.httpProxyURL)
+}
@@ -0,0 +1,126 @@
+package utils
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/yaml"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+// MaxLogVerbosity is the highest verbosity of the logs of KubeRay. V(1) logs the specs and statuses that are too large
+// to log at every reconciliation, and V(2) logs the Serve configs sent to the Ray dashboard.
+const MaxLogVerbosity = 2
+
+// RedactedValue replaces the values that may be secrets in the logs.
+const RedactedValue = "<redacted>"
+
+// VerbositySink is a logr.LogSink that drops the logs above its verbosity. The operator logger is created with the
+// verbosity of the `--log-verbosity` flag, and the reconcilers change it for the custom resources with the
+// `ray.io/log-verbosity` annotation.
+type VerbositySink struct {
+	logr.LogSink
+	verbosity int
+}
+
+var (
+	_ logr.LogSink          = &VerbositySink{}
+	_ logr.CallDepthLogSink = &VerbositySink{}
+)
+
+// NewVerbositySink wraps the sink so that only the logs up to the verbosity are written. The sink itself should be
+// enabled up to MaxLogVerbosity.
+func NewVerbositySink(sink logr.LogSink, verbosity int) *VerbositySink {
+	return &VerbositySink{LogSink: sink, verbosity: min(max(verbosity, 0), MaxLogVerbosity)}
+}
+
+func (s *VerbositySink) Enabled(level int) bool {
+	return level <= s.verbosity && s.LogSink.Enabled(level)
+}
+
+func (s *VerbositySink) WithValues(keysAndValues ...interface{}) logr.LogSink {
+	return &VerbositySink{LogSink: s.LogSink.WithValues(keysAndValues...), verbosity: s.verbosity}
+}
+
+func (s *VerbositySink) WithName(name string) logr.LogSink {
+	return &VerbositySink{LogSink: s.LogSink.WithName(name), verbosity: s.verbosity}
+}
+
+func (s *VerbositySink) WithCallDepth(depth int) logr.LogSink {
+	if sink, ok := s.LogSink.(logr.CallDepthLogSink); ok {
+		return &VerbositySink{LogSink: sink.WithCallDepth(depth), verbosity: s.verbosity}
+	}
+	return s
+}
+
+// WithLogVerbosity returns a context whose logger has the verbosity of the `ray.io/log-verbosity` annotation of the
+// object, so that a single custom resource can be debugged without raising the verbosity of the whole operator, or
+// silenced if it's too noisy.
+func WithLogVerbosity(ctx context.Context, obj metav1.Object) context.Context {
+	value, ok := obj.GetAnnotations()[RayLogVerbosityAnnotationKey]
+	if !ok {
+		return ctx
+	}
+	logger := ctrl.LoggerFrom(ctx)
+	verbosity, err := strconv.Atoi(value)
+	if err != nil {
+		logger.Info("Ignore the invalid log verbosity annotation", "annotation", RayLogVerbosityAnnotationKey, "value", value)
+		return ctx
+	}
+	sink, ok := logger.GetSink().(*VerbositySink)
+	if !ok {
+		return ctx
+	}
+	return ctrl.LoggerInto(ctx, logger.WithSink(NewVerbositySink(sink.LogSink, verbosity)))
+}
+
+// RedactRayClusterSpec returns a copy of the RayCluster spec without the values of the environment variables of its
+// containers, which often hold credentials, so that the spec can be logged.
+func RedactRayClusterSpec(spec *rayv1.RayClusterSpec) *rayv1.RayClusterSpec {
+	redacted := spec.DeepCopy()
+	redactPodSpec(&redacted.HeadGroupSpec.Template.Spec)
+	for i := range redacted.WorkerGroupSpecs {
+		redactPodSpec(&redacted.WorkerGroupSpecs[i].Template.Spec)
+	}
+	return redacted
+}
+
+func redactPodSpec(podSpec *corev1.PodSpec) {
+	for _, containers := range [][]corev1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for i := range containers {
+			for j := range containers[i].Env {
+				if containers[i].Env[j].Value != "" {
+					containers[i].Env[j].Value = RedactedValue
+				}
+			}
+		}
+	}
+}
+
+// RedactServeConfigV2 returns the Serve config without the values of the `env_vars` of the runtime environments of
+// the applications, so that it can be logged.
+func RedactServeConfigV2(serveConfigV2 string) string {
+	serveConfig := map[string]interface{}{}
+	if err := yaml.Unmarshal([]byte(serveConfigV2), &serveConfig); err != nil {
+		return RedactedValue
+	}
+	applications, _ := serveConfig["applications"].([]interface{})
+	for _, application := range applications {
+		app, _ := application.(map[string]interface{})
+		runtimeEnv, _ := app["runtime_env"].(map[string]interface{})
+		envVars, _ := runtimeEnv["env_vars"].(map[string]interface{})
+		for name := range envVars {
+			envVars[name] = RedactedValue
+		}
+	}
+	redacted, err := yaml.Marshal(serveConfig)
+	if err != nil {
+		return RedactedValue
+	}
+	return string(redacted)
+}
@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/logr/funcr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestWithLogVerbosity(t *testing.T) {
+	var lines []string
+	sink := funcr.New(func(prefix, args string) { lines = append(lines, args) }, funcr.Options{Verbosity: MaxLogVerbosity}).GetSink()
+	logger := logr.New(NewVerbositySink(sink, 0)).WithValues("controller", "raycluster")
+	ctx := ctrl.LoggerInto(context.Background(), logger)
+
+	logger.V(1).Info("dropped")
+	cluster := &rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{RayLogVerbosityAnnotationKey: "1"}}}
+	debugLogger := ctrl.LoggerFrom(WithLogVerbosity(ctx, cluster))
+	debugLogger.V(1).Info("kept")
+	debugLogger.V(2).Info("dropped")
+	assert.Len(t, lines, 1)
+	assert.Contains(t, lines[0], `"msg"="kept"`)
+	assert.Contains(t, lines[0], `"controller"="raycluster"`)
+
+	// The annotation can also lower the verbosity, and invalid values are ignored.
+	ctx = ctrl.LoggerInto(context.Background(), logr.New(NewVerbositySink(sink, 1)))
+	cluster.Annotations[RayLogVerbosityAnnotationKey] = "0"
+	assert.False(t, ctrl.LoggerFrom(WithLogVerbosity(ctx, cluster)).V(1).Enabled())
+	cluster.Annotations[RayLogVerbosityAnnotationKey] = "debug"
+	assert.Equal(t, ctx, WithLogVerbosity(ctx, cluster))
+}
+
+func TestRedactRayClusterSpec(t *testing.T) {
+	spec := &rayv1.RayClusterSpec{
+		HeadGroupSpec: rayv1.HeadGroupSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Env: []corev1.EnvVar{
+							{Name: "AWS_SECRET_ACCESS_KEY", Value: "secret"},
+							{Name: "TOKEN", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{Key: "token"}}},
+						},
+					}},
+				},
+			},
+		},
+		WorkerGroupSpecs: []rayv1.WorkerGroupSpec{{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{{Env: []corev1.EnvVar{{Name: "PASSWORD", Value: "secret"}}}},
+				},
+			},
+		}},
+	}
+
+	redacted := RedactRayClusterSpec(spec)
+	headEnv := redacted.HeadGroupSpec.Template.Spec.Containers[0].Env
+	assert.Equal(t, RedactedValue, headEnv[0].Value)
+	assert.Equal(t, spec.HeadGroupSpec.Template.Spec.Containers[0].Env[1], headEnv[1])
+	assert.Equal(t, RedactedValue, redacted.WorkerGroupSpecs[0].Template.Spec.InitContainers[0].Env[0].Value)
+	// The spec itself is not modified.
+	assert.Equal(t, "secret", spec.HeadGroupSpec.Template.Spec.Containers[0].Env[0].Value)
+}
+
+func TestRedactServeConfigV2(t *testing.T) {
+	serveConfigV2 := `
+applications:
+  - name: app
+    import_path: app:deployment
+    runtime_env:
+      env_vars:
+        API_KEY: secret
+`
+	redacted := RedactServeConfigV2(serveConfigV2)
+	assert.NotContains(t, redacted, "secret")
+	assert.Contains(t, redacted, "API_KEY: <redacted>")
+	assert.Contains(t, redacted, "import_path: app:deployment")
+
+	assert.Equal(t, RedactedValue, RedactServeConfigV2("applications: ["))
+}
@@ -8,11 +8,27 @@ package utils
 // be returned by the GetMultiApplicationStatus method of the dashboard client
 // Describes the status of a deployment
 type ServeDeploymentStatus struct {
-	Name    string `json:"name,omitempty"`
-	Status  string `json:"status,omitempty"`
-	Message string `json:"message,omitempty"`
+	Name     string                `json:"name,omitempty"`
+	Status   string                `json:"status,omitempty"`
+	Message  string                `json:"message,omitempty"`
+	Replicas []ServeReplicaDetails `json:"replicas,omitempty"`
 }
 
+// Describes a replica of a deployment, including the Ray node on which it is placed
+type ServeReplicaDetails struct {
+	ReplicaID  string  `json:"replica_id,omitempty"`
+	State      string  `json:"state,omitempty"`
+	NodeID     string  `json:"node_id,omitempty"`
+	NodeIP     string  `json:"node_ip,omitempty"`
+	StartTimeS float64 `json:"start_time_s,omitempty"`
+}
+
+// ServeReplicaStartingState is the state of a replica whose actor is being started
+const ServeReplicaStartingState = "STARTING"
+
+// ServeReplicaRunningState is the state of a replica that serves requests
+const ServeReplicaRunningState = "RUNNING"
+
 // Describes the status of an application
 type ServeApplicationStatus struct {
 	Deployments map[string]ServeDeploymentStatus `json:"deployments"`
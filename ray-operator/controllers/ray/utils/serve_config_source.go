@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// serveConfigSourceClient fetches the Serve configs from the serveConfigSource of the RayServices.
+var serveConfigSourceClient = &http.Client{Timeout: 30 * time.Second}
+
+// ServeConfigSourceResponse is a Serve config fetched from the serveConfigSource of a RayService.
+type ServeConfigSourceResponse struct {
+	Content string
+	// ETag is the ETag header of the response, if any.
+	ETag string
+	// ContentHash is the SHA-256 hash of the content.
+	ContentHash string
+}
+
+// FetchServeConfig fetches the Serve config at the URL. The authorization is sent as the Authorization header if it
+// isn't empty. If etag isn't empty, it is sent as the If-None-Match header, and nil is returned if the Serve config
+// didn't change.
+func FetchServeConfig(ctx context.Context, url, authorization, etag string) (*ServeConfigSourceResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	resp, err := serveConfigSourceClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if etag != "" && resp.StatusCode == http.StatusNotModified {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("the serve config source returned the status %d", resp.StatusCode)
+	}
+	// One more byte than the limit is read to detect the Serve configs that are too large.
+	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxServeConfigV2Size+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(body) > MaxServeConfigV2Size {
+		return nil, fmt.Errorf("the Serve config exceeds the limit of %d bytes", MaxServeConfigV2Size)
+	}
+	hash := sha256.Sum256(body)
+	return &ServeConfigSourceResponse{
+		Content:     string(body),
+		ETag:        resp.Header.Get("ETag"),
+		ContentHash: hex.EncodeToString(hash[:]),
+	}, nil
+}
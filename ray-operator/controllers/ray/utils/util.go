@@ -1,18 +1,28 @@
 package utils
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"crypto/sha1" //nolint:gosec // We are not using this for security purposes
 	"encoding/base32"
 	"fmt"
+	"io"
 	"math"
+	"net"
 	"os"
 	"reflect"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
 
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
@@ -20,19 +30,31 @@ import (
 	"k8s.io/apimachinery/pkg/util/json"
 
 	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/util/wait"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 )
 
 const (
 	RayClusterSuffix    = "-raycluster-"
+	DebugClusterSuffix  = "-debug"
 	ServeName           = "serve"
 	ClusterDomainEnvKey = "CLUSTER_DOMAIN"
 	DefaultDomainName   = "cluster.local"
+
+	// MaxRayClusterNameLength is the maximum length of a RayCluster name, because the name is used as a label value.
+	MaxRayClusterNameLength = 63
+	// nameHashLength is the length of the hash suffix of truncated names.
+	nameHashLength = 5
 )
 
 // TODO (kevin85421): Define CRDType here rather than constant.go to avoid circular dependency.
@@ -57,8 +79,167 @@ func GetCRDType(key string) CRDType {
 	return RayClusterCRD
 }
 
+// clusterDomainName is the cluster domain set with the --cluster-domain flag of the operator.
+var clusterDomainName string
+
+// SetClusterDomainName sets the cluster domain of the operator. It takes precedence over the CLUSTER_DOMAIN env.
+func SetClusterDomainName(domain string) {
+	clusterDomainName = domain
+}
+
+// serviceAccountNamespaceFile is the file of the namespace of the service account mounted in the Pods.
+const serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// GetOperatorNamespace returns the namespace of the operator: the POD_NAMESPACE env if it's set, or the namespace of
+// the service account of the operator Pod. It defaults to "default" when the operator runs outside of Kubernetes.
+func GetOperatorNamespace() string {
+	if namespace := os.Getenv("POD_NAMESPACE"); namespace != "" {
+		return namespace
+	}
+	if data, err := os.ReadFile(serviceAccountNamespaceFile); err == nil {
+		if namespace := strings.TrimSpace(string(data)); namespace != "" {
+			return namespace
+		}
+	}
+	return "default"
+}
+
+// fastDeletion is set with the --fast-deletion flag of the operator.
+var fastDeletion bool
+
+// SetFastDeletion enables the fast deletion of the custom resources in all the namespaces.
+func SetFastDeletion(enabled bool) {
+	fastDeletion = enabled
+}
+
+// IsFastDeletionEnabled returns whether the custom resources in the namespace are deleted without the graceful paths:
+// the Redis cleanup of GCS fault tolerance, the drain of the Ray Pods, the stop of the Ray jobs, and the wait for the
+// objects created for RayJobs and RayServices to be deleted. It's enabled in all the namespaces with the
+// --fast-deletion flag, or in a namespace with the RayFastDeletionAnnotationKey annotation set to "true". The
+// annotation is ignored if the namespace can't be read, e.g. when the operator isn't allowed to read Namespaces.
+func IsFastDeletionEnabled(ctx context.Context, c client.Reader, namespace string) bool {
+	if fastDeletion {
+		return true
+	}
+	ns := &corev1.Namespace{}
+	if err := c.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		ctrl.LoggerFrom(ctx).Info("Failed to get the namespace, fast deletion is disabled", "namespace", namespace, "error", err)
+		return false
+	}
+	return ns.Annotations[RayFastDeletionAnnotationKey] == "true"
+}
+
+const (
+	// RayServiceDefaultRequeueInterval is the default interval between the reconciliations of a RayService.
+	RayServiceDefaultRequeueInterval = 2 * time.Second
+	// RayServiceRequeueJitterFactor is the maximum fraction of the interval added to the intervals between the
+	// reconciliations of a RayService.
+	RayServiceRequeueJitterFactor = 0.5
+)
+
+// rayServiceRequeueInterval and rayServiceStableRequeueInterval are set with the --rayservice-requeue-interval and
+// --rayservice-stable-requeue-interval flags of the operator.
+var rayServiceRequeueInterval, rayServiceStableRequeueInterval time.Duration
+
+// SetRayServiceRequeueIntervals sets the intervals between the reconciliations of the RayServices. The interval is
+// used while the RayServices are created, upgraded or restarted, and the stable interval once their Serve
+// applications are running. A zero interval defaults to RayServiceDefaultRequeueInterval, and a zero stable interval
+// to the interval.
+func SetRayServiceRequeueIntervals(interval, stableInterval time.Duration) {
+	rayServiceRequeueInterval = interval
+	rayServiceStableRequeueInterval = stableInterval
+}
+
+// GetRayServiceRequeueInterval returns the interval until the next reconciliation of a RayService, jittered so that
+// the RayServices reconciled together, e.g. after a restart of the operator, don't poll their Ray dashboards at the
+// same time.
+func GetRayServiceRequeueInterval(stable bool) time.Duration {
+	interval := rayServiceRequeueInterval
+	if interval <= 0 {
+		interval = RayServiceDefaultRequeueInterval
+	}
+	if stable && rayServiceStableRequeueInterval > 0 {
+		interval = rayServiceStableRequeueInterval
+	}
+	return wait.Jitter(interval, RayServiceRequeueJitterFactor)
+}
+
+// InFlightWriteTimeout bounds the writes that aren't interrupted when the operator shuts down. It's shorter than the
+// default graceful shutdown timeout of the operator, so the writes finish before the operator exits.
+const InFlightWriteTimeout = 10 * time.Second
+
+// WithoutShutdownCancel returns the context of a write that shouldn't be interrupted when the operator shuts down, e.g.
+// the submission of a Serve config or a status update. The context isn't canceled with ctx, and times out after
+// InFlightWriteTimeout instead.
+func WithoutShutdownCancel(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(ctx), InFlightWriteTimeout)
+}
+
+// watchNamespaceSelector is set with the --watch-namespace-selector flag of the operator.
+var watchNamespaceSelector labels.Selector
+
+// SetWatchNamespaceSelector sets the label selector of the namespaces whose custom resources are reconciled. All the
+// watched namespaces are reconciled if the selector is empty.
+func SetWatchNamespaceSelector(selector string) error {
+	if selector == "" {
+		watchNamespaceSelector = nil
+		return nil
+	}
+	parsed, err := labels.Parse(selector)
+	if err != nil {
+		return fmt.Errorf("invalid watch namespace selector %q: %w", selector, err)
+	}
+	watchNamespaceSelector = parsed
+	return nil
+}
+
+// IsWatchNamespaceSelectorSet returns whether the custom resources are only reconciled in the selected namespaces.
+func IsWatchNamespaceSelectorSet() bool {
+	return watchNamespaceSelector != nil
+}
+
+// IsNamespaceSelected returns whether the custom resources in the namespace are reconciled. The labels of the namespace
+// are matched against the --watch-namespace-selector flag at every reconciliation rather than at startup, so that
+// namespaces are onboarded to KubeRay, or offboarded from it, by labeling them without restarting the operator.
+func IsNamespaceSelected(ctx context.Context, c client.Reader, namespace string) (bool, error) {
+	if watchNamespaceSelector == nil {
+		return true, nil
+	}
+	ns := &corev1.Namespace{}
+	if err := c.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		return false, err
+	}
+	return watchNamespaceSelector.Matches(labels.Set(ns.Labels)), nil
+}
+
+// EnqueueObjectsInNamespace returns the handler of the events of Namespaces that enqueues the custom resources of the
+// type of the list in the namespace, so that they're reconciled once the namespace is selected by its labels.
+func EnqueueObjectsInNamespace(c client.Reader, list client.ObjectList) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		objects := list.DeepCopyObject().(client.ObjectList)
+		if err := c.List(ctx, objects, client.InNamespace(obj.GetName())); err != nil {
+			ctrl.LoggerFrom(ctx).Error(err, "Failed to list the custom resources of the namespace", "namespace", obj.GetName())
+			return nil
+		}
+		items, err := meta.ExtractList(objects)
+		if err != nil {
+			return nil
+		}
+		requests := make([]reconcile.Request, 0, len(items))
+		for _, item := range items {
+			if o, ok := item.(client.Object); ok {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(o)})
+			}
+		}
+		return requests
+	})
+}
+
 // GetClusterDomainName returns cluster's domain name
 func GetClusterDomainName() string {
+	if len(clusterDomainName) > 0 {
+		return clusterDomainName
+	}
 	if domain := os.Getenv(ClusterDomainEnvKey); len(domain) > 0 {
 		return domain
 	}
@@ -205,6 +386,19 @@ func CheckName(s string) string {
 	return s
 }
 
+// TruncateNameWithHash shortens the name to maxLength characters if it is longer. The name is truncated at the end
+// and suffixed with a hash of the full name, so that long names sharing the same prefix are still distinct and the
+// same name always results in the same truncated name.
+func TruncateNameWithHash(name string, maxLength int) string {
+	if len(name) <= maxLength {
+		return name
+	}
+	hashBytes := sha1.Sum([]byte(name)) //nolint:gosec // We are not using this for security purposes
+	hash := strings.ToLower(base32.HexEncoding.EncodeToString(hashBytes[:]))[:nameHashLength]
+	prefix := strings.TrimRight(name[:maxLength-nameHashLength-1], "-.")
+	return prefix + "-" + hash
+}
+
 // CheckLabel makes sure the label value does not start with a punctuation and the total length is < 63 char
 func CheckLabel(s string) string {
 	maxLenght := 63
@@ -242,8 +436,8 @@ func GetNamespace(metaData metav1.ObjectMeta) string {
 
 // GenerateHeadServiceName generates a Ray head service name. Note that there are two types of head services:
 //
-// (1) For RayCluster: If `HeadService.Name` in the cluster spec is not empty, it will be used as the head service name.
-// Otherwise, the name is generated based on the RayCluster CR's name.
+// (1) For RayCluster: If `ExistingHeadServiceName` or `HeadService.Name` in the cluster spec is not empty, it will be used as
+// the head service name. Otherwise, the name is generated based on the RayCluster CR's name.
 // (2) For RayService: It's important to note that the RayService CR not only possesses a head service owned by its RayCluster CR
 // but also maintains a separate head service for itself to facilitate zero-downtime upgrades. The name of the head service owned
 // by the RayService CR is generated based on the RayService CR's name.
@@ -257,7 +451,9 @@ func GenerateHeadServiceName(crdType CRDType, clusterSpec rayv1.RayClusterSpec,
 		return CheckName(fmt.Sprintf("%s-%s-%s", ownerName, rayv1.HeadNode, "svc")), nil
 	case RayClusterCRD:
 		headSvcName := CheckName(fmt.Sprintf("%s-%s-%s", ownerName, rayv1.HeadNode, "svc"))
-		if clusterSpec.HeadGroupSpec.HeadService != nil && clusterSpec.HeadGroupSpec.HeadService.Name != "" {
+		if clusterSpec.HeadGroupSpec.ExistingHeadServiceName != "" {
+			headSvcName = clusterSpec.HeadGroupSpec.ExistingHeadServiceName
+		} else if clusterSpec.HeadGroupSpec.HeadService != nil && clusterSpec.HeadGroupSpec.HeadService.Name != "" {
 			headSvcName = clusterSpec.HeadGroupSpec.HeadService.Name
 		}
 		return headSvcName, nil
@@ -274,7 +470,19 @@ func GenerateFQDNServiceName(ctx context.Context, cluster rayv1.RayCluster, name
 		log.Error(err, "Failed to generate head service name")
 		return ""
 	}
-	return fmt.Sprintf("%s.%s.svc.%s", headSvcName, namespace, GetClusterDomainName())
+	if cluster.Status.Head.ServiceName != "" {
+		headSvcName = cluster.Status.Head.ServiceName
+	}
+	return fmt.Sprintf("%s.%s.svc.%s", headSvcName, namespace, GetRayClusterDomainName(cluster.Spec))
+}
+
+// GetRayClusterDomainName returns the cluster domain used in the FQDNs of the RayCluster. The ClusterDomain of the
+// RayCluster overrides the cluster domain of the operator.
+func GetRayClusterDomainName(spec rayv1.RayClusterSpec) string {
+	if spec.ClusterDomain != nil && len(*spec.ClusterDomain) > 0 {
+		return *spec.ClusterDomain
+	}
+	return GetClusterDomainName()
 }
 
 // ExtractRayIPFromFQDN extracts the head service name (i.e., RAY_IP, deprecated) from a fully qualified
@@ -283,11 +491,101 @@ func ExtractRayIPFromFQDN(fqdnRayIP string) string {
 	return strings.Split(fqdnRayIP, ".")[0]
 }
 
+// IsPodIP returns whether ip is one of the IPs of the Pod. On dual-stack clusters, a Pod has an IP of each family and
+// Ray may report either of them. The IPs are compared after parsing, so that different forms of an IPv6 address match.
+func IsPodIP(pod *corev1.Pod, ip string) bool {
+	parsedIP := net.ParseIP(ip)
+	if parsedIP == nil {
+		return false
+	}
+	podIPs := []string{pod.Status.PodIP}
+	for _, podIP := range pod.Status.PodIPs {
+		podIPs = append(podIPs, podIP.IP)
+	}
+	return slices.ContainsFunc(podIPs, func(podIP string) bool { return parsedIP.Equal(net.ParseIP(podIP)) })
+}
+
 // GenerateServeServiceName generates name for serve service.
 func GenerateServeServiceName(serviceName string) string {
 	return CheckName(fmt.Sprintf("%s-%s-%s", serviceName, ServeName, "svc"))
 }
 
+// IsPreviewServiceEnabled returns whether the preview serve service is created during the upgrades of the RayService.
+func IsPreviewServiceEnabled(rayService *rayv1.RayService) bool {
+	strategy := rayService.Spec.UpgradeStrategy
+	return strategy != nil && strategy.EnablePreviewService != nil && *strategy.EnablePreviewService
+}
+
+// IsManualPromotionEnabled returns whether the pending RayCluster of the RayService waits for an approval to be promoted.
+func IsManualPromotionEnabled(rayService *rayv1.RayService) bool {
+	strategy := rayService.Spec.UpgradeStrategy
+	return strategy != nil && strategy.Promotion != nil && *strategy.Promotion == rayv1.ManualPromotion
+}
+
+// IsApplicationScopedUpgradeEnabled returns whether the Serve applications of the RayService are upgraded independently.
+func IsApplicationScopedUpgradeEnabled(rayService *rayv1.RayService) bool {
+	strategy := rayService.Spec.UpgradeStrategy
+	return strategy != nil && strategy.ApplicationScoped != nil && *strategy.ApplicationScoped
+}
+
+// IsDashboardDisabled returns whether KubeRay must not use the Ray dashboard of the RayClusters of the RayService.
+func IsDashboardDisabled(rayService *rayv1.RayService) bool {
+	return rayService.Spec.DisableDashboard != nil && *rayService.Spec.DisableDashboard
+}
+
+// IsManagedServeTopologyRouting returns whether KubeRay manages the EndpointSlice of the serve service of the RayService.
+func IsManagedServeTopologyRouting(rayService *rayv1.RayService) bool {
+	routing := rayService.Spec.ServeTopologyRouting
+	return routing != nil && routing.Mode != nil && *routing.Mode == rayv1.ManagedServeTopologyRouting
+}
+
+// IsManualKubeRayVersionPolicy returns whether the updates of the active RayCluster of the RayService caused by upgrades
+// of KubeRay wait for an approval.
+func IsManualKubeRayVersionPolicy(rayService *rayv1.RayService) bool {
+	strategy := rayService.Spec.UpgradeStrategy
+	return strategy != nil && strategy.KubeRayVersionPolicy != nil && *strategy.KubeRayVersionPolicy == rayv1.ManualKubeRayVersionPolicy
+}
+
+// GetRequestShadowing returns the request shadowing of the upgrades of the RayService, or nil if it is disabled.
+func GetRequestShadowing(rayService *rayv1.RayService) *rayv1.RequestShadowing {
+	if strategy := rayService.Spec.UpgradeStrategy; strategy != nil {
+		return strategy.RequestShadowing
+	}
+	return nil
+}
+
+// GenerateHeadStatefulSetName generates the name of the StatefulSet that manages the head Pod of a RayCluster.
+func GenerateHeadStatefulSetName(clusterName string) string {
+	return CheckName(fmt.Sprintf("%s-%s", clusterName, rayv1.HeadNode))
+}
+
+// GeneratePreviewServeServiceName generates the name of the serve service that points at the pending RayCluster.
+func GeneratePreviewServeServiceName(serviceName string) string {
+	return CheckName(fmt.Sprintf("%s-preview-%s-%s", serviceName, ServeName, "svc"))
+}
+
+// GenerateServeHTTPRouteName generates the name of the Gateway API HTTPRoute in front of the serve service.
+func GenerateServeHTTPRouteName(serviceName string) string {
+	return CheckName(fmt.Sprintf("%s-%s-%s", serviceName, ServeName, "route"))
+}
+
+// GeneratePodMonitorName generates the name of the PodMonitor of the head or worker Pods of the RayCluster.
+func GeneratePodMonitorName(clusterName string, nodeType rayv1.RayNodeType) string {
+	return CheckName(fmt.Sprintf("%s-%s-%s", clusterName, nodeType, "monitor"))
+}
+
+// GenerateServeConfigSourceConfigMapName generates the name of the ConfigMap with the Serve config fetched from the
+// serveConfigSource of the RayService.
+func GenerateServeConfigSourceConfigMapName(serviceName string) string {
+	return CheckName(fmt.Sprintf("%s-%s-%s", serviceName, ServeName, "config"))
+}
+
+// GenerateServeAlertsRuleName generates the name of the PrometheusRule with the alerts of the Serve applications of
+// the RayService.
+func GenerateServeAlertsRuleName(serviceName string) string {
+	return CheckName(fmt.Sprintf("%s-%s-%s", serviceName, ServeName, "alerts"))
+}
+
 // GenerateServeServiceLabel generates label value for serve service selector.
 func GenerateServeServiceLabel(serviceName string) string {
 	return fmt.Sprintf("%s-%s", serviceName, ServeName)
@@ -298,14 +596,31 @@ func GenerateIngressName(clusterName string) string {
 	return fmt.Sprintf("%s-%s-%s", clusterName, rayv1.HeadNode, "ingress")
 }
 
+// GenerateDashboardIngressName generates the name of the dashboard Ingress of a RayCluster or a RayService.
+func GenerateDashboardIngressName(name string) string {
+	return CheckName(fmt.Sprintf("%s-%s-%s", name, DashboardPortName, "ingress"))
+}
+
 // GenerateRouteName generates an ingress name from cluster name
 func GenerateRouteName(clusterName string) string {
 	return fmt.Sprintf("%s-%s-%s", clusterName, rayv1.HeadNode, "route")
 }
 
-// GenerateRayClusterName generates a ray cluster name from ray service name
+// GenerateRayClusterName generates a ray cluster name from ray service name. The name of the RayService is truncated
+// so that the RayCluster name fits in a label value.
 func GenerateRayClusterName(serviceName string) string {
-	return fmt.Sprintf("%s%s%s", serviceName, RayClusterSuffix, rand.String(5))
+	return RayClusterNamePrefix(serviceName) + rand.String(5)
+}
+
+// RayClusterNamePrefix returns the prefix shared by the names of the RayClusters of the RayService.
+func RayClusterNamePrefix(serviceName string) string {
+	return TruncateNameWithHash(serviceName, MaxRayClusterNameLength-len(RayClusterSuffix)-5) + RayClusterSuffix
+}
+
+// GenerateDebugRayClusterName generates the name of the debug RayCluster of the RayService. It doesn't share the
+// prefix of the names of the RayClusters that serve the traffic of the RayService.
+func GenerateDebugRayClusterName(serviceName string) string {
+	return TruncateNameWithHash(serviceName, MaxRayClusterNameLength-len(DebugClusterSuffix)) + DebugClusterSuffix
 }
 
 // GenerateRayJobId generates a ray job id for submission
@@ -422,7 +737,7 @@ func CalculateDesiredResources(cluster *rayv1.RayCluster) corev1.ResourceList {
 			desiredResourcesList = append(desiredResourcesList, podResource)
 		}
 	}
-	return sumResourceList(desiredResourcesList)
+	return SumResourceList(desiredResourcesList)
 }
 
 func CalculateMinResources(cluster *rayv1.RayCluster) corev1.ResourceList {
@@ -435,7 +750,34 @@ func CalculateMinResources(cluster *rayv1.RayCluster) corev1.ResourceList {
 			minResourcesList = append(minResourcesList, podResource)
 		}
 	}
-	return sumResourceList(minResourcesList)
+	return SumResourceList(minResourcesList)
+}
+
+// CalculateMaxResources calculates the resources of the RayCluster when every worker group is scaled to maxReplicas.
+// Worker groups without an upper bound (maxReplicas unset or math.MaxInt32, its default) are counted with minReplicas.
+func CalculateMaxResources(cluster *rayv1.RayCluster) corev1.ResourceList {
+	maxResourcesList := []corev1.ResourceList{{}}
+	headPodResource := CalculatePodResource(cluster.Spec.HeadGroupSpec.Template.Spec)
+	maxResourcesList = append(maxResourcesList, headPodResource)
+	for _, nodeGroup := range cluster.Spec.WorkerGroupSpecs {
+		if nodeGroup.Suspend != nil && *nodeGroup.Suspend {
+			continue
+		}
+		replicas := int64(0)
+		if nodeGroup.MaxReplicas != nil && *nodeGroup.MaxReplicas != math.MaxInt32 {
+			replicas = int64(*nodeGroup.MaxReplicas)
+		} else if nodeGroup.MinReplicas != nil {
+			replicas = int64(*nodeGroup.MinReplicas)
+		}
+		count := replicas * int64(max(nodeGroup.NumOfHosts, 1))
+		groupResource := corev1.ResourceList{}
+		for name, quantity := range CalculatePodResource(nodeGroup.Template.Spec) {
+			quantity.Mul(count)
+			groupResource[name] = quantity
+		}
+		maxResourcesList = append(maxResourcesList, groupResource)
+	}
+	return SumResourceList(maxResourcesList)
 }
 
 // CalculatePodResource returns the total resources of a pod.
@@ -472,7 +814,8 @@ func ConvertResourceListToMapString(resourceList corev1.ResourceList) map[string
 	return result
 }
 
-func sumResourceList(list []corev1.ResourceList) corev1.ResourceList {
+// SumResourceList returns the sum of the resource lists.
+func SumResourceList(list []corev1.ResourceList) corev1.ResourceList {
 	totalResource := corev1.ResourceList{}
 	for _, l := range list {
 		for name, quantity := range l {
@@ -506,6 +849,17 @@ func GetHeadGroupServiceAccountName(cluster *rayv1.RayCluster) string {
 	return cluster.Name
 }
 
+// IsDedicatedServiceAccountEnabled returns whether KubeRay creates a ServiceAccount dedicated to the RayCluster.
+func IsDedicatedServiceAccountEnabled(cluster *rayv1.RayCluster) bool {
+	return cluster.Spec.DedicatedServiceAccount != nil && *cluster.Spec.DedicatedServiceAccount
+}
+
+// GetDedicatedServiceAccountName returns the name of the ServiceAccount dedicated to the RayCluster. It's the name of
+// the ServiceAccount of the autoscaler too if the head Pod template doesn't set a serviceAccountName.
+func GetDedicatedServiceAccountName(cluster *rayv1.RayCluster) string {
+	return CheckName(cluster.Name)
+}
+
 // CheckAllPodsRunning returns true if all the RayCluster's Pods are running, false otherwise
 func CheckAllPodsRunning(ctx context.Context, runningPods corev1.PodList) bool {
 	log := ctrl.LoggerFrom(ctx)
@@ -633,3 +987,177 @@ func IsAutoscalingEnabled[T *rayv1.RayCluster | *rayv1.RayJob | *rayv1.RayServic
 		panic(fmt.Sprintf("unsupported type: %T", obj))
 	}
 }
+
+// NextReconcileError returns the LastReconcileError of a custom resource after a reconciliation that returned
+// reconcileErr. The count is increased if the error message is the same as the last one.
+func NextReconcileError(last *rayv1.ReconcileError, reconcileErr error, now metav1.Time) *rayv1.ReconcileError {
+	if reconcileErr == nil {
+		return nil
+	}
+	message := reconcileErr.Error()
+	if len(message) > MaxReconcileErrorMessageLength {
+		message = message[:MaxReconcileErrorMessageLength]
+	}
+	count := int32(1)
+	if last != nil && last.Message == message {
+		count = last.Count + 1
+	}
+	return &rayv1.ReconcileError{Timestamp: now, Message: message, Count: count}
+}
+
+// RecordReconcileError records the error returned by the reconciliation of the custom resource in its status, and
+// clears it once a reconciliation succeeds. The AdmissionDenied condition is set if the error is an
+// AdmissionDeniedError, and removed otherwise. The status is patched, so that the other fields of the status aren't
+// overwritten. It does nothing if the custom resource doesn't exist. A repeated error is only recorded again after
+// ReconcileErrorRecordInterval, since each status update triggers another reconciliation that may fail the same way.
+func RecordReconcileError(ctx context.Context, c client.Client, key types.NamespacedName, obj client.Object, reconcileErr error) error {
+	if err := c.Get(ctx, key, obj); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	original := obj.DeepCopyObject().(client.Object)
+
+	var lastReconcileError **rayv1.ReconcileError
+	var conditions *[]metav1.Condition
+	var admissionDeniedType string
+	switch obj := obj.(type) {
+	case *rayv1.RayCluster:
+		lastReconcileError = &obj.Status.LastReconcileError
+		conditions = &obj.Status.Conditions
+		admissionDeniedType = string(rayv1.RayClusterAdmissionDenied)
+	case *rayv1.RayJob:
+		lastReconcileError = &obj.Status.LastReconcileError
+		conditions = &obj.Status.Conditions
+		admissionDeniedType = string(rayv1.RayJobAdmissionDenied)
+	case *rayv1.RayService:
+		lastReconcileError = &obj.Status.LastReconcileError
+		conditions = &obj.Status.Conditions
+		admissionDeniedType = string(rayv1.RayServiceAdmissionDenied)
+	default:
+		panic(fmt.Sprintf("unsupported type: %T", obj))
+	}
+	denied := GetAdmissionDeniedError(reconcileErr)
+	if *lastReconcileError == nil && reconcileErr == nil && meta.FindStatusCondition(*conditions, admissionDeniedType) == nil {
+		return nil
+	}
+	last := *lastReconcileError
+	lastConditions := slices.Clone(*conditions)
+	now := metav1.Now()
+	*lastReconcileError = NextReconcileError(last, reconcileErr, now)
+	if denied != nil {
+		message := denied.Error()
+		if len(message) > MaxReconcileErrorMessageLength {
+			message = message[:MaxReconcileErrorMessageLength]
+		}
+		meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:    admissionDeniedType,
+			Status:  metav1.ConditionTrue,
+			Reason:  denied.Reason,
+			Message: message,
+		})
+	} else {
+		meta.RemoveStatusCondition(conditions, admissionDeniedType)
+	}
+	if next := *lastReconcileError; last != nil && next != nil && last.Message == next.Message &&
+		now.Sub(last.Timestamp.Time) < ReconcileErrorRecordInterval && reflect.DeepEqual(lastConditions, *conditions) {
+		return nil
+	}
+	return c.Status().Patch(ctx, obj, client.MergeFrom(original))
+}
+
+var serveConfigV2VariableRegex = regexp.MustCompile(`\$(\$?)\{([-._a-zA-Z0-9]+)\}`)
+
+// GetServeConfigV2Variables reads the variables of serveConfigV2 from the ConfigMaps and Secrets of the sources.
+// Missing optional sources are skipped.
+func GetServeConfigV2Variables(ctx context.Context, c client.Client, namespace string, sources []corev1.EnvFromSource) (map[string]string, error) {
+	variables := map[string]string{}
+	for _, source := range sources {
+		var data map[string]string
+		switch {
+		case source.ConfigMapRef != nil:
+			configMap := &corev1.ConfigMap{}
+			if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: source.ConfigMapRef.Name}, configMap); err != nil {
+				if errors.IsNotFound(err) && ptr.Deref(source.ConfigMapRef.Optional, false) {
+					continue
+				}
+				return nil, fmt.Errorf("failed to get the ConfigMap %s of the serveConfigV2 variables: %w", source.ConfigMapRef.Name, err)
+			}
+			data = configMap.Data
+		case source.SecretRef != nil:
+			secret := &corev1.Secret{}
+			if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: source.SecretRef.Name}, secret); err != nil {
+				if errors.IsNotFound(err) && ptr.Deref(source.SecretRef.Optional, false) {
+					continue
+				}
+				return nil, fmt.Errorf("failed to get the Secret %s of the serveConfigV2 variables: %w", source.SecretRef.Name, err)
+			}
+			data = make(map[string]string, len(secret.Data))
+			for key, value := range secret.Data {
+				data[key] = string(value)
+			}
+		}
+		for key, value := range data {
+			variables[source.Prefix+key] = value
+		}
+	}
+	return variables, nil
+}
+
+// SubstituteServeConfigV2Variables replaces the `${KEY}` variables of the Serve config with their values, and
+// `$${` with `${`. It returns an error listing the variables without a value.
+func SubstituteServeConfigV2Variables(serveConfig string, variables map[string]string) (string, error) {
+	var undefined []string
+	result := serveConfigV2VariableRegex.ReplaceAllStringFunc(serveConfig, func(match string) string {
+		groups := serveConfigV2VariableRegex.FindStringSubmatch(match)
+		if groups[1] != "" {
+			return match[1:]
+		}
+		value, ok := variables[groups[2]]
+		if !ok {
+			undefined = append(undefined, groups[2])
+		}
+		return value
+	})
+	if len(undefined) > 0 {
+		slices.Sort(undefined)
+		return "", fmt.Errorf("serveConfigV2 references undefined variables: %s", strings.Join(slices.Compact(undefined), ", "))
+	}
+	return result, nil
+}
+
+// gzipMagic is the header of the gzipped data. It can't start a Serve config, since YAML doesn't allow control
+// characters.
+const gzipMagic = "\x1f\x8b"
+
+// CompressServeConfig gzips the Serve configs larger than ServeConfigCompressionThreshold, to reduce the memory used
+// by the cache of the RayService controller. The smaller configs are returned as is.
+func CompressServeConfig(serveConfig string) string {
+	if len(serveConfig) <= ServeConfigCompressionThreshold {
+		return serveConfig
+	}
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write([]byte(serveConfig)); err != nil {
+		return serveConfig
+	}
+	if err := writer.Close(); err != nil {
+		return serveConfig
+	}
+	return buf.String()
+}
+
+// DecompressServeConfig returns the Serve config compressed by CompressServeConfig.
+func DecompressServeConfig(serveConfig string) (string, error) {
+	if !strings.HasPrefix(serveConfig, gzipMagic) {
+		return serveConfig, nil
+	}
+	reader, err := gzip.NewReader(strings.NewReader(serveConfig))
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+	decompressed, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+	return string(decompressed), nil
+}
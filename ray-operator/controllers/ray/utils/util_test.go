@@ -3,11 +3,21 @@ package utils
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	corev1 "k8s.io/api/core/v1"
 
@@ -42,6 +52,22 @@ func TestGetClusterDomainName(t *testing.T) {
 	}
 }
 
+func TestGetRayClusterDomainName(t *testing.T) {
+	t.Setenv(ClusterDomainEnvKey, "abc.com")
+	assert.Equal(t, "abc.com", GetRayClusterDomainName(rayv1.RayClusterSpec{}))
+
+	// The --cluster-domain flag takes precedence over the env.
+	SetClusterDomainName("flag.example")
+	defer SetClusterDomainName("")
+	assert.Equal(t, "flag.example", GetRayClusterDomainName(rayv1.RayClusterSpec{}))
+
+	// The ClusterDomain of the RayCluster takes precedence over the cluster domain of the operator.
+	spec := rayv1.RayClusterSpec{ClusterDomain: ptr.To("cr.example")}
+	assert.Equal(t, "cr.example", GetRayClusterDomainName(spec))
+	cluster := rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Name: "raycluster"}, Spec: spec}
+	assert.Equal(t, "raycluster-head-svc.default.svc.cr.example", GenerateFQDNServiceName(context.Background(), cluster, "default"))
+}
+
 func TestStatus(t *testing.T) {
 	pod := createSomePod()
 	pod.Status.Phase = corev1.PodPending
@@ -182,6 +208,36 @@ func TestCheckName(t *testing.T) {
 	}
 }
 
+func TestTruncateNameWithHash(t *testing.T) {
+	// Names that fit are unchanged.
+	assert.Equal(t, "short-name", TruncateNameWithHash("short-name", 10))
+
+	// Long names are truncated at the end and suffixed with a hash of the full name.
+	name1 := strings.Repeat("a", 60) + "-serve-svc"
+	name2 := strings.Repeat("a", 60) + "-preview-serve-svc"
+	truncated1 := TruncateNameWithHash(name1, 50)
+	truncated2 := TruncateNameWithHash(name2, 50)
+	assert.Len(t, truncated1, 50)
+	assert.Len(t, truncated2, 50)
+	assert.True(t, strings.HasPrefix(truncated1, strings.Repeat("a", 44)+"-"))
+	assert.NotEqual(t, truncated1, truncated2)
+	assert.Equal(t, truncated1, TruncateNameWithHash(name1, 50))
+
+	// Trailing dashes of the prefix are trimmed.
+	truncated := TruncateNameWithHash(strings.Repeat("a", 8)+"----"+strings.Repeat("b", 10), 18)
+	assert.Regexp(t, "^a{8}-[0-9a-v]{5}$", truncated)
+}
+
+func TestGenerateRayClusterName(t *testing.T) {
+	name := GenerateRayClusterName("rayservice-sample")
+	assert.Regexp(t, "^rayservice-sample-raycluster-[a-z0-9]{5}$", name)
+
+	longName := strings.Repeat("a", 63)
+	name = GenerateRayClusterName(longName)
+	assert.Len(t, name, MaxRayClusterNameLength)
+	assert.Equal(t, TruncateNameWithHash(longName, 46)+RayClusterSuffix, name[:len(name)-5])
+}
+
 func TestCheckRouteName(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -475,6 +531,12 @@ func TestGenerateHeadServiceName(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, headSvcName, expectedGeneratedSvcName)
 
+	// Test 5: `ExistingHeadServiceName` takes precedence over `HeadService.Name`.
+	clusterSpecWithHeadService.HeadGroupSpec.ExistingHeadServiceName = "mesh-head-svc"
+	headSvcName, err = GenerateHeadServiceName(RayClusterCRD, *clusterSpecWithHeadService.DeepCopy(), "raycluster-sample")
+	assert.Nil(t, err)
+	assert.Equal(t, "mesh-head-svc", headSvcName)
+
 	// Invalid CRD type
 	_, err = GenerateHeadServiceName(RayJobCRD, rayv1.RayClusterSpec{}, "rayjob-sample")
 	assert.NotNil(t, err)
@@ -566,6 +628,41 @@ func TestCalculateMaxReplicas(t *testing.T) {
 	assert.Equal(t, CalculateMaxReplicas(rayCluster), int32(0))
 }
 
+func TestCalculateMaxResources(t *testing.T) {
+	podSpec := func(cpu string) corev1.PodSpec {
+		return corev1.PodSpec{Containers: []corev1.Container{{
+			Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)}},
+		}}}
+	}
+	rayCluster := &rayv1.RayCluster{
+		Spec: rayv1.RayClusterSpec{
+			HeadGroupSpec: rayv1.HeadGroupSpec{Template: corev1.PodTemplateSpec{Spec: podSpec("1")}},
+			WorkerGroupSpecs: []rayv1.WorkerGroupSpec{
+				{
+					MaxReplicas: ptr.To[int32](3),
+					NumOfHosts:  2,
+					Template:    corev1.PodTemplateSpec{Spec: podSpec("500m")},
+				},
+				{
+					// Unbounded worker groups are counted with minReplicas.
+					MinReplicas: ptr.To[int32](1),
+					MaxReplicas: ptr.To[int32](math.MaxInt32),
+					Template:    corev1.PodTemplateSpec{Spec: podSpec("4")},
+				},
+				{
+					Template: corev1.PodTemplateSpec{Spec: podSpec("8")},
+				},
+			},
+		},
+	}
+	maxCPU := CalculateMaxResources(rayCluster)[corev1.ResourceCPU]
+	assert.Equal(t, "8", maxCPU.String())
+
+	rayCluster.Spec.WorkerGroupSpecs[0].Suspend = ptr.To(true)
+	maxCPU = CalculateMaxResources(rayCluster)[corev1.ResourceCPU]
+	assert.Equal(t, "5", maxCPU.String())
+}
+
 func TestCalculateDesiredReplicas(t *testing.T) {
 	tests := map[string]struct {
 		group1Replicas    *int32
@@ -752,3 +849,220 @@ func TestIsAutoscalingEnabled(t *testing.T) {
 	}
 	assert.True(t, IsAutoscalingEnabled(service))
 }
+
+func TestNextReconcileError(t *testing.T) {
+	now := metav1.Now()
+	last := &rayv1.ReconcileError{Timestamp: metav1.NewTime(now.Add(-time.Minute)), Message: "foo", Count: 2}
+
+	assert.Nil(t, NextReconcileError(last, nil, now))
+	assert.Equal(t, &rayv1.ReconcileError{Timestamp: now, Message: "foo", Count: 1}, NextReconcileError(nil, errors.New("foo"), now))
+	assert.Equal(t, &rayv1.ReconcileError{Timestamp: now, Message: "foo", Count: 3}, NextReconcileError(last, errors.New("foo"), now))
+	assert.Equal(t, &rayv1.ReconcileError{Timestamp: now, Message: "bar", Count: 1}, NextReconcileError(last, errors.New("bar"), now))
+
+	longMessage := strings.Repeat("a", MaxReconcileErrorMessageLength+1)
+	assert.Len(t, NextReconcileError(nil, errors.New(longMessage), now).Message, MaxReconcileErrorMessageLength)
+}
+
+func TestRecordReconcileError(t *testing.T) {
+	ctx := context.Background()
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+
+	rayCluster := &rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Name: "raycluster", Namespace: "default"}}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithObjects(rayCluster).WithStatusSubresource(rayCluster).Build()
+	key := client.ObjectKeyFromObject(rayCluster)
+
+	for i := 1; i <= 2; i++ {
+		err := RecordReconcileError(ctx, fakeClient, key, &rayv1.RayCluster{}, errors.New("failed to create Pod"))
+		require.NoError(t, err)
+		err = fakeClient.Get(ctx, key, rayCluster)
+		require.NoError(t, err)
+		require.NotNil(t, rayCluster.Status.LastReconcileError)
+		assert.Equal(t, "failed to create Pod", rayCluster.Status.LastReconcileError.Message)
+		// The same error is only recorded again after ReconcileErrorRecordInterval, so that the status updates don't
+		// trigger reconciliations in a hot loop.
+		assert.Equal(t, int32(1), rayCluster.Status.LastReconcileError.Count)
+	}
+	rayCluster.Status.LastReconcileError.Timestamp = metav1.NewTime(time.Now().Add(-ReconcileErrorRecordInterval))
+	err := fakeClient.Status().Update(ctx, rayCluster)
+	require.NoError(t, err)
+	err = RecordReconcileError(ctx, fakeClient, key, &rayv1.RayCluster{}, errors.New("failed to create Pod"))
+	require.NoError(t, err)
+	err = fakeClient.Get(ctx, key, rayCluster)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), rayCluster.Status.LastReconcileError.Count)
+
+	// A different error is recorded immediately.
+	err = RecordReconcileError(ctx, fakeClient, key, &rayv1.RayCluster{}, errors.New("failed to create Service"))
+	require.NoError(t, err)
+	err = fakeClient.Get(ctx, key, rayCluster)
+	require.NoError(t, err)
+	assert.Equal(t, "failed to create Service", rayCluster.Status.LastReconcileError.Message)
+	assert.Equal(t, int32(1), rayCluster.Status.LastReconcileError.Count)
+
+	// The error is cleared once a reconciliation succeeds.
+	err = RecordReconcileError(ctx, fakeClient, key, &rayv1.RayCluster{}, nil)
+	require.NoError(t, err)
+	err = fakeClient.Get(ctx, key, rayCluster)
+	require.NoError(t, err)
+	assert.Nil(t, rayCluster.Status.LastReconcileError)
+
+	// The AdmissionDenied condition is set by an AdmissionDeniedError, and removed once a reconciliation succeeds.
+	denied := &AdmissionDeniedError{Reason: rayv1.ResourceQuotaExceeded, Kind: "Pod", Name: "default/raycluster-head-", Err: errors.New("exceeded quota")}
+	err = RecordReconcileError(ctx, fakeClient, key, &rayv1.RayCluster{}, fmt.Errorf("failed to create the head Pod: %w", denied))
+	require.NoError(t, err)
+	err = fakeClient.Get(ctx, key, rayCluster)
+	require.NoError(t, err)
+	condition := meta.FindStatusCondition(rayCluster.Status.Conditions, string(rayv1.RayClusterAdmissionDenied))
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, rayv1.ResourceQuotaExceeded, condition.Reason)
+	assert.Equal(t, "the creation of the Pod default/raycluster-head- was denied: exceeded quota", condition.Message)
+
+	err = RecordReconcileError(ctx, fakeClient, key, &rayv1.RayCluster{}, nil)
+	require.NoError(t, err)
+	err = fakeClient.Get(ctx, key, rayCluster)
+	require.NoError(t, err)
+	assert.Nil(t, meta.FindStatusCondition(rayCluster.Status.Conditions, string(rayv1.RayClusterAdmissionDenied)))
+
+	// Nothing is recorded if the custom resource doesn't exist.
+	err = RecordReconcileError(ctx, fakeClient, client.ObjectKey{Name: "missing", Namespace: "default"}, &rayv1.RayJob{}, errors.New("foo"))
+	require.NoError(t, err)
+}
+
+func TestSubstituteServeConfigV2Variables(t *testing.T) {
+	variables := map[string]string{"MODEL_PATH": "s3://models/prod", "NUM_REPLICAS": "3"}
+
+	serveConfig, err := SubstituteServeConfigV2Variables("model: ${MODEL_PATH}\nnum_replicas: ${NUM_REPLICAS}\nliteral: $${MODEL_PATH}\nshell: $HOME", variables)
+	require.NoError(t, err)
+	assert.Equal(t, "model: s3://models/prod\nnum_replicas: 3\nliteral: ${MODEL_PATH}\nshell: $HOME", serveConfig)
+
+	_, err = SubstituteServeConfigV2Variables("a: ${B}\nb: ${A}\nc: ${B}", variables)
+	assert.EqualError(t, err, "serveConfigV2 references undefined variables: A, B")
+}
+
+func TestCompressServeConfig(t *testing.T) {
+	small := "applications:\n- name: app1\n"
+	assert.Equal(t, small, CompressServeConfig(small))
+
+	large := "applications:\n" + strings.Repeat("- name: app\n  import_path: app:graph\n", ServeConfigCompressionThreshold/20)
+	compressed := CompressServeConfig(large)
+	assert.Less(t, len(compressed), len(large))
+	for _, serveConfig := range []string{small, large} {
+		decompressed, err := DecompressServeConfig(CompressServeConfig(serveConfig))
+		require.NoError(t, err)
+		assert.Equal(t, serveConfig, decompressed)
+	}
+
+	_, err := DecompressServeConfig(compressed[:len(compressed)/2])
+	assert.Error(t, err)
+}
+
+func TestGetServeConfigV2Variables(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := clientFake.NewClientBuilder().WithObjects(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "serve-values", Namespace: "default"},
+			Data:       map[string]string{"MODEL_PATH": "s3://models/staging", "NUM_REPLICAS": "1"},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "serve-secrets", Namespace: "default"},
+			Data:       map[string][]byte{"NUM_REPLICAS": []byte("2"), "TOKEN": []byte("secret")},
+		},
+	).Build()
+
+	variables, err := GetServeConfigV2Variables(ctx, fakeClient, "default", []corev1.EnvFromSource{
+		{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "serve-values"}}},
+		{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "serve-secrets"}}},
+		{Prefix: "HF_", SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "serve-secrets"}}},
+		{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "missing"}, Optional: ptr.To(true)}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"MODEL_PATH":      "s3://models/staging",
+		"NUM_REPLICAS":    "2",
+		"TOKEN":           "secret",
+		"HF_NUM_REPLICAS": "2",
+		"HF_TOKEN":        "secret",
+	}, variables)
+
+	_, err = GetServeConfigV2Variables(ctx, fakeClient, "default", []corev1.EnvFromSource{
+		{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "missing"}}},
+	})
+	assert.ErrorContains(t, err, "failed to get the ConfigMap missing of the serveConfigV2 variables")
+}
+
+func TestIsPodIP(t *testing.T) {
+	pod := &corev1.Pod{Status: corev1.PodStatus{
+		PodIP:  "10.0.0.1",
+		PodIPs: []corev1.PodIP{{IP: "10.0.0.1"}, {IP: "fd00:0:0::1"}},
+	}}
+	assert.True(t, IsPodIP(pod, "10.0.0.1"))
+	assert.True(t, IsPodIP(pod, "fd00::1"))
+	assert.False(t, IsPodIP(pod, "10.0.0.2"))
+	assert.False(t, IsPodIP(pod, ""))
+}
+
+func TestIsFastDeletionEnabled(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := clientFake.NewClientBuilder().WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ci", Annotations: map[string]string{RayFastDeletionAnnotationKey: "true"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod"}},
+	).Build()
+
+	assert.True(t, IsFastDeletionEnabled(ctx, fakeClient, "ci"))
+	assert.False(t, IsFastDeletionEnabled(ctx, fakeClient, "prod"))
+	// The annotation is ignored if the namespace can't be read.
+	assert.False(t, IsFastDeletionEnabled(ctx, fakeClient, "missing"))
+
+	// The flag of the operator enables it in all the namespaces.
+	SetFastDeletion(true)
+	defer SetFastDeletion(false)
+	assert.True(t, IsFastDeletionEnabled(ctx, fakeClient, "prod"))
+}
+
+func TestIsNamespaceSelected(t *testing.T) {
+	ctx := context.Background()
+	fakeClient := clientFake.NewClientBuilder().WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"kuberay.io/enabled": "true"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b"}},
+	).Build()
+
+	// All the namespaces are selected without the selector, even if they can't be read.
+	selected, err := IsNamespaceSelected(ctx, fakeClient, "missing")
+	require.NoError(t, err)
+	assert.True(t, selected)
+
+	require.Error(t, SetWatchNamespaceSelector("kuberay.io/enabled in (true"))
+	require.NoError(t, SetWatchNamespaceSelector("kuberay.io/enabled=true"))
+	defer func() { require.NoError(t, SetWatchNamespaceSelector("")) }()
+	assert.True(t, IsWatchNamespaceSelectorSet())
+
+	selected, err = IsNamespaceSelected(ctx, fakeClient, "team-a")
+	require.NoError(t, err)
+	assert.True(t, selected)
+	selected, err = IsNamespaceSelected(ctx, fakeClient, "team-b")
+	require.NoError(t, err)
+	assert.False(t, selected)
+	_, err = IsNamespaceSelected(ctx, fakeClient, "missing")
+	assert.Error(t, err)
+}
+
+func TestGetRayServiceRequeueInterval(t *testing.T) {
+	inRange := func(interval, base time.Duration) bool {
+		return interval >= base && interval <= time.Duration(float64(base)*(1+RayServiceRequeueJitterFactor))
+	}
+
+	// The intervals default to RayServiceDefaultRequeueInterval.
+	assert.True(t, inRange(GetRayServiceRequeueInterval(false), RayServiceDefaultRequeueInterval))
+	assert.True(t, inRange(GetRayServiceRequeueInterval(true), RayServiceDefaultRequeueInterval))
+
+	// The stable interval defaults to the interval.
+	SetRayServiceRequeueIntervals(5*time.Second, 0)
+	defer SetRayServiceRequeueIntervals(0, 0)
+	assert.True(t, inRange(GetRayServiceRequeueInterval(true), 5*time.Second))
+
+	SetRayServiceRequeueIntervals(5*time.Second, time.Minute)
+	assert.True(t, inRange(GetRayServiceRequeueInterval(false), 5*time.Second))
+	assert.True(t, inRange(GetRayServiceRequeueInterval(true), time.Minute))
+}
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/go-logr/logr"
 	"github.com/go-logr/zapr"
 	routev1 "github.com/openshift/api/route/v1"
 
@@ -13,7 +15,11 @@ import (
 	"go.uber.org/zap/zapcore"
 	"gopkg.in/natefinch/lumberjack.v2"
 
+	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
@@ -34,7 +40,12 @@ import (
 	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray"
 	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	"github.com/ray-project/kuberay/ray-operator/pkg/clusterstate"
+	"github.com/ray-project/kuberay/ray-operator/pkg/crds"
+	"github.com/ray-project/kuberay/ray-operator/pkg/dashboardproxy"
 	"github.com/ray-project/kuberay/ray-operator/pkg/features"
+	"github.com/ray-project/kuberay/ray-operator/pkg/servemetrics"
+	"github.com/ray-project/kuberay/ray-operator/pkg/servicehealth"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -60,15 +71,27 @@ func main() {
 	var probeAddr string
 	var reconcileConcurrency int
 	var watchNamespace string
+	var watchNamespaceSelector string
 	var forcedClusterUpgrade bool
 	var logFile string
 	var logFileEncoder string
 	var logStdoutEncoder string
+	var logVerbosity int
 	var useKubernetesProxy bool
 	var configFile string
 	var featureGates string
 	var enableBatchScheduler bool
 	var batchScheduler string
+	var manageCRDs bool
+	var clusterDomain string
+	var clusterStateProviderAddr string
+	var serveMetricsAdapterAddr string
+	var dashboardProxyAddr string
+	var rayServiceHealthAddr string
+	var fastDeletion bool
+	var rayServiceRequeueInterval time.Duration
+	var rayServiceStableRequeueInterval time.Duration
+	var gracefulShutdownTimeout time.Duration
 
 	// TODO: remove flag-based config once Configuration API graduates to v1.
 	flag.StringVar(&metricsAddr, "metrics-addr", configapi.DefaultMetricsAddr, "The address the metric endpoint binds to.")
@@ -83,6 +106,8 @@ func main() {
 		"watch-namespace",
 		"",
 		"Specify a list of namespaces to watch for custom resources, separated by commas. If left empty, all namespaces will be watched.")
+	flag.StringVar(&watchNamespaceSelector, "watch-namespace-selector", "",
+		"Label selector of the namespaces whose custom resources are reconciled, e.g. kuberay.io/enabled=true. The labels are matched at runtime, so namespaces can be onboarded without restarting the operator. It can't be combined with --watch-namespace, since the Namespaces are watched with a ClusterRole.")
 	flag.BoolVar(&forcedClusterUpgrade, "forced-cluster-upgrade", false,
 		"(Deprecated) Forced cluster upgrade flag")
 	flag.StringVar(&logFile, "log-file-path", "",
@@ -91,6 +116,8 @@ func main() {
 		"Encoder to use for log file. Valid values are 'json' and 'console'. Defaults to 'json'")
 	flag.StringVar(&logStdoutEncoder, "log-stdout-encoder", "json",
 		"Encoder to use for logging stdout. Valid values are 'json' and 'console'. Defaults to 'json'")
+	flag.IntVar(&logVerbosity, "log-verbosity", 0,
+		"The highest verbosity of the logs, from 0 to 2. Overridden for a custom resource by its ray.io/log-verbosity annotation.")
 	flag.BoolVar(&enableBatchScheduler, "enable-batch-scheduler", false,
 		"(Deprecated) Enable batch scheduler. Currently is volcano, which supports gang scheduler policy. Please use --batch-scheduler instead.")
 	flag.StringVar(&batchScheduler, "batch-scheduler", "",
@@ -98,10 +125,32 @@ func main() {
 	flag.StringVar(&configFile, "config", "", "Path to structured config file. Flags are ignored if config file is set.")
 	flag.BoolVar(&useKubernetesProxy, "use-kubernetes-proxy", false,
 		"Use Kubernetes proxy subresource when connecting to the Ray Head node.")
+	flag.BoolVar(&manageCRDs, "manage-crds", false,
+		"Apply the CRDs bundled with the operator at startup. Use this when installing KubeRay without Helm.")
+	flag.StringVar(&clusterDomain, "cluster-domain", "",
+		"The DNS domain of the Kubernetes cluster used in the FQDNs of the head services. Defaults to the CLUSTER_DOMAIN env or cluster.local.")
+	flag.StringVar(&clusterStateProviderAddr, "cluster-state-provider-bind-address", "",
+		"The address the cluster state provider binds to, e.g. :8082. The cluster state provider is disabled if empty.")
+	flag.StringVar(&serveMetricsAdapterAddr, "serve-metrics-adapter-bind-address", "",
+		"The address the Ray Serve custom metrics adapter binds to, e.g. :6443. The adapter is disabled if empty.")
+	flag.StringVar(&dashboardProxyAddr, "dashboard-proxy-bind-address", "",
+		"The address the authenticated Ray dashboard proxy binds to, e.g. :8083. The proxy is disabled if empty.")
+	flag.StringVar(&rayServiceHealthAddr, "rayservice-health-bind-address", "",
+		"The address the RayService health endpoint for external load balancers binds to, e.g. :8084. The endpoint is disabled if empty.")
+	flag.BoolVar(&fastDeletion, "fast-deletion", false,
+		"Delete the custom resources without waiting for the Redis cleanup, the drain of the Ray Pods and the cleanup of their objects.")
+	flag.DurationVar(&rayServiceRequeueInterval, "rayservice-requeue-interval", utils.RayServiceDefaultRequeueInterval,
+		"The interval between the reconciliations of the RayServices that are created, upgraded or restarted. The intervals are jittered.")
+	flag.DurationVar(&rayServiceStableRequeueInterval, "rayservice-stable-requeue-interval", 0,
+		"The interval between the reconciliations of the RayServices whose Serve applications are running. Defaults to --rayservice-requeue-interval.")
+	flag.DurationVar(&gracefulShutdownTimeout, "graceful-shutdown-timeout", configapi.DefaultGracefulShutdownTimeout,
+		"How long the operator waits for the in-flight reconciliations to finish when it's terminated.")
 	flag.StringVar(&featureGates, "feature-gates", "", "A set of key=value pairs that describe feature gates. E.g. FeatureOne=true,FeatureTwo=false,...")
 
+	// The verbosity is filtered by utils.VerbositySink, so that it can be changed per custom resource.
 	opts := k8szap.Options{
 		TimeEncoder: zapcore.ISO8601TimeEncoder,
+		Level:       zapcore.Level(-utils.MaxLogVerbosity),
 	}
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
@@ -121,15 +170,28 @@ func main() {
 		config.LeaderElectionNamespace = leaderElectionNamespace
 		config.ReconcileConcurrency = reconcileConcurrency
 		config.WatchNamespace = watchNamespace
+		config.WatchNamespaceSelector = watchNamespaceSelector
 		config.LogFile = logFile
 		config.LogFileEncoder = logFileEncoder
 		config.LogStdoutEncoder = logStdoutEncoder
+		config.LogVerbosity = logVerbosity
 		config.EnableBatchScheduler = enableBatchScheduler
 		config.BatchScheduler = batchScheduler
 		config.UseKubernetesProxy = useKubernetesProxy
 		config.DeleteRayJobAfterJobFinishes = os.Getenv(utils.DELETE_RAYJOB_CR_AFTER_JOB_FINISHES) == "true"
+		config.ManageCRDs = manageCRDs
+		config.ClusterDomain = clusterDomain
+		config.ClusterStateProviderAddr = clusterStateProviderAddr
+		config.ServeMetricsAdapterAddr = serveMetricsAdapterAddr
+		config.DashboardProxyAddr = dashboardProxyAddr
+		config.RayServiceHealthAddr = rayServiceHealthAddr
+		config.FastDeletion = fastDeletion
+		config.RayServiceRequeueInterval = metav1.Duration{Duration: rayServiceRequeueInterval}
+		config.RayServiceStableRequeueInterval = metav1.Duration{Duration: rayServiceStableRequeueInterval}
+		config.GracefulShutdownTimeout = metav1.Duration{Duration: gracefulShutdownTimeout}
 	}
 
+	var logger logr.Logger
 	stdoutEncoder, err := newLogEncoder(logStdoutEncoder)
 	exitOnError(err, "failed to create log encoder for stdout")
 	opts.Encoder = stdoutEncoder
@@ -151,21 +213,16 @@ func main() {
 			k8sLogger.Core(),
 			zapcore.NewCore(fileEncoder, zapcore.AddSync(fileWriter), zap.InfoLevel),
 		)).WithOptions(zapOpts...)
-		combineLoggerR := zapr.NewLogger(combineLogger)
-
-		ctrl.SetLogger(combineLoggerR)
-
-		// By default, the log from kubernetes/client-go is not json format.
-		// This will apply the logger to kubernetes/client-go and change it to json format.
-		klog.SetLogger(combineLoggerR)
+		logger = zapr.NewLogger(combineLogger)
 	} else {
-		k8sLogger := k8szap.New(k8szap.UseFlagOptions(&opts))
-		ctrl.SetLogger(k8sLogger)
-
-		// By default, the log from kubernetes/client-go is not json format.
-		// This will apply the logger to kubernetes/client-go and change it to json format.
-		klog.SetLogger(k8sLogger)
+		logger = k8szap.New(k8szap.UseFlagOptions(&opts))
 	}
+	logger = logger.WithSink(utils.NewVerbositySink(logger.GetSink(), config.LogVerbosity))
+	ctrl.SetLogger(logger)
+
+	// By default, the log from kubernetes/client-go is not json format.
+	// This will apply the logger to kubernetes/client-go and change it to json format.
+	klog.SetLogger(logger)
 
 	if forcedClusterUpgrade {
 		setupLog.Info("Deprecated feature flag forced-cluster-upgrade is enabled, which has no effect.")
@@ -177,6 +234,26 @@ func main() {
 		exitOnError(err, "batch scheduler configs validation failed")
 	}
 
+	if err := configapi.ValidateInjectedEnvPolicy(config.InjectedEnvPolicy); err != nil {
+		exitOnError(err, "injected environment variable policy validation failed")
+	}
+	if err := configapi.ValidateImageArchitecturePolicy(config.ImageArchitectures); err != nil {
+		exitOnError(err, "image architecture policy validation failed")
+	}
+	if err := configapi.ValidateServeProxyHealthCheck(config.ServeProxyHealthCheck); err != nil {
+		exitOnError(err, "serve proxy health check validation failed")
+	}
+
+	utils.SetClusterDomainName(config.ClusterDomain)
+	utils.SetFastDeletion(config.FastDeletion)
+	utils.SetRayServiceRequeueIntervals(config.RayServiceRequeueInterval.Duration, config.RayServiceStableRequeueInterval.Duration)
+	if err := configapi.ValidateWatchNamespaceSelector(config); err != nil {
+		exitOnError(err, "watch namespace selector validation failed")
+	}
+	if err := utils.SetWatchNamespaceSelector(config.WatchNamespaceSelector); err != nil {
+		exitOnError(err, "watch namespace selector validation failed")
+	}
+
 	if err := utilfeature.DefaultMutableFeatureGate.Set(featureGates); err != nil {
 		exitOnError(err, "Unable to set flag gates for known features")
 	}
@@ -188,6 +265,17 @@ func main() {
 			DefaultNamespaces: map[string]cache.Config{},
 		},
 		Scheme: scheme,
+		// The reconcilers get the VerbositySink from the logger of the manager to apply the log verbosity annotation.
+		Logger: logger,
+		// ConfigMaps and Secrets are only read for the serveConfigV2 variables of RayServices, Nodes for the virtual
+		// nodes of burstable worker groups and the zones of serve Pods, and Namespaces for the fast deletion annotation
+		// unless the watch namespace selector is set. They are read from the API server so that the operator doesn't
+		// need to watch and cache all of them.
+		Client: client.Options{
+			Cache: &client.CacheOptions{
+				DisableFor: uncachedObjects(config),
+			},
+		},
 		Metrics: metricsserver.Options{
 			BindAddress: config.MetricsAddr,
 		},
@@ -196,6 +284,9 @@ func main() {
 		LeaderElectionID:        "ray-operator-leader",
 		LeaderElectionNamespace: config.LeaderElectionNamespace,
 	}
+	if config.GracefulShutdownTimeout.Duration > 0 {
+		options.GracefulShutdownTimeout = &config.GracefulShutdownTimeout.Duration
+	}
 
 	// Manager Cache
 	// Set the informers label selectors to narrow the scope of the resources being watched and cached.
@@ -208,6 +299,9 @@ func main() {
 	exitOnError(err, "unable to create cache selectors")
 	options.Cache.ByObject = selectorsByObject
 
+	if config.WatchNamespaceSelector != "" {
+		setupLog.Info("Only reconcile custom resources in the namespaces selected by their labels.", "selector", config.WatchNamespaceSelector)
+	}
 	if watchNamespaces := strings.Split(config.WatchNamespace, ","); len(watchNamespaces) == 1 { // It is not possible for len(watchNamespaces) == 0 to be true. The length of `strings.Split("", ",")` is still 1.
 		if watchNamespaces[0] == "" {
 			setupLog.Info("Flag watchNamespace is not set. Watch custom resources in all namespaces.")
@@ -225,20 +319,38 @@ func main() {
 	setupLog.Info("Setup manager")
 	restConfig := ctrl.GetConfigOrDie()
 	restConfig.UserAgent = userAgent
+	ctx := ctrl.SetupSignalHandler()
+	if config.ManageCRDs {
+		setupLog.Info("Applying bundled CRDs")
+		exitOnError(crds.InstallBundled(logr.NewContext(ctx, setupLog), restConfig), "unable to install CRDs")
+	}
 	mgr, err := ctrl.NewManager(restConfig, options)
 	exitOnError(err, "unable to start manager")
 
 	rayClusterOptions := ray.RayClusterReconcilerOptions{
 		HeadSidecarContainers:   config.HeadSidecarContainers,
 		WorkerSidecarContainers: config.WorkerSidecarContainers,
+		InjectedEnvPolicy:       config.InjectedEnvPolicy,
+		GeneratedPodSecurity:    config.GeneratedPodSecurity,
+		PodSpecDefaults:         config.PodSpecDefaults,
+		DashboardMetrics:        config.DashboardMetrics,
+		ImageArchitectures:      config.ImageArchitectures,
 	}
-	ctx := ctrl.SetupSignalHandler()
 	exitOnError(ray.NewReconciler(ctx, mgr, rayClusterOptions, config).SetupWithManager(mgr, config.ReconcileConcurrency),
 		"unable to create controller", "controller", "RayCluster")
 	exitOnError(ray.NewRayServiceReconciler(ctx, mgr, config).SetupWithManager(mgr, config.ReconcileConcurrency),
 		"unable to create controller", "controller", "RayService")
-	exitOnError(ray.NewRayJobReconciler(ctx, mgr, config).SetupWithManager(mgr, config.ReconcileConcurrency),
+	rayJobOptions := ray.RayJobReconcilerOptions{
+		GeneratedPodSecurity: config.GeneratedPodSecurity,
+		PodSpecDefaults:      config.PodSpecDefaults,
+		ImageArchitectures:   config.ImageArchitectures,
+	}
+	exitOnError(ray.NewRayJobReconciler(ctx, mgr, rayJobOptions, config).SetupWithManager(mgr, config.ReconcileConcurrency),
 		"unable to create controller", "controller", "RayJob")
+	if features.Enabled(features.RayQuota) {
+		exitOnError(ray.NewRayQuotaReconciler(mgr).SetupWithManager(mgr, config.ReconcileConcurrency),
+			"unable to create controller", "controller", "RayQuota")
+	}
 
 	if os.Getenv("ENABLE_WEBHOOKS") == "true" {
 		exitOnError((&rayv1.RayCluster{}).SetupWebhookWithManager(mgr),
@@ -246,6 +358,24 @@ func main() {
 	}
 	// +kubebuilder:scaffold:builder
 
+	if config.ClusterStateProviderAddr != "" {
+		provider := clusterstate.NewProvider(mgr.GetClient(), config.GetDashboardClient(mgr))
+		exitOnError(mgr.Add(clusterstate.NewServer(config.ClusterStateProviderAddr, provider)),
+			"unable to set up cluster state provider")
+	}
+	if config.ServeMetricsAdapterAddr != "" {
+		exitOnError(mgr.Add(servemetrics.NewServer(config.ServeMetricsAdapterAddr, servemetrics.NewProvider(mgr.GetClient()))),
+			"unable to set up Ray Serve custom metrics adapter")
+	}
+	if config.DashboardProxyAddr != "" {
+		exitOnError(mgr.Add(dashboardproxy.NewServer(config.DashboardProxyAddr, dashboardproxy.NewProxy(mgr.GetClient()))),
+			"unable to set up Ray dashboard proxy")
+	}
+	if config.RayServiceHealthAddr != "" {
+		exitOnError(mgr.Add(servicehealth.NewServer(config.RayServiceHealthAddr, servicehealth.NewProvider(mgr.GetClient()))),
+			"unable to set up RayService health endpoint")
+	}
+
 	exitOnError(mgr.AddHealthzCheck("healthz", healthz.Ping), "unable to set up health check")
 	exitOnError(mgr.AddReadyzCheck("readyz", healthz.Ping), "unable to set up ready check")
 
@@ -261,10 +391,23 @@ func cacheSelectors() (map[client.Object]cache.ByObject, error) {
 	selector := labels.NewSelector().Add(*label)
 
 	return map[client.Object]cache.ByObject{
-		&batchv1.Job{}: {Label: selector},
+		&batchv1.Job{}:               {Label: selector},
+		&appsv1.DaemonSet{}:          {Label: selector},
+		&appsv1.Deployment{}:         {Label: selector},
+		&discoveryv1.EndpointSlice{}: {Label: selector},
 	}, nil
 }
 
+// uncachedObjects returns the objects read from the API server rather than the cache of the manager. The Namespaces
+// are cached if the watch namespace selector is set, because they're then watched and read at every reconciliation.
+func uncachedObjects(config configapi.Configuration) []client.Object {
+	objects := []client.Object{&corev1.ConfigMap{}, &corev1.Secret{}, &corev1.Node{}}
+	if config.WatchNamespaceSelector == "" {
+		objects = append(objects, &corev1.Namespace{})
+	}
+	return objects
+}
+
 func exitOnError(err error, msg string, keysAndValues ...interface{}) {
 	if err != nil {
 		setupLog.Error(err, msg, keysAndValues...)
@@ -3,16 +3,19 @@
 package v1
 
 import (
-	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	v1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // AppStatusApplyConfiguration represents an declarative configuration of the AppStatus type for use
 // with apply.
 type AppStatusApplyConfiguration struct {
-	HealthLastUpdateTime *v1.Time                                           `json:"healthLastUpdateTime,omitempty"`
+	HealthLastUpdateTime *metav1.Time                                       `json:"healthLastUpdateTime,omitempty"`
 	Deployments          map[string]ServeDeploymentStatusApplyConfiguration `json:"serveDeploymentStatuses,omitempty"`
 	Status               *string                                            `json:"status,omitempty"`
 	Message              *string                                            `json:"message,omitempty"`
+	UpgradeStatus        *v1.ServeApplicationUpgradeStatus                  `json:"upgradeStatus,omitempty"`
+	ServeConfigHash      *string                                            `json:"serveConfigHash,omitempty"`
 }
 
 // AppStatusApplyConfiguration constructs an declarative configuration of the AppStatus type for use with
@@ -24,7 +27,7 @@ func AppStatus() *AppStatusApplyConfiguration {
 // WithHealthLastUpdateTime sets the HealthLastUpdateTime field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the HealthLastUpdateTime field is set to the value of the last call.
-func (b *AppStatusApplyConfiguration) WithHealthLastUpdateTime(value v1.Time) *AppStatusApplyConfiguration {
+func (b *AppStatusApplyConfiguration) WithHealthLastUpdateTime(value metav1.Time) *AppStatusApplyConfiguration {
 	b.HealthLastUpdateTime = &value
 	return b
 }
@@ -58,3 +61,19 @@ func (b *AppStatusApplyConfiguration) WithMessage(value string) *AppStatusApplyC
 	b.Message = &value
 	return b
 }
+
+// WithUpgradeStatus sets the UpgradeStatus field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UpgradeStatus field is set to the value of the last call.
+func (b *AppStatusApplyConfiguration) WithUpgradeStatus(value v1.ServeApplicationUpgradeStatus) *AppStatusApplyConfiguration {
+	b.UpgradeStatus = &value
+	return b
+}
+
+// WithServeConfigHash sets the ServeConfigHash field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServeConfigHash field is set to the value of the last call.
+func (b *AppStatusApplyConfiguration) WithServeConfigHash(value string) *AppStatusApplyConfiguration {
+	b.ServeConfigHash = &value
+	return b
+}
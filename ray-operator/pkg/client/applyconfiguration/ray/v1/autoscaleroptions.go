@@ -10,15 +10,16 @@ import (
 // AutoscalerOptionsApplyConfiguration represents an declarative configuration of the AutoscalerOptions type for use
 // with apply.
 type AutoscalerOptionsApplyConfiguration struct {
-	Resources          *v1.ResourceRequirements `json:"resources,omitempty"`
-	Image              *string                  `json:"image,omitempty"`
-	ImagePullPolicy    *v1.PullPolicy           `json:"imagePullPolicy,omitempty"`
-	SecurityContext    *v1.SecurityContext      `json:"securityContext,omitempty"`
-	IdleTimeoutSeconds *int32                   `json:"idleTimeoutSeconds,omitempty"`
-	UpscalingMode      *rayv1.UpscalingMode     `json:"upscalingMode,omitempty"`
-	Env                []v1.EnvVar              `json:"env,omitempty"`
-	EnvFrom            []v1.EnvFromSource       `json:"envFrom,omitempty"`
-	VolumeMounts       []v1.VolumeMount         `json:"volumeMounts,omitempty"`
+	Resources          *v1.ResourceRequirements              `json:"resources,omitempty"`
+	Image              *string                               `json:"image,omitempty"`
+	ImagePullPolicy    *v1.PullPolicy                        `json:"imagePullPolicy,omitempty"`
+	SecurityContext    *v1.SecurityContext                   `json:"securityContext,omitempty"`
+	IdleTimeoutSeconds *int32                                `json:"idleTimeoutSeconds,omitempty"`
+	UpscalingMode      *rayv1.UpscalingMode                  `json:"upscalingMode,omitempty"`
+	Env                []v1.EnvVar                           `json:"env,omitempty"`
+	EnvFrom            []v1.EnvFromSource                    `json:"envFrom,omitempty"`
+	VolumeMounts       []v1.VolumeMount                      `json:"volumeMounts,omitempty"`
+	BalloonPods        *BalloonPodsOptionsApplyConfiguration `json:"balloonPods,omitempty"`
 }
 
 // AutoscalerOptionsApplyConfiguration constructs an declarative configuration of the AutoscalerOptions type for use with
@@ -104,3 +105,11 @@ func (b *AutoscalerOptionsApplyConfiguration) WithVolumeMounts(values ...v1.Volu
 	}
 	return b
 }
+
+// WithBalloonPods sets the BalloonPods field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BalloonPods field is set to the value of the last call.
+func (b *AutoscalerOptionsApplyConfiguration) WithBalloonPods(value *BalloonPodsOptionsApplyConfiguration) *AutoscalerOptionsApplyConfiguration {
+	b.BalloonPods = value
+	return b
+}
@@ -10,11 +10,17 @@ import (
 // HeadGroupSpecApplyConfiguration represents an declarative configuration of the HeadGroupSpec type for use
 // with apply.
 type HeadGroupSpecApplyConfiguration struct {
-	ServiceType    *v1.ServiceType                           `json:"serviceType,omitempty"`
-	HeadService    *v1.Service                               `json:"headService,omitempty"`
-	EnableIngress  *bool                                     `json:"enableIngress,omitempty"`
-	RayStartParams map[string]string                         `json:"rayStartParams,omitempty"`
-	Template       *corev1.PodTemplateSpecApplyConfiguration `json:"template,omitempty"`
+	ServiceType              *v1.ServiceType                           `json:"serviceType,omitempty"`
+	HeadService              *v1.Service                               `json:"headService,omitempty"`
+	ExistingHeadServiceName  *string                                   `json:"existingHeadServiceName,omitempty"`
+	EnableIngress            *bool                                     `json:"enableIngress,omitempty"`
+	ObjectStoreMemoryPercent *int32                                    `json:"objectStoreMemoryPercent,omitempty"`
+	RayStartParams           map[string]string                         `json:"rayStartParams,omitempty"`
+	Template                 *corev1.PodTemplateSpecApplyConfiguration `json:"template,omitempty"`
+	EnableColdStandby        *bool                                     `json:"enableColdStandby,omitempty"`
+	ServicePorts             *HeadServicePortsApplyConfiguration       `json:"servicePorts,omitempty"`
+	StatefulSet              *HeadStatefulSetOptionsApplyConfiguration `json:"statefulSet,omitempty"`
+	HealthProbes             *HeadHealthProbesApplyConfiguration       `json:"healthProbes,omitempty"`
 }
 
 // HeadGroupSpecApplyConfiguration constructs an declarative configuration of the HeadGroupSpec type for use with
@@ -39,6 +45,14 @@ func (b *HeadGroupSpecApplyConfiguration) WithHeadService(value v1.Service) *Hea
 	return b
 }
 
+// WithExistingHeadServiceName sets the ExistingHeadServiceName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ExistingHeadServiceName field is set to the value of the last call.
+func (b *HeadGroupSpecApplyConfiguration) WithExistingHeadServiceName(value string) *HeadGroupSpecApplyConfiguration {
+	b.ExistingHeadServiceName = &value
+	return b
+}
+
 // WithEnableIngress sets the EnableIngress field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the EnableIngress field is set to the value of the last call.
@@ -47,6 +61,14 @@ func (b *HeadGroupSpecApplyConfiguration) WithEnableIngress(value bool) *HeadGro
 	return b
 }
 
+// WithObjectStoreMemoryPercent sets the ObjectStoreMemoryPercent field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ObjectStoreMemoryPercent field is set to the value of the last call.
+func (b *HeadGroupSpecApplyConfiguration) WithObjectStoreMemoryPercent(value int32) *HeadGroupSpecApplyConfiguration {
+	b.ObjectStoreMemoryPercent = &value
+	return b
+}
+
 // WithRayStartParams puts the entries into the RayStartParams field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, the entries provided by each call will be put on the RayStartParams field,
@@ -68,3 +90,35 @@ func (b *HeadGroupSpecApplyConfiguration) WithTemplate(value *corev1.PodTemplate
 	b.Template = value
 	return b
 }
+
+// WithEnableColdStandby sets the EnableColdStandby field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EnableColdStandby field is set to the value of the last call.
+func (b *HeadGroupSpecApplyConfiguration) WithEnableColdStandby(value bool) *HeadGroupSpecApplyConfiguration {
+	b.EnableColdStandby = &value
+	return b
+}
+
+// WithServicePorts sets the ServicePorts field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServicePorts field is set to the value of the last call.
+func (b *HeadGroupSpecApplyConfiguration) WithServicePorts(value *HeadServicePortsApplyConfiguration) *HeadGroupSpecApplyConfiguration {
+	b.ServicePorts = value
+	return b
+}
+
+// WithStatefulSet sets the StatefulSet field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StatefulSet field is set to the value of the last call.
+func (b *HeadGroupSpecApplyConfiguration) WithStatefulSet(value *HeadStatefulSetOptionsApplyConfiguration) *HeadGroupSpecApplyConfiguration {
+	b.StatefulSet = value
+	return b
+}
+
+// WithHealthProbes sets the HealthProbes field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the HealthProbes field is set to the value of the last call.
+func (b *HeadGroupSpecApplyConfiguration) WithHealthProbes(value *HeadHealthProbesApplyConfiguration) *HeadGroupSpecApplyConfiguration {
+	b.HealthProbes = value
+	return b
+}
@@ -2,18 +2,38 @@
 
 package v1
 
+import (
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
 // RayClusterSpecApplyConfiguration represents an declarative configuration of the RayClusterSpec type for use
 // with apply.
 type RayClusterSpecApplyConfiguration struct {
-	Suspend                  *bool                                       `json:"suspend,omitempty"`
-	ManagedBy                *string                                     `json:"managedBy,omitempty"`
-	AutoscalerOptions        *AutoscalerOptionsApplyConfiguration        `json:"autoscalerOptions,omitempty"`
-	HeadServiceAnnotations   map[string]string                           `json:"headServiceAnnotations,omitempty"`
-	EnableInTreeAutoscaling  *bool                                       `json:"enableInTreeAutoscaling,omitempty"`
-	GcsFaultToleranceOptions *GcsFaultToleranceOptionsApplyConfiguration `json:"gcsFaultToleranceOptions,omitempty"`
-	HeadGroupSpec            *HeadGroupSpecApplyConfiguration            `json:"headGroupSpec,omitempty"`
-	RayVersion               *string                                     `json:"rayVersion,omitempty"`
-	WorkerGroupSpecs         []WorkerGroupSpecApplyConfiguration         `json:"workerGroupSpecs,omitempty"`
+	Suspend                  *bool                                          `json:"suspend,omitempty"`
+	ManagedBy                *string                                        `json:"managedBy,omitempty"`
+	AutoscalerOptions        *AutoscalerOptionsApplyConfiguration           `json:"autoscalerOptions,omitempty"`
+	HeadServiceAnnotations   map[string]string                              `json:"headServiceAnnotations,omitempty"`
+	EnableInTreeAutoscaling  *bool                                          `json:"enableInTreeAutoscaling,omitempty"`
+	GcsFaultToleranceOptions *GcsFaultToleranceOptionsApplyConfiguration    `json:"gcsFaultToleranceOptions,omitempty"`
+	ServiceMeshOptions       *ServiceMeshOptionsApplyConfiguration          `json:"serviceMeshOptions,omitempty"`
+	IdleTimeoutSeconds       *int32                                         `json:"idleTimeoutSeconds,omitempty"`
+	IdleAction               *rayv1.IdleAction                              `json:"idleAction,omitempty"`
+	ImagePrePull             *ImagePrePullOptionsApplyConfiguration         `json:"imagePrePull,omitempty"`
+	DashboardIngress         *DashboardIngressOptionsApplyConfiguration     `json:"dashboardIngress,omitempty"`
+	Profiling                *ProfilingOptionsApplyConfiguration            `json:"profiling,omitempty"`
+	RayStartHooks            *RayStartHooksApplyConfiguration               `json:"rayStartHooks,omitempty"`
+	IPFamilyPolicy           *corev1.IPFamilyPolicy                         `json:"ipFamilyPolicy,omitempty"`
+	IPFamilies               []corev1.IPFamily                              `json:"ipFamilies,omitempty"`
+	ClusterDomain            *string                                        `json:"clusterDomain,omitempty"`
+	MetricsOptions           *MetricsOptionsApplyConfiguration              `json:"metricsOptions,omitempty"`
+	PrometheusMonitoring     *PrometheusMonitoringOptionsApplyConfiguration `json:"prometheusMonitoring,omitempty"`
+	TmpDirPolicy             *TmpDirPolicyApplyConfiguration                `json:"tmpDirPolicy,omitempty"`
+	MetadataSync             *MetadataSyncApplyConfiguration                `json:"metadataSync,omitempty"`
+	DedicatedServiceAccount  *bool                                          `json:"dedicatedServiceAccount,omitempty"`
+	HeadGroupSpec            *HeadGroupSpecApplyConfiguration               `json:"headGroupSpec,omitempty"`
+	RayVersion               *string                                        `json:"rayVersion,omitempty"`
+	WorkerGroupSpecs         []WorkerGroupSpecApplyConfiguration            `json:"workerGroupSpecs,omitempty"`
 }
 
 // RayClusterSpecApplyConfiguration constructs an declarative configuration of the RayClusterSpec type for use with
@@ -76,6 +96,128 @@ func (b *RayClusterSpecApplyConfiguration) WithGcsFaultToleranceOptions(value *G
 	return b
 }
 
+// WithServiceMeshOptions sets the ServiceMeshOptions field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServiceMeshOptions field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithServiceMeshOptions(value *ServiceMeshOptionsApplyConfiguration) *RayClusterSpecApplyConfiguration {
+	b.ServiceMeshOptions = value
+	return b
+}
+
+// WithIdleTimeoutSeconds sets the IdleTimeoutSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IdleTimeoutSeconds field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithIdleTimeoutSeconds(value int32) *RayClusterSpecApplyConfiguration {
+	b.IdleTimeoutSeconds = &value
+	return b
+}
+
+// WithIdleAction sets the IdleAction field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IdleAction field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithIdleAction(value rayv1.IdleAction) *RayClusterSpecApplyConfiguration {
+	b.IdleAction = &value
+	return b
+}
+
+// WithImagePrePull sets the ImagePrePull field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ImagePrePull field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithImagePrePull(value *ImagePrePullOptionsApplyConfiguration) *RayClusterSpecApplyConfiguration {
+	b.ImagePrePull = value
+	return b
+}
+
+// WithDashboardIngress sets the DashboardIngress field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DashboardIngress field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithDashboardIngress(value *DashboardIngressOptionsApplyConfiguration) *RayClusterSpecApplyConfiguration {
+	b.DashboardIngress = value
+	return b
+}
+
+// WithProfiling sets the Profiling field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Profiling field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithProfiling(value *ProfilingOptionsApplyConfiguration) *RayClusterSpecApplyConfiguration {
+	b.Profiling = value
+	return b
+}
+
+// WithRayStartHooks sets the RayStartHooks field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RayStartHooks field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithRayStartHooks(value *RayStartHooksApplyConfiguration) *RayClusterSpecApplyConfiguration {
+	b.RayStartHooks = value
+	return b
+}
+
+// WithIPFamilyPolicy sets the IPFamilyPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the IPFamilyPolicy field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithIPFamilyPolicy(value corev1.IPFamilyPolicy) *RayClusterSpecApplyConfiguration {
+	b.IPFamilyPolicy = &value
+	return b
+}
+
+// WithIPFamilies adds the given value to the IPFamilies field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the IPFamilies field.
+func (b *RayClusterSpecApplyConfiguration) WithIPFamilies(values ...corev1.IPFamily) *RayClusterSpecApplyConfiguration {
+	for i := range values {
+		b.IPFamilies = append(b.IPFamilies, values[i])
+	}
+	return b
+}
+
+// WithClusterDomain sets the ClusterDomain field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ClusterDomain field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithClusterDomain(value string) *RayClusterSpecApplyConfiguration {
+	b.ClusterDomain = &value
+	return b
+}
+
+// WithMetricsOptions sets the MetricsOptions field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MetricsOptions field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithMetricsOptions(value *MetricsOptionsApplyConfiguration) *RayClusterSpecApplyConfiguration {
+	b.MetricsOptions = value
+	return b
+}
+
+// WithPrometheusMonitoring sets the PrometheusMonitoring field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PrometheusMonitoring field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithPrometheusMonitoring(value *PrometheusMonitoringOptionsApplyConfiguration) *RayClusterSpecApplyConfiguration {
+	b.PrometheusMonitoring = value
+	return b
+}
+
+// WithTmpDirPolicy sets the TmpDirPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TmpDirPolicy field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithTmpDirPolicy(value *TmpDirPolicyApplyConfiguration) *RayClusterSpecApplyConfiguration {
+	b.TmpDirPolicy = value
+	return b
+}
+
+// WithMetadataSync sets the MetadataSync field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MetadataSync field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithMetadataSync(value *MetadataSyncApplyConfiguration) *RayClusterSpecApplyConfiguration {
+	b.MetadataSync = value
+	return b
+}
+
+// WithDedicatedServiceAccount sets the DedicatedServiceAccount field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DedicatedServiceAccount field is set to the value of the last call.
+func (b *RayClusterSpecApplyConfiguration) WithDedicatedServiceAccount(value bool) *RayClusterSpecApplyConfiguration {
+	b.DedicatedServiceAccount = &value
+	return b
+}
+
 // WithHeadGroupSpec sets the HeadGroupSpec field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the HeadGroupSpec field is set to the value of the last call.
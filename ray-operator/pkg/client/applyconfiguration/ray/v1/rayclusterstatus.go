@@ -11,23 +11,28 @@ import (
 // RayClusterStatusApplyConfiguration represents an declarative configuration of the RayClusterStatus type for use
 // with apply.
 type RayClusterStatusApplyConfiguration struct {
-	State                   *v1.ClusterState                 `json:"state,omitempty"`
-	DesiredCPU              *resource.Quantity               `json:"desiredCPU,omitempty"`
-	DesiredMemory           *resource.Quantity               `json:"desiredMemory,omitempty"`
-	DesiredGPU              *resource.Quantity               `json:"desiredGPU,omitempty"`
-	DesiredTPU              *resource.Quantity               `json:"desiredTPU,omitempty"`
-	LastUpdateTime          *metav1.Time                     `json:"lastUpdateTime,omitempty"`
-	StateTransitionTimes    map[v1.ClusterState]*metav1.Time `json:"stateTransitionTimes,omitempty"`
-	Endpoints               map[string]string                `json:"endpoints,omitempty"`
-	Head                    *HeadInfoApplyConfiguration      `json:"head,omitempty"`
-	Reason                  *string                          `json:"reason,omitempty"`
-	Conditions              []metav1.Condition               `json:"conditions,omitempty"`
-	ReadyWorkerReplicas     *int32                           `json:"readyWorkerReplicas,omitempty"`
-	AvailableWorkerReplicas *int32                           `json:"availableWorkerReplicas,omitempty"`
-	DesiredWorkerReplicas   *int32                           `json:"desiredWorkerReplicas,omitempty"`
-	MinWorkerReplicas       *int32                           `json:"minWorkerReplicas,omitempty"`
-	MaxWorkerReplicas       *int32                           `json:"maxWorkerReplicas,omitempty"`
-	ObservedGeneration      *int64                           `json:"observedGeneration,omitempty"`
+	State                   *v1.ClusterState                        `json:"state,omitempty"`
+	DesiredCPU              *resource.Quantity                      `json:"desiredCPU,omitempty"`
+	DesiredMemory           *resource.Quantity                      `json:"desiredMemory,omitempty"`
+	DesiredGPU              *resource.Quantity                      `json:"desiredGPU,omitempty"`
+	DesiredTPU              *resource.Quantity                      `json:"desiredTPU,omitempty"`
+	LastUpdateTime          *metav1.Time                            `json:"lastUpdateTime,omitempty"`
+	StateTransitionTimes    map[v1.ClusterState]*metav1.Time        `json:"stateTransitionTimes,omitempty"`
+	Endpoints               map[string]string                       `json:"endpoints,omitempty"`
+	Head                    *HeadInfoApplyConfiguration             `json:"head,omitempty"`
+	Reason                  *string                                 `json:"reason,omitempty"`
+	Conditions              []metav1.Condition                      `json:"conditions,omitempty"`
+	ReadyWorkerReplicas     *int32                                  `json:"readyWorkerReplicas,omitempty"`
+	AvailableWorkerReplicas *int32                                  `json:"availableWorkerReplicas,omitempty"`
+	DesiredWorkerReplicas   *int32                                  `json:"desiredWorkerReplicas,omitempty"`
+	MinWorkerReplicas       *int32                                  `json:"minWorkerReplicas,omitempty"`
+	MaxWorkerReplicas       *int32                                  `json:"maxWorkerReplicas,omitempty"`
+	ObservedGeneration      *int64                                  `json:"observedGeneration,omitempty"`
+	PendingResourceDemands  []ResourceDemandApplyConfiguration      `json:"pendingResourceDemands,omitempty"`
+	LastActivityTime        *metav1.Time                            `json:"lastActivityTime,omitempty"`
+	LastReconcileError      *ReconcileErrorApplyConfiguration       `json:"lastReconcileError,omitempty"`
+	MemoryFailures          []GroupMemoryFailuresApplyConfiguration `json:"memoryFailures,omitempty"`
+	Journal                 []JournalEntryApplyConfiguration        `json:"journal,omitempty"`
 }
 
 // RayClusterStatusApplyConfiguration constructs an declarative configuration of the RayClusterStatus type for use with
@@ -185,3 +190,58 @@ func (b *RayClusterStatusApplyConfiguration) WithObservedGeneration(value int64)
 	b.ObservedGeneration = &value
 	return b
 }
+
+// WithPendingResourceDemands adds the given value to the PendingResourceDemands field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the PendingResourceDemands field.
+func (b *RayClusterStatusApplyConfiguration) WithPendingResourceDemands(values ...*ResourceDemandApplyConfiguration) *RayClusterStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithPendingResourceDemands")
+		}
+		b.PendingResourceDemands = append(b.PendingResourceDemands, *values[i])
+	}
+	return b
+}
+
+// WithLastActivityTime sets the LastActivityTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastActivityTime field is set to the value of the last call.
+func (b *RayClusterStatusApplyConfiguration) WithLastActivityTime(value metav1.Time) *RayClusterStatusApplyConfiguration {
+	b.LastActivityTime = &value
+	return b
+}
+
+// WithLastReconcileError sets the LastReconcileError field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastReconcileError field is set to the value of the last call.
+func (b *RayClusterStatusApplyConfiguration) WithLastReconcileError(value *ReconcileErrorApplyConfiguration) *RayClusterStatusApplyConfiguration {
+	b.LastReconcileError = value
+	return b
+}
+
+// WithMemoryFailures adds the given value to the MemoryFailures field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the MemoryFailures field.
+func (b *RayClusterStatusApplyConfiguration) WithMemoryFailures(values ...*GroupMemoryFailuresApplyConfiguration) *RayClusterStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithMemoryFailures")
+		}
+		b.MemoryFailures = append(b.MemoryFailures, *values[i])
+	}
+	return b
+}
+
+// WithJournal adds the given value to the Journal field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Journal field.
+func (b *RayClusterStatusApplyConfiguration) WithJournal(values ...*JournalEntryApplyConfiguration) *RayClusterStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithJournal")
+		}
+		b.Journal = append(b.Journal, *values[i])
+	}
+	return b
+}
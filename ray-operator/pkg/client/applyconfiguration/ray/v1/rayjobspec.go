@@ -10,25 +10,28 @@ import (
 // RayJobSpecApplyConfiguration represents an declarative configuration of the RayJobSpec type for use
 // with apply.
 type RayJobSpecApplyConfiguration struct {
-	ActiveDeadlineSeconds    *int32                                    `json:"activeDeadlineSeconds,omitempty"`
-	BackoffLimit             *int32                                    `json:"backoffLimit,omitempty"`
-	RayClusterSpec           *RayClusterSpecApplyConfiguration         `json:"rayClusterSpec,omitempty"`
-	SubmitterPodTemplate     *corev1.PodTemplateSpecApplyConfiguration `json:"submitterPodTemplate,omitempty"`
-	Metadata                 map[string]string                         `json:"metadata,omitempty"`
-	ClusterSelector          map[string]string                         `json:"clusterSelector,omitempty"`
-	SubmitterConfig          *SubmitterConfigApplyConfiguration        `json:"submitterConfig,omitempty"`
-	ManagedBy                *string                                   `json:"managedBy,omitempty"`
-	DeletionPolicy           *rayv1.DeletionPolicy                     `json:"deletionPolicy,omitempty"`
-	Entrypoint               *string                                   `json:"entrypoint,omitempty"`
-	RuntimeEnvYAML           *string                                   `json:"runtimeEnvYAML,omitempty"`
-	JobId                    *string                                   `json:"jobId,omitempty"`
-	SubmissionMode           *rayv1.JobSubmissionMode                  `json:"submissionMode,omitempty"`
-	EntrypointResources      *string                                   `json:"entrypointResources,omitempty"`
-	EntrypointNumCpus        *float32                                  `json:"entrypointNumCpus,omitempty"`
-	EntrypointNumGpus        *float32                                  `json:"entrypointNumGpus,omitempty"`
-	TTLSecondsAfterFinished  *int32                                    `json:"ttlSecondsAfterFinished,omitempty"`
-	ShutdownAfterJobFinishes *bool                                     `json:"shutdownAfterJobFinishes,omitempty"`
-	Suspend                  *bool                                     `json:"suspend,omitempty"`
+	ActiveDeadlineSeconds    *int32                                          `json:"activeDeadlineSeconds,omitempty"`
+	BackoffLimit             *int32                                          `json:"backoffLimit,omitempty"`
+	RayClusterSpec           *RayClusterSpecApplyConfiguration               `json:"rayClusterSpec,omitempty"`
+	SubmitterPodTemplate     *corev1.PodTemplateSpecApplyConfiguration       `json:"submitterPodTemplate,omitempty"`
+	ImagePullSecrets         []corev1.LocalObjectReferenceApplyConfiguration `json:"imagePullSecrets,omitempty"`
+	Metadata                 map[string]string                               `json:"metadata,omitempty"`
+	ClusterSelector          map[string]string                               `json:"clusterSelector,omitempty"`
+	SubmitterConfig          *SubmitterConfigApplyConfiguration              `json:"submitterConfig,omitempty"`
+	ManagedBy                *string                                         `json:"managedBy,omitempty"`
+	DeletionPolicy           *rayv1.DeletionPolicy                           `json:"deletionPolicy,omitempty"`
+	Entrypoint               *string                                         `json:"entrypoint,omitempty"`
+	RuntimeEnvYAML           *string                                         `json:"runtimeEnvYAML,omitempty"`
+	JobId                    *string                                         `json:"jobId,omitempty"`
+	SubmissionMode           *rayv1.JobSubmissionMode                        `json:"submissionMode,omitempty"`
+	InteractiveSession       *InteractiveSessionOptionsApplyConfiguration    `json:"interactiveSession,omitempty"`
+	Checkpointing            *CheckpointingOptionsApplyConfiguration         `json:"checkpointing,omitempty"`
+	EntrypointResources      *string                                         `json:"entrypointResources,omitempty"`
+	EntrypointNumCpus        *float32                                        `json:"entrypointNumCpus,omitempty"`
+	EntrypointNumGpus        *float32                                        `json:"entrypointNumGpus,omitempty"`
+	TTLSecondsAfterFinished  *int32                                          `json:"ttlSecondsAfterFinished,omitempty"`
+	ShutdownAfterJobFinishes *bool                                           `json:"shutdownAfterJobFinishes,omitempty"`
+	Suspend                  *bool                                           `json:"suspend,omitempty"`
 }
 
 // RayJobSpecApplyConfiguration constructs an declarative configuration of the RayJobSpec type for use with
@@ -69,6 +72,19 @@ func (b *RayJobSpecApplyConfiguration) WithSubmitterPodTemplate(value *corev1.Po
 	return b
 }
 
+// WithImagePullSecrets adds the given value to the ImagePullSecrets field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ImagePullSecrets field.
+func (b *RayJobSpecApplyConfiguration) WithImagePullSecrets(values ...*corev1.LocalObjectReferenceApplyConfiguration) *RayJobSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithImagePullSecrets")
+		}
+		b.ImagePullSecrets = append(b.ImagePullSecrets, *values[i])
+	}
+	return b
+}
+
 // WithMetadata puts the entries into the Metadata field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, the entries provided by each call will be put on the Metadata field,
@@ -153,6 +169,22 @@ func (b *RayJobSpecApplyConfiguration) WithSubmissionMode(value rayv1.JobSubmiss
 	return b
 }
 
+// WithInteractiveSession sets the InteractiveSession field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the InteractiveSession field is set to the value of the last call.
+func (b *RayJobSpecApplyConfiguration) WithInteractiveSession(value *InteractiveSessionOptionsApplyConfiguration) *RayJobSpecApplyConfiguration {
+	b.InteractiveSession = value
+	return b
+}
+
+// WithCheckpointing sets the Checkpointing field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Checkpointing field is set to the value of the last call.
+func (b *RayJobSpecApplyConfiguration) WithCheckpointing(value *CheckpointingOptionsApplyConfiguration) *RayJobSpecApplyConfiguration {
+	b.Checkpointing = value
+	return b
+}
+
 // WithEntrypointResources sets the EntrypointResources field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the EntrypointResources field is set to the value of the last call.
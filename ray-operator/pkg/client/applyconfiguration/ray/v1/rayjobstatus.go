@@ -10,19 +10,25 @@ import (
 // RayJobStatusApplyConfiguration represents an declarative configuration of the RayJobStatus type for use
 // with apply.
 type RayJobStatusApplyConfiguration struct {
-	JobId               *string                             `json:"jobId,omitempty"`
-	RayClusterName      *string                             `json:"rayClusterName,omitempty"`
-	DashboardURL        *string                             `json:"dashboardURL,omitempty"`
-	JobStatus           *v1.JobStatus                       `json:"jobStatus,omitempty"`
-	JobDeploymentStatus *v1.JobDeploymentStatus             `json:"jobDeploymentStatus,omitempty"`
-	Reason              *v1.JobFailedReason                 `json:"reason,omitempty"`
-	Message             *string                             `json:"message,omitempty"`
-	StartTime           *metav1.Time                        `json:"startTime,omitempty"`
-	EndTime             *metav1.Time                        `json:"endTime,omitempty"`
-	Succeeded           *int32                              `json:"succeeded,omitempty"`
-	Failed              *int32                              `json:"failed,omitempty"`
-	RayClusterStatus    *RayClusterStatusApplyConfiguration `json:"rayClusterStatus,omitempty"`
-	ObservedGeneration  *int64                              `json:"observedGeneration,omitempty"`
+	JobId                    *string                                     `json:"jobId,omitempty"`
+	RayClusterName           *string                                     `json:"rayClusterName,omitempty"`
+	DashboardURL             *string                                     `json:"dashboardURL,omitempty"`
+	JobStatus                *v1.JobStatus                               `json:"jobStatus,omitempty"`
+	JobDeploymentStatus      *v1.JobDeploymentStatus                     `json:"jobDeploymentStatus,omitempty"`
+	Reason                   *v1.JobFailedReason                         `json:"reason,omitempty"`
+	Message                  *string                                     `json:"message,omitempty"`
+	StartTime                *metav1.Time                                `json:"startTime,omitempty"`
+	EndTime                  *metav1.Time                                `json:"endTime,omitempty"`
+	Succeeded                *int32                                      `json:"succeeded,omitempty"`
+	Failed                   *int32                                      `json:"failed,omitempty"`
+	RayClusterStatus         *RayClusterStatusApplyConfiguration         `json:"rayClusterStatus,omitempty"`
+	ResumedFromCheckpointURI *string                                     `json:"resumedFromCheckpointURI,omitempty"`
+	Session                  *InteractiveSessionStatusApplyConfiguration `json:"session,omitempty"`
+	ResourceUsage            *ResourceUsageApplyConfiguration            `json:"resourceUsage,omitempty"`
+	ObservedGeneration       *int64                                      `json:"observedGeneration,omitempty"`
+	LastReconcileError       *ReconcileErrorApplyConfiguration           `json:"lastReconcileError,omitempty"`
+	Journal                  []JournalEntryApplyConfiguration            `json:"journal,omitempty"`
+	Conditions               []metav1.Condition                          `json:"conditions,omitempty"`
 }
 
 // RayJobStatusApplyConfiguration constructs an declarative configuration of the RayJobStatus type for use with
@@ -127,6 +133,30 @@ func (b *RayJobStatusApplyConfiguration) WithRayClusterStatus(value *RayClusterS
 	return b
 }
 
+// WithResumedFromCheckpointURI sets the ResumedFromCheckpointURI field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ResumedFromCheckpointURI field is set to the value of the last call.
+func (b *RayJobStatusApplyConfiguration) WithResumedFromCheckpointURI(value string) *RayJobStatusApplyConfiguration {
+	b.ResumedFromCheckpointURI = &value
+	return b
+}
+
+// WithSession sets the Session field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Session field is set to the value of the last call.
+func (b *RayJobStatusApplyConfiguration) WithSession(value *InteractiveSessionStatusApplyConfiguration) *RayJobStatusApplyConfiguration {
+	b.Session = value
+	return b
+}
+
+// WithResourceUsage sets the ResourceUsage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ResourceUsage field is set to the value of the last call.
+func (b *RayJobStatusApplyConfiguration) WithResourceUsage(value *ResourceUsageApplyConfiguration) *RayJobStatusApplyConfiguration {
+	b.ResourceUsage = value
+	return b
+}
+
 // WithObservedGeneration sets the ObservedGeneration field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the ObservedGeneration field is set to the value of the last call.
@@ -134,3 +164,34 @@ func (b *RayJobStatusApplyConfiguration) WithObservedGeneration(value int64) *Ra
 	b.ObservedGeneration = &value
 	return b
 }
+
+// WithLastReconcileError sets the LastReconcileError field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastReconcileError field is set to the value of the last call.
+func (b *RayJobStatusApplyConfiguration) WithLastReconcileError(value *ReconcileErrorApplyConfiguration) *RayJobStatusApplyConfiguration {
+	b.LastReconcileError = value
+	return b
+}
+
+// WithJournal adds the given value to the Journal field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Journal field.
+func (b *RayJobStatusApplyConfiguration) WithJournal(values ...*JournalEntryApplyConfiguration) *RayJobStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithJournal")
+		}
+		b.Journal = append(b.Journal, *values[i])
+	}
+	return b
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *RayJobStatusApplyConfiguration) WithConditions(values ...metav1.Condition) *RayJobStatusApplyConfiguration {
+	for i := range values {
+		b.Conditions = append(b.Conditions, values[i])
+	}
+	return b
+}
@@ -3,19 +3,39 @@
 package v1
 
 import (
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
 	v1 "k8s.io/api/core/v1"
+	corev1 "k8s.io/client-go/applyconfigurations/core/v1"
 )
 
 // RayServiceSpecApplyConfiguration represents an declarative configuration of the RayServiceSpec type for use
 // with apply.
 type RayServiceSpecApplyConfiguration struct {
-	ServiceUnhealthySecondThreshold    *int32                                       `json:"serviceUnhealthySecondThreshold,omitempty"`
-	DeploymentUnhealthySecondThreshold *int32                                       `json:"deploymentUnhealthySecondThreshold,omitempty"`
-	ServeService                       *v1.Service                                  `json:"serveService,omitempty"`
-	UpgradeStrategy                    *RayServiceUpgradeStrategyApplyConfiguration `json:"upgradeStrategy,omitempty"`
-	ServeConfigV2                      *string                                      `json:"serveConfigV2,omitempty"`
-	RayClusterSpec                     *RayClusterSpecApplyConfiguration            `json:"rayClusterConfig,omitempty"`
-	ExcludeHeadPodFromServeSvc         *bool                                        `json:"excludeHeadPodFromServeSvc,omitempty"`
+	ServiceUnhealthySecondThreshold    *int32                                          `json:"serviceUnhealthySecondThreshold,omitempty"`
+	DeploymentUnhealthySecondThreshold *int32                                          `json:"deploymentUnhealthySecondThreshold,omitempty"`
+	ServeService                       *v1.Service                                     `json:"serveService,omitempty"`
+	ServeSessionAffinity               *ServeSessionAffinityApplyConfiguration         `json:"serveSessionAffinity,omitempty"`
+	ServeTopologyRouting               *ServeTopologyRoutingApplyConfiguration         `json:"serveTopologyRouting,omitempty"`
+	ServeProbe                         *ServeProbeApplyConfiguration                   `json:"serveProbe,omitempty"`
+	ServeReadiness                     *ServeReadinessApplyConfiguration               `json:"serveReadiness,omitempty"`
+	ServeProxyHealthCheck              *ServeProxyHealthCheckApplyConfiguration        `json:"serveProxyHealthCheck,omitempty"`
+	ServeAlerting                      *ServeAlertingOptionsApplyConfiguration         `json:"serveAlerting,omitempty"`
+	FailoverPolicy                     *FailoverPolicyApplyConfiguration               `json:"failoverPolicy,omitempty"`
+	UpgradeStrategy                    *RayServiceUpgradeStrategyApplyConfiguration    `json:"upgradeStrategy,omitempty"`
+	ServeConfigV2                      *string                                         `json:"serveConfigV2,omitempty"`
+	RayClusterSpec                     *RayClusterSpecApplyConfiguration               `json:"rayClusterConfig,omitempty"`
+	ServeConfigSource                  *ServeConfigSourceApplyConfiguration            `json:"serveConfigSource,omitempty"`
+	ServeConfigV2Variables             []corev1.EnvFromSourceApplyConfiguration        `json:"serveConfigV2Variables,omitempty"`
+	ServeApplicationWorkerGroups       map[string]string                               `json:"serveApplicationWorkerGroups,omitempty"`
+	ExcludeHeadPodFromServeSvc         *bool                                           `json:"excludeHeadPodFromServeSvc,omitempty"`
+	WorkerServeProxyHealthCheck        *bool                                           `json:"workerServeProxyHealthCheck,omitempty"`
+	ImagePullSecrets                   []corev1.LocalObjectReferenceApplyConfiguration `json:"imagePullSecrets,omitempty"`
+	PriorityClassName                  *string                                         `json:"priorityClassName,omitempty"`
+	SchedulerName                      *string                                         `json:"schedulerName,omitempty"`
+	QueueLabels                        map[string]string                               `json:"queueLabels,omitempty"`
+	ServiceReconcileMode               *rayv1.ServiceReconcileMode                     `json:"serviceReconcileMode,omitempty"`
+	DisableDashboard                   *bool                                           `json:"disableDashboard,omitempty"`
+	MaintenanceMode                    *bool                                           `json:"maintenanceMode,omitempty"`
 }
 
 // RayServiceSpecApplyConfiguration constructs an declarative configuration of the RayServiceSpec type for use with
@@ -48,6 +68,62 @@ func (b *RayServiceSpecApplyConfiguration) WithServeService(value v1.Service) *R
 	return b
 }
 
+// WithServeSessionAffinity sets the ServeSessionAffinity field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServeSessionAffinity field is set to the value of the last call.
+func (b *RayServiceSpecApplyConfiguration) WithServeSessionAffinity(value *ServeSessionAffinityApplyConfiguration) *RayServiceSpecApplyConfiguration {
+	b.ServeSessionAffinity = value
+	return b
+}
+
+// WithServeTopologyRouting sets the ServeTopologyRouting field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServeTopologyRouting field is set to the value of the last call.
+func (b *RayServiceSpecApplyConfiguration) WithServeTopologyRouting(value *ServeTopologyRoutingApplyConfiguration) *RayServiceSpecApplyConfiguration {
+	b.ServeTopologyRouting = value
+	return b
+}
+
+// WithServeProbe sets the ServeProbe field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServeProbe field is set to the value of the last call.
+func (b *RayServiceSpecApplyConfiguration) WithServeProbe(value *ServeProbeApplyConfiguration) *RayServiceSpecApplyConfiguration {
+	b.ServeProbe = value
+	return b
+}
+
+// WithServeReadiness sets the ServeReadiness field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServeReadiness field is set to the value of the last call.
+func (b *RayServiceSpecApplyConfiguration) WithServeReadiness(value *ServeReadinessApplyConfiguration) *RayServiceSpecApplyConfiguration {
+	b.ServeReadiness = value
+	return b
+}
+
+// WithServeProxyHealthCheck sets the ServeProxyHealthCheck field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServeProxyHealthCheck field is set to the value of the last call.
+func (b *RayServiceSpecApplyConfiguration) WithServeProxyHealthCheck(value *ServeProxyHealthCheckApplyConfiguration) *RayServiceSpecApplyConfiguration {
+	b.ServeProxyHealthCheck = value
+	return b
+}
+
+// WithServeAlerting sets the ServeAlerting field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServeAlerting field is set to the value of the last call.
+func (b *RayServiceSpecApplyConfiguration) WithServeAlerting(value *ServeAlertingOptionsApplyConfiguration) *RayServiceSpecApplyConfiguration {
+	b.ServeAlerting = value
+	return b
+}
+
+// WithFailoverPolicy sets the FailoverPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FailoverPolicy field is set to the value of the last call.
+func (b *RayServiceSpecApplyConfiguration) WithFailoverPolicy(value *FailoverPolicyApplyConfiguration) *RayServiceSpecApplyConfiguration {
+	b.FailoverPolicy = value
+	return b
+}
+
 // WithUpgradeStrategy sets the UpgradeStrategy field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the UpgradeStrategy field is set to the value of the last call.
@@ -72,6 +148,41 @@ func (b *RayServiceSpecApplyConfiguration) WithRayClusterSpec(value *RayClusterS
 	return b
 }
 
+// WithServeConfigSource sets the ServeConfigSource field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServeConfigSource field is set to the value of the last call.
+func (b *RayServiceSpecApplyConfiguration) WithServeConfigSource(value *ServeConfigSourceApplyConfiguration) *RayServiceSpecApplyConfiguration {
+	b.ServeConfigSource = value
+	return b
+}
+
+// WithServeConfigV2Variables adds the given value to the ServeConfigV2Variables field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ServeConfigV2Variables field.
+func (b *RayServiceSpecApplyConfiguration) WithServeConfigV2Variables(values ...*corev1.EnvFromSourceApplyConfiguration) *RayServiceSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithServeConfigV2Variables")
+		}
+		b.ServeConfigV2Variables = append(b.ServeConfigV2Variables, *values[i])
+	}
+	return b
+}
+
+// WithServeApplicationWorkerGroups puts the entries into the ServeApplicationWorkerGroups field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the ServeApplicationWorkerGroups field,
+// overwriting an existing map entries in ServeApplicationWorkerGroups field with the same key.
+func (b *RayServiceSpecApplyConfiguration) WithServeApplicationWorkerGroups(entries map[string]string) *RayServiceSpecApplyConfiguration {
+	if b.ServeApplicationWorkerGroups == nil && len(entries) > 0 {
+		b.ServeApplicationWorkerGroups = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.ServeApplicationWorkerGroups[k] = v
+	}
+	return b
+}
+
 // WithExcludeHeadPodFromServeSvc sets the ExcludeHeadPodFromServeSvc field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the ExcludeHeadPodFromServeSvc field is set to the value of the last call.
@@ -79,3 +190,78 @@ func (b *RayServiceSpecApplyConfiguration) WithExcludeHeadPodFromServeSvc(value
 	b.ExcludeHeadPodFromServeSvc = &value
 	return b
 }
+
+// WithWorkerServeProxyHealthCheck sets the WorkerServeProxyHealthCheck field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the WorkerServeProxyHealthCheck field is set to the value of the last call.
+func (b *RayServiceSpecApplyConfiguration) WithWorkerServeProxyHealthCheck(value bool) *RayServiceSpecApplyConfiguration {
+	b.WorkerServeProxyHealthCheck = &value
+	return b
+}
+
+// WithImagePullSecrets adds the given value to the ImagePullSecrets field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ImagePullSecrets field.
+func (b *RayServiceSpecApplyConfiguration) WithImagePullSecrets(values ...*corev1.LocalObjectReferenceApplyConfiguration) *RayServiceSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithImagePullSecrets")
+		}
+		b.ImagePullSecrets = append(b.ImagePullSecrets, *values[i])
+	}
+	return b
+}
+
+// WithPriorityClassName sets the PriorityClassName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PriorityClassName field is set to the value of the last call.
+func (b *RayServiceSpecApplyConfiguration) WithPriorityClassName(value string) *RayServiceSpecApplyConfiguration {
+	b.PriorityClassName = &value
+	return b
+}
+
+// WithSchedulerName sets the SchedulerName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SchedulerName field is set to the value of the last call.
+func (b *RayServiceSpecApplyConfiguration) WithSchedulerName(value string) *RayServiceSpecApplyConfiguration {
+	b.SchedulerName = &value
+	return b
+}
+
+// WithQueueLabels puts the entries into the QueueLabels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the QueueLabels field,
+// overwriting an existing map entries in QueueLabels field with the same key.
+func (b *RayServiceSpecApplyConfiguration) WithQueueLabels(entries map[string]string) *RayServiceSpecApplyConfiguration {
+	if b.QueueLabels == nil && len(entries) > 0 {
+		b.QueueLabels = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.QueueLabels[k] = v
+	}
+	return b
+}
+
+// WithServiceReconcileMode sets the ServiceReconcileMode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServiceReconcileMode field is set to the value of the last call.
+func (b *RayServiceSpecApplyConfiguration) WithServiceReconcileMode(value rayv1.ServiceReconcileMode) *RayServiceSpecApplyConfiguration {
+	b.ServiceReconcileMode = &value
+	return b
+}
+
+// WithDisableDashboard sets the DisableDashboard field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DisableDashboard field is set to the value of the last call.
+func (b *RayServiceSpecApplyConfiguration) WithDisableDashboard(value bool) *RayServiceSpecApplyConfiguration {
+	b.DisableDashboard = &value
+	return b
+}
+
+// WithMaintenanceMode sets the MaintenanceMode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaintenanceMode field is set to the value of the last call.
+func (b *RayServiceSpecApplyConfiguration) WithMaintenanceMode(value bool) *RayServiceSpecApplyConfiguration {
+	b.MaintenanceMode = &value
+	return b
+}
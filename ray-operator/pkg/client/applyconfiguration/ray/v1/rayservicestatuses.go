@@ -10,12 +10,20 @@ import (
 // RayServiceStatusesApplyConfiguration represents an declarative configuration of the RayServiceStatuses type for use
 // with apply.
 type RayServiceStatusesApplyConfiguration struct {
-	LastUpdateTime       *v1.Time                            `json:"lastUpdateTime,omitempty"`
-	ServiceStatus        *rayv1.ServiceStatus                `json:"serviceStatus,omitempty"`
-	ActiveServiceStatus  *RayServiceStatusApplyConfiguration `json:"activeServiceStatus,omitempty"`
-	PendingServiceStatus *RayServiceStatusApplyConfiguration `json:"pendingServiceStatus,omitempty"`
-	NumServeEndpoints    *int32                              `json:"numServeEndpoints,omitempty"`
-	ObservedGeneration   *int64                              `json:"observedGeneration,omitempty"`
+	LastUpdateTime       *v1.Time                                   `json:"lastUpdateTime,omitempty"`
+	ServiceStatus        *rayv1.ServiceStatus                       `json:"serviceStatus,omitempty"`
+	ActiveServiceStatus  *RayServiceStatusApplyConfiguration        `json:"activeServiceStatus,omitempty"`
+	PendingServiceStatus *RayServiceStatusApplyConfiguration        `json:"pendingServiceStatus,omitempty"`
+	NumServeEndpoints    *int32                                     `json:"numServeEndpoints,omitempty"`
+	HeadServiceName      *string                                    `json:"headServiceName,omitempty"`
+	ServeServiceName     *string                                    `json:"serveServiceName,omitempty"`
+	ObservedGeneration   *int64                                     `json:"observedGeneration,omitempty"`
+	LastReconcileError   *ReconcileErrorApplyConfiguration          `json:"lastReconcileError,omitempty"`
+	Journal              []JournalEntryApplyConfiguration           `json:"journal,omitempty"`
+	RequestShadowing     *RequestShadowingStatusApplyConfiguration  `json:"requestShadowing,omitempty"`
+	ServeConfigSource    *ServeConfigSourceStatusApplyConfiguration `json:"serveConfigSource,omitempty"`
+	ResourceUsage        *ResourceUsageApplyConfiguration           `json:"resourceUsage,omitempty"`
+	Conditions           []v1.Condition                             `json:"conditions,omitempty"`
 }
 
 // RayServiceStatusesApplyConfiguration constructs an declarative configuration of the RayServiceStatuses type for use with
@@ -64,6 +72,22 @@ func (b *RayServiceStatusesApplyConfiguration) WithNumServeEndpoints(value int32
 	return b
 }
 
+// WithHeadServiceName sets the HeadServiceName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the HeadServiceName field is set to the value of the last call.
+func (b *RayServiceStatusesApplyConfiguration) WithHeadServiceName(value string) *RayServiceStatusesApplyConfiguration {
+	b.HeadServiceName = &value
+	return b
+}
+
+// WithServeServiceName sets the ServeServiceName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServeServiceName field is set to the value of the last call.
+func (b *RayServiceStatusesApplyConfiguration) WithServeServiceName(value string) *RayServiceStatusesApplyConfiguration {
+	b.ServeServiceName = &value
+	return b
+}
+
 // WithObservedGeneration sets the ObservedGeneration field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the ObservedGeneration field is set to the value of the last call.
@@ -71,3 +95,58 @@ func (b *RayServiceStatusesApplyConfiguration) WithObservedGeneration(value int6
 	b.ObservedGeneration = &value
 	return b
 }
+
+// WithLastReconcileError sets the LastReconcileError field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastReconcileError field is set to the value of the last call.
+func (b *RayServiceStatusesApplyConfiguration) WithLastReconcileError(value *ReconcileErrorApplyConfiguration) *RayServiceStatusesApplyConfiguration {
+	b.LastReconcileError = value
+	return b
+}
+
+// WithJournal adds the given value to the Journal field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Journal field.
+func (b *RayServiceStatusesApplyConfiguration) WithJournal(values ...*JournalEntryApplyConfiguration) *RayServiceStatusesApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithJournal")
+		}
+		b.Journal = append(b.Journal, *values[i])
+	}
+	return b
+}
+
+// WithRequestShadowing sets the RequestShadowing field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RequestShadowing field is set to the value of the last call.
+func (b *RayServiceStatusesApplyConfiguration) WithRequestShadowing(value *RequestShadowingStatusApplyConfiguration) *RayServiceStatusesApplyConfiguration {
+	b.RequestShadowing = value
+	return b
+}
+
+// WithServeConfigSource sets the ServeConfigSource field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ServeConfigSource field is set to the value of the last call.
+func (b *RayServiceStatusesApplyConfiguration) WithServeConfigSource(value *ServeConfigSourceStatusApplyConfiguration) *RayServiceStatusesApplyConfiguration {
+	b.ServeConfigSource = value
+	return b
+}
+
+// WithResourceUsage sets the ResourceUsage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ResourceUsage field is set to the value of the last call.
+func (b *RayServiceStatusesApplyConfiguration) WithResourceUsage(value *ResourceUsageApplyConfiguration) *RayServiceStatusesApplyConfiguration {
+	b.ResourceUsage = value
+	return b
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *RayServiceStatusesApplyConfiguration) WithConditions(values ...v1.Condition) *RayServiceStatusesApplyConfiguration {
+	for i := range values {
+		b.Conditions = append(b.Conditions, values[i])
+	}
+	return b
+}
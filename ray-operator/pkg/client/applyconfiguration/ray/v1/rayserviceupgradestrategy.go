@@ -9,7 +9,13 @@ import (
 // RayServiceUpgradeStrategyApplyConfiguration represents an declarative configuration of the RayServiceUpgradeStrategy type for use
 // with apply.
 type RayServiceUpgradeStrategyApplyConfiguration struct {
-	Type *v1.RayServiceUpgradeType `json:"type,omitempty"`
+	Type                 *v1.RayServiceUpgradeType           `json:"type,omitempty"`
+	EnablePreviewService *bool                               `json:"enablePreviewService,omitempty"`
+	Promotion            *v1.RayServicePromotionType         `json:"promotion,omitempty"`
+	IgnoredPaths         []string                            `json:"ignoredPaths,omitempty"`
+	ApplicationScoped    *bool                               `json:"applicationScoped,omitempty"`
+	KubeRayVersionPolicy *v1.KubeRayVersionPolicy            `json:"kubeRayVersionPolicy,omitempty"`
+	RequestShadowing     *RequestShadowingApplyConfiguration `json:"requestShadowing,omitempty"`
 }
 
 // RayServiceUpgradeStrategyApplyConfiguration constructs an declarative configuration of the RayServiceUpgradeStrategy type for use with
@@ -25,3 +31,53 @@ func (b *RayServiceUpgradeStrategyApplyConfiguration) WithType(value v1.RayServi
 	b.Type = &value
 	return b
 }
+
+// WithEnablePreviewService sets the EnablePreviewService field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EnablePreviewService field is set to the value of the last call.
+func (b *RayServiceUpgradeStrategyApplyConfiguration) WithEnablePreviewService(value bool) *RayServiceUpgradeStrategyApplyConfiguration {
+	b.EnablePreviewService = &value
+	return b
+}
+
+// WithPromotion sets the Promotion field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Promotion field is set to the value of the last call.
+func (b *RayServiceUpgradeStrategyApplyConfiguration) WithPromotion(value v1.RayServicePromotionType) *RayServiceUpgradeStrategyApplyConfiguration {
+	b.Promotion = &value
+	return b
+}
+
+// WithIgnoredPaths adds the given value to the IgnoredPaths field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the IgnoredPaths field.
+func (b *RayServiceUpgradeStrategyApplyConfiguration) WithIgnoredPaths(values ...string) *RayServiceUpgradeStrategyApplyConfiguration {
+	for i := range values {
+		b.IgnoredPaths = append(b.IgnoredPaths, values[i])
+	}
+	return b
+}
+
+// WithApplicationScoped sets the ApplicationScoped field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ApplicationScoped field is set to the value of the last call.
+func (b *RayServiceUpgradeStrategyApplyConfiguration) WithApplicationScoped(value bool) *RayServiceUpgradeStrategyApplyConfiguration {
+	b.ApplicationScoped = &value
+	return b
+}
+
+// WithKubeRayVersionPolicy sets the KubeRayVersionPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the KubeRayVersionPolicy field is set to the value of the last call.
+func (b *RayServiceUpgradeStrategyApplyConfiguration) WithKubeRayVersionPolicy(value v1.KubeRayVersionPolicy) *RayServiceUpgradeStrategyApplyConfiguration {
+	b.KubeRayVersionPolicy = &value
+	return b
+}
+
+// WithRequestShadowing sets the RequestShadowing field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RequestShadowing field is set to the value of the last call.
+func (b *RayServiceUpgradeStrategyApplyConfiguration) WithRequestShadowing(value *RequestShadowingApplyConfiguration) *RayServiceUpgradeStrategyApplyConfiguration {
+	b.RequestShadowing = value
+	return b
+}
@@ -5,7 +5,9 @@ package v1
 // ScaleStrategyApplyConfiguration represents an declarative configuration of the ScaleStrategy type for use
 // with apply.
 type ScaleStrategyApplyConfiguration struct {
-	WorkersToDelete []string `json:"workersToDelete,omitempty"`
+	WorkersToDelete             []string `json:"workersToDelete,omitempty"`
+	MaxParallelDeletions        *int32   `json:"maxParallelDeletions,omitempty"`
+	RespectPodDisruptionBudgets *bool    `json:"respectPodDisruptionBudgets,omitempty"`
 }
 
 // ScaleStrategyApplyConfiguration constructs an declarative configuration of the ScaleStrategy type for use with
@@ -23,3 +25,19 @@ func (b *ScaleStrategyApplyConfiguration) WithWorkersToDelete(values ...string)
 	}
 	return b
 }
+
+// WithMaxParallelDeletions sets the MaxParallelDeletions field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxParallelDeletions field is set to the value of the last call.
+func (b *ScaleStrategyApplyConfiguration) WithMaxParallelDeletions(value int32) *ScaleStrategyApplyConfiguration {
+	b.MaxParallelDeletions = &value
+	return b
+}
+
+// WithRespectPodDisruptionBudgets sets the RespectPodDisruptionBudgets field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RespectPodDisruptionBudgets field is set to the value of the last call.
+func (b *ScaleStrategyApplyConfiguration) WithRespectPodDisruptionBudgets(value bool) *ScaleStrategyApplyConfiguration {
+	b.RespectPodDisruptionBudgets = &value
+	return b
+}
@@ -9,9 +9,10 @@ import (
 // ServeDeploymentStatusApplyConfiguration represents an declarative configuration of the ServeDeploymentStatus type for use
 // with apply.
 type ServeDeploymentStatusApplyConfiguration struct {
-	HealthLastUpdateTime *v1.Time `json:"healthLastUpdateTime,omitempty"`
-	Status               *string  `json:"status,omitempty"`
-	Message              *string  `json:"message,omitempty"`
+	HealthLastUpdateTime *v1.Time                                `json:"healthLastUpdateTime,omitempty"`
+	Status               *string                                 `json:"status,omitempty"`
+	Message              *string                                 `json:"message,omitempty"`
+	Replicas             *ServeReplicasSummaryApplyConfiguration `json:"replicas,omitempty"`
 }
 
 // ServeDeploymentStatusApplyConfiguration constructs an declarative configuration of the ServeDeploymentStatus type for use with
@@ -43,3 +44,11 @@ func (b *ServeDeploymentStatusApplyConfiguration) WithMessage(value string) *Ser
 	b.Message = &value
 	return b
 }
+
+// WithReplicas sets the Replicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Replicas field is set to the value of the last call.
+func (b *ServeDeploymentStatusApplyConfiguration) WithReplicas(value *ServeReplicasSummaryApplyConfiguration) *ServeDeploymentStatusApplyConfiguration {
+	b.Replicas = value
+	return b
+}
@@ -5,7 +5,8 @@ package v1
 // SubmitterConfigApplyConfiguration represents an declarative configuration of the SubmitterConfig type for use
 // with apply.
 type SubmitterConfigApplyConfiguration struct {
-	BackoffLimit *int32 `json:"backoffLimit,omitempty"`
+	BackoffLimit      *int32 `json:"backoffLimit,omitempty"`
+	ReuseSubmitterPod *bool  `json:"reuseSubmitterPod,omitempty"`
 }
 
 // SubmitterConfigApplyConfiguration constructs an declarative configuration of the SubmitterConfig type for use with
@@ -21,3 +22,11 @@ func (b *SubmitterConfigApplyConfiguration) WithBackoffLimit(value int32) *Submi
 	b.BackoffLimit = &value
 	return b
 }
+
+// WithReuseSubmitterPod sets the ReuseSubmitterPod field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ReuseSubmitterPod field is set to the value of the last call.
+func (b *SubmitterConfigApplyConfiguration) WithReuseSubmitterPod(value bool) *SubmitterConfigApplyConfiguration {
+	b.ReuseSubmitterPod = &value
+	return b
+}
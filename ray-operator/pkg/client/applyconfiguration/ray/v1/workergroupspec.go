@@ -9,16 +9,23 @@ import (
 // WorkerGroupSpecApplyConfiguration represents an declarative configuration of the WorkerGroupSpec type for use
 // with apply.
 type WorkerGroupSpecApplyConfiguration struct {
-	Suspend            *bool                                 `json:"suspend,omitempty"`
-	GroupName          *string                               `json:"groupName,omitempty"`
-	Replicas           *int32                                `json:"replicas,omitempty"`
-	MinReplicas        *int32                                `json:"minReplicas,omitempty"`
-	MaxReplicas        *int32                                `json:"maxReplicas,omitempty"`
-	IdleTimeoutSeconds *int32                                `json:"idleTimeoutSeconds,omitempty"`
-	RayStartParams     map[string]string                     `json:"rayStartParams,omitempty"`
-	Template           *v1.PodTemplateSpecApplyConfiguration `json:"template,omitempty"`
-	ScaleStrategy      *ScaleStrategyApplyConfiguration      `json:"scaleStrategy,omitempty"`
-	NumOfHosts         *int32                                `json:"numOfHosts,omitempty"`
+	Suspend                  *bool                                        `json:"suspend,omitempty"`
+	GroupName                *string                                      `json:"groupName,omitempty"`
+	Namespace                *string                                      `json:"namespace,omitempty"`
+	Replicas                 *int32                                       `json:"replicas,omitempty"`
+	MinReplicas              *int32                                       `json:"minReplicas,omitempty"`
+	MaxReplicas              *int32                                       `json:"maxReplicas,omitempty"`
+	IdleTimeoutSeconds       *int32                                       `json:"idleTimeoutSeconds,omitempty"`
+	ObjectStoreMemoryPercent *int32                                       `json:"objectStoreMemoryPercent,omitempty"`
+	RayStartParams           map[string]string                            `json:"rayStartParams,omitempty"`
+	Template                 *v1.PodTemplateSpecApplyConfiguration        `json:"template,omitempty"`
+	ScaleStrategy            *ScaleStrategyApplyConfiguration             `json:"scaleStrategy,omitempty"`
+	NumOfHosts               *int32                                       `json:"numOfHosts,omitempty"`
+	EnableRankEnv            *bool                                        `json:"enableRankEnv,omitempty"`
+	GracefulDrainSeconds     *int32                                       `json:"gracefulDrainSeconds,omitempty"`
+	UpdateStrategy           *WorkerGroupUpdateStrategyApplyConfiguration `json:"updateStrategy,omitempty"`
+	Burstable                *BurstableOptionsApplyConfiguration          `json:"burstable,omitempty"`
+	OOMPolicy                *OOMPolicyApplyConfiguration                 `json:"oomPolicy,omitempty"`
 }
 
 // WorkerGroupSpecApplyConfiguration constructs an declarative configuration of the WorkerGroupSpec type for use with
@@ -43,6 +50,14 @@ func (b *WorkerGroupSpecApplyConfiguration) WithGroupName(value string) *WorkerG
 	return b
 }
 
+// WithNamespace sets the Namespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespace field is set to the value of the last call.
+func (b *WorkerGroupSpecApplyConfiguration) WithNamespace(value string) *WorkerGroupSpecApplyConfiguration {
+	b.Namespace = &value
+	return b
+}
+
 // WithReplicas sets the Replicas field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Replicas field is set to the value of the last call.
@@ -75,6 +90,14 @@ func (b *WorkerGroupSpecApplyConfiguration) WithIdleTimeoutSeconds(value int32)
 	return b
 }
 
+// WithObjectStoreMemoryPercent sets the ObjectStoreMemoryPercent field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ObjectStoreMemoryPercent field is set to the value of the last call.
+func (b *WorkerGroupSpecApplyConfiguration) WithObjectStoreMemoryPercent(value int32) *WorkerGroupSpecApplyConfiguration {
+	b.ObjectStoreMemoryPercent = &value
+	return b
+}
+
 // WithRayStartParams puts the entries into the RayStartParams field in the declarative configuration
 // and returns the receiver, so that objects can be build by chaining "With" function invocations.
 // If called multiple times, the entries provided by each call will be put on the RayStartParams field,
@@ -112,3 +135,43 @@ func (b *WorkerGroupSpecApplyConfiguration) WithNumOfHosts(value int32) *WorkerG
 	b.NumOfHosts = &value
 	return b
 }
+
+// WithEnableRankEnv sets the EnableRankEnv field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the EnableRankEnv field is set to the value of the last call.
+func (b *WorkerGroupSpecApplyConfiguration) WithEnableRankEnv(value bool) *WorkerGroupSpecApplyConfiguration {
+	b.EnableRankEnv = &value
+	return b
+}
+
+// WithGracefulDrainSeconds sets the GracefulDrainSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the GracefulDrainSeconds field is set to the value of the last call.
+func (b *WorkerGroupSpecApplyConfiguration) WithGracefulDrainSeconds(value int32) *WorkerGroupSpecApplyConfiguration {
+	b.GracefulDrainSeconds = &value
+	return b
+}
+
+// WithUpdateStrategy sets the UpdateStrategy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UpdateStrategy field is set to the value of the last call.
+func (b *WorkerGroupSpecApplyConfiguration) WithUpdateStrategy(value *WorkerGroupUpdateStrategyApplyConfiguration) *WorkerGroupSpecApplyConfiguration {
+	b.UpdateStrategy = value
+	return b
+}
+
+// WithBurstable sets the Burstable field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Burstable field is set to the value of the last call.
+func (b *WorkerGroupSpecApplyConfiguration) WithBurstable(value *BurstableOptionsApplyConfiguration) *WorkerGroupSpecApplyConfiguration {
+	b.Burstable = value
+	return b
+}
+
+// WithOOMPolicy sets the OOMPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OOMPolicy field is set to the value of the last call.
+func (b *WorkerGroupSpecApplyConfiguration) WithOOMPolicy(value *OOMPolicyApplyConfiguration) *WorkerGroupSpecApplyConfiguration {
+	b.OOMPolicy = value
+	return b
+}
@@ -13,16 +13,60 @@ import (
 func ForKind(kind schema.GroupVersionKind) interface{} {
 	switch kind {
 	// Group=ray.io, Version=v1
+	case v1.SchemeGroupVersion.WithKind("AcceleratorProvisioningRule"):
+		return &rayv1.AcceleratorProvisioningRuleApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("AppStatus"):
 		return &rayv1.AppStatusApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("AutoscalerOptions"):
 		return &rayv1.AutoscalerOptionsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("BalloonPodsOptions"):
+		return &rayv1.BalloonPodsOptionsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("BurstableOptions"):
+		return &rayv1.BurstableOptionsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("CheckpointingOptions"):
+		return &rayv1.CheckpointingOptionsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("DashboardIngressOptions"):
+		return &rayv1.DashboardIngressOptionsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("FailoverPolicy"):
+		return &rayv1.FailoverPolicyApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("GcsFaultToleranceOptions"):
 		return &rayv1.GcsFaultToleranceOptionsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("GroupMemoryFailures"):
+		return &rayv1.GroupMemoryFailuresApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("HeadContainerServicePort"):
+		return &rayv1.HeadContainerServicePortApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("HeadGroupSpec"):
 		return &rayv1.HeadGroupSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("HeadHealthProbes"):
+		return &rayv1.HeadHealthProbesApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("HeadInfo"):
 		return &rayv1.HeadInfoApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("HeadServicePorts"):
+		return &rayv1.HeadServicePortsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("HeadStatefulSetOptions"):
+		return &rayv1.HeadStatefulSetOptionsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("HealthProbeThresholds"):
+		return &rayv1.HealthProbeThresholdsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ImagePrePullOptions"):
+		return &rayv1.ImagePrePullOptionsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("InteractiveSessionOptions"):
+		return &rayv1.InteractiveSessionOptionsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("InteractiveSessionStatus"):
+		return &rayv1.InteractiveSessionStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("JournalEntry"):
+		return &rayv1.JournalEntryApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("MetadataSync"):
+		return &rayv1.MetadataSyncApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("MetricsOptions"):
+		return &rayv1.MetricsOptionsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("OAuth2ProxyOptions"):
+		return &rayv1.OAuth2ProxyOptionsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("OOMPolicy"):
+		return &rayv1.OOMPolicyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ProfilingOptions"):
+		return &rayv1.ProfilingOptionsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("PrometheusMonitoringOptions"):
+		return &rayv1.PrometheusMonitoringOptionsApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RayCluster"):
 		return &rayv1.RayClusterApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RayClusterSpec"):
@@ -35,6 +79,20 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &rayv1.RayJobSpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RayJobStatus"):
 		return &rayv1.RayJobStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayNodeProvisioningConfig"):
+		return &rayv1.RayNodeProvisioningConfigApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayNodeProvisioningConfigSpec"):
+		return &rayv1.RayNodeProvisioningConfigSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayPlacementPolicy"):
+		return &rayv1.RayPlacementPolicyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayPlacementPolicySpec"):
+		return &rayv1.RayPlacementPolicySpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayQuota"):
+		return &rayv1.RayQuotaApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayQuotaSpec"):
+		return &rayv1.RayQuotaSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayQuotaStatus"):
+		return &rayv1.RayQuotaStatusApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RayService"):
 		return &rayv1.RayServiceApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RayServiceSpec"):
@@ -45,16 +103,60 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &rayv1.RayServiceStatusesApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RayServiceUpgradeStrategy"):
 		return &rayv1.RayServiceUpgradeStrategyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RayStartHooks"):
+		return &rayv1.RayStartHooksApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ReconcileError"):
+		return &rayv1.ReconcileErrorApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("RedisCredential"):
 		return &rayv1.RedisCredentialApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RequestShadowing"):
+		return &rayv1.RequestShadowingApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RequestShadowingStatus"):
+		return &rayv1.RequestShadowingStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ResourceDemand"):
+		return &rayv1.ResourceDemandApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ResourceUsage"):
+		return &rayv1.ResourceUsageApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("RollingUpdateWorkerGroup"):
+		return &rayv1.RollingUpdateWorkerGroupApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ScaleStrategy"):
 		return &rayv1.ScaleStrategyApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ServeAlertingOptions"):
+		return &rayv1.ServeAlertingOptionsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ServeApplicationSLO"):
+		return &rayv1.ServeApplicationSLOApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ServeConfigSource"):
+		return &rayv1.ServeConfigSourceApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ServeConfigSourceStatus"):
+		return &rayv1.ServeConfigSourceStatusApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ServeDeploymentStatus"):
 		return &rayv1.ServeDeploymentStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ServeProbe"):
+		return &rayv1.ServeProbeApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ServeProxyHealthCheck"):
+		return &rayv1.ServeProxyHealthCheckApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ServeReadiness"):
+		return &rayv1.ServeReadinessApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ServeReadinessMetric"):
+		return &rayv1.ServeReadinessMetricApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ServeReplicasSummary"):
+		return &rayv1.ServeReplicasSummaryApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ServeRequestCounts"):
+		return &rayv1.ServeRequestCountsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ServeSessionAffinity"):
+		return &rayv1.ServeSessionAffinityApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ServeTopologyRouting"):
+		return &rayv1.ServeTopologyRoutingApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("ServiceMeshOptions"):
+		return &rayv1.ServiceMeshOptionsApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("SubmitterConfig"):
 		return &rayv1.SubmitterConfigApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("TmpDirPolicy"):
+		return &rayv1.TmpDirPolicyApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("WorkerGroupSpec"):
 		return &rayv1.WorkerGroupSpecApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("WorkerGroupUpdateStrategy"):
+		return &rayv1.WorkerGroupUpdateStrategyApplyConfiguration{}
 
 	}
 	return nil
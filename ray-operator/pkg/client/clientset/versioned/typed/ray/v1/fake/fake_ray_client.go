@@ -20,6 +20,18 @@ func (c *FakeRayV1) RayJobs(namespace string) v1.RayJobInterface {
 	return &FakeRayJobs{c, namespace}
 }
 
+func (c *FakeRayV1) RayNodeProvisioningConfigs() v1.RayNodeProvisioningConfigInterface {
+	return &FakeRayNodeProvisioningConfigs{c}
+}
+
+func (c *FakeRayV1) RayPlacementPolicies() v1.RayPlacementPolicyInterface {
+	return &FakeRayPlacementPolicies{c}
+}
+
+func (c *FakeRayV1) RayQuotas(namespace string) v1.RayQuotaInterface {
+	return &FakeRayQuotas{c, namespace}
+}
+
 func (c *FakeRayV1) RayServices(namespace string) v1.RayServiceInterface {
 	return &FakeRayServices{c, namespace}
 }
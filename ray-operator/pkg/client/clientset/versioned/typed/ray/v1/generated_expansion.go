@@ -6,4 +6,10 @@ type RayClusterExpansion interface{}
 
 type RayJobExpansion interface{}
 
+type RayNodeProvisioningConfigExpansion interface{}
+
+type RayPlacementPolicyExpansion interface{}
+
+type RayQuotaExpansion interface{}
+
 type RayServiceExpansion interface{}
@@ -14,6 +14,9 @@ type RayV1Interface interface {
 	RESTClient() rest.Interface
 	RayClustersGetter
 	RayJobsGetter
+	RayNodeProvisioningConfigsGetter
+	RayPlacementPoliciesGetter
+	RayQuotasGetter
 	RayServicesGetter
 }
 
@@ -30,6 +33,18 @@ func (c *RayV1Client) RayJobs(namespace string) RayJobInterface {
 	return newRayJobs(c, namespace)
 }
 
+func (c *RayV1Client) RayNodeProvisioningConfigs() RayNodeProvisioningConfigInterface {
+	return newRayNodeProvisioningConfigs(c)
+}
+
+func (c *RayV1Client) RayPlacementPolicies() RayPlacementPolicyInterface {
+	return newRayPlacementPolicies(c)
+}
+
+func (c *RayV1Client) RayQuotas(namespace string) RayQuotaInterface {
+	return newRayQuotas(c, namespace)
+}
+
 func (c *RayV1Client) RayServices(namespace string) RayServiceInterface {
 	return newRayServices(c, namespace)
 }
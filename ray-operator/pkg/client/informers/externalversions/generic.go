@@ -41,6 +41,12 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Ray().V1().RayClusters().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("rayjobs"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Ray().V1().RayJobs().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("raynodeprovisioningconfigs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Ray().V1().RayNodeProvisioningConfigs().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("rayplacementpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Ray().V1().RayPlacementPolicies().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("rayquotas"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Ray().V1().RayQuotas().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("rayservices"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Ray().V1().RayServices().Informer()}, nil
 
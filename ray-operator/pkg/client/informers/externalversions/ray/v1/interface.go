@@ -12,6 +12,12 @@ type Interface interface {
 	RayClusters() RayClusterInformer
 	// RayJobs returns a RayJobInformer.
 	RayJobs() RayJobInformer
+	// RayNodeProvisioningConfigs returns a RayNodeProvisioningConfigInformer.
+	RayNodeProvisioningConfigs() RayNodeProvisioningConfigInformer
+	// RayPlacementPolicies returns a RayPlacementPolicyInformer.
+	RayPlacementPolicies() RayPlacementPolicyInformer
+	// RayQuotas returns a RayQuotaInformer.
+	RayQuotas() RayQuotaInformer
 	// RayServices returns a RayServiceInformer.
 	RayServices() RayServiceInformer
 }
@@ -37,6 +43,21 @@ func (v *version) RayJobs() RayJobInformer {
 	return &rayJobInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// RayNodeProvisioningConfigs returns a RayNodeProvisioningConfigInformer.
+func (v *version) RayNodeProvisioningConfigs() RayNodeProvisioningConfigInformer {
+	return &rayNodeProvisioningConfigInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// RayPlacementPolicies returns a RayPlacementPolicyInformer.
+func (v *version) RayPlacementPolicies() RayPlacementPolicyInformer {
+	return &rayPlacementPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// RayQuotas returns a RayQuotaInformer.
+func (v *version) RayQuotas() RayQuotaInformer {
+	return &rayQuotaInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // RayServices returns a RayServiceInformer.
 func (v *version) RayServices() RayServiceInformer {
 	return &rayServiceInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
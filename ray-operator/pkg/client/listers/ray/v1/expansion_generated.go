@@ -18,6 +18,22 @@ type RayJobListerExpansion interface{}
 // RayJobNamespaceLister.
 type RayJobNamespaceListerExpansion interface{}
 
+// RayNodeProvisioningConfigListerExpansion allows custom methods to be added to
+// RayNodeProvisioningConfigLister.
+type RayNodeProvisioningConfigListerExpansion interface{}
+
+// RayPlacementPolicyListerExpansion allows custom methods to be added to
+// RayPlacementPolicyLister.
+type RayPlacementPolicyListerExpansion interface{}
+
+// RayQuotaListerExpansion allows custom methods to be added to
+// RayQuotaLister.
+type RayQuotaListerExpansion interface{}
+
+// RayQuotaNamespaceListerExpansion allows custom methods to be added to
+// RayQuotaNamespaceLister.
+type RayQuotaNamespaceListerExpansion interface{}
+
 // RayServiceListerExpansion allows custom methods to be added to
 // RayServiceLister.
 type RayServiceListerExpansion interface{}
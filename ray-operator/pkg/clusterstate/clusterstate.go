@@ -0,0 +1,259 @@
+// Package clusterstate serves a normalized view of the state of RayClusters over HTTP, so that external autoscaling
+// and placement systems can make decisions without talking to both Kubernetes and the Ray dashboard.
+//
+// The state of a RayCluster is served as JSON at /apis/v1/namespaces/{namespace}/rayclusters/{name}/state. The
+// requests must carry the Kubernetes bearer token of a user allowed to get the RayCluster in the Authorization header.
+package clusterstate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"slices"
+	"time"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/common"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	"github.com/ray-project/kuberay/ray-operator/pkg/httpauth"
+)
+
+// StatePath is the path the state of a RayCluster is served at.
+const StatePath = "/apis/v1/namespaces/{namespace}/rayclusters/{name}/state"
+
+// ClusterState is the normalized state of a RayCluster.
+type ClusterState struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	// Head is the state of the head Pod.
+	Head HeadState `json:"head"`
+	// WorkerGroups are the desired and ready Pods of each worker group.
+	WorkerGroups []WorkerGroupState `json:"workerGroups"`
+	// RayStateAvailable is whether the state below was read from the Ray dashboard. It is false if the head Pod
+	// isn't ready or the dashboard can't be reached.
+	RayStateAvailable bool `json:"rayStateAvailable"`
+	// PendingResourceDemands are the resource requests that the Ray autoscaler can't satisfy with the current nodes.
+	PendingResourceDemands []rayv1.ResourceDemand `json:"pendingResourceDemands,omitempty"`
+	// Nodes are the alive Ray nodes and their resource utilization.
+	Nodes []NodeState `json:"nodes,omitempty"`
+}
+
+// HeadState is the state of the head Pod of a RayCluster.
+type HeadState struct {
+	PodName string `json:"podName,omitempty"`
+	Ready   bool   `json:"ready"`
+}
+
+// WorkerGroupState is the state of a worker group of a RayCluster.
+type WorkerGroupState struct {
+	GroupName       string `json:"groupName"`
+	DesiredReplicas int32  `json:"desiredReplicas"`
+	MinReplicas     int32  `json:"minReplicas"`
+	MaxReplicas     int32  `json:"maxReplicas"`
+	// DesiredPods is the number of desired Pods, i.e. the desired replicas times the number of hosts per replica.
+	DesiredPods int32 `json:"desiredPods"`
+	// ReadyPods is the number of running and ready Pods.
+	ReadyPods int32 `json:"readyPods"`
+}
+
+// NodeState is the state of an alive Ray node.
+type NodeState struct {
+	NodeID string `json:"nodeID"`
+	NodeIP string `json:"nodeIP"`
+	// PodName and GroupName are the Pod and the group of the Ray node, if it runs in a Pod of the RayCluster.
+	PodName   string `json:"podName,omitempty"`
+	GroupName string `json:"groupName,omitempty"`
+	// Resources is the utilization of each Ray resource of the node, if reported by the Ray autoscaler.
+	Resources map[string]ResourceUsage `json:"resources,omitempty"`
+}
+
+// ResourceUsage is the used and total amount of a Ray resource.
+type ResourceUsage struct {
+	Used  float64 `json:"used"`
+	Total float64 `json:"total"`
+}
+
+// Provider reads the state of RayClusters from Kubernetes and the Ray dashboard.
+type Provider struct {
+	client              client.Client
+	dashboardClientFunc func() utils.RayDashboardClientInterface
+}
+
+func NewProvider(c client.Client, dashboardClientFunc func() utils.RayDashboardClientInterface) *Provider {
+	return &Provider{
+		client:              c,
+		dashboardClientFunc: dashboardClientFunc,
+	}
+}
+
+// GetClusterState returns the state of the RayCluster. The Ray part of the state is omitted if the Ray dashboard
+// can't be reached.
+func (p *Provider) GetClusterState(ctx context.Context, namespace, name string) (*ClusterState, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	cluster := &rayv1.RayCluster{}
+	if err := p.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, cluster); err != nil {
+		return nil, err
+	}
+	pods := corev1.PodList{}
+	if err := p.client.List(ctx, &pods, common.RayClusterAllPodsAssociationOptions(cluster).ToListOptions()...); err != nil {
+		return nil, err
+	}
+
+	state := &ClusterState{
+		Name:         cluster.Name,
+		Namespace:    cluster.Namespace,
+		WorkerGroups: []WorkerGroupState{},
+	}
+	var headPod *corev1.Pod
+	for i := range pods.Items {
+		if pods.Items[i].Labels[utils.RayNodeTypeLabelKey] == string(rayv1.HeadNode) {
+			headPod = &pods.Items[i]
+			state.Head = HeadState{PodName: headPod.Name, Ready: utils.IsRunningAndReady(headPod)}
+			break
+		}
+	}
+	for _, group := range cluster.Spec.WorkerGroupSpecs {
+		groupState := WorkerGroupState{
+			GroupName:       group.GroupName,
+			DesiredReplicas: utils.GetWorkerGroupDesiredReplicas(ctx, group),
+			MinReplicas:     ptr.Deref(group.MinReplicas, 0),
+			MaxReplicas:     ptr.Deref(group.MaxReplicas, math.MaxInt32),
+		}
+		groupState.DesiredPods = groupState.DesiredReplicas * max(group.NumOfHosts, 1)
+		for i := range pods.Items {
+			if pods.Items[i].Labels[utils.RayNodeGroupLabelKey] == group.GroupName && utils.IsRunningAndReady(&pods.Items[i]) {
+				groupState.ReadyPods++
+			}
+		}
+		state.WorkerGroups = append(state.WorkerGroups, groupState)
+	}
+
+	if state.Head.Ready {
+		if err := p.readRayState(ctx, cluster, pods.Items, state); err != nil {
+			logger.Info("Failed to read the state of the RayCluster from the Ray dashboard", "RayCluster", cluster.Name, "error", err)
+		}
+	}
+	return state, nil
+}
+
+// readRayState fills in the pending resource demands and the Ray nodes from the Ray dashboard.
+func (p *Provider) readRayState(ctx context.Context, cluster *rayv1.RayCluster, pods []corev1.Pod, state *ClusterState) error {
+	clientURL, err := utils.FetchHeadServiceURL(ctx, p.client, cluster, utils.DashboardPortName)
+	if err != nil {
+		return err
+	}
+	rayDashboardClient := p.dashboardClientFunc()
+	if err := rayDashboardClient.InitClient(ctx, clientURL, cluster); err != nil {
+		return err
+	}
+	nodes, err := rayDashboardClient.ListAliveNodes(ctx)
+	if err != nil {
+		return err
+	}
+	clusterStatus, err := rayDashboardClient.GetClusterStatus(ctx)
+	if err != nil {
+		return err
+	}
+
+	var usageByNode map[string]map[string][]float64
+	if clusterStatus != nil {
+		state.PendingResourceDemands = utils.ConvertResourceDemands(clusterStatus.LoadMetricsReport.ResourceDemand)
+		usageByNode = clusterStatus.LoadMetricsReport.UsageByNode
+	}
+	for _, node := range nodes {
+		nodeState := NodeState{NodeID: node.NodeID, NodeIP: node.NodeIP}
+		if i := slices.IndexFunc(pods, func(pod corev1.Pod) bool { return utils.IsPodIP(&pod, node.NodeIP) }); i >= 0 {
+			nodeState.PodName = pods[i].Name
+			nodeState.GroupName = pods[i].Labels[utils.RayNodeGroupLabelKey]
+		}
+		for name, usage := range usageByNode[node.NodeID] {
+			if len(usage) != 2 {
+				continue
+			}
+			if nodeState.Resources == nil {
+				nodeState.Resources = map[string]ResourceUsage{}
+			}
+			nodeState.Resources[name] = ResourceUsage{Used: usage[0], Total: usage[1]}
+		}
+		state.Nodes = append(state.Nodes, nodeState)
+	}
+	state.RayStateAvailable = true
+	return nil
+}
+
+// Handler returns the HTTP handler serving the state of the RayClusters.
+func (p *Provider) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+StatePath, func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		namespace, name := r.PathValue("namespace"), r.PathValue("name")
+		status, err := httpauth.Authorize(ctx, p.client, r, authorizationv1.ResourceAttributes{
+			Namespace: namespace,
+			Verb:      "get",
+			Group:     rayv1.GroupVersion.Group,
+			Resource:  "rayclusters",
+			Name:      name,
+		})
+		if status != 0 {
+			ctrl.LoggerFrom(ctx).Info("Denied a request to the state of a RayCluster", "namespace", namespace, "name", name, "status", status, "error", err)
+			http.Error(w, err.Error(), status)
+			return
+		}
+		state, err := p.GetClusterState(ctx, namespace, name)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if k8serrors.IsNotFound(err) {
+				status = http.StatusNotFound
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(state)
+	})
+	return mux
+}
+
+// Server serves the state of the RayClusters. It implements manager.Runnable so that it is started and stopped with
+// the manager.
+type Server struct {
+	addr     string
+	provider *Provider
+}
+
+func NewServer(addr string, provider *Provider) *Server {
+	return &Server{addr: addr, provider: provider}
+}
+
+func (s *Server) Start(ctx context.Context) error {
+	server := &http.Server{
+		Addr:              s.addr,
+		Handler:           s.provider.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// NeedLeaderElection returns false because every replica of the operator can serve the state from its cache.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
@@ -0,0 +1,160 @@
+package clusterstate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+func newPod(name string, nodeType rayv1.RayNodeType, group string, ip string, ready bool) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				utils.RayClusterLabelKey:   "raycluster",
+				utils.RayNodeTypeLabelKey:  string(nodeType),
+				utils.RayNodeGroupLabelKey: group,
+			},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning, PodIP: ip},
+	}
+	if ready {
+		pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	}
+	return pod
+}
+
+func TestHandler(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	cluster := &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "raycluster", Namespace: "default"},
+		Spec: rayv1.RayClusterSpec{
+			WorkerGroupSpecs: []rayv1.WorkerGroupSpec{
+				{
+					GroupName:   "small-group",
+					Replicas:    ptr.To[int32](2),
+					MinReplicas: ptr.To[int32](0),
+					MaxReplicas: ptr.To[int32](5),
+					NumOfHosts:  2,
+				},
+			},
+		},
+	}
+	headService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "raycluster-head-svc", Namespace: "default"},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{{Name: utils.DashboardPortName, Port: 8265}},
+		},
+	}
+	// The token "alice" is allowed to get the RayClusters of the namespace "default".
+	var accessReview *authorizationv1.SubjectAccessReview
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(
+		cluster,
+		headService,
+		newPod("head", rayv1.HeadNode, utils.RayNodeHeadGroupLabelValue, "10.0.0.1", true),
+		newPod("worker-1", rayv1.WorkerNode, "small-group", "10.0.0.2", true),
+		newPod("worker-2", rayv1.WorkerNode, "small-group", "10.0.0.3", false),
+	).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(_ context.Context, _ client.WithWatch, obj client.Object, _ ...client.CreateOption) error {
+			switch review := obj.(type) {
+			case *authenticationv1.TokenReview:
+				if review.Spec.Token == "alice" {
+					review.Status.Authenticated = true
+					review.Status.User = authenticationv1.UserInfo{Username: "alice"}
+				}
+			case *authorizationv1.SubjectAccessReview:
+				accessReview = review
+				review.Status.Allowed = review.Spec.User == "alice" && review.Spec.ResourceAttributes.Namespace == "default"
+			}
+			return nil
+		},
+	}).Build()
+
+	fakeDashboardClient := &utils.FakeRayDashboardClient{}
+	fakeDashboardClient.SetAliveNodes([]utils.RayNodeInfo{
+		{NodeID: "head-node", NodeIP: "10.0.0.1", State: "ALIVE"},
+		{NodeID: "worker-node", NodeIP: "10.0.0.2", State: "ALIVE"},
+	})
+	fakeDashboardClient.SetClusterStatus(&utils.RayClusterStatusInfo{
+		LoadMetricsReport: utils.RayLoadMetricsReport{
+			ResourceDemand: []utils.RayResourceDemand{{Resources: map[string]float64{"CPU": 1}, Count: 3}},
+			UsageByNode: map[string]map[string][]float64{
+				"worker-node": {"CPU": {1, 4}},
+			},
+		},
+	})
+	provider := NewProvider(fakeClient, func() utils.RayDashboardClientInterface { return fakeDashboardClient })
+
+	get := func(path string, token string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, path, nil)
+		if token != "" {
+			request.Header.Set("Authorization", "Bearer "+token)
+		}
+		recorder := httptest.NewRecorder()
+		provider.Handler().ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	recorder := get("/apis/v1/namespaces/default/rayclusters/raycluster/state", "alice")
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	assert.Equal(t, &authorizationv1.ResourceAttributes{
+		Namespace: "default",
+		Verb:      "get",
+		Group:     "ray.io",
+		Resource:  "rayclusters",
+		Name:      "raycluster",
+	}, accessReview.Spec.ResourceAttributes)
+	var state ClusterState
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &state))
+
+	assert.Equal(t, HeadState{PodName: "head", Ready: true}, state.Head)
+	assert.Equal(t, []WorkerGroupState{{
+		GroupName:       "small-group",
+		DesiredReplicas: 2,
+		MinReplicas:     0,
+		MaxReplicas:     5,
+		DesiredPods:     4,
+		ReadyPods:       1,
+	}}, state.WorkerGroups)
+	assert.True(t, state.RayStateAvailable)
+	require.Len(t, state.PendingResourceDemands, 1)
+	assert.Equal(t, int32(3), state.PendingResourceDemands[0].Count)
+	assert.Equal(t, []NodeState{
+		{NodeID: "head-node", NodeIP: "10.0.0.1", PodName: "head", GroupName: utils.RayNodeHeadGroupLabelValue},
+		{
+			NodeID:    "worker-node",
+			NodeIP:    "10.0.0.2",
+			PodName:   "worker-1",
+			GroupName: "small-group",
+			Resources: map[string]ResourceUsage{"CPU": {Used: 1, Total: 4}},
+		},
+	}, state.Nodes)
+
+	recorder = get("/apis/v1/namespaces/default/rayclusters/non-existent/state", "alice")
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+
+	assert.Equal(t, http.StatusUnauthorized, get("/apis/v1/namespaces/default/rayclusters/raycluster/state", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, get("/apis/v1/namespaces/default/rayclusters/raycluster/state", "mallory").Code)
+	assert.Equal(t, http.StatusForbidden, get("/apis/v1/namespaces/other/rayclusters/raycluster/state", "alice").Code)
+}
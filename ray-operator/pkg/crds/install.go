@@ -0,0 +1,140 @@
+// Package crds installs and upgrades the CRDs bundled with the operator, for installations that don't use Helm.
+package crds
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"time"
+
+	"github.com/go-logr/logr"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/ray-project/kuberay/ray-operator/config/crd"
+)
+
+const (
+	// FieldManager is the field manager used when applying the CRDs.
+	FieldManager = "kuberay-operator"
+
+	DefaultEstablishTimeout = 1 * time.Minute
+)
+
+// Load returns the CRDs bundled with the operator.
+func Load() ([]*apiextensionsv1.CustomResourceDefinition, error) {
+	files, err := fs.Glob(crd.Bases, "bases/*.yaml")
+	if err != nil {
+		return nil, err
+	}
+	crds := make([]*apiextensionsv1.CustomResourceDefinition, 0, len(files))
+	for _, file := range files {
+		data, err := fs.ReadFile(crd.Bases, file)
+		if err != nil {
+			return nil, err
+		}
+		crd := &apiextensionsv1.CustomResourceDefinition{}
+		if err := yaml.UnmarshalStrict(data, crd); err != nil {
+			return nil, fmt.Errorf("failed to decode %s: %w", file, err)
+		}
+		crds = append(crds, crd)
+	}
+	return crds, nil
+}
+
+// InstallBundled loads the bundled CRDs and installs them using the given REST config.
+func InstallBundled(ctx context.Context, cfg *rest.Config) error {
+	scheme := runtime.NewScheme()
+	if err := apiextensionsv1.AddToScheme(scheme); err != nil {
+		return err
+	}
+	c, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return err
+	}
+	crds, err := Load()
+	if err != nil {
+		return err
+	}
+	return Install(ctx, c, crds, DefaultEstablishTimeout)
+}
+
+// Install applies the CRDs with server-side apply and waits for them to be established. Before applying, every CRD is
+// checked against the one in the cluster, and Install fails without changing anything if a version that objects are
+// stored in would be removed, e.g. when an older operator is started against CRDs installed by a newer one.
+func Install(ctx context.Context, c client.Client, crds []*apiextensionsv1.CustomResourceDefinition, establishTimeout time.Duration) error {
+	logger := logr.FromContextOrDiscard(ctx)
+
+	for _, desired := range crds {
+		existing := &apiextensionsv1.CustomResourceDefinition{}
+		if err := c.Get(ctx, client.ObjectKey{Name: desired.Name}, existing); err != nil {
+			if client.IgnoreNotFound(err) != nil {
+				return err
+			}
+		} else if err := ValidateUpgrade(existing, desired); err != nil {
+			return err
+		}
+	}
+
+	for _, desired := range crds {
+		crd := desired.DeepCopy()
+		crd.TypeMeta.APIVersion = apiextensionsv1.SchemeGroupVersion.String()
+		crd.TypeMeta.Kind = "CustomResourceDefinition"
+		crd.Status = apiextensionsv1.CustomResourceDefinitionStatus{}
+		if err := c.Patch(ctx, crd, client.Apply, client.FieldOwner(FieldManager), client.ForceOwnership); err != nil {
+			return fmt.Errorf("failed to apply CRD %s: %w", crd.Name, err)
+		}
+		logger.Info("Applied CRD", "name", crd.Name)
+	}
+
+	for _, desired := range crds {
+		if err := waitForEstablished(ctx, c, desired.Name, establishTimeout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateUpgrade returns an error if replacing the existing CRD with the desired one would remove a version that is
+// still listed in the stored versions of the existing CRD. Objects stored in such a version couldn't be read anymore.
+func ValidateUpgrade(existing, desired *apiextensionsv1.CustomResourceDefinition) error {
+	versions := make(map[string]struct{}, len(desired.Spec.Versions))
+	for _, version := range desired.Spec.Versions {
+		versions[version.Name] = struct{}{}
+	}
+	for _, storedVersion := range existing.Status.StoredVersions {
+		if _, ok := versions[storedVersion]; !ok {
+			return fmt.Errorf("CRD %s has objects stored in version %s, which is not part of the CRD bundled with this operator. "+
+				"Migrate the objects and remove %s from the stored versions of the CRD before using this operator version",
+				existing.Name, storedVersion, storedVersion)
+		}
+	}
+	return nil
+}
+
+func waitForEstablished(ctx context.Context, c client.Client, name string, timeout time.Duration) error {
+	err := wait.PollUntilContextTimeout(ctx, time.Second, timeout, true, func(ctx context.Context) (bool, error) {
+		crd := &apiextensionsv1.CustomResourceDefinition{}
+		if err := c.Get(ctx, client.ObjectKey{Name: name}, crd); err != nil {
+			return false, client.IgnoreNotFound(err)
+		}
+		return isEstablished(crd), nil
+	})
+	if err != nil {
+		return fmt.Errorf("CRD %s was not established: %w", name, err)
+	}
+	return nil
+}
+
+func isEstablished(crd *apiextensionsv1.CustomResourceDefinition) bool {
+	for _, condition := range crd.Status.Conditions {
+		if condition.Type == apiextensionsv1.Established {
+			return condition.Status == apiextensionsv1.ConditionTrue
+		}
+	}
+	return false
+}
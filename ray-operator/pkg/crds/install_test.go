@@ -0,0 +1,73 @@
+package crds
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestLoad(t *testing.T) {
+	crds, err := Load()
+	require.NoError(t, err)
+
+	names := []string{}
+	for _, crd := range crds {
+		names = append(names, crd.Name)
+		assert.NotEmpty(t, crd.Spec.Versions)
+	}
+	assert.ElementsMatch(t, []string{"rayclusters.ray.io", "rayjobs.ray.io", "raynodeprovisioningconfigs.ray.io", "rayplacementpolicies.ray.io", "rayquotas.ray.io", "rayservices.ray.io"}, names)
+}
+
+func TestValidateUpgrade(t *testing.T) {
+	desired := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "rayclusters.ray.io"},
+		Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+			Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{Name: "v1", Storage: true, Served: true}},
+		},
+	}
+	existing := desired.DeepCopy()
+	existing.Status.StoredVersions = []string{"v1"}
+	assert.NoError(t, ValidateUpgrade(existing, desired))
+
+	// Objects are still stored in a version which the bundled CRD doesn't have.
+	existing.Status.StoredVersions = []string{"v1", "v2"}
+	assert.ErrorContains(t, ValidateUpgrade(existing, desired), "v2")
+}
+
+func TestInstallRefusesToRemoveStoredVersion(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiextensionsv1.AddToScheme(scheme))
+
+	crds, err := Load()
+	require.NoError(t, err)
+	existing := crds[0].DeepCopy()
+	existing.Status.StoredVersions = []string{"v1", "v2"}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(scheme).WithObjects(existing).WithStatusSubresource(existing).Build()
+
+	err = Install(context.Background(), fakeClient, crds, time.Second)
+	assert.ErrorContains(t, err, "stored in version v2")
+}
+
+func TestWaitForEstablished(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, apiextensionsv1.AddToScheme(scheme))
+
+	crd := &apiextensionsv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "rayjobs.ray.io"},
+		Status: apiextensionsv1.CustomResourceDefinitionStatus{
+			Conditions: []apiextensionsv1.CustomResourceDefinitionCondition{
+				{Type: apiextensionsv1.Established, Status: apiextensionsv1.ConditionTrue},
+			},
+		},
+	}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(scheme).WithObjects(crd).Build()
+	assert.NoError(t, waitForEstablished(context.Background(), fakeClient, "rayjobs.ray.io", time.Second))
+	assert.Error(t, waitForEstablished(context.Background(), fakeClient, "rayservices.ray.io", 100*time.Millisecond))
+}
@@ -0,0 +1,140 @@
+// Package dashboardproxy serves an authenticated reverse proxy to the Ray dashboards of the RayClusters, so that users
+// who aren't allowed to port-forward to or exec into the head Pods can still reach the dashboards that their RBAC
+// allows.
+//
+// The dashboard of a RayCluster is served at /proxy/{namespace}/{name}/dashboard/. The requests must carry the
+// Kubernetes bearer token of the user in the Authorization header. The token is authenticated with a TokenReview, and
+// the user must be allowed to get the `rayclusters/proxy` subresource of the RayCluster, which is checked with a
+// SubjectAccessReview.
+package dashboardproxy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"time"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	"github.com/ray-project/kuberay/ray-operator/pkg/httpauth"
+)
+
+const (
+	// DashboardPath is the path the dashboard of a RayCluster is served at.
+	DashboardPath = "/proxy/{namespace}/{name}/dashboard/"
+	// ProxySubresource is the subresource of the RayClusters that the users must be allowed to get.
+	ProxySubresource = "proxy"
+)
+
+// Proxy authorizes the requests to the Ray dashboards and forwards them to the head services.
+type Proxy struct {
+	client client.Client
+	// dashboardURLFunc returns the URL of the dashboard of the RayCluster.
+	dashboardURLFunc func(ctx context.Context, cluster *rayv1.RayCluster) (*url.URL, error)
+}
+
+func NewProxy(c client.Client) *Proxy {
+	return &Proxy{
+		client: c,
+		dashboardURLFunc: func(ctx context.Context, cluster *rayv1.RayCluster) (*url.URL, error) {
+			headServiceURL, err := utils.FetchHeadServiceURL(ctx, c, cluster, utils.DashboardPortName)
+			if err != nil {
+				return nil, err
+			}
+			return url.Parse("http://" + headServiceURL)
+		},
+	}
+}
+
+// Handler returns the HTTP handler serving the dashboards of the RayClusters.
+func (p *Proxy) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(DashboardPath+"{path...}", func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		logger := ctrl.LoggerFrom(ctx)
+		namespace, name := r.PathValue("namespace"), r.PathValue("name")
+		status, err := httpauth.Authorize(ctx, p.client, r, authorizationv1.ResourceAttributes{
+			Namespace:   namespace,
+			Verb:        "get",
+			Group:       rayv1.GroupVersion.Group,
+			Resource:    "rayclusters",
+			Subresource: ProxySubresource,
+			Name:        name,
+		})
+		if status != 0 {
+			logger.Info("Denied a request to the Ray dashboard", "namespace", namespace, "name", name, "status", status, "error", err)
+			http.Error(w, err.Error(), status)
+			return
+		}
+
+		cluster := &rayv1.RayCluster{}
+		if err := p.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, cluster); err != nil {
+			status := http.StatusInternalServerError
+			if k8serrors.IsNotFound(err) {
+				status = http.StatusNotFound
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+		target, err := p.dashboardURLFunc(ctx, cluster)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+
+		path := "/" + r.PathValue("path")
+		proxy := &httputil.ReverseProxy{
+			Rewrite: func(pr *httputil.ProxyRequest) {
+				pr.SetURL(target)
+				pr.Out.URL.Path = path
+				pr.Out.URL.RawPath = ""
+				// The token of the user isn't forwarded to the dashboard.
+				pr.Out.Header.Del("Authorization")
+				pr.SetXForwarded()
+			},
+		}
+		proxy.ServeHTTP(w, r)
+	})
+	return mux
+}
+
+// Server serves the dashboard proxy. It implements manager.Runnable so that it is started and stopped with the manager.
+type Server struct {
+	addr  string
+	proxy *Proxy
+}
+
+func NewServer(addr string, proxy *Proxy) *Server {
+	return &Server{addr: addr, proxy: proxy}
+}
+
+func (s *Server) Start(ctx context.Context) error {
+	server := &http.Server{
+		Addr:              s.addr,
+		Handler:           s.proxy.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// NeedLeaderElection returns false because every replica of the operator can serve the proxy.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
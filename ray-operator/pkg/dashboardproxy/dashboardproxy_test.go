@@ -0,0 +1,93 @@
+package dashboardproxy
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clientFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func TestHandler(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+	_ = authenticationv1.AddToScheme(newScheme)
+	_ = authorizationv1.AddToScheme(newScheme)
+
+	var dashboardRequest *http.Request
+	dashboard := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dashboardRequest = r
+		_, _ = io.WriteString(w, "dashboard")
+	}))
+	defer dashboard.Close()
+
+	// The token "alice" is allowed to access the dashboard of the RayCluster "allowed".
+	var accessReview *authorizationv1.SubjectAccessReview
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(
+		&rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Name: "allowed", Namespace: "default"}},
+		&rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Name: "denied", Namespace: "default"}},
+	).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(_ context.Context, _ client.WithWatch, obj client.Object, _ ...client.CreateOption) error {
+			switch review := obj.(type) {
+			case *authenticationv1.TokenReview:
+				if review.Spec.Token == "alice" {
+					review.Status.Authenticated = true
+					review.Status.User = authenticationv1.UserInfo{Username: "alice", Groups: []string{"developers"}}
+				}
+			case *authorizationv1.SubjectAccessReview:
+				accessReview = review
+				review.Status.Allowed = review.Spec.User == "alice" && review.Spec.ResourceAttributes.Name == "allowed"
+			}
+			return nil
+		},
+	}).Build()
+
+	proxy := NewProxy(fakeClient)
+	proxy.dashboardURLFunc = func(_ context.Context, _ *rayv1.RayCluster) (*url.URL, error) {
+		return url.Parse(dashboard.URL)
+	}
+	get := func(path string, token string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodGet, path, nil)
+		if token != "" {
+			request.Header.Set("Authorization", "Bearer "+token)
+		}
+		recorder := httptest.NewRecorder()
+		proxy.Handler().ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	recorder := get("/proxy/default/allowed/dashboard/api/jobs/?limit=1", "alice")
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	assert.Equal(t, "dashboard", recorder.Body.String())
+	assert.Equal(t, "/api/jobs/", dashboardRequest.URL.Path)
+	assert.Equal(t, "limit=1", dashboardRequest.URL.RawQuery)
+	assert.Empty(t, dashboardRequest.Header.Get("Authorization"))
+	assert.Equal(t, &authorizationv1.ResourceAttributes{
+		Namespace:   "default",
+		Verb:        "get",
+		Group:       "ray.io",
+		Resource:    "rayclusters",
+		Subresource: "proxy",
+		Name:        "allowed",
+	}, accessReview.Spec.ResourceAttributes)
+	assert.Equal(t, []string{"developers"}, accessReview.Spec.Groups)
+
+	assert.Equal(t, http.StatusUnauthorized, get("/proxy/default/allowed/dashboard/", "").Code)
+	assert.Equal(t, http.StatusUnauthorized, get("/proxy/default/allowed/dashboard/", "mallory").Code)
+	assert.Equal(t, http.StatusForbidden, get("/proxy/default/denied/dashboard/", "alice").Code)
+}
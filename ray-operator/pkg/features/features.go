@@ -24,6 +24,125 @@ const (
 	//
 	// Enables new deletion policy API in RayJob
 	RayJobDeletionPolicy featuregate.Feature = "RayJobDeletionPolicy"
+
+	// owner: @liuxsh9
+	// rep: N/A
+	// alpha: v1.3
+	//
+	// Enables publishing the pending resource demands of the Ray autoscaler in RayCluster status and balloon Pods
+	RayClusterPendingResourceDemands featuregate.Feature = "RayClusterPendingResourceDemands"
+
+	// owner: @liuxsh9
+	// rep: N/A
+	// alpha: v1.3
+	//
+	// Enables the RayQuota API that limits the total resources of the RayClusters in a namespace
+	RayQuota featuregate.Feature = "RayQuota"
+
+	// owner: @liuxsh9
+	// rep: N/A
+	// alpha: v1.3
+	//
+	// Enables suspending individual worker groups of a RayCluster with `suspend`
+	RayWorkerGroupSuspend featuregate.Feature = "RayWorkerGroupSuspend"
+
+	// owner: @liuxsh9
+	// rep: N/A
+	// alpha: v1.3
+	//
+	// Enables the RayNodeProvisioningConfig API that sets the scheduling settings of Ray Pods requesting accelerators
+	RayNodeProvisioningConfig featuregate.Feature = "RayNodeProvisioningConfig"
+
+	// owner: @liuxsh9
+	// rep: N/A
+	// alpha: v1.3
+	//
+	// Enables managing the head Pod of a RayCluster with a StatefulSet with `headGroupSpec.statefulSet`
+	RayHeadStatefulSet featuregate.Feature = "RayHeadStatefulSet"
+
+	// owner: @liuxsh9
+	// rep: N/A
+	// alpha: v1.3
+	//
+	// Enables the finalizer of RayServices and RayJobs that cleans up the objects created for them before they are deleted
+	RayCleanupFinalizer featuregate.Feature = "RayCleanupFinalizer"
+
+	// owner: @liuxsh9
+	// rep: N/A
+	// alpha: v1.3
+	//
+	// Enables creating the Pods of worker groups in other namespaces than the RayCluster with `namespace`
+	RayMultiNamespaceWorkerGroups featuregate.Feature = "RayMultiNamespaceWorkerGroups"
+
+	// owner: @liuxsh9
+	// rep: N/A
+	// alpha: v1.3
+	//
+	// Enables sizing the terminationGracePeriodSeconds of worker Pods for their object store and draining the Ray node
+	// in a preStop hook
+	RayWorkerPreStopDrain featuregate.Feature = "RayWorkerPreStopDrain"
+
+	// owner: @liuxsh9
+	// rep: N/A
+	// alpha: v1.3
+	//
+	// Enables the server-side dry-run of the RayClusters, Services and Pods before they are created, so that admission
+	// denials are reported with the AdmissionDenied condition
+	RayDryRunChildObjects featuregate.Feature = "RayDryRunChildObjects"
+
+	// owner: @liuxsh9
+	// rep: N/A
+	// alpha: v1.3
+	//
+	// Enables re-emitting the scaling decisions and failures of the Ray autoscaler as events of the RayCluster
+	RayClusterAutoscalerEvents featuregate.Feature = "RayClusterAutoscalerEvents"
+
+	// owner: @liuxsh9
+	// rep: N/A
+	// alpha: v1.3
+	//
+	// Enables the RayPlacementPolicy API that adds the node selector, tolerations and affinity of the platform admins
+	// to the Ray Pods
+	RayPlacementPolicy featuregate.Feature = "RayPlacementPolicy"
+
+	// owner: @liuxsh9
+	// rep: N/A
+	// alpha: v1.3
+	//
+	// Enables the allocation of distinct Ray ports to the RayClusters with host networking, so that their Pods don't
+	// collide on the ports of the nodes
+	RayHostNetworkPortAllocation featuregate.Feature = "RayHostNetworkPortAllocation"
+
+	// owner: @liuxsh9
+	// rep: N/A
+	// alpha: v1.3
+	//
+	// Enables accounting the CPU-hours and GPU-hours of the RayJobs and RayServices in their status
+	RayResourceUsageAccounting featuregate.Feature = "RayResourceUsageAccounting"
+
+	// owner: @liuxsh9
+	// rep: N/A
+	// alpha: v1.3
+	//
+	// Enables managing the karpenter.sh/do-not-disrupt annotation of the Ray Pods, so that Karpenter only consolidates
+	// the nodes of idle worker Pods
+	RayKarpenterConsolidation featuregate.Feature = "RayKarpenterConsolidation"
+
+	// owner: @liuxsh9
+	// rep: N/A
+	// alpha: v1.3
+	//
+	// Enables pre-provisioning a standby head Pod for RayClusters with `headGroupSpec.enableColdStandby`
+	RayHeadColdStandby featuregate.Feature = "RayHeadColdStandby"
+
+	// owner: @liuxsh9
+	// rep: N/A
+	// alpha: v1.3
+	//
+	// Enables fetching the Serve config of the RayServices from the HTTP endpoint of their `serveConfigSource`. The
+	// operator sends requests to the URLs set by the authors of the RayServices, so it should only be enabled when they
+	// are trusted or the egress of the operator is restricted
+	RayServiceServeConfigSource featuregate.Feature = "RayServiceServeConfigSource"
 )
 
 func init() {
@@ -31,8 +150,24 @@ func init() {
 }
 
 var defaultFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
-	RayClusterStatusConditions: {Default: true, PreRelease: featuregate.Beta},
-	RayJobDeletionPolicy:       {Default: false, PreRelease: featuregate.Alpha},
+	RayClusterStatusConditions:       {Default: true, PreRelease: featuregate.Beta},
+	RayJobDeletionPolicy:             {Default: false, PreRelease: featuregate.Alpha},
+	RayClusterPendingResourceDemands: {Default: false, PreRelease: featuregate.Alpha},
+	RayQuota:                         {Default: false, PreRelease: featuregate.Alpha},
+	RayWorkerGroupSuspend:            {Default: false, PreRelease: featuregate.Alpha},
+	RayNodeProvisioningConfig:        {Default: false, PreRelease: featuregate.Alpha},
+	RayHeadStatefulSet:               {Default: false, PreRelease: featuregate.Alpha},
+	RayCleanupFinalizer:              {Default: false, PreRelease: featuregate.Alpha},
+	RayMultiNamespaceWorkerGroups:    {Default: false, PreRelease: featuregate.Alpha},
+	RayWorkerPreStopDrain:            {Default: false, PreRelease: featuregate.Alpha},
+	RayDryRunChildObjects:            {Default: false, PreRelease: featuregate.Alpha},
+	RayClusterAutoscalerEvents:       {Default: false, PreRelease: featuregate.Alpha},
+	RayPlacementPolicy:               {Default: false, PreRelease: featuregate.Alpha},
+	RayHostNetworkPortAllocation:     {Default: false, PreRelease: featuregate.Alpha},
+	RayResourceUsageAccounting:       {Default: false, PreRelease: featuregate.Alpha},
+	RayKarpenterConsolidation:        {Default: false, PreRelease: featuregate.Alpha},
+	RayHeadColdStandby:               {Default: false, PreRelease: featuregate.Alpha},
+	RayServiceServeConfigSource:      {Default: false, PreRelease: featuregate.Alpha},
 }
 
 // SetFeatureGateDuringTest is a helper method to override feature gates in tests.
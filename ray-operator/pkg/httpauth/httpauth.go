@@ -0,0 +1,56 @@
+// Package httpauth authorizes the requests to the HTTP endpoints served by KubeRay with the Kubernetes bearer token of
+// the user. The token is authenticated with a TokenReview, and the user must be allowed to access the requested
+// resource, which is checked with a SubjectAccessReview.
+package httpauth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// Authorize checks that the bearer token of the request belongs to a user allowed to access the resource described by
+// attributes. It returns the HTTP status code of the denial, or 0 if the request is allowed.
+func Authorize(ctx context.Context, c client.Client, r *http.Request, attributes authorizationv1.ResourceAttributes) (int, error) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return http.StatusUnauthorized, errors.New("a bearer token is required")
+	}
+	tokenReview := &authenticationv1.TokenReview{Spec: authenticationv1.TokenReviewSpec{Token: token}}
+	if err := c.Create(ctx, tokenReview); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if !tokenReview.Status.Authenticated {
+		return http.StatusUnauthorized, errors.New("the bearer token is invalid")
+	}
+
+	user := tokenReview.Status.User
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for key, value := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+	accessReview := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:               user.Username,
+			UID:                user.UID,
+			Groups:             user.Groups,
+			Extra:              extra,
+			ResourceAttributes: &attributes,
+		},
+	}
+	if err := c.Create(ctx, accessReview); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if !accessReview.Status.Allowed {
+		return http.StatusForbidden, errors.New("the user isn't allowed to access the resource")
+	}
+	return 0, nil
+}
@@ -0,0 +1,246 @@
+// Package rayclient provides higher-level helpers on top of the generated KubeRay clientset for programmatic users:
+// waiting for RayClusters and RayJobs to reach a state, submitting RayJobs while streaming their logs, and
+// informer-backed listers.
+package rayclient
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	watchtools "k8s.io/client-go/tools/watch"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	"github.com/ray-project/kuberay/ray-operator/pkg/client/clientset/versioned"
+	"github.com/ray-project/kuberay/ray-operator/pkg/client/informers/externalversions"
+	listersv1 "github.com/ray-project/kuberay/ray-operator/pkg/client/listers/ray/v1"
+)
+
+const DefaultLogPollInterval = 2 * time.Second
+
+type Client struct {
+	rayClient versioned.Interface
+	// dashboardClientFunc creates the client used to read job logs. The dashboard URL in the RayJob status is only
+	// reachable from within the Kubernetes cluster, so users running outside of it can provide a client which
+	// rewrites the URL, e.g. to a port-forward.
+	dashboardClientFunc func() utils.RayDashboardClientInterface
+	logPollInterval     time.Duration
+}
+
+type Option func(*Client)
+
+// WithDashboardClientFunc sets the function creating the Ray dashboard client used by SubmitAndStreamLogs.
+func WithDashboardClientFunc(dashboardClientFunc func() utils.RayDashboardClientInterface) Option {
+	return func(c *Client) {
+		c.dashboardClientFunc = dashboardClientFunc
+	}
+}
+
+// WithLogPollInterval sets how often SubmitAndStreamLogs reads the job log.
+func WithLogPollInterval(interval time.Duration) Option {
+	return func(c *Client) {
+		c.logPollInterval = interval
+	}
+}
+
+func New(rayClient versioned.Interface, opts ...Option) *Client {
+	c := &Client{
+		rayClient:           rayClient,
+		dashboardClientFunc: utils.GetRayDashboardClientFunc(nil, false),
+		logPollInterval:     DefaultLogPollInterval,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func NewForConfig(cfg *rest.Config, opts ...Option) (*Client, error) {
+	rayClient, err := versioned.NewForConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return New(rayClient, opts...), nil
+}
+
+// RayClient returns the underlying generated clientset.
+func (c *Client) RayClient() versioned.Interface {
+	return c.rayClient
+}
+
+// WaitForRayCluster watches the RayCluster until condition returns true, the RayCluster is deleted or ctx is done.
+func (c *Client) WaitForRayCluster(ctx context.Context, namespace, name string, condition func(*rayv1.RayCluster) (bool, error)) (*rayv1.RayCluster, error) {
+	clusters := c.rayClient.RayV1().RayClusters(namespace)
+	lw := nameListWatch(name,
+		func(options metav1.ListOptions) (runtime.Object, error) { return clusters.List(ctx, options) },
+		func(options metav1.ListOptions) (watch.Interface, error) { return clusters.Watch(ctx, options) })
+	event, err := watchtools.UntilWithSync(ctx, lw, &rayv1.RayCluster{}, nil, func(event watch.Event) (bool, error) {
+		cluster, ok := event.Object.(*rayv1.RayCluster)
+		if !ok || cluster.Name != name {
+			return false, nil
+		}
+		if event.Type == watch.Deleted {
+			return false, fmt.Errorf("RayCluster %s/%s was deleted", namespace, name)
+		}
+		return condition(cluster)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return event.Object.(*rayv1.RayCluster), nil
+}
+
+// WaitForRayClusterReady waits until the RayCluster is ready. It fails early if the RayCluster fails.
+func (c *Client) WaitForRayClusterReady(ctx context.Context, namespace, name string) (*rayv1.RayCluster, error) {
+	return c.WaitForRayCluster(ctx, namespace, name, func(cluster *rayv1.RayCluster) (bool, error) {
+		switch cluster.Status.State { //nolint:staticcheck // https://github.com/ray-project/kuberay/pull/2288
+		case rayv1.Ready:
+			return true, nil
+		case rayv1.Failed:
+			return false, fmt.Errorf("RayCluster %s/%s failed: %s", namespace, name, cluster.Status.Reason)
+		}
+		return false, nil
+	})
+}
+
+// WaitForRayJob watches the RayJob until condition returns true, the RayJob is deleted or ctx is done.
+func (c *Client) WaitForRayJob(ctx context.Context, namespace, name string, condition func(*rayv1.RayJob) (bool, error)) (*rayv1.RayJob, error) {
+	jobs := c.rayClient.RayV1().RayJobs(namespace)
+	lw := nameListWatch(name,
+		func(options metav1.ListOptions) (runtime.Object, error) { return jobs.List(ctx, options) },
+		func(options metav1.ListOptions) (watch.Interface, error) { return jobs.Watch(ctx, options) })
+	event, err := watchtools.UntilWithSync(ctx, lw, &rayv1.RayJob{}, nil, func(event watch.Event) (bool, error) {
+		job, ok := event.Object.(*rayv1.RayJob)
+		if !ok || job.Name != name {
+			return false, nil
+		}
+		if event.Type == watch.Deleted {
+			return false, fmt.Errorf("RayJob %s/%s was deleted", namespace, name)
+		}
+		return condition(job)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return event.Object.(*rayv1.RayJob), nil
+}
+
+// WaitForRayJobCompletion waits until the RayJob reaches the Complete or Failed deployment status, and returns it.
+// The outcome of the Ray job is in the JobStatus of the returned RayJob. Use a context with a timeout to bound
+// the wait.
+func (c *Client) WaitForRayJobCompletion(ctx context.Context, namespace, name string) (*rayv1.RayJob, error) {
+	return c.WaitForRayJob(ctx, namespace, name, func(job *rayv1.RayJob) (bool, error) {
+		return IsRayJobFinished(job), nil
+	})
+}
+
+// IsRayJobFinished returns whether the RayJob reached a final deployment status and won't be retried.
+func IsRayJobFinished(job *rayv1.RayJob) bool {
+	return job.Status.JobDeploymentStatus == rayv1.JobDeploymentStatusComplete ||
+		job.Status.JobDeploymentStatus == rayv1.JobDeploymentStatusFailed
+}
+
+// SubmitAndStreamLogs creates the RayJob, writes the driver log to out as it is produced and returns the RayJob once
+// it is finished. Logs are read from the Ray dashboard, see WithDashboardClientFunc.
+func (c *Client) SubmitAndStreamLogs(ctx context.Context, rayJob *rayv1.RayJob, out io.Writer) (*rayv1.RayJob, error) {
+	created, err := c.rayClient.RayV1().RayJobs(rayJob.Namespace).Create(ctx, rayJob, metav1.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	submitted, err := c.WaitForRayJob(ctx, created.Namespace, created.Name, func(job *rayv1.RayJob) (bool, error) {
+		return (job.Status.DashboardURL != "" && job.Status.JobId != "" && job.Status.JobStatus != rayv1.JobStatusNew) || IsRayJobFinished(job), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if IsRayJobFinished(submitted) && submitted.Status.DashboardURL == "" {
+		return submitted, nil
+	}
+
+	dashboardClient := c.dashboardClientFunc()
+	if err := dashboardClient.InitClient(ctx, submitted.Status.DashboardURL, nil); err != nil {
+		return nil, err
+	}
+	if err := c.streamJobLog(ctx, dashboardClient, submitted.Status.JobId, out); err != nil {
+		return nil, err
+	}
+	return c.WaitForRayJobCompletion(ctx, created.Namespace, created.Name)
+}
+
+// streamJobLog writes the log of the Ray job to out until the job is terminal.
+func (c *Client) streamJobLog(ctx context.Context, dashboardClient utils.RayDashboardClientInterface, jobId string, out io.Writer) error {
+	written := 0
+	for {
+		// Read the status before the log, so the last read log is complete once the job is terminal.
+		jobInfo, err := dashboardClient.GetJobInfo(ctx, jobId)
+		if err != nil {
+			return err
+		}
+		jobLog, err := dashboardClient.GetJobLog(ctx, jobId)
+		if err != nil {
+			return err
+		}
+		if jobLog != nil && len(*jobLog) > written {
+			if _, err := io.WriteString(out, (*jobLog)[written:]); err != nil {
+				return err
+			}
+			written = len(*jobLog)
+		}
+		if rayv1.IsJobTerminal(jobInfo.JobStatus) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(c.logPollInterval):
+		}
+	}
+}
+
+// Listers are listers backed by shared informers.
+type Listers struct {
+	RayClusters listersv1.RayClusterLister
+	RayJobs     listersv1.RayJobLister
+	RayServices listersv1.RayServiceLister
+}
+
+// StartListers starts informers for RayClusters, RayJobs and RayServices in the namespace (all namespaces if empty),
+// waits for their caches to sync and returns listers reading from them. The informers stop when ctx is done.
+func (c *Client) StartListers(ctx context.Context, namespace string, resync time.Duration) (*Listers, error) {
+	factory := externalversions.NewSharedInformerFactoryWithOptions(c.rayClient, resync, externalversions.WithNamespace(namespace))
+	informers := factory.Ray().V1()
+	listers := &Listers{
+		RayClusters: informers.RayClusters().Lister(),
+		RayJobs:     informers.RayJobs().Lister(),
+		RayServices: informers.RayServices().Lister(),
+	}
+	factory.Start(ctx.Done())
+	for informerType, synced := range factory.WaitForCacheSync(ctx.Done()) {
+		if !synced {
+			return nil, fmt.Errorf("failed to sync informer for %v", informerType)
+		}
+	}
+	return listers, nil
+}
+
+func nameListWatch(name string, list cache.ListFunc, watchFunc cache.WatchFunc) *cache.ListWatch {
+	fieldSelector := fields.OneTermEqualSelector("metadata.name", name).String()
+	return &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			options.FieldSelector = fieldSelector
+			return list(options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			options.FieldSelector = fieldSelector
+			return watchFunc(options)
+		},
+	}
+}
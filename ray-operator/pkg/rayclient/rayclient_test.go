@@ -0,0 +1,108 @@
+package rayclient
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+	"github.com/ray-project/kuberay/ray-operator/pkg/client/clientset/versioned/fake"
+)
+
+func TestWaitForRayClusterReady(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cluster := &rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Name: "raycluster", Namespace: "default"}}
+	rayClient := fake.NewSimpleClientset(cluster)
+	client := New(rayClient)
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		ready := cluster.DeepCopy()
+		ready.Status.State = rayv1.Ready //nolint:staticcheck // https://github.com/ray-project/kuberay/pull/2288
+		_, _ = rayClient.RayV1().RayClusters("default").UpdateStatus(ctx, ready, metav1.UpdateOptions{})
+	}()
+
+	readyCluster, err := client.WaitForRayClusterReady(ctx, "default", "raycluster")
+	require.NoError(t, err)
+	assert.Equal(t, rayv1.Ready, readyCluster.Status.State) //nolint:staticcheck // https://github.com/ray-project/kuberay/pull/2288
+}
+
+func TestWaitForRayClusterReadyTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	cluster := &rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Name: "raycluster", Namespace: "default"}}
+	client := New(fake.NewSimpleClientset(cluster))
+
+	_, err := client.WaitForRayClusterReady(ctx, "default", "raycluster")
+	assert.Error(t, err)
+}
+
+func TestSubmitAndStreamLogs(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	rayClient := fake.NewSimpleClientset()
+	dashboardClient := &utils.FakeRayDashboardClient{}
+	getJobInfo := func(_ context.Context, _ string) (*utils.RayJobInfo, error) {
+		return &utils.RayJobInfo{JobStatus: rayv1.JobStatusSucceeded}, nil
+	}
+	dashboardClient.GetJobInfoMock.Store(&getJobInfo)
+	client := New(rayClient,
+		WithDashboardClientFunc(func() utils.RayDashboardClientInterface { return dashboardClient }),
+		WithLogPollInterval(10*time.Millisecond))
+
+	go func() {
+		jobs := rayClient.RayV1().RayJobs("default")
+		var job *rayv1.RayJob
+		for job == nil {
+			time.Sleep(50 * time.Millisecond)
+			job, _ = jobs.Get(ctx, "rayjob", metav1.GetOptions{})
+		}
+		job.Status.DashboardURL = "raycluster-head-svc.default.svc.cluster.local:8265"
+		job.Status.JobId = "rayjob-abcde"
+		job.Status.JobStatus = rayv1.JobStatusRunning
+		job.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusRunning
+		job, _ = jobs.UpdateStatus(ctx, job, metav1.UpdateOptions{})
+
+		time.Sleep(100 * time.Millisecond)
+		job.Status.JobStatus = rayv1.JobStatusSucceeded
+		job.Status.JobDeploymentStatus = rayv1.JobDeploymentStatusComplete
+		_, _ = jobs.UpdateStatus(ctx, job, metav1.UpdateOptions{})
+	}()
+
+	out := &bytes.Buffer{}
+	rayJob := &rayv1.RayJob{ObjectMeta: metav1.ObjectMeta{Name: "rayjob", Namespace: "default"}}
+	finished, err := client.SubmitAndStreamLogs(ctx, rayJob, out)
+	require.NoError(t, err)
+	assert.Equal(t, rayv1.JobDeploymentStatusComplete, finished.Status.JobDeploymentStatus)
+	assert.Equal(t, "log", out.String())
+}
+
+func TestStartListers(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client := New(fake.NewSimpleClientset(
+		&rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Name: "raycluster", Namespace: "default"}},
+		&rayv1.RayJob{ObjectMeta: metav1.ObjectMeta{Name: "rayjob", Namespace: "default"}},
+	))
+	listers, err := client.StartListers(ctx, "default", 0)
+	require.NoError(t, err)
+
+	cluster, err := listers.RayClusters.RayClusters("default").Get("raycluster")
+	require.NoError(t, err)
+	assert.Equal(t, "raycluster", cluster.Name)
+	jobs, err := listers.RayJobs.List(labels.Everything())
+	require.NoError(t, err)
+	assert.Len(t, jobs, 1)
+}
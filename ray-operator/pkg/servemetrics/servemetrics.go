@@ -0,0 +1,463 @@
+// Package servemetrics exposes the load of the Ray Serve replicas through the Kubernetes custom metrics API
+// (custom.metrics.k8s.io/v1beta2), so that HorizontalPodAutoscalers can scale on the Serve load instead of the CPU.
+//
+// The metrics are read from the metrics port of the Ray containers when they're requested:
+//   - ray_serve_ongoing_requests_per_replica is the average number of requests processed by the Serve replicas.
+//   - ray_serve_queued_requests is the number of requests queued in the Serve handles before they're assigned.
+//
+// They're served for Pods, at /apis/custom.metrics.k8s.io/v1beta2/namespaces/{namespace}/pods/{name}/{metric}, and
+// for RayClusters, at /apis/custom.metrics.k8s.io/v1beta2/namespaces/{namespace}/rayclusters.ray.io/{name}/{metric},
+// where they're aggregated over the worker Pods matching the metric label selector, e.g. `ray.io/group=gpu-group`.
+package servemetrics
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/cert"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+const (
+	// APIPrefix is the prefix of the paths of the custom metrics API.
+	APIPrefix  = "/apis/custom.metrics.k8s.io/v1beta2"
+	APIVersion = "custom.metrics.k8s.io/v1beta2"
+
+	OngoingRequestsPerReplicaMetric = "ray_serve_ongoing_requests_per_replica"
+	QueuedRequestsMetric            = "ray_serve_queued_requests"
+
+	// The metrics of Ray Serve that the custom metrics are computed from.
+	rayServeReplicaProcessingQueries = "ray_serve_replica_processing_queries"
+	rayServeDeploymentQueuedQueries  = "ray_serve_deployment_queued_queries"
+
+	rayClustersResource = "rayclusters.ray.io"
+	podsResource        = "pods"
+	scrapeTimeout       = 2 * time.Second
+)
+
+var (
+	metricNames = []string{OngoingRequestsPerReplicaMetric, QueuedRequestsMetric}
+
+	// The counters of the HTTP requests of the Ray Serve proxies, with and without the `_total` suffix depending on the
+	// version of Ray.
+	rayServeHTTPRequests      = []string{"ray_serve_num_http_requests", "ray_serve_num_http_requests_total"}
+	rayServeHTTPErrorRequests = []string{"ray_serve_num_http_error_requests", "ray_serve_num_http_error_requests_total"}
+)
+
+// MetricValueList is a list of values of a custom metric, as defined by the custom metrics API.
+type MetricValueList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MetricValue `json:"items"`
+}
+
+// MetricValue is the value of a custom metric for an object, as defined by the custom metrics API.
+type MetricValue struct {
+	Timestamp       metav1.Time            `json:"timestamp"`
+	WindowSeconds   *int64                 `json:"windowSeconds,omitempty"`
+	DescribedObject corev1.ObjectReference `json:"describedObject"`
+	Metric          MetricIdentifier       `json:"metric"`
+	Value           resource.Quantity      `json:"value"`
+}
+
+// MetricIdentifier identifies a custom metric by its name and its label selector.
+type MetricIdentifier struct {
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	Name     string                `json:"name"`
+}
+
+// serveLoad is the load of the Serve replicas of one or more Pods.
+type serveLoad struct {
+	processingQueries float64
+	queuedQueries     float64
+	replicas          int
+	httpRequests      float64
+	httpErrorRequests float64
+}
+
+func (l *serveLoad) add(other serveLoad) {
+	l.processingQueries += other.processingQueries
+	l.queuedQueries += other.queuedQueries
+	l.replicas += other.replicas
+	l.httpRequests += other.httpRequests
+	l.httpErrorRequests += other.httpErrorRequests
+}
+
+func (l serveLoad) value(metric string) float64 {
+	if metric == QueuedRequestsMetric {
+		return l.queuedQueries
+	}
+	if l.replicas == 0 {
+		return 0
+	}
+	return l.processingQueries / float64(l.replicas)
+}
+
+// Provider computes the custom metrics from the metrics of the Ray containers.
+type Provider struct {
+	client     client.Client
+	httpClient *http.Client
+}
+
+func NewProvider(c client.Client) *Provider {
+	return &Provider{
+		client:     c,
+		httpClient: &http.Client{Timeout: scrapeTimeout},
+	}
+}
+
+// scrapeMetricFamilies reads the metrics of a Pod from the metrics port of its Ray container.
+func (p *Provider) scrapeMetricFamilies(ctx context.Context, pod *corev1.Pod) (map[string]*dto.MetricFamily, error) {
+	metricsPort := utils.FindContainerPort(&pod.Spec.Containers[utils.RayContainerIndex], utils.MetricsPortName, utils.DefaultMetricsPort)
+	url := fmt.Sprintf("http://%s/metrics", net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(metricsPort)))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to scrape the metrics of the Pod %s/%s: status code %d", pod.Namespace, pod.Name, resp.StatusCode)
+	}
+	return (&expfmt.TextParser{}).TextToMetricFamilies(resp.Body)
+}
+
+// scrapePod reads the load of the Serve replicas of a Pod from the metrics port of its Ray container.
+func (p *Provider) scrapePod(ctx context.Context, pod *corev1.Pod) (serveLoad, error) {
+	load := serveLoad{}
+	families, err := p.scrapeMetricFamilies(ctx, pod)
+	if err != nil {
+		return load, err
+	}
+	if family, ok := families[rayServeReplicaProcessingQueries]; ok {
+		for _, metric := range family.GetMetric() {
+			load.processingQueries += metric.GetGauge().GetValue()
+			load.replicas++
+		}
+	}
+	if family, ok := families[rayServeDeploymentQueuedQueries]; ok {
+		for _, metric := range family.GetMetric() {
+			load.queuedQueries += metric.GetGauge().GetValue()
+		}
+	}
+	load.httpRequests = sumCounters(families, rayServeHTTPRequests)
+	load.httpErrorRequests = sumCounters(families, rayServeHTTPErrorRequests)
+	return load, nil
+}
+
+// sumCounters sums the samples of the first metric family found among the names.
+func sumCounters(families map[string]*dto.MetricFamily, names []string) float64 {
+	for _, name := range names {
+		family, ok := families[name]
+		if !ok {
+			continue
+		}
+		total := 0.0
+		for _, metric := range family.GetMetric() {
+			if metric.GetCounter() != nil {
+				total += metric.GetCounter().GetValue()
+			} else {
+				total += metric.GetUntyped().GetValue()
+			}
+		}
+		return total
+	}
+	return 0
+}
+
+// scrapePods reads the load of the Serve replicas of each running and ready Ray Pod. The Pods that can't be scraped
+// are skipped, so that a single unreachable Pod doesn't block the scaling.
+func (p *Provider) scrapePods(ctx context.Context, pods []corev1.Pod) map[string]serveLoad {
+	logger := ctrl.LoggerFrom(ctx)
+	loads := map[string]serveLoad{}
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Labels[utils.RayClusterLabelKey] == "" || pod.Status.PodIP == "" || !utils.IsRunningAndReady(pod) {
+			continue
+		}
+		load, err := p.scrapePod(ctx, pod)
+		if err != nil {
+			logger.Info("Failed to scrape the Serve metrics of the Pod", "pod", pod.Name, "namespace", pod.Namespace, "error", err)
+			continue
+		}
+		loads[pod.Name] = load
+	}
+	return loads
+}
+
+// GetPodMetrics returns the value of the metric for the Ray Pod, or for the Ray Pods matching the selector if name is
+// `*`.
+func (p *Provider) GetPodMetrics(ctx context.Context, namespace, name, metric string, selector labels.Selector) (*MetricValueList, error) {
+	pods := []corev1.Pod{}
+	if name == "*" {
+		podList := corev1.PodList{}
+		if err := p.client.List(ctx, &podList, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, err
+		}
+		pods = podList.Items
+	} else {
+		pod := corev1.Pod{}
+		if err := p.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &pod); err != nil {
+			return nil, err
+		}
+		pods = append(pods, pod)
+	}
+
+	now := metav1.Now()
+	list := newMetricValueList()
+	loads := p.scrapePods(ctx, pods)
+	for _, pod := range pods {
+		load, ok := loads[pod.Name]
+		if !ok {
+			continue
+		}
+		list.Items = append(list.Items, newMetricValue(corev1.ObjectReference{
+			Kind:       "Pod",
+			APIVersion: "v1",
+			Namespace:  pod.Namespace,
+			Name:       pod.Name,
+		}, metric, nil, load, now))
+	}
+	if name != "*" && len(list.Items) == 0 {
+		return nil, k8serrors.NewNotFound(corev1.Resource(podsResource), name)
+	}
+	return list, nil
+}
+
+// GetRayClusterMetrics returns the value of the metric aggregated over the worker Pods of the RayCluster matching the
+// metric selector, e.g. the Pods of a worker group.
+func (p *Provider) GetRayClusterMetrics(ctx context.Context, namespace, name, metric string, metricSelector labels.Selector) (*MetricValueList, error) {
+	cluster := rayv1.RayCluster{}
+	if err := p.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, &cluster); err != nil {
+		return nil, err
+	}
+	podSelector := labels.SelectorFromSet(labels.Set{
+		utils.RayClusterLabelKey:  cluster.Name,
+		utils.RayNodeTypeLabelKey: string(rayv1.WorkerNode),
+	})
+	if requirements, selectable := metricSelector.Requirements(); selectable {
+		podSelector = podSelector.Add(requirements...)
+	}
+	podList := corev1.PodList{}
+	if err := p.client.List(ctx, &podList, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: podSelector}); err != nil {
+		return nil, err
+	}
+
+	total := serveLoad{}
+	for _, load := range p.scrapePods(ctx, podList.Items) {
+		total.add(load)
+	}
+	var selector *metav1.LabelSelector
+	if !metricSelector.Empty() {
+		var err error
+		if selector, err = metav1.ParseToLabelSelector(metricSelector.String()); err != nil {
+			return nil, err
+		}
+	}
+	list := newMetricValueList()
+	list.Items = append(list.Items, newMetricValue(corev1.ObjectReference{
+		Kind:       "RayCluster",
+		APIVersion: rayv1.GroupVersion.String(),
+		Namespace:  cluster.Namespace,
+		Name:       cluster.Name,
+	}, metric, selector, total, metav1.Now()))
+	return list, nil
+}
+
+// GetRayClusterServeRequestCounts returns the counters of the HTTP requests handled by the Ray Serve proxies of the
+// RayCluster. The Pods that can't be scraped are skipped, so the counters may decrease.
+func (p *Provider) GetRayClusterServeRequestCounts(ctx context.Context, cluster *rayv1.RayCluster) (rayv1.ServeRequestCounts, error) {
+	podList := corev1.PodList{}
+	if err := p.client.List(ctx, &podList, client.InNamespace(cluster.Namespace), client.MatchingLabels{utils.RayClusterLabelKey: cluster.Name}); err != nil {
+		return rayv1.ServeRequestCounts{}, err
+	}
+	total := serveLoad{}
+	for _, load := range p.scrapePods(ctx, podList.Items) {
+		total.add(load)
+	}
+	return rayv1.ServeRequestCounts{Requests: int64(total.httpRequests), Errors: int64(total.httpErrorRequests)}, nil
+}
+
+// GetRayClusterMetricSum returns the sum of the samples of the metric exported by the running and ready Ray Pods of the
+// RayCluster. The Pods that can't be scraped are skipped, so that they don't count towards the sum.
+func (p *Provider) GetRayClusterMetricSum(ctx context.Context, cluster *rayv1.RayCluster, metric string) (float64, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	podList := corev1.PodList{}
+	if err := p.client.List(ctx, &podList, client.InNamespace(cluster.Namespace), client.MatchingLabels{utils.RayClusterLabelKey: cluster.Name}); err != nil {
+		return 0, err
+	}
+	total := 0.0
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.Status.PodIP == "" || !utils.IsRunningAndReady(pod) {
+			continue
+		}
+		families, err := p.scrapeMetricFamilies(ctx, pod)
+		if err != nil {
+			logger.Info("Failed to scrape the metrics of the Pod", "pod", pod.Name, "namespace", pod.Namespace, "error", err)
+			continue
+		}
+		family, ok := families[metric]
+		if !ok {
+			continue
+		}
+		for _, sample := range family.GetMetric() {
+			switch {
+			case sample.GetGauge() != nil:
+				total += sample.GetGauge().GetValue()
+			case sample.GetCounter() != nil:
+				total += sample.GetCounter().GetValue()
+			default:
+				total += sample.GetUntyped().GetValue()
+			}
+		}
+	}
+	return total, nil
+}
+
+func newMetricValueList() *MetricValueList {
+	return &MetricValueList{
+		TypeMeta: metav1.TypeMeta{Kind: "MetricValueList", APIVersion: APIVersion},
+		Items:    []MetricValue{},
+	}
+}
+
+func newMetricValue(object corev1.ObjectReference, metric string, selector *metav1.LabelSelector, load serveLoad, now metav1.Time) MetricValue {
+	return MetricValue{
+		DescribedObject: object,
+		Metric:          MetricIdentifier{Name: metric, Selector: selector},
+		Timestamp:       now,
+		Value:           *resource.NewMilliQuantity(int64(load.value(metric)*1000), resource.DecimalSI),
+	}
+}
+
+// Handler returns the HTTP handler serving the custom metrics API.
+func (p *Provider) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+APIPrefix, func(w http.ResponseWriter, _ *http.Request) {
+		resources := metav1.APIResourceList{
+			TypeMeta:     metav1.TypeMeta{Kind: "APIResourceList", APIVersion: "v1"},
+			GroupVersion: APIVersion,
+		}
+		for _, resource := range []string{podsResource, rayClustersResource} {
+			for _, metric := range metricNames {
+				resources.APIResources = append(resources.APIResources, metav1.APIResource{
+					Name:       resource + "/" + metric,
+					Namespaced: true,
+					Kind:       "MetricValueList",
+					Verbs:      metav1.Verbs{"get"},
+				})
+			}
+		}
+		writeJSON(w, resources)
+	})
+	mux.HandleFunc("GET "+APIPrefix+"/namespaces/{namespace}/{resource}/{name}/{metric}", func(w http.ResponseWriter, r *http.Request) {
+		metric := r.PathValue("metric")
+		if !slices.Contains(metricNames, metric) {
+			http.Error(w, fmt.Sprintf("the metric %s isn't served", metric), http.StatusNotFound)
+			return
+		}
+		var list *MetricValueList
+		var err error
+		switch resource := r.PathValue("resource"); resource {
+		case podsResource:
+			var selector labels.Selector
+			if selector, err = labels.Parse(r.URL.Query().Get("labelSelector")); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			list, err = p.GetPodMetrics(r.Context(), r.PathValue("namespace"), r.PathValue("name"), metric, selector)
+		case rayClustersResource:
+			var selector labels.Selector
+			if selector, err = labels.Parse(r.URL.Query().Get("metricLabelSelector")); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			list, err = p.GetRayClusterMetrics(r.Context(), r.PathValue("namespace"), r.PathValue("name"), metric, selector)
+		default:
+			http.Error(w, fmt.Sprintf("the resource %s isn't served", resource), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			status := http.StatusInternalServerError
+			if k8serrors.IsNotFound(err) {
+				status = http.StatusNotFound
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+		writeJSON(w, list)
+	})
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// Server serves the custom metrics API over TLS with a self-signed certificate, so the APIService registering it must
+// skip the TLS verification. It implements manager.Runnable so that it is started and stopped with the manager.
+type Server struct {
+	addr     string
+	provider *Provider
+}
+
+func NewServer(addr string, provider *Provider) *Server {
+	return &Server{addr: addr, provider: provider}
+}
+
+func (s *Server) Start(ctx context.Context) error {
+	certPEM, keyPEM, err := cert.GenerateSelfSignedCertKey("kuberay-operator", nil, nil)
+	if err != nil {
+		return err
+	}
+	certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return err
+	}
+	server := &http.Server{
+		Addr:              s.addr,
+		Handler:           s.provider.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		TLSConfig:         &tls.Config{Certificates: []tls.Certificate{certificate}, MinVersion: tls.VersionTLS12},
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+	if err := server.ListenAndServeTLS("", ""); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// NeedLeaderElection returns false because every replica of the operator can serve the metrics.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
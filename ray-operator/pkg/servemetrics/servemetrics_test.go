@@ -0,0 +1,175 @@
+package servemetrics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// newPod returns a ready Ray Pod whose metrics port is served by a test server returning the metrics.
+func newPod(t *testing.T, name string, nodeType rayv1.RayNodeType, group string, metrics string) *corev1.Pod {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, metrics)
+	}))
+	t.Cleanup(server.Close)
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(serverURL.Port())
+	require.NoError(t, err)
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				utils.RayClusterLabelKey:   "raycluster",
+				utils.RayNodeTypeLabelKey:  string(nodeType),
+				utils.RayNodeGroupLabelKey: group,
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:  "ray-worker",
+				Ports: []corev1.ContainerPort{{Name: utils.MetricsPortName, ContainerPort: int32(port)}}, //nolint:gosec // The port of a test server fits in an int32.
+			}},
+		},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			PodIP:      serverURL.Hostname(),
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+}
+
+func serveMetrics(processingQueries []float64, queuedQueries float64) string {
+	metrics := "# TYPE ray_serve_replica_processing_queries gauge\n"
+	for i, queries := range processingQueries {
+		metrics += fmt.Sprintf("ray_serve_replica_processing_queries{deployment=\"app\",replica=\"replica-%d\"} %v\n", i, queries)
+	}
+	metrics += "# TYPE ray_serve_deployment_queued_queries gauge\n"
+	metrics += fmt.Sprintf("ray_serve_deployment_queued_queries{deployment=\"app\"} %v\n", queuedQueries)
+	return metrics
+}
+
+func TestGetRayClusterServeRequestCounts(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	cluster := &rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Name: "raycluster", Namespace: "default"}}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(
+		cluster,
+		newPod(t, "head", rayv1.HeadNode, utils.RayNodeHeadGroupLabelValue,
+			"# TYPE ray_serve_num_http_requests_total counter\n"+
+				"ray_serve_num_http_requests_total{route=\"/a\",status_code=\"200\"} 90\n"+
+				"ray_serve_num_http_requests_total{route=\"/a\",status_code=\"500\"} 10\n"+
+				"# TYPE ray_serve_num_http_error_requests_total counter\n"+
+				"ray_serve_num_http_error_requests_total{route=\"/a\",error_code=\"500\"} 10\n"),
+		newPod(t, "worker", rayv1.WorkerNode, "group",
+			"# TYPE ray_serve_num_http_requests counter\n"+
+				"ray_serve_num_http_requests{route=\"/a\"} 50\n"),
+	).Build()
+
+	counts, err := NewProvider(fakeClient).GetRayClusterServeRequestCounts(context.Background(), cluster)
+	require.NoError(t, err)
+	assert.Equal(t, rayv1.ServeRequestCounts{Requests: 150, Errors: 10}, counts)
+}
+
+func TestGetRayClusterMetricSum(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	notReadyPod := newPod(t, "not-ready-worker", rayv1.WorkerNode, "group", "ray_model_loaded{replica=\"c\"} 1\n")
+	notReadyPod.Status.Conditions[0].Status = corev1.ConditionFalse
+	cluster := &rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Name: "raycluster", Namespace: "default"}}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(
+		cluster,
+		newPod(t, "head", rayv1.HeadNode, utils.RayNodeHeadGroupLabelValue, serveMetrics(nil, 0)),
+		newPod(t, "worker", rayv1.WorkerNode, "group",
+			"# TYPE ray_model_loaded gauge\n"+
+				"ray_model_loaded{replica=\"a\"} 1\n"+
+				"ray_model_loaded{replica=\"b\"} 1\n"),
+		notReadyPod,
+	).Build()
+	provider := NewProvider(fakeClient)
+
+	// The samples of the Pods that aren't ready don't count.
+	sum, err := provider.GetRayClusterMetricSum(context.Background(), cluster, "ray_model_loaded")
+	require.NoError(t, err)
+	assert.InDelta(t, 2.0, sum, 0)
+
+	sum, err = provider.GetRayClusterMetricSum(context.Background(), cluster, "ray_not_exported")
+	require.NoError(t, err)
+	assert.InDelta(t, 0.0, sum, 0)
+}
+
+func TestHandler(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	_ = corev1.AddToScheme(newScheme)
+
+	cluster := &rayv1.RayCluster{ObjectMeta: metav1.ObjectMeta{Name: "raycluster", Namespace: "default"}}
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(
+		cluster,
+		newPod(t, "head", rayv1.HeadNode, utils.RayNodeHeadGroupLabelValue, serveMetrics(nil, 10)),
+		newPod(t, "cpu-worker", rayv1.WorkerNode, "cpu-group", serveMetrics([]float64{1, 3}, 0)),
+		newPod(t, "gpu-worker-1", rayv1.WorkerNode, "gpu-group", serveMetrics([]float64{4}, 2)),
+		newPod(t, "gpu-worker-2", rayv1.WorkerNode, "gpu-group", serveMetrics([]float64{2, 6, 7}, 1)),
+	).Build()
+	provider := NewProvider(fakeClient)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		provider.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		return recorder
+	}
+	getValues := func(path string) map[string]string {
+		recorder := get(path)
+		require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+		var list MetricValueList
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &list))
+		values := map[string]string{}
+		for _, item := range list.Items {
+			values[item.DescribedObject.Name] = item.Value.String()
+		}
+		return values
+	}
+
+	recorder := get(APIPrefix)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var resources metav1.APIResourceList
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resources))
+	assert.Len(t, resources.APIResources, 4)
+
+	// The metrics of the Pods of a worker group, as requested by an HPA with a Pods metric.
+	assert.Equal(t, map[string]string{"gpu-worker-1": "4", "gpu-worker-2": "5"},
+		getValues(APIPrefix+"/namespaces/default/pods/*/"+OngoingRequestsPerReplicaMetric+"?labelSelector=ray.io%2Fgroup%3Dgpu-group"))
+	assert.Equal(t, map[string]string{"head": "10"},
+		getValues(APIPrefix+"/namespaces/default/pods/head/"+QueuedRequestsMetric))
+
+	// The metrics of a RayCluster are aggregated over the worker Pods matching the metric label selector.
+	assert.Equal(t, map[string]string{"raycluster": "4750m"},
+		getValues(APIPrefix+"/namespaces/default/rayclusters.ray.io/raycluster/"+OngoingRequestsPerReplicaMetric+"?metricLabelSelector=ray.io%2Fgroup%3Dgpu-group"))
+	assert.Equal(t, map[string]string{"raycluster": "3"},
+		getValues(APIPrefix+"/namespaces/default/rayclusters.ray.io/raycluster/"+QueuedRequestsMetric))
+
+	assert.Equal(t, http.StatusNotFound, get(APIPrefix+"/namespaces/default/rayclusters.ray.io/raycluster/unknown_metric").Code)
+	assert.Equal(t, http.StatusNotFound, get(APIPrefix+"/namespaces/default/rayclusters.ray.io/non-existent/"+QueuedRequestsMetric).Code)
+	assert.Equal(t, http.StatusNotFound, get(APIPrefix+"/namespaces/default/services/*/"+QueuedRequestsMetric).Code)
+}
@@ -0,0 +1,136 @@
+// Package servicehealth serves the health of RayServices over HTTP, so that external load balancers, e.g. global load
+// balancers spreading the traffic over several regions, can health-check a RayService without reaching inside the
+// Kubernetes cluster.
+//
+// The health of a RayService is served as JSON at /healthz/{namespace}/{name}, with the status 200 if the RayService
+// is healthy and 503 otherwise.
+package servicehealth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+// HealthPath is the path the health of a RayService is served at.
+const HealthPath = "/healthz/{namespace}/{name}"
+
+// Health is the health of a RayService.
+type Health struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	// Healthy is whether the RayService is ready and has at least the minimum number of serve endpoints.
+	Healthy bool `json:"healthy"`
+	// Ready is whether the Ready condition of the RayService is true, and Reason is the reason of the condition.
+	Ready  bool   `json:"ready"`
+	Reason string `json:"reason,omitempty"`
+	// NumServeEndpoints is the number of Ray Pods serving the traffic of the RayService.
+	NumServeEndpoints int32 `json:"numServeEndpoints"`
+}
+
+// Provider reads the health of RayServices from Kubernetes.
+type Provider struct {
+	client client.Client
+}
+
+func NewProvider(c client.Client) *Provider {
+	return &Provider{client: c}
+}
+
+// GetHealth returns the health of the RayService. It's healthy if its Ready condition is true and at least
+// minServeEndpoints Ray Pods serve its traffic.
+func (p *Provider) GetHealth(ctx context.Context, namespace, name string, minServeEndpoints int32) (*Health, error) {
+	rayService := &rayv1.RayService{}
+	if err := p.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, rayService); err != nil {
+		return nil, err
+	}
+	health := &Health{
+		Name:              rayService.Name,
+		Namespace:         rayService.Namespace,
+		NumServeEndpoints: rayService.Status.NumServeEndpoints,
+	}
+	if condition := meta.FindStatusCondition(rayService.Status.Conditions, string(rayv1.RayServiceReady)); condition != nil {
+		health.Ready = condition.Status == metav1.ConditionTrue
+		health.Reason = condition.Reason
+	}
+	health.Healthy = health.Ready && health.NumServeEndpoints >= minServeEndpoints
+	return health, nil
+}
+
+// Handler returns the HTTP handler serving the health of the RayServices. The minServeEndpoints query parameter sets
+// the minimum number of serve endpoints of a healthy RayService, and defaults to 1.
+func (p *Provider) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+HealthPath, func(w http.ResponseWriter, r *http.Request) {
+		minServeEndpoints := int32(1)
+		if value := r.URL.Query().Get("minServeEndpoints"); value != "" {
+			parsed, err := strconv.ParseInt(value, 10, 32)
+			if err != nil || parsed < 0 {
+				http.Error(w, fmt.Sprintf("invalid minServeEndpoints %q", value), http.StatusBadRequest)
+				return
+			}
+			minServeEndpoints = int32(parsed)
+		}
+		health, err := p.GetHealth(r.Context(), r.PathValue("namespace"), r.PathValue("name"), minServeEndpoints)
+		if err != nil {
+			status := http.StatusInternalServerError
+			if k8serrors.IsNotFound(err) {
+				status = http.StatusNotFound
+			}
+			http.Error(w, err.Error(), status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if !health.Healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		_ = json.NewEncoder(w).Encode(health)
+	})
+	return mux
+}
+
+// Server serves the health of the RayServices. It implements manager.Runnable so that it is started and stopped with
+// the manager.
+type Server struct {
+	addr     string
+	provider *Provider
+}
+
+func NewServer(addr string, provider *Provider) *Server {
+	return &Server{addr: addr, provider: provider}
+}
+
+func (s *Server) Start(ctx context.Context) error {
+	server := &http.Server{
+		Addr:              s.addr,
+		Handler:           s.provider.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// NeedLeaderElection returns false because every replica of the operator can serve the health from its cache.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
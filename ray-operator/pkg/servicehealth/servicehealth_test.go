@@ -0,0 +1,76 @@
+package servicehealth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientFake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+)
+
+func newRayService(name string, ready bool, numServeEndpoints int32) *rayv1.RayService {
+	status := metav1.ConditionFalse
+	reason := rayv1.ServeApplicationsNotReady
+	if ready {
+		status = metav1.ConditionTrue
+		reason = rayv1.ServeApplicationsRunning
+	}
+	return &rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Status: rayv1.RayServiceStatuses{
+			NumServeEndpoints: numServeEndpoints,
+			Conditions:        []metav1.Condition{{Type: string(rayv1.RayServiceReady), Status: status, Reason: reason}},
+		},
+	}
+}
+
+func TestHandler(t *testing.T) {
+	newScheme := runtime.NewScheme()
+	_ = rayv1.AddToScheme(newScheme)
+	fakeClient := clientFake.NewClientBuilder().WithScheme(newScheme).WithRuntimeObjects(
+		newRayService("ready", true, 2),
+		newRayService("initializing", false, 0),
+	).Build()
+	provider := NewProvider(fakeClient)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		provider.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		return recorder
+	}
+
+	recorder := get("/healthz/default/ready")
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+	var health Health
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &health))
+	assert.Equal(t, Health{
+		Name:              "ready",
+		Namespace:         "default",
+		Healthy:           true,
+		Ready:             true,
+		Reason:            rayv1.ServeApplicationsRunning,
+		NumServeEndpoints: 2,
+	}, health)
+
+	// A ready RayService with fewer serve endpoints than the minimum is unhealthy.
+	recorder = get("/healthz/default/ready?minServeEndpoints=3")
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	recorder = get("/healthz/default/ready?minServeEndpoints=-1")
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = get("/healthz/default/initializing")
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &health))
+	assert.False(t, health.Ready)
+	assert.Equal(t, rayv1.ServeApplicationsNotReady, health.Reason)
+
+	recorder = get("/healthz/default/non-existent")
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+}
@@ -0,0 +1,21 @@
+package loadtest
+
+import (
+	"k8s.io/apimachinery/pkg/types"
+
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils/fake"
+)
+
+// ServeApplicationName is the name of the Serve application of the RayServices created by the load test.
+const ServeApplicationName = "app"
+
+// newClientProvider returns dashboard and HTTP proxy clients that report healthy Serve applications without sending
+// any requests, so that the load test only measures the controllers and the API server.
+func newClientProvider() *fake.ClientProvider {
+	return &fake.ClientProvider{
+		NewRayDashboardClient: func(_ types.NamespacedName) *fake.RayDashboardClient {
+			return fake.NewRayDashboardClient(fake.ServeApplications(rayv1.ApplicationStatusEnum.RUNNING, ServeApplicationName))
+		},
+	}
+}
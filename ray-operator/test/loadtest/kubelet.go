@@ -0,0 +1,70 @@
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray/utils"
+)
+
+// fakeKubelet marks the Ray Pods of the load test as running and ready, because there is no kubelet in envtest, and
+// starting real Ray containers would measure the nodes rather than the controllers.
+type fakeKubelet struct {
+	client    client.Client
+	namespace string
+	nextIP    atomic.Uint32
+}
+
+func (k *fakeKubelet) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = k.markPodsReady(ctx)
+		}
+	}
+}
+
+func (k *fakeKubelet) markPodsReady(ctx context.Context) error {
+	pods := corev1.PodList{}
+	if err := k.client.List(ctx, &pods, client.InNamespace(k.namespace), client.HasLabels{utils.RayClusterLabelKey}); err != nil {
+		return err
+	}
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Status.Phase == corev1.PodRunning || !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+		now := metav1.Now()
+		ip := k.nextIP.Add(1)
+		pod.Status.Phase = corev1.PodRunning
+		pod.Status.PodIP = fmt.Sprintf("10.%d.%d.%d", (ip>>16)&0xff, (ip>>8)&0xff, ip&0xff)
+		pod.Status.StartTime = &now
+		pod.Status.Conditions = []corev1.PodCondition{
+			{Type: corev1.PodScheduled, Status: corev1.ConditionTrue, LastTransitionTime: now},
+			{Type: corev1.PodReady, Status: corev1.ConditionTrue, LastTransitionTime: now},
+		}
+		pod.Status.ContainerStatuses = nil
+		for _, container := range pod.Spec.Containers {
+			pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, corev1.ContainerStatus{
+				Name:  container.Name,
+				Image: container.Image,
+				Ready: true,
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: now}},
+			})
+		}
+		if err := k.client.Status().Update(ctx, pod); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
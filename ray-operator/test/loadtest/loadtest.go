@@ -0,0 +1,347 @@
+// Package loadtest creates many RayClusters and RayServices against controllers running in the same process, with
+// fake Ray dashboard backends, and measures how the controllers perform, so that performance regressions can be
+// caught before a release.
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+
+	configapi "github.com/ray-project/kuberay/ray-operator/apis/config/v1alpha1"
+	rayv1 "github.com/ray-project/kuberay/ray-operator/apis/ray/v1"
+	"github.com/ray-project/kuberay/ray-operator/controllers/ray"
+)
+
+// Options configures a load test run.
+type Options struct {
+	// Namespace is where the custom resources are created. It's created if it doesn't exist.
+	Namespace string
+	// RayClusters and RayServices are the numbers of custom resources to create.
+	RayClusters int
+	RayServices int
+	// WorkersPerCluster is the number of worker Pods of each RayCluster.
+	WorkersPerCluster int32
+	// Timeout is how long to wait for the custom resources to become ready.
+	Timeout time.Duration
+	// SteadyStateDuration is how long to keep observing the controllers after the custom resources are ready, to
+	// measure the API requests of the periodic reconciliations.
+	SteadyStateDuration time.Duration
+	// ReconcileConcurrency is the number of concurrent reconciliations of each controller.
+	ReconcileConcurrency int
+	// FakeKubelet marks the Ray Pods as running and ready. It's needed by clusters without kubelets, such as envtest.
+	FakeKubelet bool
+	// Cleanup deletes the custom resources at the end of the run.
+	Cleanup bool
+}
+
+// Report is the result of a load test run.
+type Report struct {
+	RayClusters int `json:"rayClusters"`
+	RayServices int `json:"rayServices"`
+	// TimeToReady is how long the custom resources of each kind took to become ready after they were created.
+	TimeToReady map[string]LatencyStats `json:"timeToReady"`
+	// ReconcileTime is the reconcile time of each controller over the whole run.
+	ReconcileTime map[string]ReconcileStats `json:"reconcileTime"`
+	// APIRequests is the number of requests sent by the controllers to the API server during the whole run, by
+	// HTTP method.
+	APIRequests map[string]int64 `json:"apiRequests"`
+	// RampUpAPIQPS is the rate of the requests of the controllers until the custom resources are ready.
+	RampUpAPIQPS float64 `json:"rampUpAPIQPS"`
+	// SteadyStateAPIQPS is the rate of the requests of the controllers once the custom resources are ready.
+	SteadyStateAPIQPS float64 `json:"steadyStateAPIQPS"`
+	// PeakHeapBytes is the peak heap of the process, which includes the load test itself.
+	PeakHeapBytes uint64 `json:"peakHeapBytes"`
+}
+
+// Run starts the KubeRay controllers with the config, creates the custom resources, and measures the controllers
+// until they are ready and for the steady state duration afterwards.
+func Run(ctx context.Context, config *rest.Config, options Options) (*Report, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	if err := rayv1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	loadClient, err := client.New(config, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the requests of the controllers are counted, not the ones of the load test.
+	counter := &requestCounter{}
+	managerConfig := rest.CopyConfig(config)
+	managerConfig.Wrap(counter.wrap)
+	mgr, err := ctrl.NewManager(managerConfig, ctrl.Options{
+		Scheme:  scheme,
+		Metrics: metricsserver.Options{BindAddress: "0"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := ray.NewReconciler(ctx, mgr, ray.RayClusterReconcilerOptions{}, configapi.Configuration{}).SetupWithManager(mgr, options.ReconcileConcurrency); err != nil {
+		return nil, err
+	}
+	if err := ray.NewRayServiceReconciler(ctx, mgr, newClientProvider()).SetupWithManager(mgr, options.ReconcileConcurrency); err != nil {
+		return nil, err
+	}
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	managerErr := make(chan error, 1)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		managerErr <- mgr.Start(ctx)
+	}()
+
+	sampler := &heapSampler{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			sampler.sample()
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	if options.FakeKubelet {
+		kubelet := &fakeKubelet{client: loadClient, namespace: options.Namespace}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			kubelet.run(ctx, time.Second)
+		}()
+	}
+
+	if err := ensureNamespace(ctx, loadClient, options.Namespace); err != nil {
+		return nil, err
+	}
+	if options.Cleanup {
+		defer func() {
+			logger.Info("Deleting the custom resources of the load test", "namespace", options.Namespace)
+			cleanupCtx := context.WithoutCancel(ctx)
+			_ = loadClient.DeleteAllOf(cleanupCtx, &rayv1.RayService{}, client.InNamespace(options.Namespace))
+			_ = loadClient.DeleteAllOf(cleanupCtx, &rayv1.RayCluster{}, client.InNamespace(options.Namespace))
+		}()
+	}
+
+	start := time.Now()
+	createdAt := map[client.ObjectKey]time.Time{}
+	for i := 0; i < options.RayClusters; i++ {
+		cluster := newRayCluster(options.Namespace, fmt.Sprintf("loadtest-cluster-%d", i), options.WorkersPerCluster)
+		if err := loadClient.Create(ctx, cluster); err != nil {
+			return nil, fmt.Errorf("failed to create RayCluster %s: %w", cluster.Name, err)
+		}
+		createdAt[client.ObjectKeyFromObject(cluster)] = time.Now()
+	}
+	for i := 0; i < options.RayServices; i++ {
+		service := newRayService(options.Namespace, fmt.Sprintf("loadtest-service-%d", i), options.WorkersPerCluster)
+		if err := loadClient.Create(ctx, service); err != nil {
+			return nil, fmt.Errorf("failed to create RayService %s: %w", service.Name, err)
+		}
+		createdAt[client.ObjectKeyFromObject(service)] = time.Now()
+	}
+	logger.Info("Created the custom resources", "rayClusters", options.RayClusters, "rayServices", options.RayServices)
+
+	clusterLatencies, serviceLatencies, err := waitForReady(ctx, loadClient, options, createdAt, managerErr)
+	if err != nil {
+		return nil, err
+	}
+	rampUpDuration := time.Since(start)
+	rampUpRequests := counter.snapshot()
+
+	logger.Info("Observing the steady state", "duration", options.SteadyStateDuration)
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case err := <-managerErr:
+		return nil, fmt.Errorf("the manager stopped: %w", err)
+	case <-time.After(options.SteadyStateDuration):
+	}
+	requests := counter.snapshot()
+	sampler.sample()
+
+	reconcileTime, err := gatherReconcileStats(ctrlmetrics.Registry)
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{
+		RayClusters: options.RayClusters,
+		RayServices: options.RayServices,
+		TimeToReady: map[string]LatencyStats{
+			"RayCluster": summarizeLatencies(clusterLatencies, options.RayClusters-len(clusterLatencies)),
+			"RayService": summarizeLatencies(serviceLatencies, options.RayServices-len(serviceLatencies)),
+		},
+		ReconcileTime: reconcileTime,
+		APIRequests:   requests,
+		RampUpAPIQPS:  float64(total(rampUpRequests)) / rampUpDuration.Seconds(),
+		PeakHeapBytes: sampler.peak.Load(),
+	}
+	if options.SteadyStateDuration > 0 {
+		report.SteadyStateAPIQPS = float64(total(diff(requests, rampUpRequests))) / options.SteadyStateDuration.Seconds()
+	}
+	return report, nil
+}
+
+// waitForReady polls the custom resources until all of them are ready or the timeout expires, and returns how long
+// the ready ones took.
+func waitForReady(ctx context.Context, c client.Client, options Options, createdAt map[client.ObjectKey]time.Time, managerErr <-chan error) (clusterLatencies, serviceLatencies []time.Duration, err error) {
+	logger := ctrl.LoggerFrom(ctx)
+	ready := map[client.ObjectKey]bool{}
+	deadline := time.After(options.Timeout)
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for len(clusterLatencies)+len(serviceLatencies) < options.RayClusters+options.RayServices {
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case err := <-managerErr:
+			return nil, nil, fmt.Errorf("the manager stopped: %w", err)
+		case <-deadline:
+			logger.Info("Timed out waiting for the custom resources to become ready",
+				"readyRayClusters", len(clusterLatencies), "readyRayServices", len(serviceLatencies))
+			return clusterLatencies, serviceLatencies, nil
+		case <-ticker.C:
+		}
+
+		now := time.Now()
+		clusters := rayv1.RayClusterList{}
+		if err := c.List(ctx, &clusters, client.InNamespace(options.Namespace)); err != nil {
+			return nil, nil, err
+		}
+		for _, cluster := range clusters.Items {
+			key := client.ObjectKeyFromObject(&cluster)
+			if created, ok := createdAt[key]; ok && !ready[key] && cluster.Status.State == rayv1.Ready { //nolint:staticcheck // State is deprecated but still set
+				ready[key] = true
+				clusterLatencies = append(clusterLatencies, now.Sub(created))
+			}
+		}
+		services := rayv1.RayServiceList{}
+		if err := c.List(ctx, &services, client.InNamespace(options.Namespace)); err != nil {
+			return nil, nil, err
+		}
+		for _, service := range services.Items {
+			key := client.ObjectKeyFromObject(&service)
+			if created, ok := createdAt[key]; ok && !ready[key] && service.Status.ServiceStatus == rayv1.Running {
+				ready[key] = true
+				serviceLatencies = append(serviceLatencies, now.Sub(created))
+			}
+		}
+	}
+	return clusterLatencies, serviceLatencies, nil
+}
+
+func ensureNamespace(ctx context.Context, c client.Client, namespace string) error {
+	err := c.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespace}})
+	if k8serrors.IsAlreadyExists(err) {
+		return nil
+	}
+	return err
+}
+
+func newRayClusterSpec(workers int32) rayv1.RayClusterSpec {
+	container := corev1.Container{
+		Name:  "ray",
+		Image: "rayproject/ray:2.9.0",
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("2Gi"),
+			},
+		},
+	}
+	return rayv1.RayClusterSpec{
+		HeadGroupSpec: rayv1.HeadGroupSpec{
+			RayStartParams: map[string]string{},
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{container}},
+			},
+		},
+		WorkerGroupSpecs: []rayv1.WorkerGroupSpec{
+			{
+				GroupName:      "workers",
+				Replicas:       &workers,
+				MinReplicas:    &workers,
+				MaxReplicas:    &workers,
+				RayStartParams: map[string]string{},
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{container}},
+				},
+			},
+		},
+	}
+}
+
+func newRayCluster(namespace, name string, workers int32) *rayv1.RayCluster {
+	return &rayv1.RayCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       newRayClusterSpec(workers),
+	}
+}
+
+func newRayService(namespace, name string, workers int32) *rayv1.RayService {
+	return &rayv1.RayService{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: rayv1.RayServiceSpec{
+			ServeConfigV2: fmt.Sprintf(`applications:
+  - name: %s
+    import_path: fruit.deployment_graph
+    route_prefix: /
+`, ServeApplicationName),
+			RayClusterSpec: newRayClusterSpec(workers),
+		},
+	}
+}
+
+// Thresholds are the limits that a load test run must stay within. A zero value disables the check.
+type Thresholds struct {
+	MaxTimeToReadyP99     time.Duration
+	MaxSteadyStateAPIQPS  float64
+	MaxPeakHeapBytes      uint64
+	AllowNotReadyResource bool
+}
+
+// Violations returns a description of each threshold that the report exceeds.
+func (r *Report) Violations(thresholds Thresholds) []string {
+	var violations []string
+	for _, kind := range []string{"RayCluster", "RayService"} {
+		stats := r.TimeToReady[kind]
+		if stats.NotReady > 0 && !thresholds.AllowNotReadyResource {
+			violations = append(violations, fmt.Sprintf("%d %s(s) did not become ready", stats.NotReady, kind))
+		}
+		if thresholds.MaxTimeToReadyP99 > 0 && stats.P99Seconds > thresholds.MaxTimeToReadyP99.Seconds() {
+			violations = append(violations, fmt.Sprintf("the p99 time to ready of %s is %.1fs, more than %s", kind, stats.P99Seconds, thresholds.MaxTimeToReadyP99))
+		}
+	}
+	if thresholds.MaxSteadyStateAPIQPS > 0 && r.SteadyStateAPIQPS > thresholds.MaxSteadyStateAPIQPS {
+		violations = append(violations, fmt.Sprintf("the steady state API QPS is %.2f, more than %.2f", r.SteadyStateAPIQPS, thresholds.MaxSteadyStateAPIQPS))
+	}
+	if thresholds.MaxPeakHeapBytes > 0 && r.PeakHeapBytes > thresholds.MaxPeakHeapBytes {
+		violations = append(violations, fmt.Sprintf("the peak heap is %d bytes, more than %d", r.PeakHeapBytes, thresholds.MaxPeakHeapBytes))
+	}
+	return violations
+}
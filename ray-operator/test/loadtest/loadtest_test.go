@@ -0,0 +1,104 @@
+package loadtest
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+)
+
+func TestHistogramQuantile(t *testing.T) {
+	histogram := &dto.Histogram{
+		SampleCount: ptr.To[uint64](100),
+		Bucket: []*dto.Bucket{
+			{UpperBound: ptr.To(0.1), CumulativeCount: ptr.To[uint64](50)},
+			{UpperBound: ptr.To(1.0), CumulativeCount: ptr.To[uint64](90)},
+			{UpperBound: ptr.To(10.0), CumulativeCount: ptr.To[uint64](100)},
+		},
+	}
+	assert.InDelta(t, 0.1, histogramQuantile(0.5, histogram), 1e-9)
+	assert.InDelta(t, 0.55, histogramQuantile(0.7, histogram), 1e-9)
+	assert.InDelta(t, 9.1, histogramQuantile(0.99, histogram), 1e-9)
+	assert.Zero(t, histogramQuantile(0.99, &dto.Histogram{}))
+}
+
+func TestGatherReconcileStats(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	reconcileTime := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "controller_runtime_reconcile_time_seconds",
+		Buckets: []float64{0.1, 1},
+	}, []string{"controller"})
+	registry.MustRegister(reconcileTime)
+	reconcileTime.WithLabelValues("raycluster-controller").Observe(0.05)
+	reconcileTime.WithLabelValues("raycluster-controller").Observe(0.15)
+	// Controllers without reconciliations are left out.
+	reconcileTime.WithLabelValues("rayjob-controller")
+
+	stats, err := gatherReconcileStats(registry)
+	require.NoError(t, err)
+	require.Len(t, stats, 1)
+	assert.Equal(t, uint64(2), stats["raycluster-controller"].Count)
+	assert.InDelta(t, 0.1, stats["raycluster-controller"].AverageSeconds, 1e-9)
+}
+
+func TestSummarizeLatencies(t *testing.T) {
+	var latencies []time.Duration
+	for i := 100; i >= 1; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Second)
+	}
+	stats := summarizeLatencies(latencies, 3)
+	assert.Equal(t, LatencyStats{Ready: 100, NotReady: 3, P50Seconds: 50, P90Seconds: 90, P99Seconds: 99, MaxSeconds: 100}, stats)
+	assert.Equal(t, LatencyStats{NotReady: 2}, summarizeLatencies(nil, 2))
+}
+
+func TestRequestCounter(t *testing.T) {
+	counter := &requestCounter{}
+	rt := counter.wrap(roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK}, nil
+	}))
+	for _, method := range []string{http.MethodGet, http.MethodGet, http.MethodPut} {
+		req, err := http.NewRequest(method, "https://localhost", nil)
+		require.NoError(t, err)
+		_, err = rt.RoundTrip(req) //nolint:bodyclose // the response has no body
+		require.NoError(t, err)
+	}
+	before := counter.snapshot()
+	assert.Equal(t, map[string]int64{http.MethodGet: 2, http.MethodPut: 1}, before)
+	assert.Equal(t, int64(3), total(before))
+
+	req, err := http.NewRequest(http.MethodPatch, "https://localhost", nil)
+	require.NoError(t, err)
+	_, err = rt.RoundTrip(req) //nolint:bodyclose // the response has no body
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{http.MethodPatch: 1}, diff(counter.snapshot(), before))
+}
+
+func TestReportViolations(t *testing.T) {
+	report := &Report{
+		TimeToReady: map[string]LatencyStats{
+			"RayCluster": {Ready: 10, P99Seconds: 30},
+			"RayService": {Ready: 9, NotReady: 1, P99Seconds: 90},
+		},
+		SteadyStateAPIQPS: 5,
+		PeakHeapBytes:     200 << 20,
+	}
+	assert.Empty(t, report.Violations(Thresholds{AllowNotReadyResource: true}))
+	assert.Len(t, report.Violations(Thresholds{}), 1)
+	assert.Len(t, report.Violations(Thresholds{
+		MaxTimeToReadyP99:     time.Minute,
+		MaxSteadyStateAPIQPS:  10,
+		MaxPeakHeapBytes:      100 << 20,
+		AllowNotReadyResource: true,
+	}), 2)
+	assert.Empty(t, report.Violations(Thresholds{
+		MaxTimeToReadyP99:     2 * time.Minute,
+		MaxSteadyStateAPIQPS:  10,
+		MaxPeakHeapBytes:      300 << 20,
+		AllowNotReadyResource: true,
+	}))
+}
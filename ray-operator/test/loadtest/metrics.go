@@ -0,0 +1,168 @@
+package loadtest
+
+import (
+	"math"
+	"net/http"
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// requestCounter counts the requests that the controllers send to the API server by HTTP method.
+type requestCounter struct {
+	counts sync.Map // map[string]*atomic.Int64
+}
+
+func (c *requestCounter) wrap(rt http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		count, _ := c.counts.LoadOrStore(req.Method, &atomic.Int64{})
+		count.(*atomic.Int64).Add(1)
+		return rt.RoundTrip(req)
+	})
+}
+
+func (c *requestCounter) snapshot() map[string]int64 {
+	snapshot := map[string]int64{}
+	c.counts.Range(func(method, count any) bool {
+		snapshot[method.(string)] = count.(*atomic.Int64).Load()
+		return true
+	})
+	return snapshot
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func total(counts map[string]int64) int64 {
+	var sum int64
+	for _, count := range counts {
+		sum += count
+	}
+	return sum
+}
+
+func diff(after, before map[string]int64) map[string]int64 {
+	result := map[string]int64{}
+	for method, count := range after {
+		if d := count - before[method]; d > 0 {
+			result[method] = d
+		}
+	}
+	return result
+}
+
+// heapSampler records the peak heap of the process, which runs the controllers and the load test itself.
+type heapSampler struct {
+	peak atomic.Uint64
+}
+
+func (s *heapSampler) sample() {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	for {
+		peak := s.peak.Load()
+		if stats.HeapAlloc <= peak || s.peak.CompareAndSwap(peak, stats.HeapAlloc) {
+			return
+		}
+	}
+}
+
+// ReconcileStats summarizes the `controller_runtime_reconcile_time_seconds` histogram of a controller.
+type ReconcileStats struct {
+	Count          uint64  `json:"count"`
+	AverageSeconds float64 `json:"averageSeconds"`
+	P99Seconds     float64 `json:"p99Seconds"`
+}
+
+// gatherReconcileStats reads the reconcile time histograms of the controllers from the registry.
+func gatherReconcileStats(gatherer prometheus.Gatherer) (map[string]ReconcileStats, error) {
+	families, err := gatherer.Gather()
+	if err != nil {
+		return nil, err
+	}
+	stats := map[string]ReconcileStats{}
+	for _, family := range families {
+		if family.GetName() != "controller_runtime_reconcile_time_seconds" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			histogram := metric.GetHistogram()
+			if histogram.GetSampleCount() == 0 {
+				continue
+			}
+			stats[labelValue(metric, "controller")] = ReconcileStats{
+				Count:          histogram.GetSampleCount(),
+				AverageSeconds: histogram.GetSampleSum() / float64(histogram.GetSampleCount()),
+				P99Seconds:     histogramQuantile(0.99, histogram),
+			}
+		}
+	}
+	return stats, nil
+}
+
+func labelValue(metric *dto.Metric, name string) string {
+	for _, label := range metric.GetLabel() {
+		if label.GetName() == name {
+			return label.GetValue()
+		}
+	}
+	return ""
+}
+
+// histogramQuantile estimates the quantile of a histogram by linear interpolation within its buckets, like the
+// histogram_quantile function of Prometheus.
+func histogramQuantile(q float64, histogram *dto.Histogram) float64 {
+	count := float64(histogram.GetSampleCount())
+	if count == 0 {
+		return 0
+	}
+	rank := q * count
+	lowerBound, lowerCount := 0.0, 0.0
+	for _, bucket := range histogram.GetBucket() {
+		upperBound, upperCount := bucket.GetUpperBound(), float64(bucket.GetCumulativeCount())
+		if upperCount >= rank {
+			if math.IsInf(upperBound, 1) || upperCount == lowerCount {
+				return lowerBound
+			}
+			return lowerBound + (upperBound-lowerBound)*(rank-lowerCount)/(upperCount-lowerCount)
+		}
+		lowerBound, lowerCount = upperBound, upperCount
+	}
+	return lowerBound
+}
+
+// LatencyStats summarizes the durations that the custom resources of a kind took to become ready.
+type LatencyStats struct {
+	Ready      int     `json:"ready"`
+	NotReady   int     `json:"notReady"`
+	P50Seconds float64 `json:"p50Seconds"`
+	P90Seconds float64 `json:"p90Seconds"`
+	P99Seconds float64 `json:"p99Seconds"`
+	MaxSeconds float64 `json:"maxSeconds"`
+}
+
+func summarizeLatencies(latencies []time.Duration, notReady int) LatencyStats {
+	stats := LatencyStats{Ready: len(latencies), NotReady: notReady}
+	if len(latencies) == 0 {
+		return stats
+	}
+	sorted := append([]time.Duration(nil), latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	percentile := func(p float64) float64 {
+		index := int(math.Ceil(p*float64(len(sorted)))) - 1
+		return sorted[max(index, 0)].Seconds()
+	}
+	stats.P50Seconds = percentile(0.5)
+	stats.P90Seconds = percentile(0.9)
+	stats.P99Seconds = percentile(0.99)
+	stats.MaxSeconds = sorted[len(sorted)-1].Seconds()
+	return stats
+}